@@ -0,0 +1,1086 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: namelens/v1/namelens.proto
+
+// NameLens gRPC API: name availability checks, AI-assisted review, and name
+// generation for services that embed namelens instead of shelling out to the
+// CLI. Served by `namelens serve --grpc-port <port>`.
+
+package namelensv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Availability mirrors the CLI availability states.
+type Availability int32
+
+const (
+	Availability_AVAILABILITY_UNKNOWN      Availability = 0
+	Availability_AVAILABILITY_AVAILABLE    Availability = 1
+	Availability_AVAILABILITY_TAKEN        Availability = 2
+	Availability_AVAILABILITY_ERROR        Availability = 3
+	Availability_AVAILABILITY_RATE_LIMITED Availability = 4
+	Availability_AVAILABILITY_UNSUPPORTED  Availability = 5
+)
+
+// Enum value maps for Availability.
+var (
+	Availability_name = map[int32]string{
+		0: "AVAILABILITY_UNKNOWN",
+		1: "AVAILABILITY_AVAILABLE",
+		2: "AVAILABILITY_TAKEN",
+		3: "AVAILABILITY_ERROR",
+		4: "AVAILABILITY_RATE_LIMITED",
+		5: "AVAILABILITY_UNSUPPORTED",
+	}
+	Availability_value = map[string]int32{
+		"AVAILABILITY_UNKNOWN":      0,
+		"AVAILABILITY_AVAILABLE":    1,
+		"AVAILABILITY_TAKEN":        2,
+		"AVAILABILITY_ERROR":        3,
+		"AVAILABILITY_RATE_LIMITED": 4,
+		"AVAILABILITY_UNSUPPORTED":  5,
+	}
+)
+
+func (x Availability) Enum() *Availability {
+	p := new(Availability)
+	*p = x
+	return p
+}
+
+func (x Availability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Availability) Descriptor() protoreflect.EnumDescriptor {
+	return file_namelens_v1_namelens_proto_enumTypes[0].Descriptor()
+}
+
+func (Availability) Type() protoreflect.EnumType {
+	return &file_namelens_v1_namelens_proto_enumTypes[0]
+}
+
+func (x Availability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Availability.Descriptor instead.
+func (Availability) EnumDescriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{0}
+}
+
+type CheckRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name to check (without TLD).
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Built-in profile name (minimal, startup, developer, ...). Explicit lists
+	// below override the profile; with neither, the minimal profile is used.
+	Profile       string   `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Tlds          []string `protobuf:"bytes,3,rep,name=tlds,proto3" json:"tlds,omitempty"`
+	Registries    []string `protobuf:"bytes,4,rep,name=registries,proto3" json:"registries,omitempty"`
+	Handles       []string `protobuf:"bytes,5,rep,name=handles,proto3" json:"handles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRequest) Reset() {
+	*x = CheckRequest{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRequest) ProtoMessage() {}
+
+func (x *CheckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRequest.ProtoReflect.Descriptor instead.
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *CheckRequest) GetTlds() []string {
+	if x != nil {
+		return x.Tlds
+	}
+	return nil
+}
+
+func (x *CheckRequest) GetRegistries() []string {
+	if x != nil {
+		return x.Registries
+	}
+	return nil
+}
+
+func (x *CheckRequest) GetHandles() []string {
+	if x != nil {
+		return x.Handles
+	}
+	return nil
+}
+
+type Provenance struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CheckId        string                 `protobuf:"bytes,1,opt,name=check_id,json=checkId,proto3" json:"check_id,omitempty"`
+	RequestedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	ResolvedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	Source         string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Server         string                 `protobuf:"bytes,5,opt,name=server,proto3" json:"server,omitempty"`
+	FromCache      bool                   `protobuf:"varint,6,opt,name=from_cache,json=fromCache,proto3" json:"from_cache,omitempty"`
+	CacheExpiresAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=cache_expires_at,json=cacheExpiresAt,proto3" json:"cache_expires_at,omitempty"`
+	ToolVersion    string                 `protobuf:"bytes,8,opt,name=tool_version,json=toolVersion,proto3" json:"tool_version,omitempty"`
+	AddressFamily  string                 `protobuf:"bytes,9,opt,name=address_family,json=addressFamily,proto3" json:"address_family,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Provenance) Reset() {
+	*x = Provenance{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Provenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provenance) ProtoMessage() {}
+
+func (x *Provenance) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provenance.ProtoReflect.Descriptor instead.
+func (*Provenance) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Provenance) GetCheckId() string {
+	if x != nil {
+		return x.CheckId
+	}
+	return ""
+}
+
+func (x *Provenance) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *Provenance) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *Provenance) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Provenance) GetServer() string {
+	if x != nil {
+		return x.Server
+	}
+	return ""
+}
+
+func (x *Provenance) GetFromCache() bool {
+	if x != nil {
+		return x.FromCache
+	}
+	return false
+}
+
+func (x *Provenance) GetCacheExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CacheExpiresAt
+	}
+	return nil
+}
+
+func (x *Provenance) GetToolVersion() string {
+	if x != nil {
+		return x.ToolVersion
+	}
+	return ""
+}
+
+func (x *Provenance) GetAddressFamily() string {
+	if x != nil {
+		return x.AddressFamily
+	}
+	return ""
+}
+
+type CheckResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CheckType     string                 `protobuf:"bytes,2,opt,name=check_type,json=checkType,proto3" json:"check_type,omitempty"`
+	Tld           string                 `protobuf:"bytes,3,opt,name=tld,proto3" json:"tld,omitempty"`
+	Available     Availability           `protobuf:"varint,4,opt,name=available,proto3,enum=namelens.v1.Availability" json:"available,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	ExtraData     *structpb.Struct       `protobuf:"bytes,7,opt,name=extra_data,json=extraData,proto3" json:"extra_data,omitempty"`
+	Provenance    *Provenance            `protobuf:"bytes,8,opt,name=provenance,proto3" json:"provenance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResult) Reset() {
+	*x = CheckResult{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResult) ProtoMessage() {}
+
+func (x *CheckResult) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResult.ProtoReflect.Descriptor instead.
+func (*CheckResult) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckResult) GetCheckType() string {
+	if x != nil {
+		return x.CheckType
+	}
+	return ""
+}
+
+func (x *CheckResult) GetTld() string {
+	if x != nil {
+		return x.Tld
+	}
+	return ""
+}
+
+func (x *CheckResult) GetAvailable() Availability {
+	if x != nil {
+		return x.Available
+	}
+	return Availability_AVAILABILITY_UNKNOWN
+}
+
+func (x *CheckResult) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CheckResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CheckResult) GetExtraData() *structpb.Struct {
+	if x != nil {
+		return x.ExtraData
+	}
+	return nil
+}
+
+func (x *CheckResult) GetProvenance() *Provenance {
+	if x != nil {
+		return x.Provenance
+	}
+	return nil
+}
+
+type CheckSummary struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Total     int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Available int32                  `protobuf:"varint,2,opt,name=available,proto3" json:"available,omitempty"`
+	Taken     int32                  `protobuf:"varint,3,opt,name=taken,proto3" json:"taken,omitempty"`
+	Unknown   int32                  `protobuf:"varint,4,opt,name=unknown,proto3" json:"unknown,omitempty"`
+	// low, medium, or high (high when .com is taken).
+	RiskLevel     string `protobuf:"bytes,5,opt,name=risk_level,json=riskLevel,proto3" json:"risk_level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckSummary) Reset() {
+	*x = CheckSummary{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckSummary) ProtoMessage() {}
+
+func (x *CheckSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckSummary.ProtoReflect.Descriptor instead.
+func (*CheckSummary) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckSummary) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CheckSummary) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *CheckSummary) GetTaken() int32 {
+	if x != nil {
+		return x.Taken
+	}
+	return 0
+}
+
+func (x *CheckSummary) GetUnknown() int32 {
+	if x != nil {
+		return x.Unknown
+	}
+	return 0
+}
+
+func (x *CheckSummary) GetRiskLevel() string {
+	if x != nil {
+		return x.RiskLevel
+	}
+	return ""
+}
+
+type CheckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Results       []*CheckResult         `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Summary       *CheckSummary          `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckResponse) Reset() {
+	*x = CheckResponse{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckResponse) ProtoMessage() {}
+
+func (x *CheckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckResponse.ProtoReflect.Descriptor instead.
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CheckResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CheckResponse) GetResults() []*CheckResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *CheckResponse) GetSummary() *CheckSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+type ReviewRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Profile    string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	Tlds       []string               `protobuf:"bytes,3,rep,name=tlds,proto3" json:"tlds,omitempty"`
+	Registries []string               `protobuf:"bytes,4,rep,name=registries,proto3" json:"registries,omitempty"`
+	Handles    []string               `protobuf:"bytes,5,rep,name=handles,proto3" json:"handles,omitempty"`
+	// Prompt slugs to run; empty runs name-availability, name-phonetics, and
+	// name-suitability.
+	Prompts []string `protobuf:"bytes,6,rep,name=prompts,proto3" json:"prompts,omitempty"`
+	// quick (default) or deep.
+	Depth string `protobuf:"bytes,7,opt,name=depth,proto3" json:"depth,omitempty"`
+	// Optional model override.
+	Model         string `protobuf:"bytes,8,opt,name=model,proto3" json:"model,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewRequest) Reset() {
+	*x = ReviewRequest{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewRequest) ProtoMessage() {}
+
+func (x *ReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewRequest.ProtoReflect.Descriptor instead.
+func (*ReviewRequest) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReviewRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReviewRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ReviewRequest) GetTlds() []string {
+	if x != nil {
+		return x.Tlds
+	}
+	return nil
+}
+
+func (x *ReviewRequest) GetRegistries() []string {
+	if x != nil {
+		return x.Registries
+	}
+	return nil
+}
+
+func (x *ReviewRequest) GetHandles() []string {
+	if x != nil {
+		return x.Handles
+	}
+	return nil
+}
+
+func (x *ReviewRequest) GetPrompts() []string {
+	if x != nil {
+		return x.Prompts
+	}
+	return nil
+}
+
+func (x *ReviewRequest) GetDepth() string {
+	if x != nil {
+		return x.Depth
+	}
+	return ""
+}
+
+func (x *ReviewRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+type Analysis struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prompt string                 `protobuf:"bytes,1,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	Ok     bool                   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	// Prompt response, shaped by the prompt's response schema.
+	Data          *structpb.Struct `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	ErrorCode     string           `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorMessage  string           `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Analysis) Reset() {
+	*x = Analysis{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Analysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Analysis) ProtoMessage() {}
+
+func (x *Analysis) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Analysis.ProtoReflect.Descriptor instead.
+func (*Analysis) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Analysis) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *Analysis) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *Analysis) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Analysis) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *Analysis) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type ReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Results       []*CheckResult         `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Summary       *CheckSummary          `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	Analyses      []*Analysis            `protobuf:"bytes,4,rep,name=analyses,proto3" json:"analyses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewResponse) Reset() {
+	*x = ReviewResponse{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewResponse) ProtoMessage() {}
+
+func (x *ReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewResponse.ProtoReflect.Descriptor instead.
+func (*ReviewResponse) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReviewResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ReviewResponse) GetResults() []*CheckResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ReviewResponse) GetSummary() *CheckSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *ReviewResponse) GetAnalyses() []*Analysis {
+	if x != nil {
+		return x.Analyses
+	}
+	return nil
+}
+
+type GenerateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Product concept or working name.
+	Concept     string `protobuf:"bytes,1,opt,name=concept,proto3" json:"concept,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Constraints string `protobuf:"bytes,3,opt,name=constraints,proto3" json:"constraints,omitempty"`
+	CurrentName string `protobuf:"bytes,4,opt,name=current_name,json=currentName,proto3" json:"current_name,omitempty"`
+	Tagline     string `protobuf:"bytes,5,opt,name=tagline,proto3" json:"tagline,omitempty"`
+	// quick (default) or deep.
+	Depth string `protobuf:"bytes,6,opt,name=depth,proto3" json:"depth,omitempty"`
+	Model string `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
+	// Prompt slug (default name-alternatives).
+	Prompt        string `protobuf:"bytes,8,opt,name=prompt,proto3" json:"prompt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateRequest) Reset() {
+	*x = GenerateRequest{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateRequest) ProtoMessage() {}
+
+func (x *GenerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateRequest.ProtoReflect.Descriptor instead.
+func (*GenerateRequest) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GenerateRequest) GetConcept() string {
+	if x != nil {
+		return x.Concept
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetConstraints() string {
+	if x != nil {
+		return x.Constraints
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetCurrentName() string {
+	if x != nil {
+		return x.CurrentName
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetTagline() string {
+	if x != nil {
+		return x.Tagline
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetDepth() string {
+	if x != nil {
+		return x.Depth
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GenerateRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+type GenerateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Prompt response, shaped by the prompt's response schema.
+	Result        *structpb.Struct `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateResponse) Reset() {
+	*x = GenerateResponse{}
+	mi := &file_namelens_v1_namelens_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateResponse) ProtoMessage() {}
+
+func (x *GenerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_namelens_v1_namelens_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateResponse.ProtoReflect.Descriptor instead.
+func (*GenerateResponse) Descriptor() ([]byte, []int) {
+	return file_namelens_v1_namelens_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GenerateResponse) GetResult() *structpb.Struct {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+var File_namelens_v1_namelens_proto protoreflect.FileDescriptor
+
+const file_namelens_v1_namelens_proto_rawDesc = "" +
+	"\n" +
+	"\x1anamelens/v1/namelens.proto\x12\vnamelens.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8a\x01\n" +
+	"\fCheckRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x12\n" +
+	"\x04tlds\x18\x03 \x03(\tR\x04tlds\x12\x1e\n" +
+	"\n" +
+	"registries\x18\x04 \x03(\tR\n" +
+	"registries\x12\x18\n" +
+	"\ahandles\x18\x05 \x03(\tR\ahandles\"\x82\x03\n" +
+	"\n" +
+	"Provenance\x12\x19\n" +
+	"\bcheck_id\x18\x01 \x01(\tR\acheckId\x12=\n" +
+	"\frequested_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12;\n" +
+	"\vresolved_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x16\n" +
+	"\x06server\x18\x05 \x01(\tR\x06server\x12\x1d\n" +
+	"\n" +
+	"from_cache\x18\x06 \x01(\bR\tfromCache\x12D\n" +
+	"\x10cache_expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0ecacheExpiresAt\x12!\n" +
+	"\ftool_version\x18\b \x01(\tR\vtoolVersion\x12%\n" +
+	"\x0eaddress_family\x18\t \x01(\tR\raddressFamily\"\xb7\x02\n" +
+	"\vCheckResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"check_type\x18\x02 \x01(\tR\tcheckType\x12\x10\n" +
+	"\x03tld\x18\x03 \x01(\tR\x03tld\x127\n" +
+	"\tavailable\x18\x04 \x01(\x0e2\x19.namelens.v1.AvailabilityR\tavailable\x12\x1f\n" +
+	"\vstatus_code\x18\x05 \x01(\x05R\n" +
+	"statusCode\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x126\n" +
+	"\n" +
+	"extra_data\x18\a \x01(\v2\x17.google.protobuf.StructR\textraData\x127\n" +
+	"\n" +
+	"provenance\x18\b \x01(\v2\x17.namelens.v1.ProvenanceR\n" +
+	"provenance\"\x91\x01\n" +
+	"\fCheckSummary\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\x05R\tavailable\x12\x14\n" +
+	"\x05taken\x18\x03 \x01(\x05R\x05taken\x12\x18\n" +
+	"\aunknown\x18\x04 \x01(\x05R\aunknown\x12\x1d\n" +
+	"\n" +
+	"risk_level\x18\x05 \x01(\tR\triskLevel\"\x8c\x01\n" +
+	"\rCheckResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
+	"\aresults\x18\x02 \x03(\v2\x18.namelens.v1.CheckResultR\aresults\x123\n" +
+	"\asummary\x18\x03 \x01(\v2\x19.namelens.v1.CheckSummaryR\asummary\"\xd1\x01\n" +
+	"\rReviewRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x12\n" +
+	"\x04tlds\x18\x03 \x03(\tR\x04tlds\x12\x1e\n" +
+	"\n" +
+	"registries\x18\x04 \x03(\tR\n" +
+	"registries\x12\x18\n" +
+	"\ahandles\x18\x05 \x03(\tR\ahandles\x12\x18\n" +
+	"\aprompts\x18\x06 \x03(\tR\aprompts\x12\x14\n" +
+	"\x05depth\x18\a \x01(\tR\x05depth\x12\x14\n" +
+	"\x05model\x18\b \x01(\tR\x05model\"\xa3\x01\n" +
+	"\bAnalysis\x12\x16\n" +
+	"\x06prompt\x18\x01 \x01(\tR\x06prompt\x12\x0e\n" +
+	"\x02ok\x18\x02 \x01(\bR\x02ok\x12+\n" +
+	"\x04data\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x04data\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"\xc0\x01\n" +
+	"\x0eReviewResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
+	"\aresults\x18\x02 \x03(\v2\x18.namelens.v1.CheckResultR\aresults\x123\n" +
+	"\asummary\x18\x03 \x01(\v2\x19.namelens.v1.CheckSummaryR\asummary\x121\n" +
+	"\banalyses\x18\x04 \x03(\v2\x15.namelens.v1.AnalysisR\banalyses\"\xf0\x01\n" +
+	"\x0fGenerateRequest\x12\x18\n" +
+	"\aconcept\x18\x01 \x01(\tR\aconcept\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12 \n" +
+	"\vconstraints\x18\x03 \x01(\tR\vconstraints\x12!\n" +
+	"\fcurrent_name\x18\x04 \x01(\tR\vcurrentName\x12\x18\n" +
+	"\atagline\x18\x05 \x01(\tR\atagline\x12\x14\n" +
+	"\x05depth\x18\x06 \x01(\tR\x05depth\x12\x14\n" +
+	"\x05model\x18\a \x01(\tR\x05model\x12\x16\n" +
+	"\x06prompt\x18\b \x01(\tR\x06prompt\"C\n" +
+	"\x10GenerateResponse\x12/\n" +
+	"\x06result\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06result*\xb1\x01\n" +
+	"\fAvailability\x12\x18\n" +
+	"\x14AVAILABILITY_UNKNOWN\x10\x00\x12\x1a\n" +
+	"\x16AVAILABILITY_AVAILABLE\x10\x01\x12\x16\n" +
+	"\x12AVAILABILITY_TAKEN\x10\x02\x12\x16\n" +
+	"\x12AVAILABILITY_ERROR\x10\x03\x12\x1d\n" +
+	"\x19AVAILABILITY_RATE_LIMITED\x10\x04\x12\x1c\n" +
+	"\x18AVAILABILITY_UNSUPPORTED\x10\x052\xdd\x01\n" +
+	"\x0fNamelensService\x12>\n" +
+	"\x05Check\x12\x19.namelens.v1.CheckRequest\x1a\x1a.namelens.v1.CheckResponse\x12A\n" +
+	"\x06Review\x12\x1a.namelens.v1.ReviewRequest\x1a\x1b.namelens.v1.ReviewResponse\x12G\n" +
+	"\bGenerate\x12\x1c.namelens.v1.GenerateRequest\x1a\x1d.namelens.v1.GenerateResponseB9Z7github.com/namelens/namelens/api/namelens/v1;namelensv1b\x06proto3"
+
+var (
+	file_namelens_v1_namelens_proto_rawDescOnce sync.Once
+	file_namelens_v1_namelens_proto_rawDescData []byte
+)
+
+func file_namelens_v1_namelens_proto_rawDescGZIP() []byte {
+	file_namelens_v1_namelens_proto_rawDescOnce.Do(func() {
+		file_namelens_v1_namelens_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_namelens_v1_namelens_proto_rawDesc), len(file_namelens_v1_namelens_proto_rawDesc)))
+	})
+	return file_namelens_v1_namelens_proto_rawDescData
+}
+
+var file_namelens_v1_namelens_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_namelens_v1_namelens_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_namelens_v1_namelens_proto_goTypes = []any{
+	(Availability)(0),             // 0: namelens.v1.Availability
+	(*CheckRequest)(nil),          // 1: namelens.v1.CheckRequest
+	(*Provenance)(nil),            // 2: namelens.v1.Provenance
+	(*CheckResult)(nil),           // 3: namelens.v1.CheckResult
+	(*CheckSummary)(nil),          // 4: namelens.v1.CheckSummary
+	(*CheckResponse)(nil),         // 5: namelens.v1.CheckResponse
+	(*ReviewRequest)(nil),         // 6: namelens.v1.ReviewRequest
+	(*Analysis)(nil),              // 7: namelens.v1.Analysis
+	(*ReviewResponse)(nil),        // 8: namelens.v1.ReviewResponse
+	(*GenerateRequest)(nil),       // 9: namelens.v1.GenerateRequest
+	(*GenerateResponse)(nil),      // 10: namelens.v1.GenerateResponse
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 12: google.protobuf.Struct
+}
+var file_namelens_v1_namelens_proto_depIdxs = []int32{
+	11, // 0: namelens.v1.Provenance.requested_at:type_name -> google.protobuf.Timestamp
+	11, // 1: namelens.v1.Provenance.resolved_at:type_name -> google.protobuf.Timestamp
+	11, // 2: namelens.v1.Provenance.cache_expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: namelens.v1.CheckResult.available:type_name -> namelens.v1.Availability
+	12, // 4: namelens.v1.CheckResult.extra_data:type_name -> google.protobuf.Struct
+	2,  // 5: namelens.v1.CheckResult.provenance:type_name -> namelens.v1.Provenance
+	3,  // 6: namelens.v1.CheckResponse.results:type_name -> namelens.v1.CheckResult
+	4,  // 7: namelens.v1.CheckResponse.summary:type_name -> namelens.v1.CheckSummary
+	12, // 8: namelens.v1.Analysis.data:type_name -> google.protobuf.Struct
+	3,  // 9: namelens.v1.ReviewResponse.results:type_name -> namelens.v1.CheckResult
+	4,  // 10: namelens.v1.ReviewResponse.summary:type_name -> namelens.v1.CheckSummary
+	7,  // 11: namelens.v1.ReviewResponse.analyses:type_name -> namelens.v1.Analysis
+	12, // 12: namelens.v1.GenerateResponse.result:type_name -> google.protobuf.Struct
+	1,  // 13: namelens.v1.NamelensService.Check:input_type -> namelens.v1.CheckRequest
+	6,  // 14: namelens.v1.NamelensService.Review:input_type -> namelens.v1.ReviewRequest
+	9,  // 15: namelens.v1.NamelensService.Generate:input_type -> namelens.v1.GenerateRequest
+	5,  // 16: namelens.v1.NamelensService.Check:output_type -> namelens.v1.CheckResponse
+	8,  // 17: namelens.v1.NamelensService.Review:output_type -> namelens.v1.ReviewResponse
+	10, // 18: namelens.v1.NamelensService.Generate:output_type -> namelens.v1.GenerateResponse
+	16, // [16:19] is the sub-list for method output_type
+	13, // [13:16] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_namelens_v1_namelens_proto_init() }
+func file_namelens_v1_namelens_proto_init() {
+	if File_namelens_v1_namelens_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_namelens_v1_namelens_proto_rawDesc), len(file_namelens_v1_namelens_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_namelens_v1_namelens_proto_goTypes,
+		DependencyIndexes: file_namelens_v1_namelens_proto_depIdxs,
+		EnumInfos:         file_namelens_v1_namelens_proto_enumTypes,
+		MessageInfos:      file_namelens_v1_namelens_proto_msgTypes,
+	}.Build()
+	File_namelens_v1_namelens_proto = out.File
+	file_namelens_v1_namelens_proto_goTypes = nil
+	file_namelens_v1_namelens_proto_depIdxs = nil
+}
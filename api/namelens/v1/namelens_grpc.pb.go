@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: namelens/v1/namelens.proto
+
+// NameLens gRPC API: name availability checks, AI-assisted review, and name
+// generation for services that embed namelens instead of shelling out to the
+// CLI. Served by `namelens serve --grpc-port <port>`.
+
+package namelensv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	NamelensService_Check_FullMethodName    = "/namelens.v1.NamelensService/Check"
+	NamelensService_Review_FullMethodName   = "/namelens.v1.NamelensService/Review"
+	NamelensService_Generate_FullMethodName = "/namelens.v1.NamelensService/Generate"
+)
+
+// NamelensServiceClient is the client API for NamelensService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NamelensServiceClient interface {
+	// Check runs domain, registry, and handle availability checks for a name.
+	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	// Review runs availability checks plus AI analyses (availability search,
+	// phonetics, suitability by default) for a name.
+	Review(ctx context.Context, in *ReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
+	// Generate asks the configured AI provider for name candidates.
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+}
+
+type namelensServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNamelensServiceClient(cc grpc.ClientConnInterface) NamelensServiceClient {
+	return &namelensServiceClient{cc}
+}
+
+func (c *namelensServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckResponse)
+	err := c.cc.Invoke(ctx, NamelensService_Check_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namelensServiceClient) Review(ctx context.Context, in *ReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, NamelensService_Review_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *namelensServiceClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, NamelensService_Generate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NamelensServiceServer is the server API for NamelensService service.
+// All implementations must embed UnimplementedNamelensServiceServer
+// for forward compatibility.
+type NamelensServiceServer interface {
+	// Check runs domain, registry, and handle availability checks for a name.
+	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	// Review runs availability checks plus AI analyses (availability search,
+	// phonetics, suitability by default) for a name.
+	Review(context.Context, *ReviewRequest) (*ReviewResponse, error)
+	// Generate asks the configured AI provider for name candidates.
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	mustEmbedUnimplementedNamelensServiceServer()
+}
+
+// UnimplementedNamelensServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNamelensServiceServer struct{}
+
+func (UnimplementedNamelensServiceServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
+}
+func (UnimplementedNamelensServiceServer) Review(context.Context, *ReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Review not implemented")
+}
+func (UnimplementedNamelensServiceServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedNamelensServiceServer) mustEmbedUnimplementedNamelensServiceServer() {}
+func (UnimplementedNamelensServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeNamelensServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NamelensServiceServer will
+// result in compilation errors.
+type UnsafeNamelensServiceServer interface {
+	mustEmbedUnimplementedNamelensServiceServer()
+}
+
+func RegisterNamelensServiceServer(s grpc.ServiceRegistrar, srv NamelensServiceServer) {
+	// If the following call pancis, it indicates UnimplementedNamelensServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NamelensService_ServiceDesc, srv)
+}
+
+func _NamelensService_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamelensServiceServer).Check(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NamelensService_Check_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamelensServiceServer).Check(ctx, req.(*CheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NamelensService_Review_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamelensServiceServer).Review(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NamelensService_Review_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamelensServiceServer).Review(ctx, req.(*ReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NamelensService_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NamelensServiceServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NamelensService_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NamelensServiceServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NamelensService_ServiceDesc is the grpc.ServiceDesc for NamelensService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NamelensService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "namelens.v1.NamelensService",
+	HandlerType: (*NamelensServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Check",
+			Handler:    _NamelensService_Check_Handler,
+		},
+		{
+			MethodName: "Review",
+			Handler:    _NamelensService_Review_Handler,
+		},
+		{
+			MethodName: "Generate",
+			Handler:    _NamelensService_Generate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "namelens/v1/namelens.proto",
+}
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
+
 	"github.com/fulmenhq/gofulmen/foundry"
 
 	"github.com/namelens/namelens/internal/cmd"
@@ -24,6 +28,14 @@ func main() {
 
 	// Execute root command
 	if err := cmd.Execute(); err != nil {
+		// Commands with their own exit code contract skip the FATAL banner
+		var exitErr *cmd.ExitCodeError
+		if errors.As(err, &exitErr) {
+			if exitErr.Err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", exitErr.Err)
+			}
+			os.Exit(exitErr.Code)
+		}
 		// Command execution failed - delegate to exit helper
 		// Individual commands may have already logged specific errors
 		cmd.ExitWithCodeStderr(foundry.ExitFailure, "Command execution failed", err)
@@ -0,0 +1,78 @@
+package ailink
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// minEchoedSummaryLen is the shortest summary checked for being copied from
+// the prompt; shorter summaries match prompt text by chance.
+const minEchoedSummaryLen = 24
+
+// CheckPlausibility marks resp suspect, with a warning per problem, when the
+// model output is degenerate: a confidence outside 0–1, no insights on a deep
+// analysis, or a summary copied verbatim from one of prompts. Warnings already
+// on resp are kept, so re-checking a cached response is safe.
+func CheckPlausibility(resp *SearchResponse, depth string, prompts ...string) {
+	if resp == nil {
+		return
+	}
+	resp.Warnings = appendWarnings(resp.Warnings, plausibilityWarnings(resp.Summary, resp.Confidence, resp.Insights, depth, prompts))
+	resp.Suspect = resp.Suspect || len(resp.Warnings) > 0
+}
+
+func checkBulkPlausibility(resp *BulkSearchResponse, depth string, prompts ...string) {
+	if resp == nil {
+		return
+	}
+	for i := range resp.Items {
+		item := &resp.Items[i]
+		item.Warnings = appendWarnings(item.Warnings, plausibilityWarnings(item.Summary, item.Confidence, item.Insights, depth, prompts))
+		item.Suspect = item.Suspect || len(item.Warnings) > 0
+	}
+}
+
+func plausibilityWarnings(summary string, confidence *float64, insights []string, depth string, prompts []string) []string {
+	var warnings []string
+	if confidence != nil && (math.IsNaN(*confidence) || *confidence < 0 || *confidence > 1) {
+		warnings = append(warnings, fmt.Sprintf("confidence %g is outside 0-1", *confidence))
+	}
+	if strings.EqualFold(strings.TrimSpace(depth), "deep") && !hasText(insights) {
+		warnings = append(warnings, "deep analysis returned no insights")
+	}
+	if echoed := normalizeEcho(summary); len(echoed) >= minEchoedSummaryLen {
+		for _, text := range prompts {
+			if strings.Contains(normalizeEcho(text), echoed) {
+				warnings = append(warnings, "summary is copied from the prompt")
+				break
+			}
+		}
+	}
+	return warnings
+}
+
+func appendWarnings(existing, warnings []string) []string {
+	for _, warning := range warnings {
+		if !slices.Contains(existing, warning) {
+			existing = append(existing, warning)
+		}
+	}
+	return existing
+}
+
+func hasText(values []string) bool {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeEcho lowercases text and collapses whitespace so reflowed copies
+// of the prompt still match.
+func normalizeEcho(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
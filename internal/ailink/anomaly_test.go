@@ -0,0 +1,76 @@
+package ailink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPlausibility(t *testing.T) {
+	prompt := "You are a naming expert. Assess whether the name is available\nand summarize the risks."
+
+	cases := []struct {
+		name     string
+		resp     SearchResponse
+		depth    string
+		warnings []string
+	}{
+		{
+			name:  "plausible",
+			resp:  SearchResponse{Summary: "No conflicting products found.", Confidence: ptrFloat(0.8), Insights: []string{"clean"}},
+			depth: "deep",
+		},
+		{
+			name:     "confidence out of range",
+			resp:     SearchResponse{Summary: "ok", Confidence: ptrFloat(85)},
+			depth:    "quick",
+			warnings: []string{"confidence 85 is outside 0-1"},
+		},
+		{
+			name:     "deep without insights",
+			resp:     SearchResponse{Summary: "ok", Insights: []string{" "}},
+			depth:    "deep",
+			warnings: []string{"deep analysis returned no insights"},
+		},
+		{
+			name:     "summary echoes prompt",
+			resp:     SearchResponse{Summary: "Assess whether the name is  available and summarize the risks."},
+			depth:    "quick",
+			warnings: []string{"summary is copied from the prompt"},
+		},
+		{
+			name:  "short summary in prompt",
+			resp:  SearchResponse{Summary: "the name"},
+			depth: "quick",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := tc.resp
+			CheckPlausibility(&resp, tc.depth, prompt)
+			require.Equal(t, tc.warnings, resp.Warnings)
+			require.Equal(t, len(tc.warnings) > 0, resp.Suspect)
+		})
+	}
+}
+
+func TestCheckPlausibilityKeepsExistingWarnings(t *testing.T) {
+	resp := SearchResponse{Summary: "ok", Confidence: ptrFloat(-1), Suspect: true, Warnings: []string{"summary is copied from the prompt"}}
+
+	CheckPlausibility(&resp, "quick")
+	CheckPlausibility(&resp, "quick")
+
+	require.True(t, resp.Suspect)
+	require.Equal(t, []string{"summary is copied from the prompt", "confidence -1 is outside 0-1"}, resp.Warnings)
+}
+
+func TestDecodeBulkSearchResponseLenientInsights(t *testing.T) {
+	parsed, err := decodeBulkSearchResponseLenient([]byte(`{"items":[{"name":"acme","summary":"ok","insights":["a",1]}]}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, parsed.Items[0].Insights)
+}
+
+func ptrFloat(v float64) *float64 {
+	return &v
+}
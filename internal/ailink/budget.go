@@ -0,0 +1,117 @@
+package ailink
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BudgetConfig caps AI calls and estimated spend per command run and per UTC
+// day across all subjects. Zero is unlimited. Costs are estimated from
+// provider pricing, so calls to unpriced models count toward call limits only.
+type BudgetConfig struct {
+	MaxCallsPerRun   int     `mapstructure:"max_calls_per_run"`
+	MaxCostPerRunUSD float64 `mapstructure:"max_cost_per_run_usd"`
+	MaxCallsPerDay   int     `mapstructure:"max_calls_per_day"`
+	MaxCostPerDayUSD float64 `mapstructure:"max_cost_per_day_usd"`
+}
+
+// Enabled reports whether any limit is configured.
+func (c BudgetConfig) Enabled() bool {
+	return c.runLimited() || c.DailyEnabled()
+}
+
+// DailyEnabled reports whether a daily limit, which is persisted in the
+// store, is configured.
+func (c BudgetConfig) DailyEnabled() bool {
+	return c.MaxCallsPerDay > 0 || c.MaxCostPerDayUSD > 0
+}
+
+func (c BudgetConfig) runLimited() bool {
+	return c.MaxCallsPerRun > 0 || c.MaxCostPerRunUSD > 0
+}
+
+// SpendCounter stores AI calls and estimated spend per UTC day.
+type SpendCounter interface {
+	AISpend(ctx context.Context, at time.Time) (calls int, costUSD float64, err error)
+	RecordAISpend(ctx context.Context, at time.Time, costUSD float64) error
+}
+
+// BudgetExceededError is returned when a run or day has used its AI budget.
+type BudgetExceededError struct {
+	// Scope is "run" or "day".
+	Scope string
+	// Limit describes the exhausted limit, e.g. "10 calls" or "$5.00".
+	Limit string
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("AI budget per %s exceeded (%s)", e.Scope, e.Limit)
+}
+
+// BudgetEnforcer rejects AI calls once a run or day has reached its budget.
+// Spend is only known after a call returns, so the call that crosses a cost
+// limit completes and the next one is rejected.
+type BudgetEnforcer struct {
+	Config  BudgetConfig
+	Counter SpendCounter
+	Now     func() time.Time
+}
+
+// Check returns a *BudgetExceededError when the run of ctx or the current
+// day has used its budget. A run is the outermost usage tracker on ctx;
+// without one only daily limits apply.
+func (b *BudgetEnforcer) Check(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	cfg := b.Config
+
+	if run, ok := runUsage(ctx); ok {
+		if cfg.MaxCallsPerRun > 0 && run.Calls >= cfg.MaxCallsPerRun {
+			return &BudgetExceededError{Scope: "run", Limit: fmt.Sprintf("%d calls", cfg.MaxCallsPerRun)}
+		}
+		if cfg.MaxCostPerRunUSD > 0 && run.EstimatedCostUSD != nil && *run.EstimatedCostUSD >= cfg.MaxCostPerRunUSD {
+			return &BudgetExceededError{Scope: "run", Limit: fmt.Sprintf("$%.2f", cfg.MaxCostPerRunUSD)}
+		}
+	}
+
+	if !cfg.DailyEnabled() || b.Counter == nil {
+		return nil
+	}
+	calls, cost, err := b.Counter.AISpend(ctx, b.now())
+	if err != nil {
+		return fmt.Errorf("read AI budget usage: %w", err)
+	}
+	if cfg.MaxCallsPerDay > 0 && calls >= cfg.MaxCallsPerDay {
+		return &BudgetExceededError{Scope: "day", Limit: fmt.Sprintf("%d calls", cfg.MaxCallsPerDay)}
+	}
+	if cfg.MaxCostPerDayUSD > 0 && cost >= cfg.MaxCostPerDayUSD {
+		return &BudgetExceededError{Scope: "day", Limit: fmt.Sprintf("$%.2f", cfg.MaxCostPerDayUSD)}
+	}
+	return nil
+}
+
+// record adds a completed provider call to the daily spend. Spend is kept
+// whenever a counter is set so it can be reported over time; failing to
+// record it is only an error when a daily limit depends on it.
+func (b *BudgetEnforcer) record(ctx context.Context, usage Usage) error {
+	if b == nil || b.Counter == nil {
+		return nil
+	}
+	cost := 0.0
+	if usage.EstimatedCostUSD != nil {
+		cost = *usage.EstimatedCostUSD
+	}
+	if err := b.Counter.RecordAISpend(ctx, b.now(), cost); err != nil && b.Config.DailyEnabled() {
+		return fmt.Errorf("record AI budget usage: %w", err)
+	}
+	return nil
+}
+
+func (b *BudgetEnforcer) now() time.Time {
+	if b.Now != nil {
+		return b.Now().UTC()
+	}
+	return time.Now().UTC()
+}
@@ -0,0 +1,100 @@
+package ailink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSpendCounter struct {
+	calls int
+	cost  float64
+}
+
+func (f *fakeSpendCounter) AISpend(ctx context.Context, at time.Time) (int, float64, error) {
+	return f.calls, f.cost, nil
+}
+
+func (f *fakeSpendCounter) RecordAISpend(ctx context.Context, at time.Time, costUSD float64) error {
+	f.calls++
+	f.cost += costUSD
+	return nil
+}
+
+func TestBudgetEnforcerRunLimits(t *testing.T) {
+	budget := &BudgetEnforcer{Config: BudgetConfig{MaxCallsPerRun: 2, MaxCostPerRunUSD: 1}}
+
+	require.NoError(t, budget.Check(context.Background()), "no run tracker means no run limit")
+
+	ctx, run := WithUsageTracker(context.Background())
+	nested, item := WithUsageTracker(ctx)
+	require.NoError(t, budget.Check(nested))
+
+	item.add(Usage{Calls: 1})
+	run.add(Usage{Calls: 1})
+	err := budget.Check(nested)
+	var budgetErr *BudgetExceededError
+	require.True(t, errors.As(err, &budgetErr))
+	require.Equal(t, "run", budgetErr.Scope)
+	require.Equal(t, "2 calls", budgetErr.Limit)
+
+	ctx, run = WithUsageTracker(context.Background())
+	cost := 1.5
+	run.add(Usage{Calls: 1, EstimatedCostUSD: &cost})
+	err = budget.Check(ctx)
+	require.True(t, errors.As(err, &budgetErr))
+	require.Equal(t, "$1.00", budgetErr.Limit)
+
+	mapped := MapProviderError(err)
+	require.Equal(t, "AILINK_BUDGET_EXCEEDED", mapped.Code)
+}
+
+func TestBudgetEnforcerDailyLimits(t *testing.T) {
+	counter := &fakeSpendCounter{}
+	budget := &BudgetEnforcer{
+		Config:  BudgetConfig{MaxCallsPerDay: 2, MaxCostPerDayUSD: 0.5},
+		Counter: counter,
+	}
+	ctx := context.Background()
+
+	cost := 0.1
+	require.NoError(t, budget.Check(ctx))
+	require.NoError(t, budget.record(ctx, Usage{Calls: 1, EstimatedCostUSD: &cost}))
+	require.NoError(t, budget.Check(ctx))
+	require.NoError(t, budget.record(ctx, Usage{Calls: 1}))
+	require.Equal(t, 2, counter.calls)
+	require.InDelta(t, 0.1, counter.cost, 1e-9)
+
+	err := budget.Check(ctx)
+	var budgetErr *BudgetExceededError
+	require.True(t, errors.As(err, &budgetErr))
+	require.Equal(t, "day", budgetErr.Scope)
+	require.Equal(t, "2 calls", budgetErr.Limit)
+
+	counter.calls = 0
+	counter.cost = 0.5
+	err = budget.Check(ctx)
+	require.True(t, errors.As(err, &budgetErr))
+	require.Equal(t, "$0.50", budgetErr.Limit)
+}
+
+func TestBudgetEnforcerRecordsSpendWithoutLimits(t *testing.T) {
+	counter := &fakeSpendCounter{}
+	budget := &BudgetEnforcer{Counter: counter}
+	ctx := context.Background()
+
+	cost := 0.2
+	require.NoError(t, budget.Check(ctx))
+	require.NoError(t, budget.record(ctx, Usage{Calls: 1, EstimatedCostUSD: &cost}))
+	require.Equal(t, 1, counter.calls)
+	require.InDelta(t, 0.2, counter.cost, 1e-9)
+}
+
+func TestBudgetEnforcerNil(t *testing.T) {
+	var budget *BudgetEnforcer
+	require.NoError(t, budget.Check(context.Background()))
+	require.NoError(t, budget.record(context.Background(), Usage{Calls: 1}))
+}
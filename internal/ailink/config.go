@@ -1,6 +1,13 @@
 package ailink
 
-import "time"
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/namelens/namelens/internal/keychain"
+)
 
 // Config defines provider configuration for AILink.
 //
@@ -24,6 +31,12 @@ type Config struct {
 
 	Routing   map[string]string   `mapstructure:"routing"`
 	Fallbacks map[string][]string `mapstructure:"fallbacks"`
+
+	// Quotas limits AI calls per API key or workspace.
+	Quotas QuotaConfig `mapstructure:"quotas"`
+
+	// Budget caps AI calls and estimated spend per run and per day.
+	Budget BudgetConfig `mapstructure:"budget"`
 }
 
 type DebugConfig struct {
@@ -52,16 +65,66 @@ type ProviderInstanceConfig struct {
 	Roles        []string          `mapstructure:"roles"`
 
 	Credentials []CredentialConfig `mapstructure:"credentials"`
+
+	// Pricing maps model ids (or "default") to USD prices per million tokens.
+	// It is only used to estimate cost in usage reports.
+	Pricing map[string]ModelPricing `mapstructure:"pricing"`
+
+	// ContextWindows maps model ids (or "default") to context windows in
+	// tokens. Models without an entry use a built-in window for their family.
+	ContextWindows map[string]int `mapstructure:"context_windows"`
 }
 
 // CredentialConfig is a single credential for a provider instance.
 //
 // Multiple credentials enable key rotation, future load balancing, and per-key rate limit handling.
 type CredentialConfig struct {
-	Enabled  bool   `mapstructure:"enabled"`
-	Label    string `mapstructure:"label"`
-	APIKey   string `mapstructure:"api_key"`
-	Priority int    `mapstructure:"priority"`
+	Enabled bool   `mapstructure:"enabled"`
+	Label   string `mapstructure:"label"`
+	APIKey  string `mapstructure:"api_key"`
+	// APIKeyFile is read for the key when APIKey is empty, e.g. a Kubernetes
+	// Secret mounted as a file. It is re-read on every resolution, so a
+	// rotated secret takes effect without a restart.
+	APIKeyFile string `mapstructure:"api_key_file"`
+	Priority   int    `mapstructure:"priority"`
+}
+
+// HasKey reports whether an API key is set inline or through a file.
+func (c CredentialConfig) HasKey() bool {
+	return strings.TrimSpace(c.APIKey) != "" || strings.TrimSpace(c.APIKeyFile) != ""
+}
+
+// LoadKey returns the credential with APIKey read from APIKeyFile when no
+// inline key is set. Surrounding whitespace, such as the trailing newline of
+// a mounted secret, is trimmed.
+func (c CredentialConfig) LoadKey() (CredentialConfig, error) {
+	path := strings.TrimSpace(c.APIKeyFile)
+	if strings.TrimSpace(c.APIKey) != "" || path == "" {
+		return c, nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- user-configured secret path
+	if err != nil {
+		return c, fmt.Errorf("read api_key_file: %w", err)
+	}
+	c.APIKey = strings.TrimSpace(string(data))
+	return c, nil
+}
+
+// ResolveKey is LoadKey with a fallback to the OS keychain when neither an
+// inline key nor an api_key_file is set. The keychain entry for the
+// credential's label wins over the provider-wide one.
+func (c CredentialConfig) ResolveKey(providerID string) (CredentialConfig, error) {
+	if c.HasKey() {
+		return c.LoadKey()
+	}
+	if key := keychain.Lookup(keychain.AIAccount(providerID, c.Label)); key != "" {
+		c.APIKey = key
+		return c, nil
+	}
+	if strings.TrimSpace(c.Label) != "" {
+		c.APIKey = keychain.Lookup(keychain.AIAccount(providerID, ""))
+	}
+	return c, nil
 }
 
 // Capabilities describes provider-level hints.
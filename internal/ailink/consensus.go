@@ -0,0 +1,292 @@
+package ailink
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultConsensusThreshold is the score spread, in points out of 100, above
+// which consensus members are flagged as disagreeing.
+const DefaultConsensusThreshold = 20
+
+// Severity levels used by suitability locale and risk assessments, mildest
+// first. Members whose levels for the same item are two or more steps apart
+// disagree.
+var consensusLevels = []string{"clear", "low", "medium", "high", "blocker"}
+
+// Overall ratings, mildest first.
+var consensusRatings = []string{"suitable", "caution", "unsuitable"}
+
+// ConsensusVote is one model's answer in a consensus run.
+type ConsensusVote struct {
+	Provider string
+	Model    string
+	Raw      json.RawMessage
+	Err      *SearchError
+}
+
+func (v ConsensusVote) label() string {
+	return v.Provider + "/" + v.Model
+}
+
+// ConsensusMember summarizes one model's answer in a merged result.
+type ConsensusMember struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Score    *int   `json:"score,omitempty"`
+	Rating   string `json:"rating,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Consensus records how several models' answers were merged. It is added to
+// the merged analysis under "consensus".
+type Consensus struct {
+	Members       []ConsensusMember `json:"members"`
+	Answered      int               `json:"answered"`
+	MedianScore   int               `json:"median_score"`
+	Spread        int               `json:"spread"`
+	Threshold     int               `json:"threshold"`
+	Disagreement  bool              `json:"disagreement"`
+	Disagreements []string          `json:"disagreements,omitempty"`
+}
+
+type suitabilityVote struct {
+	Overall struct {
+		Score  *int   `json:"score"`
+		Rating string `json:"rating"`
+	} `json:"overall_suitability"`
+	ByLocale []struct {
+		Locale      string `json:"locale"`
+		Suitability string `json:"suitability"`
+		Score       *int   `json:"score"`
+	} `json:"by_locale"`
+	RiskAssessment map[string]struct {
+		Level string `json:"level"`
+	} `json:"risk_assessment"`
+}
+
+// MergeSuitability merges name-suitability answers from several models. The
+// merged document is the answer closest to the median score, with the overall
+// and per-locale scores set to the median, the rating and each locale and risk
+// level set to the median level (the more cautious one for an even count), and
+// a "consensus" object listing every member and their disagreements: a score
+// spread above threshold, or locale and risk levels two or more steps apart.
+func MergeSuitability(votes []ConsensusVote, threshold int) (json.RawMessage, error) {
+	if threshold <= 0 {
+		threshold = DefaultConsensusThreshold
+	}
+
+	type member struct {
+		vote   ConsensusVote
+		parsed suitabilityVote
+		doc    map[string]any
+	}
+	consensus := Consensus{Threshold: threshold, Members: make([]ConsensusMember, 0, len(votes))}
+	var members []member
+	for _, vote := range votes {
+		entry := ConsensusMember{Provider: vote.Provider, Model: vote.Model}
+		if vote.Err != nil {
+			entry.Error = vote.Err.Message
+			consensus.Members = append(consensus.Members, entry)
+			continue
+		}
+		var parsed suitabilityVote
+		var doc map[string]any
+		if err := json.Unmarshal(vote.Raw, &parsed); err != nil || json.Unmarshal(vote.Raw, &doc) != nil || parsed.Overall.Score == nil {
+			entry.Error = "response has no overall suitability score"
+			consensus.Members = append(consensus.Members, entry)
+			continue
+		}
+		entry.Score = parsed.Overall.Score
+		entry.Rating = parsed.Overall.Rating
+		consensus.Members = append(consensus.Members, entry)
+		members = append(members, member{vote: vote, parsed: parsed, doc: doc})
+	}
+	if len(members) == 0 {
+		return nil, errors.New("no model returned a usable suitability analysis")
+	}
+	consensus.Answered = len(members)
+
+	scores := make([]int, len(members))
+	for i, m := range members {
+		scores[i] = *m.parsed.Overall.Score
+	}
+	consensus.MedianScore = medianScore(scores)
+
+	// The answer closest to the median keeps its prose and details
+	base := 0
+	for i, score := range scores {
+		if absInt(score-consensus.MedianScore) < absInt(scores[base]-consensus.MedianScore) {
+			base = i
+		}
+	}
+
+	low, high := 0, 0
+	for i, score := range scores {
+		if score < scores[low] {
+			low = i
+		}
+		if score > scores[high] {
+			high = i
+		}
+	}
+	consensus.Spread = scores[high] - scores[low]
+	if consensus.Spread > threshold {
+		consensus.Disagreements = append(consensus.Disagreements, fmt.Sprintf("overall score: %d (%s) vs %d (%s)",
+			scores[low], members[low].vote.label(), scores[high], members[high].vote.label()))
+	}
+
+	doc := members[base].doc
+	overall, _ := doc["overall_suitability"].(map[string]any)
+	if overall != nil {
+		overall["score"] = consensus.MedianScore
+		ratings := make([]string, 0, len(members))
+		for _, m := range members {
+			ratings = append(ratings, m.parsed.Overall.Rating)
+		}
+		if rating, ok := medianLevel(consensusRatings, ratings); ok {
+			overall["rating"] = rating
+		}
+	}
+
+	// Locales and risk categories, in the base answer's order then any others
+	localeLevels := map[string][]string{}
+	localeVoters := map[string][]string{}
+	localeScores := map[string][]int{}
+	riskLevels := map[string][]string{}
+	riskVoters := map[string][]string{}
+	for _, m := range members {
+		for _, locale := range m.parsed.ByLocale {
+			key := strings.TrimSpace(locale.Locale)
+			if key == "" {
+				continue
+			}
+			localeLevels[key] = append(localeLevels[key], locale.Suitability)
+			localeVoters[key] = append(localeVoters[key], m.vote.label())
+			if locale.Score != nil {
+				localeScores[key] = append(localeScores[key], *locale.Score)
+			}
+		}
+		for category, risk := range m.parsed.RiskAssessment {
+			riskLevels[category] = append(riskLevels[category], risk.Level)
+			riskVoters[category] = append(riskVoters[category], m.vote.label())
+		}
+	}
+
+	if locales, ok := doc["by_locale"].([]any); ok {
+		for _, item := range locales {
+			entry, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			key, _ := entry["locale"].(string)
+			key = strings.TrimSpace(key)
+			if level, ok := medianLevel(consensusLevels, localeLevels[key]); ok {
+				entry["suitability"] = level
+			}
+			if scores := localeScores[key]; len(scores) > 0 {
+				entry["score"] = medianScore(scores)
+			}
+		}
+	}
+	if risks, ok := doc["risk_assessment"].(map[string]any); ok {
+		for category, item := range risks {
+			entry, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			if level, ok := medianLevel(consensusLevels, riskLevels[category]); ok {
+				entry["level"] = level
+			}
+		}
+	}
+
+	consensus.Disagreements = append(consensus.Disagreements, levelDisagreements("locale", localeLevels, localeVoters)...)
+	consensus.Disagreements = append(consensus.Disagreements, levelDisagreements("risk", riskLevels, riskVoters)...)
+	consensus.Disagreement = len(consensus.Disagreements) > 0
+	doc["consensus"] = consensus
+
+	return json.Marshal(doc)
+}
+
+// levelDisagreements reports items whose levels are two or more steps apart,
+// sorted by item.
+func levelDisagreements(kind string, levels, voters map[string][]string) []string {
+	keys := make([]string, 0, len(levels))
+	for key := range levels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var out []string
+	for _, key := range keys {
+		low, high := -1, -1
+		for i, level := range levels[key] {
+			rank := levelRank(consensusLevels, level)
+			if rank < 0 {
+				continue
+			}
+			if low < 0 || rank < levelRank(consensusLevels, levels[key][low]) {
+				low = i
+			}
+			if high < 0 || rank > levelRank(consensusLevels, levels[key][high]) {
+				high = i
+			}
+		}
+		if low < 0 || levelRank(consensusLevels, levels[key][high])-levelRank(consensusLevels, levels[key][low]) < 2 {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s %s: %s (%s) vs %s (%s)", kind, key,
+			levels[key][low], voters[key][low], levels[key][high], voters[key][high]))
+	}
+	return out
+}
+
+// medianLevel returns the median of the known values on scale, taking the
+// higher (more cautious) of the two middle values for an even count.
+func medianLevel(scale, values []string) (string, bool) {
+	ranks := make([]int, 0, len(values))
+	for _, value := range values {
+		if rank := levelRank(scale, value); rank >= 0 {
+			ranks = append(ranks, rank)
+		}
+	}
+	if len(ranks) == 0 {
+		return "", false
+	}
+	sort.Ints(ranks)
+	return scale[ranks[len(ranks)/2]], true
+}
+
+func levelRank(scale []string, value string) int {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for i, level := range scale {
+		if level == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// medianScore returns the median, rounding the mean of the two middle scores
+// for an even count.
+func medianScore(scores []int) int {
+	sorted := append([]int(nil), scores...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return int(math.Round(float64(sorted[mid-1]+sorted[mid]) / 2))
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,83 @@
+package ailink
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSuitabilityTakesMedians(t *testing.T) {
+	votes := []ConsensusVote{
+		{Provider: "a", Model: "m1", Raw: json.RawMessage(`{"name":"acme","overall_suitability":{"score":90,"rating":"suitable","summary":"a"},"by_locale":[{"locale":"ja-JP","suitability":"clear","score":95}],"risk_assessment":{"religious":{"level":"clear"}}}`)},
+		{Provider: "b", Model: "m2", Raw: json.RawMessage(`{"name":"acme","overall_suitability":{"score":80,"rating":"caution","summary":"b"},"by_locale":[{"locale":"ja-JP","suitability":"low","score":70}],"risk_assessment":{"religious":{"level":"low"}}}`)},
+		{Provider: "c", Model: "m3", Raw: json.RawMessage(`{"name":"acme","overall_suitability":{"score":86,"rating":"suitable","summary":"c"},"by_locale":[{"locale":"ja-JP","suitability":"clear","score":88}],"risk_assessment":{"religious":{"level":"clear"}}}`)},
+	}
+
+	raw, err := MergeSuitability(votes, 20)
+	require.NoError(t, err)
+
+	var merged struct {
+		Overall struct {
+			Score   int    `json:"score"`
+			Rating  string `json:"rating"`
+			Summary string `json:"summary"`
+		} `json:"overall_suitability"`
+		ByLocale []struct {
+			Suitability string `json:"suitability"`
+			Score       int    `json:"score"`
+		} `json:"by_locale"`
+		Consensus Consensus `json:"consensus"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &merged))
+	require.Equal(t, 86, merged.Overall.Score)
+	require.Equal(t, 88, merged.ByLocale[0].Score)
+	require.Equal(t, "suitable", merged.Overall.Rating)
+	require.Equal(t, "c", merged.Overall.Summary)
+	require.Equal(t, "clear", merged.ByLocale[0].Suitability)
+	require.Equal(t, 3, merged.Consensus.Answered)
+	require.Equal(t, 10, merged.Consensus.Spread)
+	require.False(t, merged.Consensus.Disagreement)
+}
+
+func TestMergeSuitabilityFlagsDisagreement(t *testing.T) {
+	votes := []ConsensusVote{
+		{Provider: "a", Model: "m1", Raw: json.RawMessage(`{"overall_suitability":{"score":95,"rating":"suitable"},"by_locale":[{"locale":"de-DE","suitability":"clear"}]}`)},
+		{Provider: "b", Model: "m2", Raw: json.RawMessage(`{"overall_suitability":{"score":60,"rating":"caution"},"by_locale":[{"locale":"de-DE","suitability":"high"}]}`)},
+		{Provider: "c", Model: "m3", Err: &SearchError{Code: "AILINK_TIMEOUT", Message: "expert request timed out"}},
+	}
+
+	raw, err := MergeSuitability(votes, 20)
+	require.NoError(t, err)
+
+	var merged struct {
+		Overall struct {
+			Score  int    `json:"score"`
+			Rating string `json:"rating"`
+		} `json:"overall_suitability"`
+		ByLocale []struct {
+			Suitability string `json:"suitability"`
+		} `json:"by_locale"`
+		Consensus Consensus `json:"consensus"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &merged))
+	require.Equal(t, 78, merged.Overall.Score)
+	require.Equal(t, "caution", merged.Overall.Rating)
+	require.Equal(t, "high", merged.ByLocale[0].Suitability)
+	require.Equal(t, 2, merged.Consensus.Answered)
+	require.Len(t, merged.Consensus.Members, 3)
+	require.Equal(t, "expert request timed out", merged.Consensus.Members[2].Error)
+	require.True(t, merged.Consensus.Disagreement)
+	require.Equal(t, []string{
+		"overall score: 60 (b/m2) vs 95 (a/m1)",
+		"locale de-DE: clear (a/m1) vs high (b/m2)",
+	}, merged.Consensus.Disagreements)
+}
+
+func TestMergeSuitabilityNeedsOneAnswer(t *testing.T) {
+	_, err := MergeSuitability([]ConsensusVote{
+		{Provider: "a", Model: "m1", Err: &SearchError{Message: "failed"}},
+		{Provider: "b", Model: "m2", Raw: json.RawMessage(`{"name":"acme"}`)},
+	}, 20)
+	require.Error(t, err)
+}
@@ -4,7 +4,9 @@ package context
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -45,6 +47,15 @@ const DefaultMaxChars = 32000
 type Config struct {
 	Patterns []string // File patterns to search (globs)
 	MaxChars int      // Maximum characters to include
+	// MaxDepth is how many directory levels below the root patterns without
+	// a slash (such as "*.md") also match; 0 matches them at the root only.
+	// Patterns with a slash match their own path, and "**" spans at most
+	// MaxDepth levels.
+	MaxDepth int
+	Exclude  []string // Extra ignore patterns (.gitignore syntax)
+	// NoIgnore skips IgnoreFiles and DefaultIgnoreDirs. VCS directories are
+	// always skipped.
+	NoIgnore bool
 }
 
 // DefaultConfig returns the default discovery configuration.
@@ -63,11 +74,17 @@ type DiscoveredFile struct {
 	Size     int64  // File size in bytes
 }
 
-// Discover finds files matching the configured patterns in the given directory.
+// Discover finds files matching the configured patterns in the given
+// directory. It skips VCS directories, DefaultIgnoreDirs, paths excluded by
+// IgnoreFiles or cfg.Exclude, and binary files other than documents read via
+// docprims.
 func Discover(dir string, cfg Config) ([]DiscoveredFile, error) {
 	if cfg.Patterns == nil {
 		cfg.Patterns = DefaultPatterns
 	}
+	if cfg.MaxDepth < 0 {
+		cfg.MaxDepth = 0
+	}
 
 	absDir, err := filepath.Abs(dir)
 	if err != nil {
@@ -82,34 +99,78 @@ func Discover(dir string, cfg Config) ([]DiscoveredFile, error) {
 		return nil, fmt.Errorf("not a directory: %s", dir)
 	}
 
-	seen := make(map[string]bool)
-	var files []DiscoveredFile
+	patterns := make([]string, len(cfg.Patterns))
+	walkDepth := cfg.MaxDepth
+	for i, pattern := range cfg.Patterns {
+		patterns[i] = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+		if depth := strings.Count(patterns[i], "/"); depth > walkDepth && !strings.Contains(patterns[i], "**") {
+			walkDepth = depth
+		}
+	}
 
-	for priority, pattern := range cfg.Patterns {
-		matches, err := filepath.Glob(filepath.Join(absDir, pattern))
-		if err != nil {
-			continue // Invalid pattern, skip
+	ignore := &ignoreMatcher{}
+	if len(cfg.Exclude) > 0 {
+		ignore.rules = parseIgnoreRules("", strings.NewReader(strings.Join(cfg.Exclude, "\n")))
+	}
+	skipDirs := make(map[string]bool, len(DefaultIgnoreDirs))
+	if !cfg.NoIgnore {
+		for _, name := range DefaultIgnoreDirs {
+			skipDirs[name] = true
 		}
+	}
 
-		for _, absPath := range matches {
-			if seen[absPath] {
-				continue
+	var files []DiscoveredFile
+	err = filepath.WalkDir(absDir, func(absPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if absPath == absDir {
+				return err
 			}
-
-			info, err := os.Stat(absPath)
-			if err != nil || info.IsDir() {
-				continue
+			return nil // Unreadable entry, skip
+		}
+		relPath, _ := filepath.Rel(absDir, absPath)
+		rel := filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			if absPath == absDir {
+				if !cfg.NoIgnore {
+					ignore.load(absPath, "")
+				}
+				return nil
+			}
+			if vcsDirs[d.Name()] || skipDirs[d.Name()] || ignore.ignored(rel, true) ||
+				strings.Count(rel, "/") >= walkDepth {
+				return filepath.SkipDir
 			}
+			if !cfg.NoIgnore {
+				ignore.load(absPath, rel)
+			}
+			return nil
+		}
 
-			relPath, _ := filepath.Rel(absDir, absPath)
-			seen[absPath] = true
-			files = append(files, DiscoveredFile{
-				Path:     relPath,
-				AbsPath:  absPath,
-				Priority: priority,
-				Size:     info.Size(),
-			})
+		priority := matchPriority(patterns, rel, cfg.MaxDepth)
+		if priority < 0 || ignore.ignored(rel, false) {
+			return nil
+		}
+
+		// Follow symlinks to files, as the previous glob-based scan did
+		info, err := os.Stat(absPath)
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		if !IsDocprimsFormat(rel) && isBinaryFile(absPath) {
+			return nil
 		}
+
+		files = append(files, DiscoveredFile{
+			Path:     relPath,
+			AbsPath:  absPath,
+			Priority: priority,
+			Size:     info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan directory: %w", err)
 	}
 
 	// Sort by priority (pattern order), then by path for determinism
@@ -123,6 +184,25 @@ func Discover(dir string, cfg Config) ([]DiscoveredFile, error) {
 	return files, nil
 }
 
+// matchPriority returns the index of the first pattern matching the
+// slash-separated path rel, or -1. Patterns without a slash match the file
+// name at up to maxDepth levels below the root.
+func matchPriority(patterns []string, rel string, maxDepth int) int {
+	depth := strings.Count(rel, "/")
+	for i, pattern := range patterns {
+		if strings.Contains(pattern, "/") {
+			if matchGlob(pattern, rel) {
+				return i
+			}
+			continue
+		}
+		if depth <= maxDepth && matchGlob(pattern, path.Base(rel)) {
+			return i
+		}
+	}
+	return -1
+}
+
 // FileInfo contains metadata about an included or excluded file.
 type FileInfo struct {
 	Path     string `json:"path"`
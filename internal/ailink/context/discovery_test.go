@@ -44,6 +44,111 @@ func TestDiscoverSubdirectory(t *testing.T) {
 	require.Equal(t, filepath.Join("docs", "guide.md"), files[1].Path)
 }
 
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func discoveredPaths(files []DiscoveredFile) []string {
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = filepath.ToSlash(f.Path)
+	}
+	return paths
+}
+
+func TestDiscoverMaxDepth(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"README.md":                 "# Root",
+		"packages/api/README.md":    "# API",
+		"packages/api/docs/deep.md": "# Deep",
+	})
+
+	files, err := Discover(dir, DefaultConfig())
+	require.NoError(t, err)
+	require.Equal(t, []string{"README.md"}, discoveredPaths(files))
+
+	cfg := DefaultConfig()
+	cfg.MaxDepth = 2
+	files, err = Discover(dir, cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"README.md", "packages/api/README.md"}, discoveredPaths(files))
+}
+
+func TestDiscoverIgnoreRules(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"README.md":                  "# Root",
+		".gitignore":                 "generated/\n*.gen.md\n",
+		".namelensignore":            "# drafts stay out\nNOTES.md\n",
+		"CHANGES.gen.md":             "generated",
+		"NOTES.md":                   "notes",
+		"generated/api.md":           "generated docs",
+		"node_modules/pkg/README.md": "dependency readme",
+		"web/.gitignore":             "*.md\n!KEEP.md\n",
+		"web/DROP.md":                "dropped",
+		"web/KEEP.md":                "kept",
+		"docs/guide.md":              "guide",
+		".git/README.md":             "git internals",
+	})
+
+	cfg := DefaultConfig()
+	cfg.MaxDepth = 3
+	files, err := Discover(dir, cfg)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"README.md", "docs/guide.md", "web/KEEP.md"}, discoveredPaths(files))
+
+	cfg.Exclude = []string{"docs/"}
+	files, err = Discover(dir, cfg)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"README.md", "web/KEEP.md"}, discoveredPaths(files))
+
+	cfg.Exclude = nil
+	cfg.NoIgnore = true
+	files, err = Discover(dir, cfg)
+	require.NoError(t, err)
+	paths := discoveredPaths(files)
+	require.Contains(t, paths, "node_modules/pkg/README.md")
+	require.Contains(t, paths, "generated/api.md")
+	require.NotContains(t, paths, ".git/README.md")
+}
+
+func TestDiscoverSkipsBinaryFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"README.md": "# Root",
+		"blob.md":   "looks like text\x00but is not",
+	})
+
+	files, err := Discover(dir, DefaultConfig())
+	require.NoError(t, err)
+	require.Equal(t, []string{"README.md"}, discoveredPaths(files))
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.md", "README.md", true},
+		{"docs/*.md", "docs/guide.md", true},
+		{"docs/*.md", "docs/sub/guide.md", false},
+		{"docs/**/*.md", "docs/guide.md", true},
+		{"docs/**/*.md", "docs/a/b/guide.md", true},
+		{"**/build", "a/b/build", true},
+		{"[", "[", false},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.want, matchGlob(tc.pattern, tc.name), "%s vs %s", tc.pattern, tc.name)
+	}
+}
+
 func TestGather(t *testing.T) {
 	dir := t.TempDir()
 
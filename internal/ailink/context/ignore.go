@@ -0,0 +1,160 @@
+package context
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// IgnoreFiles are read from every scanned directory. Their patterns use
+// .gitignore syntax and apply to that directory and everything below it.
+var IgnoreFiles = []string{".gitignore", ".namelensignore"}
+
+// DefaultIgnoreDirs are dependency and virtualenv directories that are never
+// scanned unless ignore rules are turned off.
+var DefaultIgnoreDirs = []string{
+	"node_modules",
+	"bower_components",
+	"vendor",
+	".venv",
+	"venv",
+	"__pycache__",
+}
+
+// vcsDirs are always skipped.
+var vcsDirs = map[string]bool{".git": true, ".hg": true, ".svn": true}
+
+// binarySniffLen is how much of a file is read to detect binary content,
+// matching the heuristic git uses.
+const binarySniffLen = 8000
+
+// ignoreRule is one .gitignore line. base is the slash-separated directory,
+// relative to the scan root, whose ignore file declared it.
+type ignoreRule struct {
+	base     string
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// ignoreMatcher applies ignore rules in declaration order; the last rule
+// that matches a path decides.
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+// parseIgnoreRules reads .gitignore-syntax patterns declared in base.
+func parseIgnoreRules(base string, r io.Reader) []ignoreRule {
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			rule.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// load adds the rules of the ignore files in absDir, which is relDir relative
+// to the scan root. Missing or unreadable files are skipped.
+func (m *ignoreMatcher) load(absDir, relDir string) {
+	for _, name := range IgnoreFiles {
+		data, err := os.ReadFile(filepath.Join(absDir, name)) // #nosec G304 -- ignore file inside the scanned directory
+		if err != nil {
+			continue
+		}
+		m.rules = append(m.rules, parseIgnoreRules(relDir, bytes.NewReader(data))...)
+	}
+}
+
+// ignored reports whether the slash-separated path rel, relative to the scan
+// root, is excluded.
+func (m *ignoreMatcher) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		sub := rel
+		if rule.base != "" {
+			if !strings.HasPrefix(rel, rule.base+"/") {
+				continue
+			}
+			sub = strings.TrimPrefix(rel, rule.base+"/")
+		}
+		target := sub
+		if !rule.anchored {
+			target = path.Base(sub)
+		}
+		if matchGlob(rule.pattern, target) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matchGlob matches a slash-separated path against a pattern where "**"
+// spans any number of directories and other segments use path.Match.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// isBinaryFile reports whether the start of the file contains a NUL byte.
+func isBinaryFile(absPath string) bool {
+	f, err := os.Open(absPath) // #nosec G304 -- file inside the scanned directory
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+
+	buf := make([]byte, binarySniffLen)
+	n, _ := io.ReadFull(f, buf)
+	return bytes.IndexByte(buf[:n], 0) >= 0
+}
@@ -0,0 +1,366 @@
+package ailink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ContextSummaryPrompt condenses context that does not fit a model's window.
+const ContextSummaryPrompt = "context-summary"
+
+// Context guard actions recorded in ContextReport.Action.
+const (
+	ContextSummarized = "summarized"
+	ContextTrimmed    = "trimmed"
+)
+
+const (
+	// defaultContextWindow applies to models with no configured or known
+	// window. It is deliberately conservative.
+	defaultContextWindow = 128000
+	// minResponseTokens is the least room left in the window for the response.
+	minResponseTokens = 4096
+	// maxSummaryWords caps the summary requested from the summarizing model.
+	maxSummaryWords = 1500
+	// omissionNoteTokens covers the note that replaces omitted sections.
+	omissionNoteTokens = 20
+)
+
+// knownContextWindows are context windows in tokens for model families,
+// matched by the longest prefix of the model id. Provider context_windows
+// entries take precedence.
+var knownContextWindows = map[string]int{
+	"claude":  200000,
+	"gpt-4o":  128000,
+	"gpt-4.1": 1000000,
+	"gpt-5":   400000,
+	"grok-3":  131072,
+	"grok-4":  256000,
+}
+
+// ContextWindow returns the context window in tokens for model: the
+// provider's context_windows entry for the model or "default", then the
+// built-in window for the model family, then a conservative default.
+func (p ProviderInstanceConfig) ContextWindow(model string) int {
+	if window, ok := p.ContextWindows[model]; ok && window > 0 {
+		return window
+	}
+	if window, ok := p.ContextWindows["default"]; ok && window > 0 {
+		return window
+	}
+	id := strings.ToLower(strings.TrimSpace(model))
+	best, window := "", defaultContextWindow
+	for prefix, size := range knownContextWindows {
+		if strings.HasPrefix(id, prefix) && len(prefix) > len(best) {
+			best, window = prefix, size
+		}
+	}
+	return window
+}
+
+// EstimateTokens approximates the tokens in text at four characters per
+// token, which is close for English prose and errs high for code.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// ContextReport records how bulk prompt context was reduced to fit the
+// model's context window.
+type ContextReport struct {
+	Variable      string `json:"variable"`
+	Model         string `json:"model"`
+	ContextWindow int    `json:"context_window"`
+	// PromptTokens and FinalTokens are estimates for the whole prompt before
+	// and after the context was reduced.
+	PromptTokens int    `json:"prompt_tokens"`
+	FinalTokens  int    `json:"final_tokens"`
+	Action       string `json:"action"`
+	SummaryModel string `json:"summary_model,omitempty"`
+	// Kept lists sections included verbatim; Summarized and Omitted list
+	// sections replaced by the summary or dropped. Sections are files for
+	// scanned context.
+	Kept       []string `json:"kept,omitempty"`
+	Summarized []string `json:"summarized,omitempty"`
+	Omitted    []string `json:"omitted,omitempty"`
+	// Error is why summarization fell back to trimming.
+	Error string `json:"error,omitempty"`
+}
+
+// FitContext makes req fit the context window of the model it resolves to by
+// reducing the named variable, which holds bulk context such as a scanned
+// directory. Sections are kept verbatim in order while they fit in half the
+// room left, and the rest are summarized with the provider's fast model. If
+// summarizing fails, sections are trimmed at a sentence boundary instead, so
+// context is never cut mid-sentence. It returns req unchanged and a nil
+// report when the prompt already fits.
+func (s *Service) FitContext(ctx context.Context, req GenerateRequest, variable string) (GenerateRequest, *ContextReport, error) {
+	text := req.Variables[variable]
+	if strings.TrimSpace(text) == "" {
+		return req, nil, nil
+	}
+	if s == nil || s.Providers == nil || s.Registry == nil {
+		return req, nil, errors.New("ailink service not configured")
+	}
+
+	promptDef, err := s.Registry.Get(strings.TrimSpace(req.PromptSlug))
+	if err != nil {
+		return req, nil, err
+	}
+	depth := strings.TrimSpace(req.Depth)
+	if depth == "" {
+		depth = "quick"
+	}
+	role := strings.TrimSpace(req.Role)
+	if role == "" {
+		role = promptDef.Config.Slug
+	}
+	resolved, err := s.Providers.ResolveWithDepth(role, promptDef, req.Model, depth)
+	if err != nil {
+		return req, nil, err
+	}
+
+	system, user, err := renderPromptWithVars(promptDef, req.Variables, depth)
+	if err != nil {
+		return req, nil, err
+	}
+	window := resolved.Provider.ContextWindow(resolved.Model)
+	limit := window - responseReserve(window)
+	promptTokens := EstimateTokens(system) + EstimateTokens(user)
+	if promptTokens <= limit {
+		return req, nil, nil
+	}
+
+	// Room for the context once the rest of the prompt is accounted for
+	room := limit - (promptTokens - EstimateTokens(text))
+	if room <= 0 {
+		return req, nil, fmt.Errorf("prompt %s exceeds the %d-token context window of %s without any context", promptDef.Config.Slug, window, resolved.Model)
+	}
+
+	report := &ContextReport{
+		Variable:      variable,
+		Model:         resolved.Model,
+		ContextWindow: window,
+		PromptTokens:  promptTokens,
+	}
+
+	sections := splitContextSections(text)
+	var kept []contextSection
+	used := 0
+	for _, section := range sections {
+		tokens := EstimateTokens(section.text)
+		if used+tokens > room/2 {
+			break
+		}
+		kept = append(kept, section)
+		used += tokens
+	}
+	rest := sections[len(kept):]
+
+	fitted := ""
+	summary, summaryModel, sources, omitted, err := s.summarizeContext(ctx, rest, room-used-omissionNoteTokens)
+	if err == nil {
+		report.Action = ContextSummarized
+		report.SummaryModel = summaryModel
+		report.Kept = sectionNames(kept)
+		report.Summarized = sources
+		report.Omitted = omitted
+		fitted = joinContextSections(kept)
+		header := fmt.Sprintf("--- Summary of %d more sections (condensed to fit the model context window) ---\n", len(rest))
+		fitted = strings.TrimSpace(fitted + "\n\n" + header + summary)
+	} else {
+		if ctx.Err() != nil {
+			return req, nil, ctx.Err()
+		}
+		report.Action = ContextTrimmed
+		report.Error = err.Error()
+		fitted, report.Kept, report.Omitted = trimContextSections(sections, room)
+	}
+
+	vars := make(map[string]string, len(req.Variables))
+	for key, value := range req.Variables {
+		vars[key] = value
+	}
+	vars[variable] = fitted
+	req.Variables = vars
+
+	system, user, err = renderPromptWithVars(promptDef, vars, depth)
+	if err != nil {
+		return req, nil, err
+	}
+	report.FinalTokens = EstimateTokens(system) + EstimateTokens(user)
+	return req, report, nil
+}
+
+// responseReserve is the room kept free in a window for the response.
+func responseReserve(window int) int {
+	return max(window/8, minResponseTokens)
+}
+
+// summarizeContext condenses sections into about room tokens with the
+// context-summary prompt at the fast model tier. Context too long for the
+// summarizing model is trimmed first; it returns the names of the sections
+// summarized and of those dropped.
+func (s *Service) summarizeContext(ctx context.Context, sections []contextSection, room int) (summary, model string, sources, omitted []string, err error) {
+	words := min(room*3/4, maxSummaryWords)
+	if words < 50 {
+		return "", "", nil, nil, errors.New("no room left for a summary")
+	}
+
+	promptDef, err := s.Registry.Get(ContextSummaryPrompt)
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	resolved, err := s.Providers.ResolveWithDepth(ContextSummaryPrompt, promptDef, "", "fast")
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	window := resolved.Provider.ContextWindow(resolved.Model)
+	input, sources, omitted := trimContextSections(sections, window-responseReserve(window)-EstimateTokens(promptDef.Config.SystemTemplate))
+
+	response, err := s.Generate(ctx, GenerateRequest{
+		Role:       ContextSummaryPrompt,
+		PromptSlug: ContextSummaryPrompt,
+		Variables:  map[string]string{"context": input, "max_words": strconv.Itoa(words)},
+		Depth:      "fast",
+	})
+	if err != nil {
+		return "", "", nil, nil, fmt.Errorf("summarize context: %w", err)
+	}
+	var parsed struct {
+		Summary string `json:"summary"`
+	}
+	if err := json.Unmarshal(response.Raw, &parsed); err != nil {
+		return "", "", nil, nil, fmt.Errorf("parse context summary: %w", err)
+	}
+	summary = strings.TrimSpace(parsed.Summary)
+	if summary == "" {
+		return "", "", nil, nil, errors.New("empty context summary")
+	}
+	if EstimateTokens(summary) > room {
+		summary = trimToSentence(summary, room*4)
+	}
+	return summary, resolved.Model, sources, omitted, nil
+}
+
+// contextSection is one file of scanned context, or the whole context when
+// it has no file headers.
+type contextSection struct {
+	name string
+	text string
+}
+
+const contextFileHeader = "--- File: "
+
+// splitContextSections splits context on the "--- File: path (...) ---"
+// headers written by context.Gather and Corpus.ToPromptContext. Text before
+// the first header, such as a corpus manifest, is its own section.
+func splitContextSections(text string) []contextSection {
+	parts := strings.Split("\n"+text, "\n"+contextFileHeader)
+	var sections []contextSection
+	if lead := strings.TrimSpace(parts[0]); lead != "" {
+		name := "context"
+		if len(parts) > 1 {
+			name = "preamble"
+		}
+		sections = append(sections, contextSection{name: name, text: lead})
+	}
+	for _, part := range parts[1:] {
+		section := contextFileHeader + part
+		sections = append(sections, contextSection{name: sectionFile(section), text: strings.TrimSpace(section)})
+	}
+	return sections
+}
+
+// sectionFile returns the path from a section's file header.
+func sectionFile(section string) string {
+	header, _, _ := strings.Cut(strings.TrimPrefix(section, contextFileHeader), "\n")
+	header = strings.TrimSuffix(strings.TrimSpace(header), "---")
+	if idx := strings.Index(header, " ("); idx > 0 {
+		header = header[:idx]
+	}
+	return strings.TrimSpace(header)
+}
+
+func sectionNames(sections []contextSection) []string {
+	names := make([]string, 0, len(sections))
+	for _, section := range sections {
+		names = append(names, section.name)
+	}
+	return names
+}
+
+func joinContextSections(sections []contextSection) string {
+	parts := make([]string, 0, len(sections))
+	for _, section := range sections {
+		parts = append(parts, section.text)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// trimContextSections keeps whole sections in order within room tokens, cuts
+// the first section that does not fit at a sentence boundary, and drops the
+// rest. It returns the text and the names of the sections kept (in whole or
+// part) and omitted.
+func trimContextSections(sections []contextSection, room int) (string, []string, []string) {
+	var (
+		parts   []string
+		kept    []string
+		omitted []string
+		// Leave room for the omission note
+		used = omissionNoteTokens
+	)
+	for i, section := range sections {
+		// One more token for the paragraph break joining sections
+		tokens := EstimateTokens(section.text) + 1
+		if used+tokens <= room {
+			parts = append(parts, section.text)
+			kept = append(kept, section.name)
+			used += tokens
+			continue
+		}
+		rest := sections[i:]
+		if cut := trimToSentence(section.text, (room-used)*4); cut != "" {
+			parts = append(parts, cut)
+			kept = append(kept, section.name)
+			rest = rest[1:]
+		}
+		omitted = sectionNames(rest)
+		if len(omitted) > 0 {
+			parts = append(parts, fmt.Sprintf("[%d more sections omitted to fit the model context window]", len(omitted)))
+		}
+		break
+	}
+	return strings.Join(parts, "\n\n"), kept, omitted
+}
+
+// trimToSentence cuts text to at most maxChars, ending at the last sentence
+// or paragraph break, or at a word break when there is none.
+func trimToSentence(text string, maxChars int) string {
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text
+	}
+	if maxChars <= 0 {
+		return ""
+	}
+	cut := string(runes[:maxChars])
+	best := -1
+	for _, mark := range []string{". ", ".\n", "! ", "!\n", "? ", "?\n", "\n\n"} {
+		if idx := strings.LastIndex(cut, mark); idx > best {
+			best = idx
+		}
+	}
+	if best > 0 {
+		return strings.TrimSpace(cut[:best+1])
+	}
+	if idx := strings.LastIndexAny(cut, " \n\t"); idx > 0 {
+		return strings.TrimSpace(cut[:idx]) + " …"
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package ailink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/ailink/prompt"
+)
+
+type mapPromptRegistry map[string]*prompt.Prompt
+
+func (r mapPromptRegistry) Get(slug string) (*prompt.Prompt, error) {
+	if def, ok := r[slug]; ok {
+		return def, nil
+	}
+	return nil, fmt.Errorf("prompt %q not found", slug)
+}
+
+func (r mapPromptRegistry) List() []*prompt.Prompt { return nil }
+
+func contextGuardService(drv driver.Driver, window int) *Service {
+	providers := &Registry{cfg: Config{DefaultProvider: "p"}}
+	providers.cfg.Providers = map[string]ProviderInstanceConfig{
+		"p": {
+			Enabled:        true,
+			AIProvider:     "openai",
+			Models:         map[string]string{"default": "big", "fast": "small"},
+			Credentials:    []CredentialConfig{{APIKey: "k"}},
+			ContextWindows: map[string]int{"default": window, "small": 128000},
+		},
+	}
+	providers.drivers = map[string]driver.Driver{"p:p0": drv}
+
+	return &Service{Providers: providers, Registry: mapPromptRegistry{
+		"brief": {Config: prompt.Config{
+			Slug:           "brief",
+			SystemTemplate: "Name this product.\n{{description}}",
+			UserTemplate:   "go",
+		}},
+		ContextSummaryPrompt: {Config: prompt.Config{
+			Slug:           ContextSummaryPrompt,
+			SystemTemplate: "Summarize in {{max_words}} words:\n{{context}}",
+			UserTemplate:   "go",
+		}},
+	}}
+}
+
+// scannedContext formats files like context.Gather, each about tokens long.
+func scannedContext(files, tokens int) string {
+	var b strings.Builder
+	for i := 0; i < files; i++ {
+		fmt.Fprintf(&b, "--- File: doc%d.md (readme) ---\n", i)
+		b.WriteString(strings.Repeat("The tool does things. ", tokens*4/22))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+func TestContextWindow(t *testing.T) {
+	cfg := ProviderInstanceConfig{}
+	require.Equal(t, 200000, cfg.ContextWindow("claude-sonnet-4-6"))
+	require.Equal(t, 1000000, cfg.ContextWindow("gpt-4.1-mini"))
+	require.Equal(t, 128000, cfg.ContextWindow("gpt-4o"))
+	require.Equal(t, defaultContextWindow, cfg.ContextWindow("local-llama"))
+
+	cfg.ContextWindows = map[string]int{"default": 32000, "gpt-4o": 64000}
+	require.Equal(t, 64000, cfg.ContextWindow("gpt-4o"))
+	require.Equal(t, 32000, cfg.ContextWindow("claude-sonnet-4-6"))
+}
+
+func TestFitContextLeavesFittingPromptAlone(t *testing.T) {
+	drv := &scriptedDriver{}
+	svc := contextGuardService(drv, 128000)
+	req := GenerateRequest{PromptSlug: "brief", Variables: map[string]string{"description": scannedContext(3, 1000)}}
+
+	fitted, report, err := svc.FitContext(context.Background(), req, "description")
+	require.NoError(t, err)
+	require.Nil(t, report)
+	require.Equal(t, req.Variables, fitted.Variables)
+	require.Empty(t, drv.requests)
+}
+
+func TestFitContextSummarizesOverflow(t *testing.T) {
+	drv := &scriptedDriver{replies: []string{`{"summary":"A CLI for checking names.","sources":["doc1.md"]}`}}
+	svc := contextGuardService(drv, 8000)
+	text := scannedContext(6, 1000)
+	req := GenerateRequest{PromptSlug: "brief", Variables: map[string]string{"description": text}}
+
+	fitted, report, err := svc.FitContext(context.Background(), req, "description")
+	require.NoError(t, err)
+	require.NotNil(t, report)
+	require.Equal(t, ContextSummarized, report.Action)
+	require.Equal(t, "big", report.Model)
+	require.Equal(t, "small", report.SummaryModel)
+	require.Equal(t, 8000, report.ContextWindow)
+	require.Equal(t, []string{"doc0.md"}, report.Kept)
+	require.Equal(t, []string{"doc1.md", "doc2.md", "doc3.md", "doc4.md", "doc5.md"}, report.Summarized)
+	require.Greater(t, report.PromptTokens, 6000)
+	require.Less(t, report.FinalTokens, 8000-minResponseTokens)
+
+	description := fitted.Variables["description"]
+	require.True(t, strings.HasPrefix(description, "--- File: doc0.md (readme) ---"))
+	require.Contains(t, description, "--- Summary of 5 more sections")
+	require.True(t, strings.HasSuffix(description, "A CLI for checking names."))
+	require.Equal(t, text, req.Variables["description"], "the caller's variables are not modified")
+
+	require.Len(t, drv.requests, 1)
+	require.Equal(t, "small", drv.requests[0].Model)
+}
+
+func TestFitContextTrimsAtSentenceWhenSummaryFails(t *testing.T) {
+	drv := &scriptedDriver{}
+	svc := contextGuardService(drv, 8000)
+	req := GenerateRequest{PromptSlug: "brief", Variables: map[string]string{"description": scannedContext(6, 1000)}}
+
+	fitted, report, err := svc.FitContext(context.Background(), req, "description")
+	require.NoError(t, err)
+	require.Equal(t, ContextTrimmed, report.Action)
+	require.Contains(t, report.Error, "summarize context")
+	require.Equal(t, []string{"doc0.md", "doc1.md", "doc2.md", "doc3.md"}, report.Kept)
+	require.Equal(t, []string{"doc4.md", "doc5.md"}, report.Omitted)
+	require.Less(t, report.FinalTokens, 8000-minResponseTokens)
+
+	description := fitted.Variables["description"]
+	require.Contains(t, description, "things.\n\n[2 more sections omitted to fit the model context window]")
+}
+
+func TestTrimToSentence(t *testing.T) {
+	text := "First sentence here. Second sentence is longer than the rest."
+	require.Equal(t, text, trimToSentence(text, 100))
+	require.Equal(t, "First sentence here.", trimToSentence(text, 40))
+	require.Equal(t, "First …", trimToSentence("First sentenceherewithoutstops", 20))
+}
@@ -103,6 +103,7 @@ func (c *Client) Complete(ctx context.Context, req *driver.Request) (*driver.Res
 			Endpoint:    url,
 			Method:      "POST",
 			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
 			RequestBody: body,
 			Error:       err.Error(),
 			DurationMs:  duration.Milliseconds(),
@@ -118,6 +119,7 @@ func (c *Client) Complete(ctx context.Context, req *driver.Request) (*driver.Res
 			Endpoint:    url,
 			Method:      "POST",
 			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
 			RequestBody: body,
 			StatusCode:  resp.StatusCode,
 			Error:       err.Error(),
@@ -132,6 +134,7 @@ func (c *Client) Complete(ctx context.Context, req *driver.Request) (*driver.Res
 		Endpoint:    url,
 		Method:      "POST",
 		Model:       payload.Model,
+		PromptSlug:  req.PromptSlug,
 		RequestBody: body,
 		StatusCode:  resp.StatusCode,
 		Response:    respBody,
@@ -420,3 +420,22 @@ func TestExtractJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildMessagesRequestSendsTemperature(t *testing.T) {
+	temperature := 0.0
+	seed := int64(42)
+	payload, err := buildMessagesRequest(&driver.Request{
+		Model:       "claude-3-haiku-20240307",
+		Temperature: &temperature,
+		Seed:        &seed,
+		Messages: []content.Message{
+			{Role: "user", Content: []content.ContentBlock{{Type: content.ContentTypeText, Text: "hi"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `"temperature":0`)
+	require.NotContains(t, string(body), "seed")
+}
@@ -10,10 +10,11 @@ import (
 
 // messagesRequest is the request body for the /v1/messages endpoint.
 type messagesRequest struct {
-	Model     string    `json:"model"`
-	MaxTokens int       `json:"max_tokens"`
-	Messages  []message `json:"messages"`
-	System    string    `json:"system,omitempty"`
+	Model       string    `json:"model"`
+	MaxTokens   int       `json:"max_tokens"`
+	Messages    []message `json:"messages"`
+	System      string    `json:"system,omitempty"`
+	Temperature *float64  `json:"temperature,omitempty"`
 }
 
 // message represents a conversation message in Anthropic format.
@@ -87,11 +88,14 @@ func buildMessagesRequest(req *driver.Request) (*messagesRequest, error) {
 		maxTokens = *req.MaxTokens
 	}
 
+	// The Messages API has no seed parameter; Temperature is the only
+	// sampling control it takes.
 	payload := &messagesRequest{
-		Model:     req.Model,
-		MaxTokens: maxTokens,
-		Messages:  messages,
-		System:    systemText,
+		Model:       req.Model,
+		MaxTokens:   maxTokens,
+		Messages:    messages,
+		System:      systemText,
+		Temperature: req.Temperature,
 	}
 
 	return payload, nil
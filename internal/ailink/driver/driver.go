@@ -23,6 +23,14 @@ type ImageGenerator interface {
 	GenerateImage(ctx context.Context, req *ImageRequest) (*ImageResponse, error)
 }
 
+// Streamer is an optional interface implemented by drivers that can stream a
+// completion as it is generated. Stream passes each text delta to onDelta and
+// returns the assembled response; when the stream breaks off it returns the
+// text received so far along with the error.
+type Streamer interface {
+	Stream(ctx context.Context, req *Request, onDelta func(text string)) (*Response, error)
+}
+
 type ImageRequest struct {
 	Model        string
 	Prompt       string
@@ -101,9 +109,12 @@ type Request struct {
 	SearchParameters *SearchParameters
 	ResponseFormat   *ResponseFormat
 	Temperature      *float64
-	MaxTokens        *int
-	PromptSlug       string
-	Metadata         map[string]string
+	// Seed asks for reproducible sampling; drivers whose API has no seed
+	// ignore it
+	Seed       *int64
+	MaxTokens  *int
+	PromptSlug string
+	Metadata   map[string]string
 }
 
 // Response is a provider-agnostic completion response.
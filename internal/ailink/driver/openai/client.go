@@ -100,6 +100,7 @@ func (c *Client) Complete(ctx context.Context, req *driver.Request) (*driver.Res
 			Endpoint:    url,
 			Method:      "POST",
 			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
 			RequestBody: body,
 			Error:       err.Error(),
 			DurationMs:  duration.Milliseconds(),
@@ -115,6 +116,7 @@ func (c *Client) Complete(ctx context.Context, req *driver.Request) (*driver.Res
 			Endpoint:    url,
 			Method:      "POST",
 			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
 			RequestBody: body,
 			StatusCode:  resp.StatusCode,
 			Error:       err.Error(),
@@ -129,6 +131,7 @@ func (c *Client) Complete(ctx context.Context, req *driver.Request) (*driver.Res
 		Endpoint:    url,
 		Method:      "POST",
 		Model:       payload.Model,
+		PromptSlug:  req.PromptSlug,
 		RequestBody: body,
 		StatusCode:  resp.StatusCode,
 		Response:    respBody,
@@ -92,3 +92,22 @@ func TestClientErrorsOnNon2xx(t *testing.T) {
 	require.Equal(t, 401, perr.StatusCode)
 	require.Contains(t, perr.Message, "nope")
 }
+
+func TestBuildChatRequestSendsSeedAndTemperature(t *testing.T) {
+	temperature := 0.0
+	seed := int64(42)
+	payload, err := buildChatRequest(&driver.Request{
+		Model:       "gpt-4o",
+		Temperature: &temperature,
+		Seed:        &seed,
+		Messages: []content.Message{
+			{Role: "user", Content: []content.ContentBlock{{Type: content.ContentTypeText, Text: "hi"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `"temperature":0`)
+	require.Contains(t, string(body), `"seed":42`)
+}
@@ -14,6 +14,7 @@ type chatCompletionRequest struct {
 	Tools          []map[string]any `json:"tools,omitempty"`
 	ResponseFormat *responseFormat  `json:"response_format,omitempty"`
 	Temperature    *float64         `json:"temperature,omitempty"`
+	Seed           *int64           `json:"seed,omitempty"`
 	MaxTokens      *int             `json:"max_tokens,omitempty"`
 }
 
@@ -60,6 +61,7 @@ func buildChatRequest(req *driver.Request) (*chatCompletionRequest, error) {
 		Messages:       messages,
 		Tools:          flattenTools(req.Tools),
 		Temperature:    req.Temperature,
+		Seed:           req.Seed,
 		MaxTokens:      req.MaxTokens,
 		ResponseFormat: nil,
 	}
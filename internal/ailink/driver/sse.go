@@ -0,0 +1,47 @@
+package driver
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxSSELine bounds a single server-sent event line; completion chunks are
+// far smaller.
+const maxSSELine = 1 << 20
+
+// ReadSSE calls fn with the data of each server-sent event in r until the
+// stream ends or fn returns an error. Multi-line data fields are joined with
+// newlines; comments, event names, and ids are ignored.
+func ReadSSE(r io.Reader, fn func(data string) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELine)
+
+	var data []string
+	dispatch := func() error {
+		if len(data) == 0 {
+			return nil
+		}
+		event := strings.Join(data, "\n")
+		data = data[:0]
+		return fn(event)
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			if err := dispatch(); err != nil {
+				return err
+			}
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		if field == "data" {
+			data = append(data, strings.TrimPrefix(value, " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return dispatch()
+}
@@ -0,0 +1,25 @@
+package driver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSSE(t *testing.T) {
+	stream := ": keep-alive\r\n\r\nevent: chunk\ndata: {\"a\":1}\n\ndata: line one\ndata: line two\n\ndata: [DONE]"
+
+	var events []string
+	err := ReadSSE(strings.NewReader(stream), func(data string) error {
+		events = append(events, data)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{`{"a":1}`, "line one\nline two", "[DONE]"}, events)
+
+	stop := errors.New("stop")
+	err = ReadSSE(strings.NewReader("data: a\n\ndata: b\n\n"), func(data string) error { return stop })
+	require.ErrorIs(t, err, stop)
+}
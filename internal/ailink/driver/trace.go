@@ -2,10 +2,14 @@ package driver
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sync"
 	"time"
+
+	"github.com/namelens/namelens/internal/redact"
 )
 
 // TraceEntry represents a single request/response trace entry.
@@ -15,6 +19,7 @@ type TraceEntry struct {
 	Endpoint    string          `json:"endpoint"`
 	Method      string          `json:"method"`
 	Model       string          `json:"model,omitempty"`
+	PromptSlug  string          `json:"prompt_slug,omitempty"`
 	RequestBody json.RawMessage `json:"request_body,omitempty"`
 	StatusCode  int             `json:"status_code,omitempty"`
 	Response    json.RawMessage `json:"response,omitempty"`
@@ -96,6 +101,10 @@ func (t *Tracer) Write(entry TraceEntry) {
 	if entry.Timestamp.IsZero() {
 		entry.Timestamp = time.Now()
 	}
+	entry.Endpoint = redact.String(entry.Endpoint)
+	entry.RequestBody = redact.JSON(entry.RequestBody)
+	entry.Response = redact.JSON(entry.Response)
+	entry.Error = redact.String(entry.Error)
 
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -108,6 +117,22 @@ func (t *Tracer) Write(entry TraceEntry) {
 	_, _ = t.file.Write([]byte("\n"))
 }
 
+// ReadTrace parses the NDJSON entries written by a Tracer.
+func ReadTrace(r io.Reader) ([]TraceEntry, error) {
+	var entries []TraceEntry
+	decoder := json.NewDecoder(r)
+	for {
+		var entry TraceEntry
+		if err := decoder.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return nil, fmt.Errorf("trace entry %d: %w", len(entries)+1, err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
 // Sync flushes any buffered data to the trace file.
 func (t *Tracer) Sync() error {
 	if t == nil || t.file == nil {
@@ -0,0 +1,60 @@
+package driver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracerRedactsSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.ndjson")
+	cleanup, err := EnableTracing(path)
+	require.NoError(t, err)
+
+	Trace(TraceEntry{
+		Driver:      "openai",
+		Endpoint:    "https://api.example.com/v1/chat?api_key=abc123456",
+		Method:      "POST",
+		RequestBody: json.RawMessage(`{"api_key":"plain","messages":[{"content":"hi"}]}`),
+		Response:    json.RawMessage(`{"error":"Incorrect API key provided: sk-proj-abcdefghijklmnop1234"}`),
+		Error:       "401: Bearer abcdefghijklmnopqrstuvwxyz0123 rejected",
+	})
+	cleanup()
+
+	data, err := os.ReadFile(path) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	trace := string(data)
+	require.NotContains(t, trace, "abc123456")
+	require.NotContains(t, trace, "plain")
+	require.NotContains(t, trace, "sk-proj-abcdefghijklmnop1234")
+	require.NotContains(t, trace, "abcdefghijklmnopqrstuvwxyz0123")
+	require.Contains(t, trace, "[REDACTED]")
+	require.Contains(t, trace, `"content":"hi"`)
+}
+
+func TestReadTraceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.ndjson")
+	cleanup, err := EnableTracing(path)
+	require.NoError(t, err)
+	Trace(TraceEntry{Driver: "xai", PromptSlug: "name-availability", StatusCode: 200, Response: json.RawMessage(`{"id":"1"}`)})
+	Trace(TraceEntry{Driver: "openai", Error: "connection refused"})
+	cleanup()
+
+	f, err := os.Open(path) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+
+	entries, err := ReadTrace(f)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "name-availability", entries[0].PromptSlug)
+	require.JSONEq(t, `{"id":"1"}`, string(entries[0].Response))
+	require.Equal(t, "connection refused", entries[1].Error)
+
+	_, err = ReadTrace(strings.NewReader("{\"driver\":\"xai\"}\nnot json\n"))
+	require.ErrorContains(t, err, "trace entry 2")
+}
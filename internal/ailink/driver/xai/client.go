@@ -46,7 +46,7 @@ func (c *Client) Capabilities() driver.Capabilities {
 	return driver.Capabilities{
 		SupportsTools:     true,
 		SupportsImages:    true,
-		SupportsStreaming: false,
+		SupportsStreaming: true,
 	}
 }
 
@@ -108,6 +108,7 @@ func (c *Client) completeWithResponses(ctx context.Context, req *driver.Request)
 			Endpoint:    url,
 			Method:      "POST",
 			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
 			RequestBody: body,
 			Error:       err.Error(),
 			DurationMs:  duration.Milliseconds(),
@@ -127,6 +128,7 @@ func (c *Client) completeWithResponses(ctx context.Context, req *driver.Request)
 		Endpoint:    url,
 		Method:      "POST",
 		Model:       payload.Model,
+		PromptSlug:  req.PromptSlug,
 		RequestBody: body,
 		StatusCode:  resp.StatusCode,
 		Response:    respBody,
@@ -181,6 +183,7 @@ func (c *Client) completeWithChat(ctx context.Context, req *driver.Request) (*dr
 			Endpoint:    url,
 			Method:      "POST",
 			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
 			RequestBody: body,
 			Error:       err.Error(),
 			DurationMs:  duration.Milliseconds(),
@@ -200,6 +203,7 @@ func (c *Client) completeWithChat(ctx context.Context, req *driver.Request) (*dr
 		Endpoint:    url,
 		Method:      "POST",
 		Model:       payload.Model,
+		PromptSlug:  req.PromptSlug,
 		RequestBody: body,
 		StatusCode:  resp.StatusCode,
 		Response:    respBody,
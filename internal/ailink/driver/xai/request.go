@@ -14,14 +14,22 @@ type chatCompletionRequest struct {
 	Messages       []chatMessage   `json:"messages"`
 	ResponseFormat *responseFormat `json:"response_format,omitempty"`
 	Temperature    *float64        `json:"temperature,omitempty"`
+	Seed           *int64          `json:"seed,omitempty"`
 	MaxTokens      *int            `json:"max_tokens,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+	StreamOptions  *streamOptions  `json:"stream_options,omitempty"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
 }
 
 // responsesAPIRequest is for the new /v1/responses endpoint (with tools).
 type responsesAPIRequest struct {
-	Model string          `json:"model"`
-	Input []inputMessage  `json:"input"`
-	Tools []responsesTool `json:"tools,omitempty"`
+	Model       string          `json:"model"`
+	Input       []inputMessage  `json:"input"`
+	Tools       []responsesTool `json:"tools,omitempty"`
+	Temperature *float64        `json:"temperature,omitempty"`
 }
 
 type inputMessage struct {
@@ -88,9 +96,10 @@ func buildResponsesRequest(req *driver.Request) (*responsesAPIRequest, error) {
 	}
 
 	payload := &responsesAPIRequest{
-		Model: req.Model,
-		Input: input,
-		Tools: tools,
+		Model:       req.Model,
+		Input:       input,
+		Tools:       tools,
+		Temperature: req.Temperature,
 	}
 
 	return payload, nil
@@ -113,6 +122,7 @@ func buildChatRequest(req *driver.Request) (*chatCompletionRequest, error) {
 		Model:       req.Model,
 		Messages:    messages,
 		Temperature: req.Temperature,
+		Seed:        req.Seed,
 		MaxTokens:   req.MaxTokens,
 	}
 	if req.ResponseFormat != nil {
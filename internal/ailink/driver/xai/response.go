@@ -57,6 +57,18 @@ type usage struct {
 	TotalTokens      int `json:"total_tokens"`
 }
 
+// chatCompletionChunk is one server-sent event of a streamed chat completion.
+// The last chunk carries usage when stream_options.include_usage is set.
+type chatCompletionChunk struct {
+	Choices []chunkChoice `json:"choices"`
+	Usage   *usage        `json:"usage,omitempty"`
+}
+
+type chunkChoice struct {
+	Delta        chatResponseMessage `json:"delta"`
+	FinishReason *string             `json:"finish_reason"`
+}
+
 // responsesAPIResponse is for the new /v1/responses endpoint.
 type responsesAPIResponse struct {
 	ID     string          `json:"id"`
@@ -0,0 +1,209 @@
+package xai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/namelens/namelens/internal/ailink/content"
+	"github.com/namelens/namelens/internal/ailink/driver"
+)
+
+const streamDone = "[DONE]"
+
+// Stream sends a chat completion request with stream enabled and passes each
+// content delta to onDelta. Tool-enabled requests use /v1/responses, which is
+// not streamed; their full text is passed to onDelta once.
+func (c *Client) Stream(ctx context.Context, req *driver.Request, onDelta func(text string)) (*driver.Response, error) {
+	if c == nil {
+		return nil, fmt.Errorf("xai client not configured")
+	}
+	if strings.TrimSpace(c.APIKey) == "" {
+		return nil, fmt.Errorf("api key is required")
+	}
+	if onDelta == nil {
+		onDelta = func(string) {}
+	}
+
+	ctx, cancel := withTimeout(ctx, c.Timeout)
+	if cancel != nil {
+		defer cancel()
+	}
+
+	if useResponsesAPI(req) {
+		resp, err := c.completeWithResponses(ctx, req)
+		if err == nil && resp != nil {
+			onDelta(responseText(resp))
+		}
+		return resp, err
+	}
+	return c.streamWithChat(ctx, req, onDelta)
+}
+
+func (c *Client) streamWithChat(ctx context.Context, req *driver.Request, onDelta func(text string)) (*driver.Response, error) {
+	payload, err := buildChatRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	payload.Stream = true
+	payload.StreamOptions = &streamOptions{IncludeUsage: true}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode request: %w", err)
+	}
+
+	url := strings.TrimRight(c.BaseURL, "/") + "/chat/completions"
+	start := time.Now()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+c.APIKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "text/event-stream")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		driver.Trace(driver.TraceEntry{
+			Driver:      "xai",
+			Endpoint:    url,
+			Method:      "POST",
+			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
+			RequestBody: body,
+			Error:       err.Error(),
+			DurationMs:  time.Since(start).Milliseconds(),
+		})
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close() // nolint:errcheck // best-effort cleanup
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(resp.Body)
+		driver.Trace(driver.TraceEntry{
+			Driver:      "xai",
+			Endpoint:    url,
+			Method:      "POST",
+			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
+			RequestBody: body,
+			StatusCode:  resp.StatusCode,
+			Response:    respBody,
+			DurationMs:  time.Since(start).Milliseconds(),
+		})
+		return nil, &driver.ProviderError{Provider: "xai", StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody)), RawResponse: respBody}
+	}
+
+	// Endpoints that ignore stream, such as some proxies, answer with a
+	// regular completion.
+	if !strings.Contains(resp.Header.Get("Content-Type"), "text/event-stream") {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("read response: %w", err)
+		}
+		driver.Trace(driver.TraceEntry{
+			Driver:      "xai",
+			Endpoint:    url,
+			Method:      "POST",
+			Model:       payload.Model,
+			PromptSlug:  req.PromptSlug,
+			RequestBody: body,
+			StatusCode:  resp.StatusCode,
+			Response:    respBody,
+			DurationMs:  time.Since(start).Milliseconds(),
+		})
+		var parsed chatCompletionResponse
+		if err := json.Unmarshal(respBody, &parsed); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+		response, err := toDriverResponse(&parsed)
+		if err == nil {
+			onDelta(responseText(response))
+		}
+		return response, err
+	}
+
+	// Assemble the chunks into the response the non-streaming endpoint
+	// would have returned, so traces and callers see the same shape.
+	var (
+		text      strings.Builder
+		assembled = chatCompletionResponse{Choices: []choice{{}}}
+	)
+	streamErr := driver.ReadSSE(resp.Body, func(data string) error {
+		if strings.TrimSpace(data) == streamDone {
+			return nil
+		}
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("decode stream chunk: %w", err)
+		}
+		if chunk.Usage != nil {
+			assembled.Usage = chunk.Usage
+		}
+		for _, delta := range chunk.Choices {
+			if delta.Delta.Content != "" {
+				text.WriteString(delta.Delta.Content)
+				onDelta(delta.Delta.Content)
+			}
+			assembled.Choices[0].Message.ToolCalls = append(assembled.Choices[0].Message.ToolCalls, delta.Delta.ToolCalls...)
+			if delta.FinishReason != nil {
+				assembled.Choices[0].FinishReason = *delta.FinishReason
+			}
+		}
+		return nil
+	})
+	assembled.Choices[0].Message.Content = text.String()
+
+	entry := driver.TraceEntry{
+		Driver:      "xai",
+		Endpoint:    url,
+		Method:      "POST",
+		Model:       payload.Model,
+		PromptSlug:  req.PromptSlug,
+		RequestBody: body,
+		StatusCode:  resp.StatusCode,
+		DurationMs:  time.Since(start).Milliseconds(),
+	}
+	if encoded, err := json.Marshal(assembled); err == nil {
+		entry.Response = encoded
+	}
+	if streamErr != nil {
+		entry.Error = streamErr.Error()
+	}
+	driver.Trace(entry)
+
+	response, err := toDriverResponse(&assembled)
+	if err != nil {
+		return nil, err
+	}
+	if streamErr != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			streamErr = ctxErr
+		}
+		return response, fmt.Errorf("read stream: %w", streamErr)
+	}
+	return response, nil
+}
+
+func responseText(resp *driver.Response) string {
+	var parts []string
+	for _, block := range resp.Content {
+		if block.Type == content.ContentTypeText {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,86 @@
+package xai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink/content"
+	"github.com/namelens/namelens/internal/ailink/driver"
+)
+
+func streamRequest() *driver.Request {
+	return &driver.Request{
+		Model:    "grok-4",
+		Messages: []content.Message{{Role: "user", Content: []content.ContentBlock{{Type: content.ContentTypeText, Text: "hi"}}}},
+	}
+}
+
+func TestClientStreamAssemblesChunks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/chat/completions", r.URL.Path)
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		require.Equal(t, true, payload["stream"])
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []string{
+			`{"choices":[{"delta":{"content":"{\"summary\":"}}]}`,
+			`{"choices":[{"delta":{"content":"\"ok\"}"},"finish_reason":"stop"}]}`,
+			`{"choices":[],"usage":{"prompt_tokens":5,"completion_tokens":3,"total_tokens":8}}`,
+			`[DONE]`,
+		} {
+			_, _ = w.Write([]byte("data: " + event + "\n\n"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.HTTPClient = server.Client()
+
+	var deltas []string
+	resp, err := client.Stream(context.Background(), streamRequest(), func(text string) { deltas = append(deltas, text) })
+	require.NoError(t, err)
+	require.Equal(t, []string{`{"summary":`, `"ok"}`}, deltas)
+	require.Equal(t, `{"summary":"ok"}`, resp.Content[0].Text)
+	require.Equal(t, "stop", resp.FinishReason)
+	require.Equal(t, 8, resp.Usage.TotalTokens)
+}
+
+func TestClientStreamAcceptsNonStreamingReply(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"{\"summary\":\"ok\"}"},"finish_reason":"stop"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.HTTPClient = server.Client()
+
+	var received strings.Builder
+	resp, err := client.Stream(context.Background(), streamRequest(), func(text string) { received.WriteString(text) })
+	require.NoError(t, err)
+	require.Equal(t, `{"summary":"ok"}`, resp.Content[0].Text)
+	require.Equal(t, `{"summary":"ok"}`, received.String())
+}
+
+func TestClientStreamReportsProviderErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"bad key"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.HTTPClient = server.Client()
+
+	_, err := client.Stream(context.Background(), streamRequest(), nil)
+	var perr *driver.ProviderError
+	require.ErrorAs(t, err, &perr)
+	require.Equal(t, http.StatusUnauthorized, perr.StatusCode)
+}
@@ -36,9 +36,13 @@ func StandaloneSchemasRoot() (string, error) {
 	return standaloneSchemasRootDir()
 }
 
-// CleanupStandaloneSchemas removes temporary schema assets written to disk.
-// The sync.Once guard is reset so subsequent calls re-extract if needed.
+// CleanupStandaloneSchemas removes temporary schema assets written to disk,
+// including layered catalogs with user schemas. The sync.Once guard is reset
+// so subsequent calls re-extract if needed.
 func CleanupStandaloneSchemas() error {
+	if err := cleanupLayeredSchemas(); err != nil {
+		return err
+	}
 	if standaloneSchemasRoot != "" {
 		if err := os.RemoveAll(standaloneSchemasRoot); err != nil {
 			return fmt.Errorf("cleanup standalone schema temp dir: %w", err)
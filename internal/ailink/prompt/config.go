@@ -37,4 +37,26 @@ type ToolConfig struct {
 type Prompt struct {
 	Config Config
 	Source string
+	// Embedded is true for the prompts built into the binary and false for
+	// prompts loaded from prompts_dir.
+	Embedded bool
+}
+
+// MaxRepairAttempts caps response_options.repair_attempts.
+const MaxRepairAttempts = 3
+
+// RepairAttempts returns response_options.repair_attempts: how many times a
+// response that fails response_schema validation is sent back to the model
+// for correction. It is 0 when unset and capped at MaxRepairAttempts.
+func (c Config) RepairAttempts() int {
+	var attempts int
+	switch value := c.ResponseOpts["repair_attempts"].(type) {
+	case int:
+		attempts = value
+	case int64:
+		attempts = int(value)
+	case float64:
+		attempts = int(value)
+	}
+	return max(0, min(attempts, MaxRepairAttempts))
 }
@@ -3,6 +3,7 @@ package prompt
 import (
 	"embed"
 	"fmt"
+	"strings"
 )
 
 //go:embed prompts/*.md
@@ -27,6 +28,7 @@ func LoadDefaults() ([]*Prompt, error) {
 		if err != nil {
 			return nil, err
 		}
+		prompt.Embedded = true
 		results = append(results, prompt)
 	}
 	return results, nil
@@ -40,3 +42,52 @@ func DefaultRegistry() (Registry, error) {
 	}
 	return NewRegistry(prompts)
 }
+
+// LoadLayered loads the embedded prompt set with the prompts of overrideDir
+// layered on top: an override whose slug matches an embedded prompt shadows
+// it, and other slugs are added. An empty overrideDir uses the embedded set.
+func LoadLayered(overrideDir string) ([]*Prompt, error) {
+	defaults, err := LoadDefaults()
+	if err != nil {
+		return nil, err
+	}
+	overrideDir = strings.TrimSpace(overrideDir)
+	if overrideDir == "" {
+		return defaults, nil
+	}
+	overrides, err := LoadFromDir(overrideDir)
+	if err != nil {
+		return nil, err
+	}
+
+	bySlug := make(map[string]int, len(defaults)+len(overrides))
+	results := make([]*Prompt, 0, len(defaults)+len(overrides))
+	for _, prompt := range defaults {
+		bySlug[prompt.Config.Slug] = len(results)
+		results = append(results, prompt)
+	}
+	overridden := make(map[string]string, len(overrides))
+	for _, prompt := range overrides {
+		slug := prompt.Config.Slug
+		if previous, ok := overridden[slug]; ok {
+			return nil, fmt.Errorf("duplicate prompt slug %s in %s and %s", slug, previous, prompt.Source)
+		}
+		overridden[slug] = prompt.Source
+		if i, ok := bySlug[slug]; ok {
+			results[i] = prompt
+			continue
+		}
+		bySlug[slug] = len(results)
+		results = append(results, prompt)
+	}
+	return results, nil
+}
+
+// LayeredRegistry builds a registry from LoadLayered.
+func LayeredRegistry(overrideDir string) (Registry, error) {
+	prompts, err := LoadLayered(overrideDir)
+	if err != nil {
+		return nil, err
+	}
+	return NewRegistry(prompts)
+}
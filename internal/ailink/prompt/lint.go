@@ -0,0 +1,163 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Lint severities. Errors break the prompt at run time; warnings are likely
+// mistakes.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// LintIssue is a problem found in a prompt definition.
+type LintIssue struct {
+	Source   string `json:"source"`
+	Slug     string `json:"slug,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// SchemaResolver reports whether a response_schema $ref resolves, returning
+// nil when it does.
+type SchemaResolver func(ref string) error
+
+// builtinVariables are set by the renderer for every prompt.
+var builtinVariables = map[string]bool{"input": true}
+
+// templateTagPattern matches {{var}} and {{#if var}} tags.
+var templateTagPattern = regexp.MustCompile(`\{\{\s*(#if\s+)?([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// Lint checks a loaded prompt: required variables must be used by a
+// template, templates should only use declared variables, {{#if}} blocks must
+// be closed, and a response_schema $ref must resolve. A nil resolve skips the
+// $ref check.
+func Lint(p *Prompt, resolve SchemaResolver) []LintIssue {
+	if p == nil {
+		return nil
+	}
+	cfg := p.Config
+	var issues []LintIssue
+	add := func(severity, format string, args ...any) {
+		issues = append(issues, LintIssue{Source: p.Source, Slug: cfg.Slug, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	templates := map[string]string{
+		"system_template": cfg.SystemTemplate,
+		"user_template":   cfg.UserTemplate,
+	}
+	for depth, variant := range cfg.DepthVariants {
+		templates["depth_variants."+depth] = variant
+	}
+
+	used := map[string]bool{}
+	for _, name := range sortedKeys(templates) {
+		text := templates[name]
+		for _, variable := range TemplateVariables(text) {
+			used[variable] = true
+		}
+		if opens, closes := strings.Count(text, "{{#if"), strings.Count(text, "{{/if}}"); opens != closes {
+			add(LintError, "%s has %d {{#if}} and %d {{/if}} tags", name, opens, closes)
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, variable := range cfg.Input.RequiredVariables {
+		declared[variable] = true
+		if !used[variable] {
+			add(LintError, "required variable %q is not used by any template", variable)
+		}
+	}
+	for _, variable := range cfg.Input.OptionalVariables {
+		declared[variable] = true
+	}
+	for _, variable := range sortedKeys(used) {
+		if !declared[variable] && !builtinVariables[variable] {
+			add(LintWarning, "template variable %q is not declared in input", variable)
+		}
+	}
+
+	if ref, ok := cfg.ResponseSchema["$ref"].(string); ok && resolve != nil {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			add(LintError, "response_schema $ref is empty")
+		} else if err := resolve(ref); err != nil {
+			add(LintError, "response_schema $ref %q does not resolve: %v", ref, err)
+		}
+	}
+	return issues
+}
+
+// LintDir loads and lints every prompt file of dir. Files that fail to parse
+// or validate against the prompt schema are reported as errors, as are slugs
+// used by more than one file.
+func LintDir(dir string, resolve SchemaResolver) ([]*Prompt, []LintIssue, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("prompts dir: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("prompts dir %s is not a directory", dir)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("scan prompts: %w", err)
+	}
+
+	var (
+		prompts []*Prompt
+		issues  []LintIssue
+	)
+	seen := map[string]string{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path) // #nosec G304 -- Prompt path is user-provided
+		if err != nil {
+			return nil, nil, fmt.Errorf("read prompt %s: %w", path, err)
+		}
+		p, err := Load(path, data)
+		if err != nil {
+			issues = append(issues, LintIssue{Source: path, Severity: LintError, Message: err.Error()})
+			continue
+		}
+		if previous, ok := seen[p.Config.Slug]; ok {
+			issues = append(issues, LintIssue{Source: path, Slug: p.Config.Slug, Severity: LintError, Message: "slug also used by " + previous})
+		}
+		seen[p.Config.Slug] = path
+		prompts = append(prompts, p)
+		issues = append(issues, Lint(p, resolve)...)
+	}
+	return prompts, issues, nil
+}
+
+// TemplateVariables returns the variables a template references, in order of
+// first use.
+func TemplateVariables(template string) []string {
+	var variables []string
+	seen := map[string]bool{}
+	for _, match := range templateTagPattern.FindAllStringSubmatch(template, -1) {
+		name := match[2]
+		if match[1] == "" && name == "else" {
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			variables = append(variables, name)
+		}
+	}
+	return variables
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
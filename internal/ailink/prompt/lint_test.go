@@ -0,0 +1,64 @@
+package prompt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateVariables(t *testing.T) {
+	vars := TemplateVariables("Name: {{name}} {{#if locales}}in {{ locales }}{{else}}anywhere{{/if}} {{name}}")
+	require.Equal(t, []string{"name", "locales"}, vars)
+}
+
+func TestLint(t *testing.T) {
+	p := &Prompt{Source: "x.md", Config: Config{
+		Slug:           "x",
+		Input:          InputSpec{RequiredVariables: []string{"name", "concept"}},
+		SystemTemplate: "Check {{name}}. {{#if extra}}More: {{extra}}",
+		ResponseSchema: map[string]any{"$ref": "ailink/v0/missing"},
+	}}
+	resolve := func(ref string) error { return errors.New("not found") }
+
+	issues := Lint(p, resolve)
+	messages := map[string]string{}
+	for _, issue := range issues {
+		messages[issue.Message] = issue.Severity
+	}
+	require.Equal(t, LintError, messages[`system_template has 1 {{#if}} and 0 {{/if}} tags`])
+	require.Equal(t, LintError, messages[`required variable "concept" is not used by any template`])
+	require.Equal(t, LintWarning, messages[`template variable "extra" is not declared in input`])
+	require.Equal(t, LintError, messages[`response_schema $ref "ailink/v0/missing" does not resolve: not found`])
+	require.Len(t, issues, 4)
+}
+
+func TestLintEmbeddedPromptsClean(t *testing.T) {
+	prompts, err := LoadDefaults()
+	require.NoError(t, err)
+	for _, p := range prompts {
+		require.Empty(t, Lint(p, nil), p.Config.Slug)
+	}
+}
+
+func TestLintDirReportsInvalidAndDuplicateFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o600))
+	}
+	write("a.md", "---\nslug: dup\ninput:\n  required_variables: [name]\n---\nCheck {{name}}\n")
+	write("b.md", "---\nslug: dup\n---\nHello\n")
+	write("c.md", "bad: [")
+
+	prompts, issues, err := LintDir(dir, nil)
+	require.NoError(t, err)
+	require.Len(t, prompts, 2)
+	require.Len(t, issues, 2)
+	require.Contains(t, issues[0].Message, "slug also used by")
+	require.Equal(t, filepath.Join(dir, "c.md"), issues[1].Source)
+
+	_, _, err = LintDir(filepath.Join(dir, "missing"), nil)
+	require.Error(t, err)
+}
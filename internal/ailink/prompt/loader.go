@@ -49,8 +49,17 @@ func Load(source string, data []byte) (*Prompt, error) {
 	return &Prompt{Config: config, Source: source}, nil
 }
 
-// LoadFromDir reads all prompt files (.md with YAML frontmatter) from a directory.
+// LoadFromDir reads all prompt files (.md with YAML frontmatter) from a
+// directory, which must exist so a mistyped prompts_dir is not silently
+// ignored.
 func LoadFromDir(dir string) ([]*Prompt, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("prompts dir: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("prompts dir %s is not a directory", dir)
+	}
 	entries, err := filepath.Glob(filepath.Join(dir, "*.md"))
 	if err != nil {
 		return nil, fmt.Errorf("scan prompts: %w", err)
@@ -141,10 +150,17 @@ func validateConfig(cfg Config) error {
 	return nil
 }
 
+// promptSchemaFile is the prompt schema within a repository. A repository
+// root without it is not a namelens checkout.
+const promptSchemaFile = "schemas/ailink/v0/prompt.schema.json"
+
 func catalogForSchemas() (*schema.Catalog, error) {
 	root, err := findRepoRoot()
 	if err == nil {
-		return schema.NewCatalog(filepath.Join(root, "schemas")), nil
+		if _, statErr := os.Stat(filepath.Join(root, promptSchemaFile)); statErr == nil {
+			return schema.NewCatalog(filepath.Join(root, "schemas")), nil
+		}
+		err = fmt.Errorf("%s has no %s", root, promptSchemaFile)
 	}
 
 	fallback, fallbackErr := standaloneSchemaRoot()
@@ -2,6 +2,7 @@ package prompt
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -36,6 +37,25 @@ func TestCatalogForSchemasFallsBackToEmbedded(t *testing.T) {
 	require.NotNil(t, catalog)
 }
 
+func TestCatalogForSchemasIgnoresOtherRepository(t *testing.T) {
+	home := t.TempDir()
+	project := filepath.Join(home, "project")
+	require.NoError(t, os.MkdirAll(filepath.Join(project, ".git"), 0o755))
+	t.Setenv("HOME", home)
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(project))
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		_ = CleanupStandaloneSchemas()
+	})
+
+	catalog, err := catalogForSchemas()
+	require.NoError(t, err)
+	_, err = catalog.GetSchema(promptSchemaID)
+	require.NoError(t, err, "prompt schema should come from the embedded fallback")
+}
+
 func TestLoadDefaultsOutsideRepo(t *testing.T) {
 	// Verify that LoadDefaults works from a directory with no .git or go.mod.
 	origDir, err := os.Getwd()
@@ -65,3 +85,66 @@ func TestBrandPlanPromptUsesBrandPlanResponseSchema(t *testing.T) {
 	ref, _ := brandPlan.Config.ResponseSchema["$ref"].(string)
 	require.Equal(t, "ailink/v0/brand-plan-response", ref)
 }
+
+func writePrompt(t *testing.T, dir, file, slug, body string) {
+	t.Helper()
+	data := "---\nslug: " + slug + "\ndescription: custom\n---\n\n" + body + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(data), 0o600))
+}
+
+func TestLoadLayeredShadowsEmbeddedBySlug(t *testing.T) {
+	dir := t.TempDir()
+	writePrompt(t, dir, "availability.md", "name-availability", "Custom availability prompt.")
+	writePrompt(t, dir, "team-review.md", "team-review", "Team review prompt.")
+
+	reg, err := LayeredRegistry(dir)
+	require.NoError(t, err)
+
+	shadowed, err := reg.Get("name-availability")
+	require.NoError(t, err)
+	require.False(t, shadowed.Embedded)
+	require.Equal(t, "Custom availability prompt.", shadowed.Config.SystemTemplate)
+
+	added, err := reg.Get("team-review")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "team-review.md"), added.Source)
+
+	embedded, err := reg.Get("name-phonetics")
+	require.NoError(t, err)
+	require.True(t, embedded.Embedded)
+
+	defaults, err := LoadDefaults()
+	require.NoError(t, err)
+	require.Len(t, reg.List(), len(defaults)+1)
+}
+
+func TestLoadLayeredRejectsBadOverrideDirs(t *testing.T) {
+	_, err := LoadLayered(filepath.Join(t.TempDir(), "missing"))
+	require.ErrorContains(t, err, "prompts dir")
+
+	dir := t.TempDir()
+	writePrompt(t, dir, "a.md", "team-review", "A.")
+	writePrompt(t, dir, "b.md", "team-review", "B.")
+	_, err = LoadLayered(dir)
+	require.ErrorContains(t, err, "duplicate prompt slug team-review")
+
+	prompts, err := LoadLayered("")
+	require.NoError(t, err)
+	require.NotEmpty(t, prompts)
+}
+
+func TestReviewPromptsAllowOneRepair(t *testing.T) {
+	prompts, err := LoadDefaults()
+	require.NoError(t, err)
+	reg, err := NewRegistry(prompts)
+	require.NoError(t, err)
+
+	for _, slug := range []string{"name-availability", "name-phonetics", "name-suitability", "brand-proposal", "brand-plan"} {
+		prompt, err := reg.Get(slug)
+		require.NoError(t, err)
+		require.Equal(t, 1, prompt.Config.RepairAttempts(), slug)
+	}
+
+	require.Equal(t, MaxRepairAttempts, Config{ResponseOpts: map[string]any{"repair_attempts": 9}}.RepairAttempts())
+	require.Equal(t, 0, Config{}.RepairAttempts())
+}
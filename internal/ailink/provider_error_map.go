@@ -3,15 +3,33 @@ package ailink
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/redact"
 )
 
 func MapProviderError(err error) *SearchError {
 	if err == nil {
 		return nil
 	}
+	var quotaErr *QuotaExceededError
+	if errors.As(err, &quotaErr) {
+		return &SearchError{Code: "AILINK_QUOTA_EXCEEDED", Message: quotaErr.Error()}
+	}
+	var budgetErr *BudgetExceededError
+	if errors.As(err, &budgetErr) {
+		return &SearchError{Code: "AILINK_BUDGET_EXCEEDED", Message: budgetErr.Error()}
+	}
+	var partialErr *PartialResponseError
+	if errors.As(err, &partialErr) && errors.Is(err, context.DeadlineExceeded) {
+		details := fmt.Sprintf("received %d bytes", partialErr.Bytes)
+		if len(partialErr.Fields) > 0 {
+			details += " with fields " + strings.Join(partialErr.Fields, ", ")
+		}
+		return &SearchError{Code: "AILINK_PROVIDER_TIMEOUT", Message: "provider request timed out mid-response", Details: details}
+	}
 	if errors.Is(err, context.DeadlineExceeded) {
 		return &SearchError{Code: "AILINK_PROVIDER_TIMEOUT", Message: "provider request timed out"}
 	}
@@ -19,7 +37,7 @@ func MapProviderError(err error) *SearchError {
 	var perr *driver.ProviderError
 	if errors.As(err, &perr) && perr != nil {
 		status := perr.StatusCode
-		details := strings.TrimSpace(perr.Message)
+		details := redact.String(strings.TrimSpace(perr.Message))
 		switch {
 		case status == 401 || status == 403:
 			return &SearchError{Code: "AILINK_PROVIDER_AUTH", Message: "provider authentication failed", Details: details}
@@ -34,5 +52,5 @@ func MapProviderError(err error) *SearchError {
 		}
 	}
 
-	return &SearchError{Code: "AILINK_PROVIDER_ERROR", Message: "provider request failed", Details: err.Error()}
+	return &SearchError{Code: "AILINK_PROVIDER_ERROR", Message: "provider request failed", Details: redact.String(err.Error())}
 }
@@ -0,0 +1,134 @@
+package ailink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultQuotaWorkspace is the quota subject for CLI runs when no workspace is
+// configured.
+const DefaultQuotaWorkspace = "default"
+
+// QuotaConfig limits AI calls per subject: the API key name for server
+// requests, or the workspace for CLI runs and unauthenticated requests.
+type QuotaConfig struct {
+	Workspace string `mapstructure:"workspace"`
+
+	// Default applies to subjects without an entry in Subjects.
+	Default  QuotaLimit            `mapstructure:"default"`
+	Subjects map[string]QuotaLimit `mapstructure:"subjects"`
+}
+
+// QuotaLimit caps AI calls per UTC day and month. Zero is unlimited.
+type QuotaLimit struct {
+	Daily   int `mapstructure:"daily" json:"daily"`
+	Monthly int `mapstructure:"monthly" json:"monthly"`
+}
+
+// Enabled reports whether any limit is configured.
+func (c QuotaConfig) Enabled() bool {
+	if c.Default.limited() {
+		return true
+	}
+	for _, limit := range c.Subjects {
+		if limit.limited() {
+			return true
+		}
+	}
+	return false
+}
+
+// LimitFor returns the limit for a subject.
+func (c QuotaConfig) LimitFor(subject string) QuotaLimit {
+	if limit, ok := c.Subjects[subject]; ok {
+		return limit
+	}
+	return c.Default
+}
+
+// WorkspaceName returns the configured workspace or DefaultQuotaWorkspace.
+func (c QuotaConfig) WorkspaceName() string {
+	if workspace := strings.TrimSpace(c.Workspace); workspace != "" {
+		return workspace
+	}
+	return DefaultQuotaWorkspace
+}
+
+func (l QuotaLimit) limited() bool {
+	return l.Daily > 0 || l.Monthly > 0
+}
+
+// QuotaCounter stores AI call counts per subject and period.
+type QuotaCounter interface {
+	AIUsage(ctx context.Context, subject string, at time.Time) (daily, monthly int, err error)
+	IncrementAIUsage(ctx context.Context, subject string, at time.Time) error
+}
+
+// QuotaExceededError is returned when a subject has used its AI calls for
+// the period.
+type QuotaExceededError struct {
+	Subject string
+	Period  string
+	Limit   int
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("AI %s quota exceeded for %q (%d calls)", e.Period, e.Subject, e.Limit)
+}
+
+// QuotaEnforcer records AI calls and rejects them once the caller's subject
+// reaches its limit. Checks and increments are not atomic, so concurrent
+// calls can overshoot a limit slightly.
+type QuotaEnforcer struct {
+	Config  QuotaConfig
+	Counter QuotaCounter
+	Now     func() time.Time
+}
+
+type quotaSubjectKey struct{}
+
+// WithQuotaSubject returns a context whose AI calls count against subject.
+func WithQuotaSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, quotaSubjectKey{}, subject)
+}
+
+// Subject returns the quota subject of ctx, falling back to the workspace.
+func (q *QuotaEnforcer) Subject(ctx context.Context) string {
+	if subject, ok := ctx.Value(quotaSubjectKey{}).(string); ok && strings.TrimSpace(subject) != "" {
+		return subject
+	}
+	return q.Config.WorkspaceName()
+}
+
+// Reserve counts one AI call for the subject of ctx, or returns a
+// *QuotaExceededError when its daily or monthly limit is reached.
+func (q *QuotaEnforcer) Reserve(ctx context.Context) error {
+	if q == nil || q.Counter == nil {
+		return nil
+	}
+	subject := q.Subject(ctx)
+	now := time.Now().UTC()
+	if q.Now != nil {
+		now = q.Now().UTC()
+	}
+
+	if limit := q.Config.LimitFor(subject); limit.limited() {
+		daily, monthly, err := q.Counter.AIUsage(ctx, subject, now)
+		if err != nil {
+			return fmt.Errorf("read AI quota usage: %w", err)
+		}
+		if limit.Daily > 0 && daily >= limit.Daily {
+			return &QuotaExceededError{Subject: subject, Period: "daily", Limit: limit.Daily}
+		}
+		if limit.Monthly > 0 && monthly >= limit.Monthly {
+			return &QuotaExceededError{Subject: subject, Period: "monthly", Limit: limit.Monthly}
+		}
+	}
+
+	if err := q.Counter.IncrementAIUsage(ctx, subject, now); err != nil {
+		return fmt.Errorf("record AI quota usage: %w", err)
+	}
+	return nil
+}
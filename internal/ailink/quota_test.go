@@ -0,0 +1,92 @@
+package ailink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQuotaCounter struct {
+	calls map[string]int
+}
+
+func (f *fakeQuotaCounter) AIUsage(ctx context.Context, subject string, at time.Time) (int, int, error) {
+	return f.calls[subject], f.calls[subject], nil
+}
+
+func (f *fakeQuotaCounter) IncrementAIUsage(ctx context.Context, subject string, at time.Time) error {
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+	f.calls[subject]++
+	return nil
+}
+
+func TestQuotaEnforcerReserve(t *testing.T) {
+	counter := &fakeQuotaCounter{}
+	quota := &QuotaEnforcer{
+		Config: QuotaConfig{
+			Default:  QuotaLimit{Daily: 2},
+			Subjects: map[string]QuotaLimit{"ci": {Monthly: 1}},
+		},
+		Counter: counter,
+	}
+	ctx := context.Background()
+
+	require.NoError(t, quota.Reserve(ctx))
+	require.NoError(t, quota.Reserve(ctx))
+	err := quota.Reserve(ctx)
+	var quotaErr *QuotaExceededError
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, DefaultQuotaWorkspace, quotaErr.Subject)
+	require.Equal(t, "daily", quotaErr.Period)
+	require.Equal(t, 2, counter.calls[DefaultQuotaWorkspace])
+
+	ciCtx := WithQuotaSubject(ctx, "ci")
+	require.NoError(t, quota.Reserve(ciCtx))
+	err = quota.Reserve(ciCtx)
+	require.True(t, errors.As(err, &quotaErr))
+	require.Equal(t, "monthly", quotaErr.Period)
+
+	mapped := MapProviderError(err)
+	require.NotNil(t, mapped)
+	require.Equal(t, "AILINK_QUOTA_EXCEEDED", mapped.Code)
+}
+
+func TestQuotaEnforcerUnlimited(t *testing.T) {
+	var nilQuota *QuotaEnforcer
+	require.NoError(t, nilQuota.Reserve(context.Background()))
+
+	counter := &fakeQuotaCounter{}
+	quota := &QuotaEnforcer{Config: QuotaConfig{Workspace: "team"}, Counter: counter}
+	require.False(t, quota.Config.Enabled())
+	for range 5 {
+		require.NoError(t, quota.Reserve(context.Background()))
+	}
+	require.Equal(t, 5, counter.calls["team"])
+}
+
+func TestQuotaNotReservedForInvalidRequests(t *testing.T) {
+	drv := &scriptedDriver{replies: []string{`{"summary":"ok"}`}}
+	svc := repairTestService(drv, 0)
+	counter := &fakeQuotaCounter{}
+	svc.Quota = &QuotaEnforcer{Config: QuotaConfig{Default: QuotaLimit{Daily: 1}}, Counter: counter}
+	ctx := context.Background()
+
+	_, err := svc.Search(ctx, SearchRequest{Name: " ", PromptSlug: "shape"})
+	require.ErrorContains(t, err, "name is required")
+	_, err = svc.Generate(ctx, GenerateRequest{})
+	require.ErrorContains(t, err, "prompt slug is required")
+	_, err = svc.SearchBulk(ctx, BulkSearchRequest{})
+	require.Error(t, err)
+	require.Zero(t, counter.calls[DefaultQuotaWorkspace])
+
+	// The one allowed call is still available for a valid request
+	_, err = svc.Generate(ctx, GenerateRequest{PromptSlug: "shape"})
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.calls[DefaultQuotaWorkspace])
+	require.Len(t, drv.requests, 1)
+}
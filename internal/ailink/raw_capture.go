@@ -2,12 +2,17 @@ package ailink
 
 import (
 	"encoding/json"
+
+	"github.com/namelens/namelens/internal/redact"
 )
 
+// truncateJSONRaw prepares a captured raw payload for output: credentials are
+// redacted, then the payload is cut to max bytes.
 func truncateJSONRaw(input json.RawMessage, max int) json.RawMessage {
 	if max <= 0 {
 		return nil
 	}
+	input = redact.JSON(input)
 	if len(input) <= max {
 		return input
 	}
@@ -16,3 +16,10 @@ func TestTruncateJSONRaw(t *testing.T) {
 	require.Equal(t, input, truncateJSONRaw(input, 1024))
 	require.Nil(t, truncateJSONRaw(input, 0))
 }
+
+func TestTruncateJSONRawRedacts(t *testing.T) {
+	input := json.RawMessage(`{"note":"key sk-ant-REDACTED leaked"}`)
+	out := truncateJSONRaw(input, 1024)
+	require.NotContains(t, string(out), "sk-ant-REDACTED")
+	require.Contains(t, string(out), "[REDACTED]")
+}
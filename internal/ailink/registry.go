@@ -2,6 +2,7 @@ package ailink
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -10,14 +11,22 @@ import (
 	"github.com/namelens/namelens/internal/ailink/driver/openai"
 	"github.com/namelens/namelens/internal/ailink/driver/xai"
 	"github.com/namelens/namelens/internal/ailink/prompt"
+	"github.com/namelens/namelens/internal/audit"
 )
 
+// auditedHTTPClient sends provider requests, recording them in the request
+// audit log when it is enabled.
+var auditedHTTPClient = &http.Client{Transport: audit.NewTransport(audit.KindAI, nil)}
+
 type Registry struct {
 	cfg Config
 
 	mu      sync.Mutex
 	drivers map[string]driver.Driver
-	rr      map[string]int
+	// keys records the API key each cached driver was built with, so a key
+	// rotated through api_key_file replaces the driver.
+	keys map[string]string
+	rr   map[string]int
 }
 
 type ResolvedProvider struct {
@@ -42,6 +51,10 @@ func (r *Registry) ResolveWithDepth(role string, promptDef *prompt.Prompt, model
 	if err != nil {
 		return nil, err
 	}
+	providerCfg.Credentials, err = loadCredentialKeys(providerID, providerCfg.Credentials)
+	if err != nil {
+		return nil, fmt.Errorf("provider %q: %w", providerID, err)
+	}
 
 	cred, credKey, err := selectCredential(providerCfg, func(groupKey string, n int) int {
 		return r.rrIndex(providerID+":"+groupKey, n)
@@ -140,6 +153,28 @@ func (r *Registry) resolveProvider(role string) (string, ProviderInstanceConfig,
 	return onlyID, onlyCfg, nil
 }
 
+// loadCredentialKeys returns a copy of creds with keys read from their
+// api_key_file or the OS keychain. Disabled credentials are left as they are.
+func loadCredentialKeys(providerID string, creds []CredentialConfig) ([]CredentialConfig, error) {
+	loaded := make([]CredentialConfig, len(creds))
+	for i, cred := range creds {
+		if !cred.Enabled && strings.TrimSpace(cred.Label) != "" {
+			loaded[i] = cred
+			continue
+		}
+		var err error
+		loaded[i], err = cred.ResolveKey(providerID)
+		if err != nil {
+			label := strings.TrimSpace(cred.Label)
+			if label == "" {
+				label = fmt.Sprintf("%d", i)
+			}
+			return nil, fmt.Errorf("credential %s: %w", label, err)
+		}
+	}
+	return loaded, nil
+}
+
 func selectCredential(cfg ProviderInstanceConfig, rrNext func(groupKey string, n int) int) (CredentialConfig, string, error) {
 	if len(cfg.Credentials) == 0 {
 		return CredentialConfig{}, "", fmt.Errorf("no credentials configured")
@@ -229,29 +264,38 @@ func (r *Registry) driverFor(providerID string, providerCfg ProviderInstanceConf
 	if r.rr == nil {
 		r.rr = map[string]int{}
 	}
+	if r.keys == nil {
+		r.keys = map[string]string{}
+	}
 	driverKey := providerID
 	if strings.TrimSpace(credKey) != "" {
 		driverKey += ":" + credKey
 	}
 	if drv, ok := r.drivers[driverKey]; ok {
-		return drv, nil
+		if key, built := r.keys[driverKey]; !built || key == cred.APIKey {
+			return drv, nil
+		}
 	}
+	r.keys[driverKey] = cred.APIKey
 
 	providerType := strings.ToLower(strings.TrimSpace(providerCfg.AIProvider))
 	switch providerType {
 	case "xai":
 		client := xai.NewClient(providerCfg.BaseURL, cred.APIKey)
 		client.Timeout = r.cfg.DefaultTimeout
+		client.HTTPClient = auditedHTTPClient
 		r.drivers[driverKey] = client
 		return client, nil
 	case "openai":
 		client := openai.NewClient(providerCfg.BaseURL, cred.APIKey)
 		client.Timeout = r.cfg.DefaultTimeout
+		client.HTTPClient = auditedHTTPClient
 		r.drivers[driverKey] = client
 		return client, nil
 	case "anthropic":
 		client := anthropic.NewClient(providerCfg.BaseURL, cred.APIKey)
 		client.Timeout = r.cfg.DefaultTimeout
+		client.HTTPClient = auditedHTTPClient
 		r.drivers[driverKey] = client
 		return client, nil
 	default:
@@ -1,11 +1,14 @@
 package ailink
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/namelens/namelens/internal/ailink/prompt"
+	"github.com/namelens/namelens/internal/keychain"
 )
 
 func TestResolveModelPrefersProviderTierReasoningForDeep(t *testing.T) {
@@ -49,3 +52,72 @@ func TestResolveModelFallsBackToPromptPreferredModels(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "prompt-model", model)
 }
+
+func TestCredentialLoadKeyReadsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(t, os.WriteFile(path, []byte("file-key\n"), 0o600))
+
+	cred, err := CredentialConfig{APIKeyFile: path}.LoadKey()
+	require.NoError(t, err)
+	require.Equal(t, "file-key", cred.APIKey)
+
+	cred, err = CredentialConfig{APIKey: "inline", APIKeyFile: path}.LoadKey()
+	require.NoError(t, err)
+	require.Equal(t, "inline", cred.APIKey)
+
+	_, err = CredentialConfig{APIKeyFile: filepath.Join(t.TempDir(), "missing")}.LoadKey()
+	require.ErrorContains(t, err, "api_key_file")
+}
+
+func TestCredentialResolveKeyFallsBackToKeychain(t *testing.T) {
+	keychain.MockInit()
+	require.NoError(t, keychain.Set(keychain.AIAccount("p", ""), "provider-key"))
+
+	cred, err := CredentialConfig{Label: "default"}.ResolveKey("p")
+	require.NoError(t, err)
+	require.Equal(t, "provider-key", cred.APIKey)
+
+	require.NoError(t, keychain.Set(keychain.AIAccount("p", "default"), "labeled-key"))
+	cred, err = CredentialConfig{Label: "default"}.ResolveKey("p")
+	require.NoError(t, err)
+	require.Equal(t, "labeled-key", cred.APIKey)
+
+	cred, err = CredentialConfig{Label: "default", APIKey: "inline"}.ResolveKey("p")
+	require.NoError(t, err)
+	require.Equal(t, "inline", cred.APIKey)
+
+	cred, err = CredentialConfig{}.ResolveKey("other")
+	require.NoError(t, err)
+	require.Empty(t, cred.APIKey)
+}
+
+func TestResolveRereadsRotatedKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(t, os.WriteFile(path, []byte("key-one"), 0o600))
+
+	registry := NewRegistry(Config{
+		DefaultProvider: "p",
+		Providers: map[string]ProviderInstanceConfig{
+			"p": {
+				Enabled:     true,
+				AIProvider:  "openai",
+				Models:      map[string]string{"default": "m"},
+				Credentials: []CredentialConfig{{Enabled: true, Label: "default", APIKeyFile: path}},
+			},
+		},
+	})
+
+	first, err := registry.Resolve("", nil, "")
+	require.NoError(t, err)
+	require.Equal(t, "key-one", first.Credential.APIKey)
+
+	again, err := registry.Resolve("", nil, "")
+	require.NoError(t, err)
+	require.Same(t, first.Driver, again.Driver)
+
+	require.NoError(t, os.WriteFile(path, []byte("key-two"), 0o600))
+	rotated, err := registry.Resolve("", nil, "")
+	require.NoError(t, err)
+	require.Equal(t, "key-two", rotated.Credential.APIKey)
+	require.NotSame(t, first.Driver, rotated.Driver)
+}
@@ -0,0 +1,106 @@
+package ailink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fulmenhq/gofulmen/schema"
+	"github.com/namelens/namelens/internal/ailink/content"
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/ailink/prompt"
+)
+
+// maxRepairDiagnostics limits how many validation errors a repair prompt lists.
+const maxRepairDiagnostics = 10
+
+// SchemaValidationError reports a response that does not match its prompt's
+// response schema.
+type SchemaValidationError struct {
+	Diagnostics []schema.Diagnostic
+}
+
+func (e *SchemaValidationError) Error() string {
+	if e == nil || len(e.Diagnostics) == 0 {
+		return "response schema validation failed"
+	}
+	return fmt.Sprintf("response schema validation failed: %s", e.Diagnostics[0].Message)
+}
+
+// repairResponse sends a response that failed schema validation back to the
+// model with the validation errors, up to the prompt's repair_attempts. It
+// returns the last schema-checked response and its validation error, which
+// is nil once a repair validates. A reply that is not even JSON does not
+// replace the previous response. Repairs share the caller's timeout and count
+// toward usage, budget, and quota like any other call; an exhausted budget or
+// quota, or a failed repair call, ends the loop.
+func (s *Service) repairResponse(ctx context.Context, resolved *ResolvedProvider, req *driver.Request, promptDef *prompt.Prompt, raw string, validationErr error) (string, error) {
+	var schemaErr *SchemaValidationError
+	if promptDef == nil || !errors.As(validationErr, &schemaErr) {
+		return raw, validationErr
+	}
+	for attempt := 0; attempt < promptDef.Config.RepairAttempts(); attempt++ {
+		if err := s.Budget.Check(ctx); err != nil {
+			break
+		}
+		if err := s.Quota.Reserve(ctx); err != nil {
+			break
+		}
+		resp, err := s.complete(ctx, resolved, repairRequest(req, promptDef, s.Catalog, raw, schemaErr))
+		if err != nil {
+			break
+		}
+
+		candidate := extractContent(resp)
+		candidateErr := s.validateResponse(promptDef, []byte(candidate))
+		if candidateErr == nil {
+			return candidate, nil
+		}
+		var nextErr *SchemaValidationError
+		if errors.As(candidateErr, &nextErr) {
+			raw, validationErr, schemaErr = candidate, candidateErr, nextErr
+		}
+	}
+	return raw, validationErr
+}
+
+// repairRequest extends the original conversation with a request to fix the
+// invalid payload. Search tools are dropped: the repair only reshapes the
+// answer the model already gave.
+func repairRequest(req *driver.Request, promptDef *prompt.Prompt, catalog *schema.Catalog, raw string, schemaErr *SchemaValidationError) *driver.Request {
+	var b strings.Builder
+	b.WriteString("Your previous response does not conform to the required JSON schema.\n\nValidation errors:\n")
+	for i, diag := range schemaErr.Diagnostics {
+		if i == maxRepairDiagnostics {
+			fmt.Fprintf(&b, "- and %d more\n", len(schemaErr.Diagnostics)-i)
+			break
+		}
+		pointer := diag.Pointer
+		if pointer == "" {
+			pointer = "(root)"
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", pointer, diag.Message)
+	}
+	b.WriteString("\nInvalid response:\n")
+	b.WriteString(strings.TrimSpace(raw))
+	b.WriteString("\n\n")
+	if doc := openAISchemaForPrompt(promptDef, catalog); doc != nil {
+		if encoded, err := json.Marshal(doc); err == nil {
+			b.WriteString("Required schema:\n")
+			b.Write(encoded)
+			b.WriteString("\n\n")
+		}
+	}
+	b.WriteString("Fix the response so it conforms to the schema, keeping its content. Return only the corrected JSON document.")
+
+	repair := *req
+	repair.Tools = nil
+	repair.SearchParameters = nil
+	repair.Messages = append(append([]content.Message(nil), req.Messages...), content.Message{
+		Role:    "user",
+		Content: []content.ContentBlock{{Type: content.ContentTypeText, Text: b.String()}},
+	})
+	return &repair
+}
@@ -0,0 +1,125 @@
+package ailink
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink/content"
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/ailink/prompt"
+)
+
+// scriptedDriver answers each call with the next scripted response text.
+type scriptedDriver struct {
+	replies  []string
+	requests []*driver.Request
+}
+
+func (d *scriptedDriver) Complete(ctx context.Context, req *driver.Request) (*driver.Response, error) {
+	d.requests = append(d.requests, req)
+	if len(d.requests) > len(d.replies) {
+		return nil, errors.New("no scripted reply")
+	}
+	text := d.replies[len(d.requests)-1]
+	return &driver.Response{Content: []content.ContentBlock{{Type: content.ContentTypeText, Text: text}}}, nil
+}
+
+func (d *scriptedDriver) Name() string { return "openai" }
+
+func (d *scriptedDriver) Capabilities() driver.Capabilities { return driver.Capabilities{} }
+
+func repairTestService(drv driver.Driver, attempts int) *Service {
+	providers := &Registry{cfg: Config{DefaultProvider: "p"}}
+	providers.cfg.Providers = map[string]ProviderInstanceConfig{
+		"p": {
+			Enabled:     true,
+			AIProvider:  "openai",
+			Models:      map[string]string{"default": "m"},
+			Credentials: []CredentialConfig{{APIKey: "k"}},
+		},
+	}
+	providers.drivers = map[string]driver.Driver{"p:p0": drv}
+
+	promptDef := &prompt.Prompt{Config: prompt.Config{
+		Slug:           "shape",
+		SystemTemplate: "sys",
+		UserTemplate:   "usr",
+		ResponseSchema: map[string]any{
+			"type":       "object",
+			"required":   []any{"summary"},
+			"properties": map[string]any{"summary": map[string]any{"type": "string"}},
+		},
+		ResponseOpts: map[string]any{"repair_attempts": attempts},
+	}}
+	return &Service{Providers: providers, Registry: stubPromptRegistry{prompt: promptDef}}
+}
+
+func TestGenerateRepairsInvalidResponse(t *testing.T) {
+	drv := &scriptedDriver{replies: []string{`{"summary":42}`, `{"summary":"ok"}`}}
+	svc := repairTestService(drv, 2)
+
+	resp, err := svc.Generate(context.Background(), GenerateRequest{PromptSlug: "shape"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"summary":"ok"}`, string(resp.Raw))
+	require.Len(t, drv.requests, 2)
+
+	repair := drv.requests[1]
+	require.Len(t, repair.Messages, len(drv.requests[0].Messages)+1)
+	text := repair.Messages[len(repair.Messages)-1].Content[0].Text
+	require.Contains(t, text, "/summary")
+	require.Contains(t, text, `{"summary":42}`)
+	require.Contains(t, text, "Required schema:")
+}
+
+func TestGenerateRepairGivesUpAfterAttempts(t *testing.T) {
+	drv := &scriptedDriver{replies: []string{`{"summary":1}`, `{"summary":2}`, `not json`, `{"summary":"late"}`}}
+	svc := repairTestService(drv, 2)
+
+	_, err := svc.Generate(context.Background(), GenerateRequest{PromptSlug: "shape"})
+	require.Error(t, err)
+	require.Len(t, drv.requests, 3)
+
+	var rawErr *RawResponseError
+	require.ErrorAs(t, err, &rawErr)
+	// The non-JSON reply does not replace the last schema-checked response
+	require.JSONEq(t, `{"summary":2}`, string(rawErr.Raw))
+	var schemaErr *SchemaValidationError
+	require.ErrorAs(t, err, &schemaErr)
+}
+
+func TestGenerateWithoutRepairAttempts(t *testing.T) {
+	drv := &scriptedDriver{replies: []string{`{"summary":1}`, `{"summary":"ok"}`}}
+	svc := repairTestService(drv, 0)
+
+	_, err := svc.Generate(context.Background(), GenerateRequest{PromptSlug: "shape"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "response schema validation failed")
+	require.Len(t, drv.requests, 1)
+}
+
+func TestSearchRepairsInvalidResponse(t *testing.T) {
+	drv := &scriptedDriver{replies: []string{`{}`, `{"summary":"clear"}`}}
+	svc := repairTestService(drv, 1)
+
+	resp, err := svc.Search(context.Background(), SearchRequest{Name: "acme", PromptSlug: "shape"})
+	require.NoError(t, err)
+	require.Equal(t, "clear", resp.Summary)
+	require.Len(t, drv.requests, 2)
+}
+
+func TestGenerateRepairReservesQuota(t *testing.T) {
+	drv := &scriptedDriver{replies: []string{`{"summary":42}`, `{"summary":"ok"}`}}
+	svc := repairTestService(drv, 2)
+	counter := &fakeQuotaCounter{}
+	svc.Quota = &QuotaEnforcer{Config: QuotaConfig{Default: QuotaLimit{Daily: 1}}, Counter: counter}
+
+	_, err := svc.Generate(context.Background(), GenerateRequest{PromptSlug: "shape"})
+	var schemaErr *SchemaValidationError
+	require.ErrorAs(t, err, &schemaErr)
+	// The quota of one call is used by the first request, so no repair is sent
+	require.Len(t, drv.requests, 1)
+	require.Equal(t, 1, counter.calls[DefaultQuotaWorkspace])
+}
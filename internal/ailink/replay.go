@@ -0,0 +1,176 @@
+package ailink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/namelens/namelens/internal/ailink/content"
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/ailink/driver/anthropic"
+	"github.com/namelens/namelens/internal/ailink/driver/openai"
+	"github.com/namelens/namelens/internal/ailink/driver/xai"
+)
+
+// Replay outcomes.
+const (
+	ReplayValid         = "valid"
+	ReplaySchemaInvalid = "schema_invalid"
+	ReplayProviderError = "provider_error"
+	ReplayError         = "error"
+	// ReplayUnchecked means the response decoded but the entry names no
+	// prompt, so there was no schema to check it against.
+	ReplayUnchecked = "unchecked"
+)
+
+// ReplayResult is the outcome of replaying one trace entry.
+type ReplayResult struct {
+	Driver     string          `json:"driver"`
+	Endpoint   string          `json:"endpoint,omitempty"`
+	Model      string          `json:"model,omitempty"`
+	PromptSlug string          `json:"prompt_slug,omitempty"`
+	Outcome    string          `json:"outcome"`
+	Error      string          `json:"error,omitempty"`
+	Raw        json.RawMessage `json:"raw,omitempty"`
+}
+
+// Replay re-runs a traced completion offline. The recorded response is served
+// to the real driver by a mock transport, so decoding and schema validation
+// follow the same path as Generate. slug is used when the entry does not
+// record its prompt (traces written before prompt_slug was added). Replay
+// needs Registry and, for $ref schemas, Catalog; Providers is not used.
+func (s *Service) Replay(ctx context.Context, entry driver.TraceEntry, slug string) ReplayResult {
+	result := ReplayResult{
+		Driver:     entry.Driver,
+		Endpoint:   entry.Endpoint,
+		Model:      entry.Model,
+		PromptSlug: entry.PromptSlug,
+	}
+	if result.PromptSlug == "" {
+		result.PromptSlug = strings.TrimSpace(slug)
+	}
+	fail := func(outcome string, err error) ReplayResult {
+		result.Outcome = outcome
+		result.Error = err.Error()
+		return result
+	}
+
+	// A request that never got a response has nothing to replay
+	if entry.StatusCode == 0 {
+		msg := entry.Error
+		if msg == "" {
+			msg = "no response recorded"
+		}
+		return fail(ReplayError, errors.New(msg))
+	}
+
+	drv, req, err := replayDriver(entry)
+	if err != nil {
+		return fail(ReplayError, err)
+	}
+	req.PromptSlug = result.PromptSlug
+
+	resp, err := drv.Complete(ctx, req)
+	if err != nil {
+		var providerErr *driver.ProviderError
+		if errors.As(err, &providerErr) {
+			return fail(ReplayProviderError, err)
+		}
+		return fail(ReplayError, err)
+	}
+
+	if result.PromptSlug == "" {
+		raw := extractContent(resp)
+		if strings.TrimSpace(raw) == "" {
+			return fail(ReplayError, errors.New("empty response content"))
+		}
+		result.Outcome = ReplayUnchecked
+		result.Raw = json.RawMessage(raw)
+		return result
+	}
+	if s == nil || s.Registry == nil {
+		return fail(ReplayError, errors.New("ailink prompt registry not configured"))
+	}
+	promptDef, err := s.Registry.Get(result.PromptSlug)
+	if err != nil {
+		return fail(ReplayError, err)
+	}
+
+	raw, err := s.checkResponse(promptDef, resp)
+	if err != nil {
+		var rawErr *RawResponseError
+		if errors.As(err, &rawErr) {
+			result.Raw = rawErr.Raw
+			return fail(ReplaySchemaInvalid, rawErr.Err)
+		}
+		return fail(ReplayError, err)
+	}
+	result.Outcome = ReplayValid
+	result.Raw = raw
+	return result
+}
+
+// replayDriver builds the entry's driver with a transport that answers every
+// request with the recorded response, and a request that takes the same
+// endpoint as the recorded one.
+func replayDriver(entry driver.TraceEntry) (driver.Driver, *driver.Request, error) {
+	httpClient := &http.Client{Transport: replayTransport{entry: entry}}
+	const baseURL = "http://replay.invalid/v1"
+	const apiKey = "replay"
+
+	model := entry.Model
+	if model == "" {
+		model = "replay"
+	}
+	req := &driver.Request{
+		Model: model,
+		Messages: []content.Message{
+			{Role: "user", Content: []content.ContentBlock{{Type: content.ContentTypeText, Text: "replay"}}},
+		},
+	}
+
+	switch strings.ToLower(strings.TrimSpace(entry.Driver)) {
+	case "xai":
+		client := xai.NewClient(baseURL, apiKey)
+		client.HTTPClient = httpClient
+		// Tool-enabled requests went to /responses, which has its own format
+		if strings.HasSuffix(entry.Endpoint, "/responses") {
+			req.SearchParameters = &driver.SearchParameters{Sources: []driver.Source{{Type: "web"}}}
+		}
+		return client, req, nil
+	case "openai":
+		client := openai.NewClient(baseURL, apiKey)
+		client.HTTPClient = httpClient
+		return client, req, nil
+	case "anthropic":
+		client := anthropic.NewClient(baseURL, apiKey)
+		client.HTTPClient = httpClient
+		return client, req, nil
+	default:
+		return nil, nil, fmt.Errorf("cannot replay driver %q", entry.Driver)
+	}
+}
+
+// replayTransport is the mock provider: it returns the recorded status and
+// body without making a network request.
+type replayTransport struct {
+	entry driver.TraceEntry
+}
+
+func (t replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	return &http.Response{
+		StatusCode: t.entry.StatusCode,
+		Status:     fmt.Sprintf("%d %s", t.entry.StatusCode, http.StatusText(t.entry.StatusCode)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(t.entry.Response)),
+		Request:    req,
+	}, nil
+}
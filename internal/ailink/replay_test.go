@@ -0,0 +1,79 @@
+package ailink
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/schema"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/ailink/prompt"
+)
+
+// TestReplayFixtures replays the golden traces in testdata/replay. Each
+// fixture lives in a directory named for the outcome it must produce; add a
+// regression fixture with `namelens trace replay --save-fixtures`.
+func TestReplayFixtures(t *testing.T) {
+	registry, err := prompt.LayeredRegistry("")
+	require.NoError(t, err)
+	svc := &Service{Registry: registry, Catalog: schema.NewCatalog(filepath.Join("..", "..", "schemas"))}
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "replay", "*", "*.ndjson"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		want := filepath.Base(filepath.Dir(path))
+		t.Run(want+"/"+filepath.Base(path), func(t *testing.T) {
+			f, err := os.Open(path) // #nosec G304 -- test fixture
+			require.NoError(t, err)
+			defer f.Close() //nolint:errcheck
+
+			entries, err := driver.ReadTrace(f)
+			require.NoError(t, err)
+			require.NotEmpty(t, entries)
+			for _, entry := range entries {
+				result := svc.Replay(context.Background(), entry, "")
+				require.Equal(t, want, result.Outcome, result.Error)
+			}
+		})
+	}
+}
+
+func TestReplayReportsSchemaFailure(t *testing.T) {
+	svc := &Service{Catalog: schema.NewCatalog(filepath.Join("..", "..", "schemas")), Registry: stubPromptRegistry{prompt: &prompt.Prompt{
+		Config: prompt.Config{Slug: "brand-plan", ResponseSchema: map[string]any{"$ref": "ailink/v0/brand-plan-response"}},
+	}}}
+	entry := driver.TraceEntry{
+		Driver:     "xai",
+		Endpoint:   "https://api.x.ai/v1/chat/completions",
+		StatusCode: 200,
+		Response:   json.RawMessage(`{"choices":[{"message":{"content":"{\"mentions\":[{\"source\":\"web\"}]}"}}]}`),
+	}
+
+	result := svc.Replay(context.Background(), entry, "brand-plan")
+	require.Equal(t, ReplaySchemaInvalid, result.Outcome)
+	require.Equal(t, "brand-plan", result.PromptSlug)
+	require.Contains(t, result.Error, "response schema validation failed")
+	require.JSONEq(t, `{"mentions":[{"source":"web"}]}`, string(result.Raw))
+}
+
+func TestReplayWithoutResponse(t *testing.T) {
+	result := (&Service{}).Replay(context.Background(), driver.TraceEntry{Driver: "openai", Error: "request failed: connection refused"}, "")
+	require.Equal(t, ReplayError, result.Outcome)
+	require.Contains(t, result.Error, "connection refused")
+
+	result = (&Service{}).Replay(context.Background(), driver.TraceEntry{Driver: "gemini", StatusCode: 200}, "")
+	require.Equal(t, ReplayError, result.Outcome)
+
+	unchecked := (&Service{}).Replay(context.Background(), driver.TraceEntry{
+		Driver:     "openai",
+		StatusCode: 200,
+		Response:   json.RawMessage(`{"choices":[{"message":{"content":"{}"}}]}`),
+	}, "")
+	require.Equal(t, ReplayUnchecked, unchecked.Outcome)
+}
@@ -25,6 +25,12 @@ type Service struct {
 	Providers *Registry
 	Registry  prompt.Registry
 	Catalog   *schema.Catalog
+	// Quota, when set, counts each Search, Generate, and SearchBulk call and
+	// rejects it once the caller's quota is used up.
+	Quota *QuotaEnforcer
+	// Budget, when set, rejects calls once the run or day has used its AI
+	// budget.
+	Budget *BudgetEnforcer
 }
 
 // Search runs an expert search using a role-selected provider.
@@ -35,6 +41,9 @@ func (s *Service) Search(ctx context.Context, req SearchRequest) (*SearchRespons
 	if s.Registry == nil {
 		return nil, errors.New("ailink prompt registry not configured")
 	}
+	if err := s.Budget.Check(ctx); err != nil {
+		return nil, err
+	}
 
 	name := strings.TrimSpace(req.Name)
 	if name == "" {
@@ -51,7 +60,7 @@ func (s *Service) Search(ctx context.Context, req SearchRequest) (*SearchRespons
 		return nil, err
 	}
 
-	systemPrompt, userPrompt, err := renderPrompt(promptDef, name, req.Depth)
+	systemPrompt, userPrompt, err := renderPrompt(promptDef, name, req.Depth, req.Variables)
 	if err != nil {
 		return nil, err
 	}
@@ -118,12 +127,16 @@ func (s *Service) Search(ctx context.Context, req SearchRequest) (*SearchRespons
 	ctx, cancel := context.WithTimeout(ctx, duration)
 	defer cancel()
 
-	resp, err := resolved.Driver.Complete(ctx, driverReq)
+	// Reserve quota only for a well-formed request about to reach the provider
+	if err := s.Quota.Reserve(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := s.complete(ctx, resolved, driverReq)
 	if err != nil {
 		// If OpenAI rejects json_schema, retry once with json_object.
 		if resolved.Driver.Name() == "openai" && isOpenAIUnsupportedSchemaError(err) {
 			fallbackToJSONObject(driverReq)
-			resp, err = resolved.Driver.Complete(ctx, driverReq)
+			resp, err = s.complete(ctx, resolved, driverReq)
 			if err != nil {
 				return nil, err
 			}
@@ -141,12 +154,22 @@ func (s *Service) Search(ctx context.Context, req SearchRequest) (*SearchRespons
 	if err != nil {
 		return nil, &RawResponseError{Err: err, Raw: json.RawMessage(raw)}
 	}
+	CheckPlausibility(parsed, req.Depth, systemPrompt, userPrompt)
 
 	if err := s.validateResponse(promptDef, []byte(raw)); err != nil {
-		// Preserve parsed fields to keep CLI output useful, but still signal schema failure.
-		parsed.Raw = append(parsed.Raw[:0], raw...)
-		parsed.Raw = truncateJSONRaw(parsed.Raw, rawLimit(s.Providers.cfg))
-		return parsed, &RawResponseError{Err: err, Raw: json.RawMessage(raw)}
+		raw, err = s.repairResponse(ctx, resolved, driverReq, promptDef, raw, err)
+		repaired, decodeErr := decodeSearchResponse([]byte(raw))
+		if decodeErr != nil {
+			return nil, &RawResponseError{Err: decodeErr, Raw: json.RawMessage(raw)}
+		}
+		parsed = repaired
+		if err != nil {
+			// Preserve parsed fields to keep CLI output useful, but still signal schema failure.
+			parsed.Raw = append(parsed.Raw[:0], raw...)
+			parsed.Raw = truncateJSONRaw(parsed.Raw, rawLimit(s.Providers.cfg))
+			return parsed, &RawResponseError{Err: err, Raw: json.RawMessage(raw)}
+		}
+		CheckPlausibility(parsed, req.Depth, systemPrompt, userPrompt)
 	}
 
 	if isRawCaptureEnabled(s.Providers.cfg, req.IncludeRaw) {
@@ -167,6 +190,9 @@ func (s *Service) Generate(ctx context.Context, req GenerateRequest) (*GenerateR
 	if s.Registry == nil {
 		return nil, errors.New("ailink prompt registry not configured")
 	}
+	if err := s.Budget.Check(ctx); err != nil {
+		return nil, err
+	}
 
 	slug := strings.TrimSpace(req.PromptSlug)
 	if slug == "" {
@@ -222,6 +248,8 @@ func (s *Service) Generate(ctx context.Context, req GenerateRequest) (*GenerateR
 		Tools:            tools,
 		SearchParameters: searchParams,
 		ResponseFormat:   responseFormatForProvider(resolved, promptDef, s.Catalog),
+		Temperature:      req.Temperature,
+		Seed:             req.Seed,
 		PromptSlug:       promptDef.Config.Slug,
 	}
 
@@ -257,12 +285,16 @@ func (s *Service) Generate(ctx context.Context, req GenerateRequest) (*GenerateR
 	ctx, cancel := context.WithTimeout(ctx, duration)
 	defer cancel()
 
-	resp, err := resolved.Driver.Complete(ctx, driverReq)
+	// Reserve quota only for a well-formed request about to reach the provider
+	if err := s.Quota.Reserve(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := s.complete(ctx, resolved, driverReq)
 	if err != nil {
 		// If OpenAI rejects json_schema, retry once with json_object.
 		if resolved.Driver.Name() == "openai" && isOpenAIUnsupportedSchemaError(err) {
 			fallbackToJSONObject(driverReq)
-			resp, err = resolved.Driver.Complete(ctx, driverReq)
+			resp, err = s.complete(ctx, resolved, driverReq)
 			if err != nil {
 				return nil, err
 			}
@@ -271,16 +303,20 @@ func (s *Service) Generate(ctx context.Context, req GenerateRequest) (*GenerateR
 		}
 	}
 
-	raw := extractContent(resp)
-	if strings.TrimSpace(raw) == "" {
-		return nil, errors.New("empty response content")
-	}
-
-	if err := s.validateResponse(promptDef, []byte(raw)); err != nil {
-		return nil, &RawResponseError{Err: err, Raw: json.RawMessage(raw)}
+	raw, err := s.checkResponse(promptDef, resp)
+	if err != nil {
+		var rawErr *RawResponseError
+		if !errors.As(err, &rawErr) {
+			return nil, err
+		}
+		repaired, repairErr := s.repairResponse(ctx, resolved, driverReq, promptDef, string(rawErr.Raw), rawErr.Err)
+		if repairErr != nil {
+			return nil, &RawResponseError{Err: repairErr, Raw: json.RawMessage(repaired)}
+		}
+		raw = json.RawMessage(repaired)
 	}
 
-	response := &GenerateResponse{Raw: json.RawMessage(raw)}
+	response := &GenerateResponse{Raw: raw}
 	if isRawCaptureEnabled(s.Providers.cfg, req.IncludeRaw) {
 		response.Raw = truncateJSONRaw(response.Raw, rawLimit(s.Providers.cfg))
 	}
@@ -288,6 +324,20 @@ func (s *Service) Generate(ctx context.Context, req GenerateRequest) (*GenerateR
 	return response, nil
 }
 
+// checkResponse returns the completion text once it validates against the
+// prompt's response schema. A response that fails validation is returned as
+// a *RawResponseError.
+func (s *Service) checkResponse(promptDef *prompt.Prompt, resp *driver.Response) (json.RawMessage, error) {
+	raw := extractContent(resp)
+	if strings.TrimSpace(raw) == "" {
+		return nil, errors.New("empty response content")
+	}
+	if err := s.validateResponse(promptDef, []byte(raw)); err != nil {
+		return nil, &RawResponseError{Err: err, Raw: json.RawMessage(raw)}
+	}
+	return json.RawMessage(raw), nil
+}
+
 func promptTools(def *prompt.Prompt, enabled bool) []driver.Tool {
 	if def == nil || !enabled {
 		return nil
@@ -303,6 +353,12 @@ func promptTools(def *prompt.Prompt, enabled bool) []driver.Tool {
 	return tools
 }
 
+// UsesSearch reports whether requests for the prompt carry xAI search
+// parameters, which send xAI calls through its Responses API.
+func UsesSearch(def *prompt.Prompt, useTools bool) bool {
+	return def != nil && buildSearchParams(def.Config.Tools, useTools) != nil
+}
+
 // buildSearchParams maps search-specific tools to xAI search_parameters.
 func buildSearchParams(tools []prompt.ToolConfig, enabled bool) *driver.SearchParameters {
 	if !enabled || len(tools) == 0 {
@@ -328,19 +384,22 @@ func buildSearchParams(tools []prompt.ToolConfig, enabled bool) *driver.SearchPa
 	return params
 }
 
-func renderPrompt(def *prompt.Prompt, name, depth string) (string, string, error) {
+func renderPrompt(def *prompt.Prompt, name, depth string, extra map[string]string) (string, string, error) {
 	if def == nil {
 		return "", "", errors.New("prompt is required")
 	}
-	vars := map[string]string{
-		"name":  name,
-		"input": name,
+	vars := make(map[string]string, len(extra)+3)
+	for key, value := range extra {
+		vars[key] = value
 	}
+	vars["name"] = name
+	vars["input"] = name
 	if depth != "" {
 		vars["depth"] = depth
 	}
 
-	system := applyVars(def.Config.SystemTemplate, vars)
+	system := applyConditionals(def.Config.SystemTemplate, vars)
+	system = applyVars(system, vars)
 	user := ""
 	if depth != "" {
 		if variant, ok := def.Config.DepthVariants[depth]; ok {
@@ -353,6 +412,7 @@ func renderPrompt(def *prompt.Prompt, name, depth string) (string, string, error
 	if user == "" {
 		user = "{{input}}"
 	}
+	user = applyConditionals(user, vars)
 	user = applyVars(user, vars)
 
 	if strings.TrimSpace(system) == "" {
@@ -447,6 +507,12 @@ func findConditionalBlock(input string, start int) (int, int, int, int) {
 	}
 }
 
+// RenderPrompt renders the system and user messages of def with vars, as
+// they are sent to a provider for the given depth.
+func RenderPrompt(def *prompt.Prompt, vars map[string]string, depth string) (string, string, error) {
+	return renderPromptWithVars(def, vars, depth)
+}
+
 // renderPromptWithVars renders a prompt template with arbitrary variables and conditionals.
 func renderPromptWithVars(def *prompt.Prompt, vars map[string]string, depth string) (string, string, error) {
 	if def == nil {
@@ -545,7 +611,7 @@ func (s *Service) validateResponse(def *prompt.Prompt, payload []byte) error {
 			return err
 		}
 		if len(diagnostics) > 0 {
-			return fmt.Errorf("response schema validation failed: %s", diagnostics[0].Message)
+			return &SchemaValidationError{Diagnostics: diagnostics}
 		}
 		return nil
 	}
@@ -563,7 +629,7 @@ func (s *Service) validateResponse(def *prompt.Prompt, payload []byte) error {
 		return err
 	}
 	if len(diagnostics) > 0 {
-		return fmt.Errorf("response schema validation failed: %s", diagnostics[0].Message)
+		return &SchemaValidationError{Diagnostics: diagnostics}
 	}
 	return nil
 }
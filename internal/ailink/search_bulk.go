@@ -42,6 +42,8 @@ type BulkSearchItem struct {
 	Insights        []string        `json:"insights,omitempty"`
 	Mentions        []SearchMention `json:"mentions,omitempty"`
 	Recommendations []string        `json:"recommendations,omitempty"`
+	Suspect         bool            `json:"suspect,omitempty"`
+	Warnings        []string        `json:"warnings,omitempty"`
 }
 
 // SearchBulk runs a bulk expert search using a prompt that accepts a list of names.
@@ -52,6 +54,9 @@ func (s *Service) SearchBulk(ctx context.Context, req BulkSearchRequest) (*BulkS
 	if s.Registry == nil {
 		return nil, errors.New("ailink prompt registry not configured")
 	}
+	if err := s.Budget.Check(ctx); err != nil {
+		return nil, err
+	}
 
 	names := normalizeBulkNames(req.Names)
 	if len(names) == 0 {
@@ -97,6 +102,7 @@ func (s *Service) SearchBulk(ctx context.Context, req BulkSearchRequest) (*BulkS
 		if errors.As(err, &rawErr) {
 			parsed, decodeErr := decodeBulkSearchResponseLenient(rawErr.Raw)
 			if decodeErr == nil && parsed != nil && len(parsed.Items) > 0 {
+				checkBulkPlausibility(parsed, req.Depth, s.bulkPrompts(slug, variables, req.Depth)...)
 				if isRawCaptureEnabled(s.Providers.cfg, req.IncludeRaw) {
 					parsed.Raw = append(parsed.Raw[:0], rawErr.Raw...)
 					parsed.Raw = truncateJSONRaw(parsed.Raw, rawLimit(s.Providers.cfg))
@@ -111,6 +117,7 @@ func (s *Service) SearchBulk(ctx context.Context, req BulkSearchRequest) (*BulkS
 	if err != nil {
 		return nil, &RawResponseError{Err: err, Raw: append(json.RawMessage(nil), gen.Raw...)}
 	}
+	checkBulkPlausibility(parsed, req.Depth, s.bulkPrompts(slug, variables, req.Depth)...)
 
 	if isRawCaptureEnabled(s.Providers.cfg, req.IncludeRaw) {
 		parsed.Raw = append(parsed.Raw[:0], gen.Raw...)
@@ -120,6 +127,20 @@ func (s *Service) SearchBulk(ctx context.Context, req BulkSearchRequest) (*BulkS
 	return parsed, nil
 }
 
+// bulkPrompts renders the bulk prompt for the plausibility check's summary
+// comparison; a prompt that fails to render skips that comparison.
+func (s *Service) bulkPrompts(slug string, variables map[string]string, depth string) []string {
+	promptDef, err := s.Registry.Get(slug)
+	if err != nil {
+		return nil
+	}
+	system, user, err := renderPromptWithVars(promptDef, variables, depth)
+	if err != nil {
+		return nil
+	}
+	return []string{system, user}
+}
+
 func decodeBulkSearchResponseLenient(raw []byte) (*BulkSearchResponse, error) {
 	var payload map[string]any
 	if err := json.Unmarshal(raw, &payload); err != nil {
@@ -160,6 +181,13 @@ func decodeBulkSearchResponseLenient(raw []byte) (*BulkSearchResponse, error) {
 		if v, ok := obj["confidence"].(float64); ok {
 			item.Confidence = &v
 		}
+		if values, ok := obj["insights"].([]any); ok {
+			for _, value := range values {
+				if insight, ok := value.(string); ok {
+					item.Insights = append(item.Insights, insight)
+				}
+			}
+		}
 
 		parsed.Items = append(parsed.Items, item)
 	}
@@ -113,3 +113,27 @@ func TestServiceValidateResponseSearchBulkSchemaRef(t *testing.T) {
 	err := svc.validateResponse(def, []byte(valid))
 	require.NoError(t, err)
 }
+
+func TestRenderPromptAppliesSearchVariables(t *testing.T) {
+	def := &prompt.Prompt{Config: prompt.Config{
+		SystemTemplate: "Check {{name}}.{{#if languages}} Assess sentiment in {{languages}}.{{/if}}",
+		UserTemplate:   "{{input}} at {{depth}}",
+	}}
+
+	system, user, err := renderPrompt(def, "acme", "quick", map[string]string{"languages": "German, Japanese", "name": "ignored"})
+	require.NoError(t, err)
+	require.Equal(t, "Check acme. Assess sentiment in German, Japanese.", system)
+	require.Equal(t, "acme at quick", user)
+
+	system, _, err = renderPrompt(def, "acme", "quick", nil)
+	require.NoError(t, err)
+	require.Equal(t, "Check acme.", system)
+}
+
+func TestUsesSearch(t *testing.T) {
+	def := &prompt.Prompt{Config: prompt.Config{Tools: []prompt.ToolConfig{{Type: "web_search"}}}}
+	require.True(t, UsesSearch(def, true))
+	require.False(t, UsesSearch(def, false))
+	require.False(t, UsesSearch(&prompt.Prompt{Config: prompt.Config{Tools: []prompt.ToolConfig{{Type: "code_interpreter"}}}}, true))
+	require.False(t, UsesSearch(nil, true))
+}
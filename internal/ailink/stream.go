@@ -0,0 +1,170 @@
+package ailink
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/tracing"
+)
+
+// StreamProgress describes a streamed completion as it arrives.
+type StreamProgress struct {
+	PromptSlug string
+	// Bytes is the response text received so far.
+	Bytes int
+	// Fields are the top-level JSON fields received in full, in order.
+	Fields []string
+}
+
+// String renders progress for status lines, e.g. "summary, risk_level (812 B)".
+func (p StreamProgress) String() string {
+	if len(p.Fields) == 0 {
+		return fmt.Sprintf("%d B", p.Bytes)
+	}
+	return fmt.Sprintf("%s (%d B)", strings.Join(p.Fields, ", "), p.Bytes)
+}
+
+type streamProgressKey struct{}
+
+// WithStreamProgress attaches a callback that receives progress for
+// completions streamed by drivers that support it. It is called each time a
+// top-level field of the JSON response completes.
+func WithStreamProgress(ctx context.Context, fn func(StreamProgress)) context.Context {
+	return context.WithValue(ctx, streamProgressKey{}, fn)
+}
+
+// PartialResponseError is returned when a streamed completion breaks off,
+// for example at the request timeout, after some of the response arrived.
+type PartialResponseError struct {
+	Bytes  int
+	Fields []string
+	Err    error
+}
+
+func (e *PartialResponseError) Error() string {
+	return fmt.Sprintf("stream interrupted after %d bytes: %v", e.Bytes, e.Err)
+}
+
+func (e *PartialResponseError) Unwrap() error {
+	return e.Err
+}
+
+// stream runs a driver request, streaming it when the driver supports that.
+// Progress goes to the context's WithStreamProgress callback. A stream that
+// breaks off after some text arrived fails with a *PartialResponseError.
+func stream(ctx context.Context, drv driver.Driver, req *driver.Request) (resp *driver.Response, err error) {
+	streamer, ok := drv.(driver.Streamer)
+	ctx, span := tracing.Start(ctx, "ailink.complete",
+		attribute.String("gen_ai.system", drv.Name()),
+		attribute.String("gen_ai.request.model", req.Model),
+		attribute.String("namelens.prompt", req.PromptSlug),
+		attribute.Bool("namelens.streamed", ok),
+	)
+	defer func() {
+		if resp != nil && resp.Usage != nil {
+			span.SetAttributes(
+				attribute.Int("gen_ai.usage.input_tokens", resp.Usage.PromptTokens),
+				attribute.Int("gen_ai.usage.output_tokens", resp.Usage.CompletionTokens),
+			)
+		}
+		tracing.End(span, err)
+	}()
+
+	if !ok {
+		return drv.Complete(ctx, req)
+	}
+
+	report, _ := ctx.Value(streamProgressKey{}).(func(StreamProgress))
+	assembler := &jsonAssembler{}
+	resp, err = streamer.Stream(ctx, req, func(text string) {
+		if assembler.Write(text) && report != nil {
+			report(assembler.progress(req.PromptSlug))
+		}
+	})
+	if err != nil && assembler.bytes > 0 {
+		return resp, &PartialResponseError{Bytes: assembler.bytes, Fields: assembler.fields, Err: err}
+	}
+	return resp, err
+}
+
+// jsonAssembler follows a JSON object as it is streamed in arbitrary chunks
+// and reports its top-level fields as their values complete. Text around the
+// object, such as a Markdown code fence, is ignored.
+type jsonAssembler struct {
+	bytes    int
+	depth    int
+	inString bool
+	escaped  bool
+
+	readingKey bool
+	key        strings.Builder
+	pending    string
+	fields     []string
+}
+
+// Write consumes a chunk and reports whether a field completed.
+func (a *jsonAssembler) Write(chunk string) bool {
+	a.bytes += len(chunk)
+	completed := false
+	for _, r := range chunk {
+		if a.inString {
+			switch {
+			case a.escaped:
+				a.escaped = false
+			case r == '\\':
+				a.escaped = true
+			case r == '"':
+				a.inString = false
+				if a.readingKey {
+					a.readingKey = false
+					a.pending = a.key.String()
+				}
+				continue
+			}
+			if a.readingKey {
+				a.key.WriteRune(r)
+			}
+			continue
+		}
+
+		switch r {
+		case '"':
+			a.inString = true
+			if a.depth == 1 && a.pending == "" {
+				a.readingKey = true
+				a.key.Reset()
+			}
+		case '{', '[':
+			a.depth++
+		case '}', ']':
+			if a.depth == 1 {
+				completed = a.complete() || completed
+			}
+			if a.depth > 0 {
+				a.depth--
+			}
+		case ',':
+			if a.depth == 1 {
+				completed = a.complete() || completed
+			}
+		}
+	}
+	return completed
+}
+
+func (a *jsonAssembler) complete() bool {
+	if a.pending == "" {
+		return false
+	}
+	a.fields = append(a.fields, a.pending)
+	a.pending = ""
+	return true
+}
+
+func (a *jsonAssembler) progress(slug string) StreamProgress {
+	return StreamProgress{PromptSlug: slug, Bytes: a.bytes, Fields: append([]string(nil), a.fields...)}
+}
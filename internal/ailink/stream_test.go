@@ -0,0 +1,96 @@
+package ailink
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink/content"
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/ailink/prompt"
+)
+
+func TestJSONAssemblerReportsTopLevelFields(t *testing.T) {
+	assembler := &jsonAssembler{}
+	chunks := []string{"```json\n{\"sum", "mary\": \"a \\\"quoted\\\", text\",", " \"insights\": [\"x\", {\"k\": 1}]", ", \"risk_level\"", ": \"low\"}\n```"}
+
+	var completed []bool
+	for _, chunk := range chunks {
+		completed = append(completed, assembler.Write(chunk))
+	}
+	require.Equal(t, []bool{false, true, false, true, true}, completed)
+	require.Equal(t, []string{"summary", "insights", "risk_level"}, assembler.fields)
+	require.Equal(t, "summary, insights, risk_level (95 B)", assembler.progress("p").String())
+}
+
+type streamingDriver struct {
+	chunks []string
+	err    error
+}
+
+func (d *streamingDriver) Complete(ctx context.Context, req *driver.Request) (*driver.Response, error) {
+	return nil, fmt.Errorf("complete should not be called")
+}
+
+func (d *streamingDriver) Stream(ctx context.Context, req *driver.Request, onDelta func(text string)) (*driver.Response, error) {
+	text := ""
+	for _, chunk := range d.chunks {
+		text += chunk
+		onDelta(chunk)
+	}
+	return &driver.Response{Content: []content.ContentBlock{{Type: content.ContentTypeText, Text: text}}}, d.err
+}
+
+func (d *streamingDriver) Name() string { return "xai" }
+
+func (d *streamingDriver) Capabilities() driver.Capabilities {
+	return driver.Capabilities{SupportsStreaming: true}
+}
+
+func TestStreamReportsProgressAndPartialTimeouts(t *testing.T) {
+	var reports []StreamProgress
+	ctx := WithStreamProgress(context.Background(), func(p StreamProgress) { reports = append(reports, p) })
+
+	drv := &streamingDriver{chunks: []string{`{"summary":"ok",`, `"risk_level":"low"}`}}
+	resp, err := stream(ctx, drv, &driver.Request{PromptSlug: "name-availability"})
+	require.NoError(t, err)
+	require.Equal(t, `{"summary":"ok","risk_level":"low"}`, resp.Content[0].Text)
+	require.Len(t, reports, 2)
+	require.Equal(t, "name-availability", reports[1].PromptSlug)
+	require.Equal(t, []string{"summary", "risk_level"}, reports[1].Fields)
+
+	drv = &streamingDriver{chunks: []string{`{"summary":"ok",`}, err: context.DeadlineExceeded}
+	_, err = stream(context.Background(), drv, &driver.Request{})
+	var partial *PartialResponseError
+	require.ErrorAs(t, err, &partial)
+	require.Equal(t, []string{"summary"}, partial.Fields)
+
+	mapped := MapProviderError(err)
+	require.Equal(t, "AILINK_PROVIDER_TIMEOUT", mapped.Code)
+	require.Equal(t, "received 16 bytes with fields summary", mapped.Details)
+}
+
+func TestServiceSearchParsesStreamedResponse(t *testing.T) {
+	drv := &streamingDriver{chunks: []string{`{"summary":`, `"streamed"}`}}
+
+	providers := &Registry{cfg: Config{}}
+	providers.cfg.DefaultProvider = "p"
+	providers.cfg.Providers = map[string]ProviderInstanceConfig{
+		"p": {
+			Enabled:     true,
+			AIProvider:  "xai",
+			Models:      map[string]string{"default": "m"},
+			Credentials: []CredentialConfig{{APIKey: "k"}},
+		},
+	}
+	providers.drivers = map[string]driver.Driver{"p:p0": drv}
+
+	promptDef := &prompt.Prompt{Config: prompt.Config{Slug: "name-availability", SystemTemplate: "sys", UserTemplate: "usr"}}
+	svc := &Service{Providers: providers, Registry: stubPromptRegistry{prompt: promptDef}}
+
+	resp, err := svc.Search(context.Background(), SearchRequest{Name: "test", PromptSlug: "name-availability"})
+	require.NoError(t, err)
+	require.Equal(t, "streamed", resp.Summary)
+}
@@ -7,6 +7,9 @@ type SearchRequest struct {
 	Role       string
 	Name       string
 	PromptSlug string
+	// Variables are optional prompt variables (e.g. languages); name, input,
+	// and depth always come from the fields above.
+	Variables  map[string]string
 	Depth      string
 	Model      string
 	TimeoutSec int
@@ -23,7 +26,11 @@ type SearchResponse struct {
 	Insights        []string        `json:"insights,omitempty"`
 	Mentions        []SearchMention `json:"mentions,omitempty"`
 	Recommendations []string        `json:"recommendations,omitempty"`
-	Raw             json.RawMessage `json:"raw,omitempty"`
+	// Suspect marks degenerate output that failed CheckPlausibility; Warnings
+	// says why.
+	Suspect  bool            `json:"suspect,omitempty"`
+	Warnings []string        `json:"warnings,omitempty"`
+	Raw      json.RawMessage `json:"raw,omitempty"`
 }
 
 // SearchMention represents a single mention returned by the model.
@@ -53,6 +60,10 @@ type GenerateRequest struct {
 	TimeoutSec int
 	UseTools   bool
 	IncludeRaw bool
+	// Temperature and Seed are passed to providers that support them, for
+	// reproducible output; nil leaves the provider default
+	Temperature *float64
+	Seed        *int64
 }
 
 // GenerateResponse captures the raw JSON response from generation prompts.
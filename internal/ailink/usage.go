@@ -0,0 +1,139 @@
+package ailink
+
+import (
+	"context"
+	"sync"
+
+	"github.com/namelens/namelens/internal/ailink/driver"
+)
+
+// ModelPricing is the provider price for a model in USD per million tokens.
+type ModelPricing struct {
+	InputPerMillion  float64 `mapstructure:"input_per_million"`
+	OutputPerMillion float64 `mapstructure:"output_per_million"`
+}
+
+// Usage accumulates token consumption and estimated cost across provider calls.
+type Usage struct {
+	Calls            int      `json:"calls"`
+	Model            string   `json:"model,omitempty"`
+	PromptTokens     int      `json:"prompt_tokens"`
+	CompletionTokens int      `json:"completion_tokens"`
+	TotalTokens      int      `json:"total_tokens"`
+	EstimatedCostUSD *float64 `json:"estimated_cost_usd,omitempty"`
+}
+
+// Add folds another usage record into u. The cost stays unset until at least
+// one priced call has been added.
+func (u *Usage) Add(other Usage) {
+	if u == nil {
+		return
+	}
+	u.Calls += other.Calls
+	if other.Model != "" {
+		u.Model = other.Model
+	}
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.TotalTokens += other.TotalTokens
+	if other.EstimatedCostUSD != nil {
+		cost := *other.EstimatedCostUSD
+		if u.EstimatedCostUSD != nil {
+			cost += *u.EstimatedCostUSD
+		}
+		u.EstimatedCostUSD = &cost
+	}
+}
+
+// EstimateCost prices token usage for a model using the provider's pricing table.
+// It reports false when no pricing is configured for the model.
+func (p ProviderInstanceConfig) EstimateCost(model string, usage driver.Usage) (float64, bool) {
+	pricing, ok := p.Pricing[model]
+	if !ok {
+		pricing, ok = p.Pricing["default"]
+	}
+	if !ok {
+		return 0, false
+	}
+	return float64(usage.PromptTokens)*pricing.InputPerMillion/1e6 +
+		float64(usage.CompletionTokens)*pricing.OutputPerMillion/1e6, true
+}
+
+// UsageTracker collects usage for provider calls made with a tracked context.
+// Usage recorded on a nested tracker also counts toward its parent, so a
+// command can total a run while attributing calls to each name.
+type UsageTracker struct {
+	mu     sync.Mutex
+	usage  Usage
+	parent *UsageTracker
+}
+
+type usageTrackerKey struct{}
+
+// WithUsageTracker returns a context whose provider calls are recorded on the
+// returned tracker and on any tracker already attached to ctx.
+func WithUsageTracker(ctx context.Context) (context.Context, *UsageTracker) {
+	parent, _ := ctx.Value(usageTrackerKey{}).(*UsageTracker)
+	tracker := &UsageTracker{parent: parent}
+	return context.WithValue(ctx, usageTrackerKey{}, tracker), tracker
+}
+
+// Snapshot returns the usage recorded so far.
+func (t *UsageTracker) Snapshot() Usage {
+	if t == nil {
+		return Usage{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	snapshot := t.usage
+	if t.usage.EstimatedCostUSD != nil {
+		cost := *t.usage.EstimatedCostUSD
+		snapshot.EstimatedCostUSD = &cost
+	}
+	return snapshot
+}
+
+func (t *UsageTracker) add(usage Usage) {
+	for ; t != nil; t = t.parent {
+		t.mu.Lock()
+		t.usage.Add(usage)
+		t.mu.Unlock()
+	}
+}
+
+// runUsage returns the usage recorded on the outermost tracker of ctx, which
+// commands attach for the whole run.
+func runUsage(ctx context.Context) (Usage, bool) {
+	tracker, _ := ctx.Value(usageTrackerKey{}).(*UsageTracker)
+	if tracker == nil {
+		return Usage{}, false
+	}
+	for tracker.parent != nil {
+		tracker = tracker.parent
+	}
+	return tracker.Snapshot(), true
+}
+
+// complete runs a driver request and records its usage on the context tracker
+// and the daily budget.
+func (s *Service) complete(ctx context.Context, resolved *ResolvedProvider, req *driver.Request) (*driver.Response, error) {
+	resp, err := stream(ctx, resolved.Driver, req)
+
+	usage := Usage{Calls: 1, Model: req.Model}
+	if resp != nil && resp.Usage != nil {
+		usage.PromptTokens = resp.Usage.PromptTokens
+		usage.CompletionTokens = resp.Usage.CompletionTokens
+		usage.TotalTokens = resp.Usage.TotalTokens
+		if cost, ok := resolved.Provider.EstimateCost(req.Model, *resp.Usage); ok {
+			usage.EstimatedCostUSD = &cost
+		}
+	}
+	if tracker, _ := ctx.Value(usageTrackerKey{}).(*UsageTracker); tracker != nil {
+		tracker.add(usage)
+	}
+	if recordErr := s.Budget.record(ctx, usage); recordErr != nil && err == nil {
+		err = recordErr
+	}
+
+	return resp, err
+}
@@ -0,0 +1,89 @@
+package ailink
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink/content"
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/ailink/prompt"
+)
+
+type usageDriver struct{}
+
+func (usageDriver) Complete(ctx context.Context, req *driver.Request) (*driver.Response, error) {
+	return &driver.Response{
+		Content: []content.ContentBlock{{Type: content.ContentTypeText, Text: `{"summary":"ok"}`}},
+		Usage:   &driver.Usage{PromptTokens: 1000, CompletionTokens: 500, TotalTokens: 1500},
+	}, nil
+}
+
+func (usageDriver) Name() string { return "openai" }
+
+func (usageDriver) Capabilities() driver.Capabilities { return driver.Capabilities{} }
+
+func TestServiceRecordsUsageOnTracker(t *testing.T) {
+	providers := &Registry{cfg: Config{DefaultProvider: "p"}}
+	providers.cfg.Providers = map[string]ProviderInstanceConfig{
+		"p": {
+			Enabled:     true,
+			AIProvider:  "openai",
+			Models:      map[string]string{"default": "m"},
+			Credentials: []CredentialConfig{{APIKey: "k"}},
+			Pricing:     map[string]ModelPricing{"m": {InputPerMillion: 2, OutputPerMillion: 10}},
+		},
+	}
+	providers.drivers = map[string]driver.Driver{"p:p0": usageDriver{}}
+
+	promptDef := &prompt.Prompt{Config: prompt.Config{Slug: "name-availability", SystemTemplate: "sys", UserTemplate: "usr"}}
+	svc := &Service{Providers: providers, Registry: stubPromptRegistry{prompt: promptDef}}
+
+	ctx, tracker := WithUsageTracker(context.Background())
+	for i := 0; i < 2; i++ {
+		_, err := svc.Search(ctx, SearchRequest{Name: "test", PromptSlug: "name-availability"})
+		require.NoError(t, err)
+	}
+
+	usage := tracker.Snapshot()
+	require.Equal(t, 2, usage.Calls)
+	require.Equal(t, "m", usage.Model)
+	require.Equal(t, 3000, usage.TotalTokens)
+	require.NotNil(t, usage.EstimatedCostUSD)
+	require.InDelta(t, 0.014, *usage.EstimatedCostUSD, 1e-9)
+
+	// Calls made without a tracker are not recorded anywhere.
+	_, err := svc.Search(context.Background(), SearchRequest{Name: "test", PromptSlug: "name-availability"})
+	require.NoError(t, err)
+	require.Equal(t, 2, tracker.Snapshot().Calls)
+}
+
+func TestEstimateCostFallsBackToDefaultPricing(t *testing.T) {
+	provider := ProviderInstanceConfig{Pricing: map[string]ModelPricing{"default": {InputPerMillion: 1, OutputPerMillion: 1}}}
+	cost, ok := provider.EstimateCost("other", driver.Usage{PromptTokens: 500000, CompletionTokens: 500000})
+	require.True(t, ok)
+	require.InDelta(t, 1.0, cost, 1e-9)
+
+	_, ok = ProviderInstanceConfig{}.EstimateCost("m", driver.Usage{PromptTokens: 1})
+	require.False(t, ok)
+}
+
+func TestNestedUsageTrackersRollUp(t *testing.T) {
+	cost := 0.5
+	ctx, run := WithUsageTracker(context.Background())
+	nameCtx, name := WithUsageTracker(ctx)
+
+	tracker, _ := nameCtx.Value(usageTrackerKey{}).(*UsageTracker)
+	tracker.add(Usage{Calls: 1, TotalTokens: 100, EstimatedCostUSD: &cost})
+	run.add(Usage{Calls: 1, TotalTokens: 50})
+
+	require.Equal(t, 1, name.Snapshot().Calls)
+	require.Equal(t, 100, name.Snapshot().TotalTokens)
+
+	total := run.Snapshot()
+	require.Equal(t, 2, total.Calls)
+	require.Equal(t, 150, total.TotalTokens)
+	require.NotNil(t, total.EstimatedCostUSD)
+	require.InDelta(t, 0.5, *total.EstimatedCostUSD, 1e-9)
+}
@@ -0,0 +1,155 @@
+package ailink
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+// UserSchemaCategory is the catalog category for user-authored response
+// schemas. A schema at <dir>/user/v0/brand-fit.schema.json is referenced from
+// a prompt as $ref: "user/v0/brand-fit".
+const UserSchemaCategory = "user"
+
+var (
+	layeredSchemasMu    sync.Mutex
+	layeredSchemasRoots = map[string]string{}
+)
+
+// LayeredSchemaCatalog returns a catalog of the schemas under baseDir plus
+// the user schemas under userDir/user, which replace same-named user schemas
+// in baseDir. Only the user category is read from userDir, so user schemas
+// cannot replace built-in ones. When userDir has no user schemas the catalog
+// reads baseDir directly; otherwise both trees are copied to a temp
+// directory, once per process. A user schema that is not a valid JSON Schema
+// is an error rather than being skipped.
+func LayeredSchemaCatalog(baseDir, userDir string) (*schema.Catalog, error) {
+	userRoot := ""
+	if strings.TrimSpace(userDir) != "" {
+		userRoot = filepath.Join(userDir, UserSchemaCategory)
+	}
+	files, err := userSchemaFiles(userRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return schema.NewCatalog(baseDir), nil
+	}
+
+	layeredSchemasMu.Lock()
+	defer layeredSchemasMu.Unlock()
+	key := filepath.Clean(baseDir) + string(os.PathListSeparator) + filepath.Clean(userRoot)
+	if root, ok := layeredSchemasRoots[key]; ok {
+		return schema.NewCatalog(root), nil
+	}
+
+	root, err := os.MkdirTemp("", "namelens-layered-schemas-*")
+	if err != nil {
+		return nil, fmt.Errorf("create layered schema temp dir: %w", err)
+	}
+	if err := copySchemaTree(baseDir, root); err != nil {
+		_ = os.RemoveAll(root)
+		return nil, fmt.Errorf("copy schemas: %w", err)
+	}
+	if err := copySchemaTree(userRoot, filepath.Join(root, UserSchemaCategory)); err != nil {
+		_ = os.RemoveAll(root)
+		return nil, fmt.Errorf("copy user schemas: %w", err)
+	}
+
+	catalog := schema.NewCatalog(root)
+	if err := checkUserSchemas(catalog); err != nil {
+		_ = os.RemoveAll(root)
+		return nil, err
+	}
+	layeredSchemasRoots[key] = root
+	return catalog, nil
+}
+
+// userSchemaFiles lists the schema files under root; a missing root has none.
+func userSchemaFiles(root string) ([]string, error) {
+	if root == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(root); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && isSchemaFileName(d.Name()) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read user schemas: %w", err)
+	}
+	return files, nil
+}
+
+// checkUserSchemas compiles every user schema so a broken one fails when the
+// catalog is built instead of when a response is validated.
+func checkUserSchemas(catalog *schema.Catalog) error {
+	descriptors, err := catalog.ListSchemas(UserSchemaCategory + "/")
+	if err != nil {
+		return fmt.Errorf("load user schemas: %w", err)
+	}
+	for _, desc := range descriptors {
+		if _, err := catalog.ValidatorByID(desc.ID); err != nil {
+			return fmt.Errorf("user schema %s (%s): %w", desc.ID, desc.Path, err)
+		}
+	}
+	return nil
+}
+
+// copySchemaTree copies the schema files under src to dst, replacing files
+// already there.
+func copySchemaTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0o755) // #nosec G301 -- temp dir for merged schemas
+		}
+		if !isSchemaFileName(d.Name()) {
+			return nil
+		}
+		data, err := os.ReadFile(path) // #nosec G304 -- schema files from the repo or user config dir
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, rel), data, 0o644) // #nosec G306 -- read-only schema files in temp dir
+	})
+}
+
+func isSchemaFileName(name string) bool {
+	lower := strings.ToLower(name)
+	return strings.HasSuffix(lower, ".json") || strings.HasSuffix(lower, ".yaml") || strings.HasSuffix(lower, ".yml")
+}
+
+// cleanupLayeredSchemas removes the temp directories of layered catalogs.
+func cleanupLayeredSchemas() error {
+	layeredSchemasMu.Lock()
+	defer layeredSchemasMu.Unlock()
+	var errs []error
+	for key, root := range layeredSchemasRoots {
+		if err := os.RemoveAll(root); err != nil {
+			errs = append(errs, fmt.Errorf("cleanup layered schema temp dir: %w", err))
+		}
+		delete(layeredSchemasRoots, key)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,67 @@
+package ailink
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const brandFitSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "Brand fit",
+  "type": "object",
+  "required": ["fit"],
+  "properties": {"fit": {"type": "integer", "minimum": 0, "maximum": 100}}
+}`
+
+func writeUserSchema(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, UserSchemaCategory, "v0", name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestLayeredSchemaCatalogAddsUserSchemas(t *testing.T) {
+	t.Cleanup(func() { _ = CleanupStandaloneSchemas() })
+	base, err := StandaloneSchemasRoot()
+	require.NoError(t, err)
+	userDir := t.TempDir()
+	writeUserSchema(t, userDir, "brand-fit.schema.json", brandFitSchema)
+
+	catalog, err := LayeredSchemaCatalog(base, userDir)
+	require.NoError(t, err)
+
+	diagnostics, err := catalog.ValidateDataByID("user/v0/brand-fit", []byte(`{"fit": 80}`))
+	require.NoError(t, err)
+	require.Empty(t, diagnostics)
+	diagnostics, err = catalog.ValidateDataByID("user/v0/brand-fit", []byte(`{"fit": "high"}`))
+	require.NoError(t, err)
+	require.NotEmpty(t, diagnostics)
+
+	// Built-in schemas stay available
+	_, err = catalog.GetSchema("ailink/v0/search-response")
+	require.NoError(t, err)
+}
+
+func TestLayeredSchemaCatalogWithoutUserSchemas(t *testing.T) {
+	base := t.TempDir()
+	catalog, err := LayeredSchemaCatalog(base, filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	_, err = catalog.GetSchema("user/v0/brand-fit")
+	require.Error(t, err)
+
+	catalog, err = LayeredSchemaCatalog(base, "")
+	require.NoError(t, err)
+	require.NotNil(t, catalog)
+}
+
+func TestLayeredSchemaCatalogRejectsInvalidUserSchema(t *testing.T) {
+	t.Cleanup(func() { _ = CleanupStandaloneSchemas() })
+	userDir := t.TempDir()
+	writeUserSchema(t, userDir, "broken.schema.json", `{"type": 5}`)
+
+	_, err := LayeredSchemaCatalog(t.TempDir(), userDir)
+	require.ErrorContains(t, err, "user schema user/v0/broken")
+}
@@ -1,32 +1,178 @@
 package api
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/hex"
+	"fmt"
 	"net"
 	"net/http"
 	"strings"
+
+	servermw "github.com/namelens/namelens/internal/server/middleware"
+)
+
+// Role scopes what an API key may call. Roles are ordered: each one includes
+// the endpoints of the roles before it.
+type Role string
+
+const (
+	// RoleReadOnly may read status, profiles, and stored results.
+	RoleReadOnly Role = "read-only"
+	// RoleCheck may also run live availability checks.
+	RoleCheck Role = "check"
+	// RoleAdmin may also run AI analyses and generation.
+	RoleAdmin Role = "admin"
 )
 
+var roleRank = map[Role]int{RoleReadOnly: 1, RoleCheck: 2, RoleAdmin: 3}
+
+// ParseRole validates a role name.
+func ParseRole(value string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := roleRank[role]; !ok {
+		return "", fmt.Errorf("unknown API key role %q (expected read-only, check, or admin)", value)
+	}
+	return role, nil
+}
+
+// Allows reports whether r includes the permissions of required.
+func (r Role) Allows(required Role) bool {
+	return roleRank[r] >= roleRank[required]
+}
+
+// Principal identifies the caller of an authenticated request. Name is the
+// configured key name, or empty for requests allowed without a key.
+type Principal struct {
+	Name string
+	Role Role
+	// RateLimit is requests per minute on limited endpoints; zero is unlimited.
+	RateLimit int
+	// DailyQuota is requests per UTC day on limited endpoints; zero is
+	// unlimited.
+	DailyQuota int
+}
+
+// KeyStore looks up API keys kept outside the configuration, such as those
+// created with 'namelens apikey create'.
+type KeyStore interface {
+	// LookupAPIKey returns the principal of an active key.
+	LookupAPIKey(ctx context.Context, key string) (Principal, bool, error)
+	// HasAPIKeys reports whether the store holds any active key.
+	HasAPIKeys(ctx context.Context) (bool, error)
+}
+
+// defaultKeyName names the --api-key / NAMELENS_CONTROL_PLANE_API_KEY key.
+const defaultKeyName = "admin"
+
 // AuthConfig configures API authentication.
 type AuthConfig struct {
-	// APIKey is the expected API key. If empty, authentication is disabled.
+	// APIKey is the expected API key and has the admin role. If it and Keys
+	// are empty, authentication is disabled.
 	APIKey string
 
+	// Keys maps additional API keys to their name and role.
+	Keys map[string]Principal
+
+	// Store looks up keys that are not in APIKey or Keys. It enables
+	// authentication while it holds an active key.
+	Store KeyStore
+
+	// Limits enforces per-key rate limits and daily quotas on live lookups.
+	Limits *KeyLimiter
+
 	// AllowLocalhost allows unauthenticated access from localhost.
 	AllowLocalhost bool
 }
 
-// AuthMiddleware creates middleware that validates API keys.
-// Authentication is required for non-localhost requests when an API key is configured.
+// Enabled reports whether any API key is configured. Keys in Store are not
+// counted; see Required.
+func (cfg AuthConfig) Enabled() bool {
+	return cfg.APIKey != "" || len(cfg.Keys) > 0
+}
+
+// Required reports whether requests must be authenticated: a key is
+// configured, or the key store holds an active key. The store is asked on
+// every call, so keys created or revoked while the server runs take effect
+// without a restart; stores may cache the answer briefly.
+func (cfg AuthConfig) Required(ctx context.Context) (bool, error) {
+	if cfg.Enabled() {
+		return true, nil
+	}
+	if cfg.Store == nil {
+		return false, nil
+	}
+	return cfg.Store.HasAPIKeys(ctx)
+}
+
+// Authenticate returns the principal of a provided API key.
+func (cfg AuthConfig) Authenticate(key string) (Principal, bool) {
+	principal, found := Principal{}, false
+	// Compare against every key so timing does not reveal which one matched.
+	if cfg.APIKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(cfg.APIKey)) == 1 {
+		principal, found = Principal{Name: defaultKeyName, Role: RoleAdmin}, true
+	}
+	for candidate, candidatePrincipal := range cfg.Keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(candidate)) == 1 && !found {
+			principal, found = candidatePrincipal, true
+		}
+	}
+	return principal, found
+}
+
+// Resolve returns the principal of a provided API key, checking configured
+// keys before the key store.
+func (cfg AuthConfig) Resolve(ctx context.Context, key string) (Principal, bool, error) {
+	if principal, ok := cfg.Authenticate(key); ok {
+		return principal, true, nil
+	}
+	if cfg.Store == nil {
+		return Principal{}, false, nil
+	}
+	return cfg.Store.LookupAPIKey(ctx, key)
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal returns a context carrying the caller's principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller's principal set by AuthMiddleware
+// or the gRPC auth interceptor.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+	return principal, ok
+}
+
+// RoleFromContext returns the caller's role.
+func RoleFromContext(ctx context.Context) (Role, bool) {
+	principal, ok := PrincipalFromContext(ctx)
+	return principal.Role, ok
+}
+
+// anonymousAdmin is the principal of requests allowed without a key.
+var anonymousAdmin = Principal{Role: RoleAdmin}
+
+// AuthMiddleware creates middleware that validates API keys and records the
+// caller's role for RequireRole.
+// Authentication is required for non-localhost requests when an API key is
+// configured or stored.
 // If a key is provided in the request, it is always validated (even from localhost).
+// Requests allowed without a key (auth disabled, or localhost) get the admin role.
 func AuthMiddleware(cfg AuthConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// No API key configured - allow all requests
-			if cfg.APIKey == "" {
-				next.ServeHTTP(w, r)
+			required, err := cfg.Required(r.Context())
+			if err != nil {
+				writeErrorResponse(w, http.StatusInternalServerError, "internal_error", "failed to verify API key")
+				return
+			}
+			if !required {
+				next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), anonymousAdmin)))
 				return
 			}
 
@@ -36,17 +182,23 @@ func AuthMiddleware(cfg AuthConfig) func(http.Handler) http.Handler {
 			// If a key is provided, always validate it (even from localhost)
 			// This helps catch configuration errors during local development
 			if providedKey != "" {
-				if subtle.ConstantTimeCompare([]byte(providedKey), []byte(cfg.APIKey)) != 1 {
+				principal, ok, err := cfg.Resolve(r.Context(), providedKey)
+				if err != nil {
+					writeErrorResponse(w, http.StatusInternalServerError, "internal_error", "failed to verify API key")
+					return
+				}
+				if !ok {
 					writeErrorResponse(w, http.StatusUnauthorized, "unauthorized", "invalid API key")
 					return
 				}
-				next.ServeHTTP(w, r)
+				servermw.SetAPIKey(r.Context(), principal.Name)
+				next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
 				return
 			}
 
 			// No key provided - allow localhost if configured
 			if cfg.AllowLocalhost && isLocalhost(r) {
-				next.ServeHTTP(w, r)
+				next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), anonymousAdmin)))
 				return
 			}
 
@@ -56,6 +208,21 @@ func AuthMiddleware(cfg AuthConfig) func(http.Handler) http.Handler {
 	}
 }
 
+// RequireRole creates middleware that rejects callers whose role, recorded by
+// AuthMiddleware, does not include required.
+func RequireRole(required Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := RoleFromContext(r.Context())
+			if !ok || !role.Allows(required) {
+				writeErrorResponse(w, http.StatusForbidden, "forbidden", "API key role does not allow this endpoint (requires "+string(required)+")")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // isLocalhost checks if the request originated from localhost.
 func isLocalhost(r *http.Request) bool {
 	// If request includes proxy forwarding headers, do not treat as localhost.
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -100,6 +101,134 @@ func TestAuthMiddleware(t *testing.T) {
 	}
 }
 
+func TestRequireRole(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	cfg := AuthConfig{
+		APIKey: "admin-key",
+		Keys: map[string]Principal{
+			"dashboard-key": {Name: "dashboard", Role: RoleReadOnly},
+			"ci-key":        {Name: "ci", Role: RoleCheck},
+		},
+		AllowLocalhost: true,
+	}
+
+	tests := []struct {
+		name       string
+		required   Role
+		apiKey     string
+		remoteAddr string
+		wantStatus int
+	}{
+		{"read-only key reads", RoleReadOnly, "dashboard-key", "192.168.1.1:12345", http.StatusOK},
+		{"read-only key cannot check", RoleCheck, "dashboard-key", "192.168.1.1:12345", http.StatusForbidden},
+		{"check key checks", RoleCheck, "ci-key", "192.168.1.1:12345", http.StatusOK},
+		{"check key cannot run AI", RoleAdmin, "ci-key", "192.168.1.1:12345", http.StatusForbidden},
+		{"legacy key is admin", RoleAdmin, "admin-key", "192.168.1.1:12345", http.StatusOK},
+		{"localhost without key is admin", RoleAdmin, "", "127.0.0.1:12345", http.StatusOK},
+		{"unknown key rejected", RoleReadOnly, "other-key", "192.168.1.1:12345", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := AuthMiddleware(cfg)(RequireRole(tt.required)(handler))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.apiKey != "" {
+				req.Header.Set("X-API-Key", tt.apiKey)
+			}
+
+			rec := httptest.NewRecorder()
+			wrapped.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+type fakeKeyStore map[string]Principal
+
+func (f fakeKeyStore) LookupAPIKey(_ context.Context, key string) (Principal, bool, error) {
+	principal, ok := f[key]
+	return principal, ok, nil
+}
+
+func (f fakeKeyStore) HasAPIKeys(context.Context) (bool, error) {
+	return len(f) > 0, nil
+}
+
+func TestAuthMiddlewareKeyStore(t *testing.T) {
+	var seen Principal
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	cfg := AuthConfig{
+		Store: fakeKeyStore{"nlcp_stored": {Name: "partner", Role: RoleCheck, DailyQuota: 10}},
+	}
+	if required, err := cfg.Required(context.Background()); err != nil || !required {
+		t.Fatalf("a key store holding keys enables authentication (%v)", err)
+	}
+
+	for key, want := range map[string]int{"nlcp_stored": http.StatusOK, "nlcp_other": http.StatusUnauthorized, "": http.StatusUnauthorized} {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		AuthMiddleware(cfg)(handler).ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("key %q: expected status %d, got %d", key, want, rec.Code)
+		}
+	}
+	if seen.Name != "partner" || seen.DailyQuota != 10 {
+		t.Errorf("expected the stored principal, got %+v", seen)
+	}
+}
+
+func TestAuthMiddlewareEmptyKeyStore(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	store := fakeKeyStore{}
+	wrapped := AuthMiddleware(AuthConfig{Store: store})(handler)
+
+	serve := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		rec := httptest.NewRecorder()
+		wrapped.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := serve(); code != http.StatusOK {
+		t.Fatalf("an empty key store leaves authentication off, got %d", code)
+	}
+
+	// A key created while the server runs turns authentication on.
+	store["nlcp_new"] = Principal{Name: "partner", Role: RoleCheck}
+	if code := serve(); code != http.StatusUnauthorized {
+		t.Fatalf("expected a stored key to require authentication, got %d", code)
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole(" Read-Only ")
+	if err != nil || role != RoleReadOnly {
+		t.Fatalf("expected read-only, got %q (%v)", role, err)
+	}
+	if _, err := ParseRole("superuser"); err == nil {
+		t.Error("expected error for unknown role")
+	}
+	if !RoleAdmin.Allows(RoleCheck) || RoleCheck.Allows(RoleAdmin) || Role("").Allows(RoleReadOnly) {
+		t.Error("unexpected role ordering")
+	}
+}
+
 func TestGenerateAPIKey(t *testing.T) {
 	key, err := GenerateAPIKey()
 	if err != nil {
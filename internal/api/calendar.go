@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+// CalendarFunc renders the portfolio expiration calendar as iCalendar data.
+type CalendarFunc func(ctx context.Context) ([]byte, error)
+
+// SetCalendar enables GET /calendar.ics.
+func (s *Server) SetCalendar(fn CalendarFunc) {
+	s.calendar = fn
+}
+
+// GetCalendar returns upcoming portfolio domain expirations as an iCalendar
+// feed that calendar clients can subscribe to.
+// (GET /calendar.ics)
+func (s *Server) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	if s.calendar == nil {
+		writeErrorJSON(w, http.StatusNotFound, "not_found", "calendar export is not enabled")
+		return
+	}
+
+	data, err := s.calendar(r.Context())
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to build calendar")
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="namelens.ics"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetCalendar(t *testing.T) {
+	srv := NewServer(nil, "1.0.0")
+	srv.SetCalendar(func(ctx context.Context) ([]byte, error) {
+		return []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil
+	})
+
+	rec := httptest.NewRecorder()
+	srv.GetCalendar(rec, httptest.NewRequest(http.MethodGet, "/calendar.ics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/calendar") {
+		t.Errorf("expected text/calendar content type, got %q", got)
+	}
+	if !strings.HasPrefix(rec.Body.String(), "BEGIN:VCALENDAR") {
+		t.Errorf("unexpected body %q", rec.Body.String())
+	}
+}
+
+func TestGetCalendarErrors(t *testing.T) {
+	srv := NewServer(nil, "1.0.0")
+	rec := httptest.NewRecorder()
+	srv.GetCalendar(rec, httptest.NewRequest(http.MethodGet, "/calendar.ics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d when disabled, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	srv.SetCalendar(func(ctx context.Context) ([]byte, error) {
+		return nil, errors.New("store closed")
+	})
+	rec = httptest.NewRecorder()
+	srv.GetCalendar(rec, httptest.NewRequest(http.MethodGet, "/calendar.ics", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d on failure, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -14,6 +15,10 @@ import (
 type Server struct {
 	orchestrator *engine.Orchestrator
 	version      string
+	usage        UsageFunc
+	calendar     CalendarFunc
+	sharing      *Sharing
+	readOnly     bool
 }
 
 // Ensure Server implements ServerInterface at compile time.
@@ -27,6 +32,12 @@ func NewServer(orchestrator *engine.Orchestrator, version string) *Server {
 	}
 }
 
+// SetReadOnly makes mutating endpoints return 403. Lookups stay available;
+// serve makes them cache-only by running the orchestrator offline.
+func (s *Server) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
 // GetHealth returns the server health status.
 // (GET /health)
 func (s *Server) GetHealth(w http.ResponseWriter, r *http.Request) {
@@ -70,27 +81,8 @@ func (s *Server) GetStatus(w http.ResponseWriter, r *http.Request) {
 // CheckName performs a name availability check.
 // (POST /v1/check)
 func (s *Server) CheckName(w http.ResponseWriter, r *http.Request) {
-	var req CheckRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeErrorJSON(w, http.StatusBadRequest, "bad_request", "invalid JSON: "+err.Error())
-		return
-	}
-
-	// Validate name
-	name := strings.TrimSpace(req.Name)
-	if name == "" {
-		writeErrorJSON(w, http.StatusBadRequest, "bad_request", "name is required")
-		return
-	}
-	if len(name) > 63 {
-		writeErrorJSON(w, http.StatusBadRequest, "bad_request", "name exceeds maximum length of 63 characters")
-		return
-	}
-
-	// Build profile from request
-	profile, err := s.buildProfile(req.Profile, req.Tlds, req.Registries, req.Handles)
-	if err != nil {
-		writeErrorJSON(w, http.StatusBadRequest, "bad_request", err.Error())
+	name, profile, ok := s.parseCheckRequest(w, r)
+	if !ok {
 		return
 	}
 
@@ -101,24 +93,51 @@ func (s *Server) CheckName(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Convert results
+	// TODO: Add expert analysis if req.Expert is true
+
+	writeJSON(w, http.StatusOK, checkResponse(name, results))
+}
+
+// checkResponse converts the results of a check run for the API.
+func checkResponse(name string, results []*core.CheckResult) CheckResponse {
 	apiResults := make([]CheckResult, 0, len(results))
 	for _, result := range results {
 		apiResults = append(apiResults, toAPICheckResult(result))
 	}
-
-	// Calculate summary
-	summary := calculateSummary(results)
-
-	response := CheckResponse{
+	return CheckResponse{
 		Name:    name,
 		Results: apiResults,
-		Summary: summary,
+		Summary: calculateSummary(results),
 	}
+}
 
-	// TODO: Add expert analysis if req.Expert is true
+// parseCheckRequest decodes and validates a check request body, writing a
+// 4xx response and returning false when it is invalid.
+func (s *Server) parseCheckRequest(w http.ResponseWriter, r *http.Request) (string, core.Profile, bool) {
+	var req CheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeDecodeError(w, err)
+		return "", core.Profile{}, false
+	}
 
-	writeJSON(w, http.StatusOK, response)
+	// Validate name
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		writeErrorJSON(w, http.StatusBadRequest, "bad_request", "name is required")
+		return "", core.Profile{}, false
+	}
+	if len(name) > 63 {
+		writeErrorJSON(w, http.StatusBadRequest, "bad_request", "name exceeds maximum length of 63 characters")
+		return "", core.Profile{}, false
+	}
+
+	// Build profile from request
+	profile, err := s.buildProfile(req.Profile, req.Tlds, req.Registries, req.Handles)
+	if err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, "bad_request", err.Error())
+		return "", core.Profile{}, false
+	}
+	return name, profile, true
 }
 
 // CompareCandidates compares multiple name candidates.
@@ -126,7 +145,7 @@ func (s *Server) CheckName(w http.ResponseWriter, r *http.Request) {
 func (s *Server) CompareCandidates(w http.ResponseWriter, r *http.Request) {
 	var req CompareRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeErrorJSON(w, http.StatusBadRequest, "bad_request", "invalid JSON: "+err.Error())
+		writeDecodeError(w, err)
 		return
 	}
 
@@ -423,6 +442,17 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 }
 
 // writeErrorJSON writes a JSON error response.
+// writeDecodeError reports a request body that failed to decode, with 413
+// when it was cut off by the server's max_body_bytes.
+func writeDecodeError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeErrorJSON(w, http.StatusRequestEntityTooLarge, "too_large", fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit))
+		return
+	}
+	writeErrorJSON(w, http.StatusBadRequest, "bad_request", "invalid JSON: "+err.Error())
+}
+
 func writeErrorJSON(w http.ResponseWriter, status int, code, message string) {
 	writeJSON(w, status, ErrorResponse{
 		Error: Error{
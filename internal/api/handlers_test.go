@@ -160,6 +160,30 @@ func TestCheckNameValidation(t *testing.T) {
 	}
 }
 
+func TestCheckNameBodyTooLarge(t *testing.T) {
+	srv := NewServer(&engine.Orchestrator{
+		Checkers: make(map[core.CheckType]engine.Checker),
+	}, "1.0.0")
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/check", bytes.NewBufferString(`{"name":"acme"}`))
+	rec := httptest.NewRecorder()
+	// As the server's max_body_bytes middleware does
+	req.Body = http.MaxBytesReader(rec, req.Body, 8)
+
+	srv.CheckName(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status %d, got %d", http.StatusRequestEntityTooLarge, rec.Code)
+	}
+	var resp ErrorResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if resp.Error.Code != "too_large" {
+		t.Errorf("expected code too_large, got %q", resp.Error.Code)
+	}
+}
+
 func TestCompareCandidatesValidation(t *testing.T) {
 	srv := NewServer(&engine.Orchestrator{
 		Checkers: make(map[core.CheckType]engine.Checker),
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// UsageCounter counts an API key's requests per UTC day and returns the
+// day's count including the new request.
+type UsageCounter interface {
+	IncrementAPIKeyUsage(ctx context.Context, name string, at time.Time) (int, error)
+}
+
+// KeyLimiter enforces the per-minute rate limit and daily quota of each
+// API key. Rate limit windows are kept in memory, so each server replica
+// counts on its own; daily quotas go through Usage and are shared by every
+// replica using the same store. Requests allowed without a key are never
+// limited.
+type KeyLimiter struct {
+	// Usage counts requests against daily quotas; without it quotas are not
+	// enforced.
+	Usage UsageCounter
+	// Clock returns the current time; time.Now when nil.
+	Clock func() time.Time
+
+	mu      sync.Mutex
+	windows map[string]keyWindow
+}
+
+type keyWindow struct {
+	start time.Time
+	count int
+}
+
+// KeyDecision is the outcome of KeyLimiter.Allow.
+type KeyDecision struct {
+	Allowed bool
+	// Reason is "rate_limited" or "quota_exceeded" when not allowed.
+	Reason     string
+	Message    string
+	RetryAfter time.Duration
+	// Remaining is what is left of the daily quota, or -1 when unlimited.
+	Remaining int
+}
+
+// Allow counts a request by principal and reports whether it is within the
+// key's limits. Rejected requests still count toward the daily quota.
+func (l *KeyLimiter) Allow(ctx context.Context, principal Principal) (KeyDecision, error) {
+	decision := KeyDecision{Allowed: true, Remaining: -1}
+	if l == nil || principal.Name == "" {
+		return decision, nil
+	}
+	now := l.now()
+
+	if principal.RateLimit > 0 {
+		if retry, ok := l.take(principal.Name, principal.RateLimit, now); !ok {
+			return KeyDecision{
+				Reason:     "rate_limited",
+				Message:    fmt.Sprintf("API key %s is limited to %d requests per minute", principal.Name, principal.RateLimit),
+				RetryAfter: retry,
+				Remaining:  -1,
+			}, nil
+		}
+	}
+
+	if principal.DailyQuota > 0 && l.Usage != nil {
+		used, err := l.Usage.IncrementAPIKeyUsage(ctx, principal.Name, now)
+		if err != nil {
+			return KeyDecision{}, err
+		}
+		if used > principal.DailyQuota {
+			midnight := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+			return KeyDecision{
+				Reason:     "quota_exceeded",
+				Message:    fmt.Sprintf("API key %s has used its daily quota of %d requests", principal.Name, principal.DailyQuota),
+				RetryAfter: midnight.Sub(now),
+				Remaining:  0,
+			}, nil
+		}
+		decision.Remaining = principal.DailyQuota - used
+	}
+	return decision, nil
+}
+
+// take counts a request in name's current one-minute window, or returns how
+// long until the window ends when it is full.
+func (l *KeyLimiter) take(name string, limit int, now time.Time) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.windows == nil {
+		l.windows = make(map[string]keyWindow)
+	}
+	window := l.windows[name]
+	if window.start.IsZero() || !now.Before(window.start.Add(time.Minute)) {
+		window = keyWindow{start: now}
+	}
+	if window.count >= limit {
+		return window.start.Add(time.Minute).Sub(now), false
+	}
+	window.count++
+	l.windows[name] = window
+	return 0, true
+}
+
+// Middleware rejects requests over the caller's limits with 429 and a
+// Retry-After header. It must run after AuthMiddleware.
+func (l *KeyLimiter) Middleware(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := PrincipalFromContext(r.Context())
+		decision, err := l.Allow(r.Context(), principal)
+		if err != nil {
+			writeErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to check API key quota")
+			return
+		}
+		if principal.DailyQuota > 0 && decision.Remaining >= 0 {
+			w.Header().Set("X-Quota-Limit", strconv.Itoa(principal.DailyQuota))
+			w.Header().Set("X-Quota-Remaining", strconv.Itoa(decision.Remaining))
+		}
+		if !decision.Allowed {
+			seconds := int(math.Ceil(decision.RetryAfter.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+			writeErrorJSON(w, http.StatusTooManyRequests, decision.Reason, decision.Message)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (l *KeyLimiter) now() time.Time {
+	if l.Clock != nil {
+		return l.Clock()
+	}
+	return time.Now()
+}
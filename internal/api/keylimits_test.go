@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUsage map[string]int
+
+func (f fakeUsage) IncrementAPIKeyUsage(_ context.Context, name string, at time.Time) (int, error) {
+	key := name + "|" + at.UTC().Format("2006-01-02")
+	f[key]++
+	return f[key], nil
+}
+
+func TestKeyLimiterRateLimit(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := &KeyLimiter{Clock: func() time.Time { return now }}
+	principal := Principal{Name: "partner", Role: RoleCheck, RateLimit: 2}
+
+	for i := 0; i < 2; i++ {
+		decision, err := limiter.Allow(ctx, principal)
+		require.NoError(t, err)
+		require.True(t, decision.Allowed)
+	}
+
+	now = now.Add(15 * time.Second)
+	decision, err := limiter.Allow(ctx, principal)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "rate_limited", decision.Reason)
+	require.Equal(t, 45*time.Second, decision.RetryAfter)
+
+	// Other keys have their own window
+	decision, err = limiter.Allow(ctx, Principal{Name: "other", RateLimit: 2})
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+
+	now = now.Add(45 * time.Second)
+	decision, err = limiter.Allow(ctx, principal)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed, "a new window starts after a minute")
+}
+
+func TestKeyLimiterDailyQuota(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	limiter := &KeyLimiter{Usage: fakeUsage{}, Clock: func() time.Time { return now }}
+	principal := Principal{Name: "partner", Role: RoleCheck, DailyQuota: 2}
+
+	decision, err := limiter.Allow(ctx, principal)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Equal(t, 1, decision.Remaining)
+
+	_, err = limiter.Allow(ctx, principal)
+	require.NoError(t, err)
+	decision, err = limiter.Allow(ctx, principal)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "quota_exceeded", decision.Reason)
+	require.Equal(t, 2*time.Hour, decision.RetryAfter, "quotas reset at UTC midnight")
+
+	// Requests allowed without a key are never limited
+	decision, err = limiter.Allow(ctx, Principal{Role: RoleAdmin})
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+}
+
+func TestKeyLimiterMiddleware(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	limiter := &KeyLimiter{Usage: fakeUsage{}, Clock: func() time.Time { return now }}
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	principal := Principal{Name: "partner", Role: RoleCheck, DailyQuota: 1}
+
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/check", nil)
+		req = req.WithContext(WithPrincipal(req.Context(), principal))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("X-Quota-Limit"))
+	require.Equal(t, "0", rec.Header().Get("X-Quota-Remaining"))
+
+	rec = serve()
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "43200", rec.Header().Get("Retry-After"))
+	require.Contains(t, rec.Body.String(), "quota_exceeded")
+
+	// A nil limiter passes requests through
+	var none *KeyLimiter
+	require.NotNil(t, none.Middleware(http.NotFoundHandler()))
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Share kinds.
+const (
+	ShareKindCompare = "compare"
+	ShareKindReview  = "review"
+)
+
+// maxShareBodyBytes caps a share request; snapshots are results, not uploads.
+const maxShareBodyBytes = 1 << 20
+
+// ShareRecord is an immutable result snapshot behind a share link.
+type ShareRecord struct {
+	ID        string
+	Kind      string
+	Title     string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// ShareStore persists share snapshots.
+type ShareStore interface {
+	SaveShare(ctx context.Context, record ShareRecord) error
+	// LoadShare returns the unexpired snapshot with id, or nil.
+	LoadShare(ctx context.Context, id string, now time.Time) (*ShareRecord, error)
+}
+
+// Sharing configures share links. Links are signed with Secret and expire
+// after TTL, or a requested TTL of at most MaxTTL.
+type Sharing struct {
+	Store  ShareStore
+	Secret []byte
+	TTL    time.Duration
+	MaxTTL time.Duration
+	// BaseURL is the public URL links point at; empty uses the request's
+	// scheme and host.
+	BaseURL string
+	// Now returns the current time; nil uses time.Now.
+	Now func() time.Time
+}
+
+// ShareRequest is the body of POST /v1/share.
+type ShareRequest struct {
+	// Kind is the result type: compare or review.
+	Kind  string `json:"kind"`
+	Title string `json:"title,omitempty"`
+	// Result is the JSON output of compare, or of review for one or more
+	// names.
+	Result json.RawMessage `json:"result"`
+	// TTL is how long the link stays valid, as a Go duration like "72h".
+	TTL string `json:"ttl,omitempty"`
+}
+
+// ShareResponse describes a created share link.
+type ShareResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SetSharing enables POST /v1/share and GET /share/{token}.
+func (s *Server) SetSharing(sharing *Sharing) {
+	s.sharing = sharing
+}
+
+// CreateShare snapshots a compare or review result and returns a signed,
+// expiring link to a read-only view of it.
+// (POST /v1/share)
+func (s *Server) CreateShare(w http.ResponseWriter, r *http.Request) {
+	if s.sharing == nil || s.sharing.Store == nil {
+		writeErrorJSON(w, http.StatusNotFound, "not_found", "share links are not enabled")
+		return
+	}
+	if s.readOnly {
+		writeErrorJSON(w, http.StatusForbidden, "read_only", "server is read-only")
+		return
+	}
+
+	var req ShareRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxShareBodyBytes)).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeErrorJSON(w, http.StatusRequestEntityTooLarge, "too_large", "share request exceeds 1 MiB")
+			return
+		}
+		writeErrorJSON(w, http.StatusBadRequest, "bad_request", "invalid JSON: "+err.Error())
+		return
+	}
+
+	req.Kind = strings.ToLower(strings.TrimSpace(req.Kind))
+	if err := validateSharePayload(req.Kind, req.Result); err != nil {
+		writeErrorJSON(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	ttl := s.sharing.TTL
+	if strings.TrimSpace(req.TTL) != "" {
+		parsed, err := time.ParseDuration(strings.TrimSpace(req.TTL))
+		if err != nil || parsed <= 0 {
+			writeErrorJSON(w, http.StatusBadRequest, "bad_request", "ttl must be a positive duration like 72h")
+			return
+		}
+		ttl = parsed
+	}
+	if s.sharing.MaxTTL > 0 && ttl > s.sharing.MaxTTL {
+		writeErrorJSON(w, http.StatusBadRequest, "bad_request", "ttl exceeds the maximum of "+s.sharing.MaxTTL.String())
+		return
+	}
+
+	id, err := newShareID()
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to create share")
+		return
+	}
+	now := s.sharing.now().UTC().Truncate(time.Second)
+	record := ShareRecord{
+		ID:        id,
+		Kind:      req.Kind,
+		Title:     strings.TrimSpace(req.Title),
+		Payload:   req.Result,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.sharing.Store.SaveShare(r.Context(), record); err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to save share")
+		return
+	}
+
+	token := s.sharing.sign(record.ID, record.ExpiresAt)
+	writeJSON(w, http.StatusCreated, ShareResponse{
+		Token:     token,
+		URL:       s.sharing.baseURL(r) + "/share/" + token,
+		ExpiresAt: record.ExpiresAt,
+	})
+}
+
+// GetShare renders a shared snapshot as a read-only HTML page. The signed
+// token is the only credential, so the route needs no API key.
+// (GET /share/{token})
+func (s *Server) GetShare(w http.ResponseWriter, r *http.Request) {
+	if s.sharing == nil || s.sharing.Store == nil {
+		writeErrorJSON(w, http.StatusNotFound, "not_found", "share links are not enabled")
+		return
+	}
+
+	now := s.sharing.now()
+	id, ok := s.sharing.verify(chi.URLParam(r, "token"), now)
+	if !ok {
+		writeSharePage(w, http.StatusNotFound, shareGonePage())
+		return
+	}
+	record, err := s.sharing.Store.LoadShare(r.Context(), id, now)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to load share")
+		return
+	}
+	if record == nil {
+		writeSharePage(w, http.StatusNotFound, shareGonePage())
+		return
+	}
+
+	page, err := newSharePage(record)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to render share")
+		return
+	}
+	writeSharePage(w, http.StatusOK, page)
+}
+
+// validateSharePayload checks that result holds the output kind names, so a
+// link never points at a page that cannot render.
+func validateSharePayload(kind string, result json.RawMessage) error {
+	if len(bytes.TrimSpace(result)) == 0 {
+		return errors.New("result is required")
+	}
+	switch kind {
+	case ShareKindCompare:
+		var compare CompareResponse
+		if err := json.Unmarshal(result, &compare); err != nil {
+			return errors.New("result is not a compare response: " + err.Error())
+		}
+		if len(compare.Candidates) == 0 {
+			return errors.New("compare result has no candidates")
+		}
+	case ShareKindReview:
+		reviews, err := decodeShareReviews(result)
+		if err != nil {
+			return errors.New("result is not review output: " + err.Error())
+		}
+		if len(reviews) == 0 {
+			return errors.New("review result has no names")
+		}
+		for _, review := range reviews {
+			if strings.TrimSpace(review.Name) == "" {
+				return errors.New("review result is missing a name")
+			}
+		}
+	default:
+		return errors.New("kind must be compare or review")
+	}
+	return nil
+}
+
+// newShareID returns a random, URL-safe share ID.
+func newShareID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (sh *Sharing) now() time.Time {
+	if sh.Now != nil {
+		return sh.Now()
+	}
+	return time.Now()
+}
+
+// sign returns the token for a share: its ID, expiry, and an HMAC-SHA256 of
+// both, so links cannot be forged or extended.
+func (sh *Sharing) sign(id string, expiresAt time.Time) string {
+	payload := id + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + sh.mac(payload)
+}
+
+// verify checks a token's signature and expiry and returns its share ID.
+func (sh *Sharing) verify(token string, now time.Time) (string, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", false
+	}
+	payload := parts[0] + "." + parts[1]
+	if subtle.ConstantTimeCompare([]byte(sh.mac(payload)), []byte(parts[2])) != 1 {
+		return "", false
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || !now.Before(time.Unix(expires, 0)) {
+		return "", false
+	}
+	return parts[0], true
+}
+
+func (sh *Sharing) mac(payload string) string {
+	h := hmac.New(sha256.New, sh.Secret)
+	_, _ = h.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+func (sh *Sharing) baseURL(r *http.Request) string {
+	if base := strings.TrimRight(strings.TrimSpace(sh.BaseURL), "/"); base != "" {
+		return base
+	}
+	scheme := "http"
+	if r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https") {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
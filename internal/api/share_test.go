@@ -0,0 +1,209 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type memoryShareStore struct {
+	records map[string]ShareRecord
+}
+
+func (m *memoryShareStore) SaveShare(ctx context.Context, record ShareRecord) error {
+	m.records[record.ID] = record
+	return nil
+}
+
+func (m *memoryShareStore) LoadShare(ctx context.Context, id string, now time.Time) (*ShareRecord, error) {
+	record, ok := m.records[id]
+	if !ok || !now.Before(record.ExpiresAt) {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func newShareTestServer(now *time.Time) (*Server, http.Handler) {
+	srv := NewServer(nil, "1.0.0")
+	srv.SetSharing(&Sharing{
+		Store:   &memoryShareStore{records: map[string]ShareRecord{}},
+		Secret:  []byte("test-secret"),
+		TTL:     24 * time.Hour,
+		MaxTTL:  72 * time.Hour,
+		BaseURL: "https://names.example.com/",
+		Now:     func() time.Time { return *now },
+	})
+	r := chi.NewRouter()
+	r.Post("/v1/share", srv.CreateShare)
+	r.Get("/share/{token}", srv.GetShare)
+	return srv, r
+}
+
+func postShare(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/share", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func getShare(handler http.Handler, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+const shareCompareBody = `{
+	"kind": "compare",
+	"title": "Finalists <q1>",
+	"result": {"candidates": [
+		{"name": "acme", "summary": {"available": 1, "taken": 1, "total": 2, "unknown": 0},
+		 "results": [
+			{"name": "acme.com", "check_type": "domain", "available": "taken"},
+			{"name": "acme", "check_type": "npm", "available": "available"}
+		 ]},
+		{"name": "zeta", "summary": {"available": 2, "taken": 0, "total": 2, "unknown": 0}, "results": []}
+	]}
+}`
+
+func TestShareRoundTrip(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	_, handler := newShareTestServer(&now)
+
+	rec := postShare(t, handler, shareCompareBody)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var resp ShareResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.ExpiresAt.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("expected expiry %s, got %s", now.Add(24*time.Hour), resp.ExpiresAt)
+	}
+	if resp.URL != "https://names.example.com/share/"+resp.Token {
+		t.Errorf("unexpected URL %q", resp.URL)
+	}
+
+	rec = getShare(handler, "/share/"+resp.Token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	page := rec.Body.String()
+	for _, want := range []string{"Finalists &lt;q1&gt;", "acme.com", `class="taken"`, "zeta", "2/2 available"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("share page missing %q", want)
+		}
+	}
+	if got := rec.Header().Get("X-Robots-Tag"); got != "noindex" {
+		t.Errorf("expected X-Robots-Tag noindex, got %q", got)
+	}
+
+	// A tampered token, or one past its expiry, shows the expired page
+	id, rest, _ := strings.Cut(resp.Token, ".")
+	if rec := getShare(handler, "/share/"+id+"x."+rest); rec.Code != http.StatusNotFound {
+		t.Errorf("tampered token: expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+	now = now.Add(25 * time.Hour)
+	if rec := getShare(handler, "/share/"+resp.Token); rec.Code != http.StatusNotFound {
+		t.Errorf("expired token: expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestShareReview(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	_, handler := newShareTestServer(&now)
+
+	body := `{"kind": "review", "ttl": "48h", "result": [{
+		"name": "acme",
+		"availability": {"results": [{"name": "acme.io", "check_type": "domain", "available": "available"}], "score": 1, "total": 1},
+		"analyses": {"brand-safety": {"ok": true, "data": {"risk": "low"}}}
+	}]}`
+	rec := postShare(t, handler, body)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var resp ShareResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.ExpiresAt.Equal(now.Add(48 * time.Hour)) {
+		t.Errorf("expected expiry %s, got %s", now.Add(48*time.Hour), resp.ExpiresAt)
+	}
+
+	page := getShare(handler, "/share/"+resp.Token).Body.String()
+	for _, want := range []string{"acme.io", "brand-safety", "&#34;risk&#34;: &#34;low&#34;"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("share page missing %q", want)
+		}
+	}
+}
+
+func TestCreateShareRejectsInvalidRequests(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	_, handler := newShareTestServer(&now)
+
+	cases := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"unknown kind", `{"kind": "check", "result": {}}`, http.StatusBadRequest},
+		{"missing result", `{"kind": "compare"}`, http.StatusBadRequest},
+		{"no candidates", `{"kind": "compare", "result": {"candidates": []}}`, http.StatusBadRequest},
+		{"review without name", `{"kind": "review", "result": {"availability": {}}}`, http.StatusBadRequest},
+		{"ttl over maximum", `{"kind": "review", "ttl": "96h", "result": {"name": "acme"}}`, http.StatusBadRequest},
+		{"invalid ttl", `{"kind": "review", "ttl": "soon", "result": {"name": "acme"}}`, http.StatusBadRequest},
+		{"too large", `{"kind": "review", "title": "` + string(bytes.Repeat([]byte("x"), maxShareBodyBytes)) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range cases {
+		if rec := postShare(t, handler, tc.body); rec.Code != tc.want {
+			t.Errorf("%s: expected status %d, got %d", tc.name, tc.want, rec.Code)
+		}
+	}
+}
+
+func TestShareDisabled(t *testing.T) {
+	srv := NewServer(nil, "1.0.0")
+
+	rec := httptest.NewRecorder()
+	srv.CreateShare(rec, httptest.NewRequest(http.MethodPost, "/v1/share", strings.NewReader(shareCompareBody)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestShareReadOnly(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	srv, handler := newShareTestServer(&now)
+
+	rec := postShare(t, handler, shareCompareBody)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var resp ShareResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// Read-only servers reject new shares but keep serving existing ones
+	srv.SetReadOnly(true)
+	rec = postShare(t, handler, shareCompareBody)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"read_only"`) {
+		t.Errorf("expected read_only error code, got %s", rec.Body.String())
+	}
+	if rec := getShare(handler, "/share/"+resp.Token); rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
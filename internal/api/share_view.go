@@ -0,0 +1,219 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// shareReview is the part of review output a share page shows.
+type shareReview struct {
+	Name         string `json:"name"`
+	Profile      string `json:"profile"`
+	Mode         string `json:"mode"`
+	Availability struct {
+		Results []shareResult `json:"results"`
+		Score   int           `json:"score"`
+		Total   int           `json:"total"`
+		Unknown int           `json:"unknown"`
+	} `json:"availability"`
+	Analyses map[string]struct {
+		OK   bool            `json:"ok"`
+		Data json.RawMessage `json:"data"`
+	} `json:"analyses"`
+}
+
+// shareResult is a check result as both compare and review output it.
+type shareResult struct {
+	Name      string `json:"name"`
+	CheckType string `json:"check_type"`
+	Available string `json:"available"`
+	Message   string `json:"message"`
+}
+
+type sharePage struct {
+	Title          string
+	Kind           string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	Recommendation string
+	Candidates     []shareCandidate
+	Gone           bool
+}
+
+type shareCandidate struct {
+	Name     string
+	Summary  string
+	Expert   string
+	Results  []shareResult
+	Analyses []shareAnalysis
+}
+
+type shareAnalysis struct {
+	Name string
+	OK   bool
+	Body string
+}
+
+// decodeShareReviews decodes review output for one name or several.
+func decodeShareReviews(data json.RawMessage) ([]shareReview, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var reviews []shareReview
+		if err := json.Unmarshal(trimmed, &reviews); err != nil {
+			return nil, err
+		}
+		return reviews, nil
+	}
+	var review shareReview
+	if err := json.Unmarshal(trimmed, &review); err != nil {
+		return nil, err
+	}
+	return []shareReview{review}, nil
+}
+
+func newSharePage(record *ShareRecord) (*sharePage, error) {
+	page := &sharePage{
+		Title:     record.Title,
+		Kind:      record.Kind,
+		CreatedAt: record.CreatedAt,
+		ExpiresAt: record.ExpiresAt,
+	}
+
+	switch record.Kind {
+	case ShareKindCompare:
+		var compare CompareResponse
+		if err := json.Unmarshal(record.Payload, &compare); err != nil {
+			return nil, err
+		}
+		if compare.Recommendation != nil {
+			page.Recommendation = *compare.Recommendation
+		}
+		for _, c := range compare.Candidates {
+			candidate := shareCandidate{
+				Name:    c.Name,
+				Summary: fmt.Sprintf("%d/%d available", c.Summary.Available, c.Summary.Total),
+			}
+			if c.Expert != nil && c.Expert.Summary != nil {
+				candidate.Expert = *c.Expert.Summary
+			}
+			for _, r := range c.Results {
+				result := shareResult{Name: r.Name, CheckType: string(r.CheckType), Available: string(r.Available)}
+				if r.Message != nil {
+					result.Message = *r.Message
+				}
+				candidate.Results = append(candidate.Results, result)
+			}
+			page.Candidates = append(page.Candidates, candidate)
+		}
+	case ShareKindReview:
+		reviews, err := decodeShareReviews(record.Payload)
+		if err != nil {
+			return nil, err
+		}
+		for _, review := range reviews {
+			availability := review.Availability
+			candidate := shareCandidate{
+				Name:    review.Name,
+				Summary: fmt.Sprintf("%d/%d available", availability.Score, availability.Total),
+				Results: availability.Results,
+			}
+			if availability.Unknown > 0 {
+				candidate.Summary += fmt.Sprintf(" (%d unknown)", availability.Unknown)
+			}
+			names := make([]string, 0, len(review.Analyses))
+			for name := range review.Analyses {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				analysis := review.Analyses[name]
+				body := ""
+				var pretty bytes.Buffer
+				if len(analysis.Data) > 0 && json.Indent(&pretty, analysis.Data, "", "  ") == nil {
+					body = pretty.String()
+				}
+				candidate.Analyses = append(candidate.Analyses, shareAnalysis{Name: name, OK: analysis.OK, Body: body})
+			}
+			page.Candidates = append(page.Candidates, candidate)
+		}
+	default:
+		return nil, fmt.Errorf("unknown share kind %q", record.Kind)
+	}
+	return page, nil
+}
+
+func shareGonePage() *sharePage {
+	return &sharePage{Gone: true}
+}
+
+func writeSharePage(w http.ResponseWriter, status int, page *sharePage) {
+	var buf bytes.Buffer
+	if err := shareTemplate.Execute(&buf, page); err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to render share")
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// Share pages are read-only snapshots; keep them out of search results
+	w.Header().Set("X-Robots-Tag", "noindex")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+var shareTemplate = template.Must(template.New("share").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{if .Gone}}Link expired{{else if .Title}}{{.Title}}{{else}}Name {{.Kind}}{{end}} · namelens</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 60rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin: 0.5rem 0 1.5rem; }
+th, td { text-align: left; padding: 0.3rem 0.6rem; border-bottom: 1px solid #ddd; }
+.available { color: #17803d; } .taken { color: #b42318; }
+.meta { color: #666; font-size: 0.9rem; }
+pre { background: #f5f5f5; padding: 0.75rem; overflow-x: auto; }
+</style>
+</head>
+<body>
+{{- if .Gone}}
+<h1>Link expired</h1>
+<p>This share link has expired or does not exist. Ask the sender for a new one.</p>
+{{- else}}
+<h1>{{if .Title}}{{.Title}}{{else}}Name {{.Kind}}{{end}}</h1>
+<p class="meta">Read-only snapshot taken {{date .CreatedAt}}; this link expires {{date .ExpiresAt}}.</p>
+{{- if .Recommendation}}
+<p><strong>Recommendation:</strong> {{.Recommendation}}</p>
+{{- end}}
+{{- range .Candidates}}
+<h2>{{.Name}} <small class="meta">{{.Summary}}</small></h2>
+{{- if .Expert}}
+<p>{{.Expert}}</p>
+{{- end}}
+<table>
+<tr><th>Type</th><th>Name</th><th>Status</th><th>Notes</th></tr>
+{{- range .Results}}
+<tr><td>{{.CheckType}}</td><td>{{.Name}}</td><td class="{{.Available}}">{{.Available}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</table>
+{{- range .Analyses}}
+<details>
+<summary>{{.Name}}{{if not .OK}} (failed){{end}}</summary>
+{{- if .Body}}
+<pre>{{.Body}}</pre>
+{{- end}}
+</details>
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// StreamStep is the data of a "progress" event of POST /v1/check/stream.
+type StreamStep struct {
+	Step string `json:"step"`
+}
+
+// CheckStream runs a check like CheckName but answers with server-sent
+// events, so clients can show results as each lookup resolves:
+//
+//	event: progress  StreamStep, before each lookup
+//	event: result    CheckResult, as each lookup resolves
+//	event: done      CheckResponse, once the run is complete
+//	event: error     ErrorResponse, if the run fails
+//
+// Invalid requests are rejected with a JSON error before the stream starts.
+// (POST /v1/check/stream)
+func (s *Server) CheckStream(w http.ResponseWriter, r *http.Request) {
+	name, profile, ok := s.parseCheckRequest(w, r)
+	if !ok {
+		return
+	}
+
+	events := newEventStream(w)
+	ctx := core.WithProgress(r.Context(), func(step string) {
+		events.send("progress", StreamStep{Step: step})
+	})
+	ctx = core.WithResults(ctx, func(result *core.CheckResult) {
+		events.send("result", toAPICheckResult(result))
+	})
+
+	results, err := s.orchestrator.Check(ctx, name, profile)
+	if err != nil {
+		events.send("error", ErrorResponse{Error: Error{Code: "internal_error", Message: err.Error()}})
+		return
+	}
+	events.send("done", checkResponse(name, results))
+}
+
+// eventStream writes server-sent events, flushing after each one.
+type eventStream struct {
+	mu sync.Mutex
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func newEventStream(w http.ResponseWriter) *eventStream {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Keep reverse proxies such as nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	return &eventStream{w: w, rc: http.NewResponseController(w)}
+}
+
+// send writes one event; write errors mean the client went away, and the
+// request context ends the run.
+func (e *eventStream) send(event string, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, err := fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return
+	}
+	_ = e.rc.Flush()
+}
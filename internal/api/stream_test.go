@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+type streamChecker struct{}
+
+func (streamChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	return &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable}, nil
+}
+
+func (streamChecker) Type() core.CheckType { return core.CheckTypeDomain }
+
+func (streamChecker) SupportsName(name string) bool { return true }
+
+func TestCheckStream(t *testing.T) {
+	srv := NewServer(&engine.Orchestrator{
+		Checkers: map[core.CheckType]engine.Checker{core.CheckTypeDomain: streamChecker{}},
+	}, "1.0.0")
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/check/stream", bytes.NewBufferString(`{"name":"acme","tlds":["com","io"]}`))
+	rec := httptest.NewRecorder()
+	srv.CheckStream(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("expected an event stream, got %q", got)
+	}
+
+	var events []string
+	for _, line := range strings.Split(rec.Body.String(), "\n") {
+		if event, ok := strings.CutPrefix(line, "event: "); ok {
+			events = append(events, event)
+		}
+	}
+	want := []string{"progress", "result", "progress", "result", "done"}
+	if strings.Join(events, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected events %v, got %v", want, events)
+	}
+	if !strings.Contains(rec.Body.String(), `"name":"acme.io"`) {
+		t.Errorf("expected a result for acme.io, got %s", rec.Body.String())
+	}
+}
+
+func TestCheckStreamInvalidRequest(t *testing.T) {
+	srv := NewServer(&engine.Orchestrator{}, "1.0.0")
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/check/stream", bytes.NewBufferString(`{"name":""}`))
+	rec := httptest.NewRecorder()
+	srv.CheckStream(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("expected a JSON error before the stream starts, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"net/http"
+)
+
+// QuotaUsage reports a subject's AI calls for the current UTC day and month
+// against its quota. Zero limits are unlimited.
+type QuotaUsage struct {
+	Subject      string `json:"subject"`
+	Daily        int    `json:"daily"`
+	DailyLimit   int    `json:"daily_limit"`
+	Monthly      int    `json:"monthly"`
+	MonthlyLimit int    `json:"monthly_limit"`
+}
+
+// UsageResponse is the body of GET /v1/usage.
+type UsageResponse struct {
+	Subjects []QuotaUsage `json:"subjects"`
+}
+
+// UsageFunc reports AI quota usage for subject, or for every subject when
+// subject is empty.
+type UsageFunc func(ctx context.Context, subject string) ([]QuotaUsage, error)
+
+// SetUsage enables GET /v1/usage.
+func (s *Server) SetUsage(fn UsageFunc) {
+	s.usage = fn
+}
+
+// GetUsage returns AI quota usage. Admin callers see every subject; other
+// keys see only their own.
+// (GET /v1/usage)
+func (s *Server) GetUsage(w http.ResponseWriter, r *http.Request) {
+	if s.usage == nil {
+		writeErrorJSON(w, http.StatusNotFound, "not_found", "usage reporting is not enabled")
+		return
+	}
+
+	subject := ""
+	if principal, ok := PrincipalFromContext(r.Context()); ok && !principal.Role.Allows(RoleAdmin) {
+		subject = principal.Name
+	}
+
+	subjects, err := s.usage(r.Context(), subject)
+	if err != nil {
+		writeErrorJSON(w, http.StatusInternalServerError, "internal_error", "failed to read usage")
+		return
+	}
+	if subjects == nil {
+		subjects = []QuotaUsage{}
+	}
+	writeJSON(w, http.StatusOK, UsageResponse{Subjects: subjects})
+}
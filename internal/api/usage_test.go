@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetUsageScopesToCaller(t *testing.T) {
+	srv := NewServer(nil, "1.0.0")
+	srv.SetUsage(func(ctx context.Context, subject string) ([]QuotaUsage, error) {
+		if subject != "" {
+			return []QuotaUsage{{Subject: subject, Daily: 1}}, nil
+		}
+		return []QuotaUsage{{Subject: "ci"}, {Subject: "default"}}, nil
+	})
+
+	cases := []struct {
+		name      string
+		principal Principal
+		want      int
+	}{
+		{"admin", Principal{Name: "ops", Role: RoleAdmin}, 2},
+		{"read-only", Principal{Name: "dash", Role: RoleReadOnly}, 1},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/v1/usage", nil)
+		req = req.WithContext(WithPrincipal(req.Context(), tc.principal))
+		rec := httptest.NewRecorder()
+
+		srv.GetUsage(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d", tc.name, http.StatusOK, rec.Code)
+		}
+		var resp UsageResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", tc.name, err)
+		}
+		if len(resp.Subjects) != tc.want {
+			t.Errorf("%s: expected %d subjects, got %d", tc.name, tc.want, len(resp.Subjects))
+		}
+	}
+}
+
+func TestGetUsageDisabled(t *testing.T) {
+	srv := NewServer(nil, "1.0.0")
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/usage", nil)
+	rec := httptest.NewRecorder()
+
+	srv.GetUsage(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,171 @@
+// Package audit records every outbound request namelens makes (RDAP, WHOIS,
+// DNS, registries, handles, and AI providers) to an NDJSON file, so a result
+// like "unknown" can be traced back to the request that caused it. Recording
+// is a no-op until Enable is called.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/namelens/namelens/internal/redact"
+	"github.com/namelens/namelens/internal/tracing"
+)
+
+// Request kinds.
+const (
+	KindRDAP      = "rdap"
+	KindWHOIS     = "whois"
+	KindDNS       = "dns"
+	KindBootstrap = "bootstrap"
+	KindRegistry  = "registry"
+	KindHandle    = "handle"
+	KindHTTP      = "http"
+	KindAI        = "ai"
+)
+
+// Entry is one outbound request.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Kind      string    `json:"kind"`
+	Method    string    `json:"method,omitempty"`
+	// URL is the request URL; WHOIS and DNS use whois://server/query and
+	// dns:name?type=A.
+	URL        string     `json:"url"`
+	Status     int        `json:"status,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+	Error      string     `json:"error,omitempty"`
+	RateLimit  *RateLimit `json:"rate_limit,omitempty"`
+	TraceID    string     `json:"trace_id,omitempty"`
+}
+
+// RateLimit is the rate-limit state a server reported with its response.
+type RateLimit struct {
+	// Limited is set when the server refused the request with 429
+	Limited    bool   `json:"limited,omitempty"`
+	Limit      string `json:"limit,omitempty"`
+	Remaining  string `json:"remaining,omitempty"`
+	Reset      string `json:"reset,omitempty"`
+	RetryAfter string `json:"retry_after,omitempty"`
+}
+
+// Logger appends entries to a file.
+type Logger struct {
+	file *os.File
+	mu   sync.Mutex
+}
+
+var (
+	global   *Logger
+	globalMu sync.Mutex
+)
+
+// Enable starts recording to path, appending to an existing file and
+// creating its directory. It returns a function that stops recording and
+// closes the file.
+func Enable(path string) (func(), error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("audit log path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) // #nosec G304 -- configured audit log path
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+
+	logger := &Logger{file: f}
+	globalMu.Lock()
+	previous := global
+	global = logger
+	globalMu.Unlock()
+	if previous != nil {
+		_ = previous.Close()
+	}
+	return func() {
+		globalMu.Lock()
+		if global == logger {
+			global = nil
+		}
+		globalMu.Unlock()
+		_ = logger.Close()
+	}, nil
+}
+
+// Disable stops recording and closes the file.
+func Disable() {
+	globalMu.Lock()
+	logger := global
+	global = nil
+	globalMu.Unlock()
+	if logger != nil {
+		_ = logger.Close()
+	}
+}
+
+// Enabled reports whether requests are being recorded.
+func Enabled() bool {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	return global != nil
+}
+
+// Record writes entry when recording is enabled, adding the trace ID from
+// ctx when there is one.
+func Record(ctx context.Context, entry Entry) {
+	globalMu.Lock()
+	logger := global
+	globalMu.Unlock()
+	if logger == nil {
+		return
+	}
+	if entry.TraceID == "" {
+		entry.TraceID = tracing.TraceID(ctx)
+	}
+	logger.Write(entry)
+}
+
+// Write records entry, redacting credentials from its URL and error.
+func (l *Logger) Write(entry Entry) {
+	if l == nil {
+		return
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+	entry.URL = redact.String(entry.URL)
+	entry.Error = redact.String(entry.Error)
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file != nil {
+		_, _ = l.file.Write(data)
+	}
+}
+
+// Close closes the file.
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
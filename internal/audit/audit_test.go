@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readEntries(t *testing.T, path string) []Entry {
+	t.Helper()
+	f, err := os.Open(path) // #nosec G304 -- test temp file
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestTransportRecordsRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/limited" {
+			w.Header().Set("X-RateLimit-Limit", "60")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewTransport(KindRegistry, nil)}
+
+	// Nothing is recorded until the log is enabled
+	resp, err := client.Get(server.URL + "/before")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	path := filepath.Join(t.TempDir(), "logs", "audit.ndjson")
+	stop, err := Enable(path)
+	require.NoError(t, err)
+	require.True(t, Enabled())
+
+	resp, err = client.Get(server.URL + "/missing?api_key=abc123456")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	resp, err = client.Get(server.URL + "/limited")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	_, err = client.Get("http://127.0.0.1:1/closed")
+	require.Error(t, err)
+	stop()
+	require.False(t, Enabled())
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 3)
+
+	require.Equal(t, KindRegistry, entries[0].Kind)
+	require.Equal(t, http.MethodGet, entries[0].Method)
+	require.Equal(t, http.StatusNotFound, entries[0].Status)
+	require.NotContains(t, entries[0].URL, "abc123456")
+	require.Nil(t, entries[0].RateLimit)
+	require.False(t, entries[0].Timestamp.IsZero())
+
+	require.Equal(t, http.StatusTooManyRequests, entries[1].Status)
+	require.Equal(t, &RateLimit{Limited: true, Limit: "60", Remaining: "0", RetryAfter: "30"}, entries[1].RateLimit)
+
+	require.Zero(t, entries[2].Status)
+	require.NotEmpty(t, entries[2].Error)
+}
+
+func TestRecordAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.ndjson")
+	for _, target := range []string{"whois://whois.example/example.com", "dns:example.com?type=NS"} {
+		stop, err := Enable(path)
+		require.NoError(t, err)
+		Record(context.Background(), Entry{Kind: KindDNS, URL: target, Error: "lookup example.com: no such host"})
+		stop()
+	}
+	Record(context.Background(), Entry{Kind: KindDNS, URL: "dns:ignored"})
+
+	entries := readEntries(t, path)
+	require.Len(t, entries, 2)
+	require.Equal(t, "whois://whois.example/example.com", entries[0].URL)
+	require.Equal(t, "dns:example.com?type=NS", entries[1].URL)
+}
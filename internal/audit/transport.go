@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"net/http"
+	"time"
+)
+
+// Transport records each request sent through it as an entry of the given
+// kind.
+type Transport struct {
+	Kind string
+	// Base sends the requests; nil uses http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// NewTransport wraps base so its requests are recorded as kind.
+func NewTransport(kind string, base http.RoundTripper) *Transport {
+	return &Transport{Kind: kind, Base: base}
+}
+
+// RoundTrip sends req through Base and records the outcome.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !Enabled() {
+		return base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	entry := Entry{
+		Timestamp:  start.UTC(),
+		Kind:       t.Kind,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.RateLimit = rateLimitFrom(resp)
+	}
+	Record(req.Context(), entry)
+	return resp, err
+}
+
+// Rate-limit headers, most common first: the IETF draft, GitHub and most
+// APIs, then OpenAI and Anthropic's per-request limits.
+var (
+	limitHeaders     = []string{"RateLimit-Limit", "X-RateLimit-Limit", "X-RateLimit-Limit-Requests", "Anthropic-RateLimit-Requests-Limit"}
+	remainingHeaders = []string{"RateLimit-Remaining", "X-RateLimit-Remaining", "X-RateLimit-Remaining-Requests", "Anthropic-RateLimit-Requests-Remaining"}
+	resetHeaders     = []string{"RateLimit-Reset", "X-RateLimit-Reset", "X-RateLimit-Reset-Requests", "Anthropic-RateLimit-Requests-Reset"}
+)
+
+// rateLimitFrom returns the rate-limit state of resp, or nil when the server
+// reported none.
+func rateLimitFrom(resp *http.Response) *RateLimit {
+	state := RateLimit{
+		Limited:    resp.StatusCode == http.StatusTooManyRequests,
+		Limit:      firstHeader(resp.Header, limitHeaders),
+		Remaining:  firstHeader(resp.Header, remainingHeaders),
+		Reset:      firstHeader(resp.Header, resetHeaders),
+		RetryAfter: resp.Header.Get("Retry-After"),
+	}
+	if state == (RateLimit{}) {
+		return nil
+	}
+	return &state
+}
+
+func firstHeader(header http.Header, names []string) string {
+	for _, name := range names {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
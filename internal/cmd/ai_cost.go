@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/namelens/namelens/internal/ailink"
+)
+
+// printAIUsageSummary writes a run's AI calls, tokens, and estimated cost to
+// w. Runs without provider calls print nothing.
+func printAIUsageSummary(w io.Writer, usage ailink.Usage) {
+	if w == nil || usage.Calls == 0 {
+		return
+	}
+	calls := "calls"
+	if usage.Calls == 1 {
+		calls = "call"
+	}
+	cost := formatCostUSD(usage.EstimatedCostUSD)
+	if usage.EstimatedCostUSD == nil {
+		cost = "unknown (no pricing configured)"
+	}
+	_, _ = fmt.Fprintf(w, "AI usage: %d %s, %d tokens (%d prompt, %d completion), est. cost %s\n",
+		usage.Calls, calls, usage.TotalTokens, usage.PromptTokens, usage.CompletionTokens, cost)
+}
+
+// trackedUsage returns the usage recorded on tracker, or nil when it saw no
+// provider calls.
+func trackedUsage(tracker *ailink.UsageTracker) *ailink.Usage {
+	usage := tracker.Snapshot()
+	if usage.Calls == 0 {
+		return nil
+	}
+	return &usage
+}
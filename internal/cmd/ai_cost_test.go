@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+)
+
+func TestPrintAIUsageSummary(t *testing.T) {
+	var empty bytes.Buffer
+	printAIUsageSummary(&empty, ailink.Usage{})
+	require.Empty(t, empty.String())
+
+	cost := 0.0125
+	var priced bytes.Buffer
+	printAIUsageSummary(&priced, ailink.Usage{Calls: 3, PromptTokens: 900, CompletionTokens: 300, TotalTokens: 1200, EstimatedCostUSD: &cost})
+	require.Equal(t, "AI usage: 3 calls, 1200 tokens (900 prompt, 300 completion), est. cost $0.0125\n", priced.String())
+
+	var unpriced bytes.Buffer
+	printAIUsageSummary(&unpriced, ailink.Usage{Calls: 1, TotalTokens: 10})
+	require.Contains(t, unpriced.String(), "1 call,")
+	require.Contains(t, unpriced.String(), "est. cost unknown (no pricing configured)")
+}
+
+func TestTrackedUsage(t *testing.T) {
+	_, tracker := ailink.WithUsageTracker(t.Context())
+	require.Nil(t, trackedUsage(tracker))
+}
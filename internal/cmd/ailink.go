@@ -37,12 +37,16 @@ var ailinkListCmd = &cobra.Command{
 		}
 
 		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		_, _ = fmt.Fprintln(writer, "SLUG\tVERSION\tDESCRIPTION") // nolint:errcheck // tabwriter buffers; errors surface at Flush
+		_, _ = fmt.Fprintln(writer, "SLUG\tVERSION\tSOURCE\tDESCRIPTION") // nolint:errcheck // tabwriter buffers; errors surface at Flush
 		for _, prompt := range prompts {
 			if prompt == nil {
 				continue
 			}
-			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", prompt.Config.Slug, prompt.Config.Version, prompt.Config.Description) // nolint:errcheck // tabwriter buffers
+			source := "embedded"
+			if !prompt.Embedded {
+				source = prompt.Source
+			}
+			_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", prompt.Config.Slug, prompt.Config.Version, source, prompt.Config.Description) // nolint:errcheck // tabwriter buffers
 		}
 		return writer.Flush()
 	},
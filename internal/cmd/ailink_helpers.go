@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -11,57 +12,42 @@ import (
 	"github.com/namelens/namelens/internal/ailink"
 	"github.com/namelens/namelens/internal/ailink/prompt"
 	"github.com/namelens/namelens/internal/config"
+	corestore "github.com/namelens/namelens/internal/core/store"
 )
 
+// buildPromptRegistry returns the embedded prompts shadowed by slug with any
+// in ailink.prompts_dir.
 func buildPromptRegistry(cfg *config.Config) (prompt.Registry, error) {
-	defaults, err := prompt.LoadDefaults()
-	if err != nil {
-		return nil, err
-	}
-
-	merged := make(map[string]*prompt.Prompt, len(defaults))
-	for _, p := range defaults {
-		if p == nil {
-			continue
-		}
-		merged[p.Config.Slug] = p
-	}
-
+	dir := ""
 	if cfg != nil {
-		dir := strings.TrimSpace(cfg.AILink.PromptsDir)
-		if dir != "" {
-			overrides, err := prompt.LoadFromDir(dir)
-			if err != nil {
-				return nil, err
-			}
-			for _, p := range overrides {
-				if p == nil {
-					continue
-				}
-				merged[p.Config.Slug] = p
-			}
-		}
-	}
-
-	prompts := make([]*prompt.Prompt, 0, len(merged))
-	for _, p := range merged {
-		prompts = append(prompts, p)
+		dir = cfg.AILink.PromptsDir
 	}
-	return prompt.NewRegistry(prompts)
+	return prompt.LayeredRegistry(dir)
 }
 
+// buildSchemaCatalog returns the repo schemas, or the embedded ailink schemas
+// outside a repo, plus user schemas from the config dir's schemas/user.
 func buildSchemaCatalog() (*schema.Catalog, error) {
+	base := ""
 	root, err := findRepoRoot()
 	if err == nil {
-		return schema.NewCatalog(filepath.Join(root, "schemas")), nil
+		// Another repository, such as one a go install-ed binary runs in,
+		// has no namelens schemas
+		if _, statErr := os.Stat(filepath.Join(root, "schemas", "ailink", "v0")); statErr != nil {
+			err = fmt.Errorf("%s has no schemas/ailink/v0", root)
+		}
 	}
-
-	// Fallback: use embedded ailink schemas extracted to a temp directory.
-	catalog, fallbackErr := ailink.StandaloneSchemaCatalog()
-	if fallbackErr != nil {
-		return nil, fmt.Errorf("project root not found: %w; embedded fallback failed: %w", err, fallbackErr)
+	if err == nil {
+		base = filepath.Join(root, "schemas")
+	} else {
+		// Fallback: use embedded ailink schemas extracted to a temp directory.
+		extracted, fallbackErr := ailink.StandaloneSchemasRoot()
+		if fallbackErr != nil {
+			return nil, fmt.Errorf("project root not found: %w; embedded fallback failed: %w", err, fallbackErr)
+		}
+		base = extracted
 	}
-	return catalog, nil
+	return ailink.LayeredSchemaCatalog(base, config.DefaultSchemasDir())
 }
 
 func findRepoRoot() (string, error) {
@@ -110,3 +96,40 @@ func findRepoRoot() (string, error) {
 	}
 	return root, nil
 }
+
+// aiQuota returns the enforcer that counts AI calls in store against
+// ailink.quotas. Calls are not counted without a store.
+func aiQuota(cfg *config.Config, store *corestore.Store) *ailink.QuotaEnforcer {
+	if cfg == nil || store == nil {
+		return nil
+	}
+	return &ailink.QuotaEnforcer{Config: cfg.AILink.Quotas, Counter: store}
+}
+
+// aiBudget returns the enforcer for ailink.budget, with daily spend counted
+// in store so `namelens stats` can report it even without limits. It is nil
+// when there is neither a budget nor a store.
+func aiBudget(cfg *config.Config, store *corestore.Store) *ailink.BudgetEnforcer {
+	if cfg == nil || (!cfg.AILink.Budget.Enabled() && store == nil) {
+		return nil
+	}
+	budget := &ailink.BudgetEnforcer{Config: cfg.AILink.Budget}
+	if store != nil {
+		budget.Counter = store
+	}
+	return budget
+}
+
+// openQuotaStore opens the store for AI commands that do not otherwise need
+// it, so their calls are counted. Failing to open it is only an error when
+// quotas or daily budgets are configured; otherwise it returns nil.
+func openQuotaStore(ctx context.Context, cfg *config.Config) (*corestore.Store, error) {
+	store, err := openStore(ctx)
+	if err != nil {
+		if cfg != nil && (cfg.AILink.Quotas.Enabled() || cfg.AILink.Budget.DailyEnabled()) {
+			return nil, fmt.Errorf("open store for AI quotas: %w", err)
+		}
+		return nil, nil
+	}
+	return store, nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+// shouldSuggestAlternatives reports whether .com is taken while at least half
+// of the remaining checks came back available.
+func shouldSuggestAlternatives(name string, results []*core.CheckResult) bool {
+	comName := name + ".com"
+	comTaken := false
+	others, available := 0, 0
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		if r.CheckType == core.CheckTypeDomain && strings.EqualFold(r.Name, comName) {
+			comTaken = r.Available == core.AvailabilityTaken
+			continue
+		}
+		others++
+		if r.Available == core.AvailabilityAvailable {
+			available++
+		}
+	}
+	if !comTaken || others == 0 {
+		return false
+	}
+	return available*2 >= others
+}
+
+// alternativeDomains lists fallback-TLD and prefix/suffix .com candidates for
+// a name, skipping domains the profile already checked.
+func alternativeDomains(name string, cfg config.AlternativesConfig, checked []string) []string {
+	seen := make(map[string]struct{}, len(checked))
+	for _, tld := range checked {
+		tld = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tld)), ".")
+		if tld != "" {
+			seen[name+"."+tld] = struct{}{}
+		}
+	}
+
+	limit := cfg.Max
+	candidates := make([]string, 0)
+	add := func(domain string) {
+		if limit > 0 && len(candidates) >= limit {
+			return
+		}
+		if _, ok := seen[domain]; ok {
+			return
+		}
+		seen[domain] = struct{}{}
+		candidates = append(candidates, domain)
+	}
+
+	for _, tld := range cfg.TLDs {
+		tld = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tld)), ".")
+		if tld != "" {
+			add(name + "." + tld)
+		}
+	}
+	for _, prefix := range cfg.Prefixes {
+		prefix = strings.ToLower(strings.TrimSpace(prefix))
+		if prefix != "" && validateName(prefix+name) == nil {
+			add(prefix + name + ".com")
+		}
+	}
+	for _, suffix := range cfg.Suffixes {
+		suffix = strings.ToLower(strings.TrimSpace(suffix))
+		if suffix != "" && validateName(name+suffix) == nil {
+			add(name + suffix + ".com")
+		}
+	}
+
+	return candidates
+}
+
+// checkAlternatives runs domain checks for the candidates. Results come from the
+// cache when available and are not recorded in history.
+func checkAlternatives(ctx context.Context, orchestrator *engine.Orchestrator, domains []string) []*core.CheckResult {
+	if orchestrator == nil || len(domains) == 0 {
+		return nil
+	}
+	domainChecker := orchestrator.Checkers[core.CheckTypeDomain]
+	if domainChecker == nil {
+		return nil
+	}
+
+	results := make([]*core.CheckResult, 0, len(domains))
+	for _, domain := range domains {
+		if ctx.Err() != nil {
+			break
+		}
+		if !domainChecker.SupportsName(domain) {
+			continue
+		}
+		result, err := domainChecker.Check(ctx, domain)
+		if err != nil || result == nil {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// cachedAlternatives returns the candidates that have a cached domain result,
+// without any lookups.
+func cachedAlternatives(ctx context.Context, orchestrator *engine.Orchestrator, domains []string) []*core.CheckResult {
+	results := make([]*core.CheckResult, 0, len(domains))
+	for _, result := range checkAlternatives(core.WithOffline(ctx), orchestrator, domains) {
+		if result.Provenance.FromCache {
+			results = append(results, result)
+		}
+	}
+	return results
+}
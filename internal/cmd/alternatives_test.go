@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+func TestShouldSuggestAlternatives(t *testing.T) {
+	domain := func(name string, a core.Availability) *core.CheckResult {
+		return &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, Available: a}
+	}
+	npm := &core.CheckResult{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable}
+
+	require.True(t, shouldSuggestAlternatives("acme", []*core.CheckResult{
+		domain("acme.com", core.AvailabilityTaken),
+		domain("acme.io", core.AvailabilityTaken),
+		npm,
+	}))
+	require.False(t, shouldSuggestAlternatives("acme", []*core.CheckResult{
+		domain("acme.com", core.AvailabilityAvailable),
+		npm,
+	}), ".com available")
+	require.False(t, shouldSuggestAlternatives("acme", []*core.CheckResult{
+		domain("acme.com", core.AvailabilityTaken),
+		domain("acme.io", core.AvailabilityTaken),
+		domain("acme.dev", core.AvailabilityTaken),
+		npm,
+	}), "name mostly taken")
+	require.False(t, shouldSuggestAlternatives("acme", []*core.CheckResult{
+		domain("acme.com", core.AvailabilityTaken),
+	}), "no other signals")
+}
+
+func TestAlternativeDomains(t *testing.T) {
+	cfg := config.AlternativesConfig{
+		TLDs:     []string{"dev", ".io", "co"},
+		Prefixes: []string{"get", "-"},
+		Suffixes: []string{"hq"},
+		Max:      4,
+	}
+
+	require.Equal(t,
+		[]string{"acme.io", "acme.co", "getacme.com", "acmehq.com"},
+		alternativeDomains("acme", cfg, []string{"com", "dev"}),
+	)
+
+	cfg.Max = 0
+	require.Equal(t,
+		[]string{"acme.dev", "acme.io", "acme.co", "getacme.com", "acmehq.com"},
+		alternativeDomains("acme", cfg, []string{"com"}),
+	)
+}
+
+// cachedDomainChecker answers the listed domains from cache; the rest are
+// unknown when offline and available otherwise.
+type cachedDomainChecker map[string]bool
+
+func (c cachedDomainChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	result := &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable}
+	switch {
+	case c[name]:
+		result.Available = core.AvailabilityTaken
+		result.Provenance.FromCache = true
+	case core.IsOffline(ctx):
+		result.Available = core.AvailabilityUnknown
+	}
+	return result, nil
+}
+
+func (c cachedDomainChecker) Type() core.CheckType { return core.CheckTypeDomain }
+
+func (c cachedDomainChecker) SupportsName(string) bool { return true }
+
+func TestCachedAlternatives(t *testing.T) {
+	orchestrator := &engine.Orchestrator{
+		Checkers: map[core.CheckType]engine.Checker{core.CheckTypeDomain: cachedDomainChecker{"acme.dev": true}},
+	}
+	domains := []string{"acme.dev", "getacme.com"}
+
+	cached := cachedAlternatives(context.Background(), orchestrator, domains)
+	require.Len(t, cached, 1)
+	require.Equal(t, "acme.dev", cached[0].Name)
+
+	live := checkAlternatives(context.Background(), orchestrator, domains)
+	require.Len(t, live, 2)
+	require.Equal(t, core.AvailabilityAvailable, live[1].Available)
+}
+
+func TestAlternativesFlagsAreExclusive(t *testing.T) {
+	cmd := checkCmd
+	t.Cleanup(func() {
+		_ = cmd.Flags().Set("alternatives", "false")
+		_ = cmd.Flags().Set("no-alternatives", "false")
+		cmd.Flags().Lookup("alternatives").Changed = false
+		cmd.Flags().Lookup("no-alternatives").Changed = false
+	})
+	require.NoError(t, cmd.Flags().Set("alternatives", "true"))
+	require.NoError(t, cmd.Flags().Set("no-alternatives", "true"))
+	require.Error(t, cmd.ValidateFlagGroups())
+}
@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/api"
+	"github.com/namelens/namelens/internal/config"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var apikeyCmd = &cobra.Command{
+	Use:   "apikey",
+	Short: "Manage control plane API keys kept in the store",
+	Long: `Manage control plane API keys kept in the local database.
+
+Stored keys work alongside server.api_keys: each has a name, a role
+(read-only, check, or admin), and optional limits on live lookups
+(POST /v1/check, POST /v1/compare, gRPC Check) per minute and per UTC day.
+Only a hash of each key is stored, so the key is shown once, on create.
+
+"namelens serve" accepts new keys right away and stops accepting revoked
+ones. While no key is configured or stored, the server runs without
+authentication; creating the first key turns it on within about five
+seconds, without a restart.`,
+}
+
+var apikeyCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an API key and print it",
+	Example: `  namelens apikey create partner --role check --rate-limit 60 --daily-quota 1000
+  namelens apikey create dashboard --role read-only`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAPIKeyCreate,
+}
+
+var apikeyRevokeCmd = &cobra.Command{
+	Use:   "revoke <name>...",
+	Short: "Revoke API keys",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runAPIKeyRevoke,
+}
+
+var apikeyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List API keys with today's usage",
+	Long: `List stored and configured API keys with their role, limits, and live
+lookups so far in the current UTC day. Key values are never shown.`,
+	Args: cobra.NoArgs,
+	RunE: runAPIKeyList,
+}
+
+func init() {
+	rootCmd.AddCommand(apikeyCmd)
+	apikeyCmd.AddCommand(apikeyCreateCmd)
+	apikeyCmd.AddCommand(apikeyRevokeCmd)
+	apikeyCmd.AddCommand(apikeyListCmd)
+
+	apikeyCreateCmd.Flags().String("role", string(api.RoleCheck), "Key role: read-only, check, admin")
+	apikeyCreateCmd.Flags().Int("rate-limit", 0, "Live lookups per minute (0 = unlimited)")
+	apikeyCreateCmd.Flags().Int("daily-quota", 0, "Live lookups per UTC day (0 = unlimited)")
+
+	apikeyListCmd.Flags().Bool("all", false, "Include revoked keys")
+	apikeyListCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	apikeyListCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+}
+
+func runAPIKeyCreate(cmd *cobra.Command, args []string) error {
+	roleName, err := cmd.Flags().GetString("role")
+	if err != nil {
+		return err
+	}
+	rateLimit, err := cmd.Flags().GetInt("rate-limit")
+	if err != nil {
+		return err
+	}
+	dailyQuota, err := cmd.Flags().GetInt("daily-quota")
+	if err != nil {
+		return err
+	}
+	if rateLimit < 0 || dailyQuota < 0 {
+		return errors.New("--rate-limit and --daily-quota must be 0 or greater")
+	}
+	role, err := api.ParseRole(roleName)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return errors.New("api key name is required")
+	}
+	if cfg := config.GetConfig(); cfg != nil {
+		for _, entry := range cfg.Server.APIKeys {
+			if strings.TrimSpace(entry.Name) == name {
+				return fmt.Errorf("api key %s is already configured in server.api_keys", name)
+			}
+		}
+	}
+
+	secret, err := api.GenerateAPIKey()
+	if err != nil {
+		return fmt.Errorf("generate api key: %w", err)
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	key := corestore.APIKey{Name: name, Role: string(role), RateLimit: rateLimit, DailyQuota: dailyQuota, CreatedAt: time.Now()}
+	if err := store.CreateAPIKey(ctx, key, secret); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Created API key %s (%s). Store it now; it cannot be shown again.\n", name, role)
+	fmt.Fprintln(cmd.OutOrStdout(), secret)
+	return nil
+}
+
+func runAPIKeyRevoke(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	for _, name := range args {
+		revoked, err := store.RevokeAPIKey(ctx, name, time.Now())
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return fmt.Errorf("no active stored api key named %s", name)
+		}
+		fmt.Printf("Revoked %s\n", name)
+	}
+	return nil
+}
+
+// apiKeyRow is a line of 'namelens apikey list'.
+type apiKeyRow struct {
+	Name       string     `json:"name"`
+	Source     string     `json:"source"`
+	Role       string     `json:"role"`
+	RateLimit  int        `json:"rate_limit"`
+	DailyQuota int        `json:"daily_quota"`
+	Today      int        `json:"today"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+func runAPIKeyList(cmd *cobra.Command, args []string) error {
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	rows, err := apiKeyRows(ctx, config.GetConfig(), store, all, time.Now())
+	if err != nil {
+		return err
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderAPIKeys(sink.writer, rows, format)
+}
+
+// apiKeyRows lists configured keys followed by stored ones, with their live
+// lookups in the UTC day of now.
+func apiKeyRows(ctx context.Context, cfg *config.Config, store *corestore.Store, all bool, now time.Time) ([]apiKeyRow, error) {
+	usage, err := store.APIKeyUsage(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]apiKeyRow, 0)
+	if cfg != nil {
+		for i, entry := range cfg.Server.APIKeys {
+			name := strings.TrimSpace(entry.Name)
+			if name == "" {
+				name = fmt.Sprintf("key-%d", i+1)
+			}
+			rows = append(rows, apiKeyRow{
+				Name:       name,
+				Source:     "config",
+				Role:       strings.ToLower(strings.TrimSpace(entry.Role)),
+				RateLimit:  entry.RateLimit,
+				DailyQuota: entry.DailyQuota,
+				Today:      usage[name],
+			})
+		}
+	}
+
+	keys, err := store.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if !key.Active() && !all {
+			continue
+		}
+		row := apiKeyRow{
+			Name:       key.Name,
+			Source:     "store",
+			Role:       key.Role,
+			RateLimit:  key.RateLimit,
+			DailyQuota: key.DailyQuota,
+			Today:      usage[key.Name],
+			CreatedAt:  &key.CreatedAt,
+		}
+		if !key.Active() {
+			row.RevokedAt = &key.RevokedAt
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (r apiKeyRow) status() string {
+	if r.RevokedAt != nil {
+		return "revoked " + r.RevokedAt.Format("2006-01-02")
+	}
+	return "active"
+}
+
+func (r apiKeyRow) created() string {
+	if r.CreatedAt == nil {
+		return "-"
+	}
+	return r.CreatedAt.Format("2006-01-02")
+}
+
+func renderAPIKeys(w io.Writer, rows []apiKeyRow, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		_, _ = fmt.Fprintln(w, "| Name | Source | Role | Per minute | Daily quota | Today | Created | Status |")
+		_, _ = fmt.Fprintln(w, "|------|--------|------|------------|-------------|-------|---------|--------|")
+		for _, row := range rows {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %d | %s | %s |\n",
+				row.Name, row.Source, row.Role, formatQuotaLimit(row.RateLimit), formatQuotaLimit(row.DailyQuota), row.Today, row.created(), row.status())
+		}
+		return nil
+	default:
+		t := table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.AppendHeader(table.Row{"Name", "Source", "Role", "Per minute", "Daily quota", "Today", "Created", "Status"})
+		for _, row := range rows {
+			t.AppendRow(table.Row{row.Name, row.Source, row.Role, formatQuotaLimit(row.RateLimit), formatQuotaLimit(row.DailyQuota), row.Today, row.created(), row.status()})
+		}
+		t.Render()
+		return nil
+	}
+}
+
+// storedKeysTTL is how long serve reuses its answer to whether any stored key
+// exists, so created or revoked keys switch authentication on or off within
+// this time.
+const storedKeysTTL = 5 * time.Second
+
+// storeKeyLookup authenticates API keys created with 'namelens apikey create'.
+type storeKeyLookup struct {
+	store *corestore.Store
+	now   func() time.Time
+
+	mu        sync.Mutex
+	hasKeys   bool
+	checkedAt time.Time
+}
+
+// newStoreKeyLookup returns the key store for serve. It fails when the store
+// cannot tell whether any key exists, so serve never starts with an unknown
+// authentication state.
+func newStoreKeyLookup(ctx context.Context, store *corestore.Store) (*storeKeyLookup, error) {
+	lookup := &storeKeyLookup{store: store, now: time.Now}
+	count, err := store.CountActiveAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lookup.hasKeys, lookup.checkedAt = count > 0, lookup.now()
+	return lookup, nil
+}
+
+func (l *storeKeyLookup) LookupAPIKey(ctx context.Context, key string) (api.Principal, bool, error) {
+	stored, err := l.store.LookupAPIKey(ctx, key)
+	if err != nil || stored == nil {
+		return api.Principal{}, false, err
+	}
+	role, err := api.ParseRole(stored.Role)
+	if err != nil {
+		return api.Principal{}, false, fmt.Errorf("stored api key %s: %w", stored.Name, err)
+	}
+	return api.Principal{Name: stored.Name, Role: role, RateLimit: stored.RateLimit, DailyQuota: stored.DailyQuota}, true, nil
+}
+
+// HasAPIKeys reports whether any stored key exists, asking the store at most
+// once per storedKeysTTL. A failed query keeps the last answer.
+func (l *storeKeyLookup) HasAPIKeys(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.checkedAt) < storedKeysTTL {
+		return l.hasKeys, nil
+	}
+	count, err := l.store.CountActiveAPIKeys(ctx)
+	if err != nil {
+		if observability.ServerLogger != nil {
+			observability.ServerLogger.Warn("Failed to count stored API keys; keeping the last answer",
+				zap.Bool("has_keys", l.hasKeys), zap.Error(err))
+		}
+	} else {
+		l.hasKeys = count > 0
+	}
+	l.checkedAt = now
+	return l.hasKeys, nil
+}
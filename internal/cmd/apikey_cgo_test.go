@@ -0,0 +1,48 @@
+//go:build cgo
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	corestore "github.com/namelens/namelens/internal/core/store"
+)
+
+func TestStoreKeyLookupCachesHasKeys(t *testing.T) {
+	ctx := context.Background()
+	store, err := corestore.Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	defer store.Close() // nolint:errcheck // test cleanup
+	require.NoError(t, store.Migrate(ctx))
+
+	lookup, err := newStoreKeyLookup(ctx, store)
+	require.NoError(t, err)
+	now := time.Now()
+	lookup.now = func() time.Time { return now }
+
+	hasKeys, err := lookup.HasAPIKeys(ctx)
+	require.NoError(t, err)
+	require.False(t, hasKeys)
+
+	require.NoError(t, store.CreateAPIKey(ctx, corestore.APIKey{Name: "partner", Role: "check", CreatedAt: now}, "nlcp_secret"))
+	hasKeys, err = lookup.HasAPIKeys(ctx)
+	require.NoError(t, err)
+	require.False(t, hasKeys, "answer is reused within the TTL")
+
+	now = now.Add(storedKeysTTL)
+	hasKeys, err = lookup.HasAPIKeys(ctx)
+	require.NoError(t, err)
+	require.True(t, hasKeys, "a key created while serving is picked up after the TTL")
+
+	// A store error keeps the last answer instead of failing requests
+	require.NoError(t, store.DB.Close())
+	now = now.Add(storedKeysTTL)
+	hasKeys, err = lookup.HasAPIKeys(ctx)
+	require.NoError(t, err)
+	require.True(t, hasKeys)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestRenderAPIKeys(t *testing.T) {
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	revoked := created.Add(48 * time.Hour)
+	rows := []apiKeyRow{
+		{Name: "dashboard", Source: "config", Role: "read-only"},
+		{Name: "partner", Source: "store", Role: "check", RateLimit: 60, DailyQuota: 1000, Today: 12, CreatedAt: &created},
+		{Name: "old", Source: "store", Role: "check", CreatedAt: &created, RevokedAt: &revoked},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderAPIKeys(&buf, rows, output.FormatMarkdown))
+	require.Contains(t, buf.String(), "| dashboard | config | read-only | unlimited | unlimited | 0 | - | active |")
+	require.Contains(t, buf.String(), "| partner | store | check | 60 | 1000 | 12 | 2026-03-01 | active |")
+	require.Contains(t, buf.String(), "revoked 2026-03-03")
+
+	buf.Reset()
+	require.NoError(t, renderAPIKeys(&buf, rows, output.FormatJSON))
+	require.Contains(t, buf.String(), `"daily_quota": 1000`)
+	require.NotContains(t, buf.String(), "key_hash")
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/audit"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// startAuditLog records outbound requests when the audit log is enabled.
+func startAuditLog(cfg *config.Config) {
+	if !cfg.Logging.Audit.Enabled {
+		return
+	}
+	path := strings.TrimSpace(cfg.Logging.Audit.Path)
+	if path == "" {
+		path = config.DefaultAuditLogPath()
+	}
+	// Stopped by audit.Disable() in Execute()
+	if _, err := audit.Enable(path); err != nil {
+		if observability.CLILogger != nil {
+			observability.CLILogger.Warn("Failed to enable the request audit log", zap.Error(err))
+		}
+		return
+	}
+	if observability.CLILogger != nil {
+		observability.CLILogger.Debug("Request audit log enabled", zap.String("file", path))
+	}
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/keychain"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var (
+	authLabel        string
+	authStatusFormat string
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Store API keys and tokens in the OS keychain",
+	Long: `Manages credentials kept in the OS keychain (macOS Keychain, Windows
+Credential Manager, or the Secret Service on Linux) instead of the config file.
+
+AI provider keys are used when a credential has neither api_key nor
+api_key_file set. The GitHub token is used before GITHUB_TOKEN and
+NAMELENS_GITHUB_TOKEN.`,
+}
+
+var authSetCmd = &cobra.Command{
+	Use:   "set <provider>",
+	Short: "Store a key for an AI provider or GitHub",
+	Long: `Prompts for a secret without echo and stores it in the OS keychain.
+
+<provider> is github, a setup slug (xai, openai, anthropic), or an AILink
+provider instance id from the config. --label stores the key for one labeled
+credential; without it the key applies to every credential of the provider.`,
+	Example: `  namelens auth set xai
+  namelens auth set github
+  echo "$OPENAI_API_KEY" | namelens auth set namelens-openai --label prod`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		account, name, configured, err := resolveAuthAccount(cfg, args[0], authLabel)
+		if err != nil {
+			return err
+		}
+
+		stdout := cmd.OutOrStdout()
+		secret, err := readSecret(stdout, os.Stdin, name)
+		if err != nil {
+			return err
+		}
+		if err := keychain.Set(account, secret); err != nil {
+			return fmt.Errorf("store %s in keychain: %w", name, err)
+		}
+
+		_, _ = fmt.Fprintf(stdout, "Stored %s in the OS keychain (%s)\n", name, maskKey(secret))
+		if !configured {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Note: provider %s is not in the config yet; run 'namelens setup --provider %s' or add it under ailink.providers.\n", name, strings.TrimSpace(args[0]))
+		}
+		return nil
+	},
+}
+
+var authDeleteCmd = &cobra.Command{
+	Use:   "delete <provider>",
+	Short: "Remove a stored key from the OS keychain",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		account, name, _, err := resolveAuthAccount(cfg, args[0], authLabel)
+		if err != nil {
+			return err
+		}
+		if err := keychain.Delete(account); err != nil {
+			if errors.Is(err, keychain.ErrNotFound) {
+				return fmt.Errorf("no keychain entry for %s", name)
+			}
+			return fmt.Errorf("delete %s from keychain: %w", name, err)
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Removed %s from the OS keychain\n", name)
+		return nil
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show where each credential is read from",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(authStatusFormat)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatJSON && format != output.FormatTable {
+			return fmt.Errorf("unsupported output format for auth status: %s", format)
+		}
+		cfg, err := config.Load(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		entries := collectAuthStatus(cfg)
+		if format == output.FormatJSON {
+			payload, err := json.MarshalIndent(entries, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(payload))
+			return err
+		}
+		return renderAuthStatus(cmd.OutOrStdout(), entries)
+	},
+}
+
+// authStatusEntry reports where one credential comes from. Source is
+// keychain, inline, file, env, or none.
+type authStatusEntry struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Origin string `json:"origin,omitempty"`
+	Key    string `json:"key,omitempty"`
+}
+
+// resolveAuthAccount maps a provider argument to its keychain account and
+// display name. configured is false for a setup slug whose instance is not
+// in the config yet.
+func resolveAuthAccount(cfg *config.Config, provider, label string) (account, name string, configured bool, err error) {
+	provider = strings.TrimSpace(provider)
+	if strings.EqualFold(provider, keychain.GitHubAccount) {
+		if strings.TrimSpace(label) != "" {
+			return "", "", false, fmt.Errorf("--label does not apply to github")
+		}
+		return keychain.GitHubAccount, keychain.GitHubAccount, true, nil
+	}
+
+	id := provider
+	if _, ok := cfg.AILink.Providers[id]; !ok {
+		info, lookupErr := lookupProvider(provider)
+		if lookupErr != nil {
+			valid := []string{keychain.GitHubAccount}
+			for _, p := range providerTable {
+				valid = append(valid, p.Slug)
+			}
+			ids := make([]string, 0, len(cfg.AILink.Providers))
+			for configuredID := range cfg.AILink.Providers {
+				ids = append(ids, configuredID)
+			}
+			sort.Strings(ids)
+			valid = append(valid, ids...)
+			return "", "", false, fmt.Errorf("unknown provider %q (valid: %s)", provider, strings.Join(valid, ", "))
+		}
+		id = info.InstanceID
+	}
+	_, configured = cfg.AILink.Providers[id]
+
+	name = id
+	if label = strings.TrimSpace(label); label != "" {
+		name += "/" + label
+	}
+	return keychain.AIAccount(id, label), name, configured, nil
+}
+
+// readSecret reads a secret without echo from a terminal, or one line from
+// piped input.
+func readSecret(stdout io.Writer, stdin io.Reader, name string) (string, error) {
+	if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) { // #nosec G115 -- fd fits int on all supported platforms
+		_, _ = fmt.Fprintf(stdout, "Enter the key for %s: ", name)
+		key, err := term.ReadPassword(int(f.Fd())) // #nosec G115 -- fd fits int on all supported platforms
+		_, _ = fmt.Fprintln(stdout)                // newline after hidden input
+		if err != nil {
+			return "", fmt.Errorf("read key: %w", err)
+		}
+		return nonEmptySecret(string(key))
+	}
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("read key: %w", err)
+	}
+	return nonEmptySecret(line)
+}
+
+func nonEmptySecret(secret string) (string, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("key cannot be empty")
+	}
+	return secret, nil
+}
+
+// collectAuthStatus lists the GitHub token and each credential of the enabled
+// AILink providers, in the order they are resolved.
+func collectAuthStatus(cfg *config.Config) []authStatusEntry {
+	entries := []authStatusEntry{githubAuthStatus()}
+
+	ids := make([]string, 0, len(cfg.AILink.Providers))
+	for id := range cfg.AILink.Providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		provider := cfg.AILink.Providers[id]
+		if !provider.Enabled {
+			continue
+		}
+		for i, cred := range provider.Credentials {
+			label := strings.TrimSpace(cred.Label)
+			if label == "" {
+				label = fmt.Sprintf("%d", i)
+			}
+			entry := authStatusEntry{Name: id + "/" + label, Source: "none"}
+			switch {
+			case strings.TrimSpace(cred.APIKey) != "":
+				entry.Source, entry.Key = "inline", maskKey(cred.APIKey)
+			case strings.TrimSpace(cred.APIKeyFile) != "":
+				entry.Source, entry.Origin = "file", cred.APIKeyFile
+				if loaded, err := cred.LoadKey(); err == nil && loaded.APIKey != "" {
+					entry.Key = maskKey(loaded.APIKey)
+				}
+			default:
+				if key := keychain.Lookup(keychain.AIAccount(id, cred.Label)); key != "" {
+					entry.Source, entry.Origin, entry.Key = "keychain", keychain.AIAccount(id, cred.Label), maskKey(key)
+				} else if key := keychain.Lookup(keychain.AIAccount(id, "")); key != "" {
+					entry.Source, entry.Origin, entry.Key = "keychain", keychain.AIAccount(id, ""), maskKey(key)
+				}
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func githubAuthStatus() authStatusEntry {
+	entry := authStatusEntry{Name: keychain.GitHubAccount, Source: "none"}
+	if token := keychain.Lookup(keychain.GitHubAccount); token != "" {
+		entry.Source, entry.Origin, entry.Key = "keychain", keychain.GitHubAccount, maskKey(token)
+		return entry
+	}
+	for _, name := range []string{"GITHUB_TOKEN", "NAMELENS_GITHUB_TOKEN"} {
+		if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+			entry.Source, entry.Origin, entry.Key = "env", name, maskKey(token)
+			return entry
+		}
+	}
+	return entry
+}
+
+func renderAuthStatus(w io.Writer, entries []authStatusEntry) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "CREDENTIAL\tSOURCE\tORIGIN\tKEY") // nolint:errcheck // tabwriter buffers; errors surface at Flush
+	for _, entry := range entries {
+		origin, key := entry.Origin, entry.Key
+		if origin == "" {
+			origin = "-"
+		}
+		if key == "" {
+			key = "-"
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", entry.Name, entry.Source, origin, key) // nolint:errcheck // tabwriter buffers
+	}
+	return writer.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authSetCmd)
+	authCmd.AddCommand(authDeleteCmd)
+	authCmd.AddCommand(authStatusCmd)
+
+	authSetCmd.Flags().StringVar(&authLabel, "label", "", "Store the key for one labeled credential")
+	authDeleteCmd.Flags().StringVar(&authLabel, "label", "", "Remove the key for one labeled credential")
+	authStatusCmd.Flags().StringVar(&authStatusFormat, "output-format", string(output.FormatTable), "Output format: table|json")
+}
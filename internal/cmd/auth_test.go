@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/keychain"
+)
+
+func TestResolveAuthAccount(t *testing.T) {
+	cfg := &config.Config{AILink: ailink.Config{Providers: map[string]ailink.ProviderInstanceConfig{
+		"namelens-xai": {Enabled: true},
+		"local":        {Enabled: true},
+	}}}
+
+	account, name, configured, err := resolveAuthAccount(cfg, "GitHub", "")
+	require.NoError(t, err)
+	require.Equal(t, keychain.GitHubAccount, account)
+	require.Equal(t, "github", name)
+	require.True(t, configured)
+
+	_, _, _, err = resolveAuthAccount(cfg, "github", "prod")
+	require.ErrorContains(t, err, "--label")
+
+	account, name, configured, err = resolveAuthAccount(cfg, "xai", "")
+	require.NoError(t, err)
+	require.Equal(t, "ailink/namelens-xai", account)
+	require.Equal(t, "namelens-xai", name)
+	require.True(t, configured)
+
+	account, _, _, err = resolveAuthAccount(cfg, "local", "prod")
+	require.NoError(t, err)
+	require.Equal(t, "ailink/local/prod", account)
+
+	_, name, configured, err = resolveAuthAccount(cfg, "anthropic", "")
+	require.NoError(t, err)
+	require.Equal(t, "namelens-anthropic", name)
+	require.False(t, configured)
+
+	_, _, _, err = resolveAuthAccount(cfg, "gopher", "")
+	require.ErrorContains(t, err, "valid: github, xai, openai, anthropic, local, namelens-xai")
+}
+
+func TestResolveGitHubTokenPrefersKeychain(t *testing.T) {
+	keychain.MockInit()
+	t.Setenv("GITHUB_TOKEN", "env-token")
+	t.Setenv("NAMELENS_GITHUB_TOKEN", "namelens-token")
+	require.Equal(t, "env-token", resolveGitHubToken())
+
+	require.NoError(t, keychain.Set(keychain.GitHubAccount, "keychain-token"))
+	require.Equal(t, "keychain-token", resolveGitHubToken())
+	require.Equal(t, authStatusEntry{Name: "github", Source: "keychain", Origin: "github", Key: "keyc…ken"}, githubAuthStatus())
+}
+
+func TestCollectAuthStatus(t *testing.T) {
+	keychain.MockInit()
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("NAMELENS_GITHUB_TOKEN", "")
+	require.NoError(t, keychain.Set(keychain.AIAccount("namelens-xai", ""), "xai-keychain-key"))
+
+	cfg := &config.Config{AILink: ailink.Config{Providers: map[string]ailink.ProviderInstanceConfig{
+		"namelens-xai": {Enabled: true, Credentials: []ailink.CredentialConfig{{Enabled: true, Label: "default"}}},
+		"inline":       {Enabled: true, Credentials: []ailink.CredentialConfig{{Enabled: true, APIKey: "inline-secret-key"}}},
+		"off":          {Enabled: false, Credentials: []ailink.CredentialConfig{{Enabled: true}}},
+	}}}
+
+	entries := collectAuthStatus(cfg)
+	require.Equal(t, []authStatusEntry{
+		{Name: "github", Source: "none"},
+		{Name: "inline/0", Source: "inline", Key: "inli…key"},
+		{Name: "namelens-xai/default", Source: "keychain", Origin: "ailink/namelens-xai", Key: "xai-…key"},
+	}, entries)
+	require.True(t, isAIBackendConfigured(cfg.AILink))
+}
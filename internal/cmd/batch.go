@@ -5,7 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"path/filepath"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -16,6 +16,7 @@ import (
 	"github.com/namelens/namelens/internal/core"
 	"github.com/namelens/namelens/internal/core/engine"
 	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/progress"
 )
 
 var batchCmd = &cobra.Command{
@@ -30,11 +31,22 @@ func init() {
 	rootCmd.AddCommand(batchCmd)
 
 	batchCmd.Flags().String("profile", "minimal", "Profile to use")
-	batchCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	batchCmd.Flags().String("output-format", "table", "Output format: table, json, markdown, pr-comment, html, csv, junit")
 	batchCmd.Flags().String("out", "", "Write output to a file (default stdout)")
 	batchCmd.Flags().String("out-dir", "", "Write per-name outputs to a directory")
+	addOutArchiveFlag(batchCmd)
+	addJUnitFlag(batchCmd)
 	batchCmd.Flags().Bool("available-only", false, "Only show names fully available across all checks")
 	batchCmd.Flags().Int("concurrency", 3, "Concurrent checks")
+	batchCmd.Flags().Bool("request-summary", false, "Print a footer counting external requests and cache hits for the run")
+	addNameSelectionFlags(batchCmd)
+	addSaaSFlag(batchCmd)
+	addMarketsFlag(batchCmd)
+	addSimilarityFlags(batchCmd)
+	addWordScanFlags(batchCmd)
+	addVerifyTakenFlags(batchCmd)
+	addPriceFlags(batchCmd)
+	addCheckTimeoutFlags(batchCmd)
 }
 
 func runBatch(cmd *cobra.Command, args []string) error {
@@ -46,11 +58,11 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		return errors.New("profile is required")
 	}
 
-	formatValue, err := cmd.Flags().GetString("output-format")
+	format, err := resolveCheckOutputFormat(cmd)
 	if err != nil {
 		return err
 	}
-	format, err := output.ParseFormat(formatValue)
+	junit, err := resolveJUnitFormatter(cmd)
 	if err != nil {
 		return err
 	}
@@ -68,14 +80,32 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		return errors.New("concurrency must be at least 1")
 	}
 
+	requestSummary, err := cmd.Flags().GetBool("request-summary")
+	if err != nil {
+		return err
+	}
+
+	verifySample, err := resolveVerifyTaken(cmd)
+	if err != nil {
+		return err
+	}
+
 	names, err := readNamesFile(args[0])
 	if err != nil {
 		return err
 	}
 	// readNamesFile already validates non-empty
+	names, err = selectNames(cmd, names)
+	if err != nil {
+		return err
+	}
 
 	ctx := cmd.Context()
 	startedAt := time.Now()
+	archive, err := resolveOutputArchive(cmd, "batch", startedAt)
+	if err != nil {
+		return err
+	}
 
 	store, err := openStore(ctx)
 	if err != nil {
@@ -87,22 +117,52 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	if cfg == nil {
 		return errors.New("config not loaded")
 	}
+	cfg, markets, err := resolveMarkets(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	similar, err := resolveSimilarity(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	priceProviders, err := resolvePriceProviders(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	words, err := resolveWordScan(cmd, cfg)
+	if err != nil {
+		return err
+	}
 
 	profile, err := resolveProfile(ctx, store, profileName, nil, nil, nil)
 	if err != nil {
 		return err
 	}
+	if err := applySaaSFlag(cmd, &profile); err != nil {
+		return err
+	}
+	applyMarketTLDs(&profile, markets)
 	if len(profile.TLDs) == 0 && len(profile.Registries) == 0 && len(profile.Handles) == 0 {
 		return errors.New("at least one check target is required")
 	}
 
+	defer startBootstrapRefresh(ctx, cfg, store)()
 	orchestrator := buildOrchestrator(cfg, store, true)
+	defer orchestrator.Wait()
+	if err := applyCheckTimeouts(cmd, orchestrator, startedAt); err != nil {
+		return err
+	}
 
-	results, err := runBatchChecks(ctx, orchestrator, profile, names, concurrency)
+	results, err := runBatchChecks(ctx, orchestrator, profile, names, concurrency, nil)
 	if err != nil {
 		return err
 	}
+	applySimilarity(results, similar)
+	applyWordScan(results, words)
+	verifyAvailable(ctx, orchestrator, results, verifySample)
+	priceAvailable(ctx, priceProviders, cfg.Pricing.PremiumThreshold, results)
 
+	requestCounts := runRequestCounts(results, nil)
 	results = filterBatchResults(results, availableOnly)
 
 	outPath, outDir, err := resolveOutputTargets(cmd)
@@ -111,19 +171,26 @@ func runBatch(cmd *cobra.Command, args []string) error {
 	}
 
 	ext := outputExtension(format)
-	rendered, err := output.FormatBatchList(format, results)
+	var rendered string
+	switch format {
+	case output.FormatPRComment:
+		rendered, err = prCommentFormatter(cmd, args).FormatBatches(results)
+	case output.FormatJUnit:
+		rendered, err = junit.FormatBatches(results)
+	default:
+		rendered, err = output.FormatBatchList(format, results)
+	}
 	if err != nil {
 		return err
 	}
 
-	if outDir != "" {
+	if outDir != "" || archive != nil {
 		outDir, err := ensureOutDir(outDir)
 		if err != nil {
 			return err
 		}
 
-		indexPath := filepath.Join(outDir, fmt.Sprintf("batch.index.%s", ext))
-		indexSink, err := openSink(indexPath)
+		indexSink, err := openRunSink(archive, outDir, fmt.Sprintf("batch.index.%s", ext), archiveKindIndex)
 		if err != nil {
 			return err
 		}
@@ -136,13 +203,15 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		}
 
 		formatter := output.NewFormatter(format)
+		if format == output.FormatJUnit {
+			formatter = junit
+		}
 		for _, result := range results {
 			if result == nil {
 				continue
 			}
 			name := sanitizeFilename(result.Name)
-			path := filepath.Join(outDir, fmt.Sprintf("%s.batch.%s", name, ext))
-			sink, err := openSink(path)
+			sink, err := openRunSink(archive, outDir, fmt.Sprintf("%s.batch.%s", name, ext), archiveKindOutput)
 			if err != nil {
 				return err
 			}
@@ -187,7 +256,23 @@ func runBatch(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if archive != nil {
+		if err := writeBatchArchive(archive, results, archiveProvenance{
+			OutputFormat: string(format),
+			Profile:      profileName,
+			Names:        names,
+			Cache:        true,
+			Offline:      isOffline(cfg),
+			Requests:     requestCounts,
+		}); err != nil {
+			return err
+		}
+	}
+
 	logThroughput(totalChecks(results), startedAt)
+	if requestSummary {
+		printRequestSummary(os.Stderr, requestCounts)
+	}
 	return nil
 }
 
@@ -196,7 +281,8 @@ type batchJob struct {
 	name  string
 }
 
-func runBatchChecks(ctx context.Context, orchestrator *engine.Orchestrator, profile core.Profile, names []string, concurrency int) ([]*core.BatchResult, error) {
+// runBatchChecks checks names concurrently. display may be nil.
+func runBatchChecks(ctx context.Context, orchestrator *engine.Orchestrator, profile core.Profile, names []string, concurrency int, display *progress.Display) ([]*core.BatchResult, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -225,12 +311,16 @@ func runBatchChecks(ctx context.Context, orchestrator *engine.Orchestrator, prof
 			if ctx.Err() != nil {
 				return
 			}
-			checks, err := orchestrator.Check(ctx, job.name, profile)
+			jobCtx, budget := withNameBudget(ctx)
+			jobCtx = display.Begin(jobCtx, job.name)
+			checks, err := orchestrator.Check(jobCtx, job.name, profile)
 			if err != nil {
 				setErr(err)
 				return
 			}
 			results[job.index] = summarizeResults(job.name, checks, nil, nil, nil, nil, nil, nil)
+			results[job.index].Requests = budget.Counts()
+			display.Done(job.name)
 		}
 	}
 
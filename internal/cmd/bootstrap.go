@@ -1,16 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/fulmenhq/gofulmen/logging"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	"github.com/namelens/namelens/internal/config"
 	"github.com/namelens/namelens/internal/core/checker"
+	"github.com/namelens/namelens/internal/core/store"
 	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/progress"
 )
 
 var bootstrapCmd = &cobra.Command{
@@ -18,9 +25,21 @@ var bootstrapCmd = &cobra.Command{
 	Short: "Manage RDAP bootstrap data",
 }
 
+var (
+	bootstrapUpdateIfStale bool
+	bootstrapUpdateMaxAge  time.Duration
+)
+
 var bootstrapUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Refresh RDAP bootstrap cache",
+	Long: `Fetch the IANA RDAP bootstrap data and cache it locally, then list the
+TLD to RDAP server mappings that were added, removed, or changed since the
+previous update. Each update is kept as a snapshot (the newest 10); compare
+them with 'namelens bootstrap diff'.
+
+With --if-stale the fetch is skipped while the cached data is younger than
+--max-age (default: bootstrap.max_age), which suits a cron schedule.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		store, err := openStore(cmd.Context())
 		if err != nil {
@@ -29,7 +48,28 @@ var bootstrapUpdateCmd = &cobra.Command{
 		defer store.Close() // nolint:errcheck // best-effort cleanup; errors logged internally
 
 		service := &checker.BootstrapService{Store: store}
+		if bootstrapUpdateIfStale {
+			maxAge := bootstrapUpdateMaxAge
+			if maxAge <= 0 {
+				if cfg := config.GetConfig(); cfg != nil {
+					maxAge = cfg.Bootstrap.MaxAge
+				}
+			}
+			status, err := service.Status(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if !status.Stale(maxAge, time.Now()) {
+				fmt.Printf("Bootstrap cache is fresh (%d TLDs, fetched %s); skipping update\n", status.TLDCount, formatTimeAgo(status.FetchedAt))
+				return nil
+			}
+		}
+
+		display := newProgressDisplay(cmd, output.FormatTable, "", "", len(bootstrapStages))
+		service.Progress = bootstrapProgress(cmd.Context(), display)
+		display.Start()
 		summary, err := service.Update(cmd.Context())
+		display.Stop()
 		if err != nil {
 			return err
 		}
@@ -47,10 +87,41 @@ var bootstrapUpdateCmd = &cobra.Command{
 
 		fmt.Printf("Fetched %d TLDs from IANA\n", summary.TLDCount)
 		fmt.Printf("Database: %s\n", dbPath)
+		if summary.Diff != nil {
+			fmt.Println()
+			return renderBootstrapDiff(os.Stdout, *summary.Diff)
+		}
 		return nil
 	},
 }
 
+// bootstrapStages are the update stages shown by the progress display.
+var bootstrapStages = []string{checker.BootstrapStageFetch, checker.BootstrapStageParse, checker.BootstrapStageStore}
+
+// bootstrapProgress reports each update stage as one unit of work on
+// display, with the stage's own done/total count as its step.
+func bootstrapProgress(ctx context.Context, display *progress.Display) func(stage string, done, total int) {
+	if display == nil {
+		return nil
+	}
+	var (
+		mu      sync.Mutex
+		started = map[string]bool{}
+	)
+	return func(stage string, done, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !started[stage] {
+			started[stage] = true
+			display.Begin(ctx, stage)
+		}
+		display.Step(stage, fmt.Sprintf("%d/%d", done, total))
+		if done >= total {
+			display.Done(stage)
+		}
+	}
+}
+
 var bootstrapStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show bootstrap cache status",
@@ -69,6 +140,9 @@ var bootstrapStatusCmd = &cobra.Command{
 
 		fmt.Printf("Bootstrap cache: %d TLDs\n", status.TLDCount)
 		fmt.Printf("Last updated: %s\n", formatTime(status.FetchedAt))
+		if cfg := config.GetConfig(); cfg != nil && cfg.Bootstrap.MaxAge > 0 && status.Stale(cfg.Bootstrap.MaxAge, time.Now()) {
+			fmt.Printf("Stale: older than %s (run 'namelens bootstrap update')\n", cfg.Bootstrap.MaxAge)
+		}
 		if status.Publication.IsZero() {
 			fmt.Printf("Publication: unknown\n")
 		} else {
@@ -113,6 +187,76 @@ func getDBPath() string {
 
 func init() {
 	bootstrapCmd.AddCommand(bootstrapUpdateCmd)
+	bootstrapUpdateCmd.Flags().BoolVar(&bootstrapUpdateIfStale, "if-stale", false, "only update when the cache is missing or older than --max-age")
+	bootstrapUpdateCmd.Flags().DurationVar(&bootstrapUpdateMaxAge, "max-age", 0, "staleness threshold for --if-stale (default: bootstrap.max_age)")
+	bootstrapUpdateCmd.Flags().Bool("no-progress", false, "Disable the live progress display on interactive terminals")
 	bootstrapCmd.AddCommand(bootstrapStatusCmd)
+	bootstrapCmd.AddCommand(bootstrapHistoryCmd)
+	bootstrapCmd.AddCommand(bootstrapDiffCmd)
 	rootCmd.AddCommand(bootstrapCmd)
 }
+
+// startBootstrapRefresh keeps the RDAP bootstrap data fresh for a command run
+// when bootstrap.auto_refresh is set. Missing data is fetched before returning
+// so lookups can be routed; stale data is refreshed in the background while
+// lookups use the current copy. The returned func waits for that refresh and
+// must run before the store is closed.
+func startBootstrapRefresh(ctx context.Context, cfg *config.Config, st *store.Store) func() {
+	noop := func() {}
+	if cfg == nil || st == nil || !cfg.Bootstrap.AutoRefresh || isOffline(cfg) {
+		return noop
+	}
+
+	service := &checker.BootstrapService{Store: st}
+	status, err := service.Status(ctx)
+	if err != nil || !status.Stale(cfg.Bootstrap.MaxAge, time.Now()) {
+		return noop
+	}
+	if status.TLDCount == 0 {
+		refreshBootstrap(ctx, service, observability.CLILogger)
+		return noop
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		refreshBootstrap(ctx, service, observability.CLILogger)
+	}()
+	return func() { <-done }
+}
+
+// runBootstrapRefresher refreshes stale bootstrap data for a long-running
+// server: once at startup and then every interval until ctx is done.
+func runBootstrapRefresher(ctx context.Context, cfg *config.Config, st *store.Store, interval time.Duration) {
+	if cfg == nil || st == nil || !cfg.Bootstrap.AutoRefresh || isOffline(cfg) {
+		return
+	}
+	service := &checker.BootstrapService{Store: st}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status, err := service.Status(ctx)
+		if err == nil && status.Stale(cfg.Bootstrap.MaxAge, time.Now()) {
+			refreshBootstrap(ctx, service, observability.ServerLogger)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func refreshBootstrap(ctx context.Context, service *checker.BootstrapService, logger *logging.Logger) {
+	summary, err := service.Update(ctx)
+	if logger == nil {
+		return
+	}
+	if err != nil {
+		logger.Warn("RDAP bootstrap refresh failed; using cached data", zap.Error(err))
+		return
+	}
+	logger.Debug("RDAP bootstrap data refreshed",
+		zap.Int("tld_count", summary.TLDCount),
+		zap.String("version", summary.Version))
+}
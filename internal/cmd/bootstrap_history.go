@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var bootstrapHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List stored bootstrap snapshots",
+	Long: `List the bootstrap snapshots kept from past updates, newest first. Pass
+their IDs to 'namelens bootstrap diff' to compare two of them.`,
+	Args: cobra.NoArgs,
+	RunE: runBootstrapHistory,
+}
+
+var bootstrapDiffCmd = &cobra.Command{
+	Use:   "diff [from-id] [to-id]",
+	Short: "Compare TLD to RDAP server mappings between bootstrap snapshots",
+	Long: `Compare the TLD to RDAP server mappings of two stored bootstrap snapshots.
+
+Without arguments the two newest snapshots are compared; with one ID that
+snapshot is compared with the newest. Use this when availability results shift
+after an update to see which TLDs moved to a different RDAP server.`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: runBootstrapDiff,
+}
+
+func init() {
+	bootstrapHistoryCmd.Flags().String("output-format", "table", "Output format: table, json")
+	bootstrapDiffCmd.Flags().String("output-format", "table", "Output format: table, json")
+}
+
+func runBootstrapHistory(cmd *cobra.Command, args []string) error {
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatTable && format != output.FormatJSON {
+		return fmt.Errorf("unsupported output format for bootstrap history: %s", format)
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	snapshots, err := store.ListBootstrapSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if format == output.FormatJSON {
+		if snapshots == nil {
+			snapshots = []core.BootstrapSnapshot{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(snapshots)
+	}
+	if len(snapshots) == 0 {
+		_, err := fmt.Fprintln(w, "No bootstrap snapshots yet (run 'namelens bootstrap update')")
+		return err
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"ID", "Fetched", "Version", "Publication", "TLDs"})
+	for _, snapshot := range snapshots {
+		t.AppendRow(table.Row{snapshot.ID, formatTime(snapshot.FetchedAt), snapshot.Version, snapshot.Publication, snapshot.TLDCount})
+	}
+	t.Render()
+	return nil
+}
+
+// bootstrapDiffReport is the JSON form of bootstrap diff.
+type bootstrapDiffReport struct {
+	From core.BootstrapSnapshot `json:"from"`
+	To   core.BootstrapSnapshot `json:"to"`
+	core.BootstrapDiff
+}
+
+func runBootstrapDiff(cmd *cobra.Command, args []string) error {
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatTable && format != output.FormatJSON {
+		return fmt.Errorf("unsupported output format for bootstrap diff: %s", format)
+	}
+	ids := make([]int64, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid snapshot ID %q", arg)
+		}
+		ids = append(ids, id)
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	snapshots, err := store.ListBootstrapSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	fromID, toID, err := bootstrapDiffRange(ids, snapshots)
+	if err != nil {
+		return err
+	}
+
+	from, err := store.GetBootstrapSnapshot(ctx, fromID)
+	if err != nil {
+		return err
+	}
+	if from == nil {
+		return fmt.Errorf("no bootstrap snapshot with ID %d", fromID)
+	}
+	to, err := store.GetBootstrapSnapshot(ctx, toID)
+	if err != nil {
+		return err
+	}
+	if to == nil {
+		return fmt.Errorf("no bootstrap snapshot with ID %d", toID)
+	}
+
+	diff := core.DiffBootstrap(from.Servers, to.Servers)
+	w := cmd.OutOrStdout()
+	if format == output.FormatJSON {
+		from.Servers, to.Servers = nil, nil
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(bootstrapDiffReport{From: *from, To: *to, BootstrapDiff: diff})
+	}
+	_, _ = fmt.Fprintf(w, "Snapshot %d (%s) → %d (%s)\n\n", from.ID, formatTime(from.FetchedAt), to.ID, formatTime(to.FetchedAt))
+	return renderBootstrapDiff(w, diff)
+}
+
+// bootstrapDiffRange picks the snapshots to compare: the given IDs, with the
+// newest snapshot standing in for a missing "to" and the two newest used when
+// no IDs are given. snapshots are newest first.
+func bootstrapDiffRange(ids []int64, snapshots []core.BootstrapSnapshot) (int64, int64, error) {
+	switch len(ids) {
+	case 2:
+		return ids[0], ids[1], nil
+	case 1:
+		if len(snapshots) == 0 {
+			return 0, 0, errors.New("no bootstrap snapshots yet (run 'namelens bootstrap update')")
+		}
+		return ids[0], snapshots[0].ID, nil
+	default:
+		if len(snapshots) < 2 {
+			return 0, 0, fmt.Errorf("need two bootstrap snapshots to compare, have %d (run 'namelens bootstrap update')", len(snapshots))
+		}
+		return snapshots[1].ID, snapshots[0].ID, nil
+	}
+}
+
+// renderBootstrapDiff writes a summary line and one line per changed TLD:
+// "+" added, "-" removed, "~" changed.
+func renderBootstrapDiff(w io.Writer, diff core.BootstrapDiff) error {
+	if diff.Count() == 0 {
+		_, err := fmt.Fprintln(w, "No TLD → RDAP server changes")
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "TLD → RDAP server changes: %d added, %d removed, %d changed\n",
+		len(diff.Added), len(diff.Removed), len(diff.Changed))
+	for _, change := range diff.Added {
+		_, _ = fmt.Fprintf(w, "  + .%s  %s\n", change.TLD, strings.Join(change.After, ", "))
+	}
+	for _, change := range diff.Removed {
+		_, _ = fmt.Fprintf(w, "  - .%s  %s\n", change.TLD, strings.Join(change.Before, ", "))
+	}
+	for _, change := range diff.Changed {
+		_, _ = fmt.Fprintf(w, "  ~ .%s  %s → %s\n", change.TLD, strings.Join(change.Before, ", "), strings.Join(change.After, ", "))
+	}
+	return nil
+}
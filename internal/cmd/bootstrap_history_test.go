@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestBootstrapDiffRange(t *testing.T) {
+	snapshots := []core.BootstrapSnapshot{{ID: 7}, {ID: 5}, {ID: 4}}
+
+	from, to, err := bootstrapDiffRange(nil, snapshots)
+	require.NoError(t, err)
+	require.Equal(t, [2]int64{5, 7}, [2]int64{from, to})
+
+	from, to, err = bootstrapDiffRange([]int64{4}, snapshots)
+	require.NoError(t, err)
+	require.Equal(t, [2]int64{4, 7}, [2]int64{from, to})
+
+	from, to, err = bootstrapDiffRange([]int64{7, 4}, snapshots)
+	require.NoError(t, err)
+	require.Equal(t, [2]int64{7, 4}, [2]int64{from, to})
+
+	_, _, err = bootstrapDiffRange(nil, snapshots[:1])
+	require.Error(t, err)
+	_, _, err = bootstrapDiffRange([]int64{4}, nil)
+	require.Error(t, err)
+}
+
+func TestRenderBootstrapDiff(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderBootstrapDiff(&buf, core.DiffBootstrap(
+		map[string][]string{"dev": {"https://old.example/"}, "net": {"https://net.example/"}},
+		map[string][]string{"dev": {"https://new.example/"}, "app": {"https://app.example/"}},
+	)))
+	require.Equal(t, `TLD → RDAP server changes: 1 added, 1 removed, 1 changed
+  + .app  https://app.example/
+  - .net  https://net.example/
+  ~ .dev  https://old.example/ → https://new.example/
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, renderBootstrapDiff(&buf, core.BootstrapDiff{}))
+	require.Equal(t, "No TLD → RDAP server changes\n", buf.String())
+}
@@ -0,0 +1,458 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var brandkitCmd = &cobra.Command{
+	Use:   "brandkit <name>",
+	Short: "Check every asset a brand needs and list what to claim",
+	Long: `Expand one name into the full brand asset matrix and check it in parallel:
+apex and www domains across a TLD set, package registry names, the GitHub
+organization, and workspace handles. The result is a claim checklist with
+direct registrar and signup links, followed by the steps that keep the
+claimed assets secure.
+
+Social networks without a reliable public lookup (X, Instagram, LinkedIn,
+YouTube) are listed with profile links to check by hand; use --no-social to
+leave them out.
+
+Domain prices are typical first-year retail prices and vary by registrar.`,
+	Example: `  namelens brandkit acme
+  namelens brandkit acme --tlds com,io,dev --registries npm --handles github
+  namelens brandkit acme --out brandkit.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBrandKit,
+}
+
+// brandKitDefaultTLDs are the TLDs a new brand usually wants to hold.
+var brandKitDefaultTLDs = []string{"com", "io", "dev", "app", "co", "ai", "net", "org"}
+
+func init() {
+	rootCmd.AddCommand(brandkitCmd)
+
+	brandkitCmd.Flags().StringSlice("tlds", brandKitDefaultTLDs, "TLDs to check")
+	brandkitCmd.Flags().StringSlice("registries", []string{"npm", "pypi", "cargo"}, "Package registries to check")
+	brandkitCmd.Flags().StringSlice("handles", []string{"github", checker.SaaSSlack, checker.SaaSDiscord}, "Handles to check")
+	brandkitCmd.Flags().Bool("no-social", false, "Leave out social networks to check by hand")
+	brandkitCmd.Flags().Int("concurrency", 6, "Concurrent checks")
+	brandkitCmd.Flags().String("output-format", "markdown", "Output format: markdown, table, json")
+	brandkitCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+	brandkitCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
+}
+
+// brandKitSocial are networks without a lookup, listed for a manual check.
+var brandKitSocial = []struct {
+	network string
+	profile string
+}{
+	{"X", "https://x.com/%s"},
+	{"Instagram", "https://www.instagram.com/%s/"},
+	{"LinkedIn", "https://www.linkedin.com/company/%s"},
+	{"YouTube", "https://www.youtube.com/@%s"},
+}
+
+// brandKitSignupLinks are where workspace handles are created.
+var brandKitSignupLinks = map[core.CheckType]string{
+	core.CheckTypeSlack:           "https://slack.com/get-started#/createnew",
+	core.CheckTypeDiscord:         "https://discord.com/",
+	core.CheckTypeGoogleWorkspace: "https://workspace.google.com/",
+}
+
+// brandKitUnchecked is the status of assets namelens cannot look up.
+const brandKitUnchecked = "unchecked"
+
+type brandKitAsset struct {
+	Category         string   `json:"category"`
+	Target           string   `json:"target"`
+	Status           string   `json:"status"`
+	Action           string   `json:"action,omitempty"`
+	Link             string   `json:"link,omitempty"`
+	EstimatedCostUSD *float64 `json:"estimated_cost_usd,omitempty"`
+	Notes            string   `json:"notes,omitempty"`
+}
+
+type brandKit struct {
+	Name         string          `json:"name"`
+	Assets       []brandKitAsset `json:"assets"`
+	Claimable    int             `json:"claimable"`
+	Secure       []string        `json:"secure,omitempty"`
+	TotalCostUSD float64         `json:"total_cost_usd"`
+}
+
+func runBrandKit(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	tlds, err := cmd.Flags().GetStringSlice("tlds")
+	if err != nil {
+		return err
+	}
+	registries, err := cmd.Flags().GetStringSlice("registries")
+	if err != nil {
+		return err
+	}
+	handles, err := cmd.Flags().GetStringSlice("handles")
+	if err != nil {
+		return err
+	}
+	noSocial, err := cmd.Flags().GetBool("no-social")
+	if err != nil {
+		return err
+	}
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return err
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return err
+	}
+
+	profile := core.Profile{
+		Name:       "brandkit",
+		TLDs:       normalizeTLDs(tlds),
+		Registries: normalizeList(registries),
+		Handles:    normalizeList(handles),
+	}
+	if len(profile.TLDs) == 0 && len(profile.Registries) == 0 && len(profile.Handles) == 0 {
+		return errors.New("at least one check target is required")
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+	results, err := runTargetChecks(ctx, splitProfileTargets(profile), concurrency, func(ctx context.Context, target core.Profile) ([]*core.CheckResult, error) {
+		return orchestrator.Check(ctx, name, target)
+	})
+	if err != nil {
+		return err
+	}
+
+	kit := buildBrandKit(name, results, !noSocial)
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderBrandKit(sink.writer, kit, format)
+}
+
+// splitProfileTargets splits profile into one single-target profile per check,
+// in the orchestrator's order, so the checks can run in parallel.
+func splitProfileTargets(profile core.Profile) []core.Profile {
+	targets := make([]core.Profile, 0, len(profile.TLDs)+len(profile.Registries)+len(profile.Handles))
+	for _, tld := range profile.TLDs {
+		targets = append(targets, core.Profile{Name: profile.Name, TLDs: []string{tld}})
+	}
+	for _, registry := range profile.Registries {
+		targets = append(targets, core.Profile{Name: profile.Name, Registries: []string{registry}})
+	}
+	for _, handle := range profile.Handles {
+		targets = append(targets, core.Profile{Name: profile.Name, Handles: []string{handle}})
+	}
+	return targets
+}
+
+// runTargetChecks runs check for each target on up to concurrency workers
+// and returns the results in target order. The first error is returned.
+func runTargetChecks(ctx context.Context, targets []core.Profile, concurrency int, check func(context.Context, core.Profile) ([]*core.CheckResult, error)) ([]*core.CheckResult, error) {
+	perTarget := make([][]*core.CheckResult, len(targets))
+	errs := make([]error, len(targets))
+	if concurrency > len(targets) {
+		concurrency = len(targets)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				if ctx.Err() != nil {
+					errs[index] = ctx.Err()
+					continue
+				}
+				perTarget[index], errs[index] = check(ctx, targets[index])
+			}
+		}()
+	}
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	results := make([]*core.CheckResult, 0, len(targets))
+	for i := range targets {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		results = append(results, perTarget[i]...)
+	}
+	return results, nil
+}
+
+// buildBrandKit turns check results into the asset matrix and the steps to
+// secure what can be claimed.
+func buildBrandKit(name string, results []*core.CheckResult, social bool) brandKit {
+	kit := brandKit{Name: name, Assets: make([]brandKitAsset, 0, len(results)*2)}
+
+	var domains []string
+	var accounts []string
+	githubOrg := false
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		available := result.Available == core.AvailabilityAvailable
+		asset := brandKitAsset{
+			Target: fmt.Sprintf("%s %s", result.CheckType, result.Name),
+			Status: result.Available.String(),
+			Notes:  brandKitNotes(result),
+		}
+
+		switch result.CheckType {
+		case core.CheckTypeDomain:
+			asset.Category = "domain"
+			asset.Target = result.Name
+			if available {
+				asset.Action = "Register " + result.Name
+				asset.Link = "https://www.namecheap.com/domains/registration/results/?domain=" + result.Name
+				if cost, ok := planDomainCosts[brandKitTLD(result)]; ok {
+					asset.EstimatedCostUSD = &cost
+				}
+				domains = append(domains, result.Name)
+			}
+			kit.Assets = append(kit.Assets, asset)
+			kit.Assets = append(kit.Assets, brandKitAsset{
+				Category: "domain",
+				Target:   "www." + result.Name,
+				Status:   "with apex",
+				Notes:    "Served from the apex registration; point it at the same site.",
+			})
+			continue
+		case core.CheckTypeNPM, core.CheckTypePyPI, core.CheckTypeCargo:
+			asset.Category = "package"
+			if available {
+				accounts = append(accounts, brandKitRegistryLabel(result.CheckType))
+			}
+		case core.CheckTypeGitHub:
+			asset.Category = "org"
+			githubOrg = githubOrg || available
+		default:
+			asset.Category = "workspace"
+		}
+
+		if available {
+			if step, ok := planClaimStep(name, result.CheckType); ok {
+				asset.Action = step.Title
+				asset.Link = step.Link
+				asset.EstimatedCostUSD = step.EstimatedCostUSD
+			} else if link, ok := brandKitSignupLinks[result.CheckType]; ok {
+				asset.Action = fmt.Sprintf("Create the %s %s", brandKitServiceLabel(result.CheckType), result.Name)
+				asset.Link = link
+			}
+		}
+		kit.Assets = append(kit.Assets, asset)
+	}
+
+	if social {
+		for _, network := range brandKitSocial {
+			kit.Assets = append(kit.Assets, brandKitAsset{
+				Category: "social",
+				Target:   network.network + " @" + name,
+				Status:   brandKitUnchecked,
+				Action:   fmt.Sprintf("Check and claim @%s on %s", name, network.network),
+				Link:     fmt.Sprintf(network.profile, name),
+			})
+		}
+	}
+
+	for _, asset := range kit.Assets {
+		if asset.Status != core.AvailabilityAvailable.String() {
+			continue
+		}
+		kit.Claimable++
+		if asset.EstimatedCostUSD != nil {
+			kit.TotalCostUSD += *asset.EstimatedCostUSD
+		}
+	}
+
+	if len(domains) > 0 {
+		kit.Secure = append(kit.Secure,
+			"Enable auto-renew and registrar lock on "+strings.Join(domains, ", "),
+			"Point the www hosts at the same site as their apex domains",
+			"Add SPF, DKIM, and DMARC records, even on domains that send no mail",
+		)
+	}
+	if githubOrg {
+		kit.Secure = append(kit.Secure, fmt.Sprintf("Require two-factor authentication for the %s GitHub organization: https://github.com/organizations/%s/settings/security", name, name))
+	}
+	if len(accounts) > 0 {
+		kit.Secure = append(kit.Secure, "Turn on two-factor authentication for the "+strings.Join(accounts, ", ")+" accounts that own the names")
+	}
+	return kit
+}
+
+func brandKitTLD(result *core.CheckResult) string {
+	tld := strings.TrimPrefix(result.TLD, ".")
+	if tld == "" {
+		_, tld, _ = strings.Cut(result.Name, ".")
+	}
+	return tld
+}
+
+func brandKitNotes(result *core.CheckResult) string {
+	if result.Available == core.AvailabilityAvailable || result.Available == core.AvailabilityTaken {
+		return ""
+	}
+	return result.Message
+}
+
+func brandKitRegistryLabel(checkType core.CheckType) string {
+	switch checkType {
+	case core.CheckTypePyPI:
+		return "PyPI"
+	case core.CheckTypeCargo:
+		return "crates.io"
+	}
+	return string(checkType)
+}
+
+func brandKitServiceLabel(checkType core.CheckType) string {
+	switch checkType {
+	case core.CheckTypeSlack:
+		return "Slack workspace"
+	case core.CheckTypeDiscord:
+		return "Discord server"
+	case core.CheckTypeGoogleWorkspace:
+		return "Google Workspace for"
+	}
+	return string(checkType)
+}
+
+func renderBrandKit(w io.Writer, kit brandKit, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(kit)
+	case output.FormatTable:
+		t := table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.SetTitle("Brand kit: " + kit.Name)
+		t.AppendHeader(table.Row{"Category", "Asset", "Status", "Action", "Link"})
+		for _, asset := range kit.Assets {
+			t.AppendRow(table.Row{asset.Category, asset.Target, asset.Status, asset.Action, asset.Link})
+		}
+		t.Render()
+		return nil
+	default:
+		return renderBrandKitMarkdown(w, kit)
+	}
+}
+
+func renderBrandKitMarkdown(w io.Writer, kit brandKit) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Brand kit: %s\n\n", kit.Name)
+	fmt.Fprintf(&b, "- **Claimable now:** %d\n", kit.Claimable)
+	fmt.Fprintf(&b, "- **Estimated cost:** %s (known costs only)\n", formatPlanCost(kit.TotalCostUSD))
+
+	b.WriteString("\n## Assets\n\n")
+	b.WriteString("| Category | Asset | Status | Notes |\n")
+	b.WriteString("|----------|-------|--------|-------|\n")
+	for _, asset := range kit.Assets {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", asset.Category, asset.Target, asset.Status, strings.ReplaceAll(asset.Notes, "|", "\\|"))
+	}
+
+	var claim, manual, unavailable, unconfirmed []string
+	for _, asset := range kit.Assets {
+		switch {
+		case asset.Status == core.AvailabilityAvailable.String():
+			claim = append(claim, brandKitChecklistItem(asset))
+		case asset.Status == brandKitUnchecked:
+			manual = append(manual, brandKitChecklistItem(asset))
+		case asset.Status == core.AvailabilityTaken.String():
+			unavailable = append(unavailable, asset.Target)
+		case asset.Status != "with apex":
+			unconfirmed = append(unconfirmed, fmt.Sprintf("%s (%s)", asset.Target, asset.Status))
+		}
+	}
+
+	if len(claim) > 0 {
+		b.WriteString("\n## Claim now\n\n")
+		b.WriteString(strings.Join(claim, ""))
+	}
+	if len(kit.Secure) > 0 {
+		b.WriteString("\n## Secure\n\n")
+		for _, step := range kit.Secure {
+			fmt.Fprintf(&b, "- [ ] %s\n", step)
+		}
+	}
+	if len(manual) > 0 {
+		b.WriteString("\n## Check by hand\n\n")
+		b.WriteString(strings.Join(manual, ""))
+	}
+	writeMarkdownList(&b, "Not available", unavailable)
+	writeMarkdownList(&b, "Could not confirm", unconfirmed)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func brandKitChecklistItem(asset brandKitAsset) string {
+	cost := ""
+	if asset.EstimatedCostUSD != nil {
+		cost = " — " + formatPlanCost(*asset.EstimatedCostUSD)
+	}
+	item := fmt.Sprintf("- [ ] **%s** [%s]%s\n", asset.Action, asset.Category, cost)
+	if asset.Link != "" {
+		item += fmt.Sprintf("  %s\n", asset.Link)
+	}
+	return item
+}
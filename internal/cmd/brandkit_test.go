@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestSplitProfileTargets(t *testing.T) {
+	targets := splitProfileTargets(core.Profile{
+		Name:       "brandkit",
+		TLDs:       []string{"com", "io"},
+		Registries: []string{"npm"},
+		Handles:    []string{"github"},
+	})
+	require.Equal(t, []core.Profile{
+		{Name: "brandkit", TLDs: []string{"com"}},
+		{Name: "brandkit", TLDs: []string{"io"}},
+		{Name: "brandkit", Registries: []string{"npm"}},
+		{Name: "brandkit", Handles: []string{"github"}},
+	}, targets)
+}
+
+func TestRunTargetChecksKeepsOrder(t *testing.T) {
+	targets := splitProfileTargets(core.Profile{TLDs: []string{"com", "io", "dev", "app"}, Registries: []string{"npm"}})
+
+	var running, peak int32
+	results, err := runTargetChecks(context.Background(), targets, 3, func(_ context.Context, target core.Profile) ([]*core.CheckResult, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&running, -1)
+		if len(target.TLDs) > 0 {
+			return []*core.CheckResult{{Name: "acme." + target.TLDs[0], CheckType: core.CheckTypeDomain}}, nil
+		}
+		return []*core.CheckResult{{Name: "acme", CheckType: core.CheckTypeNPM}}, nil
+	})
+	require.NoError(t, err)
+	require.LessOrEqual(t, peak, int32(3))
+
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		names = append(names, result.Name)
+	}
+	require.Equal(t, []string{"acme.com", "acme.io", "acme.dev", "acme.app", "acme"}, names)
+
+	_, err = runTargetChecks(context.Background(), targets, 2, func(context.Context, core.Profile) ([]*core.CheckResult, error) {
+		return nil, errors.New("boom")
+	})
+	require.EqualError(t, err, "boom")
+}
+
+func TestBuildBrandKit(t *testing.T) {
+	results := []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+		{Name: "acme.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeGitHub, Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeSlack, Available: core.AvailabilityUnknown, Message: "workspace lookup inconclusive"},
+	}
+
+	kit := buildBrandKit("acme", results, true)
+
+	require.Equal(t, 3, kit.Claimable)
+	require.Equal(t, 40.0, kit.TotalCostUSD)
+	require.Len(t, kit.Assets, 5+2+len(brandKitSocial))
+
+	require.Equal(t, "www.acme.com", kit.Assets[1].Target)
+	require.Equal(t, "with apex", kit.Assets[1].Status)
+	require.Equal(t, "Register acme.io", kit.Assets[2].Action)
+	require.Contains(t, kit.Assets[2].Link, "domain=acme.io")
+	require.Equal(t, "Create the npm organization @acme", kit.Assets[4].Action)
+	require.Equal(t, "workspace lookup inconclusive", kit.Assets[6].Notes)
+	require.Empty(t, kit.Assets[6].Action)
+
+	require.Contains(t, kit.Secure[0], "acme.io")
+	require.Contains(t, kit.Secure, "Turn on two-factor authentication for the npm accounts that own the names")
+
+	withoutSocial := buildBrandKit("acme", results, false)
+	require.Len(t, withoutSocial.Assets, 5+2)
+}
+
+func TestRenderBrandKitMarkdown(t *testing.T) {
+	kit := buildBrandKit("acme", []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+		{Name: "acme.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeDiscord, Available: core.AvailabilityAvailable},
+	}, true)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderBrandKit(&buf, kit, output.FormatMarkdown))
+	out := buf.String()
+
+	require.Contains(t, out, "# Brand kit: acme")
+	require.Contains(t, out, "- **Claimable now:** 2")
+	require.Contains(t, out, "| domain | www.acme.io | with apex |")
+	require.Contains(t, out, "## Claim now\n\n- [ ] **Register acme.io** [domain] — $40\n  https://www.namecheap.com/domains/registration/results/?domain=acme.io\n")
+	require.Contains(t, out, "- [ ] **Create the Discord server acme** [workspace]\n  https://discord.com/\n")
+	require.Contains(t, out, "## Secure\n\n- [ ] Enable auto-renew and registrar lock on acme.io\n")
+	require.Contains(t, out, "## Check by hand\n\n- [ ] **Check and claim @acme on X** [social]\n  https://x.com/acme\n")
+	require.Contains(t, out, "## Not available\n\n- acme.com\n")
+	require.NotContains(t, out, "- www.acme.com")
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var cachePurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete check cache entries by name, TLD, type, or age",
+	Long: `Delete check cache entries. Filters combine, so --name acme --tld io only
+deletes the acme.io domain entry. The next check of a purged entry makes a
+fresh lookup.`,
+	Example: `  namelens cache purge --name acme
+  namelens cache purge --type npm --older-than 168h
+  namelens cache purge --expired
+  namelens cache purge --all --yes`,
+	Args: cobra.NoArgs,
+	RunE: runCachePurge,
+}
+
+func init() {
+	addCachePurgeFilterFlags(cachePurgeCmd)
+	cachePurgeCmd.Flags().Bool("yes", false, "Confirm purging every entry with --all")
+	cachePurgeCmd.Flags().Bool("dry-run", false, "Show what would be deleted")
+	cachePurgeCmd.Flags().String("output-format", string(output.FormatTable), "Output format: table|json")
+}
+
+// addCachePurgeFilterFlags adds the flags that select entries to purge.
+func addCachePurgeFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "Purge every entry")
+	cmd.Flags().String("name", "", "Purge entries for a name")
+	cmd.Flags().String("tld", "", "Purge domain entries for a TLD")
+	cmd.Flags().String("type", "", "Purge entries of a check type (domain, npm, pypi, cargo, github, ...)")
+	cmd.Flags().Duration("older-than", 0, "Purge entries checked more than this long ago, e.g. 168h")
+	cmd.Flags().Bool("expired", false, "Purge entries whose TTL has passed")
+}
+
+func runCachePurge(cmd *cobra.Command, args []string) error {
+	formatValue, err := cmd.Flags().GetString("output-format")
+	if err != nil {
+		return err
+	}
+	format, err := output.ParseFormat(formatValue)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatJSON && format != output.FormatTable {
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	query, err := cachePurgeQuery(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := query.Validate(); err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+	if query.All && !yes && !dryRun {
+		return errors.New("--all requires --yes (or use --dry-run)")
+	}
+
+	ctx := cmd.Context()
+	db, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint:errcheck // best-effort cleanup
+
+	matched, err := db.CountCacheEntries(ctx, query)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return writeCachePurgeResult(format, cmd.OutOrStdout(), matched, 0, true)
+	}
+
+	deleted, err := db.PurgeCache(ctx, query)
+	if err != nil {
+		return err
+	}
+	return writeCachePurgeResult(format, cmd.OutOrStdout(), matched, deleted, false)
+}
+
+func cachePurgeQuery(cmd *cobra.Command, now time.Time) (store.CachePurgeQuery, error) {
+	var query store.CachePurgeQuery
+	var err error
+	if query.All, err = cmd.Flags().GetBool("all"); err != nil {
+		return query, err
+	}
+	if query.Name, err = cmd.Flags().GetString("name"); err != nil {
+		return query, err
+	}
+	if query.TLD, err = cmd.Flags().GetString("tld"); err != nil {
+		return query, err
+	}
+	if query.CheckType, err = cmd.Flags().GetString("type"); err != nil {
+		return query, err
+	}
+	olderThan, err := cmd.Flags().GetDuration("older-than")
+	if err != nil {
+		return query, err
+	}
+	if olderThan < 0 {
+		return query, errors.New("--older-than must be positive")
+	}
+	if olderThan > 0 {
+		query.CheckedBefore = now.Add(-olderThan)
+	}
+	expired, err := cmd.Flags().GetBool("expired")
+	if err != nil {
+		return query, err
+	}
+	if expired {
+		query.ExpiredAt = now
+	}
+	if query.All && (strings.TrimSpace(query.Name) != "" || strings.TrimSpace(query.TLD) != "" || strings.TrimSpace(query.CheckType) != "" || olderThan > 0 || expired) {
+		return query, errors.New("--all cannot be combined with filters")
+	}
+	return query, nil
+}
+
+func writeCachePurgeResult(format output.Format, w io.Writer, matched int, deleted int64, dryRun bool) error {
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(map[string]any{
+			"matched": matched,
+			"deleted": deleted,
+			"dry_run": dryRun,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	if dryRun {
+		_, err := fmt.Fprintf(w, "Would delete %d cache entr(ies)\n", matched)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Deleted %d/%d cache entr(ies)\n", deleted, matched)
+	return err
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show check cache entries, sizes, and hit rates by check type",
+	Long: `Show the check cache by check type: entries (and how many are still live),
+approximate stored size, cache hits, fresh lookups, and the hit rate.
+
+Fresh lookups come from check history, which records every check that was not
+answered from the cache.`,
+	Args: cobra.NoArgs,
+	RunE: runCacheStats,
+}
+
+func init() {
+	cacheStatsCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	cacheStatsCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	stats, err := store.CacheStats(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderCacheStats(sink.writer, stats, format)
+}
+
+func renderCacheStats(w io.Writer, stats []corestore.CacheTypeStat, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		_, _ = fmt.Fprintln(w, "| Type | Entries | Live | Size | Hits | Fresh Lookups | Hit Rate |")
+		_, _ = fmt.Fprintln(w, "|------|---------|------|------|------|---------------|----------|")
+		for _, row := range cacheStatsRows(stats) {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s | %s |\n", row[0], row[1], row[2], row[3], row[4], row[5], row[6])
+		}
+		return nil
+	default:
+		if len(stats) == 0 {
+			_, err := fmt.Fprintln(w, "The check cache is empty")
+			return err
+		}
+		t := table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.AppendHeader(table.Row{"Type", "Entries", "Live", "Size", "Hits", "Fresh Lookups", "Hit Rate"})
+		for _, row := range cacheStatsRows(stats) {
+			t.AppendRow(table.Row{row[0], row[1], row[2], row[3], row[4], row[5], row[6]})
+		}
+		t.Render()
+		return nil
+	}
+}
+
+// cacheStatsRows formats each check type, then the totals.
+func cacheStatsRows(stats []corestore.CacheTypeStat) [][7]string {
+	var total corestore.CacheTypeStat
+	rows := make([][7]string, 0, len(stats)+1)
+	for _, stat := range stats {
+		rows = append(rows, cacheStatsRow(stat.CheckType, stat))
+		total.Entries += stat.Entries
+		total.Live += stat.Live
+		total.Bytes += stat.Bytes
+		total.Hits += stat.Hits
+		total.FreshLookups += stat.FreshLookups
+	}
+	if len(stats) > 1 {
+		rows = append(rows, cacheStatsRow("total", total))
+	}
+	return rows
+}
+
+func cacheStatsRow(label string, stat corestore.CacheTypeStat) [7]string {
+	return [7]string{
+		label,
+		fmt.Sprintf("%d", stat.Entries),
+		fmt.Sprintf("%d", stat.Live),
+		formatFileSize(stat.Bytes),
+		fmt.Sprintf("%d", stat.Hits),
+		fmt.Sprintf("%d", stat.FreshLookups),
+		formatPercent(stat.Hits, stat.Hits+stat.FreshLookups),
+	}
+}
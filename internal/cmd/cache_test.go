@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestCachePurgeQuery(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		addCachePurgeFilterFlags(cmd)
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	query, err := cachePurgeQuery(newCmd("--name", "acme", "--older-than", "24h", "--expired"), now)
+	require.NoError(t, err)
+	require.Equal(t, "acme", query.Name)
+	require.Equal(t, now.Add(-24*time.Hour), query.CheckedBefore)
+	require.Equal(t, now, query.ExpiredAt)
+
+	_, err = cachePurgeQuery(newCmd("--all", "--type", "npm"), now)
+	require.ErrorContains(t, err, "cannot be combined")
+
+	query, err = cachePurgeQuery(newCmd(), now)
+	require.NoError(t, err)
+	require.Error(t, query.Validate())
+}
+
+func TestSummarizeCacheWarm(t *testing.T) {
+	results := []*core.BatchResult{
+		{Name: "acme", Results: []*core.CheckResult{
+			{Available: core.AvailabilityTaken},
+			{Available: core.AvailabilityAvailable, Provenance: core.Provenance{FromCache: true}},
+			{Available: core.AvailabilityRateLimited},
+		}},
+		nil,
+		{Name: "zenith", Results: []*core.CheckResult{{Available: core.AvailabilityAvailable}, nil}},
+	}
+
+	summary := summarizeCacheWarm("startup", results)
+	require.Equal(t, cacheWarmSummary{Profile: "startup", Names: 2, Checks: 4, Fresh: 2, Cached: 1, Unknown: 1}, summary)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderCacheWarm(&buf, []cacheWarmSummary{summary}, output.FormatJSON))
+	var parsed []cacheWarmSummary
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	require.Equal(t, []cacheWarmSummary{summary}, parsed)
+}
+
+func TestRenderCacheStats(t *testing.T) {
+	stats := []corestore.CacheTypeStat{
+		{CheckType: "domain", Entries: 3, Live: 2, Bytes: 2048, Hits: 3, FreshLookups: 1, HitRate: 0.75},
+		{CheckType: "npm", Entries: 1, Live: 1, Bytes: 100},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderCacheStats(&buf, stats, output.FormatMarkdown))
+	require.Contains(t, buf.String(), "| domain | 3 | 2 | 2.0 KB | 3 | 1 | 75% |")
+	require.Contains(t, buf.String(), "| npm | 1 | 1 | 100 bytes | 0 | 0 | - |")
+	require.Contains(t, buf.String(), "| total | 4 | 3 | 2.1 KB | 3 | 1 | 75% |")
+
+	buf.Reset()
+	require.NoError(t, renderCacheStats(&buf, nil, output.FormatTable))
+	require.Equal(t, "The check cache is empty\n", buf.String())
+}
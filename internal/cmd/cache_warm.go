@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var cacheWarmCmd = &cobra.Command{
+	Use:   "warm <names-file>",
+	Short: "Pre-populate the check cache for names across profiles",
+	Long: `Check every name in a names file against each profile so later checks,
+compares, and reviews are answered from the cache. Entries that are still live
+are left alone, so a scheduled warm (e.g. from cron during off-hours) only
+looks up what expired since the last run.
+
+Cached entries last for the cache TTLs (cache.available_ttl, cache.taken_ttl);
+raise them to keep warmed results through the working day.`,
+	Example: `  namelens cache warm finalists.txt --profile startup --profile developer
+  namelens cache warm finalists.txt --deadline 2h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCacheWarm,
+}
+
+func init() {
+	cacheWarmCmd.Flags().StringSlice("profile", []string{"startup"}, "Profiles to warm (repeatable)")
+	cacheWarmCmd.Flags().Int("concurrency", 3, "Concurrent checks")
+	cacheWarmCmd.Flags().String("output-format", string(output.FormatTable), "Output format: table|json")
+	addCheckTimeoutFlags(cacheWarmCmd)
+}
+
+// cacheWarmSummary counts what warming one profile did.
+type cacheWarmSummary struct {
+	Profile string `json:"profile"`
+	Names   int    `json:"names"`
+	Checks  int    `json:"checks"`
+	// Fresh checks were looked up and cached; Cached were already live
+	Fresh   int `json:"fresh"`
+	Cached  int `json:"cached"`
+	Unknown int `json:"unknown"`
+}
+
+func runCacheWarm(cmd *cobra.Command, args []string) error {
+	formatValue, err := cmd.Flags().GetString("output-format")
+	if err != nil {
+		return err
+	}
+	format, err := output.ParseFormat(formatValue)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatJSON && format != output.FormatTable {
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+	profileNames, err := cmd.Flags().GetStringSlice("profile")
+	if err != nil {
+		return err
+	}
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return err
+	}
+	if concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+
+	names, err := readNamesFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	startedAt := time.Now()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() // nolint:errcheck // best-effort cleanup
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+	if isOffline(cfg) {
+		return errors.New("cache warm needs network access; it cannot run offline")
+	}
+
+	profiles := make([]core.Profile, 0, len(profileNames))
+	for _, name := range profileNames {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		profile, err := resolveProfile(ctx, store, name, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, profile)
+	}
+	if len(profiles) == 0 {
+		return errors.New("at least one profile is required")
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, true)
+	defer orchestrator.Wait()
+	if err := applyCheckTimeouts(cmd, orchestrator, startedAt); err != nil {
+		return err
+	}
+
+	summaries := make([]cacheWarmSummary, 0, len(profiles))
+	for _, profile := range profiles {
+		results, err := runBatchChecks(ctx, orchestrator, profile, names, concurrency, nil)
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, summarizeCacheWarm(profile.Name, results))
+	}
+
+	return renderCacheWarm(cmd.OutOrStdout(), summaries, format)
+}
+
+func summarizeCacheWarm(profile string, results []*core.BatchResult) cacheWarmSummary {
+	summary := cacheWarmSummary{Profile: profile}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		summary.Names++
+		for _, check := range result.Results {
+			if check == nil {
+				continue
+			}
+			summary.Checks++
+			switch {
+			case check.Provenance.FromCache:
+				summary.Cached++
+			case check.Available == core.AvailabilityAvailable || check.Available == core.AvailabilityTaken:
+				summary.Fresh++
+			default:
+				summary.Unknown++
+			}
+		}
+	}
+	return summary
+}
+
+func renderCacheWarm(w io.Writer, summaries []cacheWarmSummary, format output.Format) error {
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Profile", "Names", "Checks", "Fresh", "Already Cached", "Unknown"})
+	for _, summary := range summaries {
+		t.AppendRow(table.Row{summary.Profile, summary.Names, summary.Checks, summary.Fresh, summary.Cached, summary.Unknown})
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/network"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// Chaos settings are set by the hidden global --chaos-* flags. They exist so
+// operators and CI can check retry and partial-result handling.
+var (
+	chaosRDAPFailureRate float64
+	chaosLatency         time.Duration
+)
+
+func addChaosFlags(cmd *cobra.Command) {
+	flags := cmd.PersistentFlags()
+	flags.Float64Var(&chaosRDAPFailureRate, "chaos-rdap-failure-rate", 0, "testing: fail this fraction (0-1) of RDAP requests with a synthetic network error")
+	flags.DurationVar(&chaosLatency, "chaos-latency", 0, "testing: add this latency to every checker HTTP request")
+	_ = flags.MarkHidden("chaos-rdap-failure-rate")
+	_ = flags.MarkHidden("chaos-latency")
+}
+
+// chaosEnabled reports whether any chaos flag is active.
+func chaosEnabled() bool {
+	return chaosRDAPFailureRate > 0 || chaosLatency > 0
+}
+
+// chaosTransports wraps base for the chaos flags, returning the transport for
+// RDAP requests, which also get injected failures, and the transport for all
+// other checker requests. Both are base when chaos is off.
+func chaosTransports(base http.RoundTripper) (rdapTransport, checkerTransport http.RoundTripper) {
+	if !chaosEnabled() {
+		return base, base
+	}
+	if observability.CLILogger != nil {
+		observability.CLILogger.Warn("Chaos testing enabled; checker requests will be slowed or fail",
+			zap.Float64("rdap_failure_rate", chaosRDAPFailureRate),
+			zap.Duration("latency", chaosLatency),
+		)
+	}
+	return chaosWrap(base, true), chaosWrap(base, false)
+}
+
+// chaosWrap wraps base for the chaos flags; only RDAP transports get injected
+// failures.
+func chaosWrap(base http.RoundTripper, rdap bool) http.RoundTripper {
+	switch {
+	case !chaosEnabled():
+		return base
+	case rdap:
+		return &network.ChaosTransport{Base: base, FailureRate: chaosRDAPFailureRate, Latency: chaosLatency}
+	case chaosLatency > 0:
+		return &network.ChaosTransport{Base: base, Latency: chaosLatency}
+	}
+	return base
+}
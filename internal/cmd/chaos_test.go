@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/network"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+func TestChaosTransports(t *testing.T) {
+	observability.InitCLILogger("namelens-test", false)
+	t.Cleanup(func() {
+		chaosRDAPFailureRate = 0
+		chaosLatency = 0
+	})
+	base := http.DefaultTransport
+
+	rdapTransport, checkerTransport := chaosTransports(base)
+	require.Same(t, base, rdapTransport)
+	require.Same(t, base, checkerTransport)
+
+	chaosRDAPFailureRate = 0.25
+	rdapTransport, checkerTransport = chaosTransports(base)
+	require.Equal(t, &network.ChaosTransport{Base: base, FailureRate: 0.25}, rdapTransport)
+	require.Same(t, base, checkerTransport, "failures are injected into RDAP requests only")
+
+	chaosLatency = time.Second
+	rdapTransport, checkerTransport = chaosTransports(base)
+	require.Equal(t, &network.ChaosTransport{Base: base, FailureRate: 0.25, Latency: time.Second}, rdapTransport)
+	require.Equal(t, &network.ChaosTransport{Base: base, Latency: time.Second}, checkerTransport)
+}
+
+func TestChaosFlagsHidden(t *testing.T) {
+	for _, name := range []string{"chaos-rdap-failure-rate", "chaos-latency"} {
+		flag := rootCmd.PersistentFlags().Lookup(name)
+		require.NotNil(t, flag, name)
+		require.True(t, flag.Hidden, name)
+	}
+}
@@ -6,25 +6,30 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
-	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/openrdap/rdap"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/audit"
 	"github.com/namelens/namelens/internal/config"
 	"github.com/namelens/namelens/internal/core"
 	"github.com/namelens/namelens/internal/core/checker"
 	"github.com/namelens/namelens/internal/core/engine"
 	"github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/keychain"
 	"github.com/namelens/namelens/internal/observability"
 	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/redact"
 )
 
 var checkCmd = &cobra.Command{
@@ -39,13 +44,19 @@ func init() {
 	rootCmd.AddCommand(checkCmd)
 
 	checkCmd.Flags().StringSlice("tlds", []string{"com", "dev", "io", "app"}, "TLDs to check")
-	checkCmd.Flags().StringSlice("registries", []string{"npm", "pypi", "cargo"}, "Registries to check (npm, pypi, cargo)")
-	checkCmd.Flags().StringSlice("handles", []string{"github"}, "Handles to check (github)")
+	checkCmd.Flags().StringSlice("registries", []string{"npm", "pypi", "cargo"}, "Registries to check (npm, pypi, cargo, or a custom checker name)")
+	checkCmd.Flags().StringSlice("handles", []string{"github"}, "Handles to check (github, slack, discord, google-workspace)")
 	checkCmd.Flags().String("profile", "", "Use predefined profile")
 	checkCmd.Flags().String("names-file", "", "Read names from file (one per line) or '-' for stdin")
-	checkCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	addNameSelectionFlags(checkCmd)
+	addSaaSFlag(checkCmd)
+	checkCmd.Flags().String("output-format", "table", "Output format: table, json, markdown, pr-comment, html, csv, junit")
 	checkCmd.Flags().String("out", "", "Write output to a file (default stdout)")
 	checkCmd.Flags().String("out-dir", "", "Write per-name outputs to a directory")
+	addPDFFlag(checkCmd)
+	addJUnitFlag(checkCmd)
+	addOutputTemplateFlag(checkCmd)
+	addOutArchiveFlag(checkCmd)
 	checkCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
 	checkCmd.Flags().Int("concurrency", 3, "Concurrent checks across names")
 	checkCmd.Flags().Bool("expert", false, "Include expert search backend")
@@ -56,13 +67,33 @@ func init() {
 	checkCmd.Flags().String("expert-model", "", "Expert model override")
 	checkCmd.Flags().String("expert-prompt", "", "Expert prompt slug (defaults to config)")
 	checkCmd.Flags().Bool("phonetics", false, "Analyze pronunciation and typeability")
+	addPhoneticsEngineFlag(checkCmd)
 	checkCmd.Flags().Bool("suitability", false, "Analyze cultural appropriateness")
 	checkCmd.Flags().StringSlice("locales", nil, "Locales to analyze (comma-separated)")
 	checkCmd.Flags().StringSlice("keyboards", nil, "Keyboard layouts for typeability analysis")
 	checkCmd.Flags().String("sensitivity", "", "Suitability sensitivity: minimal, standard, strict")
+	addMarketsFlag(checkCmd)
+	addConsensusFlags(checkCmd)
+	addSimilarityFlags(checkCmd)
+	addWordScanFlags(checkCmd)
+	addVariantsFlags(checkCmd)
+	checkCmd.Flags().Bool("alternatives", false, "Look up uncached alternative domains when .com is taken (extra domain lookups)")
+	checkCmd.Flags().Bool("no-alternatives", false, "Skip alternative domain suggestions when .com is taken")
+	checkCmd.MarkFlagsMutuallyExclusive("alternatives", "no-alternatives")
+	checkCmd.Flags().Bool("no-progress", false, "Disable the live progress display on interactive terminals")
+	checkCmd.Flags().Bool("request-summary", false, "Print a footer counting external requests and cache hits for the run")
+	addVerifyTakenFlags(checkCmd)
+	addPriceFlags(checkCmd)
+	addCheckTimeoutFlags(checkCmd)
+	addFailIfFlag(checkCmd)
+	checkCmd.Flags().BoolP("quiet", "q", false, "Check one target (e.g. acme.com) and print only available, taken, or unknown, exiting 0, 1, or 2")
 }
 
 func runCheck(cmd *cobra.Command, args []string) error {
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		return runCheckQuiet(cmd, args)
+	}
+
 	namesFile, err := cmd.Flags().GetString("names-file")
 	if err != nil {
 		return err
@@ -71,6 +102,10 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	names, err = selectNames(cmd, names)
+	if err != nil {
+		return err
+	}
 
 	tlds, err := cmd.Flags().GetStringSlice("tlds")
 	if err != nil {
@@ -138,6 +173,10 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	phoneticsEngine, err := resolvePhoneticsEngine(cmd)
+	if err != nil {
+		return err
+	}
 	suitabilityEnabled, err := cmd.Flags().GetBool("suitability")
 	if err != nil {
 		return err
@@ -154,9 +193,59 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	alternatives, err := cmd.Flags().GetBool("alternatives")
+	if err != nil {
+		return err
+	}
+	noAlternatives, err := cmd.Flags().GetBool("no-alternatives")
+	if err != nil {
+		return err
+	}
+	variantsLimit, err := resolveVariants(cmd)
+	if err != nil {
+		return err
+	}
+	requestSummary, err := cmd.Flags().GetBool("request-summary")
+	if err != nil {
+		return err
+	}
+	verifySample, err := resolveVerifyTaken(cmd)
+	if err != nil {
+		return err
+	}
+	failIf, err := resolveFailIf(cmd)
+	if err != nil {
+		return err
+	}
+
+	format, err := resolveCheckOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	pdfPath, err := resolvePDFPath(cmd, format)
+	if err != nil {
+		return err
+	}
+	junit, err := resolveJUnitFormatter(cmd)
+	if err != nil {
+		return err
+	}
+	custom, err := resolveOutputTemplate(cmd, "check")
+	if err != nil {
+		return err
+	}
+	outPath, outDir, err := resolveOutputTargets(cmd)
+	if err != nil {
+		return err
+	}
 
 	ctx := cmd.Context()
 	startedAt := time.Now()
+	runBudget := &core.RequestBudget{}
+	archive, err := resolveOutputArchive(cmd, "check", startedAt)
+	if err != nil {
+		return err
+	}
 	store, err := openStore(ctx)
 	if err != nil {
 		return err
@@ -167,6 +256,22 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	if cfg == nil {
 		return errors.New("config not loaded")
 	}
+	cfg, markets, err := resolveMarkets(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	similar, err := resolveSimilarity(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	priceProviders, err := resolvePriceProviders(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	words, err := resolveWordScan(cmd, cfg)
+	if err != nil {
+		return err
+	}
 
 	// Show guidance about AI backend if not configured
 	showExpertGuidanceWarning(cfg.AILink, nil)
@@ -175,14 +280,46 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := applySaaSFlag(cmd, &profile); err != nil {
+		return err
+	}
+	applyMarketTLDs(&profile, markets)
 	if len(profile.TLDs) == 0 && len(profile.Registries) == 0 && len(profile.Handles) == 0 {
 		return errors.New("at least one check target is required")
 	}
+	if profile.ExpertDepth != "" && !cmd.Flags().Changed("expert-depth") {
+		expertDepth = profile.ExpertDepth
+	}
+	if n, _ := cmd.Flags().GetInt("consensus"); n > 1 {
+		if !suitabilityEnabled {
+			return errors.New("--consensus requires --suitability")
+		}
+		if strings.TrimSpace(expertModel) != "" {
+			return errors.New("--consensus picks its own models; drop --expert-model")
+		}
+	}
+	consensus, consensusThreshold, err := resolveConsensus(cmd, cfg, expertDepth)
+	if err != nil {
+		return err
+	}
 
+	defer startBootstrapRefresh(ctx, cfg, store)()
 	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+	if err := applyCheckTimeouts(cmd, orchestrator, startedAt); err != nil {
+		return err
+	}
+	suggestAlternatives := (cfg.Domain.Alternatives.Enabled || alternatives) && !noAlternatives && len(profile.TLDs) > 0
 
 	locales := normalizeInputList(localesRaw)
+	if len(locales) == 0 {
+		locales = markets.Locales
+	}
 	keyboards := normalizeInputList(keyboardsRaw)
+	if len(keyboards) == 0 {
+		keyboards = markets.Keyboards
+	}
+	ctx, runUsage := ailink.WithUsageTracker(ctx)
 
 	var (
 		bulkAttempted    bool
@@ -202,7 +339,7 @@ func runCheck(cmd *cobra.Command, args []string) error {
 		if len(names) > expertBulkLimit {
 			return fmt.Errorf("--expert-bulk supports up to %d names (got %d)", expertBulkLimit, len(names))
 		}
-		bulkExpertByName, bulkFatalErr = runExpertBulk(ctx, cfg, store, names, expertDepth, expertModel, expertPrompt, !noCache)
+		bulkExpertByName, bulkFatalErr = runExpertBulk(core.WithRequestBudget(ctx, runBudget), cfg, store, names, expertDepth, expertModel, expertPrompt, !noCache)
 		if bulkExpertByName == nil {
 			bulkExpertByName = map[string]*ailink.SearchResponse{}
 		}
@@ -219,6 +356,9 @@ func runCheck(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	display := newProgressDisplay(cmd, format, outPath, outDir, len(names))
+	display.Start()
+
 	type checkJob struct {
 		index int
 		name  string
@@ -249,7 +389,10 @@ func runCheck(cmd *cobra.Command, args []string) error {
 				return
 			}
 
+			ctx, budget := withNameBudget(ctx)
 			name := job.name
+			ctx = display.Begin(ctx, name)
+			ctx, nameUsage := ailink.WithUsageTracker(ctx)
 			results, err := orchestrator.Check(ctx, name, profile)
 			if err != nil {
 				setErr(err)
@@ -326,7 +469,7 @@ func runCheck(cmd *cobra.Command, args []string) error {
 				if len(keyboards) > 0 {
 					vars["keyboards"] = strings.Join(keyboards, ", ")
 				}
-				phoneticsResult, phoneticsError = runAnalysis(ctx, cfg, store, "name-phonetics", name, expertDepth, expertModel, vars, !noCache)
+				phoneticsResult, phoneticsError = runPhonetics(ctx, cfg, store, phoneticsEngine, name, expertDepth, expertModel, vars, keyboards, !noCache)
 			}
 			if suitabilityEnabled {
 				vars := map[string]string{"name": name}
@@ -336,10 +479,32 @@ func runCheck(cmd *cobra.Command, args []string) error {
 				if trimmed := strings.TrimSpace(sensitivity); trimmed != "" {
 					vars["sensitivity_level"] = trimmed
 				}
-				suitabilityRaw, suitabilityErr = runAnalysis(ctx, cfg, store, "name-suitability", name, expertDepth, expertModel, vars, !noCache)
+				if len(consensus) > 0 {
+					suitabilityRaw, suitabilityErr = runConsensus(ctx, cfg, consensus, consensusThreshold, func(ctx context.Context, cfg *config.Config, model string) (json.RawMessage, *ailink.SearchError) {
+						return runAnalysis(ctx, cfg, store, suitabilityPromptSlug, name, expertDepth, model, vars, !noCache)
+					})
+				} else {
+					suitabilityRaw, suitabilityErr = runAnalysis(ctx, cfg, store, suitabilityPromptSlug, name, expertDepth, expertModel, vars, !noCache)
+				}
 			}
 
-			batches[job.index] = summarizeResults(name, results, expertResult, expertError, phoneticsResult, phoneticsError, suitabilityRaw, suitabilityErr)
+			batch := summarizeResults(name, results, expertResult, expertError, phoneticsResult, phoneticsError, suitabilityRaw, suitabilityErr)
+			batch.LocaleMatrix = suitabilityLocaleMatrix(suitabilityRaw, locales)
+			if suggestAlternatives && shouldSuggestAlternatives(name, results) {
+				domains := alternativeDomains(name, cfg.Domain.Alternatives, profile.TLDs)
+				if alternatives {
+					batch.Alternatives = checkAlternatives(ctx, orchestrator, domains)
+				} else {
+					batch.Alternatives = cachedAlternatives(ctx, orchestrator, domains)
+				}
+			}
+			if variantsLimit > 0 {
+				batch.Variants = checkVariants(ctx, orchestrator, name, variantsLimit)
+			}
+			batch.Requests = budget.Counts()
+			batch.AIUsage = trackedUsage(nameUsage)
+			batches[job.index] = batch
+			display.Done(name)
 		}
 	}
 
@@ -361,24 +526,27 @@ enqueue:
 	}
 	close(jobs)
 	wg.Wait()
+	display.Stop()
 
 	if firstErr != nil {
 		return firstErr
 	}
-
-	format, err := resolveOutputFormat(cmd)
-	if err != nil {
-		return err
-	}
-	outPath, outDir, err := resolveOutputTargets(cmd)
-	if err != nil {
-		return err
-	}
+	applySimilarity(batches, similar)
+	applyWordScan(batches, words)
+	verifyAvailable(ctx, orchestrator, batches, verifySample)
+	priceAvailable(ctx, priceProviders, cfg.Pricing.PremiumThreshold, batches)
 
 	var rendered string
-	if len(batches) == 1 {
+	switch {
+	case custom != nil:
+		rendered, err = custom.FormatBatches(batches)
+	case format == output.FormatPRComment:
+		rendered, err = prCommentFormatter(cmd, args).FormatBatches(batches)
+	case format == output.FormatJUnit:
+		rendered, err = junit.FormatBatches(batches)
+	case len(batches) == 1:
 		rendered, err = output.NewFormatter(format).FormatBatch(batches[0])
-	} else {
+	default:
 		rendered, err = output.FormatBatchList(format, batches)
 	}
 	if err != nil {
@@ -386,22 +554,24 @@ enqueue:
 	}
 
 	ext := outputExtension(format)
-	if outDir != "" {
+	if custom != nil {
+		ext = templateExtension(custom.Template.Name())
+	}
+	if outDir != "" || archive != nil {
 		outDir, err := ensureOutDir(outDir)
 		if err != nil {
 			return err
 		}
 
 		indexRendered := rendered
-		if len(batches) == 1 {
+		if len(batches) == 1 && custom == nil {
 			indexRendered, err = output.FormatBatchList(format, batches)
 			if err != nil {
 				return err
 			}
 		}
 
-		indexPath := filepath.Join(outDir, fmt.Sprintf("check.index.%s", ext))
-		indexSink, err := openSink(indexPath)
+		indexSink, err := openRunSink(archive, outDir, fmt.Sprintf("check.index.%s", ext), archiveKindIndex)
 		if err != nil {
 			return err
 		}
@@ -414,19 +584,24 @@ enqueue:
 		}
 
 		formatter := output.NewFormatter(format)
+		switch {
+		case custom != nil:
+			formatter = custom
+		case format == output.FormatJUnit:
+			formatter = junit
+		}
 		for _, batch := range batches {
 			if batch == nil {
 				continue
 			}
 			fileName := sanitizeFilename(batch.Name)
-			path := filepath.Join(outDir, fmt.Sprintf("%s.check.%s", fileName, ext))
-			sink, err := openSink(path)
+			sink, err := openRunSink(archive, outDir, fmt.Sprintf("%s.check.%s", fileName, ext), archiveKindOutput)
 			if err != nil {
 				return err
 			}
 
 			var content string
-			if format == output.FormatJSON {
+			if format == output.FormatJSON && custom == nil {
 				payload, err := json.MarshalIndent(batch, "", "  ")
 				if err != nil {
 					_ = sink.close()
@@ -480,6 +655,37 @@ enqueue:
 		}
 	}
 
+	if pdfPath != "" {
+		if err := writeReportPDF(ctx, cfg, pdfPath, func(w io.Writer) error {
+			_, err := io.WriteString(w, rendered)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	if archive != nil {
+		if err := writeBatchArchive(archive, batches, archiveProvenance{
+			OutputFormat: string(format),
+			Profile:      profileName,
+			Names:        names,
+			Cache:        !noCache,
+			Offline:      isOffline(cfg),
+			Requests:     runRequestCounts(batches, runBudget),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if requestSummary {
+		printRequestSummary(os.Stderr, runRequestCounts(batches, runBudget))
+	}
+	printAIUsageSummary(os.Stderr, runUsage.Snapshot())
+
+	if err := evaluateFailIf(failIf, batches); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
 	return nil
 }
 
@@ -524,7 +730,12 @@ func normalizeTLDs(values []string) []string {
 	return result
 }
 
+// resolveGitHubToken returns the GitHub token from the OS keychain (see
+// namelens auth set github), then GITHUB_TOKEN, then NAMELENS_GITHUB_TOKEN.
 func resolveGitHubToken() string {
+	if token := keychain.Lookup(keychain.GitHubAccount); token != "" {
+		return token
+	}
 	if token := strings.TrimSpace(os.Getenv("GITHUB_TOKEN")); token != "" {
 		return token
 	}
@@ -549,18 +760,30 @@ func logThroughput(count int, startedAt time.Time) {
 }
 
 func buildOrchestrator(cfg *config.Config, store *store.Store, useCache bool) *engine.Orchestrator {
-	limiter := &engine.RateLimiter{Store: store}
+	limiter := &engine.RateLimiter{Store: store, Backend: configuredRateLimitBackend(cfg)}
 	limiter.ApplyOverrides(cfg.RateLimits)
 	limiter.ApplySafetyMargin(cfg.RateLimitMargin)
 
+	revalidator := &engine.Revalidator{}
 	cachePolicy := checker.CachePolicy{
-		AvailableTTL: cfg.Cache.AvailableTTL,
-		TakenTTL:     cfg.Cache.TakenTTL,
-		ErrorTTL:     cfg.Cache.ErrorTTL,
+		AvailableTTL:         cfg.Cache.AvailableTTL,
+		TakenTTL:             cfg.Cache.TakenTTL,
+		ErrorTTL:             cfg.Cache.ErrorTTL,
+		StaleWhileRevalidate: cfg.Cache.StaleWhileRevalidate,
+		Revalidator:          revalidator,
 	}
 
+	dialer := configuredDialer(cfg)
+	dialers := checkerDialers(cfg, dialer)
+	rdapTransport, transport := chaosTransports(dialer.Transport())
+	rdapTransport = checkerTransport(dialers, audit.KindRDAP, rdapTransport)
+	registryTransport := audit.NewTransport(audit.KindRegistry, checkerTransport(dialers, audit.KindRegistry, transport))
+	handleTransport := audit.NewTransport(audit.KindHandle, checkerTransport(dialers, audit.KindHandle, transport))
+	httpTransport := checkerTransport(dialers, audit.KindHTTP, transport)
+
 	domainChecker := &checker.DomainChecker{
 		Store:       store,
+		Client:      &rdap.Client{HTTP: &http.Client{Transport: audit.NewTransport(audit.KindRDAP, rdapTransport)}},
 		ToolVersion: versionInfo.Version,
 		Limiter:     limiter,
 		CachePolicy: cachePolicy,
@@ -574,15 +797,28 @@ func buildOrchestrator(cfg *config.Config, store *store.Store, useCache bool) *e
 			Servers:           cfg.Domain.WhoisFallback.Servers,
 			AvailablePatterns: cfg.Domain.WhoisFallback.AvailablePatterns,
 			TakenPatterns:     cfg.Domain.WhoisFallback.TakenPatterns,
+			MaxPerServer:      cfg.Domain.WhoisFallback.MaxPerServer,
+			Delay:             cfg.Domain.WhoisFallback.Delay,
+			ServerDelays:      whoisServerDelays(cfg.Domain.WhoisFallback.ServerDelays),
 		},
 		DNSCfg: checker.DNSFallbackConfig{
-			Enabled:  cfg.Domain.DNSFallback.Enabled,
-			CacheTTL: cfg.Domain.DNSFallback.CacheTTL,
-			Timeout:  cfg.Domain.DNSFallback.Timeout,
+			Enabled:        cfg.Domain.DNSFallback.Enabled,
+			CacheTTL:       cfg.Domain.DNSFallback.CacheTTL,
+			Timeout:        cfg.Domain.DNSFallback.Timeout,
+			Records:        cfg.Domain.DNSFallback.Records,
+			DetectWildcard: cfg.Domain.DNSFallback.DetectWildcard,
 		},
+		DNS:             dnsFallbackResolver(cfg),
+		Dial:            checkerDialer(dialers, audit.KindWHOIS, dialer).DialContext,
+		Probe:           &http.Client{Timeout: 5 * time.Second, Transport: audit.NewTransport(audit.KindHTTP, httpTransport)},
+		Retry:           retryPolicy(cfg.Domain.RDAPRetry.RetryPolicyConfig),
+		RetryEndpoints:  retryEndpoints(cfg.Domain.RDAPRetry.Endpoints),
+		BootstrapMaxAge: cfg.Bootstrap.MaxAge,
+		DropList:        configuredDropList(cfg.Domain.DropLists, &http.Client{Timeout: 30 * time.Second, Transport: audit.NewTransport(audit.KindHTTP, httpTransport)}),
 	}
 	npmChecker := &checker.NPMChecker{
 		Store:       store,
+		Client:      &http.Client{Timeout: 10 * time.Second, Transport: registryTransport},
 		ToolVersion: versionInfo.Version,
 		Limiter:     limiter,
 		CachePolicy: cachePolicy,
@@ -590,6 +826,7 @@ func buildOrchestrator(cfg *config.Config, store *store.Store, useCache bool) *e
 	}
 	pypiChecker := &checker.PyPIChecker{
 		Store:       store,
+		Client:      &http.Client{Timeout: 10 * time.Second, Transport: registryTransport},
 		ToolVersion: versionInfo.Version,
 		Limiter:     limiter,
 		CachePolicy: cachePolicy,
@@ -597,6 +834,7 @@ func buildOrchestrator(cfg *config.Config, store *store.Store, useCache bool) *e
 	}
 	cargoChecker := &checker.CargoChecker{
 		Store:       store,
+		Client:      &http.Client{Timeout: 10 * time.Second, Transport: registryTransport},
 		ToolVersion: versionInfo.Version,
 		Limiter:     limiter,
 		CachePolicy: cachePolicy,
@@ -604,6 +842,7 @@ func buildOrchestrator(cfg *config.Config, store *store.Store, useCache bool) *e
 	}
 	githubChecker := &checker.GitHubChecker{
 		Store:       store,
+		Client:      &http.Client{Timeout: 10 * time.Second, Transport: handleTransport},
 		ToolVersion: versionInfo.Version,
 		Limiter:     limiter,
 		Token:       resolveGitHubToken(),
@@ -611,7 +850,7 @@ func buildOrchestrator(cfg *config.Config, store *store.Store, useCache bool) *e
 		UseCache:    useCache,
 	}
 
-	return &engine.Orchestrator{
+	orchestrator := &engine.Orchestrator{
 		Checkers: map[core.CheckType]engine.Checker{
 			core.CheckTypeDomain: domainChecker,
 		},
@@ -623,28 +862,162 @@ func buildOrchestrator(cfg *config.Config, store *store.Store, useCache bool) *e
 		HandleCheckers: map[string]engine.Checker{
 			"github": githubChecker,
 		},
+		Revalidator: revalidator,
+	}
+	for _, service := range checker.SaaSServices {
+		orchestrator.HandleCheckers[service] = &checker.SaaSChecker{
+			Service:     service,
+			Store:       store,
+			Client:      &http.Client{Timeout: 10 * time.Second, Transport: handleTransport},
+			Resolver:    configuredResolver(cfg),
+			ToolVersion: versionInfo.Version,
+			Limiter:     limiter,
+			CachePolicy: cachePolicy,
+			UseCache:    useCache,
+		}
 	}
+	registerCustomCheckers(orchestrator, cfg.Checkers.Custom, func(c *checker.HTTPPluginChecker) {
+		c.Store = store
+		c.Client = &http.Client{Timeout: c.Config.Timeout, Transport: audit.NewTransport(audit.KindHTTP, httpTransport)}
+		c.ToolVersion = versionInfo.Version
+		c.Limiter = limiter
+		c.CachePolicy = cachePolicy
+		c.UseCache = useCache
+	})
+	registerCatalogCheckers(orchestrator, cfg.Checkers.Catalogs)
+	if store != nil {
+		orchestrator.History = store
+	}
+	orchestrator.Offline = isOffline(cfg)
+	return orchestrator
 }
 
-func summarizeResults(name string, results []*core.CheckResult, expert *ailink.SearchResponse, expertErr *ailink.SearchError, phonetics json.RawMessage, phoneticsErr *ailink.SearchError, suitability json.RawMessage, suitabilityErr *ailink.SearchError) *core.BatchResult {
-	canonicalName := canonicalBatchName(name, results)
-	total := 0
-	score := 0
-	unknown := 0
-	for _, result := range results {
-		if result == nil {
+func retryPolicy(cfg config.RetryPolicyConfig) checker.RetryPolicy {
+	return checker.RetryPolicy{
+		MaxAttempts: cfg.MaxAttempts,
+		BaseDelay:   cfg.BaseDelay,
+		MaxDelay:    cfg.MaxDelay,
+		Jitter:      cfg.Jitter,
+	}
+}
+
+func retryEndpoints(endpoints map[string]config.RetryPolicyConfig) map[string]checker.RetryPolicy {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	policies := make(map[string]checker.RetryPolicy, len(endpoints))
+	for host, cfg := range endpoints {
+		policies[strings.ToLower(strings.TrimSpace(host))] = retryPolicy(cfg)
+	}
+	return policies
+}
+
+// whoisServerDelays lowercases the hosts of per-server WHOIS delays.
+func whoisServerDelays(delays map[string]time.Duration) map[string]time.Duration {
+	if len(delays) == 0 {
+		return nil
+	}
+	normalized := make(map[string]time.Duration, len(delays))
+	for host, delay := range delays {
+		normalized[strings.ToLower(strings.TrimSpace(host))] = delay
+	}
+	return normalized
+}
+
+// configuredDropList returns the pending-delete lists to look taken domains
+// up in, in name order, or nil when drop lists are off or have no sources.
+func configuredDropList(cfg config.DropListConfig, client *http.Client) *checker.DropList {
+	if !cfg.Enabled || len(cfg.Sources) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(cfg.Sources))
+	for name := range cfg.Sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sources := make([]checker.DropListSource, 0, len(names))
+	for _, name := range names {
+		sources = append(sources, checker.DropListSource{Name: name, Location: cfg.Sources[name]})
+	}
+	return &checker.DropList{
+		Sources: sources,
+		Refresh: cfg.Refresh,
+		Client:  client,
+		OnError: func(source string, err error) {
+			observability.CLILogger.Warn("Drop list unavailable", zap.String("source", source), zap.Error(err))
+		},
+	}
+}
+
+// registerCustomCheckers adds configured HTTP plugin checkers as registries.
+// Invalid entries and names that shadow built-in checkers are skipped with a warning.
+func registerCustomCheckers(orchestrator *engine.Orchestrator, custom map[string]config.CustomCheckerConfig, configure func(*checker.HTTPPluginChecker)) {
+	keys := make([]string, 0, len(custom))
+	for key := range custom {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		if _, exists := orchestrator.RegistryCheckers[normalized]; exists || orchestrator.HandleCheckers[normalized] != nil || normalized == string(core.CheckTypeDomain) {
+			observability.CLILogger.Warn("Custom checker name conflicts with a built-in checker; skipping", zap.String("checker", key))
 			continue
 		}
-		// Count unknown/unsupported separately - they shouldn't affect the score denominator
-		if result.Available == core.AvailabilityUnknown || result.Available == core.AvailabilityUnsupported {
-			unknown++
+		cfg := custom[key]
+		pluginChecker, err := checker.NewHTTPPluginChecker(normalized, checker.HTTPPluginConfig{
+			URL:             cfg.URL,
+			Method:          cfg.Method,
+			Headers:         cfg.Headers,
+			Body:            cfg.Body,
+			AvailableStatus: cfg.AvailableStatus,
+			TakenStatus:     cfg.TakenStatus,
+			NamePattern:     cfg.NamePattern,
+			Timeout:         cfg.Timeout,
+		})
+		if err != nil {
+			observability.CLILogger.Warn("Invalid custom checker; skipping", zap.String("checker", key), zap.Error(err))
 			continue
 		}
-		total++
-		if result.Available == core.AvailabilityAvailable {
-			score++
+		if configure != nil {
+			configure(pluginChecker)
 		}
+		orchestrator.RegistryCheckers[normalized] = pluginChecker
 	}
+}
+
+// registerCatalogCheckers adds configured internal service catalogs as
+// registries. Catalogs that fail to load and names that shadow other
+// checkers are skipped with a warning.
+func registerCatalogCheckers(orchestrator *engine.Orchestrator, catalogs map[string]config.CatalogConfig) {
+	keys := make([]string, 0, len(catalogs))
+	for key := range catalogs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		normalized := strings.ToLower(strings.TrimSpace(key))
+		if _, exists := orchestrator.RegistryCheckers[normalized]; exists || orchestrator.HandleCheckers[normalized] != nil || normalized == string(core.CheckTypeDomain) {
+			observability.CLILogger.Warn("Catalog name conflicts with another checker; skipping", zap.String("catalog", key))
+			continue
+		}
+		cfg := catalogs[key]
+		entries, err := checker.LoadCatalog(cfg.Path, cfg.Format)
+		if err != nil {
+			observability.CLILogger.Warn("Invalid service catalog; skipping", zap.String("catalog", key), zap.Error(err))
+			continue
+		}
+		catalogChecker := checker.NewCatalogChecker(normalized, cfg.Path, entries)
+		catalogChecker.ToolVersion = versionInfo.Version
+		orchestrator.RegistryCheckers[normalized] = catalogChecker
+	}
+}
+
+func summarizeResults(name string, results []*core.CheckResult, expert *ailink.SearchResponse, expertErr *ailink.SearchError, phonetics json.RawMessage, phoneticsErr *ailink.SearchError, suitability json.RawMessage, suitabilityErr *ailink.SearchError) *core.BatchResult {
+	canonicalName := canonicalBatchName(name, results)
+	score, total, unknown := scoreResults(results)
 
 	return &core.BatchResult{
 		Name:             canonicalName,
@@ -662,6 +1035,97 @@ func summarizeResults(name string, results []*core.CheckResult, expert *ailink.S
 	}
 }
 
+// localeNotAssessed marks a requested locale missing from the analysis.
+const localeNotAssessed = "not assessed"
+
+// suitabilityLocaleMatrix lists the suitability analysis's by_locale entries
+// for the requested locales, in request order, followed by any other locales
+// the analysis covered. A language-only locale such as "de" matches the first
+// regional entry ("de-DE") when there is no exact match.
+func suitabilityLocaleMatrix(raw json.RawMessage, locales []string) []core.LocaleSuitability {
+	if len(raw) == 0 || len(locales) == 0 {
+		return nil
+	}
+	var analysis struct {
+		ByLocale []struct {
+			Locale      string   `json:"locale"`
+			Suitability string   `json:"suitability"`
+			Score       *int     `json:"score"`
+			Concerns    []string `json:"concerns"`
+		} `json:"by_locale"`
+	}
+	if err := json.Unmarshal(raw, &analysis); err != nil {
+		return nil
+	}
+
+	used := make([]bool, len(analysis.ByLocale))
+	row := func(i int, locale string) core.LocaleSuitability {
+		used[i] = true
+		entry := analysis.ByLocale[i]
+		rating := strings.ToLower(strings.TrimSpace(entry.Suitability))
+		if rating == "" {
+			rating = localeNotAssessed
+		}
+		var concerns []string
+		for _, concern := range entry.Concerns {
+			if trimmed := strings.TrimSpace(concern); trimmed != "" && !strings.EqualFold(trimmed, "none") {
+				concerns = append(concerns, trimmed)
+			}
+		}
+		return core.LocaleSuitability{Locale: locale, Rating: rating, Score: entry.Score, Concerns: concerns}
+	}
+	find := func(locale string) int {
+		for i, entry := range analysis.ByLocale {
+			if !used[i] && strings.EqualFold(strings.TrimSpace(entry.Locale), locale) {
+				return i
+			}
+		}
+		if strings.Contains(locale, "-") {
+			return -1
+		}
+		for i, entry := range analysis.ByLocale {
+			if !used[i] && strings.HasPrefix(strings.ToLower(strings.TrimSpace(entry.Locale)), strings.ToLower(locale)+"-") {
+				return i
+			}
+		}
+		return -1
+	}
+
+	matrix := make([]core.LocaleSuitability, 0, max(len(locales), len(analysis.ByLocale)))
+	for _, locale := range locales {
+		if i := find(locale); i >= 0 {
+			matrix = append(matrix, row(i, strings.TrimSpace(analysis.ByLocale[i].Locale)))
+		} else {
+			matrix = append(matrix, core.LocaleSuitability{Locale: locale, Rating: localeNotAssessed})
+		}
+	}
+	for i, entry := range analysis.ByLocale {
+		if !used[i] && strings.TrimSpace(entry.Locale) != "" {
+			matrix = append(matrix, row(i, strings.TrimSpace(entry.Locale)))
+		}
+	}
+	return matrix
+}
+
+// scoreResults counts available results out of those with a verdict.
+func scoreResults(results []*core.CheckResult) (score, total, unknown int) {
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		// Count unknown/unsupported separately - they shouldn't affect the score denominator
+		if result.Available == core.AvailabilityUnknown || result.Available == core.AvailabilityUnsupported {
+			unknown++
+			continue
+		}
+		total++
+		if result.Available == core.AvailabilityAvailable {
+			score++
+		}
+	}
+	return score, total, unknown
+}
+
 func canonicalBatchName(name string, results []*core.CheckResult) string {
 	normalized := strings.ToLower(strings.TrimSpace(name))
 	inferred := inferredBatchName(results)
@@ -728,7 +1192,7 @@ func resultNameCandidate(result *core.CheckResult) string {
 		name = strings.TrimPrefix(name, "@")
 	}
 
-	if result.CheckType == core.CheckTypeDomain {
+	if result.CheckType == core.CheckTypeDomain || result.CheckType == core.CheckTypeGoogleWorkspace {
 		tld := strings.ToLower(strings.TrimSpace(result.TLD))
 		if tld != "" {
 			suffix := "." + tld
@@ -785,20 +1249,28 @@ func runExpert(ctx context.Context, cfg *config.Config, store *store.Store, name
 		return nil, &ailink.SearchError{Code: "AILINK_NO_API_KEY", Message: "provider api key not configured", Details: resolved.ProviderID}
 	}
 
+	variables := marketSearchVariables(cfg)
+	cacheSlug := analysisCacheKey(promptSlug, variables)
 	cacheTTL := cfg.AILink.CacheTTL
-	if useCache && store != nil && cacheTTL > 0 {
-		entry, err := store.GetExpertCache(ctx, name, promptSlug, resolved.Model, resolved.BaseURL, depth)
+	if (useCache || isOffline(cfg)) && store != nil && cacheTTL > 0 {
+		entry, err := store.GetExpertCache(ctx, name, cacheSlug, resolved.Model, resolved.BaseURL, depth)
 		if err != nil {
 			observability.CLILogger.Warn("Expert cache lookup failed", zap.Error(err))
 		} else if entry != nil {
 			response, err := decodeCachedExpert(entry.ResponseJSON)
 			if err == nil {
+				ailink.CheckPlausibility(response, depth)
+				core.CountRequest(ctx, core.RequestCacheHit)
 				return response, nil
 			}
 			observability.CLILogger.Warn("Expert cache decode failed", zap.Error(err))
 		}
 	}
 
+	if isOffline(cfg) {
+		return nil, offlineSearchError()
+	}
+
 	catalog, err := buildSchemaCatalog()
 	if err != nil {
 		return nil, &ailink.SearchError{Code: "AILINK_API_ERROR", Message: "failed to load schemas", Details: err.Error()}
@@ -808,12 +1280,17 @@ func runExpert(ctx context.Context, cfg *config.Config, store *store.Store, name
 		Providers: providers,
 		Registry:  registry,
 		Catalog:   catalog,
+		Quota:     aiQuota(cfg, store),
+		Budget:    aiBudget(cfg, store),
 	}
 
+	core.CountRequest(ctx, core.RequestAI)
+	core.ReportProgress(ctx, "AI "+promptSlug)
 	response, err := service.Search(ctx, ailink.SearchRequest{
 		Role:       role,
 		Name:       name,
 		PromptSlug: promptSlug,
+		Variables:  variables,
 		Depth:      depth,
 		Model:      modelOverride,
 		UseTools:   true,
@@ -831,7 +1308,7 @@ func runExpert(ctx context.Context, cfg *config.Config, store *store.Store, name
 			}
 		}
 		if raw != "" {
-			if err := store.SetExpertCache(ctx, name, promptSlug, resolved.Model, resolved.BaseURL, depth, raw, cacheTTL); err != nil {
+			if err := store.SetExpertCache(ctx, name, cacheSlug, resolved.Model, resolved.BaseURL, depth, raw, cacheTTL); err != nil {
 				observability.CLILogger.Warn("Expert cache write failed", zap.Error(err))
 			}
 		}
@@ -932,7 +1409,7 @@ func runExpertBulk(ctx context.Context, cfg *config.Config, store *store.Store,
 	cacheTTL := cfg.AILink.CacheTTL
 	cacheVars := map[string]string{"names": strings.Join(names, ","), "prompt": promptSlug}
 	cacheSlug := analysisCacheKey(promptSlug, cacheVars)
-	if useCache && store != nil && cacheTTL > 0 {
+	if (useCache || isOffline(cfg)) && store != nil && cacheTTL > 0 {
 		entry, err := store.GetExpertCache(ctx, "__bulk__", cacheSlug, resolved.Model, resolved.BaseURL, depth)
 		if err != nil {
 			observability.CLILogger.Warn("Expert bulk cache lookup failed", zap.Error(err))
@@ -950,22 +1427,32 @@ func runExpertBulk(ctx context.Context, cfg *config.Config, store *store.Store,
 						Insights:        item.Insights,
 						Mentions:        item.Mentions,
 						Recommendations: item.Recommendations,
+						Suspect:         item.Suspect,
+						Warnings:        item.Warnings,
 					}
+					ailink.CheckPlausibility(resp, depth)
 					out[item.Name] = resp
 				}
+				core.CountRequest(ctx, core.RequestCacheHit)
 				return out, nil
 			}
 			observability.CLILogger.Warn("Expert bulk cache decode failed", zap.Error(jsonErr))
 		}
 	}
 
+	if isOffline(cfg) {
+		return nil, offlineSearchError()
+	}
+
 	catalog, err := buildSchemaCatalog()
 	if err != nil {
 		return nil, &ailink.SearchError{Code: "AILINK_API_ERROR", Message: "failed to load schemas", Details: err.Error()}
 	}
 
-	service := &ailink.Service{Providers: providers, Registry: registry, Catalog: catalog}
+	service := &ailink.Service{Providers: providers, Registry: registry, Catalog: catalog, Quota: aiQuota(cfg, store), Budget: aiBudget(cfg, store)}
 
+	core.CountRequest(ctx, core.RequestAI)
+	core.ReportProgress(ctx, "AI "+promptSlug)
 	bulk, err := service.SearchBulk(ctx, ailink.BulkSearchRequest{
 		Role:       role,
 		Names:      names,
@@ -991,6 +1478,8 @@ func runExpertBulk(ctx context.Context, cfg *config.Config, store *store.Store,
 			Insights:        item.Insights,
 			Mentions:        item.Mentions,
 			Recommendations: item.Recommendations,
+			Suspect:         item.Suspect,
+			Warnings:        item.Warnings,
 		}
 		out[strings.ToLower(strings.TrimSpace(item.Name))] = resp
 	}
@@ -1062,15 +1551,20 @@ func runAnalysis(ctx context.Context, cfg *config.Config, store *store.Store, pr
 
 	cacheTTL := cfg.AILink.CacheTTL
 	cacheSlug := analysisCacheKey(promptSlug, cleaned)
-	if useCache && store != nil && cacheTTL > 0 {
+	if (useCache || isOffline(cfg)) && store != nil && cacheTTL > 0 {
 		entry, err := store.GetExpertCache(ctx, name, cacheSlug, resolved.Model, resolved.BaseURL, depth)
 		if err != nil {
 			observability.CLILogger.Warn("Expert cache lookup failed", zap.Error(err))
 		} else if entry != nil {
+			core.CountRequest(ctx, core.RequestCacheHit)
 			return json.RawMessage(entry.ResponseJSON), nil
 		}
 	}
 
+	if isOffline(cfg) {
+		return nil, offlineSearchError()
+	}
+
 	catalog, err := buildSchemaCatalog()
 	if err != nil {
 		return nil, &ailink.SearchError{Code: "AILINK_API_ERROR", Message: "failed to load schemas", Details: err.Error()}
@@ -1080,8 +1574,12 @@ func runAnalysis(ctx context.Context, cfg *config.Config, store *store.Store, pr
 		Providers: providers,
 		Registry:  registry,
 		Catalog:   catalog,
+		Quota:     aiQuota(cfg, store),
+		Budget:    aiBudget(cfg, store),
 	}
 
+	core.CountRequest(ctx, core.RequestAI)
+	core.ReportProgress(ctx, "AI "+promptSlug)
 	response, err := service.Generate(ctx, ailink.GenerateRequest{
 		Role:       role,
 		PromptSlug: promptSlug,
@@ -1140,7 +1638,7 @@ func mapExpertError(err error) *ailink.SearchError {
 		return mapped
 	}
 
-	message := err.Error()
+	message := redact.String(err.Error())
 	switch {
 	case strings.Contains(message, "schema validation failed"):
 		return &ailink.SearchError{Code: "AILINK_VALIDATION_ERROR", Message: "expert response failed schema validation (try again with --trace <file> to capture the raw payload)", Details: message}
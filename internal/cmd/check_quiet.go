@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+// Exit codes of check --quiet.
+const (
+	quietExitAvailable = 0
+	quietExitTaken     = 1
+	quietExitUnknown   = 2
+)
+
+// runCheckQuiet checks a single target and prints one token, available,
+// taken, or unknown, exiting 0, 1, or 2 to match. Anything that keeps it from
+// an answer, including bad arguments, is unknown.
+func runCheckQuiet(cmd *cobra.Command, args []string) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	unknown := func(err error) error {
+		fmt.Fprintln(cmd.OutOrStdout(), "unknown")
+		return &ExitCodeError{Code: quietExitUnknown, Err: err}
+	}
+
+	name, profile, err := quietTarget(cmd, args)
+	if err != nil {
+		return unknown(err)
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return unknown(err)
+	}
+
+	ctx := cmd.Context()
+	startedAt := time.Now()
+	store, err := openStore(ctx)
+	if err != nil {
+		return unknown(err)
+	}
+	defer store.Close() // nolint:errcheck // best-effort cleanup
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return unknown(errors.New("config not loaded"))
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+	if err := applyCheckTimeouts(cmd, orchestrator, startedAt); err != nil {
+		return unknown(err)
+	}
+
+	results, err := orchestrator.Check(ctx, name, profile)
+	if err != nil {
+		return unknown(err)
+	}
+	token, code := quietAnswer(results)
+	fmt.Fprintln(cmd.OutOrStdout(), token)
+	if code != quietExitAvailable {
+		return &ExitCodeError{Code: code}
+	}
+	return nil
+}
+
+// quietTarget resolves the name and single target of check --quiet: a domain
+// such as example.com, or a bare name with exactly one value across the
+// --tlds, --registries, and --handles flags given on the command line.
+func quietTarget(cmd *cobra.Command, args []string) (string, core.Profile, error) {
+	if len(args) != 1 {
+		return "", core.Profile{}, errors.New("--quiet checks exactly one name")
+	}
+	profile := core.Profile{Name: "quiet"}
+	targetFlags := cmd.Flags().Changed("tlds") || cmd.Flags().Changed("registries") || cmd.Flags().Changed("handles")
+
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+	if label, tld, ok := strings.Cut(name, "."); ok {
+		if targetFlags {
+			return "", core.Profile{}, errors.New("--quiet takes either a domain or a --tlds, --registries, or --handles target, not both")
+		}
+		if err := validateName(label); err != nil {
+			return "", core.Profile{}, err
+		}
+		profile.TLDs = normalizeTLDs([]string{tld})
+		if len(profile.TLDs) == 0 {
+			return "", core.Profile{}, fmt.Errorf("invalid domain %q", args[0])
+		}
+		return label, profile, nil
+	}
+
+	if err := validateName(name); err != nil {
+		return "", core.Profile{}, err
+	}
+	if cmd.Flags().Changed("tlds") {
+		tlds, _ := cmd.Flags().GetStringSlice("tlds")
+		profile.TLDs = normalizeTLDs(tlds)
+	}
+	if cmd.Flags().Changed("registries") {
+		registries, _ := cmd.Flags().GetStringSlice("registries")
+		profile.Registries = normalizeList(registries)
+	}
+	if cmd.Flags().Changed("handles") {
+		handles, _ := cmd.Flags().GetStringSlice("handles")
+		profile.Handles = normalizeList(handles)
+	}
+	if len(profile.TLDs)+len(profile.Registries)+len(profile.Handles) != 1 {
+		return "", core.Profile{}, errors.New("--quiet needs exactly one target: pass a domain like acme.com, or one --tlds, --registries, or --handles value")
+	}
+	return name, profile, nil
+}
+
+// quietAnswer maps the result of a single-target check to its token and
+// exit code.
+func quietAnswer(results []*core.CheckResult) (string, int) {
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		switch result.Available {
+		case core.AvailabilityAvailable:
+			return "available", quietExitAvailable
+		case core.AvailabilityTaken:
+			return "taken", quietExitTaken
+		default:
+			return "unknown", quietExitUnknown
+		}
+	}
+	return "unknown", quietExitUnknown
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func quietTestCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "check"}
+	cmd.Flags().StringSlice("tlds", []string{"com", "dev"}, "")
+	cmd.Flags().StringSlice("registries", []string{"npm"}, "")
+	cmd.Flags().StringSlice("handles", []string{"github"}, "")
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestQuietTarget(t *testing.T) {
+	name, profile, err := quietTarget(quietTestCmd(t), []string{"Example.co.uk"})
+	require.NoError(t, err)
+	require.Equal(t, "example", name)
+	require.Equal(t, []string{"co.uk"}, profile.TLDs)
+	require.Empty(t, profile.Registries)
+	require.Empty(t, profile.Handles)
+
+	name, profile, err = quietTarget(quietTestCmd(t, "--registries", "pypi"), []string{"acme"})
+	require.NoError(t, err)
+	require.Equal(t, "acme", name)
+	require.Equal(t, []string{"pypi"}, profile.Registries)
+	require.Empty(t, profile.TLDs)
+
+	_, _, err = quietTarget(quietTestCmd(t), []string{"acme"})
+	require.ErrorContains(t, err, "exactly one target")
+	_, _, err = quietTarget(quietTestCmd(t, "--tlds", "com,io"), []string{"acme"})
+	require.ErrorContains(t, err, "exactly one target")
+	_, _, err = quietTarget(quietTestCmd(t, "--tlds", "io"), []string{"acme.com"})
+	require.ErrorContains(t, err, "not both")
+	_, _, err = quietTarget(quietTestCmd(t), []string{"acme.com", "zentro.com"})
+	require.ErrorContains(t, err, "exactly one name")
+}
+
+func TestQuietAnswer(t *testing.T) {
+	token, code := quietAnswer([]*core.CheckResult{{Available: core.AvailabilityAvailable}})
+	require.Equal(t, "available", token)
+	require.Equal(t, 0, code)
+
+	token, code = quietAnswer([]*core.CheckResult{{Available: core.AvailabilityTaken}})
+	require.Equal(t, "taken", token)
+	require.Equal(t, 1, code)
+
+	token, code = quietAnswer([]*core.CheckResult{{Available: core.AvailabilityRateLimited}})
+	require.Equal(t, "unknown", token)
+	require.Equal(t, 2, code)
+
+	token, code = quietAnswer(nil)
+	require.Equal(t, "unknown", token)
+	require.Equal(t, 2, code)
+}
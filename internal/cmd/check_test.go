@@ -1,9 +1,18 @@
 package cmd
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
 	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+	"github.com/namelens/namelens/internal/core/engine"
+	"github.com/namelens/namelens/internal/observability"
 )
 
 func TestValidateName(t *testing.T) {
@@ -75,3 +84,81 @@ func TestSummarizeResultsKeepsInputNameWhenChecksMatch(t *testing.T) {
 		t.Fatalf("expected batch name ailink, got %q", batch.Name)
 	}
 }
+
+func TestSuitabilityLocaleMatrix(t *testing.T) {
+	raw := json.RawMessage(`{"by_locale":[
+		{"locale":"en-US","suitability":"clear","score":95,"concerns":["None"]},
+		{"locale":"de-DE","suitability":"Medium","score":60,"concerns":["Sounds like a slang term"]},
+		{"locale":"fr-FR","suitability":"low"}
+	]}`)
+
+	matrix := suitabilityLocaleMatrix(raw, []string{"de", "ja-JP", "EN-us"})
+	require.Len(t, matrix, 4)
+	require.Equal(t, "de-DE", matrix[0].Locale)
+	require.Equal(t, "medium", matrix[0].Rating)
+	require.Equal(t, 60, *matrix[0].Score)
+	require.Equal(t, []string{"Sounds like a slang term"}, matrix[0].Concerns)
+	require.Equal(t, core.LocaleSuitability{Locale: "ja-JP", Rating: localeNotAssessed}, matrix[1])
+	require.Equal(t, "en-US", matrix[2].Locale)
+	require.Empty(t, matrix[2].Concerns)
+	require.Equal(t, "fr-FR", matrix[3].Locale)
+	require.Nil(t, matrix[3].Score)
+
+	require.Nil(t, suitabilityLocaleMatrix(raw, nil))
+	require.Nil(t, suitabilityLocaleMatrix(nil, []string{"en-US"}))
+}
+
+func TestRegisterCustomCheckersSkipsConflicts(t *testing.T) {
+	observability.InitCLILogger("namelens-test", false)
+	orchestrator := &engine.Orchestrator{
+		RegistryCheckers: map[string]engine.Checker{"npm": nil},
+		HandleCheckers:   map[string]engine.Checker{"github": &checker.GitHubChecker{}},
+	}
+	custom := map[string]config.CustomCheckerConfig{
+		"npm":       {URL: "https://example.com/{name}"},
+		"github":    {URL: "https://example.com/{name}"},
+		"broken":    {URL: "https://example.com/names"},
+		"CorpNames": {URL: "https://example.com/{name}"},
+	}
+
+	registerCustomCheckers(orchestrator, custom, nil)
+
+	if _, ok := orchestrator.RegistryCheckers["corpnames"].(*checker.HTTPPluginChecker); !ok {
+		t.Fatalf("expected corpnames to be registered, got %#v", orchestrator.RegistryCheckers)
+	}
+	if orchestrator.RegistryCheckers["npm"] != nil || orchestrator.RegistryCheckers["github"] != nil {
+		t.Fatalf("expected built-in names to be left alone")
+	}
+	if _, ok := orchestrator.RegistryCheckers["broken"]; ok {
+		t.Fatalf("expected invalid checker to be skipped")
+	}
+}
+
+func TestRegisterCatalogCheckers(t *testing.T) {
+	observability.InitCLILogger("namelens-test", false)
+	path := filepath.Join(t.TempDir(), "services.csv")
+	if err := os.WriteFile(path, []byte("name,owner\npayments,team-payments\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	orchestrator := &engine.Orchestrator{
+		RegistryCheckers: map[string]engine.Checker{"npm": nil},
+		HandleCheckers:   map[string]engine.Checker{},
+	}
+	catalogs := map[string]config.CatalogConfig{
+		"npm":      {Path: path},
+		"missing":  {Path: filepath.Join(t.TempDir(), "missing.yaml")},
+		"Internal": {Path: path},
+	}
+
+	registerCatalogCheckers(orchestrator, catalogs)
+
+	if _, ok := orchestrator.RegistryCheckers["internal"].(*checker.CatalogChecker); !ok {
+		t.Fatalf("expected internal catalog to be registered, got %#v", orchestrator.RegistryCheckers)
+	}
+	if orchestrator.RegistryCheckers["npm"] != nil {
+		t.Fatalf("expected npm to be left alone")
+	}
+	if _, ok := orchestrator.RegistryCheckers["missing"]; ok {
+		t.Fatalf("expected unreadable catalog to be skipped")
+	}
+}
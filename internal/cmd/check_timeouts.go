@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+func addCheckTimeoutFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("check-timeout", 0, "Resolve a single check as unknown when it runs longer than this, e.g. 10s (0 = no limit)")
+	cmd.Flags().Duration("deadline", 0, "Stop checking after this long, e.g. 5m; unfinished checks resolve as unknown (0 = no deadline)")
+}
+
+// applyCheckTimeouts sets the orchestrator's per-check timeout and, measured
+// from startedAt, its run deadline.
+func applyCheckTimeouts(cmd *cobra.Command, orchestrator *engine.Orchestrator, startedAt time.Time) error {
+	checkTimeout, err := cmd.Flags().GetDuration("check-timeout")
+	if err != nil {
+		return err
+	}
+	deadline, err := cmd.Flags().GetDuration("deadline")
+	if err != nil {
+		return err
+	}
+	if checkTimeout < 0 {
+		return errors.New("--check-timeout must be 0 or greater")
+	}
+	if deadline < 0 {
+		return errors.New("--deadline must be 0 or greater")
+	}
+
+	orchestrator.CheckTimeout = checkTimeout
+	if deadline > 0 {
+		orchestrator.Deadline = startedAt.Add(deadline)
+	}
+	return nil
+}
@@ -6,26 +6,37 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 
+	"github.com/namelens/namelens/internal/ailink"
 	"github.com/namelens/namelens/internal/config"
 	"github.com/namelens/namelens/internal/core"
 	corestore "github.com/namelens/namelens/internal/core/store"
 	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/phonetics"
 )
 
 // compareRow holds extracted metrics for a single name.
 type compareRow struct {
-	Name              string              `json:"name"`
-	Length            int                 `json:"length"`
-	Availability      compareAvailability `json:"availability"`
-	AvailabilityError string              `json:"availability_error,omitempty"`
-	RiskLevel         string              `json:"risk_level,omitempty"`
-	Phonetics         *comparePhonetics   `json:"phonetics,omitempty"`
-	Suitability       *compareSuitability `json:"suitability,omitempty"`
+	Name              string                  `json:"name"`
+	Length            int                     `json:"length"`
+	Availability      compareAvailability     `json:"availability"`
+	AvailabilityError string                  `json:"availability_error,omitempty"`
+	RiskLevel         string                  `json:"risk_level,omitempty"`
+	Phonetics         *comparePhonetics       `json:"phonetics,omitempty"`
+	Memorability      *phonetics.Memorability `json:"memorability,omitempty"`
+	Suitability       *compareSuitability     `json:"suitability,omitempty"`
+	AIUsage           *ailink.Usage           `json:"ai_usage,omitempty"`
+
+	// Kept for --diff-pair, which shows every target and analysis excerpt
+	results        []*core.CheckResult
+	phoneticsRaw   json.RawMessage
+	suitabilityRaw json.RawMessage
 }
 
 type compareAvailability struct {
@@ -48,9 +59,18 @@ type compareSuitability struct {
 var compareCmd = &cobra.Command{
 	Use:   "compare <name1> <name2> [<name>...]",
 	Short: "Compare candidate names side-by-side",
-	Long:  "Compare multiple candidate names across availability, phonetics, and suitability in a compact table format for screening.",
-	Args:  cobra.MinimumNArgs(2),
-	RunE:  runCompare,
+	Long: `Compare multiple candidate names across availability, phonetics, and suitability in a compact table format for screening.
+
+With --diff-pair, compare exactly two finalists head to head: every metric,
+analysis excerpt, and availability target side by side, with the stronger name
+marked.
+
+--output-format html writes a self-contained HTML report, with each name's
+targets and analysis summaries below the comparison. --output-format csv
+writes a flat table with a row per name and target and each name's scores
+repeated on every row, for spreadsheets; --diff-pair uses the same table.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runCompare,
 }
 
 func init() {
@@ -58,11 +78,16 @@ func init() {
 
 	compareCmd.Flags().String("profile", "startup", "Availability profile to use")
 	compareCmd.Flags().String("mode", "", "Analysis mode: 'quick' for availability only, omit for full analysis with phonetics/suitability")
-	compareCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	compareCmd.Flags().String("output-format", "table", "Output format: table, json, markdown, html, csv")
 	compareCmd.Flags().String("out", "", "Write output to a file (default stdout)")
 	compareCmd.Flags().String("out-dir", "", "Write output to a directory")
 	_ = compareCmd.Flags().MarkHidden("out-dir") // compare outputs single table, not per-name files
+	addPDFFlag(compareCmd)
+	addOutputTemplateFlag(compareCmd)
 	compareCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
+	compareCmd.Flags().Bool("no-progress", false, "Disable the live progress display on interactive terminals")
+	compareCmd.Flags().Bool("diff-pair", false, "Compare exactly two finalists head to head")
+	addCheckTimeoutFlags(compareCmd)
 }
 
 func runCompare(cmd *cobra.Command, args []string) error {
@@ -94,8 +119,23 @@ func runCompare(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	diffPair, err := cmd.Flags().GetBool("diff-pair")
+	if err != nil {
+		return err
+	}
+	if diffPair && len(names) != 2 {
+		return fmt.Errorf("--diff-pair needs exactly 2 names, got %d", len(names))
+	}
 
-	format, err := resolveOutputFormat(cmd)
+	format, err := resolveReportOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	pdfPath, err := resolvePDFPath(cmd, format)
+	if err != nil {
+		return err
+	}
+	custom, err := resolveOutputTemplate(cmd, "compare")
 	if err != nil {
 		return err
 	}
@@ -125,11 +165,21 @@ func runCompare(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	defer startBootstrapRefresh(ctx, cfg, store)()
 	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+	if err := applyCheckTimeouts(cmd, orchestrator, time.Now()); err != nil {
+		return err
+	}
 
 	rows := make([]compareRow, 0, len(names))
+	ctx, runUsage := ailink.WithUsageTracker(ctx)
+
+	display := newProgressDisplay(cmd, format, outPath, "", len(names))
+	display.Start()
 
 	for _, name := range names {
+		ctx, nameUsage := ailink.WithUsageTracker(display.Begin(ctx, name))
 		row := compareRow{
 			Name:   name,
 			Length: len(name),
@@ -143,24 +193,28 @@ func runCompare(cmd *cobra.Command, args []string) error {
 			row.Availability = summarizeAvailability(results)
 			// Derive risk level from availability results (no AI call needed)
 			row.RiskLevel = deriveRiskLevel(results)
+			row.results = results
 		}
 
 		if !quickMode && row.AvailabilityError == "" {
 			// Run phonetics analysis
-			phonetics := runComparePhonetics(ctx, cfg, store, name, !noCache)
-			if phonetics != nil {
-				row.Phonetics = phonetics
-			}
+			row.phoneticsRaw = runCompareAnalysis(ctx, cfg, store, "name-phonetics", name, !noCache)
+			row.Phonetics = extractPhonetics(row.phoneticsRaw)
+
+			// Memorability is scored locally, so it shows even without an AI backend
+			memorability := phonetics.ScoreMemorability(name)
+			row.Memorability = &memorability
 
 			// Run suitability analysis
-			suitability := runCompareSuitability(ctx, cfg, store, name, !noCache)
-			if suitability != nil {
-				row.Suitability = suitability
-			}
+			row.suitabilityRaw = runCompareAnalysis(ctx, cfg, store, "name-suitability", name, !noCache)
+			row.Suitability = extractSuitability(row.suitabilityRaw)
 		}
 
+		row.AIUsage = trackedUsage(nameUsage)
 		rows = append(rows, row)
+		display.Done(name)
 	}
+	display.Stop()
 
 	sink, err := openSink(outPath)
 	if err != nil {
@@ -168,7 +222,31 @@ func runCompare(cmd *cobra.Command, args []string) error {
 	}
 	defer sink.close() //nolint:errcheck
 
-	return renderCompare(sink.writer, rows, format, quickMode)
+	render := func(w io.Writer) error {
+		if custom != nil {
+			rendered, err := custom.Render(names, rows)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, rendered)
+			return err
+		}
+		// A flat CSV table already lines the pair up target by target
+		if diffPair && format != output.FormatCSV {
+			return renderComparePair(w, newComparePair(rows[0], rows[1]), format)
+		}
+		return renderCompare(w, rows, format, quickMode)
+	}
+	if err := render(sink.writer); err != nil {
+		return err
+	}
+	if pdfPath != "" {
+		if err := writeReportPDF(ctx, cfg, pdfPath, render); err != nil {
+			return err
+		}
+	}
+	printAIUsageSummary(os.Stderr, runUsage.Snapshot())
+	return nil
 }
 
 func summarizeAvailability(results []*core.CheckResult) compareAvailability {
@@ -226,23 +304,22 @@ func deriveRiskLevel(results []*core.CheckResult) string {
 }
 
 func runComparePhonetics(ctx context.Context, cfg *config.Config, store *corestore.Store, name string, useCache bool) *comparePhonetics {
-	vars := map[string]string{"name": name}
-	raw, searchErr, _ := runReviewGenerate(ctx, cfg, store, "name-phonetics", name, "quick", "", vars, useCache)
-	if searchErr != nil || len(raw) == 0 {
-		return nil
-	}
-
-	return extractPhonetics(raw)
+	return extractPhonetics(runCompareAnalysis(ctx, cfg, store, "name-phonetics", name, useCache))
 }
 
 func runCompareSuitability(ctx context.Context, cfg *config.Config, store *corestore.Store, name string, useCache bool) *compareSuitability {
+	return extractSuitability(runCompareAnalysis(ctx, cfg, store, "name-suitability", name, useCache))
+}
+
+// runCompareAnalysis runs a quick analysis prompt for name and returns its
+// response, or nil when the analysis failed.
+func runCompareAnalysis(ctx context.Context, cfg *config.Config, store *corestore.Store, prompt, name string, useCache bool) json.RawMessage {
 	vars := map[string]string{"name": name}
-	raw, searchErr, _ := runReviewGenerate(ctx, cfg, store, "name-suitability", name, "quick", "", vars, useCache)
+	raw, searchErr, _ := runReviewGenerate(ctx, cfg, store, prompt, name, "quick", "", vars, useCache)
 	if searchErr != nil || len(raw) == 0 {
 		return nil
 	}
-
-	return extractSuitability(raw)
+	return raw
 }
 
 func extractPhonetics(raw json.RawMessage) *comparePhonetics {
@@ -298,11 +375,88 @@ func renderCompare(w io.Writer, rows []compareRow, format output.Format, quickMo
 		return err
 	case output.FormatMarkdown:
 		return renderCompareMarkdown(w, rows, quickMode)
+	case output.FormatHTML:
+		return renderCompareHTML(w, rows, quickMode)
+	case output.FormatCSV:
+		return renderCompareCSV(w, rows)
 	default:
 		return renderCompareTable(w, rows, quickMode)
 	}
 }
 
+// renderCompareHTML writes the comparison table as an HTML report, followed
+// by each name's targets and analysis summaries.
+func renderCompareHTML(w io.Writer, rows []compareRow, quickMode bool) error {
+	overview := &output.HTMLTable{Header: []string{"Name", "Availability", "Risk", "Phonetics", "Memorability", "Suitability", "Length"}}
+	if quickMode {
+		overview.Header = []string{"Name", "Availability", "Length"}
+	}
+	names := make([]string, 0, len(rows))
+	batches := make([]*core.BatchResult, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Name)
+		if quickMode {
+			overview.Rows = append(overview.Rows, []string{row.Name, formatAvailability(row), fmt.Sprintf("%d", row.Length)})
+		} else {
+			overview.Rows = append(overview.Rows, []string{
+				row.Name,
+				formatAvailability(row),
+				formatRisk(row),
+				formatPhonetics(row),
+				formatMemorability(row),
+				formatSuitability(row),
+				fmt.Sprintf("%d", row.Length),
+			})
+		}
+		batch := summarizeResults(row.Name, row.results, nil, nil, row.phoneticsRaw, nil, row.suitabilityRaw, nil)
+		batch.Name = row.Name
+		batches = append(batches, batch)
+	}
+
+	report := output.HTMLReport{
+		Title:    "Name comparison: " + strings.Join(names, ", "),
+		Overview: overview,
+		Batches:  batches,
+	}
+	return report.Render(w)
+}
+
+// renderCompareCSV writes a row per name and target, with the comparison
+// scores appended to the standard CSV columns.
+func renderCompareCSV(w io.Writer, rows []compareRow) error {
+	formatter := &output.CSVFormatter{
+		ExtraHeader: []string{"length", "risk", "phonetics", "memorability"},
+		Extra:       make(map[string][]string, len(rows)),
+	}
+	batches := make([]*core.BatchResult, 0, len(rows))
+	for _, row := range rows {
+		batch := summarizeResults(row.Name, row.results, nil, nil, row.phoneticsRaw, nil, row.suitabilityRaw, nil)
+		batch.Name = row.Name
+		batches = append(batches, batch)
+		formatter.Extra[row.Name] = []string{
+			fmt.Sprintf("%d", row.Length),
+			row.RiskLevel,
+			csvScore(formatPhonetics(row)),
+			csvScore(formatMemorability(row)),
+		}
+	}
+
+	rendered, err := formatter.FormatBatches(batches)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, rendered)
+	return err
+}
+
+// csvScore leaves missing scores empty instead of the table's "-".
+func csvScore(value string) string {
+	if value == "-" {
+		return ""
+	}
+	return value
+}
+
 func renderCompareTable(w io.Writer, rows []compareRow, quickMode bool) error {
 	t := table.NewWriter()
 	t.SetOutputMirror(w)
@@ -318,13 +472,14 @@ func renderCompareTable(w io.Writer, rows []compareRow, quickMode bool) error {
 			})
 		}
 	} else {
-		t.AppendHeader(table.Row{"Name", "Availability", "Risk", "Phonetics", "Suitability", "Length"})
+		t.AppendHeader(table.Row{"Name", "Availability", "Risk", "Phonetics", "Memorability", "Suitability", "Length"})
 		for _, row := range rows {
 			t.AppendRow(table.Row{
 				row.Name,
 				formatAvailability(row),
 				formatRisk(row),
 				formatPhonetics(row),
+				formatMemorability(row),
 				formatSuitability(row),
 				row.Length,
 			})
@@ -346,14 +501,15 @@ func renderCompareMarkdown(w io.Writer, rows []compareRow, quickMode bool) error
 		return nil
 	}
 
-	_, _ = fmt.Fprintln(w, "| Name | Availability | Risk | Phonetics | Suitability | Length |")
-	_, _ = fmt.Fprintln(w, "|------|--------------|------|-----------|-------------|--------|")
+	_, _ = fmt.Fprintln(w, "| Name | Availability | Risk | Phonetics | Memorability | Suitability | Length |")
+	_, _ = fmt.Fprintln(w, "|------|--------------|------|-----------|--------------|-------------|--------|")
 	for _, row := range rows {
-		_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %d |\n",
+		_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s | %d |\n",
 			row.Name,
 			formatAvailability(row),
 			formatRisk(row),
 			formatPhonetics(row),
+			formatMemorability(row),
 			formatSuitability(row),
 			row.Length)
 	}
@@ -390,6 +546,13 @@ func formatPhonetics(row compareRow) string {
 	return fmt.Sprintf("%d", row.Phonetics.OverallScore)
 }
 
+func formatMemorability(row compareRow) string {
+	if row.Memorability == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d", row.Memorability.Score)
+}
+
 func formatSuitability(row compareRow) string {
 	if row.Suitability == nil || row.Suitability.OverallScore == 0 {
 		return "-"
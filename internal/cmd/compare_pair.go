@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/output"
+)
+
+// comparePair is the head-to-head report of compare --diff-pair. Edge names
+// the stronger finalist on each line; Winner is the finalist with more edges.
+type comparePair struct {
+	Names    [2]string         `json:"names"`
+	Winner   string            `json:"winner,omitempty"`
+	Edges    map[string]int    `json:"edges"`
+	Metrics  []comparePairLine `json:"metrics"`
+	Excerpts []comparePairLine `json:"excerpts"`
+	Targets  []comparePairLine `json:"targets"`
+}
+
+type comparePairLine struct {
+	Label  string    `json:"label"`
+	Values [2]string `json:"values"`
+	Edge   string    `json:"edge,omitempty"`
+}
+
+// riskRank orders derived risk levels from best to worst.
+var riskRank = map[string]int{"low": 1, "medium": 2, "high": 3}
+
+// availabilityRank orders check verdicts from best to worst.
+var availabilityRank = map[core.Availability]int{
+	core.AvailabilityAvailable: 1,
+	core.AvailabilityUnknown:   2,
+	core.AvailabilityTaken:     3,
+}
+
+func newComparePair(a, b compareRow) comparePair {
+	pair := comparePair{
+		Names: [2]string{a.Name, b.Name},
+		Edges: map[string]int{a.Name: 0, b.Name: 0},
+	}
+
+	// Each metric is compared by a rank where higher is better; 0 means the
+	// value is missing and gives neither side the edge.
+	metric := func(label string, format func(compareRow) string, rank func(compareRow) int) {
+		line := comparePairLine{Label: label, Values: [2]string{format(a), format(b)}}
+		if rank != nil {
+			line.Edge = pair.edge(rank(a), rank(b))
+		}
+		pair.Metrics = append(pair.Metrics, line)
+	}
+	metric("Availability", formatAvailability, func(r compareRow) int {
+		if r.AvailabilityError != "" || r.Availability.Total == 0 {
+			return 0
+		}
+		return r.Availability.Score + 1
+	})
+	metric("Risk", formatRisk, func(r compareRow) int {
+		if level, ok := riskRank[r.RiskLevel]; ok && r.AvailabilityError == "" {
+			return len(riskRank) + 1 - level
+		}
+		return 0
+	})
+	metric("Phonetics", formatPhonetics, func(r compareRow) int {
+		if r.Phonetics == nil {
+			return 0
+		}
+		return r.Phonetics.OverallScore
+	})
+	metric("Typeability", func(r compareRow) string {
+		if r.Phonetics == nil {
+			return "-"
+		}
+		return scoreOrDash(r.Phonetics.TypeabilityScore)
+	}, func(r compareRow) int {
+		if r.Phonetics == nil {
+			return 0
+		}
+		return r.Phonetics.TypeabilityScore
+	})
+	metric("CLI suitability", func(r compareRow) string {
+		if r.Phonetics == nil {
+			return "-"
+		}
+		return scoreOrDash(r.Phonetics.CLISuitability)
+	}, func(r compareRow) int {
+		if r.Phonetics == nil {
+			return 0
+		}
+		return r.Phonetics.CLISuitability
+	})
+	metric("Memorability", formatMemorability, func(r compareRow) int {
+		if r.Memorability == nil {
+			return 0
+		}
+		// Offset so a score of 0 still ranks above a missing score
+		return r.Memorability.Score + 1
+	})
+	metric("Suitability", formatSuitability, func(r compareRow) int {
+		if r.Suitability == nil {
+			return 0
+		}
+		return r.Suitability.OverallScore
+	})
+	metric("Suitability rating", func(r compareRow) string {
+		if r.Suitability == nil {
+			return "-"
+		}
+		return dashIfEmpty(r.Suitability.Rating)
+	}, nil)
+	metric("Length", func(r compareRow) string { return fmt.Sprintf("%d", r.Length) }, func(r compareRow) int {
+		// Shorter names are easier to type and remember
+		return 1000 - r.Length
+	})
+
+	pair.Excerpts = comparePairExcerpts(a, b)
+	pair.Targets = pair.targets(a.results, b.results)
+
+	switch {
+	case pair.Edges[a.Name] > pair.Edges[b.Name]:
+		pair.Winner = a.Name
+	case pair.Edges[b.Name] > pair.Edges[a.Name]:
+		pair.Winner = b.Name
+	}
+	return pair
+}
+
+// edge returns the name with the higher rank and counts it, or "" on a tie
+// or when either side has no value.
+func (p *comparePair) edge(rankA, rankB int) string {
+	if rankA <= 0 || rankB <= 0 || rankA == rankB {
+		return ""
+	}
+	winner := p.Names[0]
+	if rankB > rankA {
+		winner = p.Names[1]
+	}
+	p.Edges[winner]++
+	return winner
+}
+
+// targets lines up availability results by target kind, since the two names
+// check different domains and handles for the same TLDs and registries.
+func (p *comparePair) targets(a, b []*core.CheckResult) []comparePairLine {
+	order := make([]string, 0, len(a)+len(b))
+	byKey := make(map[string]*[2]*core.CheckResult)
+	for side, results := range [2][]*core.CheckResult{a, b} {
+		for _, result := range results {
+			if result == nil {
+				continue
+			}
+			key := comparePairTargetLabel(result)
+			pair, ok := byKey[key]
+			if !ok {
+				pair = &[2]*core.CheckResult{}
+				byKey[key] = pair
+				order = append(order, key)
+			}
+			pair[side] = result
+		}
+	}
+
+	lines := make([]comparePairLine, 0, len(order))
+	for _, key := range order {
+		pair := byKey[key]
+		line := comparePairLine{Label: key}
+		var ranks [2]int
+		for side, result := range pair {
+			if result == nil {
+				line.Values[side] = "-"
+				continue
+			}
+			line.Values[side] = fmt.Sprintf("%s %s", result.Name, result.Available.String())
+			if rank, ok := availabilityRank[result.Available]; ok {
+				ranks[side] = len(availabilityRank) + 1 - rank
+			}
+		}
+		line.Edge = p.edge(ranks[0], ranks[1])
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func comparePairTargetLabel(result *core.CheckResult) string {
+	if result.CheckType == core.CheckTypeDomain && result.TLD != "" {
+		return "domain ." + strings.TrimPrefix(result.TLD, ".")
+	}
+	return string(result.CheckType)
+}
+
+// comparePairExcerpts pulls the prose parts of both analyses. Lines where
+// neither name has text are left out.
+func comparePairExcerpts(a, b compareRow) []comparePairLine {
+	type excerpts struct {
+		phoneticsRecommendation string
+		strengths               string
+		concerns                string
+		pronunciation           string
+		cliNotes                string
+		suitabilitySummary      string
+		associations            string
+		caveats                 string
+	}
+	extract := func(row compareRow) excerpts {
+		var phon struct {
+			Pronunciation struct {
+				IPAPrimary string `json:"ipa_primary"`
+			} `json:"pronunciation"`
+			CLISuitability struct {
+				Notes string `json:"notes"`
+			} `json:"cli_suitability"`
+			OverallAssessment struct {
+				Recommendation string   `json:"recommendation"`
+				Concerns       []string `json:"concerns"`
+				Strengths      []string `json:"strengths"`
+			} `json:"overall_assessment"`
+		}
+		var suit struct {
+			OverallSuitability struct {
+				Summary string `json:"summary"`
+			} `json:"overall_suitability"`
+			PositiveAssociations []string `json:"positive_associations"`
+			Recommendations      struct {
+				Caveats []string `json:"caveats"`
+			} `json:"recommendations"`
+		}
+		if len(row.phoneticsRaw) > 0 {
+			_ = json.Unmarshal(row.phoneticsRaw, &phon)
+		}
+		if len(row.suitabilityRaw) > 0 {
+			_ = json.Unmarshal(row.suitabilityRaw, &suit)
+		}
+		return excerpts{
+			phoneticsRecommendation: phon.OverallAssessment.Recommendation,
+			strengths:               strings.Join(phon.OverallAssessment.Strengths, "; "),
+			concerns:                strings.Join(phon.OverallAssessment.Concerns, "; "),
+			pronunciation:           phon.Pronunciation.IPAPrimary,
+			cliNotes:                phon.CLISuitability.Notes,
+			suitabilitySummary:      suit.OverallSuitability.Summary,
+			associations:            strings.Join(suit.PositiveAssociations, "; "),
+			caveats:                 strings.Join(suit.Recommendations.Caveats, "; "),
+		}
+	}
+
+	ea, eb := extract(a), extract(b)
+	lines := make([]comparePairLine, 0, 8)
+	add := func(label, valueA, valueB string) {
+		valueA, valueB = strings.TrimSpace(valueA), strings.TrimSpace(valueB)
+		if valueA == "" && valueB == "" {
+			return
+		}
+		lines = append(lines, comparePairLine{Label: label, Values: [2]string{dashIfEmpty(valueA), dashIfEmpty(valueB)}})
+	}
+	add("Pronunciation", ea.pronunciation, eb.pronunciation)
+	add("Phonetics verdict", ea.phoneticsRecommendation, eb.phoneticsRecommendation)
+	add("Strengths", ea.strengths, eb.strengths)
+	add("Concerns", ea.concerns, eb.concerns)
+	add("CLI notes", ea.cliNotes, eb.cliNotes)
+	add("Suitability summary", ea.suitabilitySummary, eb.suitabilitySummary)
+	add("Positive associations", ea.associations, eb.associations)
+	add("Caveats", ea.caveats, eb.caveats)
+	return lines
+}
+
+func scoreOrDash(score int) string {
+	if score == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d", score)
+}
+
+func renderComparePair(w io.Writer, pair comparePair, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(pair, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		return renderComparePairMarkdown(w, pair)
+	case output.FormatHTML:
+		return comparePairTemplate.Execute(w, pair)
+	default:
+		return renderComparePairTable(w, pair)
+	}
+}
+
+type comparePairSection struct {
+	Title string
+	Lines []comparePairLine
+}
+
+// Sections orders the report for the table, markdown, and HTML renderers.
+func (p comparePair) Sections() []comparePairSection {
+	return []comparePairSection{
+		{Title: "Metrics", Lines: p.Metrics},
+		{Title: "Availability", Lines: p.Targets},
+		{Title: "Analysis", Lines: p.Excerpts},
+	}
+}
+
+// markEdge suffixes the stronger side's value with an asterisk.
+func (p comparePair) markEdge(line comparePairLine, side int) string {
+	if line.Edge != "" && line.Edge == p.Names[side] {
+		return line.Values[side] + " *"
+	}
+	return line.Values[side]
+}
+
+func (p comparePair) verdict() string {
+	if p.Winner == "" {
+		return fmt.Sprintf("Even: %s and %s each lead on %d lines", p.Names[0], p.Names[1], p.Edges[p.Names[0]])
+	}
+	loser := p.Names[0]
+	if loser == p.Winner {
+		loser = p.Names[1]
+	}
+	return fmt.Sprintf("%s leads on %d lines, %s on %d", p.Winner, p.Edges[p.Winner], loser, p.Edges[loser])
+}
+
+func renderComparePairTable(w io.Writer, pair comparePair) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"", pair.Names[0], pair.Names[1]})
+	for i, section := range pair.Sections() {
+		if len(section.Lines) == 0 {
+			continue
+		}
+		if i > 0 {
+			t.AppendSeparator()
+		}
+		for _, line := range section.Lines {
+			t.AppendRow(table.Row{line.Label, pair.markEdge(line, 0), pair.markEdge(line, 1)})
+		}
+	}
+	t.Render()
+
+	_, err := fmt.Fprintf(w, "%s (* marks the stronger value)\n", pair.verdict())
+	return err
+}
+
+func renderComparePairMarkdown(w io.Writer, pair comparePair) error {
+	_, _ = fmt.Fprintf(w, "## %s vs %s\n\n", pair.Names[0], pair.Names[1])
+	_, _ = fmt.Fprintf(w, "%s (* marks the stronger value).\n", pair.verdict())
+	for _, section := range pair.Sections() {
+		if len(section.Lines) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n### %s\n\n", section.Title)
+		_, _ = fmt.Fprintf(w, "| | %s | %s |\n", pair.Names[0], pair.Names[1])
+		_, _ = fmt.Fprintln(w, "|---|---|---|")
+		for _, line := range section.Lines {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %s |\n", line.Label,
+				markdownCell(pair.markEdge(line, 0)), markdownCell(pair.markEdge(line, 1)))
+		}
+	}
+	return nil
+}
+
+// markdownCell keeps free-form analysis text from breaking a table row.
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.Join(strings.Fields(value), " ")
+}
+
+var comparePairTemplate = template.Must(template.New("compare-pair").Funcs(template.FuncMap{
+	"edge": func(line comparePairLine, name string) bool { return line.Edge != "" && line.Edge == name },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{index .Names 0}} vs {{index .Names 1}} · namelens</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 60rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin: 0.5rem 0 1.5rem; table-layout: fixed; }
+th, td { text-align: left; vertical-align: top; padding: 0.3rem 0.6rem; border-bottom: 1px solid #ddd; }
+th:first-child { width: 22%; }
+td.edge { background: #e8f5ec; font-weight: 600; }
+.meta { color: #666; font-size: 0.9rem; }
+</style>
+</head>
+<body>
+<h1>{{index .Names 0}} vs {{index .Names 1}}</h1>
+<p class="meta">{{if .Winner}}{{.Winner}} leads{{else}}Even{{end}}: {{index .Names 0}} {{index .Edges (index .Names 0)}}, {{index .Names 1}} {{index .Edges (index .Names 1)}}. Highlighted cells mark the stronger value.</p>
+{{- $names := .Names}}
+{{- range $section := .Sections}}
+{{- if $section.Lines}}
+<h2>{{$section.Title}}</h2>
+<table>
+<tr><th></th><th>{{index $names 0}}</th><th>{{index $names 1}}</th></tr>
+{{- range $section.Lines}}
+<tr><th>{{.Label}}</th><td{{if edge . (index $names 0)}} class="edge"{{end}}>{{index .Values 0}}</td><td{{if edge . (index $names 1)}} class="edge"{{end}}>{{index .Values 1}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/phonetics"
+)
+
+func comparePairFixture() comparePair {
+	a := compareRow{
+		Name:         "acme",
+		Length:       4,
+		Availability: compareAvailability{Score: 2, Total: 3},
+		RiskLevel:    "medium",
+		Phonetics:    &comparePhonetics{OverallScore: 80, TypeabilityScore: 90},
+		Memorability: &phonetics.Memorability{Score: 70},
+		Suitability:  &compareSuitability{OverallScore: 75, Rating: "good"},
+		results: []*core.CheckResult{
+			{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+			{Name: "acme.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable},
+			{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+		},
+		phoneticsRaw:   json.RawMessage(`{"overall_assessment":{"combined_score":80,"recommendation":"Crisp","strengths":["short","familiar"]}}`),
+		suitabilityRaw: json.RawMessage(`{"overall_suitability":{"score":75,"rating":"good","summary":"Safe | broadly"}}`),
+	}
+	b := compareRow{
+		Name:         "zentrova",
+		Length:       8,
+		Availability: compareAvailability{Score: 3, Total: 3},
+		RiskLevel:    "low",
+		Phonetics:    &comparePhonetics{OverallScore: 65},
+		Memorability: &phonetics.Memorability{Score: 70},
+		results: []*core.CheckResult{
+			{Name: "zentrova.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable},
+			{Name: "zentrova.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable},
+			{Name: "zentrova", CheckType: core.CheckTypeGitHub, Available: core.AvailabilityTaken},
+		},
+		phoneticsRaw: json.RawMessage(`{"overall_assessment":{"combined_score":65,"concerns":["hard to spell"]}}`),
+	}
+	return newComparePair(a, b)
+}
+
+func findPairLine(t *testing.T, lines []comparePairLine, label string) comparePairLine {
+	t.Helper()
+	for _, line := range lines {
+		if line.Label == label {
+			return line
+		}
+	}
+	t.Fatalf("no line %q", label)
+	return comparePairLine{}
+}
+
+func TestNewComparePairMetrics(t *testing.T) {
+	pair := comparePairFixture()
+
+	require.Equal(t, "zentrova", findPairLine(t, pair.Metrics, "Availability").Edge)
+	require.Equal(t, "zentrova", findPairLine(t, pair.Metrics, "Risk").Edge)
+	require.Equal(t, "acme", findPairLine(t, pair.Metrics, "Phonetics").Edge)
+	require.Equal(t, "acme", findPairLine(t, pair.Metrics, "Length").Edge)
+
+	// Ties and missing values give neither side the edge
+	require.Empty(t, findPairLine(t, pair.Metrics, "Memorability").Edge)
+	typeability := findPairLine(t, pair.Metrics, "Typeability")
+	require.Empty(t, typeability.Edge)
+	require.Equal(t, [2]string{"90", "-"}, typeability.Values)
+	require.Empty(t, findPairLine(t, pair.Metrics, "Suitability").Edge)
+}
+
+func TestNewComparePairTargets(t *testing.T) {
+	pair := comparePairFixture()
+
+	require.Len(t, pair.Targets, 4)
+	com := findPairLine(t, pair.Targets, "domain .com")
+	require.Equal(t, [2]string{"acme.com taken", "zentrova.com available"}, com.Values)
+	require.Equal(t, "zentrova", com.Edge)
+	require.Empty(t, findPairLine(t, pair.Targets, "domain .io").Edge)
+
+	npm := findPairLine(t, pair.Targets, "npm")
+	require.Equal(t, "-", npm.Values[1])
+	require.Empty(t, npm.Edge)
+
+	// zentrova leads on availability, risk, and .com; acme on phonetics and length
+	require.Equal(t, 3, pair.Edges["zentrova"])
+	require.Equal(t, 2, pair.Edges["acme"])
+	require.Equal(t, "zentrova", pair.Winner)
+}
+
+func TestNewComparePairExcerpts(t *testing.T) {
+	pair := comparePairFixture()
+
+	require.Equal(t, [2]string{"Crisp", "-"}, findPairLine(t, pair.Excerpts, "Phonetics verdict").Values)
+	require.Equal(t, [2]string{"short; familiar", "-"}, findPairLine(t, pair.Excerpts, "Strengths").Values)
+	require.Equal(t, [2]string{"-", "hard to spell"}, findPairLine(t, pair.Excerpts, "Concerns").Values)
+	for _, line := range pair.Excerpts {
+		require.NotEqual(t, "Caveats", line.Label, "lines empty on both sides are dropped")
+	}
+}
+
+func TestRenderComparePairFormats(t *testing.T) {
+	pair := comparePairFixture()
+
+	var buf bytes.Buffer
+	require.NoError(t, renderComparePair(&buf, pair, output.FormatTable))
+	require.Contains(t, buf.String(), "zentrova.com available *")
+	require.Contains(t, buf.String(), "zentrova leads on 3 lines, acme on 2")
+
+	buf.Reset()
+	require.NoError(t, renderComparePair(&buf, pair, output.FormatMarkdown))
+	require.Contains(t, buf.String(), "## acme vs zentrova")
+	require.Contains(t, buf.String(), `Safe \| broadly`)
+
+	buf.Reset()
+	require.NoError(t, renderComparePair(&buf, pair, output.FormatJSON))
+	var parsed comparePair
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	require.Equal(t, "zentrova", parsed.Winner)
+
+	buf.Reset()
+	require.NoError(t, renderComparePair(&buf, pair, output.FormatHTML))
+	html := buf.String()
+	require.Contains(t, html, "<title>acme vs zentrova · namelens</title>")
+	require.Contains(t, html, `<td class="edge">zentrova.com available</td>`)
+	require.Contains(t, html, "Safe | broadly")
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/namelens/namelens/internal/core"
 	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/phonetics"
 )
 
 func TestSummarizeAvailability(t *testing.T) {
@@ -330,6 +331,7 @@ func TestRenderCompareTable(t *testing.T) {
 			Availability: compareAvailability{Score: 5, Total: 7, Unknown: 1},
 			RiskLevel:    "low",
 			Phonetics:    &comparePhonetics{OverallScore: 85},
+			Memorability: &phonetics.Memorability{Score: 77},
 			Suitability:  &compareSuitability{OverallScore: 90},
 		},
 		{
@@ -351,6 +353,7 @@ func TestRenderCompareTable(t *testing.T) {
 	require.Contains(t, output, "5/7")
 	require.Contains(t, output, "low")
 	require.Contains(t, output, "85")
+	require.Contains(t, output, "77")
 	require.Contains(t, output, "90")
 	require.Contains(t, output, "another")
 	require.Contains(t, output, "7/7")
@@ -376,6 +379,7 @@ func TestRenderCompareTableQuickMode(t *testing.T) {
 	// Quick mode should not have risk/phonetics/suitability columns
 	require.NotContains(t, output, "Risk")
 	require.NotContains(t, output, "Phonetics")
+	require.NotContains(t, output, "Memorability")
 }
 
 func TestRenderCompareTableWithError(t *testing.T) {
@@ -404,6 +408,7 @@ func TestRenderCompareMarkdown(t *testing.T) {
 			Availability: compareAvailability{Score: 4, Total: 6, Unknown: 2},
 			RiskLevel:    "medium",
 			Phonetics:    &comparePhonetics{OverallScore: 70},
+			Memorability: &phonetics.Memorability{Score: 64},
 			Suitability:  &compareSuitability{OverallScore: 80},
 		},
 	}
@@ -417,8 +422,7 @@ func TestRenderCompareMarkdown(t *testing.T) {
 	require.Contains(t, output, "| mdtest |")
 	require.Contains(t, output, "4/6 (2?)")
 	require.Contains(t, output, "medium")
-	require.Contains(t, output, "70")
-	require.Contains(t, output, "80")
+	require.Contains(t, output, "| 70 | 64 | 80 |")
 }
 
 func TestRenderCompareMarkdownWithError(t *testing.T) {
@@ -447,6 +451,7 @@ func TestRenderCompareJSON(t *testing.T) {
 			Availability: compareAvailability{Score: 2, Total: 4, Unknown: 1},
 			RiskLevel:    "high",
 			Phonetics:    &comparePhonetics{OverallScore: 65, TypeabilityScore: 60, CLISuitability: 70},
+			Memorability: &phonetics.Memorability{Score: 58, NearestWord: "test"},
 			Suitability:  &compareSuitability{OverallScore: 55, Rating: "caution"},
 		},
 	}
@@ -463,6 +468,8 @@ func TestRenderCompareJSON(t *testing.T) {
 	require.Equal(t, 2, parsed[0].Availability.Score)
 	require.Equal(t, "high", parsed[0].RiskLevel)
 	require.Equal(t, 65, parsed[0].Phonetics.OverallScore)
+	require.Equal(t, 58, parsed[0].Memorability.Score)
+	require.Equal(t, "test", parsed[0].Memorability.NearestWord)
 	require.Equal(t, "caution", parsed[0].Suitability.Rating)
 }
 
@@ -518,3 +525,48 @@ func TestCompareModeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderCompareHTML(t *testing.T) {
+	rows := []compareRow{
+		{
+			Name:         "acme",
+			Length:       4,
+			Availability: compareAvailability{Score: 1, Total: 1},
+			results: []*core.CheckResult{
+				{Name: "acme.dev", CheckType: core.CheckTypeDomain, TLD: "dev", Available: core.AvailabilityAvailable},
+			},
+		},
+		{Name: "zenith", Length: 6, AvailabilityError: "error"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderCompare(&buf, rows, output.FormatHTML, true))
+	rendered := buf.String()
+	require.Contains(t, rendered, "<h1>Name comparison: acme, zenith</h1>")
+	require.Contains(t, rendered, "<tr><th>Name</th><th>Availability</th><th>Length</th></tr>")
+	require.Contains(t, rendered, "<td>acme.dev</td>")
+	require.Contains(t, rendered, "<h2>zenith</h2>")
+}
+
+func TestRenderCompareCSV(t *testing.T) {
+	rows := []compareRow{
+		{
+			Name:         "acme",
+			Length:       4,
+			Availability: compareAvailability{Score: 1, Total: 1},
+			RiskLevel:    "low",
+			Phonetics:    &comparePhonetics{OverallScore: 77},
+			results: []*core.CheckResult{
+				{Name: "acme.dev", CheckType: core.CheckTypeDomain, TLD: "dev", Available: core.AvailabilityAvailable},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderCompare(&buf, rows, output.FormatCSV, false))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasSuffix(lines[0], ",notes,length,risk,phonetics,memorability"))
+	require.True(t, strings.HasPrefix(lines[1], "acme,domain,acme.dev,available,"))
+	require.True(t, strings.HasSuffix(lines[1], ",4,low,77,"))
+}
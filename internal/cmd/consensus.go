@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+)
+
+const suitabilityPromptSlug = "name-suitability"
+
+func addConsensusFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("consensus", 0, "Run the suitability analysis on N distinct providers/models and merge the scores (median)")
+	cmd.Flags().Int("consensus-threshold", ailink.DefaultConsensusThreshold, "Flag disagreement when consensus scores spread more than this many points")
+}
+
+// consensusTarget is one provider/model pair in a consensus run.
+type consensusTarget struct {
+	Provider string
+	Model    string
+}
+
+// consensusAnalysis runs one analysis on a provider/model pair; cfg routes
+// the analysis to the pair's provider.
+type consensusAnalysis func(ctx context.Context, cfg *config.Config, model string) (json.RawMessage, *ailink.SearchError)
+
+// resolveConsensus reads --consensus and --consensus-threshold and picks the
+// provider/model pairs for the suitability analysis. It returns no targets
+// when consensus is off (0 or 1).
+func resolveConsensus(cmd *cobra.Command, cfg *config.Config, depth string) ([]consensusTarget, int, error) {
+	n, err := cmd.Flags().GetInt("consensus")
+	if err != nil {
+		return nil, 0, err
+	}
+	threshold, err := cmd.Flags().GetInt("consensus-threshold")
+	if err != nil {
+		return nil, 0, err
+	}
+	if n < 0 {
+		return nil, 0, errors.New("--consensus must be 0 or greater")
+	}
+	if threshold < 1 || threshold > 100 {
+		return nil, 0, errors.New("--consensus-threshold must be between 1 and 100")
+	}
+	if n <= 1 {
+		return nil, threshold, nil
+	}
+
+	registry, err := buildPromptRegistry(cfg)
+	if err != nil {
+		return nil, 0, fmt.Errorf("loading prompts: %w", err)
+	}
+	promptDef, err := registry.Get(suitabilityPromptSlug)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	routed := ""
+	if resolved, err := ailink.NewRegistry(cfg.AILink).ResolveWithDepth(suitabilityPromptSlug, promptDef, "", depth); err == nil {
+		routed = resolved.ProviderID
+	}
+	ids := configuredProviderIDs(cfg.AILink.Providers)
+	sort.SliceStable(ids, func(i, j int) bool { return ids[i] == routed && ids[j] != routed })
+
+	var (
+		targets []consensusTarget
+		seen    = map[consensusTarget]bool{}
+		usable  []string
+	)
+	add := func(target consensusTarget) {
+		if len(targets) < n && target.Model != "" && !seen[target] {
+			seen[target] = true
+			targets = append(targets, target)
+		}
+	}
+	// Each provider's own model for the prompt first, then its other models
+	for _, id := range ids {
+		routedCfg, err := applyGenerateProviderOverride(cfg.AILink, suitabilityPromptSlug, id)
+		if err != nil {
+			continue
+		}
+		resolved, err := ailink.NewRegistry(routedCfg).ResolveWithDepth(suitabilityPromptSlug, promptDef, "", depth)
+		if err != nil || strings.TrimSpace(resolved.Credential.APIKey) == "" {
+			continue
+		}
+		usable = append(usable, id)
+		add(consensusTarget{Provider: id, Model: resolved.Model})
+	}
+	for _, id := range usable {
+		models := cfg.AILink.Providers[id].Models
+		tiers := make([]string, 0, len(models))
+		for tier := range models {
+			tiers = append(tiers, tier)
+		}
+		sort.Strings(tiers)
+		for _, tier := range tiers {
+			add(consensusTarget{Provider: id, Model: strings.TrimSpace(models[tier])})
+		}
+	}
+
+	if len(targets) < n {
+		return nil, 0, fmt.Errorf("--consensus %d needs %d distinct provider/model pairs; only %d configured with API keys", n, n, len(targets))
+	}
+	return targets, threshold, nil
+}
+
+// runConsensus runs analysis on every target in parallel and merges the
+// answers with ailink.MergeSuitability.
+func runConsensus(ctx context.Context, cfg *config.Config, targets []consensusTarget, threshold int, analysis consensusAnalysis) (json.RawMessage, *ailink.SearchError) {
+	votes := make([]ailink.ConsensusVote, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target consensusTarget) {
+			defer wg.Done()
+			vote := ailink.ConsensusVote{Provider: target.Provider, Model: target.Model}
+			routedCfg := *cfg
+			var err error
+			routedCfg.AILink, err = applyGenerateProviderOverride(cfg.AILink, suitabilityPromptSlug, target.Provider)
+			if err != nil {
+				vote.Err = &ailink.SearchError{Code: "AILINK_API_ERROR", Message: err.Error()}
+			} else {
+				vote.Raw, vote.Err = analysis(ctx, &routedCfg, target.Model)
+			}
+			votes[i] = vote
+		}(i, target)
+	}
+	wg.Wait()
+
+	merged, err := ailink.MergeSuitability(votes, threshold)
+	if err != nil {
+		for _, vote := range votes {
+			if vote.Err != nil {
+				return nil, vote.Err
+			}
+		}
+		return nil, &ailink.SearchError{Code: "AILINK_VALIDATION_ERROR", Message: err.Error()}
+	}
+	return merged, nil
+}
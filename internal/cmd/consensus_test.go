@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+)
+
+func consensusTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	addConsensusFlags(cmd)
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestResolveConsensusPicksDistinctProvidersThenModels(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.AILink.DefaultProvider = "xai"
+	cfg.AILink.Providers = map[string]ailink.ProviderInstanceConfig{
+		"xai": {
+			Enabled: true, AIProvider: "xai",
+			Models:      map[string]string{"default": "grok-4", "fast": "grok-4-fast"},
+			Credentials: []ailink.CredentialConfig{{APIKey: "k"}},
+		},
+		"anthropic": {
+			Enabled: true, AIProvider: "anthropic",
+			Models:      map[string]string{"default": "claude-sonnet-4-6"},
+			Credentials: []ailink.CredentialConfig{{APIKey: "k"}},
+		},
+		"openai": {
+			Enabled: true, AIProvider: "openai",
+			Models: map[string]string{"default": "gpt-4o"},
+		},
+	}
+
+	targets, threshold, err := resolveConsensus(consensusTestCommand(t, "--consensus", "3", "--consensus-threshold", "15"), cfg, "quick")
+	require.NoError(t, err)
+	require.Equal(t, 15, threshold)
+	// The routed provider comes first; openai has no API key
+	require.Equal(t, []consensusTarget{
+		{Provider: "xai", Model: "grok-4"},
+		{Provider: "anthropic", Model: "claude-sonnet-4-6"},
+		{Provider: "xai", Model: "grok-4-fast"},
+	}, targets)
+
+	_, _, err = resolveConsensus(consensusTestCommand(t, "--consensus", "4"), cfg, "quick")
+	require.ErrorContains(t, err, "only 3 configured")
+
+	targets, _, err = resolveConsensus(consensusTestCommand(t, "--consensus", "1"), cfg, "quick")
+	require.NoError(t, err)
+	require.Empty(t, targets)
+
+	_, _, err = resolveConsensus(consensusTestCommand(t, "--consensus", "2", "--consensus-threshold", "0"), cfg, "quick")
+	require.Error(t, err)
+}
@@ -18,6 +18,9 @@ var contextCmd = &cobra.Command{
 The corpus includes file classification, budget allocation, and content extraction.
 Output can be JSON (schema-backed) or Markdown (human-readable).
 
+Paths matched by .gitignore or .namelensignore files, dependency directories
+such as node_modules and vendor, and binary files are skipped.
+
 Examples:
   # Generate JSON corpus
   namelens context ./planning > corpus.json
@@ -28,6 +31,9 @@ Examples:
   # Show manifest only (no content)
   namelens context ./planning --manifest-only
 
+  # Scan a monorepo root two levels deep, skipping generated docs
+  namelens context . --max-depth=2 --exclude="docs/api/"
+
   # Use with generate command
   namelens context ./planning | namelens generate "concept" --corpus=-`,
 	Args: cobra.ExactArgs(1),
@@ -41,7 +47,9 @@ func init() {
 	contextCmd.Flags().Int("budget", 32000, "Max characters to include")
 	contextCmd.Flags().Bool("manifest-only", false, "Output manifest without content")
 	contextCmd.Flags().StringSlice("include", nil, "Additional patterns to include")
-	contextCmd.Flags().StringSlice("exclude", nil, "Patterns to exclude")
+	contextCmd.Flags().StringSlice("exclude", nil, "Patterns to exclude (.gitignore syntax)")
+	contextCmd.Flags().Int("max-depth", 0, "Directory levels below the root that name patterns such as *.md also match")
+	contextCmd.Flags().Bool("no-ignore", false, "Ignore .gitignore/.namelensignore files and scan dependency directories")
 }
 
 func runContext(cmd *cobra.Command, args []string) error {
@@ -52,11 +60,19 @@ func runContext(cmd *cobra.Command, args []string) error {
 	manifestOnly, _ := cmd.Flags().GetBool("manifest-only")
 	includePatterns, _ := cmd.Flags().GetStringSlice("include")
 	excludePatterns, _ := cmd.Flags().GetStringSlice("exclude")
+	maxDepth, _ := cmd.Flags().GetInt("max-depth")
+	noIgnore, _ := cmd.Flags().GetBool("no-ignore")
+	if maxDepth < 0 {
+		return fmt.Errorf("--max-depth must be zero or more")
+	}
 
 	// Build config
 	cfg := ailinkctx.Config{
 		Patterns: ailinkctx.DefaultPatterns,
 		MaxChars: budget,
+		MaxDepth: maxDepth,
+		Exclude:  excludePatterns,
+		NoIgnore: noIgnore,
 	}
 
 	// Add additional include patterns
@@ -64,9 +80,6 @@ func runContext(cmd *cobra.Command, args []string) error {
 		cfg.Patterns = append(cfg.Patterns, includePatterns...)
 	}
 
-	// Note: exclude patterns would need to be implemented in Gather
-	_ = excludePatterns // TODO: implement exclude filtering
-
 	// Gather context
 	result, err := ailinkctx.Gather(dir, cfg)
 	if err != nil {
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// contextVariable is the prompt variable that carries scanned or corpus
+// context.
+const contextVariable = "description"
+
+// fitReviewContext fits brand context into the context window of the model
+// promptSlug resolves to. Review runs it once per prompt rather than per name,
+// so oversized context is summarized once. Failures are logged and leave the
+// context as is.
+func fitReviewContext(ctx context.Context, cfg *config.Config, store *corestore.Store, promptSlug, depth, brandContext string) (string, *ailink.ContextReport) {
+	if cfg == nil || strings.TrimSpace(brandContext) == "" || isOffline(cfg) {
+		return brandContext, nil
+	}
+	registry, err := buildPromptRegistry(cfg)
+	if err != nil {
+		return brandContext, nil
+	}
+	catalog, err := buildSchemaCatalog()
+	if err != nil {
+		return brandContext, nil
+	}
+	svc := &ailink.Service{Providers: ailink.NewRegistry(cfg.AILink), Registry: registry, Catalog: catalog, Quota: aiQuota(cfg, store), Budget: aiBudget(cfg, store)}
+
+	req, report, err := svc.FitContext(ctx, ailink.GenerateRequest{
+		Role:       promptSlug,
+		PromptSlug: promptSlug,
+		Variables:  map[string]string{contextVariable: brandContext},
+		Depth:      depth,
+	}, contextVariable)
+	if err != nil {
+		observability.CLILogger.Warn("Context size check failed; sending context as is", zap.String("prompt", promptSlug), zap.Error(err))
+		return brandContext, nil
+	}
+	return req.Variables[contextVariable], report
+}
+
+// printContextReport notes on w how context was reduced to fit the model.
+func printContextReport(w io.Writer, report *ailink.ContextReport) {
+	if w == nil || report == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Context (~%d tokens) exceeded the %d-token window of %s; ", report.PromptTokens, report.ContextWindow, report.Model)
+	switch report.Action {
+	case ailink.ContextSummarized:
+		_, _ = fmt.Fprintf(w, "kept %d sections and summarized %d with %s", len(report.Kept), len(report.Summarized), report.SummaryModel)
+	default:
+		_, _ = fmt.Fprintf(w, "kept %d sections", len(report.Kept))
+	}
+	if len(report.Omitted) > 0 {
+		_, _ = fmt.Fprintf(w, ", omitted %d (%s)", len(report.Omitted), strings.Join(report.Omitted, ", "))
+	}
+	_, _ = fmt.Fprintf(w, " (~%d tokens)\n", report.FinalTokens)
+	if report.Error != "" {
+		_, _ = fmt.Fprintf(w, "Context summary unavailable: %s\n", report.Error)
+	}
+}
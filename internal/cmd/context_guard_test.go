@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+)
+
+func TestPrintContextReport(t *testing.T) {
+	var none bytes.Buffer
+	printContextReport(&none, nil)
+	require.Empty(t, none.String())
+
+	var summarized bytes.Buffer
+	printContextReport(&summarized, &ailink.ContextReport{
+		Model:         "big",
+		ContextWindow: 32768,
+		PromptTokens:  41210,
+		FinalTokens:   17877,
+		Action:        ailink.ContextSummarized,
+		SummaryModel:  "small",
+		Kept:          []string{"README.md"},
+		Summarized:    []string{"a.md", "b.md"},
+	})
+	require.Equal(t, "Context (~41210 tokens) exceeded the 32768-token window of big; kept 1 sections and summarized 2 with small (~17877 tokens)\n", summarized.String())
+
+	var trimmed bytes.Buffer
+	printContextReport(&trimmed, &ailink.ContextReport{
+		Model:         "big",
+		ContextWindow: 8000,
+		PromptTokens:  9000,
+		FinalTokens:   3900,
+		Action:        ailink.ContextTrimmed,
+		Kept:          []string{"README.md"},
+		Omitted:       []string{"a.md"},
+		Error:         "summarize context: timeout",
+	})
+	require.Equal(t, "Context (~9000 tokens) exceeded the 8000-token window of big; kept 1 sections, omitted 1 (a.md) (~3900 tokens)\nContext summary unavailable: summarize context: timeout\n", trimmed.String())
+}
@@ -0,0 +1,483 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/core"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/output"
+)
+
+// diffExtraFields lists result details compared between snapshots.
+var diffExtraFields = []string{"registrar", "expiration", "latest_version", "version"}
+
+type diffChange struct {
+	Target    string `json:"target"`
+	CheckType string `json:"check_type"`
+	Field     string `json:"field"`
+	Before    string `json:"before,omitempty"`
+	After     string `json:"after,omitempty"`
+	Summary   string `json:"summary"`
+}
+
+type diffSnapshot struct {
+	Label     string    `json:"label"`
+	CheckedAt time.Time `json:"checked_at"`
+	Results   int       `json:"results"`
+}
+
+type diffReport struct {
+	Name      string       `json:"name"`
+	From      diffSnapshot `json:"from"`
+	To        diffSnapshot `json:"to"`
+	Changes   []diffChange `json:"changes"`
+	Unchanged int          `json:"unchanged"`
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <name>",
+	Short: "Show what changed between two recorded checks of a name",
+	Long: `Compare two historical snapshots of a name and highlight what changed:
+domains that became taken, handles that freed up, registrar changes, and
+expiration date movements.
+
+Every check that resolves fresh results is recorded in the local store. A
+snapshot is selected with --from/--to, which accept a date (YYYY-MM-DD),
+an RFC3339 timestamp, or a run ID (see --runs). With neither flag, the two
+most recent runs are compared.`,
+	Example: `  namelens diff acme
+  namelens diff acme --from 2026-01-01 --to 2026-03-01
+  namelens diff acme --runs`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().String("from", "", "Earlier snapshot: date, RFC3339 timestamp, or run ID")
+	diffCmd.Flags().String("to", "", "Later snapshot: date, RFC3339 timestamp, or run ID (default latest)")
+	diffCmd.Flags().Bool("runs", false, "List recorded runs for the name instead of diffing")
+	diffCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	diffCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+	if name == "" {
+		return errors.New("name is required")
+	}
+
+	fromSpec, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return err
+	}
+	toSpec, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return err
+	}
+	listRuns, err := cmd.Flags().GetBool("runs")
+	if err != nil {
+		return err
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	runs, err := store.ListHistoryRuns(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	if listRuns {
+		return renderDiffRuns(sink.writer, runs, format)
+	}
+
+	if len(runs) == 0 {
+		return fmt.Errorf("no recorded history for %s; run `namelens check %s` first", name, name)
+	}
+
+	fromSpec = strings.TrimSpace(fromSpec)
+	toSpec = strings.TrimSpace(toSpec)
+	if fromSpec == "" {
+		if toSpec != "" {
+			return errors.New("--from is required when --to is set")
+		}
+		if len(runs) < 2 {
+			return fmt.Errorf("only one recorded run for %s; check it again later to compare", name)
+		}
+		fromSpec = runs[1].RunID
+		toSpec = runs[0].RunID
+	}
+
+	before, fromInfo, err := resolveDiffSnapshot(ctx, store, name, fromSpec, runs, false)
+	if err != nil {
+		return fmt.Errorf("resolve --from: %w", err)
+	}
+	after, toInfo, err := resolveDiffSnapshot(ctx, store, name, toSpec, runs, true)
+	if err != nil {
+		return fmt.Errorf("resolve --to: %w", err)
+	}
+
+	changes, unchanged := diffSnapshots(before, after)
+	report := diffReport{
+		Name:      name,
+		From:      fromInfo,
+		To:        toInfo,
+		Changes:   changes,
+		Unchanged: unchanged,
+	}
+
+	return renderDiff(sink.writer, report, format)
+}
+
+// resolveDiffSnapshot loads the results selected by a --from/--to value.
+// An empty spec selects the latest state.
+func resolveDiffSnapshot(ctx context.Context, store *corestore.Store, name, spec string, runs []corestore.HistoryRun, endOfDay bool) ([]*core.CheckResult, diffSnapshot, error) {
+	if run, ok := matchHistoryRun(runs, spec); ok {
+		entries, err := store.GetHistoryRun(ctx, run.RunID)
+		if err != nil {
+			return nil, diffSnapshot{}, err
+		}
+		return historyResults(entries), diffSnapshot{Label: "run " + shortRunID(run.RunID), CheckedAt: run.CheckedAt, Results: len(entries)}, nil
+	}
+
+	at := time.Now().UTC()
+	label := "latest"
+	if spec != "" {
+		parsed, err := parseDiffTime(spec, endOfDay)
+		if err != nil {
+			return nil, diffSnapshot{}, err
+		}
+		at = parsed
+		label = spec
+	}
+
+	entries, err := store.HistorySnapshot(ctx, name, at)
+	if err != nil {
+		return nil, diffSnapshot{}, err
+	}
+	if len(entries) == 0 {
+		return nil, diffSnapshot{}, fmt.Errorf("no recorded history for %s as of %s", name, at.Format(time.RFC3339))
+	}
+
+	checkedAt := entries[0].CheckedAt
+	for _, entry := range entries {
+		if entry.CheckedAt.After(checkedAt) {
+			checkedAt = entry.CheckedAt
+		}
+	}
+	return historyResults(entries), diffSnapshot{Label: label, CheckedAt: checkedAt, Results: len(entries)}, nil
+}
+
+func matchHistoryRun(runs []corestore.HistoryRun, spec string) (corestore.HistoryRun, bool) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if len(spec) < 6 {
+		return corestore.HistoryRun{}, false
+	}
+	for _, run := range runs {
+		if strings.HasPrefix(strings.ToLower(run.RunID), spec) {
+			return run, true
+		}
+	}
+	return corestore.HistoryRun{}, false
+}
+
+func parseDiffTime(value string, endOfDay bool) (time.Time, error) {
+	t, err := parseDateBound(value, endOfDay)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date or run ID %q (use YYYY-MM-DD, RFC3339, or a run ID from --runs)", value)
+	}
+	return t, nil
+}
+
+// parseDateBound parses an RFC3339 timestamp or YYYY-MM-DD date. With endOfDay,
+// a bare date resolves to its last second.
+func parseDateBound(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD or RFC3339)", value)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return t.UTC(), nil
+}
+
+func historyResults(entries []corestore.HistoryEntry) []*core.CheckResult {
+	results := make([]*core.CheckResult, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Result != nil {
+			results = append(results, entry.Result)
+		}
+	}
+	return results
+}
+
+func shortRunID(runID string) string {
+	if len(runID) > 8 {
+		return runID[:8]
+	}
+	return runID
+}
+
+// diffSnapshots compares two sets of results keyed by target and returns the
+// changes along with the number of targets that did not change.
+func diffSnapshots(before, after []*core.CheckResult) ([]diffChange, int) {
+	previous := make(map[string]*core.CheckResult, len(before))
+	for _, result := range before {
+		if result != nil {
+			previous[corestore.HistoryKey(result)] = result
+		}
+	}
+
+	changes := make([]diffChange, 0)
+	unchanged := 0
+	seen := make(map[string]bool, len(after))
+	for _, current := range after {
+		if current == nil {
+			continue
+		}
+		key := corestore.HistoryKey(current)
+		seen[key] = true
+
+		prior, ok := previous[key]
+		if !ok {
+			changes = append(changes, diffChange{
+				Target:    current.Name,
+				CheckType: string(current.CheckType),
+				Field:     "added",
+				After:     current.Available.String(),
+				Summary:   "newly checked",
+			})
+			continue
+		}
+
+		targetChanges := diffResult(prior, current)
+		if len(targetChanges) == 0 {
+			unchanged++
+			continue
+		}
+		changes = append(changes, targetChanges...)
+	}
+
+	for _, prior := range before {
+		if prior == nil || seen[corestore.HistoryKey(prior)] {
+			continue
+		}
+		changes = append(changes, diffChange{
+			Target:    prior.Name,
+			CheckType: string(prior.CheckType),
+			Field:     "removed",
+			Before:    prior.Available.String(),
+			Summary:   "no longer checked",
+		})
+	}
+
+	return changes, unchanged
+}
+
+func diffResult(prior, current *core.CheckResult) []diffChange {
+	changes := make([]diffChange, 0)
+	base := diffChange{Target: current.Name, CheckType: string(current.CheckType)}
+
+	if prior.Available != current.Available {
+		change := base
+		change.Field = "availability"
+		change.Before = prior.Available.String()
+		change.After = current.Available.String()
+		change.Summary = availabilityChangeSummary(prior.Available, current.Available)
+		changes = append(changes, change)
+	}
+
+	for _, field := range diffExtraFields {
+		was := diffExtraValue(prior.ExtraData, field)
+		now := diffExtraValue(current.ExtraData, field)
+		if was == now || was == "" || now == "" {
+			continue
+		}
+		change := base
+		change.Field = field
+		change.Before = was
+		change.After = now
+		switch field {
+		case "registrar":
+			change.Summary = "registrar changed"
+		case "expiration":
+			change.Summary = expirationChangeSummary(was, now)
+		default:
+			change.Summary = "new release published"
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func availabilityChangeSummary(before, after core.Availability) string {
+	switch {
+	case after == core.AvailabilityTaken && before == core.AvailabilityAvailable:
+		return "became taken"
+	case after == core.AvailabilityAvailable && before == core.AvailabilityTaken:
+		return "freed up"
+	case after == core.AvailabilityTaken:
+		return "confirmed taken"
+	case after == core.AvailabilityAvailable:
+		return "confirmed available"
+	default:
+		return "status changed"
+	}
+}
+
+func expirationChangeSummary(before, after string) string {
+	was, errBefore := time.Parse(time.RFC3339, before)
+	now, errAfter := time.Parse(time.RFC3339, after)
+	if errBefore != nil || errAfter != nil {
+		return "expiration changed"
+	}
+	days := int(now.Sub(was).Hours() / 24)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("expiration extended by %d days", days)
+	case days < 0:
+		return fmt.Sprintf("expiration moved earlier by %d days", -days)
+	default:
+		return "expiration changed"
+	}
+}
+
+func diffExtraValue(extra map[string]any, key string) string {
+	if extra == nil {
+		return ""
+	}
+	value, ok := extra[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if t, ok := value.(time.Time); ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+func renderDiff(w io.Writer, report diffReport, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		return renderDiffMarkdown(w, report)
+	default:
+		return renderDiffTable(w, report)
+	}
+}
+
+func renderDiffTable(w io.Writer, report diffReport) error {
+	_, _ = fmt.Fprintf(w, "%s: %s (%s) -> %s (%s)\n", report.Name,
+		report.From.Label, report.From.CheckedAt.Format(time.RFC3339),
+		report.To.Label, report.To.CheckedAt.Format(time.RFC3339))
+
+	if len(report.Changes) == 0 {
+		_, err := fmt.Fprintf(w, "No changes (%d targets unchanged)\n", report.Unchanged)
+		return err
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Target", "Type", "Change", "Before", "After"})
+	for _, change := range report.Changes {
+		t.AppendRow(table.Row{change.Target, change.CheckType, change.Summary, dashIfEmpty(change.Before), dashIfEmpty(change.After)})
+	}
+	t.Render()
+
+	_, err := fmt.Fprintf(w, "%d changed, %d unchanged\n", len(report.Changes), report.Unchanged)
+	return err
+}
+
+func renderDiffMarkdown(w io.Writer, report diffReport) error {
+	_, _ = fmt.Fprintf(w, "## %s: %s -> %s\n\n", report.Name, report.From.Label, report.To.Label)
+	if len(report.Changes) == 0 {
+		_, err := fmt.Fprintf(w, "No changes (%d targets unchanged).\n", report.Unchanged)
+		return err
+	}
+
+	_, _ = fmt.Fprintln(w, "| Target | Type | Change | Before | After |")
+	_, _ = fmt.Fprintln(w, "|--------|------|--------|--------|-------|")
+	for _, change := range report.Changes {
+		_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n",
+			change.Target, change.CheckType, change.Summary, dashIfEmpty(change.Before), dashIfEmpty(change.After))
+	}
+	_, err := fmt.Fprintf(w, "\n%d changed, %d unchanged\n", len(report.Changes), report.Unchanged)
+	return err
+}
+
+func renderDiffRuns(w io.Writer, runs []corestore.HistoryRun, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(runs, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		_, _ = fmt.Fprintln(w, "| Run | Checked At | Results |")
+		_, _ = fmt.Fprintln(w, "|-----|------------|---------|")
+		for _, run := range runs {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %d |\n", run.RunID, run.CheckedAt.Format(time.RFC3339), run.Results)
+		}
+		return nil
+	default:
+		t := table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.AppendHeader(table.Row{"Run", "Checked At", "Results"})
+		for _, run := range runs {
+			t.AppendRow(table.Row{run.RunID, run.CheckedAt.Format(time.RFC3339), run.Results})
+		}
+		t.Render()
+		return nil
+	}
+}
+
+func dashIfEmpty(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
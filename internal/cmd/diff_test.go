@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken, ExtraData: map[string]any{
+			"registrar":  "Old Registrar",
+			"expiration": "2026-01-01T00:00:00Z",
+		}},
+		{Name: "acme.io", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeGitHub, Available: core.AvailabilityTaken},
+		{Name: "acme", CheckType: core.CheckTypePyPI, Available: core.AvailabilityTaken},
+		{Name: "acme.dev", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+	}
+	after := []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken, ExtraData: map[string]any{
+			"registrar":  "New Registrar",
+			"expiration": "2027-01-01T00:00:00Z",
+		}},
+		{Name: "acme.io", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken},
+		{Name: "acme", CheckType: core.CheckTypeGitHub, Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypePyPI, Available: core.AvailabilityTaken},
+		{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+	}
+
+	changes, unchanged := diffSnapshots(before, after)
+	require.Equal(t, 1, unchanged)
+
+	summaries := make([]string, 0, len(changes))
+	for _, change := range changes {
+		summaries = append(summaries, change.Target+" "+change.Summary)
+	}
+	require.Equal(t, []string{
+		"acme.com registrar changed",
+		"acme.com expiration extended by 365 days",
+		"acme.io became taken",
+		"acme freed up",
+		"acme newly checked",
+		"acme.dev no longer checked",
+	}, summaries)
+}
+
+func TestParseDiffTime(t *testing.T) {
+	start, err := parseDiffTime("2026-03-01", false)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), start)
+
+	end, err := parseDiffTime("2026-03-01", true)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC), end)
+
+	_, err = parseDiffTime("last week", false)
+	require.Error(t, err)
+}
+
+func TestRenderDiffNoChanges(t *testing.T) {
+	report := diffReport{Name: "acme", From: diffSnapshot{Label: "2026-01-01"}, To: diffSnapshot{Label: "latest"}, Unchanged: 3}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderDiff(&buf, report, output.FormatMarkdown))
+	require.Contains(t, buf.String(), "No changes (3 targets unchanged).")
+}
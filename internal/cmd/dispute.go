@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+var disputeCmd = &cobra.Command{
+	Use:   "dispute <name>",
+	Short: "Draft a name dispute request for a taken npm package or GitHub handle",
+	Long: `Draft a dispute or transfer request for a name that is taken but appears
+abandoned or squatted.
+
+NameLens re-checks the name on the selected platform, collects the evidence it
+finds (ownership, last activity, maintainers), and asks the configured AI
+backend to draft a request addressed to npm support or GitHub Support. The
+draft is written as Markdown for you to review and send yourself.`,
+	Example: `  namelens dispute acme --platform npm --requester "Jane Doe" --project-url https://github.com/acme/acme
+  namelens dispute acme --platform github --reason "Organization name for the Acme CLI" --out dispute.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDispute,
+}
+
+func init() {
+	rootCmd.AddCommand(disputeCmd)
+
+	disputeCmd.Flags().String("platform", "npm", "Platform to draft the request for: npm, github")
+	disputeCmd.Flags().String("requester", "", "Name or organization making the request")
+	disputeCmd.Flags().String("project-url", "", "URL of the project that needs the name")
+	disputeCmd.Flags().String("reason", "", "Why you need the name (included in the draft)")
+	disputeCmd.Flags().String("depth", "quick", "Draft depth: quick, deep")
+	disputeCmd.Flags().String("model", "", "Model override")
+	disputeCmd.Flags().Bool("json", false, "Output raw JSON response")
+	disputeCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+	disputeCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
+}
+
+// disputeDraft mirrors ailink/v0/name-dispute-response.
+type disputeDraft struct {
+	Summary       string   `json:"summary"`
+	Platform      string   `json:"platform"`
+	Eligible      *bool    `json:"eligible,omitempty"`
+	PolicyBasis   string   `json:"policy_basis,omitempty"`
+	Recipient     string   `json:"recipient,omitempty"`
+	Subject       string   `json:"subject"`
+	Body          string   `json:"body"`
+	EvidenceCited []string `json:"evidence_cited,omitempty"`
+	FollowUp      []string `json:"follow_up,omitempty"`
+	Risks         []string `json:"risks,omitempty"`
+}
+
+func runDispute(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+	if name == "" {
+		return errors.New("name is required")
+	}
+
+	platform, err := cmd.Flags().GetString("platform")
+	if err != nil {
+		return err
+	}
+	platform = strings.ToLower(strings.TrimSpace(platform))
+	if platform != "npm" && platform != "github" {
+		return fmt.Errorf("unsupported platform %q (valid: npm, github)", platform)
+	}
+	requester, err := cmd.Flags().GetString("requester")
+	if err != nil {
+		return err
+	}
+	projectURL, err := cmd.Flags().GetString("project-url")
+	if err != nil {
+		return err
+	}
+	reason, err := cmd.Flags().GetString("reason")
+	if err != nil {
+		return err
+	}
+	depth, err := cmd.Flags().GetString("depth")
+	if err != nil {
+		return err
+	}
+	modelOverride, err := cmd.Flags().GetString("model")
+	if err != nil {
+		return err
+	}
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+
+	profile := core.Profile{Name: "dispute"}
+	if platform == "github" {
+		profile.Handles = []string{"github"}
+	} else {
+		profile.Registries = []string{"npm"}
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+	results, err := orchestrator.Check(ctx, name, profile)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 || results[0] == nil {
+		return fmt.Errorf("no %s result for %s", platform, name)
+	}
+	result := results[0]
+	switch result.Available {
+	case core.AvailabilityAvailable:
+		return fmt.Errorf("%s is available on %s; no dispute needed", name, platform)
+	case core.AvailabilityTaken:
+	default:
+		return fmt.Errorf("could not confirm %s is taken on %s: %s", name, platform, result.Message)
+	}
+
+	vars := map[string]string{
+		"platform":    platform,
+		"evidence":    disputeEvidence(result),
+		"requester":   requester,
+		"project_url": projectURL,
+		"reason":      reason,
+		"depth":       depth,
+	}
+	raw, searchErr := runAnalysis(ctx, cfg, store, "name-dispute", name, depth, modelOverride, vars, !noCache)
+	if searchErr != nil {
+		if searchErr.Details != "" {
+			return fmt.Errorf("%s: %s (%s)", searchErr.Code, searchErr.Message, searchErr.Details)
+		}
+		return fmt.Errorf("%s: %s", searchErr.Code, searchErr.Message)
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	if jsonOutput {
+		_, err := fmt.Fprintln(sink.writer, string(raw))
+		return err
+	}
+
+	var draft disputeDraft
+	if err := json.Unmarshal(raw, &draft); err != nil {
+		return fmt.Errorf("parse dispute draft: %w", err)
+	}
+	return renderDisputeMarkdown(sink.writer, name, result, draft)
+}
+
+// disputeEvidence formats the collected check data as a bullet list for the prompt.
+func disputeEvidence(result *core.CheckResult) string {
+	if result == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "- Checked: %s (%s)\n", result.Name, result.CheckType)
+	b.WriteString("- Status: taken\n")
+	if result.StatusCode != 0 {
+		fmt.Fprintf(&b, "- HTTP status: %d\n", result.StatusCode)
+	}
+	if !result.Provenance.RequestedAt.IsZero() {
+		fmt.Fprintf(&b, "- Checked at: %s\n", result.Provenance.RequestedAt.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+
+	keys := make([]string, 0, len(result.ExtraData))
+	for key := range result.ExtraData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := disputeValue(result.ExtraData[key])
+		if value == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", key, value)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func disputeValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ", ")
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+func renderDisputeMarkdown(w io.Writer, name string, result *core.CheckResult, draft disputeDraft) error {
+	var b strings.Builder
+
+	platform := draft.Platform
+	if platform == "" && result != nil {
+		platform = string(result.CheckType)
+	}
+
+	fmt.Fprintf(&b, "# Name dispute draft: %s (%s)\n\n", name, platform)
+	if draft.Summary != "" {
+		fmt.Fprintf(&b, "%s\n\n", draft.Summary)
+	}
+	if draft.Eligible != nil {
+		verdict := "no"
+		if *draft.Eligible {
+			verdict = "yes"
+		}
+		fmt.Fprintf(&b, "- **Meets policy:** %s\n", verdict)
+	}
+	if draft.PolicyBasis != "" {
+		fmt.Fprintf(&b, "- **Policy:** %s\n", draft.PolicyBasis)
+	}
+	if draft.Recipient != "" {
+		fmt.Fprintf(&b, "- **Send to:** %s\n", draft.Recipient)
+	}
+	b.WriteString("\n## Message\n\n")
+	fmt.Fprintf(&b, "**Subject:** %s\n\n", draft.Subject)
+	fmt.Fprintf(&b, "%s\n", strings.TrimSpace(draft.Body))
+
+	writeMarkdownList(&b, "Evidence cited", draft.EvidenceCited)
+	if result != nil {
+		fmt.Fprintf(&b, "\n## Evidence collected\n\n%s\n", disputeEvidence(result))
+	}
+	writeMarkdownList(&b, "If there is no response", draft.FollowUp)
+	writeMarkdownList(&b, "Risks", draft.Risks)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdownList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", item)
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestDisputeEvidence(t *testing.T) {
+	result := &core.CheckResult{
+		Name:       "acme",
+		CheckType:  core.CheckTypeNPM,
+		Available:  core.AvailabilityTaken,
+		StatusCode: 200,
+		ExtraData: map[string]any{
+			"latest_version": "0.0.1",
+			"modified":       "2015-02-01T00:00:00.000Z",
+			"maintainers":    []any{"squatter"},
+		},
+	}
+
+	evidence := disputeEvidence(result)
+	require.Contains(t, evidence, "- Checked: acme (npm)")
+	require.Contains(t, evidence, "- HTTP status: 200")
+	require.Contains(t, evidence, "- latest_version: 0.0.1")
+	require.Contains(t, evidence, "- maintainers: squatter")
+	require.Less(t, bytes.Index([]byte(evidence), []byte("latest_version")), bytes.Index([]byte(evidence), []byte("modified")))
+}
+
+func TestRenderDisputeMarkdown(t *testing.T) {
+	eligible := true
+	draft := disputeDraft{
+		Summary:       "Package has had no release since 2015.",
+		Platform:      "npm",
+		Eligible:      &eligible,
+		Recipient:     "support@npmjs.com",
+		Subject:       "Package name dispute: acme",
+		Body:          "Hello npm support,\n\nI am requesting the acme package name.",
+		EvidenceCited: []string{"No release since 2015"},
+	}
+	result := &core.CheckResult{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderDisputeMarkdown(&buf, "acme", result, draft))
+
+	out := buf.String()
+	require.Contains(t, out, "# Name dispute draft: acme (npm)")
+	require.Contains(t, out, "- **Meets policy:** yes")
+	require.Contains(t, out, "- **Send to:** support@npmjs.com")
+	require.Contains(t, out, "**Subject:** Package name dispute: acme")
+	require.Contains(t, out, "## Evidence cited")
+	require.Contains(t, out, "## Evidence collected")
+	require.NotContains(t, out, "## Risks")
+}
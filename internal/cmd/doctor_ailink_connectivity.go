@@ -21,7 +21,9 @@ import (
 
 	"github.com/namelens/namelens/internal/ailink"
 	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/network"
 	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/redact"
 )
 
 var (
@@ -357,7 +359,7 @@ func runDNSCheck(ctx context.Context, host string, timeout time.Duration) connec
 	start := time.Now()
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	ips, err := configuredResolver(config.GetConfig()).LookupIPAddr(ctx, host)
 	elapsed := time.Since(start)
 	check := connectivityCheck{Name: "dns", LatencyMS: elapsed.Milliseconds()}
 	if err != nil {
@@ -372,12 +374,15 @@ func runDNSCheck(ctx context.Context, host string, timeout time.Duration) connec
 	}
 	check.OK = true
 	check.Details = map[string]any{"resolved_ips": resolved}
+	if cfg := config.GetConfig(); cfg != nil && len(cfg.Network.Resolvers) > 0 {
+		check.Details["resolvers"] = network.Servers(cfg.Network.Resolvers)
+	}
 	return check
 }
 
 func runTCPCheck(ctx context.Context, host string, port int, timeout time.Duration) (connectivityCheck, net.Conn) {
 	start := time.Now()
-	dialer := &net.Dialer{Timeout: timeout}
+	dialer := &net.Dialer{Timeout: timeout, Resolver: configuredResolver(config.GetConfig())}
 	conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", host, port))
 	elapsed := time.Since(start)
 	check := connectivityCheck{Name: "tcp", LatencyMS: elapsed.Milliseconds()}
@@ -669,6 +674,7 @@ func applyBodyDetails(details map[string]any, contentType string, body []byte) {
 	if strings.Contains(contentType, "json") {
 		var parsed any
 		if err := json.Unmarshal(body, &parsed); err == nil {
+			parsed = redact.Value(parsed)
 			if truncated, ok := truncateJSONSnippet(parsed); ok {
 				details["body_json_snippet"] = truncated
 				details["body_json_truncated"] = true
@@ -680,7 +686,7 @@ func applyBodyDetails(details map[string]any, contentType string, body []byte) {
 	}
 
 	// Fallback: store a plain text snippet.
-	text := strings.TrimSpace(string(body))
+	text := redact.String(strings.TrimSpace(string(body)))
 	if len(text) > 200 {
 		text = text[:200]
 	}
@@ -820,6 +826,27 @@ func classifyConnectivity(checks []connectivityCheck, report *connectivityReport
 			}
 			break
 		}
+	case "http":
+		summary.Classification = "http_error"
+		for _, chk := range checks {
+			if chk.Name != "http" || chk.Error == nil {
+				continue
+			}
+			switch chk.Error.Code {
+			case "PROXY_AUTH_REQUIRED":
+				summary.Classification = "proxy_auth_required"
+				summary.Hints = append(summary.Hints, "Proxy requires authentication (407); add credentials to network.proxy")
+			case "RATE_LIMITED":
+				summary.Classification = "rate_limited"
+				summary.Hints = append(summary.Hints, "Endpoint rate limited the request; retry later or lower rate_limits for the host")
+			case "PROVIDER_UNAVAILABLE":
+				summary.Classification = "provider_overloaded"
+				summary.Hints = append(summary.Hints, "Endpoint returned 5xx; retry later")
+			case "HTTP_ERROR":
+				summary.Hints = append(summary.Hints, "HTTP request failed after TLS succeeded; check network.proxy and network.checker_proxies")
+			}
+			break
+		}
 	}
 
 	return summary
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyBodyDetailsRedacts(t *testing.T) {
+	details := map[string]any{}
+	applyBodyDetails(details, "application/json", []byte(`{"error":{"message":"bad key xai-ABCDEFGHIJKLMNOPQRST","token":"abc"}}`))
+	payload, err := json.Marshal(details)
+	require.NoError(t, err)
+	require.NotContains(t, string(payload), "xai-ABCDEFGHIJKLMNOPQRST")
+	require.NotContains(t, string(payload), `"abc"`)
+}
@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/ascii"
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/audit"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var (
+	doctorConnectivityCheckersTimeout   time.Duration
+	doctorConnectivityCheckersTargets   []string
+	doctorConnectivityCheckersQuiet     bool
+	doctorConnectivityCheckersOutputRaw string
+	doctorConnectivityCheckersOut       string
+	doctorConnectivityCheckersOutDir    string
+)
+
+// checkerEndpoint is a well-known endpoint the availability checkers depend
+// on. Checker is the network.checker_proxies kind whose proxy the HTTP probe
+// uses.
+type checkerEndpoint struct {
+	Name    string
+	Checker string
+	URL     string
+}
+
+// checkerEndpoints are probed in order by `doctor connectivity checkers`.
+var checkerEndpoints = []checkerEndpoint{
+	{Name: "iana-bootstrap", Checker: audit.KindRDAP, URL: "https://data.iana.org/rdap/dns.json"},
+	{Name: "rdap-org", Checker: audit.KindRDAP, URL: "https://rdap.org/domain/example.com"},
+	{Name: "verisign-rdap", Checker: audit.KindRDAP, URL: "https://rdap.verisign.com/com/v1/domain/example.com"},
+	{Name: "npm", Checker: audit.KindRegistry, URL: "https://registry.npmjs.org/"},
+	{Name: "pypi", Checker: audit.KindRegistry, URL: "https://pypi.org/pypi/pip/json"},
+	{Name: "crates", Checker: audit.KindRegistry, URL: "https://crates.io/api/v1/crates/serde"},
+	{Name: "github", Checker: audit.KindHandle, URL: "https://api.github.com/rate_limit"},
+}
+
+// checkersConnectivityReport holds one ailink/v0/connectivity-report per
+// endpoint, with resolution.provider_id naming the endpoint.
+type checkersConnectivityReport struct {
+	Version   string               `json:"version,omitempty"`
+	Timestamp string               `json:"timestamp"`
+	Targets   []connectivityReport `json:"targets"`
+	Summary   connectivitySummary  `json:"summary"`
+}
+
+var doctorConnectivityCmd = &cobra.Command{
+	Use:   "connectivity",
+	Short: "Diagnose reachability of external services",
+}
+
+var doctorConnectivityCheckersCmd = &cobra.Command{
+	Use:   "checkers",
+	Short: "Diagnose reachability of RDAP and registry endpoints",
+	Long: `Runs layered DNS/TCP/TLS/HTTP checks against the endpoints the availability
+checkers depend on: the IANA and rdap.org bootstraps, the Verisign RDAP
+server, the npm, PyPI, and crates.io registries, and the GitHub API.
+
+The HTTP probe goes through network.proxy and network.checker_proxies, the
+same route the checkers take. Each endpoint gets a report in the
+ailink/v0/connectivity-report shape.`,
+	Example: `  namelens doctor connectivity checkers
+  namelens doctor connectivity checkers --target npm --target pypi
+  namelens doctor connectivity checkers --output-format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		format, err := output.ParseFormat(doctorConnectivityCheckersOutputRaw)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatJSON && format != output.FormatTable {
+			return fmt.Errorf("unsupported output format for connectivity: %s", format)
+		}
+
+		endpoints, err := selectCheckerEndpoints(doctorConnectivityCheckersTargets)
+		if err != nil {
+			return err
+		}
+
+		timeout := doctorConnectivityCheckersTimeout
+		if timeout <= 0 {
+			timeout = 10 * time.Second
+		}
+		report := runCheckersConnectivity(cmd.Context(), cfg, endpoints, timeout, format)
+
+		if doctorConnectivityCheckersQuiet {
+			if report.Summary.OK {
+				return nil
+			}
+			return fmt.Errorf("connectivity check failed (%s)", report.Summary.Classification)
+		}
+
+		outPath := strings.TrimSpace(doctorConnectivityCheckersOut)
+		outDir := strings.TrimSpace(doctorConnectivityCheckersOutDir)
+		if outPath != "" && outDir != "" {
+			return fmt.Errorf("--out and --out-dir are mutually exclusive")
+		}
+		if outDir != "" {
+			outDir, err = ensureOutDir(outDir)
+			if err != nil {
+				return err
+			}
+			outPath = filepath.Join(outDir, fmt.Sprintf("doctor.connectivity.checkers.%s", outputExtension(format)))
+		}
+		sink, err := openSink(outPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sink.close() }()
+
+		if format == output.FormatJSON {
+			for i := range report.Targets {
+				payload, err := json.Marshal(report.Targets[i])
+				if err != nil {
+					return err
+				}
+				if err := validateConnectivityReport(payload); err != nil {
+					return fmt.Errorf("%s: %w", report.Targets[i].Resolution.ProviderID, err)
+				}
+			}
+			payload, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(sink.writer, string(payload))
+			return err
+		}
+
+		renderCheckersConnectivityTable(sink.writer, report)
+		return nil
+	},
+}
+
+// selectCheckerEndpoints returns the endpoints named in targets, or all of
+// them when targets is empty.
+func selectCheckerEndpoints(targets []string) ([]checkerEndpoint, error) {
+	if len(targets) == 0 {
+		return checkerEndpoints, nil
+	}
+	selected := make([]checkerEndpoint, 0, len(targets))
+	for _, target := range targets {
+		target = strings.ToLower(strings.TrimSpace(target))
+		found := false
+		for _, endpoint := range checkerEndpoints {
+			if endpoint.Name == target {
+				selected = append(selected, endpoint)
+				found = true
+				break
+			}
+		}
+		if !found {
+			names := make([]string, 0, len(checkerEndpoints))
+			for _, endpoint := range checkerEndpoints {
+				names = append(names, endpoint.Name)
+			}
+			return nil, fmt.Errorf("unknown target %q (valid: %s)", target, strings.Join(names, ", "))
+		}
+	}
+	return selected, nil
+}
+
+func runCheckersConnectivity(ctx context.Context, cfg *config.Config, endpoints []checkerEndpoint, timeout time.Duration, format output.Format) *checkersConnectivityReport {
+	base := configuredDialer(cfg)
+	dialers := checkerDialers(cfg, base)
+
+	report := &checkersConnectivityReport{
+		Version:   versionInfo.Version,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Summary:   connectivitySummary{OK: true, Classification: "ok"},
+	}
+	for _, endpoint := range endpoints {
+		dialer := checkerDialer(dialers, endpoint.Checker, base)
+		client := &http.Client{
+			Timeout:   timeout,
+			Transport: dialer.Transport(),
+			// A redirect is a healthy answer from a bootstrap service.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		}
+		target := runEndpointConnectivity(ctx, endpoint, client, timeout)
+		target.Resolution.AdditionalDetails = map[string]any{"proxy": dialer.Proxy.String()}
+		target.Input = connectivityInput{
+			TimeoutSeconds: int(timeout.Seconds()),
+			Output:         string(format),
+			Quiet:          doctorConnectivityCheckersQuiet,
+		}
+		if target.Input.TimeoutSeconds < 1 {
+			target.Input.TimeoutSeconds = 1
+		}
+		if report.Summary.OK && !target.Summary.OK {
+			report.Summary = target.Summary
+		}
+		report.Targets = append(report.Targets, target)
+	}
+	return report
+}
+
+// runEndpointConnectivity probes one endpoint layer by layer, stopping at the
+// first failing layer like the ailink connectivity doctor.
+func runEndpointConnectivity(ctx context.Context, endpoint checkerEndpoint, client *http.Client, timeout time.Duration) connectivityReport {
+	u, _ := url.Parse(endpoint.URL)
+	host := u.Hostname()
+	port := 443
+	if p, err := strconv.Atoi(u.Port()); err == nil {
+		port = p
+	}
+
+	report := connectivityReport{
+		Version:   versionInfo.Version,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Resolution: connectivityResolution{
+			ProviderID:       endpoint.Name,
+			ResolutionSource: "builtin",
+			RoutingTarget:    endpoint.Checker,
+			BaseURL:          endpoint.URL,
+			Host:             host,
+			Port:             port,
+		},
+		Environment: collectProxyEnv(host),
+	}
+
+	checks := make([]connectivityCheck, 0, 4)
+	dnsCheck := runDNSCheck(ctx, host, timeout)
+	checks = append(checks, dnsCheck)
+	if !dnsCheck.OK {
+		report.Checks = checks
+		report.Summary = classifyConnectivity(checks, &report, "dns")
+		return report
+	}
+
+	tcpCheck, conn := runTCPCheck(ctx, host, port, timeout)
+	checks = append(checks, tcpCheck)
+	if !tcpCheck.OK {
+		report.Checks = checks
+		report.Summary = classifyConnectivity(checks, &report, "tcp")
+		return report
+	}
+
+	tlsCheck := runTLSCheck(ctx, host, conn, timeout)
+	checks = append(checks, tlsCheck)
+	if !tlsCheck.OK {
+		report.Checks = checks
+		report.Summary = classifyConnectivity(checks, &report, "tls")
+		return report
+	}
+
+	checks = append(checks, runHTTPReachCheck(ctx, client, endpoint.URL, timeout))
+	report.Checks = checks
+	report.Summary = classifyConnectivity(checks, &report, "http")
+	return report
+}
+
+// runHTTPReachCheck issues an unauthenticated GET and treats any status
+// below 400 as reachable.
+func runHTTPReachCheck(ctx context.Context, client *http.Client, target string, timeout time.Duration) connectivityCheck {
+	check := connectivityCheck{Name: "http"}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		check.Error = &connectivityErrInfo{Code: "HTTP_REQUEST_ERROR", Message: err.Error()}
+		return check
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "namelens/"+versionInfo.Version)
+
+	resp, err := client.Do(req)
+	check.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		check.Error = &connectivityErrInfo{Code: "HTTP_ERROR", Message: err.Error()}
+		return check
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 32768))
+
+	check.Details = map[string]any{
+		"url":          target,
+		"status_code":  resp.StatusCode,
+		"content_type": strings.TrimSpace(resp.Header.Get("Content-Type")),
+	}
+	if location := resp.Header.Get("Location"); location != "" {
+		check.Details["location"] = location
+	}
+
+	switch {
+	case resp.StatusCode < 400:
+		check.OK = true
+	case resp.StatusCode == 429:
+		check.Error = &connectivityErrInfo{Code: "RATE_LIMITED", Message: resp.Status}
+	case resp.StatusCode == 407:
+		check.Error = &connectivityErrInfo{Code: "PROXY_AUTH_REQUIRED", Message: resp.Status}
+	case resp.StatusCode >= 500:
+		check.Error = &connectivityErrInfo{Code: "PROVIDER_UNAVAILABLE", Message: resp.Status}
+	default:
+		check.Error = &connectivityErrInfo{Code: "HTTP_STATUS_ERROR", Message: resp.Status}
+	}
+	return check
+}
+
+func renderCheckersConnectivityTable(w io.Writer, report *checkersConnectivityReport) {
+	if w == nil || report == nil {
+		return
+	}
+
+	status := "OK"
+	if !report.Summary.OK {
+		status = "FAIL"
+	}
+	lines := []string{fmt.Sprintf("Checker Connectivity (%s)", status), ""}
+
+	for _, target := range report.Targets {
+		symbol := "✅"
+		msg := "ok"
+		if !target.Summary.OK {
+			symbol = "❌"
+			msg = fmt.Sprintf("%s at %s", target.Summary.Classification, target.Summary.FailureLayer)
+		}
+		var latency int64
+		for _, chk := range target.Checks {
+			latency += chk.LatencyMS
+		}
+		lines = append(lines, fmt.Sprintf("%-15s %s %s (%dms)  %s", target.Resolution.ProviderID+":", symbol, msg, latency, target.Resolution.Host))
+	}
+
+	seen := map[string]bool{}
+	hints := make([]string, 0)
+	for _, target := range report.Targets {
+		for _, hint := range target.Summary.Hints {
+			if !seen[hint] {
+				seen[hint] = true
+				hints = append(hints, hint)
+			}
+		}
+	}
+	if len(hints) > 0 {
+		lines = append(lines, "", "hints:")
+		for _, hint := range hints {
+			lines = append(lines, "- "+hint)
+		}
+	}
+
+	_, _ = fmt.Fprint(w, ascii.DrawBox(strings.Join(lines, "\n"), 0))
+}
+
+func init() {
+	doctorCmd.AddCommand(doctorConnectivityCmd)
+	doctorConnectivityCmd.AddCommand(doctorConnectivityCheckersCmd)
+
+	doctorConnectivityCheckersCmd.Flags().DurationVar(&doctorConnectivityCheckersTimeout, "timeout", 10*time.Second, "Timeout per step (e.g. 10s)")
+	doctorConnectivityCheckersCmd.Flags().StringSliceVar(&doctorConnectivityCheckersTargets, "target", nil, "Endpoint to probe (repeatable; default all)")
+	doctorConnectivityCheckersCmd.Flags().BoolVar(&doctorConnectivityCheckersQuiet, "quiet", false, "Exit code only")
+	doctorConnectivityCheckersCmd.Flags().StringVar(&doctorConnectivityCheckersOutputRaw, "output-format", string(output.FormatTable), "Output format: table|json")
+	doctorConnectivityCheckersCmd.Flags().StringVar(&doctorConnectivityCheckersOut, "out", "", "Write output to a file (default stdout)")
+	doctorConnectivityCheckersCmd.Flags().StringVar(&doctorConnectivityCheckersOutDir, "out-dir", "", "Write output to a directory")
+}
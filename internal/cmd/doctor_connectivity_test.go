@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectCheckerEndpoints(t *testing.T) {
+	all, err := selectCheckerEndpoints(nil)
+	require.NoError(t, err)
+	require.Equal(t, checkerEndpoints, all)
+
+	selected, err := selectCheckerEndpoints([]string{" PyPI ", "github"})
+	require.NoError(t, err)
+	require.Len(t, selected, 2)
+	require.Equal(t, "pypi", selected[0].Name)
+	require.Equal(t, "github", selected[1].Name)
+
+	_, err = selectCheckerEndpoints([]string{"gopher"})
+	require.ErrorContains(t, err, "valid: iana-bootstrap")
+}
+
+func TestRunHTTPReachCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/redirect":
+			http.Redirect(w, r, "https://rdap.verisign.com/com/v1/domain/example.com", http.StatusFound)
+		case "/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	check := runHTTPReachCheck(context.Background(), client, server.URL+"/redirect", time.Second)
+	require.True(t, check.OK)
+	require.Equal(t, http.StatusFound, check.Details["status_code"])
+	require.Equal(t, "https://rdap.verisign.com/com/v1/domain/example.com", check.Details["location"])
+
+	check = runHTTPReachCheck(context.Background(), client, server.URL+"/limited", time.Second)
+	require.False(t, check.OK)
+	summary := classifyConnectivity([]connectivityCheck{{Name: "dns", OK: true}, check}, &connectivityReport{}, "http")
+	require.Equal(t, "http", summary.FailureLayer)
+	require.Equal(t, "rate_limited", summary.Classification)
+
+	check = runHTTPReachCheck(context.Background(), client, server.URL+"/down", time.Second)
+	summary = classifyConnectivity([]connectivityCheck{check}, &connectivityReport{}, "http")
+	require.Equal(t, "provider_overloaded", summary.Classification)
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/redact"
+)
+
+var (
+	doctorExplainOutputRaw string
+	doctorExplainOut       string
+	doctorExplainOutDir    string
+	doctorExplainSources   []string
+	doctorExplainSet       []string
+)
+
+var doctorExplainCmd = &cobra.Command{
+	Use:   "explain [key-prefix...]",
+	Short: "Show the effective config and where each value came from",
+	Long: `Prints the fully merged config with the layer that set each value:
+
+  default  crucible defaults (namelens-defaults.yaml)
+  user     the user config file
+  env      a NAMELENS_* environment variable
+  flag     a runtime override (--set)
+
+Arguments filter by dotted key prefix. --set previews a runtime override on top
+of the other layers without changing any file. Credentials are redacted.`,
+	Example: `  namelens doctor explain domain.whois_fallback
+  namelens doctor explain --source user --source env
+  namelens doctor explain network --set network.ip_strategy=prefer_ipv4 --output-format json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(doctorExplainOutputRaw)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatJSON && format != output.FormatTable {
+			return fmt.Errorf("unsupported output format for explain: %s", format)
+		}
+
+		overrides, err := parseConfigOverrides(doctorExplainSet)
+		if err != nil {
+			return err
+		}
+		// Load installs the redactor for the configured secrets.
+		if _, err := config.Load(cmd.Context(), overrides...); err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		explanation, err := config.Explain(cmd.Context(), overrides...)
+		if err != nil {
+			return err
+		}
+		explanation.Values = filterExplainedValues(explanation.Values, args, doctorExplainSources)
+		for i := range explanation.Values {
+			explanation.Values[i].Value = redactExplainedValue(explanation.Values[i].Key, explanation.Values[i].Value)
+		}
+
+		outPath := strings.TrimSpace(doctorExplainOut)
+		outDir := strings.TrimSpace(doctorExplainOutDir)
+		if outPath != "" && outDir != "" {
+			return fmt.Errorf("--out and --out-dir are mutually exclusive")
+		}
+		if outDir != "" {
+			outDir, err = ensureOutDir(outDir)
+			if err != nil {
+				return err
+			}
+			outPath = filepath.Join(outDir, fmt.Sprintf("doctor.explain.%s", outputExtension(format)))
+		}
+		sink, err := openSink(outPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sink.close() }()
+
+		if format == output.FormatJSON {
+			payload, err := json.MarshalIndent(explanation, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(sink.writer, string(payload))
+			return err
+		}
+		return renderExplanation(sink.writer, explanation)
+	},
+}
+
+// parseConfigOverrides turns key=value pairs into a runtime override map.
+// Values are parsed as YAML, so true, 30s, and [io, dev] keep their types.
+func parseConfigOverrides(pairs []string) ([]map[string]any, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	overrides := map[string]any{}
+	for _, pair := range pairs {
+		key, raw, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set %q: expected key=value", pair)
+		}
+		var value any
+		if err := yaml.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("invalid --set %q: %w", pair, err)
+		}
+		if value == nil {
+			value = raw
+		}
+		parts := strings.Split(key, ".")
+		node := overrides
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = value
+	}
+	return []map[string]any{overrides}, nil
+}
+
+// filterExplainedValues keeps values under any of the key prefixes and from
+// any of the sources; empty filters keep everything.
+func filterExplainedValues(values []config.ExplainedValue, prefixes, sources []string) []config.ExplainedValue {
+	filtered := make([]config.ExplainedValue, 0, len(values))
+	for _, value := range values {
+		if len(prefixes) > 0 && !hasKeyPrefix(value.Key, prefixes) {
+			continue
+		}
+		if len(sources) > 0 && !containsFold(sources, value.Source) {
+			continue
+		}
+		filtered = append(filtered, value)
+	}
+	return filtered
+}
+
+func hasKeyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefix = strings.Trim(strings.TrimSpace(prefix), ".")
+		if key == prefix || strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), target) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactExplainedValue hides values under sensitive keys and scrubs known
+// secrets from the rest.
+func redactExplainedValue(key string, value any) any {
+	name := key[strings.LastIndex(key, ".")+1:]
+	if redact.IsSensitiveKey(name) {
+		if s, ok := value.(string); ok && s == "" {
+			return value
+		}
+		return redact.Placeholder
+	}
+	if s, ok := value.(string); ok {
+		return redact.String(s)
+	}
+	return redact.Value(value)
+}
+
+func renderExplanation(w io.Writer, explanation *config.Explanation) error {
+	user := explanation.UserFile
+	if user == "" {
+		user = "(none)"
+	}
+	_, _ = fmt.Fprintf(w, "defaults: %s\nuser:     %s\n\n", explanation.DefaultsFile, user)
+
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "KEY\tVALUE\tSOURCE\tORIGIN") // nolint:errcheck // tabwriter buffers; errors surface at Flush
+	for _, value := range explanation.Values {
+		origin := value.Origin
+		switch value.Source {
+		case config.SourceDefault, config.SourceUser:
+			// The file paths are printed above.
+			origin = ""
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", value.Key, formatExplainedValue(value.Value), value.Source, origin) // nolint:errcheck // tabwriter buffers
+	}
+	return writer.Flush()
+}
+
+// formatExplainedValue renders a value on one line, with lists and maps as
+// compact JSON.
+func formatExplainedValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "-"
+	case string:
+		if v == "" {
+			return `""`
+		}
+		return v
+	case []any, map[string]any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
+
+func init() {
+	doctorCmd.AddCommand(doctorExplainCmd)
+
+	doctorExplainCmd.Flags().StringVar(&doctorExplainOutputRaw, "output-format", string(output.FormatTable), "Output format: table|json")
+	doctorExplainCmd.Flags().StringVar(&doctorExplainOut, "out", "", "Write output to a file (default stdout)")
+	doctorExplainCmd.Flags().StringVar(&doctorExplainOutDir, "out-dir", "", "Write output to a directory")
+	doctorExplainCmd.Flags().StringSliceVar(&doctorExplainSources, "source", nil, "Only show values from a source: default|user|env|flag (repeatable)")
+	doctorExplainCmd.Flags().StringArrayVar(&doctorExplainSet, "set", nil, "Preview a runtime override as key=value (repeatable)")
+}
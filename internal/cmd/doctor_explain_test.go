@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/redact"
+)
+
+func TestParseConfigOverrides(t *testing.T) {
+	overrides, err := parseConfigOverrides([]string{
+		"domain.whois_fallback.enabled=true",
+		"domain.whois_fallback.tlds=[io, dev]",
+		"network.ip_strategy=prefer_ipv4",
+		"server.port=9000",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []map[string]any{{
+		"domain":  map[string]any{"whois_fallback": map[string]any{"enabled": true, "tlds": []any{"io", "dev"}}},
+		"network": map[string]any{"ip_strategy": "prefer_ipv4"},
+		"server":  map[string]any{"port": 9000},
+	}}, overrides)
+
+	_, err = parseConfigOverrides([]string{"enabled"})
+	require.Error(t, err)
+}
+
+func TestFilterExplainedValues(t *testing.T) {
+	values := []config.ExplainedValue{
+		{Key: "domain.whois_fallback.enabled", Source: config.SourceUser},
+		{Key: "domain.whois_fallback.timeout", Source: config.SourceDefault},
+		{Key: "domain.whois_fallbacks", Source: config.SourceEnv},
+		{Key: "server.port", Source: config.SourceEnv},
+	}
+
+	filtered := filterExplainedValues(values, []string{"domain.whois_fallback."}, nil)
+	require.Len(t, filtered, 2)
+
+	filtered = filterExplainedValues(values, nil, []string{"ENV"})
+	require.Equal(t, []config.ExplainedValue{values[2], values[3]}, filtered)
+}
+
+func TestRedactExplainedValue(t *testing.T) {
+	require.Equal(t, redact.Placeholder, redactExplainedValue("store.auth_token", "turso-token-value"))
+	require.Equal(t, "", redactExplainedValue("server.share.secret", ""))
+	require.Equal(t, 8080, redactExplainedValue("server.port", 8080))
+	require.Equal(t, "[]", formatExplainedValue([]any{}))
+	require.Equal(t, `["io","dev"]`, formatExplainedValue([]any{"io", "dev"}))
+}
@@ -0,0 +1,611 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/ascii"
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+	"github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+)
+
+// Self-test check statuses. A "warn" only fails the run with --strict.
+const (
+	selftestOK   = "ok"
+	selftestWarn = "warn"
+	selftestFail = "fail"
+	selftestSkip = "skip"
+)
+
+const defaultSelftestRDAPHost = "rdap.verisign.com"
+
+var (
+	doctorSelftestOutputRaw       string
+	doctorSelftestOut             string
+	doctorSelftestOutDir          string
+	doctorSelftestTimeout         time.Duration
+	doctorSelftestCanary          string
+	doctorSelftestBootstrapMaxAge time.Duration
+	doctorSelftestSkipAI          bool
+	doctorSelftestStrict          bool
+)
+
+type selftestReport struct {
+	Version   string          `json:"version,omitempty"`
+	Timestamp string          `json:"timestamp"`
+	Canary    string          `json:"canary"`
+	Checks    []selftestCheck `json:"checks"`
+	Summary   selftestSummary `json:"summary"`
+}
+
+type selftestCheck struct {
+	Name      string         `json:"name"`
+	Status    string         `json:"status"`
+	LatencyMS int64          `json:"latency_ms,omitempty"`
+	Message   string         `json:"message,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+type selftestSummary struct {
+	Status   string `json:"status"`
+	Failed   int    `json:"failed"`
+	Warnings int    `json:"warnings"`
+	Skipped  int    `json:"skipped"`
+}
+
+var doctorSelftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Run an end-to-end health check for monitoring",
+	Long: `Runs connectivity, bootstrap freshness, store integrity, a taken canary
+domain, an available control label, cache writes, rate limiter bookkeeping,
+output formatting, and an AI canary prompt, then prints a single JSON report.
+
+Exit codes are meant for cron and monitoring: 0 when every check passes
+(warnings allowed), and a health-check failure code when any check fails.
+Use --strict to treat warnings as failures.`,
+	Example: `  namelens doctor selftest
+  namelens doctor selftest --skip-ai --strict
+  namelens doctor selftest --output-format table`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		format, err := output.ParseFormat(doctorSelftestOutputRaw)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatJSON && format != output.FormatTable {
+			return fmt.Errorf("unsupported output format for selftest: %s", format)
+		}
+
+		canaryName, canaryTLD, err := splitCanaryDomain(doctorSelftestCanary)
+		if err != nil {
+			return err
+		}
+
+		outPath := strings.TrimSpace(doctorSelftestOut)
+		outDir := strings.TrimSpace(doctorSelftestOutDir)
+		if outPath != "" && outDir != "" {
+			return fmt.Errorf("--out and --out-dir are mutually exclusive")
+		}
+		if outDir != "" {
+			outDir, err = ensureOutDir(outDir)
+			if err != nil {
+				return err
+			}
+			outPath = filepath.Join(outDir, fmt.Sprintf("doctor.selftest.%s", outputExtension(format)))
+		}
+
+		report := runSelftest(cmd.Context(), cfg, canaryName, canaryTLD)
+
+		sink, err := openSink(outPath)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = sink.close() }()
+
+		if format == output.FormatJSON {
+			payload, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(sink.writer, string(payload)); err != nil {
+				return err
+			}
+		} else {
+			renderSelftestReportTable(sink.writer, report)
+		}
+
+		if selftestFailed(report.Summary, doctorSelftestStrict) {
+			_ = sink.close()
+			ExitWithCode(observability.CLILogger, foundry.ExitHealthCheckFailed, "Selftest failed",
+				fmt.Errorf("%d failed, %d warnings", report.Summary.Failed, report.Summary.Warnings))
+		}
+		return nil
+	},
+}
+
+func init() {
+	doctorCmd.AddCommand(doctorSelftestCmd)
+
+	doctorSelftestCmd.Flags().StringVar(&doctorSelftestOutputRaw, "output-format", "json", "output format: json|table")
+	doctorSelftestCmd.Flags().StringVar(&doctorSelftestOut, "out", "", "write output to file (default stdout)")
+	doctorSelftestCmd.Flags().StringVar(&doctorSelftestOutDir, "out-dir", "", "write output to directory (auto-named)")
+	doctorSelftestCmd.Flags().DurationVar(&doctorSelftestTimeout, "timeout", 30*time.Second, "timeout per check")
+	doctorSelftestCmd.Flags().StringVar(&doctorSelftestCanary, "canary", "example.com", "domain expected to be registered")
+	doctorSelftestCmd.Flags().DurationVar(&doctorSelftestBootstrapMaxAge, "bootstrap-max-age", 0, "warn when bootstrap data is older than this (default: bootstrap.max_age)")
+	doctorSelftestCmd.Flags().BoolVar(&doctorSelftestSkipAI, "skip-ai", false, "skip the AI canary prompt")
+	doctorSelftestCmd.Flags().BoolVar(&doctorSelftestStrict, "strict", false, "treat warnings as failures")
+}
+
+func runSelftest(ctx context.Context, cfg *config.Config, canaryName, canaryTLD string) *selftestReport {
+	timeout := doctorSelftestTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	report := &selftestReport{
+		Version:   versionInfo.Version,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Canary:    canaryName + "." + canaryTLD,
+	}
+
+	st, storeErr := openStore(ctx)
+	if storeErr == nil {
+		defer st.Close() //nolint:errcheck
+	}
+
+	report.Checks = append(report.Checks, timedSelftestCheck("connectivity", func() selftestCheck {
+		return selftestConnectivity(ctx, st, canaryTLD, timeout)
+	}))
+
+	report.Checks = append(report.Checks, timedSelftestCheck("bootstrap", func() selftestCheck {
+		if storeErr != nil {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("open store: %v", storeErr)}
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		status, err := (&checker.BootstrapService{Store: st}).Status(checkCtx)
+		maxAge := doctorSelftestBootstrapMaxAge
+		if maxAge <= 0 {
+			maxAge = cfg.Bootstrap.MaxAge
+		}
+		return evaluateBootstrapSelftest(status, err, maxAge, time.Now())
+	}))
+
+	report.Checks = append(report.Checks, timedSelftestCheck("store", func() selftestCheck {
+		if storeErr != nil {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("open store: %v", storeErr)}
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if err := st.IntegrityCheck(checkCtx); err != nil {
+			return selftestCheck{Status: selftestFail, Message: err.Error()}
+		}
+		return selftestCheck{Status: selftestOK}
+	}))
+
+	// Rate limit state for the canary's RDAP server, read before the lookups
+	// so the rate_limiter check can confirm they were counted.
+	rdapEndpoint := ""
+	var limitBefore *core.RateLimitState
+	if storeErr == nil {
+		rdapEndpoint = selftestRDAPEndpoint(ctx, st, canaryTLD)
+		if rdapEndpoint != "" {
+			limitBefore, _ = st.GetRateLimit(ctx, rdapEndpoint)
+		}
+	}
+
+	var canaryResults, controlResults []*core.CheckResult
+	report.Checks = append(report.Checks, timedSelftestCheck("canary", func() selftestCheck {
+		if storeErr != nil {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("open store: %v", storeErr)}
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		orchestrator := buildOrchestrator(cfg, st, false)
+		// The canary is a probe, not a user lookup; keep it out of history.
+		orchestrator.History = nil
+		results, err := orchestrator.Check(checkCtx, canaryName, core.Profile{TLDs: []string{canaryTLD}})
+		if err != nil {
+			return selftestCheck{Status: selftestFail, Message: err.Error()}
+		}
+		canaryResults = results
+		return evaluateCanarySelftest(results)
+	}))
+
+	controlName := selftestControlLabel()
+	report.Checks = append(report.Checks, timedSelftestCheck("control", func() selftestCheck {
+		if storeErr != nil {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("open store: %v", storeErr)}
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		// A random label is never cached, so this is a fresh lookup whose
+		// result the cache check then expects to find stored.
+		orchestrator := buildOrchestrator(cfg, st, true)
+		orchestrator.History = nil
+		results, err := orchestrator.Check(checkCtx, controlName, core.Profile{TLDs: []string{canaryTLD}})
+		if err != nil {
+			return selftestCheck{Status: selftestFail, Message: err.Error()}
+		}
+		controlResults = results
+		return evaluateControlSelftest(results)
+	}))
+
+	report.Checks = append(report.Checks, timedSelftestCheck("cache", func() selftestCheck {
+		if storeErr != nil {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("open store: %v", storeErr)}
+		}
+		if controlResults == nil {
+			return selftestCheck{Status: selftestSkip, Message: "control lookup failed"}
+		}
+		if cfg.Cache.AvailableTTL <= 0 {
+			return selftestCheck{Status: selftestSkip, Message: "cache.available_ttl is 0"}
+		}
+		cached, err := st.GetCachedResult(ctx, controlName, core.CheckTypeDomain, canaryTLD)
+		return evaluateCacheSelftest(controlResults, cached, err)
+	}))
+
+	report.Checks = append(report.Checks, timedSelftestCheck("rate_limiter", func() selftestCheck {
+		switch {
+		case storeErr != nil:
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("open store: %v", storeErr)}
+		case configuredRateLimitBackend(cfg) != nil:
+			return selftestCheck{Status: selftestSkip, Message: "state kept in rate_limiter.backend"}
+		case rdapEndpoint == "":
+			return selftestCheck{Status: selftestSkip, Message: "no RDAP server for ." + canaryTLD}
+		case !selftestUsedRDAP(canaryResults) && !selftestUsedRDAP(controlResults):
+			return selftestCheck{Status: selftestSkip, Message: "lookups did not use RDAP"}
+		}
+		after, err := st.GetRateLimit(ctx, rdapEndpoint)
+		if err != nil {
+			return selftestCheck{Status: selftestFail, Message: err.Error()}
+		}
+		check := evaluateRateLimitSelftest(limitBefore, after)
+		if check.Details == nil {
+			check.Details = map[string]any{}
+		}
+		check.Details["endpoint"] = rdapEndpoint
+		return check
+	}))
+
+	report.Checks = append(report.Checks, timedSelftestCheck("output", func() selftestCheck {
+		results := append(append([]*core.CheckResult{}, canaryResults...), controlResults...)
+		if len(results) == 0 {
+			return selftestCheck{Status: selftestSkip, Message: "no results to render"}
+		}
+		return evaluateOutputSelftest(summarizeResults(canaryName, results, nil, nil, nil, nil, nil, nil))
+	}))
+
+	report.Checks = append(report.Checks, timedSelftestCheck("ai", func() selftestCheck {
+		if doctorSelftestSkipAI {
+			return selftestCheck{Status: selftestSkip, Message: "skipped (--skip-ai)"}
+		}
+		if !isAIBackendConfigured(cfg.AILink) {
+			return selftestCheck{Status: selftestSkip, Message: "AI backend not configured"}
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		resp, searchErr := runExpert(checkCtx, cfg, nil, canaryName, "quick", "", "", false)
+		if searchErr != nil {
+			return selftestCheck{Status: selftestFail, Message: searchErr.Message, Details: map[string]any{"code": searchErr.Code}}
+		}
+		if resp == nil {
+			return selftestCheck{Status: selftestFail, Message: "empty AI response"}
+		}
+		return selftestCheck{Status: selftestOK}
+	}))
+
+	report.Summary = summarizeSelftest(report.Checks)
+	return report
+}
+
+// timedSelftestCheck runs fn, stamping the check name and its latency.
+func timedSelftestCheck(name string, fn func() selftestCheck) selftestCheck {
+	start := time.Now()
+	check := fn()
+	check.Name = name
+	if check.Status != selftestSkip {
+		check.LatencyMS = time.Since(start).Milliseconds()
+	}
+	return check
+}
+
+// selftestConnectivity resolves and dials the RDAP server for the canary TLD,
+// falling back to the .com registry when bootstrap data is unavailable.
+func selftestConnectivity(ctx context.Context, st *store.Store, tld string, timeout time.Duration) selftestCheck {
+	host, port := defaultSelftestRDAPHost, 443
+	if st != nil {
+		if servers, err := st.GetRDAPServers(ctx, tld); err == nil && len(servers) > 0 {
+			if u, err := url.Parse(servers[0]); err == nil && u.Hostname() != "" {
+				host = u.Hostname()
+				if p, err := strconv.Atoi(u.Port()); err == nil {
+					port = p
+				} else if u.Scheme == "http" {
+					port = 80
+				}
+			}
+		}
+	}
+
+	details := map[string]any{"host": host, "port": port}
+	dnsCheck := runDNSCheck(ctx, host, timeout)
+	if !dnsCheck.OK {
+		return selftestCheck{Status: selftestFail, Message: dnsCheck.Error.Message, Details: details}
+	}
+	tcpCheck, conn := runTCPCheck(ctx, host, port, timeout)
+	if conn != nil {
+		_ = conn.Close()
+	}
+	if !tcpCheck.OK {
+		return selftestCheck{Status: selftestFail, Message: tcpCheck.Error.Message, Details: details}
+	}
+	details["remote_addr"] = tcpCheck.Details["remote_addr"]
+	return selftestCheck{Status: selftestOK, Details: details}
+}
+
+// evaluateBootstrapSelftest fails on missing bootstrap data and warns when it
+// is older than maxAge.
+func evaluateBootstrapSelftest(status *checker.BootstrapStatus, err error, maxAge time.Duration, now time.Time) selftestCheck {
+	if err != nil {
+		return selftestCheck{Status: selftestFail, Message: err.Error()}
+	}
+	if status == nil || status.TLDCount == 0 {
+		return selftestCheck{Status: selftestFail, Message: "bootstrap data missing (run 'namelens bootstrap update')"}
+	}
+
+	details := map[string]any{"tld_count": status.TLDCount}
+	if status.FetchedAt.IsZero() {
+		return selftestCheck{Status: selftestWarn, Message: "bootstrap fetch time unknown", Details: details}
+	}
+	age := now.Sub(status.FetchedAt)
+	details["fetched_at"] = status.FetchedAt.UTC().Format(time.RFC3339)
+	details["age_hours"] = int64(age.Hours())
+	if maxAge > 0 && age > maxAge {
+		return selftestCheck{
+			Status:  selftestWarn,
+			Message: fmt.Sprintf("bootstrap data fetched %s (run 'namelens bootstrap update')", formatTimeAgo(status.FetchedAt)),
+			Details: details,
+		}
+	}
+	return selftestCheck{Status: selftestOK, Details: details}
+}
+
+// evaluateCanarySelftest expects the canary domain to come back taken.
+func evaluateCanarySelftest(results []*core.CheckResult) selftestCheck {
+	for _, result := range results {
+		if result == nil || result.CheckType != core.CheckTypeDomain {
+			continue
+		}
+		details := map[string]any{
+			"available": result.Available.String(),
+			"source":    result.Provenance.Source,
+		}
+		if result.Available == core.AvailabilityTaken {
+			return selftestCheck{Status: selftestOK, Details: details}
+		}
+		msg := fmt.Sprintf("expected taken, got %s", result.Available.String())
+		if result.Message != "" {
+			msg += ": " + result.Message
+		}
+		return selftestCheck{Status: selftestFail, Message: msg, Details: details}
+	}
+	return selftestCheck{Status: selftestFail, Message: "no domain result for canary"}
+}
+
+// selftestControlLabel returns a random label that no one has registered.
+func selftestControlLabel() string {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return "namelens-selftest-" + hex.EncodeToString(buf)
+}
+
+// selftestRDAPEndpoint returns the rate limit key of the RDAP server for tld.
+func selftestRDAPEndpoint(ctx context.Context, st *store.Store, tld string) string {
+	servers, err := st.GetRDAPServers(ctx, tld)
+	if err != nil || len(servers) == 0 {
+		return ""
+	}
+	u, err := url.Parse(servers[0])
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+func selftestUsedRDAP(results []*core.CheckResult) bool {
+	for _, result := range results {
+		if result != nil && result.CheckType == core.CheckTypeDomain && result.Provenance.Source == "rdap" {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateControlSelftest expects the random control label to come back
+// available. An unknown answer warns rather than fails, since registries
+// that only answer through WHOIS or rate limits can leave it undecided.
+func evaluateControlSelftest(results []*core.CheckResult) selftestCheck {
+	for _, result := range results {
+		if result == nil || result.CheckType != core.CheckTypeDomain {
+			continue
+		}
+		details := map[string]any{
+			"domain":    result.Name,
+			"available": result.Available.String(),
+			"source":    result.Provenance.Source,
+		}
+		switch result.Available {
+		case core.AvailabilityAvailable:
+			return selftestCheck{Status: selftestOK, Details: details}
+		case core.AvailabilityTaken:
+			return selftestCheck{Status: selftestFail, Message: "expected available, got taken", Details: details}
+		}
+		msg := fmt.Sprintf("expected available, got %s", result.Available.String())
+		if result.Message != "" {
+			msg += ": " + result.Message
+		}
+		return selftestCheck{Status: selftestWarn, Message: msg, Details: details}
+	}
+	return selftestCheck{Status: selftestFail, Message: "no domain result for control"}
+}
+
+// evaluateCacheSelftest expects the control lookup's domain result to have
+// been written to the cache.
+func evaluateCacheSelftest(results []*core.CheckResult, cached *core.CheckResult, err error) selftestCheck {
+	if err != nil {
+		return selftestCheck{Status: selftestFail, Message: err.Error()}
+	}
+	for _, result := range results {
+		if result == nil || result.CheckType != core.CheckTypeDomain {
+			continue
+		}
+		if result.Available == core.AvailabilityError || result.Available == core.AvailabilityRateLimited {
+			return selftestCheck{Status: selftestSkip, Message: "control result is not cacheable"}
+		}
+		if cached == nil {
+			return selftestCheck{Status: selftestFail, Message: "control result was not cached"}
+		}
+		if cached.Available != result.Available {
+			return selftestCheck{
+				Status:  selftestFail,
+				Message: fmt.Sprintf("cached %s, looked up %s", cached.Available.String(), result.Available.String()),
+			}
+		}
+		return selftestCheck{Status: selftestOK}
+	}
+	return selftestCheck{Status: selftestSkip, Message: "no domain result for control"}
+}
+
+// evaluateRateLimitSelftest expects the lookups to have been counted: a
+// higher request count, or a window that started after the one before.
+func evaluateRateLimitSelftest(before, after *core.RateLimitState) selftestCheck {
+	if after == nil {
+		return selftestCheck{Status: selftestFail, Message: "no rate limit state recorded"}
+	}
+	details := map[string]any{"request_count": after.RequestCount}
+	if before == nil || after.RequestCount > before.RequestCount || after.WindowStart.After(before.WindowStart) {
+		return selftestCheck{Status: selftestOK, Details: details}
+	}
+	return selftestCheck{Status: selftestFail, Message: "lookups were not counted against the rate limit", Details: details}
+}
+
+// selftestOutputFormats are rendered by the output check.
+var selftestOutputFormats = []output.Format{
+	output.FormatTable, output.FormatJSON, output.FormatMarkdown, output.FormatCSV, output.FormatHTML, output.FormatJUnit,
+}
+
+// evaluateOutputSelftest renders batch in every output format, expecting
+// non-empty output and JSON that decodes back to the same results.
+func evaluateOutputSelftest(batch *core.BatchResult) selftestCheck {
+	for _, format := range selftestOutputFormats {
+		rendered, err := output.NewFormatter(format).FormatBatch(batch)
+		if err != nil {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("%s: %v", format, err)}
+		}
+		if strings.TrimSpace(rendered) == "" {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("%s: empty output", format)}
+		}
+		if format != output.FormatJSON {
+			continue
+		}
+		var decoded core.BatchResult
+		if err := json.Unmarshal([]byte(rendered), &decoded); err != nil {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("%s: %v", format, err)}
+		}
+		if len(decoded.Results) != len(batch.Results) {
+			return selftestCheck{Status: selftestFail, Message: fmt.Sprintf("%s: %d of %d results", format, len(decoded.Results), len(batch.Results))}
+		}
+	}
+	return selftestCheck{Status: selftestOK, Details: map[string]any{"formats": len(selftestOutputFormats)}}
+}
+
+func summarizeSelftest(checks []selftestCheck) selftestSummary {
+	summary := selftestSummary{Status: selftestOK}
+	for _, check := range checks {
+		switch check.Status {
+		case selftestFail:
+			summary.Failed++
+		case selftestWarn:
+			summary.Warnings++
+		case selftestSkip:
+			summary.Skipped++
+		}
+	}
+	switch {
+	case summary.Failed > 0:
+		summary.Status = selftestFail
+	case summary.Warnings > 0:
+		summary.Status = selftestWarn
+	}
+	return summary
+}
+
+func selftestFailed(summary selftestSummary, strict bool) bool {
+	return summary.Failed > 0 || (strict && summary.Warnings > 0)
+}
+
+func splitCanaryDomain(domain string) (string, string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domain), "."))
+	idx := strings.Index(domain, ".")
+	if idx <= 0 || idx == len(domain)-1 {
+		return "", "", fmt.Errorf("invalid --canary %q: expected a domain like example.com", domain)
+	}
+	return domain[:idx], domain[idx+1:], nil
+}
+
+func renderSelftestReportTable(w io.Writer, report *selftestReport) {
+	if w == nil || report == nil {
+		return
+	}
+	renderCheckBox(w, "Selftest", report.Summary.Status, report.Checks)
+}
+
+// renderCheckBox draws checks as one line each under a title and status.
+func renderCheckBox(w io.Writer, title, status string, checks []selftestCheck) {
+	lines := []string{
+		fmt.Sprintf("%s (%s)", title, strings.ToUpper(status)),
+		"",
+	}
+	for _, check := range checks {
+		symbol := "✅"
+		switch check.Status {
+		case selftestFail:
+			symbol = "❌"
+		case selftestWarn:
+			symbol = "⚠️"
+		case selftestSkip:
+			symbol = "–"
+		}
+		msg := check.Status
+		if check.Message != "" {
+			msg = check.Message
+		}
+		suffix := ""
+		if check.LatencyMS > 0 {
+			suffix = fmt.Sprintf(" (%dms)", check.LatencyMS)
+		}
+		lines = append(lines, fmt.Sprintf("%-13s %s %s%s", check.Name+":", symbol, msg, suffix))
+	}
+
+	_, _ = fmt.Fprint(w, ascii.DrawBox(strings.Join(lines, "\n"), 0))
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+)
+
+func TestEvaluateBootstrapSelftest(t *testing.T) {
+	now := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	maxAge := 7 * 24 * time.Hour
+
+	check := evaluateBootstrapSelftest(nil, errors.New("boom"), maxAge, now)
+	require.Equal(t, selftestFail, check.Status)
+
+	check = evaluateBootstrapSelftest(&checker.BootstrapStatus{}, nil, maxAge, now)
+	require.Equal(t, selftestFail, check.Status)
+
+	fresh := &checker.BootstrapStatus{TLDCount: 1200, FetchedAt: now.Add(-24 * time.Hour)}
+	check = evaluateBootstrapSelftest(fresh, nil, maxAge, now)
+	require.Equal(t, selftestOK, check.Status)
+	require.Equal(t, 1200, check.Details["tld_count"])
+
+	stale := &checker.BootstrapStatus{TLDCount: 1200, FetchedAt: now.Add(-30 * 24 * time.Hour)}
+	check = evaluateBootstrapSelftest(stale, nil, maxAge, now)
+	require.Equal(t, selftestWarn, check.Status)
+}
+
+func TestEvaluateCanarySelftest(t *testing.T) {
+	taken := []*core.CheckResult{{Name: "example.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}}
+	require.Equal(t, selftestOK, evaluateCanarySelftest(taken).Status)
+
+	available := []*core.CheckResult{{Name: "example.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable}}
+	check := evaluateCanarySelftest(available)
+	require.Equal(t, selftestFail, check.Status)
+	require.Contains(t, check.Message, "expected taken")
+
+	require.Equal(t, selftestFail, evaluateCanarySelftest(nil).Status)
+}
+
+func TestSummarizeSelftest(t *testing.T) {
+	summary := summarizeSelftest([]selftestCheck{
+		{Name: "connectivity", Status: selftestOK},
+		{Name: "bootstrap", Status: selftestWarn},
+		{Name: "ai", Status: selftestSkip},
+	})
+	require.Equal(t, selftestWarn, summary.Status)
+	require.Equal(t, 1, summary.Warnings)
+	require.Equal(t, 1, summary.Skipped)
+	require.False(t, selftestFailed(summary, false))
+	require.True(t, selftestFailed(summary, true))
+
+	summary = summarizeSelftest([]selftestCheck{{Status: selftestWarn}, {Status: selftestFail}})
+	require.Equal(t, selftestFail, summary.Status)
+	require.True(t, selftestFailed(summary, false))
+}
+
+func TestSplitCanaryDomain(t *testing.T) {
+	name, tld, err := splitCanaryDomain(" Example.COM. ")
+	require.NoError(t, err)
+	require.Equal(t, "example", name)
+	require.Equal(t, "com", tld)
+
+	name, tld, err = splitCanaryDomain("example.co.uk")
+	require.NoError(t, err)
+	require.Equal(t, "example", name)
+	require.Equal(t, "co.uk", tld)
+
+	for _, bad := range []string{"", "example", ".com", "example."} {
+		_, _, err := splitCanaryDomain(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestEvaluateControlSelftest(t *testing.T) {
+	result := func(availability core.Availability) []*core.CheckResult {
+		return []*core.CheckResult{{Name: "namelens-selftest-0011.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: availability}}
+	}
+	require.Equal(t, selftestOK, evaluateControlSelftest(result(core.AvailabilityAvailable)).Status)
+	require.Equal(t, selftestFail, evaluateControlSelftest(result(core.AvailabilityTaken)).Status)
+	require.Equal(t, selftestWarn, evaluateControlSelftest(result(core.AvailabilityRateLimited)).Status)
+	require.Equal(t, selftestFail, evaluateControlSelftest(nil).Status)
+
+	label := selftestControlLabel()
+	require.Regexp(t, `^namelens-selftest-[0-9a-f]{16}$`, label)
+	require.NotEqual(t, label, selftestControlLabel())
+}
+
+func TestEvaluateCacheSelftest(t *testing.T) {
+	available := []*core.CheckResult{{CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable}}
+	require.Equal(t, selftestOK, evaluateCacheSelftest(available, &core.CheckResult{Available: core.AvailabilityAvailable}, nil).Status)
+	require.Equal(t, selftestFail, evaluateCacheSelftest(available, nil, nil).Status)
+	require.Equal(t, selftestFail, evaluateCacheSelftest(available, &core.CheckResult{Available: core.AvailabilityTaken}, nil).Status)
+	require.Equal(t, selftestFail, evaluateCacheSelftest(available, nil, errors.New("locked")).Status)
+
+	failed := []*core.CheckResult{{CheckType: core.CheckTypeDomain, Available: core.AvailabilityError}}
+	require.Equal(t, selftestSkip, evaluateCacheSelftest(failed, nil, nil).Status)
+}
+
+func TestEvaluateRateLimitSelftest(t *testing.T) {
+	window := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	before := &core.RateLimitState{RequestCount: 4, WindowStart: window}
+
+	require.Equal(t, selftestOK, evaluateRateLimitSelftest(nil, &core.RateLimitState{RequestCount: 2, WindowStart: window}).Status)
+	require.Equal(t, selftestOK, evaluateRateLimitSelftest(before, &core.RateLimitState{RequestCount: 6, WindowStart: window}).Status)
+	require.Equal(t, selftestOK, evaluateRateLimitSelftest(before, &core.RateLimitState{RequestCount: 1, WindowStart: window.Add(time.Minute)}).Status)
+	require.Equal(t, selftestFail, evaluateRateLimitSelftest(before, &core.RateLimitState{RequestCount: 4, WindowStart: window}).Status)
+	require.Equal(t, selftestFail, evaluateRateLimitSelftest(before, nil).Status)
+}
+
+func TestEvaluateOutputSelftest(t *testing.T) {
+	batch := summarizeResults("example", []*core.CheckResult{
+		{Name: "example.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+		{Name: "namelens-selftest-0011.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable},
+	}, nil, nil, nil, nil, nil, nil)
+
+	check := evaluateOutputSelftest(batch)
+	require.Equal(t, selftestOK, check.Status, check.Message)
+	require.Equal(t, len(selftestOutputFormats), check.Details["formats"])
+}
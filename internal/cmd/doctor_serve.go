@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fulmenhq/gofulmen/foundry"
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+)
+
+// defaultShutdownTimeout mirrors the fallback serve uses when
+// server.shutdown_timeout is unset.
+const defaultShutdownTimeout = 10 * time.Second
+
+var (
+	doctorServeOutputRaw string
+	doctorServeHost      string
+	doctorServeStrict    bool
+)
+
+type serveReadinessReport struct {
+	Checks  []selftestCheck `json:"checks"`
+	Summary selftestSummary `json:"summary"`
+}
+
+var doctorServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Check serve settings for running in a container or Kubernetes",
+	Long: `Reviews the settings that matter when 'namelens serve' runs as a container,
+for example as a Kubernetes Deployment:
+
+  host         the listen address must not be localhost for probes and Services
+  shutdown     the SIGTERM drain window and the terminationGracePeriodSeconds to match
+  timeouts     server.write_timeout against ailink.default_timeout
+  probes       the liveness, readiness, and startup probe paths
+  credentials  provider api_key_file paths (e.g. mounted Secrets) are readable
+  api_key      a control plane API key is set when the server is exposed
+
+Pass the same --host you give serve. Exits with a health-check failure code
+when a check fails; use --strict to fail on warnings too.`,
+	Example: `  namelens doctor serve --host 0.0.0.0
+  namelens doctor serve --host 0.0.0.0 --output-format json --strict`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		format, err := output.ParseFormat(doctorServeOutputRaw)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatJSON && format != output.FormatTable {
+			return fmt.Errorf("unsupported output format for doctor serve: %s", format)
+		}
+
+		checks := serveReadinessChecks(cfg, doctorServeHost, os.Getenv("NAMELENS_CONTROL_PLANE_API_KEY"))
+		report := serveReadinessReport{Checks: checks, Summary: summarizeSelftest(checks)}
+
+		out := cmd.OutOrStdout()
+		if format == output.FormatJSON {
+			payload, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintln(out, string(payload)); err != nil {
+				return err
+			}
+		} else {
+			renderCheckBox(out, "Serve readiness", report.Summary.Status, report.Checks)
+		}
+
+		if selftestFailed(report.Summary, doctorServeStrict) {
+			ExitWithCode(observability.CLILogger, foundry.ExitHealthCheckFailed, "Serve readiness check failed",
+				fmt.Errorf("%d failed, %d warnings", report.Summary.Failed, report.Summary.Warnings))
+		}
+		return nil
+	},
+}
+
+func init() {
+	doctorCmd.AddCommand(doctorServeCmd)
+
+	doctorServeCmd.Flags().StringVar(&doctorServeOutputRaw, "output-format", "table", "output format: table|json")
+	doctorServeCmd.Flags().StringVar(&doctorServeHost, "host", "localhost", "host serve will listen on")
+	doctorServeCmd.Flags().BoolVar(&doctorServeStrict, "strict", false, "treat warnings as failures")
+}
+
+// serveReadinessChecks evaluates cfg for serve running on host.
+// controlPlaneKey is the NAMELENS_CONTROL_PLANE_API_KEY value.
+func serveReadinessChecks(cfg *config.Config, host, controlPlaneKey string) []selftestCheck {
+	host = strings.TrimSpace(host)
+	exposed := !isLoopbackHost(host)
+
+	checks := []selftestCheck{serveHostCheck(host, exposed)}
+	checks = append(checks, serveShutdownCheck(cfg.Server.ShutdownTimeout))
+	checks = append(checks, serveTimeoutsCheck(cfg.Server.WriteTimeout, cfg.AILink.DefaultTimeout))
+	probes := selftestCheck{
+		Name:    "probes",
+		Status:  selftestOK,
+		Message: "liveness /health/live, readiness /health/ready, startup /health/startup",
+	}
+	if cfg.Health.RequireWarmup {
+		probes.Message += "; readiness waits for bootstrap data and a warm-up check"
+	}
+	checks = append(checks, probes)
+	checks = append(checks, serveCredentialsCheck(cfg))
+
+	apiKey := selftestCheck{Name: "api_key", Status: selftestOK}
+	switch {
+	case strings.TrimSpace(controlPlaneKey) != "" || len(cfg.Server.APIKeys) > 0:
+		apiKey.Message = "control plane API key configured"
+	case exposed:
+		apiKey.Status = selftestWarn
+		apiKey.Message = "no control plane API key; set NAMELENS_CONTROL_PLANE_API_KEY or server.api_keys from a Secret"
+	default:
+		apiKey.Message = "not set (localhost requests need none)"
+	}
+	return append(checks, apiKey)
+}
+
+func isLoopbackHost(host string) bool {
+	switch host {
+	case "", "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+func serveHostCheck(host string, exposed bool) selftestCheck {
+	check := selftestCheck{Name: "host", Status: selftestOK, Details: map[string]any{"host": host}}
+	if !exposed {
+		check.Status = selftestWarn
+		check.Message = "listening on localhost; probes and Service traffic cannot reach the pod (use --host 0.0.0.0)"
+		return check
+	}
+	check.Message = "listening on " + host
+	return check
+}
+
+// serveShutdownCheck reports the drain window after SIGTERM and a grace
+// period that leaves a few seconds for logs and the gRPC server to stop.
+func serveShutdownCheck(timeout time.Duration) selftestCheck {
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	grace := int64(math.Ceil((timeout + 5*time.Second).Seconds()))
+	return selftestCheck{
+		Name:    "shutdown",
+		Status:  selftestOK,
+		Message: fmt.Sprintf("drains for %s after SIGTERM; set terminationGracePeriodSeconds to at least %d", timeout, grace),
+		Details: map[string]any{
+			"shutdown_timeout":                 timeout.String(),
+			"termination_grace_period_seconds": grace,
+		},
+	}
+}
+
+func serveTimeoutsCheck(writeTimeout, aiTimeout time.Duration) selftestCheck {
+	check := selftestCheck{
+		Name:   "timeouts",
+		Status: selftestOK,
+		Details: map[string]any{
+			"write_timeout":          writeTimeout.String(),
+			"ailink_default_timeout": aiTimeout.String(),
+		},
+	}
+	if writeTimeout > 0 && aiTimeout > writeTimeout {
+		check.Status = selftestWarn
+		check.Message = fmt.Sprintf("server.write_timeout (%s) is shorter than ailink.default_timeout (%s); AI analyses may be cut off", writeTimeout, aiTimeout)
+	}
+	return check
+}
+
+// serveCredentialsCheck reads each api_key_file of the enabled providers,
+// failing on unreadable files and warning on empty ones.
+func serveCredentialsCheck(cfg *config.Config) selftestCheck {
+	check := selftestCheck{Name: "credentials", Status: selftestOK}
+
+	ids := make([]string, 0, len(cfg.AILink.Providers))
+	for id := range cfg.AILink.Providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	details := map[string]any{}
+	var problems []string
+	for _, id := range ids {
+		provider := cfg.AILink.Providers[id]
+		if !provider.Enabled {
+			continue
+		}
+		for i, cred := range provider.Credentials {
+			if !cred.Enabled && strings.TrimSpace(cred.Label) != "" {
+				continue
+			}
+			label := strings.TrimSpace(cred.Label)
+			if label == "" {
+				label = fmt.Sprintf("%d", i)
+			}
+			name := id + "/" + label
+			if strings.TrimSpace(cred.APIKey) != "" {
+				details[name] = "inline api_key"
+				continue
+			}
+			if strings.TrimSpace(cred.APIKeyFile) == "" {
+				if loaded, err := cred.ResolveKey(id); err == nil && loaded.APIKey != "" {
+					details[name] = fmt.Sprintf("keychain (%s)", maskKey(loaded.APIKey))
+				}
+				continue
+			}
+			loaded, err := cred.LoadKey()
+			switch {
+			case err != nil:
+				check.Status = selftestFail
+				problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			case loaded.APIKey == "":
+				if check.Status == selftestOK {
+					check.Status = selftestWarn
+				}
+				problems = append(problems, fmt.Sprintf("%s: %s is empty", name, cred.APIKeyFile))
+			default:
+				details[name] = fmt.Sprintf("%s (%s)", cred.APIKeyFile, maskKey(loaded.APIKey))
+			}
+		}
+	}
+
+	if len(details) == 0 && len(problems) == 0 {
+		check.Status = selftestSkip
+		check.Message = "no AI provider keys configured"
+		return check
+	}
+	if len(details) > 0 {
+		check.Details = details
+	}
+	if len(problems) > 0 {
+		check.Message = strings.Join(problems, "; ")
+	}
+	return check
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+)
+
+func TestServeReadinessChecks(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Server.WriteTimeout = 30 * time.Second
+	cfg.AILink.DefaultTimeout = 60 * time.Second
+
+	checks := serveReadinessChecks(cfg, "localhost", "")
+	byName := map[string]selftestCheck{}
+	for _, check := range checks {
+		byName[check.Name] = check
+	}
+	require.Equal(t, selftestWarn, byName["host"].Status)
+	require.Equal(t, selftestWarn, byName["timeouts"].Status)
+	require.Equal(t, selftestOK, byName["api_key"].Status)
+	require.Equal(t, selftestSkip, byName["credentials"].Status)
+	require.Equal(t, int64(15), byName["shutdown"].Details["termination_grace_period_seconds"])
+
+	checks = serveReadinessChecks(cfg, "0.0.0.0", "")
+	for _, check := range checks {
+		if check.Name == "api_key" {
+			require.Equal(t, selftestWarn, check.Status)
+		}
+	}
+}
+
+func TestServeCredentialsCheckReadsKeyFiles(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "xai")
+	require.NoError(t, os.WriteFile(good, []byte("xai-1234567890\n"), 0o600))
+
+	cfg := &config.Config{}
+	cfg.AILink.Providers = map[string]ailink.ProviderInstanceConfig{
+		"xai": {Enabled: true, Credentials: []ailink.CredentialConfig{{Enabled: true, Label: "default", APIKeyFile: good}}},
+	}
+	check := serveCredentialsCheck(cfg)
+	require.Equal(t, selftestOK, check.Status)
+	require.Equal(t, good+" (xai-…890)", check.Details["xai/default"])
+
+	cfg.AILink.Providers["openai"] = ailink.ProviderInstanceConfig{
+		Enabled:     true,
+		Credentials: []ailink.CredentialConfig{{Enabled: true, APIKeyFile: filepath.Join(dir, "missing")}},
+	}
+	check = serveCredentialsCheck(cfg)
+	require.Equal(t, selftestFail, check.Status)
+	require.Contains(t, check.Message, "openai/0")
+}
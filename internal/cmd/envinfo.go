@@ -99,6 +99,8 @@ var envInfoCmd = &cobra.Command{
 			observability.CLILogger.Info(fmt.Sprintf("  %s.model: %s", providerID, providerCfg.Models["default"]))
 			if len(providerCfg.Credentials) > 0 && strings.TrimSpace(providerCfg.Credentials[0].APIKey) != "" {
 				observability.CLILogger.Info(fmt.Sprintf("  %s.credentials[0].api_key: (set)", providerID))
+			} else if len(providerCfg.Credentials) > 0 && strings.TrimSpace(providerCfg.Credentials[0].APIKeyFile) != "" {
+				observability.CLILogger.Info(fmt.Sprintf("  %s.credentials[0].api_key_file: %s", providerID, providerCfg.Credentials[0].APIKeyFile))
 			} else {
 				observability.CLILogger.Info(fmt.Sprintf("  %s.credentials[0].api_key: (not set)", providerID))
 			}
@@ -9,6 +9,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/fulmenhq/gofulmen/errors"
+
+	"github.com/namelens/namelens/internal/redact"
 )
 
 // ExitWithCode exits the program with a semantic foundry exit code and logs the error.
@@ -42,7 +44,7 @@ func ExitWithCode(logger *logging.Logger, exitCode foundry.ExitCode, msg string,
 		if envelope, ok := err.(*errors.ErrorEnvelope); ok {
 			fields = append(fields,
 				zap.String("error_code", envelope.Code),
-				zap.String("error_message", envelope.Message),
+				zap.String("error_message", redact.String(envelope.Message)),
 				zap.String("correlation_id", envelope.CorrelationID),
 				zap.String("trace_id", envelope.TraceID),
 			)
@@ -56,7 +58,9 @@ func ExitWithCode(logger *logging.Logger, exitCode foundry.ExitCode, msg string,
 			}
 		}
 
-		fields = append(fields, zap.Error(err))
+		if err != nil {
+			fields = append(fields, zap.String("error", redact.String(err.Error())))
+		}
 		logger.Error(msg, fields...)
 	} else {
 		// Fall back to stderr if no logger available
@@ -111,3 +115,22 @@ func ExitWithCodeStderr(exitCode foundry.ExitCode, msg string, err error) {
 
 	os.Exit(info.Code)
 }
+
+// ExitCodeError ends the process with Code instead of the generic failure
+// exit. Commands that promise specific exit codes, such as check --quiet,
+// return it; Err, when set, is printed to stderr first.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("exit code %d", e.Code)
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
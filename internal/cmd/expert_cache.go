@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var expertCacheCmd = &cobra.Command{
+	Use:   "expert-cache",
+	Short: "Inspect and invalidate cached AI answers",
+	Long: `The expert cache keeps AI answers for --expert, --phonetics, --suitability,
+and review, keyed by name, prompt slug, model, base URL, and depth, for
+ailink.cache_ttl. These commands show what is cached and delete entries so the
+next run asks the provider again.`,
+}
+
+var expertCacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cached AI answers",
+	Example: `  namelens expert-cache list
+  namelens expert-cache list --name acme
+  namelens expert-cache list --prompt name-availability --older-than 72h`,
+	Args: cobra.NoArgs,
+	RunE: runExpertCacheList,
+}
+
+var expertCacheShowCmd = &cobra.Command{
+	Use:   "show <id|name>",
+	Short: "Print the raw cached payload for an entry or a name",
+	Example: `  namelens expert-cache show 42
+  namelens expert-cache show acme --prompt name-phonetics`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExpertCacheShow,
+}
+
+var expertCachePurgeCmd = &cobra.Command{
+	Use:   "purge [id]",
+	Short: "Delete cached AI answers to force regeneration",
+	Long: `Delete expert cache entries. Filters combine, so --name acme --prompt
+name-phonetics only deletes the phonetics answer for acme.`,
+	Example: `  namelens expert-cache purge --name acme
+  namelens expert-cache purge 42
+  namelens expert-cache purge --model grok-3 --dry-run
+  namelens expert-cache purge --all --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExpertCachePurge,
+}
+
+func init() {
+	rootCmd.AddCommand(expertCacheCmd)
+	expertCacheCmd.AddCommand(expertCacheListCmd)
+	expertCacheCmd.AddCommand(expertCacheShowCmd)
+	expertCacheCmd.AddCommand(expertCachePurgeCmd)
+
+	addExpertCacheFilterFlags(expertCacheListCmd)
+	expertCacheListCmd.Flags().Int("limit", 100, "Maximum entries to list (0 for all)")
+	expertCacheListCmd.Flags().String("output-format", string(output.FormatTable), "Output format: table|json")
+
+	expertCacheShowCmd.Flags().String("prompt", "", "Only entries for a prompt slug")
+	expertCacheShowCmd.Flags().String("model", "", "Only entries for a model")
+	expertCacheShowCmd.Flags().String("output-format", string(output.FormatTable), "Output format: table|json")
+
+	addExpertCacheFilterFlags(expertCachePurgeCmd)
+	expertCachePurgeCmd.Flags().Bool("all", false, "Purge every entry")
+	expertCachePurgeCmd.Flags().Bool("yes", false, "Confirm purging every entry with --all")
+	expertCachePurgeCmd.Flags().Bool("dry-run", false, "Show what would be deleted")
+	expertCachePurgeCmd.Flags().String("output-format", string(output.FormatTable), "Output format: table|json")
+}
+
+// addExpertCacheFilterFlags adds the flags that select expert cache entries.
+func addExpertCacheFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Only entries for a name")
+	cmd.Flags().String("prompt", "", "Only entries for a prompt slug")
+	cmd.Flags().String("model", "", "Only entries for a model")
+	cmd.Flags().Duration("older-than", 0, "Only entries cached more than this long ago, e.g. 72h")
+	cmd.Flags().Bool("expired", false, "Only entries whose TTL has passed")
+}
+
+func expertCacheQuery(cmd *cobra.Command, now time.Time) (corestore.ExpertCacheQuery, error) {
+	var query corestore.ExpertCacheQuery
+	var err error
+	if flag := cmd.Flags().Lookup("name"); flag != nil {
+		query.Name = flag.Value.String()
+	}
+	if query.PromptSlug, err = cmd.Flags().GetString("prompt"); err != nil {
+		return query, err
+	}
+	if query.Model, err = cmd.Flags().GetString("model"); err != nil {
+		return query, err
+	}
+	if cmd.Flags().Lookup("older-than") != nil {
+		olderThan, err := cmd.Flags().GetDuration("older-than")
+		if err != nil {
+			return query, err
+		}
+		if olderThan < 0 {
+			return query, errors.New("--older-than must be positive")
+		}
+		if olderThan > 0 {
+			query.CreatedBefore = now.Add(-olderThan)
+		}
+	}
+	if cmd.Flags().Lookup("expired") != nil {
+		expired, err := cmd.Flags().GetBool("expired")
+		if err != nil {
+			return query, err
+		}
+		if expired {
+			query.ExpiredAt = now
+		}
+	}
+	return query, nil
+}
+
+// expertCacheFormat reads --output-format, which is table or json.
+func expertCacheFormat(cmd *cobra.Command) (output.Format, error) {
+	value, err := cmd.Flags().GetString("output-format")
+	if err != nil {
+		return "", err
+	}
+	format, err := output.ParseFormat(value)
+	if err != nil {
+		return "", err
+	}
+	if format != output.FormatJSON && format != output.FormatTable {
+		return "", fmt.Errorf("unsupported output format: %s", format)
+	}
+	return format, nil
+}
+
+func runExpertCacheList(cmd *cobra.Command, args []string) error {
+	format, err := expertCacheFormat(cmd)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	query, err := expertCacheQuery(cmd, now)
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	db, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint:errcheck // best-effort cleanup
+
+	records, err := db.ExpertCacheEntries(ctx, query, limit, false, now)
+	if err != nil {
+		return err
+	}
+	return renderExpertCacheList(cmd.OutOrStdout(), records, format)
+}
+
+func renderExpertCacheList(w io.Writer, records []corestore.ExpertCacheRecord, format output.Format) error {
+	if format == output.FormatJSON {
+		if records == nil {
+			records = []corestore.ExpertCacheRecord{}
+		}
+		payload, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	if len(records) == 0 {
+		_, err := fmt.Fprintln(w, "No cached AI answers match")
+		return err
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"ID", "Name", "Prompt", "Model", "Depth", "Size", "Cached", "Expires"})
+	for _, record := range records {
+		expires := record.ExpiresAt.Format(time.RFC3339)
+		if record.Expired {
+			expires = "expired"
+		}
+		t.AppendRow(table.Row{
+			record.ID,
+			record.Name,
+			record.PromptSlug,
+			record.Model,
+			record.Depth,
+			formatFileSize(int64(record.Bytes)),
+			record.CreatedAt.Format(time.RFC3339),
+			expires,
+		})
+	}
+	t.Render()
+	return nil
+}
+
+func runExpertCacheShow(cmd *cobra.Command, args []string) error {
+	format, err := expertCacheFormat(cmd)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	query, err := expertCacheQuery(cmd, now)
+	if err != nil {
+		return err
+	}
+	target := strings.TrimSpace(args[0])
+	if id, parseErr := strconv.ParseInt(target, 10, 64); parseErr == nil && id > 0 {
+		query.ID = id
+	} else {
+		query.Name = target
+	}
+
+	ctx := cmd.Context()
+	db, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint:errcheck // best-effort cleanup
+
+	records, err := db.ExpertCacheEntries(ctx, query, 0, true, now)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("no cached AI answers for %s", target)
+	}
+	return renderExpertCacheShow(cmd.OutOrStdout(), records, format)
+}
+
+func renderExpertCacheShow(w io.Writer, records []corestore.ExpertCacheRecord, format output.Format) error {
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	for i, record := range records {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+		status := "live"
+		if record.Expired {
+			status = "expired"
+		}
+		_, _ = fmt.Fprintf(w, "ID:       %d\n", record.ID)
+		_, _ = fmt.Fprintf(w, "Name:     %s\n", record.Name)
+		_, _ = fmt.Fprintf(w, "Prompt:   %s\n", record.PromptSlug)
+		_, _ = fmt.Fprintf(w, "Model:    %s\n", record.Model)
+		_, _ = fmt.Fprintf(w, "Base URL: %s\n", record.BaseURL)
+		_, _ = fmt.Fprintf(w, "Depth:    %s\n", record.Depth)
+		_, _ = fmt.Fprintf(w, "Cached:   %s\n", record.CreatedAt.Format(time.RFC3339))
+		_, _ = fmt.Fprintf(w, "Expires:  %s (%s)\n\n", record.ExpiresAt.Format(time.RFC3339), status)
+		if _, err := fmt.Fprintln(w, prettyPayload(record.ResponseJSON)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prettyPayload indents a JSON payload and leaves anything else as it is.
+func prettyPayload(raw string) string {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(raw), "", "  "); err != nil {
+		return raw
+	}
+	return buf.String()
+}
+
+func runExpertCachePurge(cmd *cobra.Command, args []string) error {
+	format, err := expertCacheFormat(cmd)
+	if err != nil {
+		return err
+	}
+	query, err := expertCacheQuery(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		id, err := strconv.ParseInt(strings.TrimSpace(args[0]), 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid id %q", args[0])
+		}
+		query.ID = id
+	}
+	if query.All, err = cmd.Flags().GetBool("all"); err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+	switch {
+	case query.All && query.HasFilter():
+		return errors.New("--all cannot be combined with an id or filters")
+	case !query.All && !query.HasFilter():
+		return errors.New("must specify --all, an id, --name, --prompt, --model, --older-than, or --expired")
+	case query.All && !yes && !dryRun:
+		return errors.New("--all requires --yes (or use --dry-run)")
+	}
+
+	ctx := cmd.Context()
+	db, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close() // nolint:errcheck // best-effort cleanup
+
+	matched, err := db.CountExpertCache(ctx, query)
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		return writeExpertCachePurgeResult(format, cmd.OutOrStdout(), matched, 0, true)
+	}
+	deleted, err := db.PurgeExpertCache(ctx, query)
+	if err != nil {
+		return err
+	}
+	return writeExpertCachePurgeResult(format, cmd.OutOrStdout(), matched, deleted, false)
+}
+
+func writeExpertCachePurgeResult(format output.Format, w io.Writer, matched int, deleted int64, dryRun bool) error {
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(map[string]any{
+			"matched": matched,
+			"deleted": deleted,
+			"dry_run": dryRun,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	if dryRun {
+		_, err := fmt.Fprintf(w, "Would delete %d cached AI answer(s)\n", matched)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "Deleted %d/%d cached AI answer(s)\n", deleted, matched)
+	return err
+}
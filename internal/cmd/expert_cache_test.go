@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestExpertCacheQuery(t *testing.T) {
+	cmd := &cobra.Command{Use: "list"}
+	addExpertCacheFilterFlags(cmd)
+	require.NoError(t, cmd.ParseFlags([]string{"--name", "acme", "--prompt", "name-phonetics", "--older-than", "72h", "--expired"}))
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	query, err := expertCacheQuery(cmd, now)
+	require.NoError(t, err)
+	require.Equal(t, corestore.ExpertCacheQuery{
+		Name:          "acme",
+		PromptSlug:    "name-phonetics",
+		CreatedBefore: now.Add(-72 * time.Hour),
+		ExpiredAt:     now,
+	}, query)
+
+	cmd = &cobra.Command{Use: "list"}
+	addExpertCacheFilterFlags(cmd)
+	require.NoError(t, cmd.ParseFlags([]string{"--older-than", "-1h"}))
+	_, err = expertCacheQuery(cmd, now)
+	require.ErrorContains(t, err, "--older-than must be positive")
+}
+
+func TestRenderExpertCacheShow(t *testing.T) {
+	var buf bytes.Buffer
+	err := renderExpertCacheShow(&buf, []corestore.ExpertCacheRecord{{
+		ID:           7,
+		Name:         "acme",
+		PromptSlug:   "name-availability",
+		Model:        "grok",
+		ResponseJSON: `{"summary":"taken"}`,
+		Expired:      true,
+	}}, output.FormatTable)
+	require.NoError(t, err)
+	require.Contains(t, buf.String(), "ID:       7\n")
+	require.Contains(t, buf.String(), "(expired)")
+	require.Contains(t, buf.String(), "{\n  \"summary\": \"taken\"\n}")
+
+	require.Equal(t, "not json", prettyPayload("not json"))
+}
@@ -15,14 +15,18 @@ var expertGuidanceShown bool
 
 // isAIBackendConfigured checks if any AI provider has a valid API key configured.
 // Uses the same logic as credential selection: if any provider has credentials
-// with an API key, consider it configured (matching the registry fallback behavior).
+// with an API key, inline, in a file, or in the OS keychain, consider it
+// configured (matching the registry fallback behavior).
 func isAIBackendConfigured(cfg ailink.Config) bool {
-	for _, provider := range cfg.Providers {
+	for id, provider := range cfg.Providers {
 		if !provider.Enabled {
 			continue
 		}
 		for _, cred := range provider.Credentials {
-			if strings.TrimSpace(cred.APIKey) != "" {
+			if cred.HasKey() {
+				return true
+			}
+			if resolved, err := cred.ResolveKey(id); err == nil && strings.TrimSpace(resolved.APIKey) != "" {
 				return true
 			}
 		}
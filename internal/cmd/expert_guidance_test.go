@@ -6,9 +6,13 @@ import (
 	"testing"
 
 	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/keychain"
 )
 
 func TestIsAIBackendConfigured(t *testing.T) {
+	// Keep keys in the developer's own keychain out of the results.
+	keychain.MockInit()
+
 	tests := []struct {
 		name     string
 		cfg      ailink.Config
@@ -121,6 +125,7 @@ func TestIsAIBackendConfigured(t *testing.T) {
 }
 
 func TestShowExpertGuidanceWarning(t *testing.T) {
+	keychain.MockInit()
 	// Reset state before test
 	resetExpertGuidance()
 
@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var expiringCmd = &cobra.Command{
+	Use:   "expiring <name>",
+	Short: "List when taken domains for a name expire",
+	Long: `Check a name across TLDs and list the taken domains with their expiration
+dates, soonest first.
+
+Expiration dates come from RDAP or WHOIS. Days until expiry count from today;
+a negative count means the registration has lapsed and the domain may be in
+its grace or redemption period. Domains already in redemption or
+pendingDelete show their estimated drop window. Most expiring domains are
+renewed, so treat the list as names to watch, not names about to be free.
+
+Use --within to keep domains expiring in the next N days, and --watch to add
+them to the portfolio watch list; "namelens portfolio calendar" then exports
+reminders before each expiration or drop.`,
+	Example: `  namelens expiring acme
+  namelens expiring acme --tlds com,io,ai,co --within 90
+  namelens expiring acme --within 60 --watch --notify-before 168h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExpiring,
+}
+
+func init() {
+	rootCmd.AddCommand(expiringCmd)
+
+	expiringCmd.Flags().String("profile", "startup", "Availability profile whose TLDs to check")
+	expiringCmd.Flags().StringSlice("tlds", nil, "TLDs to check (overrides profile)")
+	expiringCmd.Flags().Int("within", 0, "Only list domains expiring within this many days (0 = all)")
+	expiringCmd.Flags().Bool("watch", false, "Add the listed domains to the portfolio watch list")
+	expiringCmd.Flags().Duration("notify-before", 0, "With --watch, remind this long before the expiration or expected drop")
+	expiringCmd.Flags().Int("concurrency", 6, "Concurrent checks")
+	expiringCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	expiringCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+	expiringCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
+}
+
+// expiringDomain is one taken domain in the expiring report.
+type expiringDomain struct {
+	Domain     string     `json:"domain"`
+	Registrar  string     `json:"registrar,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+	// DaysUntilExpiry is negative once the expiration date has passed.
+	DaysUntilExpiry *int             `json:"days_until_expiry,omitempty"`
+	DropWindow      *core.DropWindow `json:"drop_window,omitempty"`
+	Statuses        []string         `json:"statuses,omitempty"`
+	Watched         bool             `json:"watched,omitempty"`
+}
+
+func runExpiring(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	profileName, err := cmd.Flags().GetString("profile")
+	if err != nil {
+		return err
+	}
+	tlds, err := cmd.Flags().GetStringSlice("tlds")
+	if err != nil {
+		return err
+	}
+	within, err := cmd.Flags().GetInt("within")
+	if err != nil {
+		return err
+	}
+	if within < 0 {
+		return errors.New("--within must be 0 or greater")
+	}
+	watch, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return err
+	}
+	notifyBefore, err := cmd.Flags().GetDuration("notify-before")
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("notify-before") && !watch {
+		return errors.New("--notify-before requires --watch")
+	}
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return err
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+
+	profile, err := resolveProfile(ctx, store, profileName, normalizeTLDs(tlds), nil, nil)
+	if err != nil {
+		return err
+	}
+	if len(profile.TLDs) == 0 {
+		return errors.New("at least one TLD is required")
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+	results, err := runTargetChecks(ctx, splitProfileTargets(core.Profile{Name: profile.Name, TLDs: profile.TLDs}), concurrency, func(ctx context.Context, target core.Profile) ([]*core.CheckResult, error) {
+		return orchestrator.Check(ctx, name, target)
+	})
+	if err != nil {
+		return err
+	}
+
+	domains := expiringDomains(results, time.Now(), within)
+	if watch {
+		for i := range domains {
+			if err := store.AddPortfolioDomain(ctx, corestore.PortfolioDomain{Domain: domains[i].Domain, Relation: corestore.PortfolioWatched, NotifyBefore: notifyBefore}); err != nil {
+				return err
+			}
+			domains[i].Watched = true
+		}
+		observability.CLILogger.Info("Added expiring domains to portfolio watch list", zap.Int("domains", len(domains)))
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderExpiring(sink.writer, name, domains, format)
+}
+
+// expiringDomains returns the taken domains among results, soonest
+// expiration first; domains without a known date come last. With within
+// above 0, only domains expiring or dropping in the next within days are
+// kept.
+func expiringDomains(results []*core.CheckResult, now time.Time, within int) []expiringDomain {
+	today := now.UTC().Truncate(24 * time.Hour)
+	domains := make([]expiringDomain, 0, len(results))
+	for _, result := range results {
+		if result == nil || result.CheckType != core.CheckTypeDomain || result.Available != core.AvailabilityTaken {
+			continue
+		}
+		entry := expiringDomain{Domain: result.Name, Statuses: core.DomainStatusCodes(result.ExtraData)}
+		entry.Registrar, _ = result.ExtraData["registrar"].(string)
+		if window, ok := core.ParseDropWindow(result.ExtraData); ok {
+			entry.DropWindow = &window
+		}
+		if raw, ok := result.ExtraData["expiration"].(string); ok {
+			if expires, err := time.Parse(time.RFC3339, raw); err == nil {
+				days := int(math.Floor(expires.UTC().Truncate(24*time.Hour).Sub(today).Hours() / 24))
+				entry.Expiration = &expires
+				entry.DaysUntilExpiry = &days
+			}
+		}
+
+		if within > 0 {
+			horizon := today.AddDate(0, 0, within)
+			switch {
+			case entry.DropWindow != nil:
+				if entry.DropWindow.Earliest.After(horizon) {
+					continue
+				}
+			case entry.DaysUntilExpiry != nil:
+				if *entry.DaysUntilExpiry > within {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		domains = append(domains, entry)
+	}
+
+	sort.SliceStable(domains, func(i, j int) bool {
+		a, b := expiringSortKey(domains[i]), expiringSortKey(domains[j])
+		return a.Before(b)
+	})
+	return domains
+}
+
+// expiringSortKey orders domains by expected drop, else expiration; unknown
+// dates sort last.
+func expiringSortKey(entry expiringDomain) time.Time {
+	switch {
+	case entry.DropWindow != nil:
+		return entry.DropWindow.Earliest
+	case entry.Expiration != nil:
+		return *entry.Expiration
+	}
+	return time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func renderExpiring(w io.Writer, name string, domains []expiringDomain, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(domains, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		_, _ = fmt.Fprintf(w, "## Expiring domains: %s\n\n", name)
+		if len(domains) == 0 {
+			_, err := fmt.Fprintln(w, "No taken domains with a matching expiration date.")
+			return err
+		}
+		_, _ = fmt.Fprintln(w, "| Domain | Expires | Days | Expected drop | Registrar | Status |")
+		_, _ = fmt.Fprintln(w, "|--------|---------|------|---------------|-----------|--------|")
+		for _, row := range expiringRows(domains) {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |\n", row[0], row[1], row[2], row[3], row[4], row[5])
+		}
+		return nil
+	default:
+		if len(domains) == 0 {
+			_, err := fmt.Fprintf(w, "No taken %s domains with a matching expiration date\n", name)
+			return err
+		}
+		t := table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.AppendHeader(table.Row{"Domain", "Expires", "Days", "Expected drop", "Registrar", "Status"})
+		for _, row := range expiringRows(domains) {
+			t.AppendRow(table.Row{row[0], row[1], row[2], row[3], row[4], row[5]})
+		}
+		t.Render()
+		return nil
+	}
+}
+
+func expiringRows(domains []expiringDomain) [][6]string {
+	rows := make([][6]string, 0, len(domains))
+	for _, entry := range domains {
+		row := [6]string{entry.Domain, "", "", "", entry.Registrar, strings.Join(entry.Statuses, ", ")}
+		if entry.Expiration != nil {
+			row[1] = entry.Expiration.UTC().Format("2006-01-02")
+		}
+		if entry.DaysUntilExpiry != nil {
+			row[2] = strconv.Itoa(*entry.DaysUntilExpiry)
+		}
+		if entry.DropWindow != nil {
+			row[3] = output.FormatDropWindow(*entry.DropWindow)
+		}
+		if entry.Watched {
+			row[0] += " (watched)"
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
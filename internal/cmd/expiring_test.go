@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestExpiringDomains(t *testing.T) {
+	now := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	drop := core.DropWindow{Earliest: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), Latest: time.Date(2026, 3, 22, 0, 0, 0, 0, time.UTC), Phase: core.DropPhaseRedemption}
+	results := []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken, ExtraData: map[string]any{"expiration": "2027-01-10T00:00:00Z", "registrar": "Example Registrar"}},
+		{Name: "acme.io", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken, ExtraData: map[string]any{"expiration": "2026-03-31T08:00:00Z", "status": []string{"client transfer prohibited"}}},
+		{Name: "acme.ai", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken, ExtraData: map[string]any{"expiration": "2026-02-20T00:00:00Z", "drop_window": core.DropWindowExtra(drop)}},
+		{Name: "acme.co", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken},
+		{Name: "acme.dev", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken},
+	}
+
+	domains := expiringDomains(results, now, 0)
+	names := make([]string, 0, len(domains))
+	for _, entry := range domains {
+		names = append(names, entry.Domain)
+	}
+	require.Equal(t, []string{"acme.ai", "acme.io", "acme.com", "acme.co"}, names)
+	require.Equal(t, -9, *domains[0].DaysUntilExpiry)
+	require.Equal(t, 30, *domains[1].DaysUntilExpiry)
+	require.Equal(t, []string{"client transfer prohibited"}, domains[1].Statuses)
+	require.Equal(t, "Example Registrar", domains[2].Registrar)
+	require.Nil(t, domains[3].Expiration)
+
+	domains = expiringDomains(results, now, 30)
+	names = names[:0]
+	for _, entry := range domains {
+		names = append(names, entry.Domain)
+	}
+	require.Equal(t, []string{"acme.ai", "acme.io"}, names)
+}
+
+func TestRenderExpiringMarkdown(t *testing.T) {
+	expires := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	days := 30
+	domains := []expiringDomain{{Domain: "acme.io", Expiration: &expires, DaysUntilExpiry: &days, Registrar: "Example Registrar", Watched: true}}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderExpiring(&buf, "acme", domains, output.FormatMarkdown))
+	require.Contains(t, buf.String(), "## Expiring domains: acme")
+	require.Contains(t, buf.String(), "| acme.io (watched) | 2026-03-31 | 30 |  | Example Registrar |  |")
+
+	buf.Reset()
+	require.NoError(t, renderExpiring(&buf, "acme", nil, output.FormatTable))
+	require.Equal(t, "No taken acme domains with a matching expiration date\n", buf.String())
+}
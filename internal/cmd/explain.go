@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <domain>",
+	Short: "Explain a taken domain's status codes",
+	Long: `Check a domain and explain its registry status codes in plain English.
+
+Status codes such as clientTransferProhibited (a registrar lock) or
+pendingDelete (about to be released) matter when you consider acquiring a
+taken domain: they tell you whether it can be transferred, whether it is in
+a dispute or on hold, and whether it may soon drop. "Set by" shows whether the
+registrar (client codes) or the registry (server codes) applied the status.
+
+For domains in redemption or pendingDelete, the expected drop window is
+estimated from RDAP event dates and the registry's deletion policy. Add such
+a domain to the portfolio watch list with --watch to get a calendar reminder
+--notify-before it drops.`,
+	Example: `  namelens explain acme.com
+  namelens explain acme.io --output-format json
+  namelens explain acme.dev --watch --notify-before 24h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExplain,
+}
+
+// explainResult is the JSON output of the explain command.
+type explainResult struct {
+	Domain       string              `json:"domain"`
+	Availability string              `json:"availability"`
+	Source       string              `json:"source,omitempty"`
+	Registrar    string              `json:"registrar,omitempty"`
+	Expiration   string              `json:"expiration,omitempty"`
+	Statuses     []core.DomainStatus `json:"statuses"`
+	DropWindow   *core.DropWindow    `json:"drop_window,omitempty"`
+	Message      string              `json:"message,omitempty"`
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+
+	explainCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	explainCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+	explainCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
+	explainCmd.Flags().Bool("watch", false, "Add the domain to the portfolio watch list")
+	explainCmd.Flags().Duration("notify-before", 0, "With --watch, remind this long before the expected drop or expiration")
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	domain := strings.ToLower(strings.TrimSpace(args[0]))
+	if !strings.Contains(domain, ".") {
+		return errors.New("explain needs a domain including its TLD, e.g. acme.com")
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return err
+	}
+	watch, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return err
+	}
+	notifyBefore, err := cmd.Flags().GetDuration("notify-before")
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("notify-before") && !watch {
+		return errors.New("--notify-before requires --watch")
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+	result, err := checkDomain(ctx, orchestrator, domain)
+	if err != nil {
+		return err
+	}
+	if watch {
+		if err := store.AddPortfolioDomain(ctx, corestore.PortfolioDomain{Domain: domain, Relation: corestore.PortfolioWatched, NotifyBefore: notifyBefore}); err != nil {
+			return err
+		}
+		observability.CLILogger.Info("Added domain to portfolio watch list", zap.String("domain", domain))
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderExplain(sink.writer, newExplainResult(domain, result), format)
+}
+
+// checkDomain runs the domain check for a full domain name such as acme.com.
+func checkDomain(ctx context.Context, orchestrator *engine.Orchestrator, domain string) (*core.CheckResult, error) {
+	name, tld, _ := strings.Cut(domain, ".")
+	results, err := orchestrator.Check(ctx, name, core.Profile{TLDs: []string{tld}})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no domain checker for %s", domain)
+	}
+	return results[0], nil
+}
+
+func newExplainResult(domain string, result *core.CheckResult) explainResult {
+	explained := explainResult{
+		Domain:       domain,
+		Availability: result.Available.String(),
+		Source:       result.Provenance.Source,
+		Statuses:     core.ExplainDomainStatuses(core.DomainStatusCodes(result.ExtraData)),
+	}
+	if registrar, ok := result.ExtraData["registrar"].(string); ok {
+		explained.Registrar = registrar
+	}
+	if expiration, ok := result.ExtraData["expiration"].(string); ok {
+		explained.Expiration = expiration
+	}
+	if window, ok := core.ParseDropWindow(result.ExtraData); ok {
+		explained.DropWindow = &window
+	}
+	if result.Available != core.AvailabilityTaken {
+		explained.Message = result.Message
+	}
+	return explained
+}
+
+func renderExplain(w io.Writer, result explainResult, format output.Format) error {
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	summary := fmt.Sprintf("%s: %s", result.Domain, result.Availability)
+	if result.Source != "" {
+		summary += fmt.Sprintf(" (%s)", result.Source)
+	}
+	details := []string{}
+	if result.Registrar != "" {
+		details = append(details, "registrar: "+result.Registrar)
+	}
+	if result.Expiration != "" {
+		details = append(details, "expires: "+result.Expiration)
+	}
+	if result.DropWindow != nil {
+		details = append(details, fmt.Sprintf("expected drop: %s (%s)", output.FormatDropWindow(*result.DropWindow), result.DropWindow.Basis))
+	}
+	if result.Message != "" {
+		details = append(details, result.Message)
+	}
+
+	if format == output.FormatMarkdown {
+		_, _ = fmt.Fprintf(w, "## %s\n\n", summary)
+		for _, detail := range details {
+			_, _ = fmt.Fprintf(w, "- %s\n", detail)
+		}
+		if len(result.Statuses) == 0 {
+			_, _ = fmt.Fprintln(w, "\nNo status codes reported.")
+			return nil
+		}
+		_, _ = fmt.Fprintln(w, "\n| Status | Set by | Meaning |")
+		_, _ = fmt.Fprintln(w, "|--------|--------|---------|")
+		for _, status := range result.Statuses {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %s |\n", status.Code, status.SetBy, explainMeaning(status))
+		}
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w, summary)
+	for _, detail := range details {
+		_, _ = fmt.Fprintln(w, "  "+detail)
+	}
+	if len(result.Statuses) == 0 {
+		_, _ = fmt.Fprintln(w, "No status codes reported.")
+		return nil
+	}
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Status", "Set by", "Meaning"})
+	for _, status := range result.Statuses {
+		t.AppendRow(table.Row{status.Code, status.SetBy, explainMeaning(status)})
+	}
+	t.Render()
+	return nil
+}
+
+func explainMeaning(status core.DomainStatus) string {
+	if status.Meaning == "" {
+		return "Unrecognized status code"
+	}
+	return status.Meaning
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestRenderExplain(t *testing.T) {
+	result := newExplainResult("acme.com", &core.CheckResult{
+		Available:  core.AvailabilityTaken,
+		Provenance: core.Provenance{Source: "rdap"},
+		ExtraData: map[string]any{
+			"registrar":  "Example Registrar",
+			"expiration": "2027-01-01T00:00:00Z",
+			"status":     []any{"client transfer prohibited", "serverHold"},
+		},
+	})
+	require.Equal(t, "taken", result.Availability)
+	require.Len(t, result.Statuses, 2)
+
+	var md bytes.Buffer
+	require.NoError(t, renderExplain(&md, result, output.FormatMarkdown))
+	require.Contains(t, md.String(), "## acme.com: taken (rdap)")
+	require.Contains(t, md.String(), "- registrar: Example Registrar")
+	require.Contains(t, md.String(), "| client transfer prohibited | registrar | Cannot be transferred")
+	require.Contains(t, md.String(), "| serverHold | registry | Removed from DNS")
+
+	var table bytes.Buffer
+	available := newExplainResult("zentro.com", &core.CheckResult{Available: core.AvailabilityAvailable, Message: "rdap not found"})
+	require.NoError(t, renderExplain(&table, available, output.FormatTable))
+	require.Contains(t, table.String(), "zentro.com: available")
+	require.Contains(t, table.String(), "No status codes reported.")
+}
+
+func TestRenderExplainDropWindow(t *testing.T) {
+	result := newExplainResult("zentro.com", &core.CheckResult{
+		Available: core.AvailabilityTaken,
+		ExtraData: map[string]any{
+			"status": []string{"pendingDelete"},
+			"drop_window": core.DropWindowExtra(core.DropWindow{
+				Earliest: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+				Latest:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+				Phase:    core.DropPhasePendingDelete,
+				Basis:    "registry deletion date",
+			}),
+		},
+	})
+	require.NotNil(t, result.DropWindow)
+
+	var table bytes.Buffer
+	require.NoError(t, renderExplain(&table, result, output.FormatTable))
+	require.Contains(t, table.String(), "expected drop: 2026-10-18 to 2026-10-20 (registry deletion date)")
+}
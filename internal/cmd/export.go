@@ -0,0 +1,273 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/spf13/cobra"
+
+	corestore "github.com/namelens/namelens/internal/core/store"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export cached results, history, and AI analyses from the local store",
+	Long: `Export the local store into a portable file for analysis in other tools.
+
+Datasets (--tables):
+  history   every recorded check result (see 'namelens diff')
+  cache     the current check cache
+  expert    cached AI analyses
+
+Formats:
+  sqlite    one database file with a table per dataset (--out results.db)
+  csv       one <table>.csv file per dataset in the --out directory
+  parquet   one <table>.parquet file per dataset in the --out directory`,
+	Example: `  namelens export --format sqlite --out results.db
+  namelens export --format csv --out ./export --tables history --name acme
+  namelens export --format parquet --out ./export --from 2026-01-01
+  namelens export --format sqlite --out q1.db --type domain --from 2026-01-01 --to 2026-03-31`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().String("format", "sqlite", "Export format: sqlite, csv, parquet")
+	exportCmd.Flags().String("out", "", "Output file (sqlite) or directory (csv, parquet)")
+	exportCmd.Flags().StringSlice("tables", corestore.ExportTables, "Datasets to export: history, cache, expert")
+	exportCmd.Flags().String("name", "", "Only export rows for this name")
+	exportCmd.Flags().String("type", "", "Only export this check type (domain, npm, pypi, cargo, github)")
+	exportCmd.Flags().String("from", "", "Only export rows recorded on or after this date (YYYY-MM-DD or RFC3339)")
+	exportCmd.Flags().String("to", "", "Only export rows recorded on or before this date (YYYY-MM-DD or RFC3339)")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+	tables, err := cmd.Flags().GetStringSlice("tables")
+	if err != nil {
+		return err
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return err
+	}
+	checkType, err := cmd.Flags().GetString("type")
+	if err != nil {
+		return err
+	}
+	fromValue, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return err
+	}
+	toValue, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return err
+	}
+
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "sqlite", "csv", "parquet":
+	default:
+		return fmt.Errorf("unsupported export format %q (use sqlite, csv, or parquet)", format)
+	}
+	outPath = strings.TrimSpace(outPath)
+	if outPath == "" || outPath == "-" {
+		return errors.New("--out is required")
+	}
+
+	filter := corestore.ExportFilter{Name: name, CheckType: checkType}
+	if strings.TrimSpace(fromValue) != "" {
+		if filter.From, err = parseDateBound(strings.TrimSpace(fromValue), false); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(toValue) != "" {
+		if filter.To, err = parseDateBound(strings.TrimSpace(toValue), true); err != nil {
+			return err
+		}
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() // nolint:errcheck // best-effort cleanup
+
+	exported, err := store.Export(ctx, normalizeList(tables), filter)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "csv":
+		err = writeExportCSV(outPath, exported)
+	case "parquet":
+		err = writeExportParquet(outPath, exported)
+	default:
+		err = corestore.WriteExportSQLite(ctx, outPath, exported)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, table := range exported {
+		fmt.Printf("Exported %d %s rows\n", len(table.Rows), table.Name)
+	}
+	fmt.Printf("Wrote %s\n", outPath)
+	return nil
+}
+
+func writeExportCSV(dir string, tables []corestore.ExportTable) error {
+	dir, err := ensureOutDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		path := filepath.Join(dir, table.Name+".csv")
+		file, err := os.Create(path) // #nosec G304 -- user-selected export directory
+		if err != nil {
+			return fmt.Errorf("create %s: %w", path, err)
+		}
+		if err := writeExportTableCSV(file, table); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeExportTableCSV(w io.Writer, table corestore.ExportTable) error {
+	writer := csv.NewWriter(w)
+	header := make([]string, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		header = append(header, column.Name)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	record := make([]string, len(table.Columns))
+	for _, row := range table.Rows {
+		for i, value := range row {
+			record[i] = exportCSVValue(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportCSVValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func writeExportParquet(dir string, tables []corestore.ExportTable) error {
+	dir, err := ensureOutDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		path := filepath.Join(dir, table.Name+".parquet")
+		file, err := os.Create(path) // #nosec G304 -- user-selected export directory
+		if err != nil {
+			return fmt.Errorf("create %s: %w", path, err)
+		}
+		if err := writeExportTableParquet(file, table); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeExportTableParquet writes one table with optional columns: INTEGER
+// columns as int64 and everything else as UTF-8 strings.
+func writeExportTableParquet(w io.Writer, table corestore.ExportTable) error {
+	group := make(parquet.Group, len(table.Columns))
+	for _, column := range table.Columns {
+		node := parquet.String()
+		if column.Type == "INTEGER" {
+			node = parquet.Int(64)
+		}
+		group[column.Name] = parquet.Optional(node)
+	}
+	schema := parquet.NewSchema(table.Name, group)
+
+	// Parquet orders leaf columns by name, not by table.Columns.
+	indexes := make([]int, len(table.Columns))
+	for i, column := range table.Columns {
+		leaf, ok := schema.Lookup(column.Name)
+		if !ok {
+			return fmt.Errorf("parquet column %s not found", column.Name)
+		}
+		indexes[i] = leaf.ColumnIndex
+	}
+
+	writer := parquet.NewWriter(w, schema)
+	rows := make([]parquet.Row, 0, len(table.Rows))
+	for _, values := range table.Rows {
+		row := make(parquet.Row, len(table.Columns))
+		for i, value := range values {
+			if value == nil {
+				row[indexes[i]] = parquet.NullValue().Level(0, 0, indexes[i])
+				continue
+			}
+			row[indexes[i]] = exportParquetValue(table.Columns[i].Type, value).Level(0, 1, indexes[i])
+		}
+		rows = append(rows, row)
+	}
+	if _, err := writer.WriteRows(rows); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+func exportParquetValue(columnType string, value any) parquet.Value {
+	if columnType == "INTEGER" {
+		switch v := value.(type) {
+		case int64:
+			return parquet.Int64Value(v)
+		case int:
+			return parquet.Int64Value(int64(v))
+		}
+	}
+	return parquet.ByteArrayValue([]byte(exportCSVValue(value)))
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	corestore "github.com/namelens/namelens/internal/core/store"
+)
+
+func TestWriteExportTableCSV(t *testing.T) {
+	table := corestore.ExportTable{
+		Name:    corestore.ExportHistory,
+		Columns: []corestore.ExportColumn{{Name: "target", Type: "TEXT"}, {Name: "status_code", Type: "INTEGER"}, {Name: "message", Type: "TEXT"}},
+		Rows: [][]any{
+			{"acme.com", int64(200), "registered, locked"},
+			{"acme", int64(0), nil},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeExportTableCSV(&buf, table))
+	require.Equal(t, "target,status_code,message\nacme.com,200,\"registered, locked\"\nacme,0,\n", buf.String())
+}
+
+func TestWriteExportTableParquet(t *testing.T) {
+	table := corestore.ExportTable{
+		Name:    corestore.ExportHistory,
+		Columns: []corestore.ExportColumn{{Name: "target", Type: "TEXT"}, {Name: "status_code", Type: "INTEGER"}, {Name: "message", Type: "TEXT"}},
+		Rows: [][]any{
+			{"acme.com", int64(200), "registered"},
+			{"acme", int64(0), nil},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeExportTableParquet(&buf, table))
+
+	type row struct {
+		Target     *string `parquet:"target,optional"`
+		StatusCode *int64  `parquet:"status_code,optional"`
+		Message    *string `parquet:"message,optional"`
+	}
+	rows, err := parquet.Read[row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "acme.com", *rows[0].Target)
+	require.Equal(t, int64(200), *rows[0].StatusCode)
+	require.Equal(t, "registered", *rows[0].Message)
+	require.Equal(t, "acme", *rows[1].Target)
+	require.Nil(t, rows[1].Message)
+}
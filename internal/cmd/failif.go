@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// failIfCondition is a parsed --fail-if expression such as "com=taken" or "score<5".
+type failIfCondition struct {
+	raw     string
+	subject string
+	op      string
+	value   string
+	number  int
+	numeric bool
+}
+
+var failIfOperators = []string{"!=", "<=", ">=", "==", "=", "<", ">"}
+
+var failIfNumericSubjects = map[string]struct{}{
+	"score":     {},
+	"total":     {},
+	"unknown":   {},
+	"taken":     {},
+	"available": {},
+}
+
+var failIfStatuses = map[string]struct{}{
+	"available":    {},
+	"taken":        {},
+	"unknown":      {},
+	"error":        {},
+	"rate_limited": {},
+	"unsupported":  {},
+}
+
+func addFailIfFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArray("fail-if", nil, `Exit non-zero when a condition matches any name (repeatable), e.g. "com=taken", "npm!=available", "score<5"`)
+}
+
+func resolveFailIf(cmd *cobra.Command) ([]failIfCondition, error) {
+	values, err := cmd.Flags().GetStringArray("fail-if")
+	if err != nil {
+		return nil, err
+	}
+	conditions := make([]failIfCondition, 0, len(values))
+	for _, value := range values {
+		condition, err := parseFailIf(value)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// parseFailIf parses "<subject><op><value>". Subjects are a TLD (com, .com),
+// registry (npm, pypi, cargo), handle (github), or any/all, compared with = or !=
+// against a status; or one of score, total, unknown, taken, available compared
+// numerically.
+func parseFailIf(expr string) (failIfCondition, error) {
+	raw := strings.TrimSpace(expr)
+	for _, op := range failIfOperators {
+		idx := strings.Index(raw, op)
+		if idx < 0 {
+			continue
+		}
+		condition := failIfCondition{
+			raw:     raw,
+			subject: strings.TrimPrefix(strings.ToLower(strings.TrimSpace(raw[:idx])), "."),
+			op:      op,
+			value:   strings.ToLower(strings.TrimSpace(raw[idx+len(op):])),
+		}
+		if condition.op == "==" {
+			condition.op = "="
+		}
+		if condition.subject == "" || condition.value == "" {
+			return failIfCondition{}, fmt.Errorf("invalid --fail-if %q: expected <subject><op><value>", expr)
+		}
+
+		if _, ok := failIfNumericSubjects[condition.subject]; ok {
+			number, err := strconv.Atoi(condition.value)
+			if err != nil {
+				return failIfCondition{}, fmt.Errorf("invalid --fail-if %q: %s must be compared with a number", expr, condition.subject)
+			}
+			condition.number = number
+			condition.numeric = true
+			return condition, nil
+		}
+
+		if condition.op != "=" && condition.op != "!=" {
+			return failIfCondition{}, fmt.Errorf("invalid --fail-if %q: status conditions support = and != only", expr)
+		}
+		if _, ok := failIfStatuses[condition.value]; !ok {
+			return failIfCondition{}, fmt.Errorf("invalid --fail-if %q: unknown status %q (use available, taken, unknown, error, rate_limited, unsupported)", expr, condition.value)
+		}
+		return condition, nil
+	}
+	return failIfCondition{}, fmt.Errorf("invalid --fail-if %q: expected <subject><op><value>", expr)
+}
+
+// matches reports whether the condition holds for a batch result.
+func (c failIfCondition) matches(batch *core.BatchResult) bool {
+	if batch == nil {
+		return false
+	}
+	if c.numeric {
+		return compareInt(failIfCount(batch, c.subject), c.op, c.number)
+	}
+
+	targets := failIfTargets(batch, c.subject)
+	if len(targets) == 0 {
+		return false
+	}
+	switch c.subject {
+	case "all":
+		for _, result := range targets {
+			if !c.statusMatches(result) {
+				return false
+			}
+		}
+		return true
+	default:
+		for _, result := range targets {
+			if c.statusMatches(result) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (c failIfCondition) statusMatches(result *core.CheckResult) bool {
+	equal := result.Available.String() == c.value
+	if c.op == "!=" {
+		return !equal
+	}
+	return equal
+}
+
+func failIfCount(batch *core.BatchResult, subject string) int {
+	switch subject {
+	case "score", "available":
+		return batch.Score
+	case "total":
+		return batch.Total
+	case "unknown":
+		return batch.Unknown
+	case "taken":
+		taken := 0
+		for _, result := range batch.Results {
+			if result != nil && result.Available == core.AvailabilityTaken {
+				taken++
+			}
+		}
+		return taken
+	}
+	return 0
+}
+
+func failIfTargets(batch *core.BatchResult, subject string) []*core.CheckResult {
+	targets := make([]*core.CheckResult, 0)
+	for _, result := range batch.Results {
+		if result == nil {
+			continue
+		}
+		switch {
+		case subject == "any" || subject == "all":
+		case result.CheckType == core.CheckTypeDomain:
+			tld := strings.TrimPrefix(result.TLD, ".")
+			if tld == "" {
+				if _, after, ok := strings.Cut(result.Name, "."); ok {
+					tld = after
+				}
+			}
+			if !strings.EqualFold(tld, subject) {
+				continue
+			}
+		case string(result.CheckType) != subject:
+			continue
+		}
+		targets = append(targets, result)
+	}
+	return targets
+}
+
+func compareInt(left int, op string, right int) bool {
+	switch op {
+	case "=":
+		return left == right
+	case "!=":
+		return left != right
+	case "<":
+		return left < right
+	case "<=":
+		return left <= right
+	case ">":
+		return left > right
+	case ">=":
+		return left >= right
+	}
+	return false
+}
+
+// evaluateFailIf returns an error describing every name/condition pair that matched.
+func evaluateFailIf(conditions []failIfCondition, batches []*core.BatchResult) error {
+	if len(conditions) == 0 {
+		return nil
+	}
+	matched := make([]string, 0)
+	for _, batch := range batches {
+		if batch == nil {
+			continue
+		}
+		for _, condition := range conditions {
+			if condition.matches(batch) {
+				matched = append(matched, fmt.Sprintf("%s: %s", batch.Name, condition.raw))
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	return fmt.Errorf("fail-if matched (%s)", strings.Join(matched, "; "))
+}
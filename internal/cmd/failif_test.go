@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func failIfBatch() *core.BatchResult {
+	return &core.BatchResult{
+		Name: "acme",
+		Results: []*core.CheckResult{
+			{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+			{Name: "acme.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable},
+			{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+			{Name: "acme", CheckType: core.CheckTypeGitHub, Available: core.AvailabilityUnknown},
+		},
+		Score:   2,
+		Total:   3,
+		Unknown: 1,
+	}
+}
+
+func TestParseFailIf(t *testing.T) {
+	condition, err := parseFailIf(" .COM = Taken ")
+	require.NoError(t, err)
+	require.Equal(t, "com", condition.subject)
+	require.Equal(t, "=", condition.op)
+	require.Equal(t, "taken", condition.value)
+
+	condition, err = parseFailIf("score<=5")
+	require.NoError(t, err)
+	require.True(t, condition.numeric)
+	require.Equal(t, "<=", condition.op)
+	require.Equal(t, 5, condition.number)
+
+	for _, expr := range []string{"", "com", "com=gone", "com<taken", "score<lots", "=taken"} {
+		_, err := parseFailIf(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestFailIfMatches(t *testing.T) {
+	batch := failIfBatch()
+	cases := map[string]bool{
+		"com=taken":          true,
+		"io=taken":           false,
+		"npm!=available":     false,
+		"github!=available":  true,
+		"pypi=taken":         false,
+		"any=taken":          true,
+		"all=available":      false,
+		"score<3":            true,
+		"score>=2":           true,
+		"taken>1":            false,
+		"unknown=1":          true,
+		"total!=3":           false,
+		"available==2":       true,
+		"dev=taken":          false,
+		"github=unknown":     true,
+		"com==taken":         true,
+		"npm=rate_limited":   false,
+		"cargo!=unsupported": false,
+	}
+	for expr, want := range cases {
+		condition, err := parseFailIf(expr)
+		require.NoError(t, err, expr)
+		require.Equal(t, want, condition.matches(batch), expr)
+	}
+}
+
+func TestEvaluateFailIf(t *testing.T) {
+	conditions := make([]failIfCondition, 0)
+	for _, expr := range []string{"com=taken", "score<1"} {
+		condition, err := parseFailIf(expr)
+		require.NoError(t, err)
+		conditions = append(conditions, condition)
+	}
+
+	err := evaluateFailIf(conditions, []*core.BatchResult{failIfBatch(), nil})
+	require.EqualError(t, err, "fail-if matched (acme: com=taken)")
+
+	require.NoError(t, evaluateFailIf(nil, []*core.BatchResult{failIfBatch()}))
+}
@@ -43,6 +43,8 @@ func init() {
 	generateCmd.Flags().String("model", "", "Model override")
 	generateCmd.Flags().String("prompt", "name-alternatives", "Prompt slug to use")
 	generateCmd.Flags().String("provider", "", "Override provider for this run (must match an ailink.providers key)")
+	generateCmd.Flags().Int64("seed", 0, "Reproducible run: temperature 0, this seed where the provider supports one, and de-duplicated, sorted candidates")
+	generateCmd.Flags().Bool("screen", false, "Check .com and npm availability for each candidate and add it to the output")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
@@ -64,6 +66,9 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	modelOverride, _ := cmd.Flags().GetString("model")
 	promptSlug, _ := cmd.Flags().GetString("prompt")
 	providerOverride, _ := cmd.Flags().GetString("provider")
+	seeded := cmd.Flags().Changed("seed")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	screen, _ := cmd.Flags().GetBool("screen")
 
 	// Build variables map - use both "concept" and "name" keys for flexibility
 	// Different prompts may use different variable names for the main input
@@ -132,6 +137,9 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+	if isOffline(cfg) {
+		return errors.New("generate needs an AI provider and is not available offline")
+	}
 
 	// Build service
 	registry, err := buildPromptRegistry(cfg)
@@ -166,24 +174,67 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("loading schemas: %w", err)
 	}
 
+	quotaStore, err := openQuotaStore(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if quotaStore != nil {
+		defer quotaStore.Close() //nolint:errcheck
+	}
+
 	service := &ailink.Service{
 		Providers: providers,
 		Registry:  registry,
 		Catalog:   catalog,
+		Quota:     aiQuota(cfg, quotaStore),
+		Budget:    aiBudget(cfg, quotaStore),
 	}
 
-	// Execute generation
-	response, err := service.Generate(ctx, ailink.GenerateRequest{
+	generateReq := ailink.GenerateRequest{
 		Role:       role,
 		PromptSlug: promptSlug,
 		Variables:  variables,
 		Depth:      depth,
 		Model:      modelOverride,
 		UseTools:   true,
-	})
+	}
+	if seeded {
+		temperature := 0.0
+		generateReq.Temperature = &temperature
+		generateReq.Seed = &seed
+		if !driverSupportsSeed(resolved.Driver.Name(), ailink.UsesSearch(promptDef, generateReq.UseTools)) {
+			_, _ = fmt.Fprintf(os.Stderr, "Note: %s has no seed parameter; --seed only sets temperature 0, so output may still vary.\n", resolved.Driver.Name())
+		}
+	}
+
+	// Execute generation
+	ctx, runUsage := ailink.WithUsageTracker(ctx)
+	defer func() { printAIUsageSummary(os.Stderr, runUsage.Snapshot()) }()
+	request, contextReport, err := service.FitContext(ctx, generateReq, contextVariable)
+	if err != nil {
+		return fmt.Errorf("fitting context: %w", err)
+	}
+	printContextReport(os.Stderr, contextReport)
+	response, err := service.Generate(ctx, request)
 	if err != nil {
 		return fmt.Errorf("generation failed: %w", err)
 	}
+	if seeded {
+		response.Raw = normalizeCandidates(response.Raw)
+	}
+
+	var screens map[string]candidateScreen
+	if screen {
+		names := candidateNames(response.Raw)
+		_, _ = fmt.Fprintf(os.Stderr, "Screening %d candidates (.%s, %s)...\n", len(names), screenTLD, screenRegistry)
+		screens, err = screenCandidates(ctx, cfg, names)
+		if err != nil {
+			// The candidates were already paid for; show them unscreened.
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: availability screen failed: %v\n", err)
+		} else if jsonOutput {
+			response.Raw = annotateCandidates(response.Raw, screens)
+		}
+	}
 
 	// Output
 	if jsonOutput {
@@ -191,7 +242,7 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	return printGenerateResults(response.Raw, concept)
+	return printGenerateResults(response.Raw, concept, screens)
 }
 
 func applyGenerateProviderOverride(cfg ailink.Config, role, providerID string) (ailink.Config, error) {
@@ -337,7 +388,80 @@ func parseCorpusMarkdown(data []byte) (*ailinkctx.Corpus, error) {
 	return corpus, nil
 }
 
-func printGenerateResults(raw json.RawMessage, concept string) error {
+// driverSupportsSeed reports whether a driver sends Request.Seed to its API.
+// xAI sends it on chat completions only; search requests go through its
+// Responses API, which has no seed parameter.
+func driverSupportsSeed(driverName string, search bool) bool {
+	switch driverName {
+	case "openai":
+		return true
+	case "xai":
+		return !search
+	}
+	return false
+}
+
+// candidateStrengthRank orders candidates strong, moderate, weak, then
+// anything else.
+var candidateStrengthRank = map[string]int{"strong": 0, "moderate": 1, "weak": 2}
+
+// normalizeCandidates drops candidates whose name repeats an earlier one
+// (ignoring case and surrounding space) and sorts the rest by strength, then
+// name, so a seeded run lists the same candidates in the same order. Other
+// fields are kept; a response without a candidates list is returned as is.
+func normalizeCandidates(raw json.RawMessage) json.RawMessage {
+	var result map[string]any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return raw
+	}
+	candidates, ok := result["candidates"].([]any)
+	if !ok {
+		return raw
+	}
+
+	type candidate struct {
+		key   string
+		rank  int
+		value any
+	}
+	seen := map[string]bool{}
+	kept := make([]candidate, 0, len(candidates))
+	for _, value := range candidates {
+		fields, _ := value.(map[string]any)
+		name, _ := fields["name"].(string)
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key != "" && seen[key] {
+			continue
+		}
+		seen[key] = true
+		rank, ok := candidateStrengthRank[strings.ToLower(fmt.Sprint(fields["strength"]))]
+		if !ok {
+			rank = len(candidateStrengthRank)
+		}
+		kept = append(kept, candidate{key: key, rank: rank, value: value})
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].rank != kept[j].rank {
+			return kept[i].rank < kept[j].rank
+		}
+		return kept[i].key < kept[j].key
+	})
+
+	sorted := make([]any, len(kept))
+	for i, c := range kept {
+		sorted[i] = c.value
+	}
+	result["candidates"] = sorted
+	normalized, err := json.Marshal(result)
+	if err != nil {
+		return raw
+	}
+	return normalized
+}
+
+// printGenerateResults prints a generate response; screens adds .com and npm
+// columns to the candidate table when set.
+func printGenerateResults(raw json.RawMessage, concept string, screens map[string]candidateScreen) error {
 	// Parse the JSON response
 	var result struct {
 		ConceptAnalysis struct {
@@ -395,7 +519,11 @@ func printGenerateResults(raw json.RawMessage, concept string) error {
 	// All Candidates
 	if len(result.Candidates) > 0 {
 		fmt.Println("All Candidates:")
-		fmt.Printf("  %-14s %-12s %-10s %s\n", "NAME", "STRATEGY", "STRENGTH", "CONFLICTS")
+		if screens != nil {
+			fmt.Printf("  %-14s %-12s %-10s %-10s %-10s %s\n", "NAME", "STRATEGY", "STRENGTH", ".COM", "NPM", "CONFLICTS")
+		} else {
+			fmt.Printf("  %-14s %-12s %-10s %s\n", "NAME", "STRATEGY", "STRENGTH", "CONFLICTS")
+		}
 		for _, c := range result.Candidates {
 			conflicts := c.PotentialConflicts
 			if conflicts == "" {
@@ -405,6 +533,11 @@ func printGenerateResults(raw json.RawMessage, concept string) error {
 			if len(conflicts) > 40 {
 				conflicts = conflicts[:37] + "..."
 			}
+			if screens != nil {
+				screen := lookupScreen(screens, c.Name)
+				fmt.Printf("  %-14s %-12s %-10s %-10s %-10s %s\n", c.Name, c.Strategy, c.Strength, screen.Com, screen.NPM, conflicts)
+				continue
+			}
 			fmt.Printf("  %-14s %-12s %-10s %s\n", c.Name, c.Strategy, c.Strength, conflicts)
 		}
 		fmt.Println()
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+// generate --screen runs one .com and one npm check per candidate.
+const (
+	screenTLD         = "com"
+	screenRegistry    = "npm"
+	screenConcurrency = 3
+	screenInvalid     = "invalid"
+)
+
+// candidateScreen is the quick availability of one generated candidate.
+// Each field is an availability label (available, taken, unknown, ...), or
+// "invalid" when the name cannot be checked as given.
+type candidateScreen struct {
+	Com string `json:"com"`
+	NPM string `json:"npm"`
+}
+
+// screenName lowercases a candidate for checking; ok is false when the
+// result is still not a valid name.
+func screenName(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return name, validateName(name) == nil
+}
+
+// candidateNames returns the candidate names of a generate response in order.
+func candidateNames(raw json.RawMessage) []string {
+	var result struct {
+		Candidates []struct {
+			Name string `json:"name"`
+		} `json:"candidates"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(result.Candidates))
+	for _, candidate := range result.Candidates {
+		if name := strings.TrimSpace(candidate.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// screenCandidates checks .com and npm for each name, reusing cached results,
+// and returns the screens keyed by screenKey.
+func screenCandidates(ctx context.Context, cfg *config.Config, names []string) (map[string]candidateScreen, error) {
+	screens := make(map[string]candidateScreen, len(names))
+	checkNames := make([]string, 0, len(names))
+	for _, name := range names {
+		checkName, ok := screenName(name)
+		if !ok {
+			screens[screenKey(name)] = candidateScreen{Com: screenInvalid, NPM: screenInvalid}
+			continue
+		}
+		if _, seen := screens[checkName]; seen {
+			continue
+		}
+		screens[checkName] = candidateScreen{}
+		checkNames = append(checkNames, checkName)
+	}
+	if len(checkNames) == 0 {
+		return screens, nil
+	}
+
+	store, err := openStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close() // nolint:errcheck // best-effort cleanup; errors logged internally
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, true)
+	defer orchestrator.Wait()
+
+	profile := core.Profile{Name: "screen", TLDs: []string{screenTLD}, Registries: []string{screenRegistry}}
+	results, err := runBatchChecks(ctx, orchestrator, profile, checkNames, screenConcurrency, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range results {
+		if result != nil {
+			screens[checkNames[i]] = screenFromResults(result.Results)
+		}
+	}
+	return screens, nil
+}
+
+// screenKey is the lookup key of a candidate name in the screenCandidates
+// map.
+func screenKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func screenFromResults(results []*core.CheckResult) candidateScreen {
+	screen := candidateScreen{
+		Com: core.AvailabilityUnknown.String(),
+		NPM: core.AvailabilityUnknown.String(),
+	}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		switch result.CheckType {
+		case core.CheckTypeDomain:
+			screen.Com = result.Available.String()
+		case core.CheckTypeNPM:
+			screen.NPM = result.Available.String()
+		}
+	}
+	return screen
+}
+
+// lookupScreen returns the screen of a candidate, with empty labels shown as
+// unknown.
+func lookupScreen(screens map[string]candidateScreen, name string) candidateScreen {
+	screen := screens[screenKey(name)]
+	if screen.Com == "" {
+		screen.Com = core.AvailabilityUnknown.String()
+	}
+	if screen.NPM == "" {
+		screen.NPM = core.AvailabilityUnknown.String()
+	}
+	return screen
+}
+
+// annotateCandidates adds a "screen" object to each candidate of a generate
+// response. Other fields are kept; a response without a candidates list is
+// returned as is.
+func annotateCandidates(raw json.RawMessage, screens map[string]candidateScreen) json.RawMessage {
+	var result map[string]any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return raw
+	}
+	candidates, ok := result["candidates"].([]any)
+	if !ok {
+		return raw
+	}
+	for _, value := range candidates {
+		fields, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := fields["name"].(string)
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		fields["screen"] = lookupScreen(screens, name)
+	}
+	annotated, err := json.Marshal(result)
+	if err != nil {
+		return raw
+	}
+	return annotated
+}
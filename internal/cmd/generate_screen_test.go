@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestCandidateNames(t *testing.T) {
+	raw := json.RawMessage(`{"candidates":[{"name":"Lumen"},{"name":"  "},{"name":"brightly"}]}`)
+	require.Equal(t, []string{"Lumen", "brightly"}, candidateNames(raw))
+	require.Nil(t, candidateNames(json.RawMessage(`not json`)))
+}
+
+func TestScreenFromResults(t *testing.T) {
+	screen := screenFromResults([]*core.CheckResult{
+		{Name: "lumen.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken},
+		{Name: "lumen", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+	})
+	require.Equal(t, candidateScreen{Com: "taken", NPM: "available"}, screen)
+
+	require.Equal(t, candidateScreen{Com: "unknown", NPM: "unknown"}, screenFromResults(nil))
+}
+
+func TestScreenCandidatesSkipsInvalidNames(t *testing.T) {
+	screens, err := screenCandidates(context.Background(), &config.Config{}, []string{"Bad Name!", "under_score"})
+	require.NoError(t, err)
+	require.Equal(t, candidateScreen{Com: screenInvalid, NPM: screenInvalid}, lookupScreen(screens, "Bad Name!"))
+	require.Equal(t, candidateScreen{Com: screenInvalid, NPM: screenInvalid}, lookupScreen(screens, "under_score"))
+}
+
+func TestAnnotateCandidates(t *testing.T) {
+	raw := json.RawMessage(`{"candidates":[{"name":"Lumen","strength":"strong"},{"name":"other"}],"avoided_patterns":["x"]}`)
+	screens := map[string]candidateScreen{"lumen": {Com: "taken", NPM: "available"}}
+
+	var got struct {
+		Candidates []struct {
+			Name     string          `json:"name"`
+			Strength string          `json:"strength"`
+			Screen   candidateScreen `json:"screen"`
+		} `json:"candidates"`
+		AvoidedPatterns []string `json:"avoided_patterns"`
+	}
+	require.NoError(t, json.Unmarshal(annotateCandidates(raw, screens), &got))
+	require.Len(t, got.Candidates, 2)
+	require.Equal(t, "strong", got.Candidates[0].Strength)
+	require.Equal(t, candidateScreen{Com: "taken", NPM: "available"}, got.Candidates[0].Screen)
+	require.Equal(t, candidateScreen{Com: "unknown", NPM: "unknown"}, got.Candidates[1].Screen)
+	require.Equal(t, []string{"x"}, got.AvoidedPatterns)
+
+	require.JSONEq(t, `{"summary":"none"}`, string(annotateCandidates(json.RawMessage(`{"summary":"none"}`), screens)))
+}
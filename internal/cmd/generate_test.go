@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -61,3 +62,44 @@ func TestApplyGenerateProviderOverrideEmptyProviderNoop(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, cfg, out)
 }
+
+func TestNormalizeCandidates(t *testing.T) {
+	raw := json.RawMessage(`{
+		"candidates": [
+			{"name": "Zephyr", "strength": "moderate"},
+			{"name": "brightly", "strength": "strong"},
+			{"name": " zephyr ", "strength": "strong"},
+			{"name": "Acorn", "strength": "moderate"},
+			{"name": "Quill"}
+		],
+		"top_recommendations": [{"name": "Zephyr", "why": "short"}]
+	}`)
+
+	var result struct {
+		Candidates []struct {
+			Name string `json:"name"`
+		} `json:"candidates"`
+		TopRecommendations []map[string]string `json:"top_recommendations"`
+	}
+	require.NoError(t, json.Unmarshal(normalizeCandidates(raw), &result))
+	names := make([]string, 0, len(result.Candidates))
+	for _, c := range result.Candidates {
+		names = append(names, c.Name)
+	}
+	require.Equal(t, []string{"brightly", "Acorn", "Zephyr", "Quill"}, names)
+	require.Len(t, result.TopRecommendations, 1)
+
+	// Repeated runs over the same response give identical bytes.
+	require.Equal(t, normalizeCandidates(raw), normalizeCandidates(raw))
+
+	require.Equal(t, json.RawMessage(`not json`), normalizeCandidates(json.RawMessage(`not json`)))
+	require.Equal(t, json.RawMessage(`{"names":[]}`), normalizeCandidates(json.RawMessage(`{"names":[]}`)))
+}
+
+func TestDriverSupportsSeed(t *testing.T) {
+	require.True(t, driverSupportsSeed("openai", false))
+	require.True(t, driverSupportsSeed("openai", true))
+	require.True(t, driverSupportsSeed("xai", false))
+	require.False(t, driverSupportsSeed("xai", true))
+	require.False(t, driverSupportsSeed("anthropic", false))
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/output"
+)
+
+func addJUnitFlag(cmd *cobra.Command) {
+	cmd.Flags().String("junit-incomplete", string(output.JUnitPolicyError), "How --output-format junit reports lookups without an answer (error, rate limited, unknown): error, failure, skip")
+}
+
+// resolveJUnitFormatter returns the formatter for --output-format junit with
+// the --junit-incomplete policy.
+func resolveJUnitFormatter(cmd *cobra.Command) (*output.JUnitFormatter, error) {
+	value, err := cmd.Flags().GetString("junit-incomplete")
+	if err != nil {
+		return nil, err
+	}
+	policy, err := output.ParseJUnitPolicy(value)
+	if err != nil {
+		return nil, err
+	}
+	return &output.JUnitFormatter{Incomplete: policy}, nil
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestResolveJUnitFormatter(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("output-format", "table", "")
+	addJUnitFlag(cmd)
+	require.NoError(t, cmd.Flags().Parse([]string{"--output-format", "junit", "--junit-incomplete", "skip"}))
+
+	formatter, err := resolveJUnitFormatter(cmd)
+	require.NoError(t, err)
+	require.Equal(t, output.JUnitPolicySkip, formatter.Incomplete)
+
+	format, err := resolveCheckOutputFormat(cmd)
+	require.NoError(t, err)
+	require.Equal(t, output.FormatJUnit, format)
+	_, err = resolveReportOutputFormat(cmd)
+	require.ErrorContains(t, err, "only supported by check and batch")
+
+	require.NoError(t, cmd.Flags().Set("junit-incomplete", "ignore"))
+	_, err = resolveJUnitFormatter(cmd)
+	require.Error(t, err)
+}
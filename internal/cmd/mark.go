@@ -89,6 +89,9 @@ func runMark(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("loading config: %w", err)
 	}
+	if isOffline(cfg) {
+		return errors.New("mark needs an AI provider and is not available offline")
+	}
 
 	registry, err := buildPromptRegistry(cfg)
 	if err != nil {
@@ -136,12 +139,14 @@ func runMark(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("provider %q does not support image generation", resolvedImage.Driver.Name())
 	}
 
-	catalog, err := buildSchemaCatalog()
+	quotaStore, err := openQuotaStore(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("loading schemas: %w", err)
+		return err
 	}
-	service := &ailink.Service{Providers: providers, Registry: registry, Catalog: catalog}
-	_ = service
+	if quotaStore != nil {
+		defer quotaStore.Close() //nolint:errcheck
+	}
+	quota := aiQuota(cfg, quotaStore)
 
 	if imageModel == "" {
 		imageModel = resolvedImage.Model
@@ -158,7 +163,7 @@ func runMark(cmd *cobra.Command, args []string) error {
 	if strings.TrimSpace(audience) != "" {
 		vars["audience"] = strings.TrimSpace(audience)
 	}
-	markJSON, genErr, _ := runReviewGenerate(ctx, cfg, nil, promptSlug, name, depth, resolvedText.Model, vars, false)
+	markJSON, genErr, _ := runReviewGenerate(ctx, cfg, quotaStore, promptSlug, name, depth, resolvedText.Model, vars, false)
 	if genErr != nil {
 		return fmt.Errorf("mark prompt failed: %s: %s", genErr.Code, genErr.Message)
 	}
@@ -192,6 +197,10 @@ func runMark(cmd *cobra.Command, args []string) error {
 
 	for i := 0; i < limit; i++ {
 		mark := parsed.Marks[i]
+		// Image calls go straight to the driver, so count them here
+		if err := quota.Reserve(ctx); err != nil {
+			return err
+		}
 		imgResp, err := gen.GenerateImage(ctx, &driver.ImageRequest{
 			Model:        imageModel,
 			Prompt:       mark.ImagePrompt,
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func addMarketsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("markets", nil, "Target markets as country codes (e.g. US,DE,JP,BR); expands into locales, keyboards, ccTLDs, and sentiment languages (overrides the markets setting)")
+}
+
+// resolveMarkets applies --markets over the markets setting and expands the
+// result. When the flag is given it returns a copy of cfg carrying the flag's
+// markets, so expert searches made with the returned config see them too.
+func resolveMarkets(cmd *cobra.Command, cfg *config.Config) (*config.Config, core.MarketCoverage, error) {
+	if cmd.Flags().Changed("markets") {
+		values, err := cmd.Flags().GetStringSlice("markets")
+		if err != nil {
+			return nil, core.MarketCoverage{}, err
+		}
+		override := *cfg
+		override.Markets = values
+		cfg = &override
+	}
+	coverage, err := core.ExpandMarkets(cfg.Markets)
+	if err != nil {
+		return nil, core.MarketCoverage{}, fmt.Errorf("invalid markets: %w", err)
+	}
+	return cfg, coverage, nil
+}
+
+// applyMarketTLDs adds the markets' ccTLDs to the profile's TLDs.
+func applyMarketTLDs(profile *core.Profile, coverage core.MarketCoverage) {
+	if len(coverage.CCTLDs) > 0 {
+		profile.TLDs = normalizeTLDs(append(append([]string{}, profile.TLDs...), coverage.CCTLDs...))
+	}
+}
+
+// marketSearchVariables returns the expert search prompt variables implied
+// by the configured markets. Unknown codes are skipped here; commands report
+// them through resolveMarkets.
+func marketSearchVariables(cfg *config.Config) map[string]string {
+	if cfg == nil || len(cfg.Markets) == 0 {
+		return nil
+	}
+	coverage, _ := core.ExpandMarkets(cfg.Markets)
+	if len(coverage.Languages) == 0 {
+		return nil
+	}
+	return map[string]string{"languages": strings.Join(coverage.Languages, ", ")}
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func marketsTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	addMarketsFlag(cmd)
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestResolveMarketsUsesSettingUnlessFlagGiven(t *testing.T) {
+	cfg := &config.Config{Markets: []string{"US", "DE"}}
+
+	resolved, coverage, err := resolveMarkets(marketsTestCommand(t), cfg)
+	require.NoError(t, err)
+	require.Same(t, cfg, resolved)
+	require.Equal(t, []string{"US", "DE"}, coverage.Markets)
+
+	resolved, coverage, err = resolveMarkets(marketsTestCommand(t, "--markets", "jp,br"), cfg)
+	require.NoError(t, err)
+	require.Equal(t, []string{"jp", "br"}, resolved.Markets)
+	require.Equal(t, []string{"US", "DE"}, cfg.Markets)
+	require.Equal(t, []string{"ja-JP", "pt-BR"}, coverage.Locales)
+	require.Equal(t, map[string]string{"languages": "Japanese, Portuguese"}, marketSearchVariables(resolved))
+
+	_, _, err = resolveMarkets(marketsTestCommand(t, "--markets", "atlantis"), cfg)
+	require.ErrorContains(t, err, "invalid markets")
+}
+
+func TestApplyMarketTLDs(t *testing.T) {
+	profile := core.Profile{TLDs: []string{"com", "io"}}
+	coverage, err := core.ExpandMarkets([]string{"US", "DE", "GB"})
+	require.NoError(t, err)
+
+	applyMarketTLDs(&profile, coverage)
+	require.Equal(t, []string{"com", "de", "io", "uk", "us"}, profile.TLDs)
+	require.Nil(t, marketSearchVariables(&config.Config{}))
+}
@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// Result kinds merge accepts.
+const (
+	mergeKindBatch  = "batch"
+	mergeKindReview = "review"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <file>...",
+	Short: "Merge JSON results from sharded or repeated runs",
+	Long: `Merge the JSON output of check, batch, or review runs into one file.
+
+Inputs may hold one result or an array of results, as written by
+--output-format json and by --out-dir. All inputs must be the same kind:
+check/batch results or review results.
+
+Results are combined per name. Within a name, each target (a check type and
+name, such as domain acme.com or npm acme) keeps its newest result by
+resolved_at, so every target keeps the provenance of the lookup that produced
+it; on a tie the later input wins. Review analyses keep the newest run's copy
+per prompt. Scores are recomputed from the merged results, and request counts
+and AI usage are summed across inputs.`,
+	Example: `  namelens batch names.txt --shard 1/4 --output-format json --out results-1.json
+  namelens merge results-*.json -o combined.json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runMerge,
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().StringP("out", "o", "", "Write merged output to a file (default stdout)")
+}
+
+// mergeStats counts what a merge combined.
+type mergeStats struct {
+	Inputs     int
+	Names      int
+	Duplicates int
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	kind := ""
+	var (
+		batches []*core.BatchResult
+		reviews []*reviewResult
+	)
+	for _, path := range args {
+		data, err := os.ReadFile(path) // #nosec G304 -- user-provided result file
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		items, err := splitMergeInput(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for i, item := range items {
+			itemKind, err := detectMergeKind(item)
+			if err != nil {
+				return fmt.Errorf("%s: entry %d: %w", path, i+1, err)
+			}
+			if kind == "" {
+				kind = itemKind
+			} else if itemKind != kind {
+				return fmt.Errorf("%s: entry %d is a %s result, but earlier inputs are %s results", path, i+1, itemKind, kind)
+			}
+			switch itemKind {
+			case mergeKindBatch:
+				var batch core.BatchResult
+				if err := json.Unmarshal(item, &batch); err != nil {
+					return fmt.Errorf("%s: entry %d: %w", path, i+1, err)
+				}
+				batches = append(batches, &batch)
+			case mergeKindReview:
+				var review reviewResult
+				if err := json.Unmarshal(item, &review); err != nil {
+					return fmt.Errorf("%s: entry %d: %w", path, i+1, err)
+				}
+				reviews = append(reviews, &review)
+			}
+		}
+	}
+	if kind == "" {
+		return errors.New("no results found in the input files")
+	}
+
+	var (
+		merged any
+		stats  mergeStats
+	)
+	if kind == mergeKindReview {
+		merged, stats = mergeReviewResults(reviews)
+	} else {
+		merged, stats = mergeBatchResults(batches)
+	}
+	stats.Inputs = len(args)
+
+	payload, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return err
+	}
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(sink.writer, string(payload)); err != nil {
+		_ = sink.close()
+		return err
+	}
+	if err := sink.close(); err != nil {
+		return err
+	}
+
+	observability.CLILogger.Info("Merged results",
+		zap.String("kind", kind),
+		zap.Int("files", stats.Inputs),
+		zap.Int("names", stats.Names),
+		zap.Int("duplicates", stats.Duplicates),
+	)
+	return nil
+}
+
+// splitMergeInput returns the objects of a JSON array, or the single object.
+func splitMergeInput(data []byte) ([]json.RawMessage, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+	if trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, fmt.Errorf("parse JSON: %w", err)
+		}
+		return items, nil
+	}
+	if trimmed[0] != '{' {
+		return nil, errors.New("not a JSON result file")
+	}
+	return []json.RawMessage{trimmed}, nil
+}
+
+// detectMergeKind tells review results (with an availability section) from
+// check and batch results (with a results list).
+func detectMergeKind(item json.RawMessage) (string, error) {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(item, &probe); err != nil {
+		return "", fmt.Errorf("parse JSON: %w", err)
+	}
+	if _, ok := probe["name"]; !ok {
+		return "", errors.New("not a check, batch, or review result (no name)")
+	}
+	if _, ok := probe["availability"]; ok {
+		return mergeKindReview, nil
+	}
+	if _, ok := probe["results"]; ok {
+		return mergeKindBatch, nil
+	}
+	return "", errors.New("not a check, batch, or review result")
+}
+
+// mergeBatchResults combines batches per name in order of first appearance.
+func mergeBatchResults(batches []*core.BatchResult) ([]*core.BatchResult, mergeStats) {
+	var (
+		merged []*core.BatchResult
+		stats  mergeStats
+	)
+	index := map[string]*core.BatchResult{}
+	for _, batch := range batches {
+		if batch == nil {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(batch.Name))
+		existing, ok := index[key]
+		if !ok {
+			copied := *batch
+			copied.Results = mergeCheckResults(nil, time.Time{}, batch.Results, batch.CompletedAt, &stats)
+			copied.Alternatives = mergeCheckResults(nil, time.Time{}, batch.Alternatives, batch.CompletedAt, &stats)
+			copied.Requests = addRequestCounts(nil, batch.Requests)
+			copied.AIUsage = addUsage(nil, batch.AIUsage)
+			index[key] = &copied
+			merged = append(merged, &copied)
+			continue
+		}
+
+		newer := !batch.CompletedAt.Before(existing.CompletedAt)
+		existing.Results = mergeCheckResults(existing.Results, existing.CompletedAt, batch.Results, batch.CompletedAt, &stats)
+		existing.Alternatives = mergeCheckResults(existing.Alternatives, existing.CompletedAt, batch.Alternatives, batch.CompletedAt, &stats)
+		existing.Requests = addRequestCounts(existing.Requests, batch.Requests)
+		existing.AIUsage = addUsage(existing.AIUsage, batch.AIUsage)
+		if newer {
+			existing.CompletedAt = batch.CompletedAt
+		}
+		// AI analyses are replaced as a unit, result and error together
+		if (batch.AILink != nil || batch.AILinkError != nil) && (newer || (existing.AILink == nil && existing.AILinkError == nil)) {
+			existing.AILink, existing.AILinkError = batch.AILink, batch.AILinkError
+		}
+		if (len(batch.Phonetics) > 0 || batch.PhoneticsError != nil) && (newer || (len(existing.Phonetics) == 0 && existing.PhoneticsError == nil)) {
+			existing.Phonetics, existing.PhoneticsError = batch.Phonetics, batch.PhoneticsError
+		}
+		if (len(batch.Suitability) > 0 || batch.SuitabilityError != nil) && (newer || (len(existing.Suitability) == 0 && existing.SuitabilityError == nil)) {
+			existing.Suitability, existing.SuitabilityError = batch.Suitability, batch.SuitabilityError
+		}
+	}
+	for _, batch := range merged {
+		batch.Score, batch.Total, batch.Unknown = scoreResults(batch.Results)
+	}
+	stats.Names = len(merged)
+	return merged, stats
+}
+
+// mergeReviewResults combines reviews per name in order of first appearance.
+func mergeReviewResults(reviews []*reviewResult) ([]*reviewResult, mergeStats) {
+	var (
+		merged []*reviewResult
+		stats  mergeStats
+	)
+	index := map[string]*reviewResult{}
+	for _, review := range reviews {
+		if review == nil {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(review.Name))
+		existing, ok := index[key]
+		if !ok {
+			copied := *review
+			copied.Availability.Results = mergeCheckResults(nil, time.Time{}, review.Availability.Results, review.Availability.CompletedAt, &stats)
+			copied.Analyses = make(map[string]reviewAnalysis, len(review.Analyses))
+			for slug, analysis := range review.Analyses {
+				copied.Analyses[slug] = analysis
+			}
+			index[key] = &copied
+			merged = append(merged, &copied)
+			continue
+		}
+
+		newer := !review.CompletedAt.Before(existing.CompletedAt)
+		existing.Availability.Results = mergeCheckResults(existing.Availability.Results, existing.Availability.CompletedAt, review.Availability.Results, review.Availability.CompletedAt, &stats)
+		if review.Availability.CompletedAt.After(existing.Availability.CompletedAt) {
+			existing.Availability.CompletedAt = review.Availability.CompletedAt
+		}
+		for slug, analysis := range review.Analyses {
+			if _, ok := existing.Analyses[slug]; ok && !newer {
+				continue
+			}
+			existing.Analyses[slug] = analysis
+		}
+		if !review.StartedAt.IsZero() && (existing.StartedAt.IsZero() || review.StartedAt.Before(existing.StartedAt)) {
+			existing.StartedAt = review.StartedAt
+		}
+		if newer {
+			existing.CompletedAt = review.CompletedAt
+			existing.Profile, existing.Mode, existing.Depth = review.Profile, review.Mode, review.Depth
+		}
+	}
+	for _, review := range merged {
+		review.Availability.Score, review.Availability.Total, review.Availability.Unknown = scoreResults(review.Availability.Results)
+		review.Usage = summarizeReviewUsage(review.Analyses)
+	}
+	stats.Names = len(merged)
+	return merged, stats
+}
+
+// mergeCheckResults adds incoming results to existing, keeping the newest
+// result per target. A result without a resolved_at time is dated by the
+// run that produced it (existingAt or incomingAt); on a tie incoming wins.
+func mergeCheckResults(existing []*core.CheckResult, existingAt time.Time, incoming []*core.CheckResult, incomingAt time.Time, stats *mergeStats) []*core.CheckResult {
+	positions := make(map[string]int, len(existing))
+	dates := make(map[string]time.Time, len(existing))
+	for i, result := range existing {
+		if result == nil {
+			continue
+		}
+		key := mergeTargetKey(result)
+		positions[key] = i
+		dates[key] = resultResolvedAt(result, existingAt)
+	}
+	for _, result := range incoming {
+		if result == nil {
+			continue
+		}
+		key := mergeTargetKey(result)
+		at := resultResolvedAt(result, incomingAt)
+		i, ok := positions[key]
+		if !ok {
+			positions[key] = len(existing)
+			dates[key] = at
+			existing = append(existing, result)
+			continue
+		}
+		stats.Duplicates++
+		if at.Before(dates[key]) {
+			continue
+		}
+		existing[i] = result
+		dates[key] = at
+	}
+	return existing
+}
+
+func mergeTargetKey(result *core.CheckResult) string {
+	return string(result.CheckType) + "|" + strings.ToLower(result.Name) + "|" + strings.ToLower(result.TLD)
+}
+
+func resultResolvedAt(result *core.CheckResult, fallback time.Time) time.Time {
+	if !result.Provenance.ResolvedAt.IsZero() {
+		return result.Provenance.ResolvedAt
+	}
+	return fallback
+}
+
+func addRequestCounts(total, counts map[string]int) map[string]int {
+	if len(counts) == 0 {
+		return total
+	}
+	if total == nil {
+		total = make(map[string]int, len(counts))
+	}
+	for category, n := range counts {
+		total[category] += n
+	}
+	return total
+}
+
+func addUsage(total, usage *ailink.Usage) *ailink.Usage {
+	if usage == nil {
+		return total
+	}
+	sum := ailink.Usage{}
+	if total != nil {
+		sum = *total
+	}
+	sum.Add(*usage)
+	return &sum
+}
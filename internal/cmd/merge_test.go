@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func mergeTestResult(name string, available core.Availability, resolvedAt time.Time) *core.CheckResult {
+	return &core.CheckResult{
+		Name:       name,
+		CheckType:  core.CheckTypeDomain,
+		Available:  available,
+		Provenance: core.Provenance{ResolvedAt: resolvedAt, CheckID: name + "@" + resolvedAt.Format(time.RFC3339)},
+	}
+}
+
+func TestMergeBatchResultsNewestWins(t *testing.T) {
+	day1 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	first := []*core.BatchResult{
+		{Name: "acme", CompletedAt: day2, Requests: map[string]int{"rdap": 2}, Results: []*core.CheckResult{
+			mergeTestResult("acme.com", core.AvailabilityTaken, day2),
+			mergeTestResult("acme.io", core.AvailabilityAvailable, day1),
+		}},
+		{Name: "zeta", CompletedAt: day1, Results: []*core.CheckResult{
+			mergeTestResult("zeta.com", core.AvailabilityAvailable, day1),
+		}},
+	}
+	second := []*core.BatchResult{
+		{Name: "ACME", CompletedAt: day1, Requests: map[string]int{"rdap": 1}, Results: []*core.CheckResult{
+			// Older than the first run's acme.com; must not replace it
+			mergeTestResult("acme.com", core.AvailabilityAvailable, day1),
+			// Newer acme.io replaces the first run's
+			mergeTestResult("acme.io", core.AvailabilityTaken, day2),
+			mergeTestResult("acme.dev", core.AvailabilityUnknown, day1),
+		}},
+	}
+
+	merged, stats := mergeBatchResults(append(first, second...))
+	require.Len(t, merged, 2)
+	require.Equal(t, 2, stats.Names)
+	require.Equal(t, 2, stats.Duplicates)
+
+	acme := merged[0]
+	require.Equal(t, "acme", acme.Name)
+	require.Len(t, acme.Results, 3)
+	require.Equal(t, core.AvailabilityTaken, acme.Results[0].Available)
+	require.Equal(t, day2, acme.Results[0].Provenance.ResolvedAt)
+	require.Equal(t, core.AvailabilityTaken, acme.Results[1].Available)
+	require.Equal(t, day2, acme.Results[1].Provenance.ResolvedAt)
+	require.Equal(t, "acme.dev", acme.Results[2].Name)
+	require.Equal(t, 0, acme.Score)
+	require.Equal(t, 2, acme.Total)
+	require.Equal(t, 1, acme.Unknown)
+	require.Equal(t, day2, acme.CompletedAt)
+	require.Equal(t, map[string]int{"rdap": 3}, acme.Requests)
+
+	// Inputs are not modified
+	require.Len(t, first[0].Results, 2)
+	require.Equal(t, map[string]int{"rdap": 2}, first[0].Requests)
+
+	require.Equal(t, "zeta", merged[1].Name)
+	require.Equal(t, 1, merged[1].Score)
+}
+
+func TestMergeCheckResultsTieGoesToLaterInput(t *testing.T) {
+	at := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	var stats mergeStats
+	existing := []*core.CheckResult{{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable}}
+	incoming := []*core.CheckResult{{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken}}
+
+	merged := mergeCheckResults(existing, at, incoming, at, &stats)
+	require.Len(t, merged, 1)
+	require.Equal(t, core.AvailabilityTaken, merged[0].Available)
+
+	// Without resolved_at, results are dated by their run
+	merged = mergeCheckResults(merged, at.Add(time.Hour), []*core.CheckResult{{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable}}, at, &stats)
+	require.Equal(t, core.AvailabilityTaken, merged[0].Available)
+	require.Equal(t, 2, stats.Duplicates)
+}
+
+func TestMergeReviewResults(t *testing.T) {
+	day1 := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	older := &reviewResult{
+		Name: "acme", Mode: "quick", StartedAt: day1, CompletedAt: day1,
+		Availability: reviewAvailability{CompletedAt: day1, Results: []*core.CheckResult{mergeTestResult("acme.com", core.AvailabilityAvailable, day1)}},
+		Analyses: map[string]reviewAnalysis{
+			"name-availability": {OK: false, DurationMS: 100},
+			"name-phonetics":    {OK: true, DurationMS: 50},
+		},
+	}
+	newer := &reviewResult{
+		Name: "acme", Mode: "full", StartedAt: day2, CompletedAt: day2,
+		Availability: reviewAvailability{CompletedAt: day2, Results: []*core.CheckResult{mergeTestResult("acme.com", core.AvailabilityTaken, day2)}},
+		Analyses: map[string]reviewAnalysis{
+			"name-availability": {OK: true, DurationMS: 200},
+		},
+	}
+
+	merged, stats := mergeReviewResults([]*reviewResult{newer, older})
+	require.Len(t, merged, 1)
+	require.Equal(t, 1, stats.Duplicates)
+
+	review := merged[0]
+	require.Equal(t, "full", review.Mode)
+	require.Equal(t, day1, review.StartedAt)
+	require.Equal(t, day2, review.CompletedAt)
+	require.Equal(t, core.AvailabilityTaken, review.Availability.Results[0].Available)
+	require.Equal(t, 0, review.Availability.Score)
+	require.True(t, review.Analyses["name-availability"].OK)
+	require.True(t, review.Analyses["name-phonetics"].OK)
+	require.Equal(t, 2, review.Usage.Analyses)
+	require.Equal(t, int64(250), review.Usage.DurationMS)
+	require.Len(t, newer.Analyses, 1)
+}
+
+func TestDetectMergeKind(t *testing.T) {
+	items, err := splitMergeInput([]byte(` [{"name":"acme","results":[]},{"name":"zeta","availability":{}}] `))
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	kind, err := detectMergeKind(items[0])
+	require.NoError(t, err)
+	require.Equal(t, mergeKindBatch, kind)
+	kind, err = detectMergeKind(items[1])
+	require.NoError(t, err)
+	require.Equal(t, mergeKindReview, kind)
+
+	_, err = detectMergeKind(json.RawMessage(`{"name":"acme"}`))
+	require.Error(t, err)
+	_, err = splitMergeInput([]byte("name,status\n"))
+	require.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// nameShard selects every Count-th name starting at Index (1-based).
+type nameShard struct {
+	Index int
+	Count int
+}
+
+func addNameSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().String("shard", "", "Check only shard K of N of the names (e.g. 3/10), for splitting a list across parallel jobs")
+	cmd.Flags().Int("sample", 0, "Check a random sample of this many names (after --shard)")
+}
+
+// parseShard parses "K/N" with 1 <= K <= N.
+func parseShard(value string) (nameShard, error) {
+	index, count, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return nameShard{}, fmt.Errorf("invalid --shard %q: expected K/N, e.g. 3/10", value)
+	}
+	k, errK := strconv.Atoi(strings.TrimSpace(index))
+	n, errN := strconv.Atoi(strings.TrimSpace(count))
+	if errK != nil || errN != nil {
+		return nameShard{}, fmt.Errorf("invalid --shard %q: expected K/N, e.g. 3/10", value)
+	}
+	if n < 1 || k < 1 || k > n {
+		return nameShard{}, fmt.Errorf("invalid --shard %q: K must be between 1 and N", value)
+	}
+	return nameShard{Index: k, Count: n}, nil
+}
+
+// selectNames narrows names to the --shard and --sample flags, logging the
+// selection when it removes any.
+func selectNames(cmd *cobra.Command, names []string) ([]string, error) {
+	shardValue, err := cmd.Flags().GetString("shard")
+	if err != nil {
+		return nil, err
+	}
+	sample, err := cmd.Flags().GetInt("sample")
+	if err != nil {
+		return nil, err
+	}
+	if sample < 0 {
+		return nil, errors.New("--sample must be 0 or greater")
+	}
+
+	total := len(names)
+	if strings.TrimSpace(shardValue) != "" {
+		shard, err := parseShard(shardValue)
+		if err != nil {
+			return nil, err
+		}
+		names = shardNames(names, shard)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("shard %d/%d has no names (%d names in total)", shard.Index, shard.Count, total)
+		}
+	}
+	names = sampleNames(names, sample, rand.Shuffle)
+
+	if len(names) < total {
+		observability.CLILogger.Info("Selected names",
+			zap.Int("selected", len(names)),
+			zap.Int("total", total),
+			zap.String("shard", shardValue),
+			zap.Int("sample", sample),
+		)
+	}
+	return names, nil
+}
+
+// shardNames deals names round-robin into shard.Count shards and returns
+// shard.Index, so shards differ in size by at most one name and every name
+// lands in exactly one shard as long as all jobs read the same list.
+func shardNames(names []string, shard nameShard) []string {
+	selected := make([]string, 0, len(names)/shard.Count+1)
+	for i := shard.Index - 1; i < len(names); i += shard.Count {
+		selected = append(selected, names[i])
+	}
+	return selected
+}
+
+// sampleNames returns sample names picked with shuffle, in their original
+// order, or all names when sample is 0 or covers the list.
+func sampleNames(names []string, sample int, shuffle func(n int, swap func(i, j int))) []string {
+	if sample <= 0 || sample >= len(names) {
+		return names
+	}
+	indexes := make([]int, len(names))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	shuffle(len(indexes), func(i, j int) {
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+	})
+	indexes = indexes[:sample]
+	sort.Ints(indexes)
+
+	selected := make([]string, 0, sample)
+	for _, i := range indexes {
+		selected = append(selected, names[i])
+	}
+	return selected
+}
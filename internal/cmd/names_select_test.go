@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShard(t *testing.T) {
+	shard, err := parseShard(" 3/10 ")
+	require.NoError(t, err)
+	require.Equal(t, nameShard{Index: 3, Count: 10}, shard)
+
+	for _, bad := range []string{"3", "0/10", "11/10", "a/b", "1/0", "-1/2"} {
+		_, err := parseShard(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestShardNamesCoversEveryNameOnce(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g"}
+	require.Equal(t, []string{"a", "d", "g"}, shardNames(names, nameShard{Index: 1, Count: 3}))
+	require.Equal(t, []string{"b", "e"}, shardNames(names, nameShard{Index: 2, Count: 3}))
+	require.Equal(t, []string{"c", "f"}, shardNames(names, nameShard{Index: 3, Count: 3}))
+	require.Empty(t, shardNames(names[:1], nameShard{Index: 2, Count: 3}))
+}
+
+func TestSampleNamesKeepsOrder(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e"}
+	reverse := func(n int, swap func(i, j int)) {
+		for i := 0; i < n/2; i++ {
+			swap(i, n-1-i)
+		}
+	}
+	require.Equal(t, []string{"d", "e"}, sampleNames(names, 2, reverse))
+	require.Equal(t, names, sampleNames(names, 0, reverse))
+	require.Equal(t, names, sampleNames(names, 10, reverse))
+}
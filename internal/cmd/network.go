@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/audit"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/network"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// configuredResolver returns the resolver for network.resolvers. It falls back
+// to the system resolver when none are configured or the list is invalid.
+func configuredResolver(cfg *config.Config) *net.Resolver {
+	if cfg == nil || len(cfg.Network.Resolvers) == 0 {
+		return net.DefaultResolver
+	}
+	resolver, err := network.NewResolver(cfg.Network.Resolvers)
+	if err != nil {
+		if observability.CLILogger != nil {
+			observability.CLILogger.Warn("Invalid network.resolvers; using system resolver", zap.Error(err))
+		}
+		return net.DefaultResolver
+	}
+	return resolver
+}
+
+// dnsFallbackResolver returns the resolver for the DNS fallback:
+// domain.dns_fallback.resolvers when set, else network.resolvers.
+func dnsFallbackResolver(cfg *config.Config) *net.Resolver {
+	if cfg == nil || len(cfg.Domain.DNSFallback.Resolvers) == 0 {
+		return configuredResolver(cfg)
+	}
+	resolver, err := network.NewResolver(cfg.Domain.DNSFallback.Resolvers)
+	if err != nil {
+		if observability.CLILogger != nil {
+			observability.CLILogger.Warn("Invalid domain.dns_fallback.resolvers; using network.resolvers", zap.Error(err))
+		}
+		return configuredResolver(cfg)
+	}
+	return resolver
+}
+
+// configuredDialer returns the dialer for network.ip_strategy and
+// network.proxy, falling back to racing IPv6 and IPv4 when the strategy is
+// invalid and to the proxy environment variables when the proxy is.
+func configuredDialer(cfg *config.Config) *network.Dialer {
+	strategy, proxySetting := "", ""
+	if cfg != nil {
+		strategy, proxySetting = cfg.Network.IPStrategy, cfg.Network.Proxy
+	}
+	dialer, err := network.NewDialer(strategy)
+	if err != nil {
+		if observability.CLILogger != nil {
+			observability.CLILogger.Warn("Invalid network.ip_strategy; racing IPv6 and IPv4", zap.Error(err))
+		}
+		dialer, _ = network.NewDialer(network.StrategyRace)
+	}
+	proxy, err := network.ParseProxy(proxySetting)
+	if err != nil && observability.CLILogger != nil {
+		observability.CLILogger.Warn("Invalid network.proxy; using HTTP_PROXY and HTTPS_PROXY", zap.Error(err))
+	}
+	dialer.Proxy = proxy
+	return dialer
+}
+
+// proxyCheckers are the checker kinds network.checker_proxies accepts.
+var proxyCheckers = []string{audit.KindRDAP, audit.KindWHOIS, audit.KindRegistry, audit.KindHandle, audit.KindHTTP}
+
+// checkerDialers returns a copy of base for each checker kind with its own
+// proxy in network.checker_proxies. Unknown kinds and invalid proxies are
+// skipped with a warning.
+func checkerDialers(cfg *config.Config, base *network.Dialer) map[string]*network.Dialer {
+	if cfg == nil || len(cfg.Network.CheckerProxies) == 0 {
+		return nil
+	}
+	dialers := make(map[string]*network.Dialer, len(cfg.Network.CheckerProxies))
+	for kind, setting := range cfg.Network.CheckerProxies {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		if !slices.Contains(proxyCheckers, kind) {
+			if observability.CLILogger != nil {
+				observability.CLILogger.Warn("Unknown checker in network.checker_proxies; skipping", zap.String("checker", kind), zap.Strings("valid", proxyCheckers))
+			}
+			continue
+		}
+		proxy, err := network.ParseProxy(setting)
+		if err != nil {
+			if observability.CLILogger != nil {
+				observability.CLILogger.Warn("Invalid network.checker_proxies entry; using network.proxy", zap.String("checker", kind), zap.Error(err))
+			}
+			continue
+		}
+		dialers[kind] = base.WithProxy(proxy)
+	}
+	return dialers
+}
+
+// checkerTransport returns the transport for a checker kind: fallback, or a
+// transport through the kind's own proxy.
+func checkerTransport(dialers map[string]*network.Dialer, kind string, fallback http.RoundTripper) http.RoundTripper {
+	dialer, ok := dialers[kind]
+	if !ok {
+		return fallback
+	}
+	return chaosWrap(dialer.Transport(), kind == audit.KindRDAP)
+}
+
+// checkerDialer returns the dialer for raw TCP connections of a checker kind.
+func checkerDialer(dialers map[string]*network.Dialer, kind string, fallback *network.Dialer) *network.Dialer {
+	if dialer, ok := dialers[kind]; ok {
+		return dialer
+	}
+	return fallback
+}
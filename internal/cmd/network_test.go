@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+)
+
+func TestCheckerDialers(t *testing.T) {
+	cfg := &config.Config{Network: config.NetworkConfig{
+		IPStrategy: "prefer_ipv4",
+		Proxy:      "http://proxy.example.com:3128",
+		CheckerProxies: map[string]string{
+			"WHOIS":    "socks5://proxy.example.com:1080",
+			"registry": "direct",
+			"ftp":      "direct",
+			"rdap":     "not a proxy",
+		},
+	}}
+
+	base := configuredDialer(cfg)
+	require.Equal(t, "http://proxy.example.com:3128", base.Proxy.String())
+
+	dialers := checkerDialers(cfg, base)
+	require.Len(t, dialers, 2)
+	require.Equal(t, "socks5://proxy.example.com:1080", dialers["whois"].Proxy.String())
+	require.Equal(t, "prefer_ipv4", dialers["whois"].Strategy)
+	require.Equal(t, "direct", dialers["registry"].Proxy.String())
+
+	require.Same(t, base, checkerDialer(dialers, "rdap", base))
+	require.Same(t, dialers["whois"], checkerDialer(dialers, "whois", base))
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+)
+
+// offlineMode is set by the global --offline flag.
+var offlineMode bool
+
+// isOffline reports whether lookups must be answered from cache only, via
+// --offline or the offline config option.
+func isOffline(cfg *config.Config) bool {
+	return offlineMode || (cfg != nil && cfg.Offline)
+}
+
+// offlineSearchError is returned by AI helpers in offline mode when no cached
+// response exists.
+func offlineSearchError() *ailink.SearchError {
+	return &ailink.SearchError{Code: "AILINK_OFFLINE", Message: "offline: no cached AI response"}
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/redact"
+)
+
+// Archive entry kinds recorded in the manifest.
+const (
+	archiveKindIndex      = "index"
+	archiveKindOutput     = "output"
+	archiveKindEvidence   = "evidence"
+	archiveKindProvenance = "provenance"
+)
+
+const archiveManifestName = "manifest.json"
+
+// outputArchive collects the files of an out-dir style run so --out-archive
+// can bundle them, with evidence and run provenance, into a single zip.
+type outputArchive struct {
+	path      string
+	command   string
+	startedAt time.Time
+	files     []archiveFile
+}
+
+type archiveFile struct {
+	Path   string `json:"path"`
+	Kind   string `json:"kind"`
+	Bytes  int    `json:"bytes"`
+	SHA256 string `json:"sha256"`
+	data   []byte
+}
+
+type archiveManifest struct {
+	Version   string        `json:"version,omitempty"`
+	Command   string        `json:"command"`
+	CreatedAt time.Time     `json:"created_at"`
+	Files     []archiveFile `json:"files"`
+}
+
+// archiveProvenance describes the run that produced an archive.
+type archiveProvenance struct {
+	Command      string         `json:"command"`
+	Args         []string       `json:"args"`
+	Version      string         `json:"version,omitempty"`
+	StartedAt    time.Time      `json:"started_at"`
+	CompletedAt  time.Time      `json:"completed_at"`
+	OutputFormat string         `json:"output_format"`
+	Profile      string         `json:"profile,omitempty"`
+	Names        []string       `json:"names"`
+	Cache        bool           `json:"cache"`
+	Offline      bool           `json:"offline,omitempty"`
+	Requests     map[string]int `json:"requests,omitempty"`
+}
+
+func addOutArchiveFlag(cmd *cobra.Command) {
+	cmd.Flags().String("out-archive", "", "Bundle per-name outputs, index, evidence, and run provenance into a zip file")
+}
+
+// resolveOutputArchive returns the archive requested with --out-archive, or
+// nil when the flag is unset.
+func resolveOutputArchive(cmd *cobra.Command, command string, startedAt time.Time) (*outputArchive, error) {
+	archivePath, err := cmd.Flags().GetString("out-archive")
+	if err != nil {
+		return nil, err
+	}
+	archivePath = strings.TrimSpace(archivePath)
+	if archivePath == "" {
+		return nil, nil
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(outPath) != "" {
+		return nil, fmt.Errorf("--out and --out-archive are mutually exclusive")
+	}
+	return &outputArchive{path: archivePath, command: command, startedAt: startedAt}, nil
+}
+
+// openRunSink opens a per-run output file. The file is written under outDir
+// when set and captured for the archive when one is requested.
+func openRunSink(archive *outputArchive, outDir, name, kind string) (*outputSink, error) {
+	var (
+		file *outputSink
+		err  error
+	)
+	if outDir != "" {
+		file, err = openSink(filepath.Join(outDir, name))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if archive == nil {
+		return file, nil
+	}
+
+	buf := &bytes.Buffer{}
+	sink := &outputSink{writer: buf, path: name}
+	if file != nil {
+		sink.writer = io.MultiWriter(file.writer, buf)
+		sink.path = file.path
+	}
+	closed := false
+	sink.close = func() error {
+		if closed {
+			return nil
+		}
+		closed = true
+		archive.add(name, kind, buf.Bytes())
+		if file != nil {
+			return file.close()
+		}
+		return nil
+	}
+	return sink, nil
+}
+
+func (a *outputArchive) add(name, kind string, data []byte) {
+	data = []byte(redact.String(string(data)))
+	sum := sha256.Sum256(data)
+	a.files = append(a.files, archiveFile{
+		Path:   path.Clean(filepath.ToSlash(name)),
+		Kind:   kind,
+		Bytes:  len(data),
+		SHA256: hex.EncodeToString(sum[:]),
+		data:   data,
+	})
+}
+
+func (a *outputArchive) addJSON(name, kind string, value any) error {
+	payload, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	a.add(name, kind, append(payload, '\n'))
+	return nil
+}
+
+// addEvidence stores the machine-readable result for one name, regardless of
+// the selected output format.
+func (a *outputArchive) addEvidence(name string, value any) error {
+	return a.addJSON(path.Join("evidence", sanitizeFilename(name)+".json"), archiveKindEvidence, value)
+}
+
+// write records the run provenance and writes the zip, manifest first.
+func (a *outputArchive) write(provenance archiveProvenance) error {
+	provenance.Command = a.command
+	provenance.Args = os.Args[1:]
+	provenance.Version = versionInfo.Version
+	provenance.StartedAt = a.startedAt.UTC()
+	provenance.CompletedAt = time.Now().UTC()
+	if err := a.addJSON("provenance.json", archiveKindProvenance, provenance); err != nil {
+		return err
+	}
+
+	manifest := archiveManifest{
+		Version:   versionInfo.Version,
+		Command:   a.command,
+		CreatedAt: provenance.CompletedAt,
+		Files:     a.files,
+	}
+	payload, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(a.path), 0755); err != nil { // #nosec G301 -- user-provided --out-archive path
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+	file, err := os.Create(a.path) // #nosec G304 -- user-provided --out-archive path
+	if err != nil {
+		return fmt.Errorf("create archive: %w", err)
+	}
+
+	zw := zip.NewWriter(file)
+	entries := append([]archiveFile{{Path: archiveManifestName, data: append(payload, '\n')}}, a.files...)
+	for _, entry := range entries {
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     entry.Path,
+			Method:   zip.Deflate,
+			Modified: provenance.CompletedAt,
+		})
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("write archive: %w", err)
+		}
+		if _, err := w.Write(entry.data); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("write archive: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("write archive: %w", err)
+	}
+	return file.Close()
+}
+
+// writeBatchArchive adds each batch as evidence and writes the archive.
+func writeBatchArchive(archive *outputArchive, batches []*core.BatchResult, provenance archiveProvenance) error {
+	for _, batch := range batches {
+		if batch == nil {
+			continue
+		}
+		if err := archive.addEvidence(batch.Name, batch); err != nil {
+			return err
+		}
+	}
+	return archive.write(provenance)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestOutputArchiveWrite(t *testing.T) {
+	dir := t.TempDir()
+	outDir := filepath.Join(dir, "out")
+	archive := &outputArchive{path: filepath.Join(dir, "bundle.zip"), command: "check", startedAt: time.Now()}
+
+	sink, err := openRunSink(archive, outDir, "check.index.md", archiveKindIndex)
+	require.NoError(t, err)
+	_, err = fmt.Fprint(sink.writer, "# index\n")
+	require.NoError(t, err)
+	require.NoError(t, sink.close())
+	require.NoError(t, sink.close())
+
+	onDisk, err := os.ReadFile(filepath.Join(outDir, "check.index.md"))
+	require.NoError(t, err)
+	require.Equal(t, "# index\n", string(onDisk))
+
+	require.NoError(t, writeBatchArchive(archive, []*core.BatchResult{{Name: "Acme"}, nil}, archiveProvenance{
+		OutputFormat: "markdown",
+		Names:        []string{"acme"},
+	}))
+
+	reader, err := zip.OpenReader(archive.path)
+	require.NoError(t, err)
+	defer reader.Close() //nolint:errcheck
+
+	contents := map[string][]byte{}
+	names := make([]string, 0, len(reader.File))
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		names = append(names, file.Name)
+		contents[file.Name] = data
+	}
+	require.Equal(t, []string{"manifest.json", "check.index.md", "evidence/acme.json", "provenance.json"}, names)
+
+	var manifest archiveManifest
+	require.NoError(t, json.Unmarshal(contents["manifest.json"], &manifest))
+	require.Equal(t, "check", manifest.Command)
+	require.Len(t, manifest.Files, 3)
+	for _, entry := range manifest.Files {
+		sum := sha256.Sum256(contents[entry.Path])
+		require.Equal(t, hex.EncodeToString(sum[:]), entry.SHA256, entry.Path)
+		require.Equal(t, len(contents[entry.Path]), entry.Bytes, entry.Path)
+	}
+	require.Equal(t, archiveKindEvidence, manifest.Files[1].Kind)
+
+	var provenance archiveProvenance
+	require.NoError(t, json.Unmarshal(contents["provenance.json"], &provenance))
+	require.Equal(t, "check", provenance.Command)
+	require.Equal(t, "markdown", provenance.OutputFormat)
+}
+
+func TestOpenRunSinkArchiveOnly(t *testing.T) {
+	archive := &outputArchive{path: filepath.Join(t.TempDir(), "bundle.zip")}
+
+	sink, err := openRunSink(archive, "", "acme.check.txt", archiveKindOutput)
+	require.NoError(t, err)
+	_, err = fmt.Fprint(sink.writer, "acme")
+	require.NoError(t, err)
+	require.NoError(t, sink.close())
+
+	require.Len(t, archive.files, 1)
+	require.Equal(t, "acme.check.txt", archive.files[0].Path)
+	require.Equal(t, "acme", string(archive.files[0].data))
+}
+
+func TestRunSinksRedactSecrets(t *testing.T) {
+	outDir := t.TempDir()
+	archive := &outputArchive{path: filepath.Join(t.TempDir(), "bundle.zip")}
+
+	sink, err := openRunSink(archive, outDir, "acme.check.json", archiveKindOutput)
+	require.NoError(t, err)
+	_, err = fmt.Fprint(sink.writer, `{"details":"Incorrect API key provided: sk-proj-abcdefghijklmnop1234"}`)
+	require.NoError(t, err)
+	require.NoError(t, sink.close())
+
+	onDisk, err := os.ReadFile(filepath.Join(outDir, "acme.check.json"))
+	require.NoError(t, err)
+	require.NotContains(t, string(onDisk), "sk-proj-abcdefghijklmnop1234")
+	require.Contains(t, string(onDisk), "[REDACTED]")
+
+	require.Len(t, archive.files, 1)
+	require.NotContains(t, string(archive.files[0].data), "sk-proj-abcdefghijklmnop1234")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/redact"
 )
 
 type outputSink struct {
@@ -23,8 +24,14 @@ func outputExtension(format output.Format) string {
 	switch format {
 	case output.FormatJSON:
 		return "json"
-	case output.FormatMarkdown:
+	case output.FormatMarkdown, output.FormatPRComment:
 		return "md"
+	case output.FormatHTML:
+		return "html"
+	case output.FormatCSV:
+		return "csv"
+	case output.FormatJUnit:
+		return "xml"
 	default:
 		return "txt"
 	}
@@ -42,7 +49,38 @@ func sanitizeFilename(value string) string {
 	return clean
 }
 
+// resolveOutputFormat reads --output-format for commands with their own
+// table, json, and markdown renderers.
 func resolveOutputFormat(cmd *cobra.Command) (output.Format, error) {
+	format, err := resolveCheckOutputFormat(cmd)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case output.FormatPRComment, output.FormatJUnit:
+		return "", fmt.Errorf("--output-format %s is only supported by check and batch", format)
+	case output.FormatHTML, output.FormatCSV:
+		return "", fmt.Errorf("--output-format %s is only supported by check, batch, review, and compare", format)
+	}
+	return format, nil
+}
+
+// resolveReportOutputFormat reads --output-format for review and compare,
+// which also render HTML reports and CSV tables.
+func resolveReportOutputFormat(cmd *cobra.Command) (output.Format, error) {
+	format, err := resolveCheckOutputFormat(cmd)
+	if err != nil {
+		return "", err
+	}
+	if format == output.FormatPRComment || format == output.FormatJUnit {
+		return "", fmt.Errorf("--output-format %s is only supported by check and batch", format)
+	}
+	return format, nil
+}
+
+// resolveCheckOutputFormat reads --output-format for commands that render
+// check results, which accept every format.
+func resolveCheckOutputFormat(cmd *cobra.Command) (output.Format, error) {
 	value, err := cmd.Flags().GetString("output-format")
 	if err != nil {
 		return "", err
@@ -68,7 +106,7 @@ func resolveOutputTargets(cmd *cobra.Command) (outPath string, outDir string, er
 func openSink(path string) (*outputSink, error) {
 	trimmed := strings.TrimSpace(path)
 	if trimmed == "" || trimmed == "-" {
-		return &outputSink{writer: os.Stdout, close: func() error { return nil }, path: "-"}, nil
+		return &outputSink{writer: redact.Writer(os.Stdout), close: func() error { return nil }, path: "-"}, nil
 	}
 
 	if err := os.MkdirAll(filepath.Dir(trimmed), 0755); err != nil { // #nosec G301 -- user-provided output dir
@@ -78,7 +116,7 @@ func openSink(path string) (*outputSink, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &outputSink{writer: file, close: file.Close, path: trimmed}, nil
+	return &outputSink{writer: redact.Writer(file), close: file.Close, path: trimmed}, nil
 }
 
 func ensureOutDir(dir string) (string, error) {
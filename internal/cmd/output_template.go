@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/output"
+)
+
+func addOutputTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().String("output-template", "", "Render output through a Go text/template file instead of --output-format")
+}
+
+// resolveOutputTemplate loads --output-template for command, or returns nil
+// when the flag is unset.
+func resolveOutputTemplate(cmd *cobra.Command, command string) (*output.TemplateFormatter, error) {
+	path, err := cmd.Flags().GetString("output-template")
+	if err != nil {
+		return nil, err
+	}
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, nil
+	}
+	tmpl, err := output.LoadTemplate(path)
+	if err != nil {
+		return nil, err
+	}
+	return &output.TemplateFormatter{Template: tmpl, Command: command}, nil
+}
+
+// templateExtension picks the extension of per-name files rendered through a
+// template: "slack.json.tmpl" writes .json files, and a name without one .txt.
+func templateExtension(name string) string {
+	for _, suffix := range []string{".tmpl", ".tpl", ".gotmpl"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	if ext := strings.TrimPrefix(filepath.Ext(name), "."); ext != "" {
+		return ext
+	}
+	return "txt"
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateExtension(t *testing.T) {
+	require.Equal(t, "json", templateExtension("slack.json.tmpl"))
+	require.Equal(t, "md", templateExtension("jira.md.gotmpl"))
+	require.Equal(t, "md", templateExtension("notes.md"))
+	require.Equal(t, "txt", templateExtension("report.tmpl"))
+}
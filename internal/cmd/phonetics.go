@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/phonetics"
+)
+
+const phoneticsPromptSlug = "name-phonetics"
+
+// Phonetics engines for --phonetics-engine.
+const (
+	phoneticsEngineAuto    = "auto"
+	phoneticsEngineAI      = "ai"
+	phoneticsEngineBuiltin = "builtin"
+)
+
+func addPhoneticsEngineFlag(cmd *cobra.Command) {
+	cmd.Flags().String("phonetics-engine", phoneticsEngineAuto, "Phonetics engine: auto (AI, or built-in when no AI provider is available), ai, builtin")
+}
+
+func resolvePhoneticsEngine(cmd *cobra.Command) (string, error) {
+	engine, err := cmd.Flags().GetString("phonetics-engine")
+	if err != nil {
+		return "", err
+	}
+	engine = strings.ToLower(strings.TrimSpace(engine))
+	switch engine {
+	case "":
+		return phoneticsEngineAuto, nil
+	case phoneticsEngineAuto, phoneticsEngineAI, phoneticsEngineBuiltin:
+		return engine, nil
+	default:
+		return "", fmt.Errorf("invalid --phonetics-engine %q (expected auto, ai, or builtin)", engine)
+	}
+}
+
+// runPhonetics runs the phonetics analysis with the chosen engine. In auto
+// mode the AI analysis runs first and the built-in engine answers when no AI
+// provider can: no provider or API key configured, or offline without a
+// cached response.
+func runPhonetics(ctx context.Context, cfg *config.Config, store *store.Store, engine, name, depth, modelOverride string, variables map[string]string, keyboards []string, useCache bool) (json.RawMessage, *ailink.SearchError) {
+	if engine != phoneticsEngineBuiltin {
+		raw, err := runAnalysis(ctx, cfg, store, phoneticsPromptSlug, name, depth, modelOverride, variables, useCache)
+		if err == nil || engine == phoneticsEngineAI || !aiUnavailable(err) {
+			return raw, err
+		}
+		observability.CLILogger.Debug("Using built-in phonetics", zap.String("name", name), zap.String("code", err.Code))
+	}
+
+	raw, err := json.Marshal(phonetics.Analyze(name, keyboards))
+	if err != nil {
+		return nil, &ailink.SearchError{Code: "PHONETICS_ERROR", Message: "built-in phonetics failed", Details: err.Error()}
+	}
+	return raw, nil
+}
+
+// aiUnavailable reports errors meaning no AI provider could be asked, as
+// opposed to a provider that failed.
+func aiUnavailable(err *ailink.SearchError) bool {
+	switch err.Code {
+	case "AILINK_DISABLED", "AILINK_NO_API_KEY", "AILINK_OFFLINE":
+		return true
+	case "AILINK_API_ERROR":
+		return err.Message == "failed to resolve provider"
+	default:
+		return false
+	}
+}
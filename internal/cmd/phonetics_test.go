@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+func TestResolvePhoneticsEngine(t *testing.T) {
+	for args, want := range map[string]string{"": "auto", "--phonetics-engine=BuiltIn": "builtin", "--phonetics-engine=ai": "ai"} {
+		cmd := &cobra.Command{Use: "test"}
+		addPhoneticsEngineFlag(cmd)
+		if args != "" {
+			require.NoError(t, cmd.Flags().Parse([]string{args}))
+		}
+		engine, err := resolvePhoneticsEngine(cmd)
+		require.NoError(t, err)
+		require.Equal(t, want, engine)
+	}
+
+	cmd := &cobra.Command{Use: "test"}
+	addPhoneticsEngineFlag(cmd)
+	require.NoError(t, cmd.Flags().Parse([]string{"--phonetics-engine=espeak"}))
+	_, err := resolvePhoneticsEngine(cmd)
+	require.Error(t, err)
+}
+
+func TestRunPhoneticsFallsBackWithoutProvider(t *testing.T) {
+	observability.InitCLILogger("namelens-test", false)
+	cfg := &config.Config{}
+
+	raw, searchErr := runPhonetics(context.Background(), cfg, nil, phoneticsEngineAuto, "fulsigil", "quick", "", nil, []string{"QWERTZ (DE)"}, false)
+	require.Nil(t, searchErr)
+	var doc struct {
+		Engine      string `json:"engine"`
+		Typeability struct {
+			ByKeyboard []struct {
+				Layout string `json:"layout"`
+			} `json:"by_keyboard"`
+		} `json:"typeability"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &doc))
+	require.Equal(t, "builtin", doc.Engine)
+	require.Len(t, doc.Typeability.ByKeyboard, 1)
+	require.Equal(t, "QWERTZ", doc.Typeability.ByKeyboard[0].Layout)
+
+	_, searchErr = runPhonetics(context.Background(), cfg, nil, phoneticsEngineAI, "fulsigil", "quick", "", nil, nil, false)
+	require.NotNil(t, searchErr)
+}
+
+func TestAIUnavailable(t *testing.T) {
+	require.True(t, aiUnavailable(&ailink.SearchError{Code: "AILINK_NO_API_KEY"}))
+	require.True(t, aiUnavailable(offlineSearchError()))
+	require.True(t, aiUnavailable(&ailink.SearchError{Code: "AILINK_API_ERROR", Message: "failed to resolve provider"}))
+	require.False(t, aiUnavailable(&ailink.SearchError{Code: "AILINK_API_ERROR", Message: "provider returned 500"}))
+	require.False(t, aiUnavailable(&ailink.SearchError{Code: "AILINK_VALIDATION_ERROR"}))
+}
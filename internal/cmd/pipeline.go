@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var pipelineCmd = &cobra.Command{
+	Use:   "pipeline <concept>",
+	Short: "Generate, check, and compare names in one run",
+	Long: `Generate naming candidates from a concept, check availability of the top
+candidates, and print the compare table.
+
+Candidates are ranked with the model's top recommendations first. Names whose
+availability check succeeded and whose risk is not high (.com not taken)
+survive; phonetics and suitability analyses run on survivors unless
+--analyze=false.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPipeline,
+}
+
+// pipelineResult is the JSON output of the pipeline command.
+type pipelineResult struct {
+	Concept    string       `json:"concept"`
+	Candidates []string     `json:"candidates"`
+	Survivors  []string     `json:"survivors"`
+	Compare    []compareRow `json:"compare"`
+}
+
+func init() {
+	rootCmd.AddCommand(pipelineCmd)
+
+	pipelineCmd.Flags().Int("top", 5, "Number of generated candidates to check (max 20)")
+	pipelineCmd.Flags().String("profile", "startup", "Availability profile to use")
+	pipelineCmd.Flags().Bool("analyze", true, "Run phonetics and suitability analyses on surviving candidates")
+	pipelineCmd.Flags().StringP("current-name", "n", "", "Current working name seeking alternatives")
+	pipelineCmd.Flags().StringP("tagline", "t", "", "Product tagline/slogan")
+	pipelineCmd.Flags().StringP("description", "d", "", "Inline product description")
+	pipelineCmd.Flags().StringP("constraints", "c", "", "Naming constraints/requirements")
+	pipelineCmd.Flags().String("depth", "quick", "Generation depth: quick, deep")
+	pipelineCmd.Flags().String("model", "", "Model override for generation")
+	pipelineCmd.Flags().String("prompt", "name-alternatives", "Generation prompt slug")
+	pipelineCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	pipelineCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+	pipelineCmd.Flags().String("out-dir", "", "Write output to a directory")
+	_ = pipelineCmd.Flags().MarkHidden("out-dir") // pipeline outputs a single table, like compare
+	pipelineCmd.Flags().Bool("no-cache", false, "Skip cache lookup for checks and analyses")
+	pipelineCmd.Flags().Bool("no-progress", false, "Disable the live progress display on interactive terminals")
+}
+
+func runPipeline(cmd *cobra.Command, args []string) error {
+	concept := strings.TrimSpace(args[0])
+	if concept == "" {
+		return errors.New("concept is required")
+	}
+
+	top, _ := cmd.Flags().GetInt("top")
+	if top < 1 || top > 20 {
+		return errors.New("--top must be between 1 and 20")
+	}
+	profileName, _ := cmd.Flags().GetString("profile")
+	analyze, _ := cmd.Flags().GetBool("analyze")
+	depth, _ := cmd.Flags().GetString("depth")
+	modelOverride, _ := cmd.Flags().GetString("model")
+	promptSlug, _ := cmd.Flags().GetString("prompt")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+
+	variables := map[string]string{
+		"concept": concept,
+		"name":    concept,
+		"input":   concept,
+	}
+	for flag, key := range map[string]string{
+		"current-name": "current_name",
+		"tagline":      "tagline",
+		"description":  "description",
+		"constraints":  "constraints",
+	} {
+		if value, _ := cmd.Flags().GetString(flag); strings.TrimSpace(value) != "" {
+			variables[key] = strings.TrimSpace(value)
+		}
+	}
+
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, _, err := resolveOutputTargets(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+	if isOffline(cfg) {
+		return errors.New("pipeline needs an AI provider for generation and is not available offline")
+	}
+
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	// Generation is not cached, matching 'namelens generate'
+	raw, errInfo, _ := runReviewGenerate(ctx, cfg, store, promptSlug, "", depth, modelOverride, variables, false)
+	if errInfo != nil {
+		return fmt.Errorf("generation failed: %s", errInfo.Message)
+	}
+	candidates, err := pipelineCandidates(raw)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return errors.New("generation returned no candidates")
+	}
+	checked := candidates
+	if len(checked) > top {
+		checked = checked[:top]
+	}
+
+	profile, err := resolveProfile(ctx, store, profileName, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+
+	result := pipelineResult{Concept: concept, Candidates: candidates, Survivors: []string{}}
+
+	display := newProgressDisplay(cmd, format, outPath, "", len(checked))
+	display.Start()
+	for _, name := range checked {
+		ctx := display.Begin(ctx, name)
+		row := compareRow{Name: name, Length: len(name)}
+
+		results, err := orchestrator.Check(ctx, name, profile)
+		if err != nil {
+			row.AvailabilityError = "error"
+		} else {
+			row.Availability = summarizeAvailability(results)
+			row.RiskLevel = deriveRiskLevel(results)
+		}
+
+		if pipelineSurvivor(row) {
+			result.Survivors = append(result.Survivors, name)
+			if analyze {
+				row.Phonetics = runComparePhonetics(ctx, cfg, store, name, !noCache)
+				row.Suitability = runCompareSuitability(ctx, cfg, store, name, !noCache)
+			}
+		}
+
+		result.Compare = append(result.Compare, row)
+		display.Done(name)
+	}
+	display.Stop()
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderPipeline(sink.writer, result, format, !analyze)
+}
+
+// pipelineCandidates returns generated names, top recommendations first,
+// lowercased and without duplicates.
+func pipelineCandidates(raw json.RawMessage) ([]string, error) {
+	var data struct {
+		Candidates []struct {
+			Name string `json:"name"`
+		} `json:"candidates"`
+		TopRecommendations []struct {
+			Name string `json:"name"`
+		} `json:"top_recommendations"`
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("parse generation response: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	var names []string
+	add := func(name string) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return
+		}
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	for _, rec := range data.TopRecommendations {
+		add(rec.Name)
+	}
+	for _, candidate := range data.Candidates {
+		add(candidate.Name)
+	}
+	return names, nil
+}
+
+// pipelineSurvivor reports whether a checked candidate moves on to analysis:
+// its checks succeeded and the .com is not taken.
+func pipelineSurvivor(row compareRow) bool {
+	return row.AvailabilityError == "" && row.RiskLevel != "high" && row.RiskLevel != "unknown"
+}
+
+func renderPipeline(w io.Writer, result pipelineResult, format output.Format, quickMode bool) error {
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	summary := fmt.Sprintf("Generated %d candidates for %q; checked %d, %d survived.",
+		len(result.Candidates), result.Concept, len(result.Compare), len(result.Survivors))
+	if format == output.FormatMarkdown {
+		_, _ = fmt.Fprintf(w, "## Pipeline: %s\n\n%s\n\n", result.Concept, summary)
+	} else {
+		_, _ = fmt.Fprintln(w, summary)
+	}
+	return renderCompare(w, result.Compare, format, quickMode)
+}
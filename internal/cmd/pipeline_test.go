@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestPipelineCandidates(t *testing.T) {
+	raw := json.RawMessage(`{
+		"candidates": [{"name": "Zentro"}, {"name": "acmely"}, {"name": " "}, {"name": "Rocketly"}],
+		"top_recommendations": [{"name": "rocketly", "why": "short"}]
+	}`)
+
+	names, err := pipelineCandidates(raw)
+	require.NoError(t, err)
+	require.Equal(t, []string{"rocketly", "zentro", "acmely"}, names)
+
+	_, err = pipelineCandidates(json.RawMessage(`not json`))
+	require.Error(t, err)
+}
+
+func TestPipelineSurvivor(t *testing.T) {
+	require.True(t, pipelineSurvivor(compareRow{RiskLevel: "low"}))
+	require.True(t, pipelineSurvivor(compareRow{RiskLevel: "medium"}))
+	require.False(t, pipelineSurvivor(compareRow{RiskLevel: "high"}))
+	require.False(t, pipelineSurvivor(compareRow{RiskLevel: "unknown"}))
+	require.False(t, pipelineSurvivor(compareRow{AvailabilityError: "error"}))
+}
+
+func TestRenderPipeline(t *testing.T) {
+	result := pipelineResult{
+		Concept:    "rocket parts",
+		Candidates: []string{"rocketly", "zentro"},
+		Survivors:  []string{"rocketly"},
+		Compare: []compareRow{
+			{Name: "rocketly", Length: 8, Availability: compareAvailability{Score: 3, Total: 3}, RiskLevel: "low"},
+		},
+	}
+
+	var md bytes.Buffer
+	require.NoError(t, renderPipeline(&md, result, output.FormatMarkdown, true))
+	require.Contains(t, md.String(), "Generated 2 candidates for \"rocket parts\"; checked 1, 1 survived.")
+	require.Contains(t, md.String(), "| rocketly | 3/3 | 8 |")
+
+	var js bytes.Buffer
+	require.NoError(t, renderPipeline(&js, result, output.FormatJSON, true))
+	var decoded pipelineResult
+	require.NoError(t, json.Unmarshal(js.Bytes(), &decoded))
+	require.Equal(t, []string{"rocketly"}, decoded.Survivors)
+}
@@ -0,0 +1,504 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan <name>",
+	Short: "Produce an ordered action checklist to reserve a name",
+	Long: `Produce an ordered checklist for securing a name after review.
+
+NameLens checks the name against the selected profile and lists every
+available domain, registry name, and handle to claim, with links and typical
+costs. The configured AI backend then drafts the steps that need judgment:
+trademark classes and filing basis, defensive domains, social handles, and
+risks. Use --no-ai for the deterministic checklist only.
+
+Domain prices are typical first-year retail prices and vary by registrar.
+Trademark costs cover the USPTO base filing fee per class only.`,
+	Example: `  namelens plan acme
+  namelens plan acme --profile developer --classes 9,42 --context "CLI for log analysis"
+  namelens plan acme --no-ai --out plan.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlan,
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+
+	planCmd.Flags().String("profile", "startup", "Availability profile to use")
+	planCmd.Flags().StringSlice("tlds", nil, "TLDs to check (overrides profile)")
+	planCmd.Flags().IntSlice("classes", nil, "Nice trademark classes to file in (e.g. 9,42)")
+	planCmd.Flags().StringSlice("jurisdictions", []string{"us"}, "Trademark jurisdictions: us, eu, uk, wipo")
+	planCmd.Flags().String("context", "", "Short product description for the AI-drafted steps")
+	planCmd.Flags().Bool("no-ai", false, "Skip AI-drafted steps")
+	planCmd.Flags().String("depth", "quick", "Draft depth: quick, deep")
+	planCmd.Flags().String("model", "", "Model override")
+	planCmd.Flags().Bool("json", false, "Output the plan as JSON")
+	planCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+	planCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
+}
+
+// planDomainCosts are typical first-year retail registration prices in USD.
+var planDomainCosts = map[string]float64{
+	"ai":  80,
+	"app": 15,
+	"co":  28,
+	"com": 11,
+	"dev": 13,
+	"gg":  60,
+	"io":  40,
+	"me":  18,
+	"net": 13,
+	"org": 11,
+	"sh":  35,
+	"xyz": 12,
+}
+
+// planUSPTOFeePerClass is the USPTO base application fee per class.
+const planUSPTOFeePerClass = 350
+
+var planTrademarkOffices = map[string]struct {
+	office string
+	link   string
+}{
+	"us":   {"USPTO", "https://www.uspto.gov/trademarks/apply"},
+	"eu":   {"EUIPO", "https://euipo.europa.eu/ohimportal/en/apply-now"},
+	"uk":   {"UKIPO", "https://www.gov.uk/how-to-register-a-trade-mark"},
+	"wipo": {"WIPO (Madrid System)", "https://www.wipo.int/madrid/en/"},
+}
+
+// planDraft mirrors ailink/v0/name-plan-response.
+type planDraft struct {
+	Summary   string `json:"summary"`
+	Trademark *struct {
+		Classes []struct {
+			Class     int    `json:"class"`
+			Title     string `json:"title,omitempty"`
+			Rationale string `json:"rationale,omitempty"`
+		} `json:"classes,omitempty"`
+		FilingBasis string `json:"filing_basis,omitempty"`
+		Notes       string `json:"notes,omitempty"`
+	} `json:"trademark,omitempty"`
+	Steps []struct {
+		Title            string   `json:"title"`
+		Category         string   `json:"category,omitempty"`
+		Details          string   `json:"details,omitempty"`
+		Link             string   `json:"link,omitempty"`
+		EstimatedCostUSD *float64 `json:"estimated_cost_usd,omitempty"`
+		Priority         string   `json:"priority,omitempty"`
+	} `json:"steps"`
+	Risks []string `json:"risks,omitempty"`
+}
+
+type planStep struct {
+	Order            int      `json:"order"`
+	Category         string   `json:"category"`
+	Title            string   `json:"title"`
+	Details          string   `json:"details,omitempty"`
+	Link             string   `json:"link,omitempty"`
+	EstimatedCostUSD *float64 `json:"estimated_cost_usd,omitempty"`
+	Priority         string   `json:"priority"`
+	Source           string   `json:"source"`
+}
+
+type planResult struct {
+	Name         string              `json:"name"`
+	Profile      string              `json:"profile"`
+	Summary      string              `json:"summary,omitempty"`
+	Steps        []planStep          `json:"steps"`
+	Unavailable  []string            `json:"unavailable,omitempty"`
+	Risks        []string            `json:"risks,omitempty"`
+	TotalCostUSD float64             `json:"total_cost_usd"`
+	AIError      *ailink.SearchError `json:"ai_error,omitempty"`
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	name := strings.ToLower(strings.TrimSpace(args[0]))
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	profileName, err := cmd.Flags().GetString("profile")
+	if err != nil {
+		return err
+	}
+	tlds, err := cmd.Flags().GetStringSlice("tlds")
+	if err != nil {
+		return err
+	}
+	classes, err := cmd.Flags().GetIntSlice("classes")
+	if err != nil {
+		return err
+	}
+	for _, class := range classes {
+		if class < 1 || class > 45 {
+			return fmt.Errorf("invalid trademark class %d (Nice classes are 1-45)", class)
+		}
+	}
+	jurisdictions, err := cmd.Flags().GetStringSlice("jurisdictions")
+	if err != nil {
+		return err
+	}
+	jurisdictions = normalizeList(jurisdictions)
+	for _, jurisdiction := range jurisdictions {
+		if _, ok := planTrademarkOffices[jurisdiction]; !ok {
+			return fmt.Errorf("unsupported jurisdiction %q (valid: us, eu, uk, wipo)", jurisdiction)
+		}
+	}
+	productContext, err := cmd.Flags().GetString("context")
+	if err != nil {
+		return err
+	}
+	noAI, err := cmd.Flags().GetBool("no-ai")
+	if err != nil {
+		return err
+	}
+	depth, err := cmd.Flags().GetString("depth")
+	if err != nil {
+		return err
+	}
+	modelOverride, err := cmd.Flags().GetString("model")
+	if err != nil {
+		return err
+	}
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+
+	profile, err := resolveProfile(ctx, store, profileName, normalizeTLDs(tlds), nil, nil)
+	if err != nil {
+		return err
+	}
+	if len(profile.TLDs) == 0 && len(profile.Registries) == 0 && len(profile.Handles) == 0 {
+		return errors.New("at least one check target is required")
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+	results, err := orchestrator.Check(ctx, name, profile)
+	if err != nil {
+		return err
+	}
+
+	var draft *planDraft
+	var aiErr *ailink.SearchError
+	if !noAI {
+		vars := map[string]string{
+			"checks":          planChecksSummary(results),
+			"product_context": strings.TrimSpace(productContext),
+			"jurisdictions":   strings.Join(jurisdictions, ", "),
+			"depth":           depth,
+		}
+		if len(classes) > 0 {
+			vars["trademark_classes"] = joinInts(classes)
+		}
+		raw, searchErr := runAnalysis(ctx, cfg, store, "name-plan", name, depth, modelOverride, vars, !noCache)
+		if searchErr != nil {
+			aiErr = searchErr
+			observability.CLILogger.Warn("AI-drafted plan steps unavailable", zap.String("code", searchErr.Code), zap.String("message", searchErr.Message))
+		} else {
+			var parsed planDraft
+			if err := json.Unmarshal(raw, &parsed); err != nil {
+				return fmt.Errorf("parse plan draft: %w", err)
+			}
+			draft = &parsed
+		}
+	}
+
+	plan := buildPlan(name, profile.Name, results, classes, jurisdictions, draft)
+	plan.AIError = aiErr
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	if jsonOutput {
+		encoder := json.NewEncoder(sink.writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(plan)
+	}
+	return renderPlanMarkdown(sink.writer, plan)
+}
+
+// buildPlan orders the checklist: available domains, registries and handles,
+// trademark filings, then AI-drafted steps by priority.
+func buildPlan(name, profile string, results []*core.CheckResult, classes []int, jurisdictions []string, draft *planDraft) planResult {
+	plan := planResult{Name: name, Profile: profile, Steps: make([]planStep, 0)}
+
+	add := func(step planStep) {
+		if step.Priority == "" {
+			step.Priority = "now"
+		}
+		if step.Source == "" {
+			step.Source = "check"
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	for _, result := range results {
+		if result == nil || result.CheckType != core.CheckTypeDomain {
+			continue
+		}
+		if result.Available != core.AvailabilityAvailable {
+			plan.Unavailable = append(plan.Unavailable, fmt.Sprintf("%s (%s)", result.Name, result.Available))
+			continue
+		}
+		tld := strings.TrimPrefix(result.TLD, ".")
+		if tld == "" {
+			_, tld, _ = strings.Cut(result.Name, ".")
+		}
+		step := planStep{
+			Category: "domain",
+			Title:    "Register " + result.Name,
+			Details:  "Compare registrar prices; enable auto-renew and registrar lock.",
+			Link:     "https://tld-list.com/tld/" + tld,
+		}
+		if cost, ok := planDomainCosts[tld]; ok {
+			step.EstimatedCostUSD = &cost
+		}
+		add(step)
+	}
+
+	for _, result := range results {
+		if result == nil || result.CheckType == core.CheckTypeDomain {
+			continue
+		}
+		if result.Available != core.AvailabilityAvailable {
+			plan.Unavailable = append(plan.Unavailable, fmt.Sprintf("%s %s (%s)", result.CheckType, result.Name, result.Available))
+			continue
+		}
+		if step, ok := planClaimStep(name, result.CheckType); ok {
+			add(step)
+		}
+	}
+
+	if draft != nil && draft.Trademark != nil && len(classes) == 0 {
+		for _, class := range draft.Trademark.Classes {
+			if class.Class >= 1 && class.Class <= 45 {
+				classes = append(classes, class.Class)
+			}
+		}
+	}
+	sort.Ints(classes)
+	for _, jurisdiction := range jurisdictions {
+		add(planTrademarkStep(name, jurisdiction, classes, draft))
+	}
+
+	if draft != nil {
+		plan.Summary = strings.TrimSpace(draft.Summary)
+		plan.Risks = draft.Risks
+
+		drafted := make([]planStep, 0, len(draft.Steps))
+		for _, s := range draft.Steps {
+			if strings.TrimSpace(s.Title) == "" {
+				continue
+			}
+			category := s.Category
+			if category == "" {
+				category = "other"
+			}
+			priority := s.Priority
+			if priority == "" {
+				priority = "soon"
+			}
+			drafted = append(drafted, planStep{
+				Category:         category,
+				Title:            strings.TrimSpace(s.Title),
+				Details:          strings.TrimSpace(s.Details),
+				Link:             strings.TrimSpace(s.Link),
+				EstimatedCostUSD: s.EstimatedCostUSD,
+				Priority:         priority,
+				Source:           "ai",
+			})
+		}
+		sort.SliceStable(drafted, func(i, j int) bool {
+			return planPriorityRank(drafted[i].Priority) < planPriorityRank(drafted[j].Priority)
+		})
+		for _, step := range drafted {
+			add(step)
+		}
+	}
+
+	for i := range plan.Steps {
+		plan.Steps[i].Order = i + 1
+		if plan.Steps[i].EstimatedCostUSD != nil {
+			plan.TotalCostUSD += *plan.Steps[i].EstimatedCostUSD
+		}
+	}
+	return plan
+}
+
+func planClaimStep(name string, checkType core.CheckType) (planStep, bool) {
+	free := 0.0
+	switch checkType {
+	case core.CheckTypeGitHub:
+		return planStep{Category: "handle", Title: "Create the GitHub organization " + name, Link: "https://github.com/account/organizations/new", EstimatedCostUSD: &free}, true
+	case core.CheckTypeNPM:
+		return planStep{Category: "registry", Title: "Create the npm organization @" + name, Details: "Publish a first release under the unscoped name when the package is ready; npm reclaims empty placeholder packages.", Link: "https://www.npmjs.com/org/create", EstimatedCostUSD: &free}, true
+	case core.CheckTypePyPI:
+		return planStep{Category: "registry", Title: "Publish an initial release of " + name + " to PyPI", Details: "PyPI has no name reservation; the name is claimed by the first upload.", Link: "https://pypi.org/account/register/", EstimatedCostUSD: &free}, true
+	case core.CheckTypeCargo:
+		return planStep{Category: "registry", Title: "Publish an initial " + name + " crate to crates.io", Details: "crates.io policy forbids pure name squatting; publish functional code.", Link: "https://crates.io/", EstimatedCostUSD: &free}, true
+	}
+	return planStep{}, false
+}
+
+func planTrademarkStep(name, jurisdiction string, classes []int, draft *planDraft) planStep {
+	office := planTrademarkOffices[jurisdiction]
+	step := planStep{
+		Category: "trademark",
+		Link:     office.link,
+		Priority: "soon",
+	}
+
+	details := make([]string, 0, 3)
+	if jurisdiction == "us" {
+		basis := "intent-to-use (Section 1(b))"
+		if draft != nil && draft.Trademark != nil && strings.TrimSpace(draft.Trademark.FilingBasis) != "" {
+			basis = strings.TrimSpace(draft.Trademark.FilingBasis)
+		}
+		details = append(details, "Filing basis: "+strings.TrimSuffix(basis, ".")+".")
+	}
+
+	title := fmt.Sprintf("File a trademark application for %s with %s", strings.ToUpper(name), office.office)
+	if len(classes) == 0 {
+		step.Title = title
+		step.Details = strings.Join(append(details, "Choose Nice classes first (software products usually file in 9 and 42); run a clearance search before filing."), " ")
+		return step
+	}
+
+	label := "class"
+	if len(classes) > 1 {
+		label = "classes"
+	}
+	step.Title = fmt.Sprintf("%s in %s %s", title, label, joinInts(classes))
+	details = append(details, "Run a clearance search before filing.")
+	if draft != nil && draft.Trademark != nil && strings.TrimSpace(draft.Trademark.Notes) != "" {
+		details = append(details, strings.TrimSpace(draft.Trademark.Notes))
+	}
+	step.Details = strings.Join(details, " ")
+	if jurisdiction == "us" {
+		cost := float64(planUSPTOFeePerClass * len(classes))
+		step.EstimatedCostUSD = &cost
+	}
+	return step
+}
+
+func planPriorityRank(priority string) int {
+	switch priority {
+	case "now":
+		return 0
+	case "soon":
+		return 1
+	case "later":
+		return 2
+	}
+	return 3
+}
+
+// planChecksSummary formats check results as a bullet list for the prompt.
+func planChecksSummary(results []*core.CheckResult) string {
+	var b strings.Builder
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s %s: %s\n", result.CheckType, result.Name, result.Available)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, strconv.Itoa(value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatPlanCost(cost float64) string {
+	if cost == float64(int64(cost)) {
+		return fmt.Sprintf("$%d", int64(cost))
+	}
+	return fmt.Sprintf("$%.2f", cost)
+}
+
+func renderPlanMarkdown(w io.Writer, plan planResult) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Reservation plan: %s\n\n", plan.Name)
+	if plan.Summary != "" {
+		fmt.Fprintf(&b, "%s\n\n", plan.Summary)
+	}
+	fmt.Fprintf(&b, "- **Profile:** %s\n", plan.Profile)
+	fmt.Fprintf(&b, "- **Estimated cost:** %s (known costs only)\n", formatPlanCost(plan.TotalCostUSD))
+
+	b.WriteString("\n## Checklist\n\n")
+	for _, step := range plan.Steps {
+		cost := ""
+		if step.EstimatedCostUSD != nil {
+			cost = " — " + formatPlanCost(*step.EstimatedCostUSD)
+		}
+		source := ""
+		if step.Source == "ai" {
+			source = " _(AI-drafted)_"
+		}
+		fmt.Fprintf(&b, "%d. [ ] **%s** [%s, %s]%s%s\n", step.Order, step.Title, step.Category, step.Priority, cost, source)
+		if step.Details != "" {
+			fmt.Fprintf(&b, "   %s\n", step.Details)
+		}
+		if step.Link != "" {
+			fmt.Fprintf(&b, "   %s\n", step.Link)
+		}
+	}
+
+	writeMarkdownList(&b, "Not available", plan.Unavailable)
+	writeMarkdownList(&b, "Risks", plan.Risks)
+
+	if plan.AIError != nil {
+		fmt.Fprintf(&b, "\n_AI-drafted steps unavailable (%s: %s); showing the deterministic checklist only._\n", plan.AIError.Code, plan.AIError.Message)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestBuildPlanOrdersSteps(t *testing.T) {
+	results := []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+		{Name: "acme.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable},
+		{Name: "acme.zz", CheckType: core.CheckTypeDomain, TLD: "zz", Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeGitHub, Available: core.AvailabilityTaken},
+	}
+
+	var draft planDraft
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"summary": "Secure acme.io and npm first.",
+		"trademark": {"classes": [{"class": 42}, {"class": 9}], "filing_basis": "Intent-to-use (Section 1(b))"},
+		"steps": [
+			{"title": "Run a knockout search", "category": "trademark", "priority": "later"},
+			{"title": "Claim the X handle", "category": "handle", "priority": "now", "estimated_cost_usd": 0, "link": "https://x.com"}
+		],
+		"risks": ["acme.com is taken"]
+	}`), &draft))
+
+	plan := buildPlan("acme", "startup", results, nil, []string{"us", "eu"}, &draft)
+
+	titles := make([]string, 0, len(plan.Steps))
+	for _, step := range plan.Steps {
+		titles = append(titles, step.Title)
+	}
+	require.Equal(t, []string{
+		"Register acme.io",
+		"Register acme.zz",
+		"Create the npm organization @acme",
+		"File a trademark application for ACME with USPTO in classes 9, 42",
+		"File a trademark application for ACME with EUIPO in classes 9, 42",
+		"Claim the X handle",
+		"Run a knockout search",
+	}, titles)
+
+	require.Equal(t, 1, plan.Steps[0].Order)
+	require.Equal(t, 40.0, *plan.Steps[0].EstimatedCostUSD)
+	require.Nil(t, plan.Steps[1].EstimatedCostUSD, "unknown TLD prices are not guessed")
+	require.Equal(t, 700.0, *plan.Steps[3].EstimatedCostUSD)
+	require.Equal(t, "Filing basis: Intent-to-use (Section 1(b)). Run a clearance search before filing.", plan.Steps[3].Details)
+	require.Nil(t, plan.Steps[4].EstimatedCostUSD)
+	require.Equal(t, "ai", plan.Steps[5].Source)
+	require.Equal(t, 740.0, plan.TotalCostUSD)
+	require.Equal(t, []string{"acme.com (taken)", "github acme (taken)"}, plan.Unavailable)
+}
+
+func TestBuildPlanWithoutAI(t *testing.T) {
+	results := []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable},
+	}
+
+	plan := buildPlan("acme", "minimal", results, []int{42, 9}, []string{"us"}, nil)
+	require.Len(t, plan.Steps, 2)
+	require.Equal(t, "File a trademark application for ACME with USPTO in classes 9, 42", plan.Steps[1].Title)
+	require.Equal(t, 711.0, plan.TotalCostUSD)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderPlanMarkdown(&buf, plan))
+	out := buf.String()
+	require.Contains(t, out, "# Reservation plan: acme")
+	require.Contains(t, out, "- **Estimated cost:** $711 (known costs only)")
+	require.Contains(t, out, "1. [ ] **Register acme.com** [domain, now] — $11")
+	require.Contains(t, out, "   https://tld-list.com/tld/com")
+	require.False(t, strings.Contains(out, "AI-drafted"))
+}
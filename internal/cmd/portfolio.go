@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+)
+
+const (
+	defaultCalendarDays         = 365
+	defaultCalendarReminderDays = 30
+)
+
+var portfolioCmd = &cobra.Command{
+	Use:   "portfolio",
+	Short: "Track owned and watched domains",
+	Long: `Track domains the team owns or is watching in the local database.
+
+Owned domains are ones you have registered and must renew; watched domains
+are taken names you hope to acquire when they expire. The calendar
+subcommand exports their upcoming expirations for your team's calendar.`,
+}
+
+var portfolioAddCmd = &cobra.Command{
+	Use:   "add <domain>...",
+	Short: "Add domains to the portfolio",
+	Example: `  namelens portfolio add acme.com acme.io --owned
+  namelens portfolio add acmecorp.com --note "parked, try to buy"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runPortfolioAdd,
+}
+
+var portfolioRemoveCmd = &cobra.Command{
+	Use:   "remove <domain>...",
+	Short: "Remove domains from the portfolio",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runPortfolioRemove,
+}
+
+var portfolioListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List portfolio domains",
+	Args:  cobra.NoArgs,
+	RunE:  runPortfolioList,
+}
+
+var portfolioCalendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Export upcoming portfolio expirations as a calendar",
+	Long: `Export upcoming expirations of portfolio domains as an iCalendar (.ics) feed.
+
+Each domain with a known expiration date within --days becomes an all-day
+event on that date, with a reminder --reminder-days before it. Expiration
+dates come from RDAP or WHOIS domain checks, served from the cache when
+fresh. Import the file into a calendar, or subscribe to /calendar.ics on
+"namelens serve" so renewals stay current.`,
+	Example: `  namelens portfolio calendar --format ics --out renewals.ics
+  namelens portfolio calendar --days 90 --reminder-days 14`,
+	Args: cobra.NoArgs,
+	RunE: runPortfolioCalendar,
+}
+
+func init() {
+	rootCmd.AddCommand(portfolioCmd)
+	portfolioCmd.AddCommand(portfolioAddCmd)
+	portfolioCmd.AddCommand(portfolioRemoveCmd)
+	portfolioCmd.AddCommand(portfolioListCmd)
+	portfolioCmd.AddCommand(portfolioCalendarCmd)
+
+	portfolioAddCmd.Flags().Bool("owned", false, "Mark the domains as owned (default watched)")
+	portfolioAddCmd.Flags().String("note", "", "Note to store with the domains")
+	portfolioAddCmd.Flags().Duration("notify-before", 0, "Remind this long before expiration or an expected drop (default: calendar --reminder-days)")
+
+	portfolioListCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	portfolioListCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+
+	portfolioCalendarCmd.Flags().String("format", "ics", "Calendar format: ics")
+	portfolioCalendarCmd.Flags().Int("days", defaultCalendarDays, "Include expirations within this many days (0 = all)")
+	portfolioCalendarCmd.Flags().Int("reminder-days", defaultCalendarReminderDays, "Remind this many days before each expiration (0 = no reminder)")
+	portfolioCalendarCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+	portfolioCalendarCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
+}
+
+func runPortfolioAdd(cmd *cobra.Command, args []string) error {
+	owned, err := cmd.Flags().GetBool("owned")
+	if err != nil {
+		return err
+	}
+	note, err := cmd.Flags().GetString("note")
+	if err != nil {
+		return err
+	}
+	notifyBefore, err := cmd.Flags().GetDuration("notify-before")
+	if err != nil {
+		return err
+	}
+	relation := corestore.PortfolioWatched
+	if owned {
+		relation = corestore.PortfolioOwned
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	for _, arg := range args {
+		domain, err := portfolioDomain(arg)
+		if err != nil {
+			return err
+		}
+		if err := store.AddPortfolioDomain(ctx, corestore.PortfolioDomain{Domain: domain, Relation: relation, Note: note, NotifyBefore: notifyBefore}); err != nil {
+			return err
+		}
+		fmt.Printf("Added %s (%s)\n", domain, relation)
+	}
+	return nil
+}
+
+func runPortfolioRemove(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	for _, arg := range args {
+		domain, err := portfolioDomain(arg)
+		if err != nil {
+			return err
+		}
+		removed, err := store.RemovePortfolioDomain(ctx, domain)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return fmt.Errorf("%s is not in the portfolio", domain)
+		}
+		fmt.Printf("Removed %s\n", domain)
+	}
+	return nil
+}
+
+func runPortfolioList(cmd *cobra.Command, args []string) error {
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	entries, err := store.ListPortfolio(ctx)
+	if err != nil {
+		return err
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderPortfolio(sink.writer, entries, format)
+}
+
+func runPortfolioCalendar(cmd *cobra.Command, args []string) error {
+	calendarFormat, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(strings.TrimSpace(calendarFormat), "ics") {
+		return fmt.Errorf("unsupported calendar format: %s (supported: ics)", calendarFormat)
+	}
+	days, err := cmd.Flags().GetInt("days")
+	if err != nil {
+		return err
+	}
+	reminderDays, err := cmd.Flags().GetInt("reminder-days")
+	if err != nil {
+		return err
+	}
+	if days < 0 || reminderDays < 0 {
+		return errors.New("--days and --reminder-days must be 0 or greater")
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+
+	defer startBootstrapRefresh(ctx, cfg, store)()
+	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
+
+	calendar, err := portfolioCalendar(ctx, store, orchestrator, time.Now(), days, time.Duration(reminderDays)*24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	_, err = sink.writer.Write(calendar)
+	return err
+}
+
+// portfolioCalendar renders the iCalendar feed of portfolio expirations and
+// expected drops within days of now.
+func portfolioCalendar(ctx context.Context, store *corestore.Store, orchestrator *engine.Orchestrator, now time.Time, days int, reminder time.Duration) ([]byte, error) {
+	entries, err := store.ListPortfolio(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := portfolioExpirations(ctx, orchestrator, entries, now, days)
+
+	var buf bytes.Buffer
+	if err := output.WriteICS(&buf, events, reminder, now); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// portfolioExpirations checks each portfolio domain and returns the
+// expirations and expected drops from today through days ahead (0 = any
+// time), soonest first. Domains without a parseable expiration date or drop
+// window are skipped.
+func portfolioExpirations(ctx context.Context, orchestrator *engine.Orchestrator, entries []corestore.PortfolioDomain, now time.Time, days int) []output.ExpirationEvent {
+	today := now.UTC().Truncate(24 * time.Hour)
+	var events []output.ExpirationEvent
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			break
+		}
+		result, err := checkDomain(ctx, orchestrator, entry.Domain)
+		if err != nil {
+			observability.CLILogger.Warn("Portfolio domain check failed",
+				zap.String("domain", entry.Domain),
+				zap.Error(err),
+			)
+			continue
+		}
+		registrar, _ := result.ExtraData["registrar"].(string)
+		inHorizon := func(at time.Time) bool {
+			return !at.Before(today) && (days <= 0 || !at.After(now.AddDate(0, 0, days)))
+		}
+
+		if window, ok := core.ParseDropWindow(result.ExtraData); ok && !window.Latest.Before(today) && inHorizon(window.Earliest) {
+			events = append(events, output.ExpirationEvent{
+				Domain:     entry.Domain,
+				Relation:   entry.Relation,
+				Registrar:  registrar,
+				Expires:    window.Earliest,
+				Drop:       true,
+				DropLatest: window.Latest,
+				Reminder:   entry.NotifyBefore,
+			})
+			continue
+		}
+
+		raw, _ := result.ExtraData["expiration"].(string)
+		expires, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			observability.CLILogger.Debug("No expiration date for portfolio domain",
+				zap.String("domain", entry.Domain),
+				zap.String("availability", result.Available.String()),
+			)
+			continue
+		}
+		if !inHorizon(expires) {
+			continue
+		}
+		events = append(events, output.ExpirationEvent{
+			Domain:    entry.Domain,
+			Relation:  entry.Relation,
+			Registrar: registrar,
+			Expires:   expires,
+			Reminder:  entry.NotifyBefore,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Expires.Before(events[j].Expires)
+	})
+	return events
+}
+
+// portfolioDomain normalizes a domain argument, which must include its TLD.
+func portfolioDomain(value string) (string, error) {
+	domain := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), ".")
+	if !strings.Contains(domain, ".") {
+		return "", fmt.Errorf("%q needs a domain including its TLD, e.g. acme.com", value)
+	}
+	return domain, nil
+}
+
+func renderPortfolio(w io.Writer, entries []corestore.PortfolioDomain, format output.Format) error {
+	type portfolioRow struct {
+		Domain       string    `json:"domain"`
+		Relation     string    `json:"relation"`
+		Note         string    `json:"note,omitempty"`
+		NotifyBefore string    `json:"notify_before,omitempty"`
+		AddedAt      time.Time `json:"added_at"`
+	}
+	rows := make([]portfolioRow, 0, len(entries))
+	for _, entry := range entries {
+		row := portfolioRow{Domain: entry.Domain, Relation: entry.Relation, Note: entry.Note, AddedAt: entry.AddedAt}
+		if entry.NotifyBefore > 0 {
+			row.NotifyBefore = entry.NotifyBefore.String()
+		}
+		rows = append(rows, row)
+	}
+
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		_, _ = fmt.Fprintln(w, "| Domain | Relation | Notify before | Note | Added |")
+		_, _ = fmt.Fprintln(w, "|--------|----------|---------------|------|-------|")
+		for _, row := range rows {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n", row.Domain, row.Relation, row.NotifyBefore, row.Note, row.AddedAt.Format("2006-01-02"))
+		}
+		return nil
+	default:
+		if len(rows) == 0 {
+			_, err := fmt.Fprintln(w, "No portfolio domains. Add some with: namelens portfolio add <domain>")
+			return err
+		}
+		t := table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.AppendHeader(table.Row{"Domain", "Relation", "Notify before", "Note", "Added"})
+		for _, row := range rows {
+			t.AppendRow(table.Row{row.Domain, row.Relation, row.NotifyBefore, row.Note, row.AddedAt.Format("2006-01-02")})
+		}
+		t.Render()
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+)
+
+// expiryChecker reports domains with an expiration date as taken; values
+// prefixed "drop:" are the earliest date of a two-day drop window instead.
+type expiryChecker map[string]string
+
+func (e expiryChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	result := &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable}
+	value, ok := e[name]
+	if !ok {
+		return result, nil
+	}
+	result.Available = core.AvailabilityTaken
+	result.ExtraData = map[string]any{"registrar": "Example Registrar"}
+	if earliest, isDrop := strings.CutPrefix(value, "drop:"); isDrop {
+		start, err := time.Parse(time.RFC3339, earliest)
+		if err != nil {
+			return nil, err
+		}
+		result.ExtraData["drop_window"] = core.DropWindowExtra(core.DropWindow{Earliest: start, Latest: start.Add(48 * time.Hour)})
+		return result, nil
+	}
+	result.ExtraData["expiration"] = value
+	return result, nil
+}
+
+func (e expiryChecker) Type() core.CheckType { return core.CheckTypeDomain }
+
+func (e expiryChecker) SupportsName(name string) bool { return true }
+
+func TestPortfolioExpirations(t *testing.T) {
+	observability.InitCLILogger("namelens-test", false)
+	orchestrator := &engine.Orchestrator{
+		Checkers: map[core.CheckType]engine.Checker{core.CheckTypeDomain: expiryChecker{
+			"acme.com":   "2026-12-01T00:00:00Z",
+			"acme.io":    "2026-11-01T08:30:00Z",
+			"acme.co.uk": "2026-03-15T00:00:00Z",
+			"acme.dev":   "2028-01-01T00:00:00Z",
+			"acme.app":   "not a date",
+			"zentro.com": "drop:2026-10-18T00:00:00Z",
+		}},
+	}
+	entries := []corestore.PortfolioDomain{
+		{Domain: "acme.com", Relation: corestore.PortfolioOwned},
+		{Domain: "acme.io", Relation: corestore.PortfolioWatched},
+		{Domain: "acme.co.uk", Relation: corestore.PortfolioOwned},
+		{Domain: "acme.dev", Relation: corestore.PortfolioOwned},
+		{Domain: "acme.app", Relation: corestore.PortfolioOwned},
+		{Domain: "zentro.com", Relation: corestore.PortfolioWatched, NotifyBefore: 12 * time.Hour},
+		{Domain: "nothing.com", Relation: corestore.PortfolioWatched},
+	}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	events := portfolioExpirations(context.Background(), orchestrator, entries, now, 365)
+	require.Len(t, events, 3)
+	require.Equal(t, "zentro.com", events[0].Domain)
+	require.True(t, events[0].Drop)
+	require.Equal(t, 12*time.Hour, events[0].Reminder)
+	require.Equal(t, "acme.io", events[1].Domain)
+	require.Equal(t, corestore.PortfolioWatched, events[1].Relation)
+	require.Equal(t, "Example Registrar", events[1].Registrar)
+	require.Equal(t, "acme.com", events[2].Domain)
+
+	all := portfolioExpirations(context.Background(), orchestrator, entries, now, 0)
+	require.Len(t, all, 4)
+	require.Equal(t, "acme.dev", all[3].Domain)
+}
+
+func TestRenderPortfolio(t *testing.T) {
+	entries := []corestore.PortfolioDomain{
+		{Domain: "acme.com", Relation: corestore.PortfolioOwned, AddedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	var md bytes.Buffer
+	require.NoError(t, renderPortfolio(&md, entries, output.FormatMarkdown))
+	require.Contains(t, md.String(), "| acme.com | owned |  |  | 2026-10-01 |")
+
+	var empty bytes.Buffer
+	require.NoError(t, renderPortfolio(&empty, nil, output.FormatTable))
+	require.Contains(t, empty.String(), "No portfolio domains")
+}
+
+func TestPortfolioDomain(t *testing.T) {
+	domain, err := portfolioDomain(" Acme.COM. ")
+	require.NoError(t, err)
+	require.Equal(t, "acme.com", domain)
+
+	_, err = portfolioDomain("acme")
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/namelens/namelens/internal/output"
+)
+
+// rerunSkipFlags are left out of a pr-comment's rerun command, which is for
+// reading the results in a terminal rather than writing another comment.
+var rerunSkipFlags = map[string]bool{
+	"output-format": true,
+	"out":           true,
+	"out-dir":       true,
+}
+
+func prCommentFormatter(cmd *cobra.Command, args []string) *output.PRCommentFormatter {
+	return &output.PRCommentFormatter{Rerun: rerunCommand(cmd, args)}
+}
+
+// rerunCommand rebuilds the command line that reproduces cmd: its path, the
+// arguments, and every flag set on it.
+func rerunCommand(cmd *cobra.Command, args []string) string {
+	parts := strings.Fields(cmd.CommandPath())
+	for _, arg := range args {
+		parts = append(parts, shellQuote(arg))
+	}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if rerunSkipFlags[flag.Name] {
+			return
+		}
+		value := flag.Value.String()
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			value = strings.Join(slice.GetSlice(), ",")
+		}
+		if flag.Value.Type() == "bool" && value == "true" {
+			parts = append(parts, "--"+flag.Name)
+			return
+		}
+		parts = append(parts, "--"+flag.Name+"="+shellQuote(value))
+	})
+	return strings.Join(parts, " ")
+}
+
+// shellQuote single-quotes value for a POSIX shell unless it only has
+// characters that need no quoting.
+func shellQuote(value string) string {
+	if value != "" && strings.Trim(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789._/:=,@+-") == "" {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestRerunCommand(t *testing.T) {
+	root := &cobra.Command{Use: "namelens"}
+	cmd := &cobra.Command{Use: "check"}
+	root.AddCommand(cmd)
+	cmd.Flags().StringSlice("tlds", nil, "")
+	cmd.Flags().Bool("offline", false, "")
+	cmd.Flags().String("handles", "", "")
+	cmd.Flags().String("output-format", "table", "")
+	cmd.Flags().String("profile", "startup", "")
+	require.NoError(t, cmd.Flags().Parse([]string{"--tlds", "com,io", "--offline", "--handles", "", "--output-format", "pr-comment"}))
+
+	require.Equal(t, "namelens check acme 'it'\\''s' --handles='' --offline --tlds=com,io", rerunCommand(cmd, []string{"acme", "it's"}))
+}
+
+func TestResolveOutputFormatPRComment(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("output-format", "table", "")
+	require.NoError(t, cmd.Flags().Parse([]string{"--output-format", "pr-comment"}))
+
+	format, err := resolveCheckOutputFormat(cmd)
+	require.NoError(t, err)
+	require.Equal(t, output.FormatPRComment, format)
+
+	_, err = resolveOutputFormat(cmd)
+	require.ErrorContains(t, err, "only supported by check and batch")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// priceQuoter quotes the registration price of one domain.
+type priceQuoter interface {
+	Quote(ctx context.Context, domain string) (*core.Pricing, error)
+}
+
+func addPriceFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("prices", false, "Quote registrar prices for available domains and flag premium ones (uses pricing.providers)")
+}
+
+// resolvePriceProviders returns the pricing APIs to quote available domains
+// with, or nil when pricing is off. --prices overrides pricing.enabled and
+// requires at least one valid provider.
+func resolvePriceProviders(cmd *cobra.Command, cfg *config.Config) ([]priceQuoter, error) {
+	enabled, err := cmd.Flags().GetBool("prices")
+	if err != nil {
+		return nil, err
+	}
+	if !cmd.Flags().Changed("prices") && cfg != nil {
+		enabled = cfg.Pricing.Enabled
+	}
+	if !enabled || cfg == nil || isOffline(cfg) {
+		return nil, nil
+	}
+
+	providers := buildPriceProviders(cfg.Pricing.Providers)
+	if len(providers) == 0 {
+		if cmd.Flags().Changed("prices") {
+			return nil, errors.New("--prices requires at least one pricing.providers entry in config")
+		}
+		observability.CLILogger.Warn("Price enrichment is enabled but no pricing providers are configured")
+	}
+	return providers, nil
+}
+
+// buildPriceProviders returns the configured pricing APIs in name order.
+// Invalid entries are skipped with a warning.
+func buildPriceProviders(providers map[string]config.PriceProviderConfig) []priceQuoter {
+	keys := make([]string, 0, len(providers))
+	for key := range providers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	quoters := make([]priceQuoter, 0, len(keys))
+	for _, key := range keys {
+		cfg := providers[key]
+		provider, err := checker.NewHTTPPriceProvider(key, checker.PriceProviderConfig{
+			URL:           cfg.URL,
+			Method:        cfg.Method,
+			Headers:       cfg.Headers,
+			Body:          cfg.Body,
+			PriceField:    cfg.PriceField,
+			RenewalField:  cfg.RenewalField,
+			PremiumField:  cfg.PremiumField,
+			CurrencyField: cfg.CurrencyField,
+			Currency:      cfg.Currency,
+			Timeout:       cfg.Timeout,
+		})
+		if err != nil {
+			observability.CLILogger.Warn("Invalid pricing provider; skipping", zap.String("provider", key), zap.Error(err))
+			continue
+		}
+		provider.ToolVersion = versionInfo.Version
+		quoters = append(quoters, provider)
+	}
+	return quoters
+}
+
+// priceAvailable quotes each available domain across batches with the first
+// provider that answers and sets its Pricing. Quotes at or above threshold
+// are flagged premium. It returns how many domains were priced.
+func priceAvailable(ctx context.Context, providers []priceQuoter, threshold float64, batches []*core.BatchResult) int {
+	if len(providers) == 0 {
+		return 0
+	}
+
+	priced := 0
+	for _, batch := range batches {
+		if batch == nil {
+			continue
+		}
+		for _, result := range batch.Results {
+			if ctx.Err() != nil {
+				return priced
+			}
+			if result == nil || result.CheckType != core.CheckTypeDomain || result.Available != core.AvailabilityAvailable {
+				continue
+			}
+			domain := strings.ToLower(strings.TrimSpace(result.Name))
+			for _, provider := range providers {
+				pricing, err := provider.Quote(ctx, domain)
+				if err != nil {
+					observability.CLILogger.Debug("Price quote failed", zap.String("domain", domain), zap.Error(err))
+					continue
+				}
+				if threshold > 0 && pricing.Registration >= threshold {
+					pricing.Premium = true
+				}
+				result.Pricing = pricing
+				priced++
+				break
+			}
+		}
+	}
+	return priced
+}
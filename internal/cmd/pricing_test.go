@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+type stubPriceQuoter struct {
+	prices map[string]float64
+	calls  []string
+}
+
+func (s *stubPriceQuoter) Quote(_ context.Context, domain string) (*core.Pricing, error) {
+	s.calls = append(s.calls, domain)
+	price, ok := s.prices[domain]
+	if !ok {
+		return nil, errors.New("no quote")
+	}
+	return &core.Pricing{Provider: "stub", Currency: "USD", Registration: price}, nil
+}
+
+func TestPriceAvailable(t *testing.T) {
+	first := &stubPriceQuoter{prices: map[string]float64{"acme.ai": 9800}}
+	second := &stubPriceQuoter{prices: map[string]float64{"acme.ai": 1, "acme.io": 40}}
+	batches := []*core.BatchResult{{
+		Name: "acme",
+		Results: []*core.CheckResult{
+			{Name: "acme.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken},
+			{Name: "acme.ai", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+			{Name: "acme.io", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+			{Name: "acme.dev", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+			{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+		},
+	}}
+
+	priced := priceAvailable(context.Background(), []priceQuoter{first, second}, 200, batches)
+	require.Equal(t, 2, priced)
+	require.Equal(t, []string{"acme.ai", "acme.io", "acme.dev"}, first.calls)
+	require.Equal(t, []string{"acme.io", "acme.dev"}, second.calls)
+
+	results := batches[0].Results
+	require.Nil(t, results[0].Pricing)
+	require.Equal(t, 9800.0, results[1].Pricing.Registration)
+	require.True(t, results[1].Pricing.Premium)
+	require.Equal(t, 40.0, results[2].Pricing.Registration)
+	require.False(t, results[2].Pricing.Premium)
+	require.Nil(t, results[3].Pricing)
+	require.Nil(t, results[4].Pricing)
+}
+
+func TestBuildPriceProvidersSkipsInvalid(t *testing.T) {
+	providers := buildPriceProviders(map[string]config.PriceProviderConfig{
+		"broken":    {URL: "https://prices.example/{domain}"},
+		"registrar": {URL: "https://prices.example/{domain}", PriceField: "price"},
+	})
+	require.Len(t, providers, 1)
+}
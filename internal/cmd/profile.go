@@ -1,18 +1,27 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
+	"github.com/namelens/namelens/internal/config"
 	"github.com/namelens/namelens/internal/core"
 )
 
 var profileCmd = &cobra.Command{
 	Use:   "profile",
 	Short: "Manage check profiles",
+	Long: `Manage check profiles stored in the local database.
+
+Built-in profiles are read-only. Custom profiles define the TLDs, registries,
+handles, and default expert depth used by check, batch, compare, and review.`,
 }
 
 var profileListCmd = &cobra.Command{
@@ -42,6 +51,9 @@ var profileListCmd = &cobra.Command{
 			if record.IsBuiltin {
 				suffix = " (builtin)"
 			}
+			if record.Profile.Description != "" {
+				suffix += ": " + record.Profile.Description
+			}
 			fmt.Printf("- %s%s\n", record.Profile.Name, suffix)
 		}
 		return nil
@@ -53,7 +65,7 @@ var profileShowCmd = &cobra.Command{
 	Short: "Show profile details",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name := strings.TrimSpace(args[0])
+		name := normalizeProfileName(args[0])
 		if name == "" {
 			return errors.New("profile name is required")
 		}
@@ -78,10 +90,311 @@ var profileShowCmd = &cobra.Command{
 	},
 }
 
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a custom profile",
+	Long: `Create a custom profile.
+
+Start from an existing profile with --from or a YAML/JSON definition with
+--file, then override individual fields with flags. The result is validated
+against the namelens/v0/profile schema before it is saved.`,
+	Example: `  namelens profile create fintech --tlds=com,io,money --registries=npm --handles=github
+  namelens profile create mytool --from=developer --expert-depth=deep
+  namelens profile create acme --file=profiles/acme.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileCreate,
+}
+
+var profileEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Update fields of a custom profile",
+	Long: `Update fields of a custom profile. Only the flags you pass are changed;
+pass an empty value (for example --handles=) to clear a list.`,
+	Example: `  namelens profile edit fintech --tlds=com,io,finance
+  namelens profile edit fintech --expert-depth=quick`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileEdit,
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a custom profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := normalizeProfileName(args[0])
+		if name == "" {
+			return errors.New("profile name is required")
+		}
+
+		ctx := cmd.Context()
+		store, err := openStore(ctx)
+		if err != nil {
+			return err
+		}
+		defer store.Close() // nolint:errcheck // best-effort cleanup; errors logged internally
+
+		record, err := store.GetProfile(ctx, name)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			return fmt.Errorf("profile %q not found", name)
+		}
+		if record.IsBuiltin {
+			return fmt.Errorf("profile %q is builtin and cannot be deleted", name)
+		}
+
+		if _, err := store.DeleteProfile(ctx, name); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted profile %s\n", name)
+		return nil
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(profileCmd)
 	profileCmd.AddCommand(profileListCmd)
 	profileCmd.AddCommand(profileShowCmd)
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileEditCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+
+	for _, c := range []*cobra.Command{profileCreateCmd, profileEditCmd} {
+		c.Flags().String("description", "", "Profile description")
+		c.Flags().StringSlice("tlds", nil, "TLDs to check (comma-separated)")
+		c.Flags().StringSlice("registries", nil, "Registries to check: npm, pypi, cargo")
+		c.Flags().StringSlice("handles", nil, "Handles to check: github")
+		c.Flags().String("expert-depth", "", "Default expert/analysis depth: quick, deep")
+	}
+	profileCreateCmd.Flags().String("from", "", "Copy fields from an existing profile")
+	profileCreateCmd.Flags().String("file", "", "Load the profile from a YAML or JSON file")
+}
+
+func runProfileCreate(cmd *cobra.Command, args []string) error {
+	name := normalizeProfileName(args[0])
+	if name == "" {
+		return errors.New("profile name is required")
+	}
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return err
+	}
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	if from != "" && file != "" {
+		return errors.New("--from and --file are mutually exclusive")
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() // nolint:errcheck // best-effort cleanup; errors logged internally
+
+	existing, err := store.GetProfile(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("profile %q already exists; use 'profile edit' to change it", name)
+	}
+
+	var profile core.Profile
+	switch {
+	case from != "":
+		base, err := resolveProfile(ctx, store, from, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		profile = base
+	case file != "":
+		loaded, err := loadProfileFile(file, name)
+		if err != nil {
+			return err
+		}
+		profile = loaded
+	}
+	profile.Name = name
+
+	if err := applyProfileFlags(cmd, &profile); err != nil {
+		return err
+	}
+	profile, err = validateProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	if err := store.UpsertProfile(ctx, profile, false, time.Now().UTC()); err != nil {
+		return err
+	}
+	fmt.Printf("Created profile %s\n", profile.Name)
+	printProfile(profile, false)
+	return nil
+}
+
+func runProfileEdit(cmd *cobra.Command, args []string) error {
+	name := normalizeProfileName(args[0])
+	if name == "" {
+		return errors.New("profile name is required")
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() // nolint:errcheck // best-effort cleanup; errors logged internally
+
+	record, err := store.GetProfile(ctx, name)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	if record.IsBuiltin {
+		return fmt.Errorf("profile %q is builtin; create a copy with 'profile create <name> --from=%s'", name, record.Profile.Name)
+	}
+
+	profile := record.Profile
+	if err := applyProfileFlags(cmd, &profile); err != nil {
+		return err
+	}
+	profile, err = validateProfile(profile)
+	if err != nil {
+		return err
+	}
+
+	if err := store.UpsertProfile(ctx, profile, false, time.Now().UTC()); err != nil {
+		return err
+	}
+	fmt.Printf("Updated profile %s\n", profile.Name)
+	printProfile(profile, false)
+	return nil
+}
+
+// normalizeProfileName trims and lowercases a profile name as it is stored.
+func normalizeProfileName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// applyProfileFlags overrides profile fields with the flags that were set.
+func applyProfileFlags(cmd *cobra.Command, profile *core.Profile) error {
+	flags := cmd.Flags()
+	if flags.Changed("description") {
+		value, err := flags.GetString("description")
+		if err != nil {
+			return err
+		}
+		profile.Description = strings.TrimSpace(value)
+	}
+	if flags.Changed("tlds") {
+		values, err := flags.GetStringSlice("tlds")
+		if err != nil {
+			return err
+		}
+		profile.TLDs = values
+	}
+	if flags.Changed("registries") {
+		values, err := flags.GetStringSlice("registries")
+		if err != nil {
+			return err
+		}
+		profile.Registries = values
+	}
+	if flags.Changed("handles") {
+		values, err := flags.GetStringSlice("handles")
+		if err != nil {
+			return err
+		}
+		profile.Handles = values
+	}
+	if flags.Changed("expert-depth") {
+		value, err := flags.GetString("expert-depth")
+		if err != nil {
+			return err
+		}
+		profile.ExpertDepth = strings.ToLower(strings.TrimSpace(value))
+	}
+	return nil
+}
+
+// validateProfile normalizes a profile and validates it against the profile schema.
+func validateProfile(profile core.Profile) (core.Profile, error) {
+	profile.Name = normalizeProfileName(profile.Name)
+	profile.TLDs = normalizeTLDs(profile.TLDs)
+	profile.Registries = normalizeList(profile.Registries)
+	profile.Handles = normalizeList(profile.Handles)
+
+	// Registries served by custom checkers and catalogs are not part of the
+	// schema enum.
+	checked := profile
+	checked.Registries = withoutCustomCheckers(profile.Registries)
+	payload, err := json.Marshal(checked)
+	if err != nil {
+		return core.Profile{}, fmt.Errorf("encode profile: %w", err)
+	}
+	if err := config.ValidateProfileJSON(payload); err != nil {
+		return core.Profile{}, err
+	}
+	if len(profile.TLDs) == 0 && len(profile.Registries) == 0 && len(profile.Handles) == 0 {
+		return core.Profile{}, errors.New("profile must define at least one TLD, registry, or handle")
+	}
+	return profile, nil
+}
+
+func withoutCustomCheckers(registries []string) []string {
+	cfg := config.GetConfig()
+	if cfg == nil || len(cfg.Checkers.Custom)+len(cfg.Checkers.Catalogs) == 0 {
+		return registries
+	}
+	filtered := make([]string, 0, len(registries))
+	for _, registry := range registries {
+		if _, ok := cfg.Checkers.Custom[registry]; ok {
+			continue
+		}
+		if _, ok := cfg.Checkers.Catalogs[registry]; ok {
+			continue
+		}
+		filtered = append(filtered, registry)
+	}
+	return filtered
+}
+
+// loadProfileFile reads a YAML or JSON profile definition and validates its
+// fields against the profile schema. The given name replaces any name in the file.
+func loadProfileFile(path, name string) (core.Profile, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- user-provided profile path
+	if err != nil {
+		return core.Profile{}, fmt.Errorf("read profile file: %w", err)
+	}
+
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return core.Profile{}, fmt.Errorf("parse profile file: %w", err)
+	}
+	if raw == nil {
+		return core.Profile{}, errors.New("profile file is empty")
+	}
+	raw["name"] = name
+
+	payload, err := json.Marshal(raw)
+	if err != nil {
+		return core.Profile{}, fmt.Errorf("encode profile file: %w", err)
+	}
+	if err := config.ValidateProfileJSON(payload); err != nil {
+		return core.Profile{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var profile core.Profile
+	if err := json.Unmarshal(payload, &profile); err != nil {
+		return core.Profile{}, fmt.Errorf("decode profile file: %w", err)
+	}
+	return profile, nil
 }
 
 func printProfile(profile core.Profile, builtin bool) {
@@ -101,4 +414,7 @@ func printProfile(profile core.Profile, builtin bool) {
 	if len(profile.Handles) > 0 {
 		fmt.Printf("Handles: %s\n", strings.Join(profile.Handles, ", "))
 	}
+	if profile.ExpertDepth != "" {
+		fmt.Printf("Expert depth: %s\n", profile.ExpertDepth)
+	}
 }
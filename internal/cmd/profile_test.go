@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestValidateProfileNormalizes(t *testing.T) {
+	profile, err := validateProfile(core.Profile{
+		Name:        " FinTech ",
+		TLDs:        []string{".IO", "com,io"},
+		Registries:  []string{"NPM"},
+		ExpertDepth: "deep",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "fintech", profile.Name)
+	require.Equal(t, []string{"com", "io"}, profile.TLDs)
+	require.Equal(t, []string{"npm"}, profile.Registries)
+}
+
+func TestNormalizeProfileName(t *testing.T) {
+	// create, edit, show, and delete all store and look up this form, so
+	// "profile edit Startup" finds the profile "profile create Startup" wrote
+	require.Equal(t, "startup", normalizeProfileName(" Startup "))
+}
+
+func TestValidateProfileRejectsInvalid(t *testing.T) {
+	cases := map[string]core.Profile{
+		"unknown registry": {Name: "x", Registries: []string{"rubygems"}},
+		"unknown depth":    {Name: "x", TLDs: []string{"com"}, ExpertDepth: "extreme"},
+		"bad name":         {Name: "my profile", TLDs: []string{"com"}},
+		"bad tld":          {Name: "x", TLDs: []string{"co m"}},
+		"no targets":       {Name: "x"},
+	}
+	for label, profile := range cases {
+		t.Run(label, func(t *testing.T) {
+			_, err := validateProfile(profile)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadProfileFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "acme.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("description: Acme\ntlds: [com, io]\nhandles: [github]\nexpert_depth: quick\n"), 0o600))
+
+	profile, err := loadProfileFile(path, "acme")
+	require.NoError(t, err)
+	require.Equal(t, "acme", profile.Name)
+	require.Equal(t, "Acme", profile.Description)
+	require.Equal(t, []string{"com", "io"}, profile.TLDs)
+	require.Equal(t, "quick", profile.ExpertDepth)
+
+	bad := filepath.Join(dir, "bad.yaml")
+	require.NoError(t, os.WriteFile(bad, []byte("tlds: [com]\nregistry: npm\n"), 0o600))
+	_, err = loadProfileFile(bad, "bad")
+	require.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/progress"
+)
+
+// newProgressDisplay returns a live status display on stderr for interactive
+// table output, or nil (which is a no-op) when output is piped, written to
+// --out/--out-dir, not a table, or --no-progress is set.
+func newProgressDisplay(cmd *cobra.Command, format output.Format, outPath, outDir string, total int) *progress.Display {
+	if noProgress, _ := cmd.Flags().GetBool("no-progress"); noProgress {
+		return nil
+	}
+	if format != output.FormatTable || (outPath != "" && outPath != "-") || outDir != "" || total <= 0 {
+		return nil
+	}
+	if !isTerminal(os.Stdout) || !isTerminal(os.Stderr) {
+		return nil
+	}
+	width, _, err := term.GetSize(int(os.Stderr.Fd())) // #nosec G115 -- fd fits int on all supported platforms
+	if err != nil {
+		width = 0
+	}
+	return progress.New(os.Stderr, total, width)
+}
+
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd())) // #nosec G115 -- fd fits int on all supported platforms
+}
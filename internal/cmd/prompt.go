@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/ailink/prompt"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Author and check AILink prompts",
+	Long: `Tools for writing prompts for ailink.prompts_dir.
+
+Prompts are Markdown files with YAML frontmatter (slug, input variables,
+response_schema, ...) and the system template as the body. A prompt in
+prompts_dir shadows the embedded prompt with the same slug.`,
+}
+
+var promptLintCmd = &cobra.Command{
+	Use:   "lint [dir]",
+	Short: "Validate prompt files",
+	Long: `Validate prompt files against the prompt schema and check that:
+
+  - required input variables appear in a template
+  - templates only use declared variables (warning)
+  - {{#if}} blocks are closed
+  - response_schema $refs resolve in the schema catalog
+  - slugs are unique
+
+Lints dir, or ailink.prompts_dir when no dir is given, or the embedded prompts
+when neither is set. Exits non-zero when any error is found.`,
+	Example: `  namelens prompt lint ./prompts
+  namelens prompt lint --output-format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPromptLint,
+}
+
+var promptShowCmd = &cobra.Command{
+	Use:   "show <slug>",
+	Short: "Render a prompt with sample variables",
+	Long: `Show a prompt's metadata and its rendered system and user messages.
+
+Required variables are filled with placeholders such as <name>; optional
+variables stay unset, so {{#if}} fallbacks are shown, unless --all-vars is
+given. Set real values with --var.`,
+	Example: `  namelens prompt show name-phonetics
+  namelens prompt show name-phonetics --var name=acme --var locales=de-DE --depth deep`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptShow,
+}
+
+func init() {
+	rootCmd.AddCommand(promptCmd)
+	promptCmd.AddCommand(promptLintCmd)
+	promptCmd.AddCommand(promptShowCmd)
+
+	promptLintCmd.Flags().String("output-format", "table", "Output format: table, json")
+
+	promptShowCmd.Flags().StringArray("var", nil, "Set a template variable (key=value, repeatable)")
+	promptShowCmd.Flags().String("depth", "", "Render the depth variant (e.g. quick, deep)")
+	promptShowCmd.Flags().Bool("all-vars", false, "Fill optional variables with placeholders too")
+}
+
+type promptLintReport struct {
+	Prompts  int                `json:"prompts"`
+	Errors   int                `json:"errors"`
+	Warnings int                `json:"warnings"`
+	Issues   []prompt.LintIssue `json:"issues"`
+}
+
+func runPromptLint(cmd *cobra.Command, args []string) error {
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatTable && format != output.FormatJSON {
+		return fmt.Errorf("unsupported output format for prompt lint: %s", format)
+	}
+	cfg, err := config.Load(cmd.Context())
+	if err != nil {
+		return err
+	}
+	resolve, err := promptSchemaResolver()
+	if err != nil {
+		return err
+	}
+
+	dir := cfg.AILink.PromptsDir
+	if len(args) == 1 {
+		dir = args[0]
+	}
+
+	report := promptLintReport{Issues: []prompt.LintIssue{}}
+	if strings.TrimSpace(dir) == "" {
+		defaults, err := prompt.LoadDefaults()
+		if err != nil {
+			return err
+		}
+		report.Prompts = len(defaults)
+		for _, p := range defaults {
+			report.Issues = append(report.Issues, prompt.Lint(p, resolve)...)
+		}
+	} else {
+		prompts, issues, err := prompt.LintDir(dir, resolve)
+		if err != nil {
+			return err
+		}
+		report.Prompts = len(prompts)
+		report.Issues = append(report.Issues, issues...)
+	}
+	for _, issue := range report.Issues {
+		if issue.Severity == prompt.LintError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(payload))
+	} else {
+		renderPromptLint(out, report)
+	}
+	if report.Errors > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("prompt lint found %d error(s)", report.Errors)
+	}
+	return nil
+}
+
+func renderPromptLint(w io.Writer, report promptLintReport) {
+	for _, issue := range report.Issues {
+		_, _ = fmt.Fprintf(w, "%s: %s: %s\n", issue.Source, issue.Severity, issue.Message)
+	}
+	_, _ = fmt.Fprintf(w, "%d prompt(s) checked: %d error(s), %d warning(s)\n", report.Prompts, report.Errors, report.Warnings)
+}
+
+// promptSchemaResolver checks response_schema $refs against the schema
+// catalog used at run time.
+func promptSchemaResolver() (prompt.SchemaResolver, error) {
+	catalog, err := buildSchemaCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("load schemas: %w", err)
+	}
+	return func(ref string) error {
+		_, err := catalog.GetSchema(ref)
+		return err
+	}, nil
+}
+
+func runPromptShow(cmd *cobra.Command, args []string) error {
+	setVars, err := cmd.Flags().GetStringArray("var")
+	if err != nil {
+		return err
+	}
+	depth, err := cmd.Flags().GetString("depth")
+	if err != nil {
+		return err
+	}
+	allVars, err := cmd.Flags().GetBool("all-vars")
+	if err != nil {
+		return err
+	}
+	cfg, err := config.Load(cmd.Context())
+	if err != nil {
+		return err
+	}
+	registry, err := buildPromptRegistry(cfg)
+	if err != nil {
+		return err
+	}
+	def, err := registry.Get(args[0])
+	if err != nil {
+		return err
+	}
+	if depth != "" {
+		if _, ok := def.Config.DepthVariants[depth]; !ok {
+			return fmt.Errorf("prompt %s has no %q depth variant", def.Config.Slug, depth)
+		}
+	}
+
+	vars, err := samplePromptVars(def.Config.Input, setVars, allVars)
+	if err != nil {
+		return err
+	}
+	system, user, err := ailink.RenderPrompt(def, vars, depth)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	renderPromptHeader(out, def)
+	_, _ = fmt.Fprintf(out, "\n--- system ---\n%s\n\n--- user ---\n%s\n", strings.TrimSpace(system), strings.TrimSpace(user))
+	return nil
+}
+
+// samplePromptVars fills required variables (and optional ones with
+// allOptional) with <name> placeholders, then applies key=value overrides.
+func samplePromptVars(input prompt.InputSpec, overrides []string, allOptional bool) (map[string]string, error) {
+	vars := map[string]string{}
+	fill := func(names []string) {
+		for _, name := range names {
+			vars[name] = "<" + name + ">"
+		}
+	}
+	fill(input.RequiredVariables)
+	if allOptional {
+		fill(input.OptionalVariables)
+	}
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected key=value", override)
+		}
+		vars[key] = value
+	}
+	// Expert prompts receive the name as {{input}} too
+	if name, ok := vars["name"]; ok {
+		if _, set := vars["input"]; !set {
+			vars["input"] = name
+		}
+	}
+	return vars, nil
+}
+
+func renderPromptHeader(w io.Writer, def *prompt.Prompt) {
+	cfg := def.Config
+	source := "embedded"
+	if !def.Embedded {
+		source = def.Source
+	}
+	_, _ = fmt.Fprintf(w, "%s", cfg.Slug)
+	if cfg.Version != "" {
+		_, _ = fmt.Fprintf(w, " (v%s)", cfg.Version)
+	}
+	_, _ = fmt.Fprintf(w, " — %s\n", source)
+	if cfg.Description != "" {
+		_, _ = fmt.Fprintln(w, cfg.Description)
+	}
+	if len(cfg.Input.RequiredVariables) > 0 {
+		_, _ = fmt.Fprintf(w, "required: %s\n", strings.Join(cfg.Input.RequiredVariables, ", "))
+	}
+	if len(cfg.Input.OptionalVariables) > 0 {
+		_, _ = fmt.Fprintf(w, "optional: %s\n", strings.Join(cfg.Input.OptionalVariables, ", "))
+	}
+	if len(cfg.DepthVariants) > 0 {
+		depths := make([]string, 0, len(cfg.DepthVariants))
+		for depth := range cfg.DepthVariants {
+			depths = append(depths, depth)
+		}
+		sort.Strings(depths)
+		_, _ = fmt.Fprintf(w, "depths: %s\n", strings.Join(depths, ", "))
+	}
+	if ref, ok := cfg.ResponseSchema["$ref"].(string); ok {
+		_, _ = fmt.Fprintf(w, "response schema: %s\n", ref)
+	} else if len(cfg.ResponseSchema) > 0 {
+		_, _ = fmt.Fprintln(w, "response schema: inline")
+	}
+	if attempts := cfg.RepairAttempts(); attempts > 0 {
+		_, _ = fmt.Fprintf(w, "repair attempts: %d\n", attempts)
+	}
+}
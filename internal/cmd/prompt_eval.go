@@ -0,0 +1,429 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+)
+
+// Prompt eval outcomes for a single call.
+const (
+	promptEvalValid   = "valid"
+	promptEvalInvalid = "schema_invalid"
+	promptEvalError   = "error"
+)
+
+var promptEvalCmd = &cobra.Command{
+	Use:   "eval <slug> --against <slug2> --names-file names.txt",
+	Short: "Compare two prompts over a set of names",
+	Long: `Run two prompts over the same names and compare them.
+
+Each name is sent to both prompts, one call at a time, alternating between
+them. Both prompts are routed to the provider of the first slug (or
+--provider) so only the prompt differs. The report shows, per prompt, the
+share of responses that validate against the response schema, call latency,
+and token usage and estimated cost.
+
+Each name is passed as the name and input variables; set other required
+variables with --var. Use --sample to keep runs on large lists affordable.`,
+	Example: `  namelens prompt eval name-availability --against name-availability-v2 --names-file names.txt
+  namelens prompt eval brand-mark --against brand-mark-terse --names-file names.txt --sample 20 --output-format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPromptEval,
+}
+
+func init() {
+	promptCmd.AddCommand(promptEvalCmd)
+
+	promptEvalCmd.Flags().String("against", "", "Prompt slug to compare with (required)")
+	promptEvalCmd.Flags().String("names-file", "", "File with names to evaluate, one per line (required)")
+	promptEvalCmd.Flags().Int("sample", 0, "Evaluate a random sample of this many names")
+	promptEvalCmd.Flags().StringArray("var", nil, "Set a template variable for both prompts (key=value, repeatable)")
+	promptEvalCmd.Flags().String("depth", "", "Prompt depth variant (e.g. quick, deep)")
+	promptEvalCmd.Flags().String("provider", "", "Provider instance for both prompts")
+	promptEvalCmd.Flags().String("model", "", "Model override for both prompts")
+	promptEvalCmd.Flags().String("output-format", "table", "Output format: table, json")
+	_ = promptEvalCmd.MarkFlagRequired("against")
+	_ = promptEvalCmd.MarkFlagRequired("names-file")
+}
+
+// promptEvalRun is one prompt call for one name.
+type promptEvalRun struct {
+	Prompt    string   `json:"prompt"`
+	Name      string   `json:"name"`
+	Outcome   string   `json:"outcome"`
+	LatencyMS int64    `json:"latency_ms"`
+	Tokens    int      `json:"tokens"`
+	CostUSD   *float64 `json:"cost_usd,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// promptEvalSummary aggregates the runs of one prompt.
+type promptEvalSummary struct {
+	Prompt       string   `json:"prompt"`
+	Runs         int      `json:"runs"`
+	Valid        int      `json:"valid"`
+	Invalid      int      `json:"schema_invalid"`
+	Errors       int      `json:"errors"`
+	ValidityRate float64  `json:"validity_rate"`
+	LatencyP50MS int64    `json:"latency_p50_ms"`
+	LatencyP95MS int64    `json:"latency_p95_ms"`
+	LatencyAvgMS int64    `json:"latency_mean_ms"`
+	Tokens       int      `json:"tokens"`
+	CostUSD      *float64 `json:"cost_usd,omitempty"`
+}
+
+type promptEvalReport struct {
+	Names    int                 `json:"names"`
+	Provider string              `json:"provider"`
+	Model    string              `json:"model"`
+	Depth    string              `json:"depth,omitempty"`
+	Prompts  []promptEvalSummary `json:"prompts"`
+	Runs     []promptEvalRun     `json:"runs"`
+}
+
+func runPromptEval(cmd *cobra.Command, args []string) error {
+	baseline := strings.TrimSpace(args[0])
+	against, err := cmd.Flags().GetString("against")
+	if err != nil {
+		return err
+	}
+	against = strings.TrimSpace(against)
+	namesFile, err := cmd.Flags().GetString("names-file")
+	if err != nil {
+		return err
+	}
+	sample, err := cmd.Flags().GetInt("sample")
+	if err != nil {
+		return err
+	}
+	setVars, err := cmd.Flags().GetStringArray("var")
+	if err != nil {
+		return err
+	}
+	depth, err := cmd.Flags().GetString("depth")
+	if err != nil {
+		return err
+	}
+	providerOverride, err := cmd.Flags().GetString("provider")
+	if err != nil {
+		return err
+	}
+	modelOverride, err := cmd.Flags().GetString("model")
+	if err != nil {
+		return err
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatTable && format != output.FormatJSON {
+		return fmt.Errorf("unsupported output format for prompt eval: %s", format)
+	}
+	if against == "" || against == baseline {
+		return errors.New("--against must name a different prompt")
+	}
+	if sample < 0 {
+		return errors.New("--sample must be 0 or greater")
+	}
+
+	vars := map[string]string{}
+	for _, v := range setVars {
+		key, value, ok := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --var %q: expected key=value", v)
+		}
+		vars[key] = value
+	}
+
+	names, err := readNamesFile(namesFile)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no names found in %s", namesFile)
+	}
+	names = sampleNames(names, sample, rand.Shuffle)
+
+	ctx := cmd.Context()
+	cfg, err := config.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if isOffline(cfg) {
+		return errors.New("prompt eval needs an AI provider and is not available offline")
+	}
+
+	registry, err := buildPromptRegistry(cfg)
+	if err != nil {
+		return fmt.Errorf("loading prompts: %w", err)
+	}
+	baselineDef, err := registry.Get(baseline)
+	if err != nil {
+		return fmt.Errorf("prompt not found: %w", err)
+	}
+	for _, slug := range []string{baseline, against} {
+		def, err := registry.Get(slug)
+		if err != nil {
+			return fmt.Errorf("prompt not found: %w", err)
+		}
+		if depth != "" {
+			if _, ok := def.Config.DepthVariants[depth]; !ok {
+				return fmt.Errorf("prompt %s has no %q depth variant", slug, depth)
+			}
+		}
+	}
+
+	// Route both prompts through the baseline's role so they share a provider
+	role := baseline
+	ailinkCfg := cfg.AILink
+	if strings.TrimSpace(providerOverride) != "" {
+		ailinkCfg, err = applyGenerateProviderOverride(cfg.AILink, role, providerOverride)
+		if err != nil {
+			return err
+		}
+	}
+	providers := ailink.NewRegistry(ailinkCfg)
+	resolved, err := providers.Resolve(role, baselineDef, modelOverride)
+	if err != nil {
+		return fmt.Errorf("resolving provider: %w", err)
+	}
+	if strings.TrimSpace(resolved.Credential.APIKey) == "" {
+		return errors.New("provider API key not configured")
+	}
+
+	catalog, err := buildSchemaCatalog()
+	if err != nil {
+		return fmt.Errorf("loading schemas: %w", err)
+	}
+	quotaStore, err := openQuotaStore(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if quotaStore != nil {
+		defer quotaStore.Close() //nolint:errcheck
+	}
+
+	service := &ailink.Service{
+		Providers: providers,
+		Registry:  registry,
+		Catalog:   catalog,
+		Quota:     aiQuota(cfg, quotaStore),
+		Budget:    aiBudget(cfg, quotaStore),
+	}
+
+	ctx, runUsage := ailink.WithUsageTracker(ctx)
+	defer func() { printAIUsageSummary(os.Stderr, runUsage.Snapshot()) }()
+
+	report := promptEvalReport{
+		Names:    len(names),
+		Provider: resolved.ProviderID,
+		Model:    resolved.Model,
+		Depth:    depth,
+	}
+	for i, name := range names {
+		// Alternate which prompt goes first so warm connections and provider
+		// caching do not favour one side
+		order := []string{baseline, against}
+		if i%2 == 1 {
+			order = []string{against, baseline}
+		}
+		for _, slug := range order {
+			variables := map[string]string{"name": name, "input": name}
+			for key, value := range vars {
+				variables[key] = value
+			}
+
+			callCtx, tracker := ailink.WithUsageTracker(ctx)
+			start := time.Now()
+			_, err := service.Generate(callCtx, ailink.GenerateRequest{
+				Role:       role,
+				PromptSlug: slug,
+				Variables:  variables,
+				Depth:      depth,
+				Model:      modelOverride,
+			})
+			run := promptEvalRun{
+				Prompt:    slug,
+				Name:      name,
+				Outcome:   promptEvalOutcome(err),
+				LatencyMS: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				run.Error = err.Error()
+			}
+			usage := tracker.Snapshot()
+			run.Tokens = usage.TotalTokens
+			run.CostUSD = usage.EstimatedCostUSD
+			report.Runs = append(report.Runs, run)
+
+			observability.CLILogger.Debug("Prompt eval run",
+				zap.String("prompt", slug),
+				zap.String("name", name),
+				zap.String("outcome", run.Outcome),
+				zap.Int64("latency_ms", run.LatencyMS))
+		}
+	}
+	report.Prompts = []promptEvalSummary{
+		summarizePromptEval(baseline, report.Runs),
+		summarizePromptEval(against, report.Runs),
+	}
+
+	out := cmd.OutOrStdout()
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(payload))
+		return nil
+	}
+	renderPromptEval(out, report)
+	return nil
+}
+
+// promptEvalOutcome classifies a Generate error: a response that failed
+// schema validation is a prompt-quality signal; anything else is an error.
+func promptEvalOutcome(err error) string {
+	if err == nil {
+		return promptEvalValid
+	}
+	var rawErr *ailink.RawResponseError
+	if errors.As(err, &rawErr) {
+		return promptEvalInvalid
+	}
+	return promptEvalError
+}
+
+// summarizePromptEval aggregates the runs of slug. Latency covers calls that
+// got a response (valid or not); errors often fail fast and would skew it.
+// Cost is nil when no run had pricing.
+func summarizePromptEval(slug string, runs []promptEvalRun) promptEvalSummary {
+	summary := promptEvalSummary{Prompt: slug}
+	var latencies []int64
+	var total int64
+	for _, run := range runs {
+		if run.Prompt != slug {
+			continue
+		}
+		summary.Runs++
+		summary.Tokens += run.Tokens
+		if run.CostUSD != nil {
+			cost := *run.CostUSD
+			if summary.CostUSD != nil {
+				cost += *summary.CostUSD
+			}
+			summary.CostUSD = &cost
+		}
+		switch run.Outcome {
+		case promptEvalValid:
+			summary.Valid++
+		case promptEvalInvalid:
+			summary.Invalid++
+		default:
+			summary.Errors++
+			continue
+		}
+		latencies = append(latencies, run.LatencyMS)
+		total += run.LatencyMS
+	}
+	if summary.Runs > 0 {
+		summary.ValidityRate = float64(summary.Valid) / float64(summary.Runs)
+	}
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		summary.LatencyP50MS = percentileMS(latencies, 50)
+		summary.LatencyP95MS = percentileMS(latencies, 95)
+		summary.LatencyAvgMS = total / int64(len(latencies))
+	}
+	return summary
+}
+
+// percentileMS returns the nearest-rank percentile of sorted latencies.
+func percentileMS(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func renderPromptEval(w io.Writer, report promptEvalReport) {
+	_, _ = fmt.Fprintf(w, "Prompt eval: %d name(s) on %s", report.Names, report.Provider)
+	if report.Model != "" {
+		_, _ = fmt.Fprintf(w, " (%s)", report.Model)
+	}
+	if report.Depth != "" {
+		_, _ = fmt.Fprintf(w, ", depth %s", report.Depth)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w)
+
+	rows := [][]string{{""}}
+	labels := []string{"Schema valid", "Schema invalid", "Errors", "Latency p50", "Latency p95", "Latency mean", "Tokens", "Est. cost"}
+	for _, label := range labels {
+		rows = append(rows, []string{label})
+	}
+	for _, s := range report.Prompts {
+		rate := "-"
+		if s.Runs > 0 {
+			rate = fmt.Sprintf("%d/%d (%.0f%%)", s.Valid, s.Runs, s.ValidityRate*100)
+		}
+		values := []string{
+			s.Prompt,
+			rate,
+			fmt.Sprintf("%d", s.Invalid),
+			fmt.Sprintf("%d", s.Errors),
+			formatEvalLatency(s.LatencyP50MS),
+			formatEvalLatency(s.LatencyP95MS),
+			formatEvalLatency(s.LatencyAvgMS),
+			fmt.Sprintf("%d", s.Tokens),
+			formatCostUSD(s.CostUSD),
+		}
+		for i := range rows {
+			rows[i] = append(rows[i], values[i])
+		}
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i > 0 {
+				_, _ = fmt.Fprint(w, "   ")
+			}
+			if i == len(row)-1 {
+				_, _ = fmt.Fprint(w, cell)
+			} else {
+				_, _ = fmt.Fprintf(w, "%-*s", widths[i], cell)
+			}
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
+
+func formatEvalLatency(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return (time.Duration(ms) * time.Millisecond).Round(10 * time.Millisecond).String()
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+)
+
+func TestPromptEvalOutcome(t *testing.T) {
+	require.Equal(t, promptEvalValid, promptEvalOutcome(nil))
+	invalid := fmt.Errorf("generate: %w", &ailink.RawResponseError{Err: errors.New("missing field")})
+	require.Equal(t, promptEvalInvalid, promptEvalOutcome(invalid))
+	require.Equal(t, promptEvalError, promptEvalOutcome(errors.New("timeout")))
+}
+
+func TestSummarizePromptEval(t *testing.T) {
+	cost := func(v float64) *float64 { return &v }
+	runs := []promptEvalRun{
+		{Prompt: "a", Outcome: promptEvalValid, LatencyMS: 100, Tokens: 10, CostUSD: cost(0.01)},
+		{Prompt: "b", Outcome: promptEvalValid, LatencyMS: 900, Tokens: 50},
+		{Prompt: "a", Outcome: promptEvalInvalid, LatencyMS: 300, Tokens: 20, CostUSD: cost(0.02)},
+		{Prompt: "a", Outcome: promptEvalValid, LatencyMS: 200, Tokens: 15},
+		{Prompt: "a", Outcome: promptEvalError, LatencyMS: 5},
+	}
+
+	a := summarizePromptEval("a", runs)
+	require.Equal(t, 4, a.Runs)
+	require.Equal(t, 2, a.Valid)
+	require.Equal(t, 1, a.Invalid)
+	require.Equal(t, 1, a.Errors)
+	require.InDelta(t, 0.5, a.ValidityRate, 1e-9)
+	// The fast-failing error is left out of latency
+	require.Equal(t, int64(200), a.LatencyP50MS)
+	require.Equal(t, int64(300), a.LatencyP95MS)
+	require.Equal(t, int64(200), a.LatencyAvgMS)
+	require.Equal(t, 45, a.Tokens)
+	require.NotNil(t, a.CostUSD)
+	require.InDelta(t, 0.03, *a.CostUSD, 1e-9)
+
+	b := summarizePromptEval("b", runs)
+	require.Equal(t, 1, b.Runs)
+	require.InDelta(t, 1.0, b.ValidityRate, 1e-9)
+	require.Nil(t, b.CostUSD)
+
+	none := summarizePromptEval("c", runs)
+	require.Zero(t, none.Runs)
+	require.Zero(t, none.LatencyP50MS)
+}
+
+func TestRenderPromptEval(t *testing.T) {
+	cost := 0.0123
+	var buf bytes.Buffer
+	renderPromptEval(&buf, promptEvalReport{
+		Names:    2,
+		Provider: "namelens-xai",
+		Model:    "grok",
+		Prompts: []promptEvalSummary{
+			{Prompt: "name-availability", Runs: 2, Valid: 1, Invalid: 1, ValidityRate: 0.5, LatencyP50MS: 1200, Tokens: 300, CostUSD: &cost},
+			{Prompt: "name-availability-v2", Runs: 2, Errors: 2},
+		},
+	})
+	out := buf.String()
+	require.Contains(t, out, "Prompt eval: 2 name(s) on namelens-xai (grok)")
+	require.Contains(t, out, "1/2 (50%)")
+	require.Contains(t, out, "0/2 (0%)")
+	require.Contains(t, out, "1.2s")
+	require.Contains(t, out, "$0.0123")
+}
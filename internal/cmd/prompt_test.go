@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/ailink/prompt"
+)
+
+func TestSamplePromptVars(t *testing.T) {
+	input := prompt.InputSpec{RequiredVariables: []string{"name"}, OptionalVariables: []string{"locales"}}
+
+	vars, err := samplePromptVars(input, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"name": "<name>", "input": "<name>"}, vars)
+
+	vars, err = samplePromptVars(input, []string{"locales=de-DE", "name=acme"}, true)
+	require.NoError(t, err)
+	require.Equal(t, "acme", vars["name"])
+	require.Equal(t, "de-DE", vars["locales"])
+
+	_, err = samplePromptVars(input, []string{"novalue"}, false)
+	require.Error(t, err)
+}
+
+func TestPromptSchemaResolverFindsUserSchemas(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Cleanup(func() { _ = ailink.CleanupStandaloneSchemas() })
+	path := filepath.Join(configHome, "namelens", "schemas", "user", "v0", "brand-fit.schema.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(`{"type":"object","required":["fit"]}`), 0o600))
+
+	resolve, err := promptSchemaResolver()
+	require.NoError(t, err)
+	require.NoError(t, resolve("user/v0/brand-fit"))
+	require.NoError(t, resolve("ailink/v0/name-phonetics-response"))
+	require.Error(t, resolve("user/v0/missing"))
+}
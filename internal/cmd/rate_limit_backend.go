@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core/engine"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+var (
+	redisBackendsMu sync.Mutex
+	// redisBackends reuses one client per URL across the orchestrators a
+	// process builds, such as serve's shared one and its cache warmup.
+	redisBackends = map[string]*engine.RedisRateLimitBackend{}
+)
+
+// configuredRateLimitBackend returns the shared backend for
+// rate_limiter.backend, or nil to keep rate limit state in the store. An
+// unknown backend or unusable Redis URL falls back to the store.
+func configuredRateLimitBackend(cfg *config.Config) engine.RateLimitBackend {
+	if cfg == nil {
+		return nil
+	}
+	switch strings.ToLower(strings.TrimSpace(cfg.RateLimiter.Backend)) {
+	case "", "store":
+		return nil
+	case "redis":
+	default:
+		warnRateLimitBackend("Unknown rate_limiter.backend; using the store", zap.String("backend", cfg.RateLimiter.Backend))
+		return nil
+	}
+
+	url := strings.TrimSpace(cfg.RateLimiter.URL)
+	redisBackendsMu.Lock()
+	defer redisBackendsMu.Unlock()
+	if backend, ok := redisBackends[url]; ok {
+		return backend
+	}
+	backend, err := engine.NewRedisRateLimitBackend(url)
+	if err != nil {
+		warnRateLimitBackend("Invalid rate_limiter.url; using the store", zap.Error(err))
+		return nil
+	}
+	redisBackends[url] = backend
+	return backend
+}
+
+func warnRateLimitBackend(msg string, fields ...zap.Field) {
+	if observability.CLILogger != nil {
+		observability.CLILogger.Warn(msg, fields...)
+	}
+}
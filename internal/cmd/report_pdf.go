@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/output"
+)
+
+// defaultPDFCommands are tried in order when report.pdf_command is empty;
+// the first whose program is on PATH converts the report.
+var defaultPDFCommands = []string{
+	"wkhtmltopdf --quiet {html} {pdf}",
+	"chromium --headless --disable-gpu --print-to-pdf={pdf} file://{html}",
+	"chromium-browser --headless --disable-gpu --print-to-pdf={pdf} file://{html}",
+	"google-chrome --headless --disable-gpu --print-to-pdf={pdf} file://{html}",
+}
+
+// addPDFFlag adds --pdf to a command that renders HTML reports.
+func addPDFFlag(cmd *cobra.Command) {
+	cmd.Flags().String("pdf", "", "Also convert the HTML report to a PDF file (requires --output-format html)")
+}
+
+// resolvePDFPath returns the --pdf path, or "" when the flag is unset.
+func resolvePDFPath(cmd *cobra.Command, format output.Format) (string, error) {
+	path, err := cmd.Flags().GetString("pdf")
+	if err != nil {
+		return "", err
+	}
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", nil
+	}
+	if format != output.FormatHTML {
+		return "", errors.New("--pdf requires --output-format html")
+	}
+	return path, nil
+}
+
+// writeReportPDF renders an HTML report to a temporary file and converts it
+// to pdfPath with the configured or detected converter.
+func writeReportPDF(ctx context.Context, cfg *config.Config, pdfPath string, render func(io.Writer) error) error {
+	template := ""
+	if cfg != nil {
+		template = cfg.Report.PDFCommand
+	}
+
+	htmlFile, err := os.CreateTemp("", "namelens-report-*.html")
+	if err != nil {
+		return fmt.Errorf("create temporary report: %w", err)
+	}
+	defer os.Remove(htmlFile.Name()) //nolint:errcheck
+	if err := render(htmlFile); err != nil {
+		_ = htmlFile.Close()
+		return err
+	}
+	if err := htmlFile.Close(); err != nil {
+		return err
+	}
+
+	absPDF, err := filepath.Abs(pdfPath)
+	if err != nil {
+		return err
+	}
+	argv, err := pdfCommand(template, htmlFile.Name(), absPDF, exec.LookPath)
+	if err != nil {
+		return err
+	}
+
+	out, err := exec.CommandContext(ctx, argv[0], argv[1:]...).CombinedOutput() // #nosec G204 -- converter comes from user config or a fixed list
+	if err != nil {
+		return fmt.Errorf("convert report to PDF with %s: %w: %s", argv[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// pdfCommand expands a converter template into arguments. The template is
+// split on whitespace without a shell, then {html} and {pdf} are replaced in
+// each argument, so paths with spaces stay one argument. An empty template
+// picks the first of defaultPDFCommands found by lookPath.
+func pdfCommand(template, htmlPath, pdfPath string, lookPath func(string) (string, error)) ([]string, error) {
+	template = strings.TrimSpace(template)
+	if template == "" {
+		for _, candidate := range defaultPDFCommands {
+			if _, err := lookPath(strings.Fields(candidate)[0]); err == nil {
+				template = candidate
+				break
+			}
+		}
+		if template == "" {
+			return nil, errors.New("no PDF converter found: install wkhtmltopdf or Chromium, or set report.pdf_command")
+		}
+	}
+	if !strings.Contains(template, "{html}") || !strings.Contains(template, "{pdf}") {
+		return nil, errors.New("report.pdf_command must contain {html} and {pdf}")
+	}
+
+	replacer := strings.NewReplacer("{html}", htmlPath, "{pdf}", pdfPath)
+	fields := strings.Fields(template)
+	argv := make([]string, len(fields))
+	for i, field := range fields {
+		argv[i] = replacer.Replace(field)
+	}
+	return argv, nil
+}
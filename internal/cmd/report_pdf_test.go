@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestPDFCommand(t *testing.T) {
+	argv, err := pdfCommand("weasyprint {html} {pdf}", "/tmp/my report.html", "/tmp/out.pdf", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"weasyprint", "/tmp/my report.html", "/tmp/out.pdf"}, argv)
+
+	_, err = pdfCommand("weasyprint {html}", "/tmp/r.html", "/tmp/out.pdf", nil)
+	require.ErrorContains(t, err, "must contain {html} and {pdf}")
+
+	onlyChromium := func(name string) (string, error) {
+		if name == "chromium" {
+			return "/usr/bin/chromium", nil
+		}
+		return "", errors.New("not found")
+	}
+	argv, err = pdfCommand("", "/tmp/r.html", "/tmp/out.pdf", onlyChromium)
+	require.NoError(t, err)
+	require.Equal(t, []string{"chromium", "--headless", "--disable-gpu", "--print-to-pdf=/tmp/out.pdf", "file:///tmp/r.html"}, argv)
+
+	_, err = pdfCommand("", "/tmp/r.html", "/tmp/out.pdf", func(string) (string, error) { return "", errors.New("not found") })
+	require.ErrorContains(t, err, "no PDF converter found")
+}
+
+func TestResolvePDFPath(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	addPDFFlag(cmd)
+	require.NoError(t, cmd.Flags().Parse([]string{"--pdf", "report.pdf"}))
+
+	path, err := resolvePDFPath(cmd, output.FormatHTML)
+	require.NoError(t, err)
+	require.Equal(t, "report.pdf", path)
+
+	_, err = resolvePDFPath(cmd, output.FormatMarkdown)
+	require.ErrorContains(t, err, "requires --output-format html")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// withNameBudget returns a context that counts the requests made for one name.
+func withNameBudget(ctx context.Context) (context.Context, *core.RequestBudget) {
+	budget := &core.RequestBudget{}
+	return core.WithRequestBudget(ctx, budget), budget
+}
+
+// runRequestCounts totals per-name request counts plus any run-level counts
+// (such as bulk expert calls) that do not belong to a single name.
+func runRequestCounts(batches []*core.BatchResult, run *core.RequestBudget) map[string]int {
+	total := &core.RequestBudget{}
+	total.Merge(run.Counts())
+	for _, batch := range batches {
+		if batch == nil {
+			continue
+		}
+		total.Merge(batch.Requests)
+	}
+	return total.Counts()
+}
+
+// printRequestSummary writes the "--request-summary" footer.
+func printRequestSummary(w io.Writer, counts map[string]int) {
+	_, _ = fmt.Fprintf(w, "\nRequests: %s\n", core.RequestSummary(counts))
+}
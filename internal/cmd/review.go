@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"path/filepath"
+	"os"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fulmenhq/gofulmen/ascii"
@@ -23,6 +25,9 @@ import (
 	corestore "github.com/namelens/namelens/internal/core/store"
 	"github.com/namelens/namelens/internal/observability"
 	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/redact"
+	"github.com/namelens/namelens/internal/similarity"
+	"github.com/namelens/namelens/internal/wordscan"
 )
 
 type includeRawMode string
@@ -36,7 +41,7 @@ const (
 func rawFromAILinkError(err error) json.RawMessage {
 	var rawErr *ailink.RawResponseError
 	if errors.As(err, &rawErr) && rawErr != nil && len(rawErr.Raw) > 0 {
-		return rawErr.Raw
+		return redact.JSON(rawErr.Raw)
 	}
 	return nil
 }
@@ -80,25 +85,33 @@ func runReviewSearch(ctx context.Context, cfg *config.Config, store *corestore.S
 	}
 
 	cacheTTL := cfg.AILink.CacheTTL
-	if useCache && store != nil && cacheTTL > 0 {
+	if (useCache || isOffline(cfg)) && store != nil && cacheTTL > 0 {
 		entry, err := store.GetExpertCache(ctx, name, promptSlug, resolved.Model, resolved.BaseURL, depth)
 		if err != nil {
 			observability.CLILogger.Warn("Expert cache lookup failed", zap.Error(err))
 		} else if entry != nil {
 			response, err := decodeCachedExpert(entry.ResponseJSON)
 			if err == nil {
+				ailink.CheckPlausibility(response, depth)
+				core.CountRequest(ctx, core.RequestCacheHit)
 				return response, nil, response.Raw
 			}
 			observability.CLILogger.Warn("Expert cache decode failed", zap.Error(err))
 		}
 	}
 
+	if isOffline(cfg) {
+		return nil, offlineSearchError(), nil
+	}
+
 	catalog, err := buildSchemaCatalog()
 	if err != nil {
 		return nil, &ailink.SearchError{Code: "AILINK_API_ERROR", Message: "failed to load schemas", Details: err.Error()}, nil
 	}
 
-	svc := &ailink.Service{Providers: providers, Registry: registry, Catalog: catalog}
+	svc := &ailink.Service{Providers: providers, Registry: registry, Catalog: catalog, Quota: aiQuota(cfg, store), Budget: aiBudget(cfg, store)}
+	core.CountRequest(ctx, core.RequestAI)
+	core.ReportProgress(ctx, "AI "+promptSlug)
 	response, err := svc.Search(ctx, ailink.SearchRequest{Role: role, Name: name, PromptSlug: promptSlug, Depth: depth, Model: modelOverride, UseTools: true})
 	if err != nil {
 		return nil, ailink.MapProviderError(err), rawFromAILinkError(err)
@@ -174,22 +187,29 @@ func runReviewGenerate(ctx context.Context, cfg *config.Config, store *corestore
 
 	cacheTTL := cfg.AILink.CacheTTL
 	cacheSlug := analysisCacheKey(promptSlug, cleaned)
-	if useCache && store != nil && cacheTTL > 0 {
+	if (useCache || isOffline(cfg)) && store != nil && cacheTTL > 0 {
 		entry, err := store.GetExpertCache(ctx, name, cacheSlug, resolved.Model, resolved.BaseURL, depth)
 		if err != nil {
 			observability.CLILogger.Warn("Expert cache lookup failed", zap.Error(err))
 		} else if entry != nil {
 			raw := json.RawMessage(entry.ResponseJSON)
+			core.CountRequest(ctx, core.RequestCacheHit)
 			return raw, nil, raw
 		}
 	}
 
+	if isOffline(cfg) {
+		return nil, offlineSearchError(), nil
+	}
+
 	catalog, err := buildSchemaCatalog()
 	if err != nil {
 		return nil, &ailink.SearchError{Code: "AILINK_API_ERROR", Message: "failed to load schemas", Details: err.Error()}, nil
 	}
 
-	svc := &ailink.Service{Providers: providers, Registry: registry, Catalog: catalog}
+	svc := &ailink.Service{Providers: providers, Registry: registry, Catalog: catalog, Quota: aiQuota(cfg, store), Budget: aiBudget(cfg, store)}
+	core.CountRequest(ctx, core.RequestAI)
+	core.ReportProgress(ctx, "AI "+promptSlug)
 	response, err := svc.Generate(ctx, ailink.GenerateRequest{Role: role, PromptSlug: promptSlug, Variables: cleaned, Depth: depth, Model: modelOverride, UseTools: true})
 	if err != nil {
 		return nil, ailink.MapProviderError(err), rawFromAILinkError(err)
@@ -217,29 +237,59 @@ type reviewResult struct {
 	CompletedAt  time.Time                 `json:"completed_at"`
 	Availability reviewAvailability        `json:"availability"`
 	Analyses     map[string]reviewAnalysis `json:"analyses"`
+	Usage        reviewUsage               `json:"usage"`
 }
 
 type reviewAvailability struct {
-	Results     []*core.CheckResult `json:"results"`
-	Score       int                 `json:"score"`
-	Total       int                 `json:"total"`
-	Unknown     int                 `json:"unknown"`
-	CompletedAt time.Time           `json:"completed_at"`
+	Results          []*core.CheckResult `json:"results"`
+	Score            int                 `json:"score"`
+	Total            int                 `json:"total"`
+	Unknown          int                 `json:"unknown"`
+	SimilarBrands    []similarity.Match  `json:"similar_brands,omitempty"`
+	UnfortunateWords []wordscan.Finding  `json:"unfortunate_words,omitempty"`
+	CompletedAt      time.Time           `json:"completed_at"`
 }
 
 type reviewAnalysis struct {
-	OK    bool                `json:"ok"`
-	Data  json.RawMessage     `json:"data,omitempty"`
-	Error *ailink.SearchError `json:"error,omitempty"`
-	Raw   json.RawMessage     `json:"raw,omitempty"`
+	OK         bool                `json:"ok"`
+	Data       json.RawMessage     `json:"data,omitempty"`
+	Error      *ailink.SearchError `json:"error,omitempty"`
+	Raw        json.RawMessage     `json:"raw,omitempty"`
+	DurationMS int64               `json:"duration_ms"`
+	Cached     bool                `json:"cached,omitempty"`
+	Usage      *ailink.Usage       `json:"usage,omitempty"`
+	// Context records how oversized brand context was reduced to fit.
+	Context *ailink.ContextReport `json:"context,omitempty"`
+}
+
+// reviewUsage totals analysis time, tokens, and estimated cost for one name.
+// DurationMS is the sum of analysis durations, not wall-clock time.
+type reviewUsage struct {
+	Analyses         int      `json:"analyses"`
+	Cached           int      `json:"cached"`
+	DurationMS       int64    `json:"duration_ms"`
+	PromptTokens     int      `json:"prompt_tokens"`
+	CompletionTokens int      `json:"completion_tokens"`
+	TotalTokens      int      `json:"total_tokens"`
+	EstimatedCostUSD *float64 `json:"estimated_cost_usd,omitempty"`
 }
 
 var reviewCmd = &cobra.Command{
 	Use:   "review [<name>...]",
 	Short: "Run a stitched name review workflow",
-	Long:  "Review runs availability checks plus a mode-selected set of AILink analysis prompts.",
-	Args:  cobra.ArbitraryArgs,
-	RunE:  runReview,
+	Long: `Review runs availability checks plus a mode-selected set of AILink analysis prompts.
+
+Templates (--template) preset the mode, profile, depth, sensitivity, locales,
+and output format for a decision context. Built-in templates are
+startup-launch, oss-library, and internal-codename; add or override templates
+with YAML files in review.templates_dir. Flags given explicitly take
+precedence over the template.
+
+Availability checks (--concurrency) and AI analyses (--ai-concurrency) run in
+separate worker pools, so network lookups overlap with model calls when
+reviewing many names.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runReview,
 }
 
 func init() {
@@ -248,21 +298,55 @@ func init() {
 	reviewCmd.Flags().String("profile", "startup", "Availability profile to use")
 	reviewCmd.Flags().String("mode", "core", "Review mode: quick (screening), core (basic), brand (finalists), full (comprehensive)")
 	reviewCmd.Flags().String("depth", "quick", "Analysis depth: quick, deep")
+	reviewCmd.Flags().Int("concurrency", 3, "Concurrent availability checks across names")
+	reviewCmd.Flags().Int("ai-concurrency", 2, "Concurrent AI analyses across names and prompts")
+	reviewCmd.Flags().Duration("analysis-timeout", 0, "Timeout for each AI analysis (0 uses the provider default)")
+	addFailIfFlag(reviewCmd)
 	reviewCmd.Flags().String("names-file", "", "Read names from file (one per line) or '-' for stdin")
-	reviewCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	addNameSelectionFlags(reviewCmd)
+	addSaaSFlag(reviewCmd)
+	reviewCmd.Flags().String("output-format", "table", "Output format: table, json, markdown, html, csv")
 	reviewCmd.Flags().String("out", "", "Write output to a file (default stdout)")
 	reviewCmd.Flags().String("out-dir", "", "Write per-name outputs to a directory")
+	addPDFFlag(reviewCmd)
+	addOutputTemplateFlag(reviewCmd)
+	addOutArchiveFlag(reviewCmd)
 	reviewCmd.Flags().String("include-raw", string(includeRawOnFail), "Include raw analysis output: never, on-failure, always")
 	reviewCmd.Flags().Bool("strict", false, "Return non-zero if any analysis fails")
 	reviewCmd.Flags().Bool("no-cache", false, "Skip cache lookup")
+	reviewCmd.Flags().Bool("no-progress", false, "Disable the live progress display on interactive terminals")
 	reviewCmd.Flags().StringP("context-file", "f", "", "Read product context from file for brand analyses (truncated to 2000 chars)")
 	reviewCmd.Flags().StringP("scan-dir", "s", "", "Scan directory for context files for brand analyses")
 	reviewCmd.Flags().Int("scan-budget", 32000, "Max characters to include from scanned context files")
 	reviewCmd.Flags().String("locales", "", "Comma-separated locales for phonetics analysis (passed to name-phonetics prompt)")
 	reviewCmd.Flags().String("keyboards", "", "Comma-separated keyboard layouts for phonetics analysis (passed to name-phonetics prompt)")
+	reviewCmd.Flags().String("sensitivity", "", "Suitability sensitivity: minimal, standard, strict")
+	addMarketsFlag(reviewCmd)
+	addConsensusFlags(reviewCmd)
+	addSimilarityFlags(reviewCmd)
+	addWordScanFlags(reviewCmd)
+	reviewCmd.Flags().String("template", "", "Review template bundling mode, profile, depth, sensitivity, locales, and output format")
+	reviewCmd.Flags().Bool("list-templates", false, "List available review templates and exit")
 }
 
 func runReview(cmd *cobra.Command, args []string) error {
+	listTemplates, err := cmd.Flags().GetBool("list-templates")
+	if err != nil {
+		return err
+	}
+	if listTemplates {
+		return runListReviewTemplates(cmd)
+	}
+	templateName, err := cmd.Flags().GetString("template")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(templateName) != "" {
+		if err := applyReviewTemplate(cmd, templateName); err != nil {
+			return err
+		}
+	}
+
 	namesFile, err := cmd.Flags().GetString("names-file")
 	if err != nil {
 		return err
@@ -271,6 +355,10 @@ func runReview(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	names, err = selectNames(cmd, names)
+	if err != nil {
+		return err
+	}
 
 	profileName, err := cmd.Flags().GetString("profile")
 	if err != nil {
@@ -284,6 +372,31 @@ func runReview(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	failIf, err := resolveFailIf(cmd)
+	if err != nil {
+		return err
+	}
+	concurrency, err := cmd.Flags().GetInt("concurrency")
+	if err != nil {
+		return err
+	}
+	if concurrency < 1 {
+		return errors.New("concurrency must be at least 1")
+	}
+	aiConcurrency, err := cmd.Flags().GetInt("ai-concurrency")
+	if err != nil {
+		return err
+	}
+	if aiConcurrency < 1 {
+		return errors.New("ai-concurrency must be at least 1")
+	}
+	analysisTimeout, err := cmd.Flags().GetDuration("analysis-timeout")
+	if err != nil {
+		return err
+	}
+	if analysisTimeout < 0 {
+		return errors.New("analysis-timeout must be 0 or greater")
+	}
 	includeRawValue, err := cmd.Flags().GetString("include-raw")
 	if err != nil {
 		return err
@@ -316,8 +429,20 @@ func runReview(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	sensitivity, err := cmd.Flags().GetString("sensitivity")
+	if err != nil {
+		return err
+	}
 
-	format, err := resolveOutputFormat(cmd)
+	format, err := resolveReportOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	pdfPath, err := resolvePDFPath(cmd, format)
+	if err != nil {
+		return err
+	}
+	custom, err := resolveOutputTemplate(cmd, "review")
 	if err != nil {
 		return err
 	}
@@ -331,8 +456,13 @@ func runReview(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ctx := cmd.Context()
+	ctx, runUsage := ailink.WithUsageTracker(cmd.Context())
+	defer func() { printAIUsageSummary(os.Stderr, runUsage.Snapshot()) }()
 	startedAt := time.Now()
+	archive, err := resolveOutputArchive(cmd, "review", startedAt)
+	if err != nil {
+		return err
+	}
 
 	store, err := openStore(ctx)
 	if err != nil {
@@ -344,16 +474,43 @@ func runReview(cmd *cobra.Command, args []string) error {
 	if cfg == nil {
 		return errors.New("config not loaded")
 	}
+	cfg, markets, err := resolveMarkets(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	similar, err := resolveSimilarity(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	words, err := resolveWordScan(cmd, cfg)
+	if err != nil {
+		return err
+	}
 
 	profile, err := resolveProfile(ctx, store, profileName, nil, nil, nil)
 	if err != nil {
 		return err
 	}
+	if err := applySaaSFlag(cmd, &profile); err != nil {
+		return err
+	}
+	applyMarketTLDs(&profile, markets)
+	if strings.TrimSpace(locales) == "" {
+		locales = strings.Join(markets.Locales, ", ")
+	}
+	if strings.TrimSpace(keyboards) == "" {
+		keyboards = strings.Join(markets.Keyboards, ", ")
+	}
 	if len(profile.TLDs) == 0 && len(profile.Registries) == 0 && len(profile.Handles) == 0 {
 		return errors.New("at least one check target is required")
 	}
+	if profile.ExpertDepth != "" && !cmd.Flags().Changed("depth") {
+		depth = profile.ExpertDepth
+	}
 
+	defer startBootstrapRefresh(ctx, cfg, store)()
 	orchestrator := buildOrchestrator(cfg, store, !noCache)
+	defer orchestrator.Wait()
 
 	registry, err := buildPromptRegistry(cfg)
 	if err != nil {
@@ -364,28 +521,130 @@ func runReview(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	consensus, consensusThreshold, err := resolveConsensus(cmd, cfg, depth)
+	if err != nil {
+		return err
+	}
+	if len(consensus) > 0 && !slices.Contains(promptSlugs, suitabilityPromptSlug) {
+		return fmt.Errorf("--consensus applies to the %s analysis, which review mode %q does not run", suitabilityPromptSlug, mode)
+	}
 
 	brandContext, err := reviewBrandContext(contextFile, scanDir, scanBudget)
 	if err != nil {
 		return err
 	}
+	// Oversized context is fitted to each brand prompt's model once, up front
+	brandContexts := make(map[string]string)
+	contextReports := make(map[string]*ailink.ContextReport)
+	for _, slug := range promptSlugs {
+		if isBrandReviewPrompt(slug) {
+			brandContexts[slug], contextReports[slug] = fitReviewContext(ctx, cfg, store, slug, depth, brandContext)
+			printContextReport(os.Stderr, contextReports[slug])
+		}
+	}
+
+	analyzePrompt := func(ctx context.Context, name, slug string) reviewAnalysisOutcome {
+		switch slug {
+		case "name-availability":
+			expertResult, expertError, raw := runReviewSearch(ctx, cfg, store, name, depth, "", slug, !noCache)
 
-	type reviewItem struct {
-		result   *reviewResult
-		batch    *core.BatchResult
-		failed   int
-		analyses map[string]reviewAnalysis
+			a := reviewAnalysis{OK: expertError == nil}
+			if expertError != nil {
+				a.Error = expertError
+			}
+			if expertResult != nil {
+				payload, _ := json.Marshal(expertResult)
+				a.Data = json.RawMessage(payload)
+			}
+			if len(raw) > 0 {
+				if rawMode == includeRawAlways || (rawMode == includeRawOnFail && expertError != nil) {
+					a.Raw = raw
+				}
+			}
+			return reviewAnalysisOutcome{analysis: a, expert: expertResult, expertErr: expertError}
+		case "name-phonetics":
+			vars := reviewPhoneticsVariables(name, locales, keyboards)
+			data, errInfo, raw := runReviewGenerate(ctx, cfg, store, slug, name, depth, "", vars, !noCache)
+			return reviewAnalysisOutcome{analysis: analysisFromGenerate(data, errInfo, raw, rawMode), data: data, dataErr: errInfo}
+		case "name-suitability":
+			vars := reviewSuitabilityVariables(name, locales, sensitivity)
+			if len(consensus) > 0 {
+				data, errInfo := runConsensus(ctx, cfg, consensus, consensusThreshold, func(ctx context.Context, cfg *config.Config, model string) (json.RawMessage, *ailink.SearchError) {
+					data, errInfo, _ := runReviewGenerate(ctx, cfg, store, slug, name, depth, model, vars, !noCache)
+					return data, errInfo
+				})
+				return reviewAnalysisOutcome{analysis: analysisFromGenerate(data, errInfo, nil, rawMode), data: data, dataErr: errInfo}
+			}
+			data, errInfo, raw := runReviewGenerate(ctx, cfg, store, slug, name, depth, "", vars, !noCache)
+			return reviewAnalysisOutcome{analysis: analysisFromGenerate(data, errInfo, raw, rawMode), data: data, dataErr: errInfo}
+		default:
+			vars := reviewAnalysisVariables(slug, name, brandContexts[slug])
+			data, errInfo, raw := runReviewGenerate(ctx, cfg, store, slug, name, depth, "", vars, !noCache)
+			analysis := analysisFromGenerate(data, errInfo, raw, rawMode)
+			analysis.Context = contextReports[slug]
+			return reviewAnalysisOutcome{analysis: analysis, data: data, dataErr: errInfo}
+		}
 	}
 
-	items := make([]reviewItem, 0, len(names))
-	failedTotal := 0
+	// Each name has one availability unit plus one unit per prompt.
+	display := newProgressDisplay(cmd, format, outPath, outDir, len(names)*(1+len(promptSlugs)))
 
-	for _, name := range names {
-		results, err := orchestrator.Check(ctx, name, profile)
-		if err != nil {
-			return err
+	analyze := func(ctx context.Context, name, slug string) reviewAnalysisOutcome {
+		ctx = display.Begin(ctx, name)
+		defer display.Done(name)
+		if analysisTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, analysisTimeout)
+			defer cancel()
+		}
+		ctx, tracker := ailink.WithUsageTracker(ctx)
+
+		started := time.Now()
+		outcome := analyzePrompt(ctx, name, slug)
+		outcome.analysis.DurationMS = time.Since(started).Milliseconds()
+
+		usage := tracker.Snapshot()
+		if usage.Calls > 0 {
+			outcome.analysis.Usage = &usage
+		} else if outcome.analysis.OK {
+			// No provider call and no error means the result came from the cache.
+			outcome.analysis.Cached = true
 		}
+		return outcome
+	}
+
+	// Availability checks and AI analyses are independent, so run them in
+	// separate pools and let network lookups overlap with model calls.
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		checkBatches []*core.BatchResult
+		checkErr     error
+		checksDone   = make(chan struct{})
+	)
+	display.Start()
+	go func() {
+		defer close(checksDone)
+		checkBatches, checkErr = runBatchChecks(runCtx, orchestrator, profile, names, concurrency, display)
+		if checkErr != nil {
+			cancel()
+		}
+	}()
+	outcomes := runReviewAnalyses(runCtx, names, promptSlugs, aiConcurrency, analyze)
+	<-checksDone
+	display.Stop()
+	if checkErr != nil {
+		return checkErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
+	items := make([]reviewItem, 0, len(names))
+	failedTotal := 0
+
+	for i, name := range names {
 		analyses := make(map[string]reviewAnalysis, len(promptSlugs))
 
 		var (
@@ -397,49 +656,35 @@ func runReview(cmd *cobra.Command, args []string) error {
 			suitabilityErr  *ailink.SearchError
 		)
 
-		for _, slug := range promptSlugs {
+		for j, slug := range promptSlugs {
+			outcome := outcomes[i][j]
+			analyses[slug] = outcome.analysis
 			switch slug {
 			case "name-availability":
-				var raw json.RawMessage
-				expertResult, expertError, raw = runReviewSearch(ctx, cfg, store, name, depth, "", slug, !noCache)
-
-				a := reviewAnalysis{OK: expertError == nil}
-				if expertError != nil {
-					a.Error = expertError
-				}
-				if expertResult != nil {
-					payload, _ := json.Marshal(expertResult)
-					a.Data = json.RawMessage(payload)
-				}
-				if len(raw) > 0 {
-					if rawMode == includeRawAlways || (rawMode == includeRawOnFail && expertError != nil) {
-						a.Raw = raw
-					}
-				}
-				analyses[slug] = a
+				expertResult, expertError = outcome.expert, outcome.expertErr
 			case "name-phonetics":
-				vars := reviewPhoneticsVariables(name, locales, keyboards)
-				phoneticsResult, phoneticsError, raw := runReviewGenerate(ctx, cfg, store, slug, name, depth, "", vars, !noCache)
-				analyses[slug] = analysisFromGenerate(phoneticsResult, phoneticsError, raw, rawMode)
+				phoneticsResult, phoneticsError = outcome.data, outcome.dataErr
 			case "name-suitability":
-				vars := map[string]string{"name": name}
-				suitabilityRaw, suitabilityErr, raw := runReviewGenerate(ctx, cfg, store, slug, name, depth, "", vars, !noCache)
-				analyses[slug] = analysisFromGenerate(suitabilityRaw, suitabilityErr, raw, rawMode)
-			default:
-				vars := reviewAnalysisVariables(slug, name, brandContext)
-				data, errInfo, raw := runReviewGenerate(ctx, cfg, store, slug, name, depth, "", vars, !noCache)
-				analyses[slug] = analysisFromGenerate(data, errInfo, raw, rawMode)
+				suitabilityRaw, suitabilityErr = outcome.data, outcome.dataErr
 			}
 		}
 
+		var results []*core.CheckResult
+		if checkBatches[i] != nil {
+			results = checkBatches[i].Results
+		}
 		batch := summarizeResults(name, results, expertResult, expertError, phoneticsResult, phoneticsError, suitabilityRaw, suitabilityErr)
+		applySimilarity([]*core.BatchResult{batch}, similar)
+		applyWordScan([]*core.BatchResult{batch}, words)
 
 		availability := reviewAvailability{
-			Results:     batch.Results,
-			Score:       batch.Score,
-			Total:       batch.Total,
-			Unknown:     batch.Unknown,
-			CompletedAt: batch.CompletedAt,
+			Results:          batch.Results,
+			Score:            batch.Score,
+			Total:            batch.Total,
+			Unknown:          batch.Unknown,
+			SimilarBrands:    batch.SimilarBrands,
+			UnfortunateWords: batch.UnfortunateWords,
+			CompletedAt:      batch.CompletedAt,
 		}
 
 		review := &reviewResult{
@@ -451,6 +696,7 @@ func runReview(cmd *cobra.Command, args []string) error {
 			CompletedAt:  time.Now().UTC(),
 			Availability: availability,
 			Analyses:     analyses,
+			Usage:        summarizeReviewUsage(analyses),
 		}
 
 		failed := analysisFailures(analyses)
@@ -459,11 +705,22 @@ func runReview(cmd *cobra.Command, args []string) error {
 	}
 
 	ext := outputExtension(format)
+	if custom != nil {
+		ext = templateExtension(custom.Template.Name())
+	}
 
 	renderOne := func(w io.Writer, item reviewItem) error {
 		if w == nil || item.result == nil {
 			return nil
 		}
+		if custom != nil {
+			rendered, err := custom.Render([]string{item.result.Name}, []*reviewResult{item.result})
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, rendered)
+			return err
+		}
 
 		switch format {
 		case output.FormatJSON:
@@ -487,7 +744,17 @@ func runReview(cmd *cobra.Command, args []string) error {
 				}
 			}
 			renderReviewExtrasMarkdown(w, item.analyses, []string{"name-availability", "name-phonetics", "name-suitability"})
+			renderReviewUsageMarkdown(w, item.analyses, item.result.Usage)
 			return nil
+		case output.FormatHTML:
+			return renderReviewHTML(w, []reviewItem{item})
+		case output.FormatCSV:
+			rendered, err := output.NewFormatter(output.FormatCSV).FormatBatch(item.batch)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, rendered)
+			return err
 		default:
 			if len(names) > 1 {
 				_, _ = fmt.Fprint(w, ascii.DrawBox(item.result.Name, 0))
@@ -503,11 +770,26 @@ func runReview(cmd *cobra.Command, args []string) error {
 				}
 			}
 			renderReviewExtrasTable(w, item.analyses, []string{"name-availability", "name-phonetics", "name-suitability"})
+			renderReviewUsageTable(w, item.analyses, item.result.Usage)
 			return nil
 		}
 	}
 
 	renderAll := func(w io.Writer) error {
+		if custom != nil {
+			names := make([]string, 0, len(items))
+			results := make([]*reviewResult, 0, len(items))
+			for _, item := range items {
+				names = append(names, item.result.Name)
+				results = append(results, item.result)
+			}
+			rendered, err := custom.Render(names, results)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, rendered)
+			return err
+		}
 		if format == output.FormatJSON {
 			if len(items) == 1 {
 				return renderOne(w, items[0])
@@ -523,6 +805,23 @@ func runReview(cmd *cobra.Command, args []string) error {
 			_, err = fmt.Fprint(w, string(payload))
 			return err
 		}
+		if format == output.FormatHTML {
+			// One page covers every name
+			return renderReviewHTML(w, items)
+		}
+		if format == output.FormatCSV {
+			// One table with a single header row
+			batches := make([]*core.BatchResult, 0, len(items))
+			for _, item := range items {
+				batches = append(batches, item.batch)
+			}
+			rendered, err := output.FormatBatchList(format, batches)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(w, rendered)
+			return err
+		}
 
 		first := true
 		for _, item := range items {
@@ -537,14 +836,13 @@ func runReview(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if outDir != "" {
+	if outDir != "" || archive != nil {
 		outDir, err := ensureOutDir(outDir)
 		if err != nil {
 			return err
 		}
 
-		indexPath := filepath.Join(outDir, fmt.Sprintf("review.index.%s", ext))
-		indexSink, err := openSink(indexPath)
+		indexSink, err := openRunSink(archive, outDir, fmt.Sprintf("review.index.%s", ext), archiveKindIndex)
 		if err != nil {
 			return err
 		}
@@ -558,8 +856,7 @@ func runReview(cmd *cobra.Command, args []string) error {
 
 		for _, item := range items {
 			fileName := sanitizeFilename(item.result.Name)
-			path := filepath.Join(outDir, fmt.Sprintf("%s.review.%s", fileName, ext))
-			sink, err := openSink(path)
+			sink, err := openRunSink(archive, outDir, fmt.Sprintf("%s.review.%s", fileName, ext), archiveKindOutput)
 			if err != nil {
 				return err
 			}
@@ -585,12 +882,103 @@ func runReview(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if pdfPath != "" {
+		if err := writeReportPDF(ctx, cfg, pdfPath, renderAll); err != nil {
+			return err
+		}
+	}
+
+	batches := make([]*core.BatchResult, 0, len(items))
+	for _, item := range items {
+		batches = append(batches, item.batch)
+	}
+
+	if archive != nil {
+		for _, item := range items {
+			if err := archive.addEvidence(item.result.Name, item.result); err != nil {
+				return err
+			}
+		}
+		if err := archive.write(archiveProvenance{
+			OutputFormat: string(format),
+			Profile:      profileName,
+			Names:        names,
+			Cache:        !noCache,
+			Offline:      isOffline(cfg),
+			Requests:     runRequestCounts(batches, nil),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := evaluateFailIf(failIf, batches); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+
 	if strict && failedTotal > 0 {
 		return fmt.Errorf("review failed (%d analyses)", failedTotal)
 	}
 	return nil
 }
 
+// reviewAnalysisOutcome is the result of one prompt for one name.
+type reviewAnalysisOutcome struct {
+	analysis  reviewAnalysis
+	expert    *ailink.SearchResponse
+	expertErr *ailink.SearchError
+	data      json.RawMessage
+	dataErr   *ailink.SearchError
+}
+
+type reviewAnalysisJob struct {
+	nameIndex int
+	slugIndex int
+}
+
+// runReviewAnalyses runs every name/prompt pair across a pool of AI workers.
+// Outcomes are indexed by name, then prompt. Jobs not started before ctx is
+// cancelled are left as zero values.
+func runReviewAnalyses(ctx context.Context, names, slugs []string, concurrency int, analyze func(context.Context, string, string) reviewAnalysisOutcome) [][]reviewAnalysisOutcome {
+	outcomes := make([][]reviewAnalysisOutcome, len(names))
+	for i := range outcomes {
+		outcomes[i] = make([]reviewAnalysisOutcome, len(slugs))
+	}
+
+	total := len(names) * len(slugs)
+	if total == 0 {
+		return outcomes
+	}
+	if concurrency > total {
+		concurrency = total
+	}
+
+	jobs := make(chan reviewAnalysisJob)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				outcomes[job.nameIndex][job.slugIndex] = analyze(ctx, names[job.nameIndex], slugs[job.slugIndex])
+			}
+		}()
+	}
+
+	for i := range names {
+		for j := range slugs {
+			jobs <- reviewAnalysisJob{nameIndex: i, slugIndex: j}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return outcomes
+}
+
 func parseIncludeRaw(value string) (includeRawMode, error) {
 	normalized := strings.ToLower(strings.TrimSpace(value))
 	switch normalized {
@@ -772,6 +1160,160 @@ func renderReviewExtrasMarkdown(w io.Writer, analyses map[string]reviewAnalysis,
 	}
 }
 
+// reviewItem is one reviewed name with everything its renderers need.
+type reviewItem struct {
+	result   *reviewResult
+	batch    *core.BatchResult
+	failed   int
+	analyses map[string]reviewAnalysis
+}
+
+// renderReviewHTML writes review items as one HTML report, adding each name's
+// other analyses and AI usage to its section.
+func renderReviewHTML(w io.Writer, items []reviewItem) error {
+	report := output.HTMLReport{Extras: map[string][]output.HTMLTable{}}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.result == nil || item.batch == nil {
+			continue
+		}
+		names = append(names, item.result.Name)
+		report.Batches = append(report.Batches, item.batch)
+		report.Extras[item.batch.Name] = reviewHTMLTables(item)
+	}
+	report.Title = "Name review: " + strings.Join(names, ", ")
+	return report.Render(w)
+}
+
+func reviewHTMLTables(item reviewItem) []output.HTMLTable {
+	base := map[string]bool{"name-availability": true, "name-phonetics": true, "name-suitability": true}
+	var tables []output.HTMLTable
+
+	extra := output.HTMLTable{Title: "Additional analyses", Header: []string{"Analysis", "Status", "Summary"}}
+	for _, slug := range sortedAnalysisSlugs(item.analyses) {
+		if base[slug] {
+			continue
+		}
+		a := item.analyses[slug]
+		status := "ok"
+		if !a.OK {
+			status = "error"
+		}
+		extra.Rows = append(extra.Rows, []string{slug, status, extractSummary(a.Data)})
+	}
+	if len(extra.Rows) > 0 {
+		tables = append(tables, extra)
+	}
+
+	if len(item.analyses) > 0 {
+		usage := output.HTMLTable{
+			Title:  "AI usage",
+			Header: []string{"Analysis", "Duration", "Tokens", "Est. cost"},
+			Note:   reviewUsageFooter(item.result.Usage),
+		}
+		for _, slug := range sortedAnalysisSlugs(item.analyses) {
+			usage.Rows = append(usage.Rows, append([]string{slug}, analysisUsageCells(item.analyses[slug])...))
+		}
+		tables = append(tables, usage)
+	}
+	return tables
+}
+
+func summarizeReviewUsage(analyses map[string]reviewAnalysis) reviewUsage {
+	summary := reviewUsage{Analyses: len(analyses)}
+	var total ailink.Usage
+	for _, a := range analyses {
+		summary.DurationMS += a.DurationMS
+		if a.Cached {
+			summary.Cached++
+		}
+		if a.Usage != nil {
+			total.Add(*a.Usage)
+		}
+	}
+	summary.PromptTokens = total.PromptTokens
+	summary.CompletionTokens = total.CompletionTokens
+	summary.TotalTokens = total.TotalTokens
+	summary.EstimatedCostUSD = total.EstimatedCostUSD
+	return summary
+}
+
+func renderReviewUsageTable(w io.Writer, analyses map[string]reviewAnalysis, usage reviewUsage) {
+	if w == nil || len(analyses) == 0 {
+		return
+	}
+	lines := []string{"AI usage", ""}
+	for _, slug := range sortedAnalysisSlugs(analyses) {
+		a := analyses[slug]
+		lines = append(lines, fmt.Sprintf("%s: %s", slug, strings.Join(analysisUsageCells(a), ", ")))
+	}
+	lines = append(lines, "", reviewUsageFooter(usage))
+	_, _ = fmt.Fprint(w, ascii.DrawBox(strings.Join(lines, "\n"), 0))
+}
+
+func renderReviewUsageMarkdown(w io.Writer, analyses map[string]reviewAnalysis, usage reviewUsage) {
+	if w == nil || len(analyses) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "\n## AI usage")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "| Analysis | Duration | Tokens | Est. cost |")
+	_, _ = fmt.Fprintln(w, "|----------|----------|--------|-----------|")
+	for _, slug := range sortedAnalysisSlugs(analyses) {
+		cells := analysisUsageCells(analyses[slug])
+		for len(cells) < 3 {
+			cells = append(cells, "-")
+		}
+		_, _ = fmt.Fprintf(w, "| `%s` | %s | %s | %s |\n", slug, cells[0], cells[1], cells[2])
+	}
+	_, _ = fmt.Fprintf(w, "\n%s\n", reviewUsageFooter(usage))
+}
+
+func sortedAnalysisSlugs(analyses map[string]reviewAnalysis) []string {
+	slugs := make([]string, 0, len(analyses))
+	for slug := range analyses {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// analysisUsageCells returns duration, tokens, and cost for one analysis.
+func analysisUsageCells(a reviewAnalysis) []string {
+	duration := formatAnalysisDuration(a.DurationMS)
+	switch {
+	case a.Cached:
+		return []string{duration, "cached", "-"}
+	case a.Usage == nil:
+		return []string{duration, "-", "-"}
+	default:
+		return []string{duration, fmt.Sprintf("%d tokens", a.Usage.TotalTokens), formatCostUSD(a.Usage.EstimatedCostUSD)}
+	}
+}
+
+func reviewUsageFooter(usage reviewUsage) string {
+	analyses := fmt.Sprintf("%d analyses", usage.Analyses)
+	if usage.Analyses == 1 {
+		analyses = "1 analysis"
+	}
+	if usage.Cached > 0 {
+		analyses += fmt.Sprintf(" (%d cached)", usage.Cached)
+	}
+	return fmt.Sprintf("Total: %s, %s, %d tokens, est. cost %s",
+		analyses, formatAnalysisDuration(usage.DurationMS), usage.TotalTokens, formatCostUSD(usage.EstimatedCostUSD))
+}
+
+func formatAnalysisDuration(ms int64) string {
+	return fmt.Sprintf("%.1fs", float64(ms)/1000)
+}
+
+func formatCostUSD(cost *float64) string {
+	if cost == nil {
+		return "-"
+	}
+	return fmt.Sprintf("$%.4f", *cost)
+}
+
 func extractSummary(payload json.RawMessage) string {
 	if len(payload) == 0 {
 		return ""
@@ -800,6 +1342,20 @@ func reviewPhoneticsVariables(name, locales, keyboards string) map[string]string
 	return vars
 }
 
+func reviewSuitabilityVariables(name, locales, sensitivity string) map[string]string {
+	vars := map[string]string{"name": name}
+
+	if trimmed := strings.TrimSpace(locales); trimmed != "" {
+		vars["locales"] = trimmed
+	}
+
+	if trimmed := strings.TrimSpace(sensitivity); trimmed != "" {
+		vars["sensitivity_level"] = trimmed
+	}
+
+	return vars
+}
+
 func reviewAnalysisVariables(slug, name, brandContext string) map[string]string {
 	vars := map[string]string{"name": name}
 	if isBrandReviewPrompt(slug) && strings.TrimSpace(brandContext) != "" {
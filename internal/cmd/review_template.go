@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/reviewtemplate"
+)
+
+func loadReviewTemplates() ([]*reviewtemplate.Template, error) {
+	dir := ""
+	if cfg := config.GetConfig(); cfg != nil {
+		dir = strings.TrimSpace(cfg.Review.TemplatesDir)
+	}
+	if dir == "" {
+		dir = config.DefaultReviewTemplatesDir()
+	}
+	return reviewtemplate.LoadAll(dir)
+}
+
+// applyReviewTemplate sets review flags from the named template. Flags the user
+// set explicitly are left alone.
+func applyReviewTemplate(cmd *cobra.Command, name string) error {
+	templates, err := loadReviewTemplates()
+	if err != nil {
+		return err
+	}
+	tmpl, err := reviewtemplate.Find(templates, name)
+	if err != nil {
+		return err
+	}
+	return setReviewTemplateFlags(cmd, tmpl)
+}
+
+func setReviewTemplateFlags(cmd *cobra.Command, tmpl *reviewtemplate.Template) error {
+	values := []struct {
+		flag  string
+		value string
+	}{
+		{"mode", tmpl.Mode},
+		{"profile", tmpl.Profile},
+		{"depth", tmpl.Depth},
+		{"sensitivity", tmpl.Sensitivity},
+		{"locales", strings.Join(tmpl.Locales, ",")},
+		{"output-format", tmpl.OutputFormat},
+	}
+	for _, v := range values {
+		if v.value == "" || cmd.Flags().Changed(v.flag) {
+			continue
+		}
+		if err := cmd.Flags().Set(v.flag, v.value); err != nil {
+			return fmt.Errorf("apply template %s: %w", tmpl.Name, err)
+		}
+	}
+	return nil
+}
+
+func runListReviewTemplates(cmd *cobra.Command) error {
+	templates, err := loadReviewTemplates()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	for _, tmpl := range templates {
+		_, _ = fmt.Fprintf(out, "%s\n", tmpl.Name)
+		if tmpl.Description != "" {
+			_, _ = fmt.Fprintf(out, "  %s\n", tmpl.Description)
+		}
+		_, _ = fmt.Fprintf(out, "  mode=%s profile=%s depth=%s sensitivity=%s locales=%s output=%s\n",
+			templateValue(tmpl.Mode), templateValue(tmpl.Profile), templateValue(tmpl.Depth),
+			templateValue(tmpl.Sensitivity), templateValue(strings.Join(tmpl.Locales, ",")), templateValue(tmpl.OutputFormat))
+		_, _ = fmt.Fprintf(out, "  source: %s\n", tmpl.Source)
+	}
+	return nil
+}
+
+func templateValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
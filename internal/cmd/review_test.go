@@ -1,17 +1,22 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
 	"github.com/namelens/namelens/internal/ailink"
 	"github.com/namelens/namelens/internal/ailink/prompt"
+	"github.com/namelens/namelens/internal/reviewtemplate"
 )
 
 type stubPromptRegistry struct {
@@ -288,3 +293,96 @@ func TestReviewBrandContextFromScanDir(t *testing.T) {
 	require.NoError(t, err)
 	require.Contains(t, context, "NameLens identity proxy context")
 }
+
+func TestRunReviewAnalysesBoundsConcurrencyAndKeepsOrder(t *testing.T) {
+	names := []string{"alpha", "bravo", "charlie", "delta"}
+	slugs := []string{"name-availability", "name-phonetics", "name-suitability"}
+
+	var inFlight, peak int32
+	outcomes := runReviewAnalyses(context.Background(), names, slugs, 2, func(_ context.Context, name, slug string) reviewAnalysisOutcome {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&peak)
+			if current <= seen || atomic.CompareAndSwapInt32(&peak, seen, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return reviewAnalysisOutcome{data: json.RawMessage(fmt.Sprintf("%q", name+"/"+slug))}
+	})
+
+	require.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+	require.Len(t, outcomes, len(names))
+	for i, name := range names {
+		require.Len(t, outcomes[i], len(slugs))
+		for j, slug := range slugs {
+			require.JSONEq(t, fmt.Sprintf("%q", name+"/"+slug), string(outcomes[i][j].data))
+		}
+	}
+}
+
+func TestRunReviewAnalysesStopsAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	runReviewAnalyses(ctx, []string{"a", "b", "c"}, []string{"x", "y"}, 1, func(context.Context, string, string) reviewAnalysisOutcome {
+		atomic.AddInt32(&calls, 1)
+		cancel()
+		return reviewAnalysisOutcome{}
+	})
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestSummarizeReviewUsage(t *testing.T) {
+	cost := 0.0125
+	analyses := map[string]reviewAnalysis{
+		"name-availability": {OK: true, DurationMS: 12400, Usage: &ailink.Usage{Calls: 1, TotalTokens: 3200, EstimatedCostUSD: &cost}},
+		"name-phonetics":    {OK: true, DurationMS: 15, Cached: true},
+		"name-suitability":  {OK: false, DurationMS: 8000, Usage: &ailink.Usage{Calls: 1, TotalTokens: 800}},
+	}
+
+	usage := summarizeReviewUsage(analyses)
+	require.Equal(t, 3, usage.Analyses)
+	require.Equal(t, 1, usage.Cached)
+	require.Equal(t, int64(20415), usage.DurationMS)
+	require.Equal(t, 4000, usage.TotalTokens)
+	require.NotNil(t, usage.EstimatedCostUSD)
+	require.InDelta(t, cost, *usage.EstimatedCostUSD, 1e-9)
+
+	require.Equal(t, "Total: 3 analyses (1 cached), 20.4s, 4000 tokens, est. cost $0.0125", reviewUsageFooter(usage))
+	require.Equal(t, []string{"0.0s", "cached", "-"}, analysisUsageCells(analyses["name-phonetics"]))
+	require.Equal(t, []string{"8.0s", "800 tokens", "-"}, analysisUsageCells(analyses["name-suitability"]))
+}
+
+func TestSetReviewTemplateFlagsKeepsExplicitFlags(t *testing.T) {
+	cmd := &cobra.Command{Use: "review"}
+	cmd.Flags().String("mode", "core", "")
+	cmd.Flags().String("profile", "startup", "")
+	cmd.Flags().String("depth", "quick", "")
+	cmd.Flags().String("sensitivity", "", "")
+	cmd.Flags().String("locales", "", "")
+	cmd.Flags().String("output-format", "table", "")
+	require.NoError(t, cmd.Flags().Set("profile", "developer"))
+
+	tmpl := &reviewtemplate.Template{
+		Name:         "startup-launch",
+		Mode:         "brand",
+		Profile:      "startup",
+		Sensitivity:  "strict",
+		Locales:      []string{"en-US", "de-DE"},
+		OutputFormat: "markdown",
+	}
+	require.NoError(t, setReviewTemplateFlags(cmd, tmpl))
+
+	get := func(name string) string {
+		value, err := cmd.Flags().GetString(name)
+		require.NoError(t, err)
+		return value
+	}
+	require.Equal(t, "brand", get("mode"))
+	require.Equal(t, "developer", get("profile"), "explicit flags win over the template")
+	require.Equal(t, "quick", get("depth"), "empty template fields keep defaults")
+	require.Equal(t, "strict", get("sensitivity"))
+	require.Equal(t, "en-US,de-DE", get("locales"))
+	require.Equal(t, "markdown", get("output-format"))
+}
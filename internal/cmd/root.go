@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/fulmenhq/gofulmen/appidentity"
 	gfconfig "github.com/fulmenhq/gofulmen/config"
@@ -13,12 +14,14 @@ import (
 
 	"github.com/namelens/namelens/internal/ailink/driver"
 	"github.com/namelens/namelens/internal/appid"
+	"github.com/namelens/namelens/internal/audit"
 	"github.com/namelens/namelens/internal/config"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
 	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/redact"
 )
 
 var (
@@ -64,10 +67,15 @@ Use the subcommands to perform specific operations.`,
 func Execute() error {
 	// Ensure tracing is properly closed when command completes
 	defer driver.DisableTracing()
+	defer audit.Disable()
+	defer flushTracing()
 	defer func() {
 		_ = config.CleanupStandaloneAssets()
 	}()
-	return rootCmd.Execute()
+	startedAt := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	recordUsage(cmd, startedAt, err)
+	return err
 }
 
 func init() {
@@ -92,14 +100,20 @@ func init() {
 	}
 
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPreRun = startObservability
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (optional; defaults to app identity config path)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output (sets log level to debug)")
 	rootCmd.PersistentFlags().StringVar(&traceFile, "trace", "", "trace AILink requests/responses to NDJSON file")
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", false, "answer checks from cache only and never make network requests")
+	addChaosFlags(rootCmd)
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
+
+	// Command errors can echo provider messages; scrub them like other output.
+	rootCmd.SetErr(redact.Writer(os.Stderr))
 }
 
 // initConfig reads in config file and ENV variables if set.
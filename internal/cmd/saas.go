@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+)
+
+func addSaaSFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("saas", nil, "Also check SaaS identities: slack, discord, google-workspace, or all")
+}
+
+// parseSaaSServices expands and validates --saas values, keeping the order
+// of checker.SaaSServices.
+func parseSaaSServices(values []string) ([]string, error) {
+	selected := map[string]bool{}
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			service := strings.ToLower(strings.TrimSpace(part))
+			switch {
+			case service == "":
+				continue
+			case service == "all":
+				for _, known := range checker.SaaSServices {
+					selected[known] = true
+				}
+			default:
+				if _, err := checker.NewSaaSChecker(service); err != nil {
+					return nil, fmt.Errorf("invalid --saas: %w", err)
+				}
+				selected[service] = true
+			}
+		}
+	}
+
+	services := make([]string, 0, len(selected))
+	for _, known := range checker.SaaSServices {
+		if selected[known] {
+			services = append(services, known)
+		}
+	}
+	return services, nil
+}
+
+// applySaaSFlag adds the --saas services to the profile's handles.
+func applySaaSFlag(cmd *cobra.Command, profile *core.Profile) error {
+	values, err := cmd.Flags().GetStringSlice("saas")
+	if err != nil {
+		return err
+	}
+	services, err := parseSaaSServices(values)
+	if err != nil {
+		return err
+	}
+	if len(services) > 0 {
+		profile.Handles = normalizeList(append(append([]string{}, profile.Handles...), services...))
+	}
+	return nil
+}
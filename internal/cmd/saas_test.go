@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSaaSServices(t *testing.T) {
+	services, err := parseSaaSServices([]string{"Discord", "slack,discord"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"slack", "discord"}, services)
+
+	services, err = parseSaaSServices([]string{"all"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"slack", "discord", "google-workspace"}, services)
+
+	services, err = parseSaaSServices(nil)
+	require.NoError(t, err)
+	require.Empty(t, services)
+
+	_, err = parseSaaSServices([]string{"teams"})
+	require.Error(t, err)
+}
@@ -3,9 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/namelens/namelens/internal/api"
 	"github.com/namelens/namelens/internal/config"
@@ -22,16 +25,20 @@ import (
 	"github.com/namelens/namelens/internal/observability"
 	"github.com/namelens/namelens/internal/server"
 	"github.com/namelens/namelens/internal/server/handlers"
+	servermw "github.com/namelens/namelens/internal/server/middleware"
 )
 
 var (
-	serverPort  int
-	serverHost  string
-	serverBind  string
-	generateKey bool
-	apiKeyFlag  string
-	daemonMode  bool
-	envFile     string
+	serverPort   int
+	grpcPort     int
+	serverHost   string
+	serverBind   string
+	generateKey  bool
+	apiKeyFlag   string
+	daemonMode   bool
+	envFile      string
+	readOnlyFlag bool
+	uiFlag       bool
 )
 
 // signalHealthChecker implements HealthChecker for signal system
@@ -80,12 +87,27 @@ var serveCmd = &cobra.Command{
 The server exposes:
   • Control Plane API at /v1/* for name availability checking
   • Health endpoints at /health, /health/live, /health/ready
+    (with health.require_warmup, /health/ready fails until RDAP bootstrap
+    data is present and a canary check of health.warmup_canary succeeds)
   • Metrics at /metrics (Prometheus format)
+  • gRPC API (namelens.v1.NamelensService) when --grpc-port is set
+  • Web dashboard at /ui when --ui is set
 
 Signal Handling:
   • Ctrl+C (SIGINT) or SIGTERM: Graceful shutdown
   • Ctrl+C twice within 2s: Force quit
   • SIGHUP: Config reload (placeholder - restart recommended)
+  Run 'namelens doctor serve' to check settings for containers and Kubernetes.
+
+Read-Only Mode:
+  --read-only (or server.read_only) serves only cached and stored data for
+  public demos: checks and AI analyses never reach the network, RDAP
+  bootstrap data is not refreshed, and POST /v1/share returns 403.
+
+Web Dashboard:
+  --ui serves a single-page dashboard at /ui for submitting names, picking
+  profiles, watching results arrive, and downloading JSON or Markdown
+  reports. It calls the API on the same server, with the same keys and roles.
 
 Environment Files:
   The server automatically loads .env files in this order:
@@ -96,7 +118,12 @@ Environment Files:
 Authentication:
   API key required for non-localhost requests when configured.
   Generate a key with: namelens serve --generate-key
-  Set via: NAMELENS_CONTROL_PLANE_API_KEY environment variable`,
+  Set via: NAMELENS_CONTROL_PLANE_API_KEY environment variable (admin role)
+
+  Role-scoped keys are configured under server.api_keys:
+    read-only  status and profiles
+    check      also live availability checks
+    admin      also AI analyses and generation (gRPC Review/Generate)`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Handle --generate-key flag
 		if generateKey {
@@ -141,12 +168,18 @@ Authentication:
 
 			// Build args for daemon (exclude --daemon flag)
 			daemonArgs := []string{"serve", "--host", serverHost, "--port", fmt.Sprintf("%d", serverPort)}
+			if grpcPort > 0 {
+				daemonArgs = append(daemonArgs, "--grpc-port", fmt.Sprintf("%d", grpcPort))
+			}
 			if apiKeyFlag != "" {
 				daemonArgs = append(daemonArgs, "--api-key", apiKeyFlag)
 			}
 			if envFile != "" {
 				daemonArgs = append(daemonArgs, "--env-file", envFile)
 			}
+			if uiFlag {
+				daemonArgs = append(daemonArgs, "--ui")
+			}
 
 			pid, err := daemon.StartDaemon(executable, daemonArgs, serverPort)
 			if err != nil {
@@ -226,15 +259,95 @@ Authentication:
 		// Get config for orchestrator
 		cfg := config.GetConfig()
 
+		// Keep hot cache entries in memory so bursts of lookups skip SQL
+		dataStore.EnableMemoryCache(cfg.Cache.Memory.Size, cfg.Cache.Memory.TTL)
+
+		// Read-only mode answers everything from the cache, as --offline does
+		readOnly := readOnlyFlag || cfg.Server.ReadOnly
+		if readOnly {
+			offlineMode = true
+			observability.ServerLogger.Info("Read-only mode: serving cached data only; live lookups, AI calls, and share creation are disabled")
+		}
+
 		// Build orchestrator for control plane API
 		orchestrator := buildOrchestrator(cfg, dataStore, true)
+		defer orchestrator.Wait()
+
+		// Keep RDAP bootstrap data fresh for the lifetime of the server
+		refreshCtx, stopRefresh := context.WithCancel(cmd.Context())
+		defer stopRefresh()
+		go runBootstrapRefresher(refreshCtx, cfg, dataStore, time.Hour)
+		go runStorePoolMetrics(refreshCtx, dataStore, 15*time.Second)
+
+		// Optionally hold readiness until lookups can actually be routed
+		if cfg.Health.RequireWarmup {
+			gate, err := newWarmupGate(cfg, dataStore)
+			if err != nil {
+				return errwrap.WrapConfigInvalid(cmd.Context(), err, "invalid warm-up configuration")
+			}
+			hm.RegisterReadinessChecker("warmup", gate)
+			go gate.run(refreshCtx, cfg.Health.WarmupRetryInterval)
+		}
 
 		// Create server with control plane API configuration
+		scopedKeys, err := controlPlaneKeys(cfg.Server.APIKeys)
+		if err != nil {
+			return errwrap.WrapConfigInvalid(cmd.Context(), err, "invalid server.api_keys")
+		}
+		keyStore, err := newStoreKeyLookup(cmd.Context(), dataStore)
+		if err != nil {
+			return errwrap.WrapDatabaseError(cmd.Context(), err, "failed to read stored API keys")
+		}
 		apiConfig := api.AuthConfig{
 			APIKey:         controlPlaneAPIKey,
+			Keys:           scopedKeys,
+			Store:          keyStore,
+			Limits:         &api.KeyLimiter{Usage: dataStore},
 			AllowLocalhost: true,
 		}
 		srv := server.NewWithAPI(serverHost, serverPort, versionInfo.Version, apiConfig, orchestrator)
+		srv.SetReadOnly(readOnly)
+		srv.SetMaxBodyBytes(cfg.Server.MaxBodyBytes)
+		if uiFlag {
+			srv.EnableUI()
+			observability.ServerLogger.Info("Web dashboard enabled",
+				zap.String("url", fmt.Sprintf("http://%s/ui/", net.JoinHostPort(serverHost, strconv.Itoa(serverPort)))))
+		}
+		srv.SetCORS(servermw.CORSConfig{
+			AllowedOrigins: cfg.Server.CORS.AllowedOrigins,
+			AllowedMethods: cfg.Server.CORS.AllowedMethods,
+			AllowedHeaders: cfg.Server.CORS.AllowedHeaders,
+			MaxAge:         cfg.Server.CORS.MaxAge,
+		})
+		srv.SetUsage(func(ctx context.Context, subject string) ([]api.QuotaUsage, error) {
+			return aiQuotaUsage(ctx, cfg, dataStore, subject, time.Now())
+		})
+		srv.SetCalendar(func(ctx context.Context) ([]byte, error) {
+			return portfolioCalendar(ctx, dataStore, orchestrator, time.Now(), defaultCalendarDays, defaultCalendarReminderDays*24*time.Hour)
+		})
+		sharing, ephemeralSecret, err := newSharing(cfg.Server.Share, dataStore)
+		if err != nil {
+			return errwrap.WrapConfigInvalid(cmd.Context(), err, "invalid server.share configuration")
+		}
+		if sharing != nil {
+			srv.SetSharing(sharing)
+			if _, err := dataStore.PurgeExpiredShares(cmd.Context(), time.Now()); err != nil {
+				observability.ServerLogger.Warn("Failed to purge expired share links", zap.Error(err))
+			}
+			if ephemeralSecret {
+				observability.ServerLogger.Warn("Share links use a random secret and stop working on restart; set server.share.secret to keep them")
+			}
+		}
+
+		// Optional gRPC API on its own port, sharing the orchestrator and auth
+		var grpcServer *grpc.Server
+		var grpcListener net.Listener
+		if grpcPort > 0 {
+			grpcServer, grpcListener, err = newGRPCServer(cfg, dataStore, orchestrator, apiConfig, serverHost, grpcPort)
+			if err != nil {
+				return errwrap.WrapInternal(cmd.Context(), err, "failed to start gRPC server")
+			}
+		}
 
 		// Set app identity for handlers
 		handlers.SetAppIdentity(identity)
@@ -257,7 +370,32 @@ Authentication:
 			return nil
 		})
 
-		// Handler 2: Shutdown HTTP server (executed first)
+		// Handler 2: Export buffered trace spans
+		signals.OnShutdown(func(ctx context.Context) error {
+			flushTracing()
+			return nil
+		})
+
+		// Handler 3: Stop gRPC server, letting in-flight calls finish
+		if grpcServer != nil {
+			signals.OnShutdown(func(ctx context.Context) error {
+				observability.ServerLogger.Info("Shutting down gRPC server...")
+				stopped := make(chan struct{})
+				go func() {
+					grpcServer.GracefulStop()
+					close(stopped)
+				}()
+				select {
+				case <-stopped:
+				case <-time.After(shutdownTimeout):
+					grpcServer.Stop()
+				}
+				observability.ServerLogger.Info("gRPC server stopped")
+				return nil
+			})
+		}
+
+		// Handler 4: Shutdown HTTP server (executed first)
 		signals.OnShutdown(func(ctx context.Context) error {
 			observability.ServerLogger.Info("Shutting down HTTP server...")
 			shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
@@ -318,6 +456,17 @@ Authentication:
 			}
 		}()
 
+		if grpcServer != nil {
+			go func() {
+				observability.ServerLogger.Info("Starting gRPC server...",
+					zap.String("host", serverHost),
+					zap.Int("port", grpcPort))
+				if err := grpcServer.Serve(grpcListener); err != nil && err != grpc.ErrServerStopped {
+					errChan <- err
+				}
+			}()
+		}
+
 		// Start signal listener in background
 		go func() {
 			if err := signals.Listen(cmd.Context()); err != nil {
@@ -372,16 +521,50 @@ func loadEnvFiles(envFileFlag string) []string {
 	return loaded
 }
 
+// controlPlaneKeys resolves server.api_keys into API keys and their name and
+// role. Unnamed keys are named by position ("key-1").
+func controlPlaneKeys(entries []config.APIKeyConfig) (map[string]api.Principal, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	keys := make(map[string]api.Principal, len(entries))
+	for i, entry := range entries {
+		label := strings.TrimSpace(entry.Name)
+		if label == "" {
+			label = fmt.Sprintf("key-%d", i+1)
+		}
+		key := strings.TrimSpace(os.ExpandEnv(entry.Key))
+		if key == "" {
+			return nil, fmt.Errorf("api key %s: key is empty", label)
+		}
+		role, err := api.ParseRole(entry.Role)
+		if err != nil {
+			return nil, fmt.Errorf("api key %s: %w", label, err)
+		}
+		if _, dup := keys[key]; dup {
+			return nil, fmt.Errorf("api key %s: key is configured more than once", label)
+		}
+		if entry.RateLimit < 0 || entry.DailyQuota < 0 {
+			return nil, fmt.Errorf("api key %s: rate_limit and daily_quota must not be negative", label)
+		}
+		keys[key] = api.Principal{Name: label, Role: role, RateLimit: entry.RateLimit, DailyQuota: entry.DailyQuota}
+	}
+	return keys, nil
+}
+
 func init() {
 	rootCmd.AddCommand(serveCmd)
 
 	serveCmd.Flags().StringVar(&serverHost, "host", "localhost", "server host")
 	serveCmd.Flags().IntVarP(&serverPort, "port", "p", 8080, "server port")
+	serveCmd.Flags().IntVar(&grpcPort, "grpc-port", 0, "gRPC API port (0 disables the gRPC API)")
 	serveCmd.Flags().StringVar(&serverBind, "bind", "", "bind address (host:port, overrides --host and --port)")
 	serveCmd.Flags().BoolVar(&generateKey, "generate-key", false, "generate a new API key and exit")
 	serveCmd.Flags().StringVar(&apiKeyFlag, "api-key", "", "API key for control plane authentication")
 	serveCmd.Flags().BoolVarP(&daemonMode, "daemon", "d", false, "run server in background (daemon mode)")
 	serveCmd.Flags().StringVarP(&envFile, "env-file", "e", "", "load environment variables from file")
+	serveCmd.Flags().BoolVar(&readOnlyFlag, "read-only", false, "serve cached data only and reject mutating endpoints")
+	serveCmd.Flags().BoolVar(&uiFlag, "ui", false, "serve the web dashboard at /ui")
 
 	_ = viper.BindPFlag("server.host", serveCmd.Flags().Lookup("host"))
 	_ = viper.BindPFlag("server.port", serveCmd.Flags().Lookup("port"))
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	namelensv1 "github.com/namelens/namelens/api/namelens/v1"
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/api"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core/engine"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/grpcapi"
+)
+
+// newGRPCServer builds the namelens.v1 gRPC server and its listener. It shares
+// the HTTP API's orchestrator and API key.
+func newGRPCServer(cfg *config.Config, store *corestore.Store, orchestrator *engine.Orchestrator, auth api.AuthConfig, host string, port int) (*grpc.Server, net.Listener, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("listen for gRPC on %s:%d: %w", host, port, err)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(grpcapi.UnaryAuthInterceptor(auth)))
+	namelensv1.RegisterNamelensServiceServer(server, grpcapi.NewServer(orchestrator, grpcAnalyze(cfg, store), grpcGenerate(cfg, store)))
+	reflection.Register(server)
+	return server, listener, nil
+}
+
+// grpcAnalyze runs review prompts the same way 'namelens review' does, using
+// the expert cache.
+func grpcAnalyze(cfg *config.Config, store *corestore.Store) grpcapi.AnalyzeFunc {
+	return func(ctx context.Context, prompt, name, depth, model string) (json.RawMessage, error) {
+		ctx = withCallerQuotaSubject(ctx)
+		var (
+			data    json.RawMessage
+			errInfo *ailink.SearchError
+		)
+		switch prompt {
+		case "name-availability":
+			var response *ailink.SearchResponse
+			response, errInfo, _ = runReviewSearch(ctx, cfg, store, name, depth, model, prompt, true)
+			if response != nil {
+				payload, err := json.Marshal(response)
+				if err != nil {
+					return nil, err
+				}
+				data = payload
+			}
+		case "name-phonetics":
+			data, errInfo, _ = runReviewGenerate(ctx, cfg, store, prompt, name, depth, model, reviewPhoneticsVariables(name, "", ""), true)
+		case "name-suitability":
+			data, errInfo, _ = runReviewGenerate(ctx, cfg, store, prompt, name, depth, model, reviewSuitabilityVariables(name, "", ""), true)
+		default:
+			data, errInfo, _ = runReviewGenerate(ctx, cfg, store, prompt, name, depth, model, reviewAnalysisVariables(prompt, name, ""), true)
+		}
+		if errInfo != nil {
+			return nil, &grpcapi.AnalysisError{Code: errInfo.Code, Message: errInfo.Message}
+		}
+		return data, nil
+	}
+}
+
+// grpcGenerate runs a generation prompt without caching, like 'namelens generate'.
+func grpcGenerate(cfg *config.Config, store *corestore.Store) grpcapi.GenerateFunc {
+	return func(ctx context.Context, prompt, depth, model string, variables map[string]string) (json.RawMessage, error) {
+		data, errInfo, _ := runReviewGenerate(withCallerQuotaSubject(ctx), cfg, store, prompt, "", depth, model, variables, false)
+		if errInfo != nil {
+			return nil, &grpcapi.AnalysisError{Code: errInfo.Code, Message: errInfo.Message}
+		}
+		return data, nil
+	}
+}
+
+// withCallerQuotaSubject counts AI calls against the calling API key. Calls
+// without a named key count against the workspace.
+func withCallerQuotaSubject(ctx context.Context) context.Context {
+	if principal, ok := api.PrincipalFromContext(ctx); ok && principal.Name != "" {
+		return ailink.WithQuotaSubject(ctx, principal.Name)
+	}
+	return ctx
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/metrics"
+)
+
+// runStorePoolMetrics publishes the store's connection pool stats every
+// interval until ctx is done.
+func runStorePoolMetrics(ctx context.Context, st *store.Store, interval time.Duration) {
+	if st == nil || st.DB == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		metrics.RecordStorePoolStats(st.DB.Stats())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/api"
+	"github.com/namelens/namelens/internal/config"
+)
+
+func TestControlPlaneKeys(t *testing.T) {
+	t.Setenv("DASHBOARD_API_KEY", "dash-secret")
+
+	keys, err := controlPlaneKeys([]config.APIKeyConfig{
+		{Name: "dashboard", Key: "${DASHBOARD_API_KEY}", Role: "read-only"},
+		{Name: "ci", Key: "ci-secret", Role: "check", RateLimit: 30, DailyQuota: 500},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]api.Principal{
+		"dash-secret": {Name: "dashboard", Role: api.RoleReadOnly},
+		"ci-secret":   {Name: "ci", Role: api.RoleCheck, RateLimit: 30, DailyQuota: 500},
+	}, keys)
+
+	_, err = controlPlaneKeys([]config.APIKeyConfig{{Name: "ci", Key: "ci-secret", Role: "check", DailyQuota: -1}})
+	require.ErrorContains(t, err, "must not be negative")
+
+	_, err = controlPlaneKeys([]config.APIKeyConfig{{Name: "ops", Key: "ops-secret", Role: "root"}})
+	require.ErrorContains(t, err, "api key ops")
+
+	_, err = controlPlaneKeys([]config.APIKeyConfig{{Key: "${UNSET_API_KEY_VAR}", Role: "admin"}})
+	require.ErrorContains(t, err, "api key key-1: key is empty")
+}
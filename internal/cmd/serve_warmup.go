@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+	"github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+const defaultWarmupRetryInterval = 15 * time.Second
+
+// warmupGate is a readiness checker that fails until RDAP bootstrap data is
+// present and a canary domain check comes back available or taken. Once
+// warm, it stays ready; the bootstrap refresher keeps the data current.
+type warmupGate struct {
+	canary string
+	status func(ctx context.Context) (*checker.BootstrapStatus, error)
+	// check is nil in offline and read-only mode, where the canary cannot
+	// reach the network and bootstrap data alone makes the server ready.
+	check func(ctx context.Context, domain string) (*core.CheckResult, error)
+	// hint is appended when bootstrap data is missing.
+	hint string
+
+	mu     sync.Mutex
+	ready  bool
+	reason string
+}
+
+// newWarmupGate builds the gate for serve from health.warmup_canary. The
+// canary check bypasses the cache and history so it reflects live routing.
+func newWarmupGate(cfg *config.Config, st *store.Store) (*warmupGate, error) {
+	canary := cfg.Health.WarmupCanary
+	if canary == "" {
+		canary = "example.com"
+	}
+	name, tld, err := splitCanaryDomain(canary)
+	if err != nil {
+		return nil, fmt.Errorf("health.warmup_canary: %w", err)
+	}
+
+	service := &checker.BootstrapService{Store: st}
+	gate := &warmupGate{
+		canary: name + "." + tld,
+		status: service.Status,
+		hint:   " (run 'namelens bootstrap update')",
+		reason: "warming up",
+	}
+	if isOffline(cfg) {
+		return gate, nil
+	}
+
+	orchestrator := buildOrchestrator(cfg, st, false)
+	orchestrator.History = nil
+	gate.check = func(ctx context.Context, domain string) (*core.CheckResult, error) {
+		return checkDomain(ctx, orchestrator, domain)
+	}
+	if cfg.Bootstrap.AutoRefresh {
+		gate.hint = " (fetching)"
+	}
+	return gate, nil
+}
+
+// CheckHealth implements handlers.HealthChecker.
+func (g *warmupGate) CheckHealth(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.ready {
+		return nil
+	}
+	return errors.New(g.reason)
+}
+
+// run retries warm-up every interval until it succeeds or ctx is done.
+func (g *warmupGate) run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultWarmupRetryInterval
+	}
+	for {
+		err := g.attempt(ctx)
+		g.mu.Lock()
+		changed := false
+		if err == nil {
+			g.ready = true
+		} else {
+			changed = g.reason != err.Error()
+			g.reason = err.Error()
+		}
+		g.mu.Unlock()
+
+		if err == nil {
+			observability.ServerLogger.Info("Warm-up complete; readiness probe passing",
+				zap.String("canary", g.canary))
+			return
+		}
+		// Log each new reason once rather than every retry
+		if changed {
+			observability.ServerLogger.Info("Warm-up pending; readiness probe failing",
+				zap.String("reason", err.Error()),
+				zap.Duration("retry_interval", interval))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// attempt returns why the server is not warm yet, or nil when it is.
+func (g *warmupGate) attempt(ctx context.Context) error {
+	status, err := g.status(ctx)
+	if err != nil {
+		return fmt.Errorf("read bootstrap status: %w", err)
+	}
+	if status == nil || status.TLDCount == 0 {
+		return errors.New("RDAP bootstrap data missing" + g.hint)
+	}
+	if g.check == nil {
+		return nil
+	}
+
+	result, err := g.check(ctx, g.canary)
+	if err != nil {
+		return fmt.Errorf("canary %s: %w", g.canary, err)
+	}
+	switch result.Available {
+	case core.AvailabilityAvailable, core.AvailabilityTaken:
+		return nil
+	}
+	msg := fmt.Sprintf("canary %s: %s", g.canary, result.Available.String())
+	if result.Message != "" {
+		msg += ": " + result.Message
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+)
+
+func TestWarmupGateAttempt(t *testing.T) {
+	status := &checker.BootstrapStatus{}
+	result := &core.CheckResult{Available: core.AvailabilityUnsupported, Message: "no RDAP server for com"}
+	gate := &warmupGate{
+		canary: "example.com",
+		status: func(context.Context) (*checker.BootstrapStatus, error) { return status, nil },
+		check: func(_ context.Context, domain string) (*core.CheckResult, error) {
+			require.Equal(t, "example.com", domain)
+			return result, nil
+		},
+		hint:   " (fetching)",
+		reason: "warming up",
+	}
+	ctx := context.Background()
+	require.EqualError(t, gate.CheckHealth(ctx), "warming up")
+
+	require.EqualError(t, gate.attempt(ctx), "RDAP bootstrap data missing (fetching)")
+
+	status.TLDCount = 1200
+	err := gate.attempt(ctx)
+	require.ErrorContains(t, err, "canary example.com: unsupported")
+	require.ErrorContains(t, err, "no RDAP server")
+
+	gate.check = func(context.Context, string) (*core.CheckResult, error) { return nil, errors.New("timeout") }
+	require.EqualError(t, gate.attempt(ctx), "canary example.com: timeout")
+
+	result.Available = core.AvailabilityTaken
+	gate.check = func(context.Context, string) (*core.CheckResult, error) { return result, nil }
+	require.NoError(t, gate.attempt(ctx))
+
+	// Offline and read-only servers are ready once bootstrap data is present
+	gate.check = nil
+	require.NoError(t, gate.attempt(ctx))
+	status.TLDCount = 0
+	require.ErrorContains(t, gate.attempt(ctx), "RDAP bootstrap data missing")
+}
@@ -269,7 +269,8 @@ func runSetupConnectionTest(ctx context.Context, stdout io.Writer, provider *pro
 	_, _ = fmt.Fprintf(stdout, "  DNS resolve %s... ", host)
 	dnsCtx, dnsCancel := context.WithTimeout(ctx, timeout)
 	defer dnsCancel()
-	ips, err := net.DefaultResolver.LookupIPAddr(dnsCtx, host)
+	resolver := configuredResolver(config.GetConfig())
+	ips, err := resolver.LookupIPAddr(dnsCtx, host)
 	if err != nil {
 		_, _ = fmt.Fprintln(stdout, "FAIL")
 		return fmt.Errorf("DNS: %w", err)
@@ -278,7 +279,7 @@ func runSetupConnectionTest(ctx context.Context, stdout io.Writer, provider *pro
 
 	// TCP
 	_, _ = fmt.Fprintf(stdout, "  TCP connect %s:%d... ", host, port)
-	dialer := &net.Dialer{Timeout: timeout}
+	dialer := &net.Dialer{Timeout: timeout, Resolver: resolver}
 	conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", host, port))
 	if err != nil {
 		_, _ = fmt.Fprintln(stdout, "FAIL")
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/namelens/namelens/internal/api"
+	"github.com/namelens/namelens/internal/config"
+	corestore "github.com/namelens/namelens/internal/core/store"
+)
+
+// newSharing builds the share link settings for serve mode, or nil when
+// share links are disabled. ephemeral reports a generated secret, whose
+// links stop working when the server restarts.
+func newSharing(cfg config.ShareConfig, store *corestore.Store) (sharing *api.Sharing, ephemeral bool, err error) {
+	if !cfg.Enabled || store == nil {
+		return nil, false, nil
+	}
+	if cfg.TTL <= 0 {
+		return nil, false, errors.New("server.share.ttl must be positive")
+	}
+	if cfg.MaxTTL > 0 && cfg.TTL > cfg.MaxTTL {
+		return nil, false, errors.New("server.share.ttl exceeds server.share.max_ttl")
+	}
+
+	secret := []byte(strings.TrimSpace(os.ExpandEnv(cfg.Secret)))
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, false, err
+		}
+		ephemeral = true
+	}
+
+	return &api.Sharing{
+		Store:   shareStore{store: store},
+		Secret:  secret,
+		TTL:     cfg.TTL,
+		MaxTTL:  cfg.MaxTTL,
+		BaseURL: cfg.BaseURL,
+	}, ephemeral, nil
+}
+
+// shareStore keeps share snapshots in the namelens store.
+type shareStore struct {
+	store *corestore.Store
+}
+
+func (s shareStore) SaveShare(ctx context.Context, record api.ShareRecord) error {
+	return s.store.CreateShare(ctx, corestore.Share{
+		ID:        record.ID,
+		Kind:      record.Kind,
+		Title:     record.Title,
+		Payload:   record.Payload,
+		CreatedAt: record.CreatedAt,
+		ExpiresAt: record.ExpiresAt,
+	})
+}
+
+func (s shareStore) LoadShare(ctx context.Context, id string, now time.Time) (*api.ShareRecord, error) {
+	share, err := s.store.GetShare(ctx, id, now)
+	if err != nil || share == nil {
+		return nil, err
+	}
+	return &api.ShareRecord{
+		ID:        share.ID,
+		Kind:      share.Kind,
+		Title:     share.Title,
+		Payload:   share.Payload,
+		CreatedAt: share.CreatedAt,
+		ExpiresAt: share.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/similarity"
+)
+
+func addSimilarityFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-similarity", false, "Skip the check for names similar to existing brands")
+	cmd.Flags().String("brands-file", "", "Also compare names against the brands in this file (one per line)")
+}
+
+// resolveSimilarity builds the brand index from the similarity settings and
+// flags. It returns nil when the check is off: --no-similarity, or disabled
+// in config without --brands-file.
+func resolveSimilarity(cmd *cobra.Command, cfg *config.Config) (*similarity.Index, error) {
+	skip, err := cmd.Flags().GetBool("no-similarity")
+	if err != nil {
+		return nil, err
+	}
+	brandsFile, err := cmd.Flags().GetString("brands-file")
+	if err != nil {
+		return nil, err
+	}
+	brandsFile = strings.TrimSpace(brandsFile)
+	if skip || (!cfg.Similarity.Enabled && brandsFile == "") {
+		return nil, nil
+	}
+
+	brands := append([]string{}, cfg.Similarity.Brands...)
+	for _, path := range []string{strings.TrimSpace(cfg.Similarity.BrandsFile), brandsFile} {
+		if path == "" {
+			continue
+		}
+		loaded, err := similarity.LoadBrandsFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("loading brands: %w", err)
+		}
+		brands = append(brands, loaded...)
+	}
+	index := similarity.NewIndex(brands)
+	index.MinScore = cfg.Similarity.MinScore
+	return index, nil
+}
+
+// applySimilarity records each name's near collisions with existing brands.
+func applySimilarity(batches []*core.BatchResult, index *similarity.Index) {
+	if index == nil {
+		return
+	}
+	for _, batch := range batches {
+		if batch != nil {
+			batch.SimilarBrands = index.Compare(batch.Name)
+		}
+	}
+}
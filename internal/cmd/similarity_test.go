@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func similarityTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	addSimilarityFlags(cmd)
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestResolveSimilarity(t *testing.T) {
+	cfg := &config.Config{Similarity: config.SimilarityConfig{Enabled: true, Brands: []string{"Widgetly"}}}
+
+	index, err := resolveSimilarity(similarityTestCommand(t, "--no-similarity"), cfg)
+	require.NoError(t, err)
+	require.Nil(t, index)
+
+	index, err = resolveSimilarity(similarityTestCommand(t), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, index)
+
+	batches := []*core.BatchResult{{Name: "strype"}, {Name: "widgetli"}, nil}
+	applySimilarity(batches, index)
+	require.NotEmpty(t, batches[0].SimilarBrands)
+	require.Equal(t, "stripe", batches[0].SimilarBrands[0].Brand)
+	require.NotEmpty(t, batches[1].SimilarBrands)
+	require.Equal(t, "Widgetly", batches[1].SimilarBrands[0].Brand)
+
+	// --brands-file turns the check on even when disabled in config
+	path := filepath.Join(t.TempDir(), "brands.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# ours\nAcmely\n"), 0o600))
+	index, err = resolveSimilarity(similarityTestCommand(t, "--brands-file", path), &config.Config{})
+	require.NoError(t, err)
+	require.Equal(t, "Acmely", index.Compare("acmely")[0].Brand)
+
+	_, err = resolveSimilarity(similarityTestCommand(t, "--brands-file", filepath.Join(t.TempDir(), "missing.txt")), cfg)
+	require.ErrorContains(t, err, "loading brands")
+}
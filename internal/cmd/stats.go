@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize naming research recorded in the local store",
+	Long: `Summarize the local store: names researched, availability rates by TLD,
+most-checked names, AI spend and cached AI analyses over time, and cache
+efficiency.
+
+Statistics are derived from check history (recorded for every fresh check),
+the check cache, the AI analysis cache, and the AI calls and estimated cost
+recorded for every provider call.`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().Int("top", 10, "Number of most-checked names to show")
+	statsCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	statsCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	top, err := cmd.Flags().GetInt("top")
+	if err != nil {
+		return err
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	stats, err := store.Stats(ctx, top)
+	if err != nil {
+		return err
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderStats(sink.writer, stats, format)
+}
+
+func renderStats(w io.Writer, stats *corestore.StoreStats, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		return renderStatsMarkdown(w, stats)
+	default:
+		return renderStatsTable(w, stats)
+	}
+}
+
+func renderStatsTable(w io.Writer, stats *corestore.StoreStats) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetStyle(table.StyleRounded)
+	t.SetTitle("Overview")
+	for _, row := range statsOverviewRows(stats) {
+		t.AppendRow(table.Row{row[0], row[1]})
+	}
+	t.Render()
+
+	if len(stats.TLDs) > 0 {
+		t = table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.SetTitle("Availability by TLD")
+		t.AppendHeader(table.Row{"TLD", "Checks", "Available", "Taken", "Available %"})
+		for _, tld := range stats.TLDs {
+			t.AppendRow(table.Row{"." + tld.TLD, tld.Checks, tld.Available, tld.Taken, formatPercent(tld.Available, tld.Checks)})
+		}
+		t.Render()
+	}
+
+	if len(stats.TopNames) > 0 {
+		t = table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.SetTitle("Most-checked names")
+		t.AppendHeader(table.Row{"Name", "Runs", "Last Checked"})
+		for _, name := range stats.TopNames {
+			t.AppendRow(table.Row{name.Name, name.Runs, name.LastCheckedAt.Format(time.RFC3339)})
+		}
+		t.Render()
+	}
+
+	if len(stats.AISpend) > 0 {
+		t = table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.SetTitle("AI spend (estimated)")
+		t.AppendHeader(table.Row{"Month", "Calls", "Cost (USD)"})
+		for _, spend := range stats.AISpend {
+			t.AppendRow(table.Row{spend.Month, spend.Calls, fmt.Sprintf("$%.2f", spend.CostUSD)})
+		}
+		t.Render()
+	}
+
+	if len(stats.AIAnalyses) > 0 {
+		t = table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.SetTitle("AI analyses (cached)")
+		t.AppendHeader(table.Row{"Month", "Prompt", "Count"})
+		for _, ai := range stats.AIAnalyses {
+			t.AppendRow(table.Row{ai.Month, ai.Prompt, ai.Count})
+		}
+		t.Render()
+	}
+
+	return nil
+}
+
+func renderStatsMarkdown(w io.Writer, stats *corestore.StoreStats) error {
+	_, _ = fmt.Fprintln(w, "## Overview")
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "| Metric | Value |")
+	_, _ = fmt.Fprintln(w, "|--------|-------|")
+	for _, row := range statsOverviewRows(stats) {
+		_, _ = fmt.Fprintf(w, "| %s | %s |\n", row[0], row[1])
+	}
+
+	if len(stats.TLDs) > 0 {
+		_, _ = fmt.Fprintln(w, "\n## Availability by TLD")
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, "| TLD | Checks | Available | Taken | Available % |")
+		_, _ = fmt.Fprintln(w, "|-----|--------|-----------|-------|-------------|")
+		for _, tld := range stats.TLDs {
+			_, _ = fmt.Fprintf(w, "| .%s | %d | %d | %d | %s |\n", tld.TLD, tld.Checks, tld.Available, tld.Taken, formatPercent(tld.Available, tld.Checks))
+		}
+	}
+
+	if len(stats.TopNames) > 0 {
+		_, _ = fmt.Fprintln(w, "\n## Most-checked names")
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, "| Name | Runs | Last Checked |")
+		_, _ = fmt.Fprintln(w, "|------|------|--------------|")
+		for _, name := range stats.TopNames {
+			_, _ = fmt.Fprintf(w, "| %s | %d | %s |\n", name.Name, name.Runs, name.LastCheckedAt.Format(time.RFC3339))
+		}
+	}
+
+	if len(stats.AISpend) > 0 {
+		_, _ = fmt.Fprintln(w, "\n## AI spend (estimated)")
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, "| Month | Calls | Cost (USD) |")
+		_, _ = fmt.Fprintln(w, "|-------|-------|------------|")
+		for _, spend := range stats.AISpend {
+			_, _ = fmt.Fprintf(w, "| %s | %d | $%.2f |\n", spend.Month, spend.Calls, spend.CostUSD)
+		}
+	}
+
+	if len(stats.AIAnalyses) > 0 {
+		_, _ = fmt.Fprintln(w, "\n## AI analyses (cached)")
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, "| Month | Prompt | Count |")
+		_, _ = fmt.Fprintln(w, "|-------|--------|-------|")
+		for _, ai := range stats.AIAnalyses {
+			_, _ = fmt.Fprintf(w, "| %s | %s | %d |\n", ai.Month, ai.Prompt, ai.Count)
+		}
+	}
+
+	return nil
+}
+
+func statsOverviewRows(stats *corestore.StoreStats) [][2]string {
+	rows := [][2]string{
+		{"Names researched", fmt.Sprintf("%d", stats.NamesResearched)},
+		{"Check runs", fmt.Sprintf("%d", stats.Runs)},
+		{"Results recorded", fmt.Sprintf("%d", stats.Results)},
+	}
+	if stats.FirstCheckedAt != nil && stats.LastCheckedAt != nil {
+		rows = append(rows, [2]string{"Period", fmt.Sprintf("%s to %s", stats.FirstCheckedAt.Format("2006-01-02"), stats.LastCheckedAt.Format("2006-01-02"))})
+	}
+	rows = append(rows,
+		[2]string{"Cache entries (live)", fmt.Sprintf("%d (%d)", stats.Cache.CheckEntries, stats.Cache.CheckLive)},
+		[2]string{"Cache hit rate", fmt.Sprintf("%.1f%% (%d hits, %d fresh lookups)", stats.Cache.HitRate*100, stats.Cache.Hits, stats.Cache.FreshLookups)},
+		[2]string{"AI cache entries (live)", fmt.Sprintf("%d (%d)", stats.Cache.ExpertEntries, stats.Cache.ExpertLive)},
+	)
+	return rows
+}
+
+func formatPercent(part, total int) string {
+	if total <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", float64(part)/float64(total)*100)
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/output"
+	"github.com/namelens/namelens/internal/usagestats"
+)
+
+// telemetryFlushTimeout bounds the automatic flush at the end of a command.
+const telemetryFlushTimeout = 3 * time.Second
+
+var (
+	telemetryStatusFormat string
+	telemetryFlushDryRun  bool
+)
+
+// newUsageStore is replaced in tests.
+var newUsageStore = func() *usagestats.Store {
+	return usagestats.NewStore(config.DefaultTelemetryDir())
+}
+
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Opt in to or out of anonymous usage stats",
+	Long: `Anonymous usage stats are off unless you turn them on.
+
+When on, each command run queues one event locally: the command, the names
+of the flags set (not their values), the output format, the checker kinds
+(domain, npm, github, ...; custom checkers as "custom"), the number of
+arguments, the duration, success, the namelens version, OS, and architecture.
+Names, paths, flag values, and errors are never recorded.
+
+Queued events are sent to telemetry.endpoint at most once per
+telemetry.flush_interval, or with 'namelens telemetry flush'. DO_NOT_TRACK=1
+turns recording off regardless of this setting.`,
+}
+
+var telemetryOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Start recording anonymous usage stats",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := newUsageStore()
+		if err := store.SetEnabled(true); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Anonymous usage stats are on. Thank you!")
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Run 'namelens telemetry flush --dry-run' to see exactly what would be sent.")
+		if usagestats.DoNotTrack() {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "Note: DO_NOT_TRACK is set, so nothing is recorded until it is unset.")
+		}
+		return nil
+	},
+}
+
+var telemetryOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Stop recording and delete queued events",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := newUsageStore().SetEnabled(false); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Anonymous usage stats are off; queued events were deleted.")
+		return nil
+	},
+}
+
+var telemetryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether usage stats are recorded and what is queued",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := output.ParseFormat(telemetryStatusFormat)
+		if err != nil {
+			return err
+		}
+		if format != output.FormatJSON && format != output.FormatTable {
+			return fmt.Errorf("unsupported output format for telemetry status: %s", format)
+		}
+		cfg, err := config.Load(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+
+		status, err := collectTelemetryStatus(newUsageStore(), cfg.Telemetry)
+		if err != nil {
+			return err
+		}
+		if format == output.FormatJSON {
+			payload, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(payload))
+			return err
+		}
+		renderTelemetryStatus(cmd.OutOrStdout(), status)
+		return nil
+	},
+}
+
+var telemetryFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Send queued usage events now",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := newUsageStore()
+		if telemetryFlushDryRun {
+			events, err := store.Pending()
+			if err != nil {
+				return err
+			}
+			if events == nil {
+				events = []usagestats.Event{}
+			}
+			payload, err := json.MarshalIndent(map[string]any{"events": events}, "", "  ")
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(payload))
+			return err
+		}
+
+		cfg, err := config.Load(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("load config: %w", err)
+		}
+		if offlineMode || cfg.Offline {
+			return fmt.Errorf("telemetry flush needs the network; it is disabled in offline mode")
+		}
+		sent, err := store.Flush(cmd.Context(), &http.Client{Timeout: 10 * time.Second}, cfg.Telemetry.Endpoint)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Sent %d usage events\n", sent)
+		return nil
+	},
+}
+
+// telemetryStatus is the telemetry status report.
+type telemetryStatus struct {
+	Enabled    bool       `json:"enabled"`
+	Consent    bool       `json:"consent"`
+	DoNotTrack bool       `json:"do_not_track"`
+	Endpoint   string     `json:"endpoint,omitempty"`
+	Queued     int        `json:"queued"`
+	LastFlush  *time.Time `json:"last_flush,omitempty"`
+	Dir        string     `json:"dir"`
+}
+
+func collectTelemetryStatus(store *usagestats.Store, cfg config.TelemetryConfig) (telemetryStatus, error) {
+	state, err := store.State()
+	if err != nil {
+		return telemetryStatus{}, err
+	}
+	events, err := store.Pending()
+	if err != nil {
+		return telemetryStatus{}, err
+	}
+	status := telemetryStatus{
+		Enabled:    state.Enabled && !usagestats.DoNotTrack(),
+		Consent:    state.Enabled,
+		DoNotTrack: usagestats.DoNotTrack(),
+		Endpoint:   strings.TrimSpace(cfg.Endpoint),
+		Queued:     len(events),
+		Dir:        store.Dir(),
+	}
+	if !state.LastFlush.IsZero() {
+		lastFlush := state.LastFlush
+		status.LastFlush = &lastFlush
+	}
+	return status, nil
+}
+
+func renderTelemetryStatus(w io.Writer, status telemetryStatus) {
+	recording := "off"
+	switch {
+	case status.Enabled:
+		recording = "on"
+	case status.Consent && status.DoNotTrack:
+		recording = "off (DO_NOT_TRACK is set)"
+	}
+	endpoint := status.Endpoint
+	if endpoint == "" {
+		endpoint = "(none; events stay local)"
+	}
+	lastFlush := "never"
+	if status.LastFlush != nil {
+		lastFlush = status.LastFlush.Format(time.RFC3339)
+	}
+	_, _ = fmt.Fprintf(w, "Usage stats: %s\n", recording)
+	_, _ = fmt.Fprintf(w, "Endpoint:    %s\n", endpoint)
+	_, _ = fmt.Fprintf(w, "Queued:      %d events\n", status.Queued)
+	_, _ = fmt.Fprintf(w, "Last flush:  %s\n", lastFlush)
+	_, _ = fmt.Fprintf(w, "Directory:   %s\n", status.Dir)
+}
+
+// builtinCheckers are reported by name; any other registry or handle is
+// reported as "custom" so user-defined checker names never leave the machine.
+var builtinCheckers = map[string]bool{
+	"npm": true, "pypi": true, "cargo": true,
+	"github": true, "slack": true, "discord": true, "google-workspace": true,
+}
+
+// recordUsage queues an event for the command that ran when usage stats are
+// on, then flushes when one is due. Failures are only logged at debug level.
+func recordUsage(cmd *cobra.Command, startedAt time.Time, runErr error) {
+	if cmd == nil || !usageRecorded(cmd) {
+		return
+	}
+	store := newUsageStore()
+	if !store.Enabled() {
+		return
+	}
+	if err := store.Record(usageEvent(cmd, time.Since(startedAt), runErr == nil)); err != nil {
+		logTelemetryError("Failed to record usage event", err)
+		return
+	}
+	flushUsageIfDue(store)
+}
+
+// usageRecorded skips the root command, help, shell completion, and the
+// telemetry command itself.
+func usageRecorded(cmd *cobra.Command) bool {
+	if !cmd.HasParent() {
+		return false
+	}
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd, telemetryCmd.Name():
+			return false
+		}
+	}
+	return true
+}
+
+// usageEvent describes a command run without names, paths, or flag values.
+func usageEvent(cmd *cobra.Command, duration time.Duration, success bool) usagestats.Event {
+	event := usagestats.Event{
+		Date:       time.Now().UTC().Format(time.DateOnly),
+		Command:    strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+		Args:       len(cmd.Flags().Args()),
+		DurationMs: duration.Milliseconds(),
+		Success:    success,
+		Version:    versionInfo.Version,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		event.Flags = append(event.Flags, flag.Name)
+	})
+
+	if flag := cmd.Flags().Lookup("output-format"); flag != nil {
+		if format, err := output.ParseFormat(flag.Value.String()); err == nil {
+			event.OutputFormat = string(format)
+		}
+	}
+
+	checkers := map[string]bool{}
+	if tlds, err := cmd.Flags().GetStringSlice("tlds"); err == nil && len(tlds) > 0 {
+		checkers["domain"] = true
+	}
+	for _, name := range []string{"registries", "handles"} {
+		values, err := cmd.Flags().GetStringSlice(name)
+		if err != nil {
+			continue
+		}
+		for _, value := range values {
+			value = strings.ToLower(strings.TrimSpace(value))
+			switch {
+			case value == "":
+			case builtinCheckers[value]:
+				checkers[value] = true
+			default:
+				checkers["custom"] = true
+			}
+		}
+	}
+	for checker := range checkers {
+		event.Checkers = append(event.Checkers, checker)
+	}
+	sort.Strings(event.Checkers)
+	return event
+}
+
+// flushUsageIfDue sends queued events when an endpoint is configured and
+// telemetry.flush_interval has passed since the last flush.
+func flushUsageIfDue(store *usagestats.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryFlushTimeout)
+	defer cancel()
+	cfg, err := config.Load(ctx)
+	if err != nil || offlineMode || cfg.Offline || strings.TrimSpace(cfg.Telemetry.Endpoint) == "" {
+		return
+	}
+	state, err := store.State()
+	if err != nil || !state.FlushDue(cfg.Telemetry.FlushInterval, time.Now()) {
+		return
+	}
+	if _, err := store.Flush(ctx, &http.Client{Timeout: telemetryFlushTimeout}, cfg.Telemetry.Endpoint); err != nil {
+		logTelemetryError("Failed to flush usage events", err)
+	}
+}
+
+func logTelemetryError(msg string, err error) {
+	if observability.CLILogger != nil {
+		observability.CLILogger.Debug(msg, zap.Error(err))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(telemetryCmd)
+	telemetryCmd.AddCommand(telemetryOnCmd)
+	telemetryCmd.AddCommand(telemetryOffCmd)
+	telemetryCmd.AddCommand(telemetryStatusCmd)
+	telemetryCmd.AddCommand(telemetryFlushCmd)
+
+	telemetryStatusCmd.Flags().StringVar(&telemetryStatusFormat, "output-format", string(output.FormatTable), "Output format: table|json")
+	telemetryFlushCmd.Flags().BoolVar(&telemetryFlushDryRun, "dry-run", false, "Print the queued events instead of sending them")
+}
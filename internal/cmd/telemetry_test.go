@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageEventOmitsValues(t *testing.T) {
+	root := &cobra.Command{Use: "namelens"}
+	check := &cobra.Command{Use: "check", RunE: func(*cobra.Command, []string) error { return nil }}
+	check.Flags().StringSlice("tlds", []string{"com"}, "")
+	check.Flags().StringSlice("registries", []string{"npm"}, "")
+	check.Flags().StringSlice("handles", []string{"github"}, "")
+	check.Flags().String("output-format", "table", "")
+	check.Flags().String("out", "", "")
+	root.AddCommand(check)
+
+	require.NoError(t, check.ParseFlags([]string{"acme", "widget", "--registries", "npm,internal-registry", "--output-format", "JSON", "--out", "/home/me/secret-project.json"}))
+
+	event := usageEvent(check, 1500*time.Millisecond, true)
+	require.Equal(t, "check", event.Command)
+	require.Equal(t, []string{"out", "output-format", "registries"}, event.Flags)
+	require.Equal(t, "json", event.OutputFormat)
+	require.Equal(t, []string{"custom", "domain", "github", "npm"}, event.Checkers)
+	require.Equal(t, 2, event.Args)
+	require.Equal(t, int64(1500), event.DurationMs)
+	require.True(t, event.Success)
+	require.Equal(t, time.Now().UTC().Format(time.DateOnly), event.Date)
+}
+
+func TestUsageRecorded(t *testing.T) {
+	require.False(t, usageRecorded(rootCmd))
+	require.True(t, usageRecorded(checkCmd))
+	require.False(t, usageRecorded(telemetryStatusCmd))
+
+	root := &cobra.Command{Use: "namelens"}
+	help := &cobra.Command{Use: "help"}
+	root.AddCommand(help)
+	require.False(t, usageRecorded(help))
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/ailink/driver"
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var traceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "Work with AILink trace files",
+	Long: `Tools for NDJSON trace files written with --trace.
+
+Each line records one provider request: driver, endpoint, model, prompt slug,
+request body, status code, and response body, with secrets redacted.`,
+}
+
+var traceReplayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Re-run traced AILink responses offline",
+	Long: `Replay recorded provider responses without calling the provider.
+
+Each entry's response is fed to the real driver through a mock transport,
+then validated against the prompt's response schema exactly as a live call
+would be. Use it to reproduce schema validation failures after changing a
+prompt or schema, and --save-fixtures to keep failing responses as
+regression fixtures.
+
+Outcomes:
+  valid           the response decoded and matched the schema
+  schema_invalid  the response did not match the prompt's response schema
+  provider_error  the provider returned an error status
+  error           no response was recorded, or it could not be decoded
+  unchecked       the entry names no prompt (set one with --prompt)
+
+Traces recorded before prompt slugs were traced need --prompt.`,
+	Example: `  namelens generate acme --trace run.ndjson
+  namelens trace replay run.ndjson
+  namelens trace replay run.ndjson --entry 3 --raw
+  namelens trace replay run.ndjson --save-fixtures internal/ailink/testdata/replay`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTraceReplay,
+}
+
+func init() {
+	rootCmd.AddCommand(traceCmd)
+	traceCmd.AddCommand(traceReplayCmd)
+
+	traceReplayCmd.Flags().IntSlice("entry", nil, "Replay only these entries (1-based, repeatable)")
+	traceReplayCmd.Flags().String("prompt", "", "Prompt slug for entries that do not record one")
+	traceReplayCmd.Flags().Bool("raw", false, "Print the response content of each entry")
+	traceReplayCmd.Flags().String("save-fixtures", "", "Write each replayed entry to <dir>/<outcome>/ as a regression fixture")
+	traceReplayCmd.Flags().Bool("strict", false, "Exit non-zero unless every replayed entry is valid")
+	traceReplayCmd.Flags().String("output-format", "table", "Output format: table, json")
+}
+
+// traceReplayEntry is a replay result with its position in the trace.
+type traceReplayEntry struct {
+	Entry int `json:"entry"`
+	ailink.ReplayResult
+}
+
+type traceReplayReport struct {
+	File     string             `json:"file"`
+	Outcomes map[string]int     `json:"outcomes"`
+	Entries  []traceReplayEntry `json:"entries"`
+}
+
+func runTraceReplay(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	only, err := cmd.Flags().GetIntSlice("entry")
+	if err != nil {
+		return err
+	}
+	slug, err := cmd.Flags().GetString("prompt")
+	if err != nil {
+		return err
+	}
+	showRaw, err := cmd.Flags().GetBool("raw")
+	if err != nil {
+		return err
+	}
+	fixturesDir, err := cmd.Flags().GetString("save-fixtures")
+	if err != nil {
+		return err
+	}
+	strict, err := cmd.Flags().GetBool("strict")
+	if err != nil {
+		return err
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format != output.FormatTable && format != output.FormatJSON {
+		return fmt.Errorf("unsupported output format for trace replay: %s", format)
+	}
+
+	f, err := os.Open(path) // #nosec G304 -- user-provided trace file
+	if err != nil {
+		return fmt.Errorf("open trace: %w", err)
+	}
+	entries, err := driver.ReadTrace(f)
+	_ = f.Close()
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	selected := map[int]bool{}
+	for _, n := range only {
+		if n < 1 || n > len(entries) {
+			return fmt.Errorf("--entry %d is out of range (trace has %d entries)", n, len(entries))
+		}
+		selected[n] = true
+	}
+
+	cfg, err := config.Load(cmd.Context())
+	if err != nil {
+		return err
+	}
+	registry, err := buildPromptRegistry(cfg)
+	if err != nil {
+		return fmt.Errorf("loading prompts: %w", err)
+	}
+	catalog, err := buildSchemaCatalog()
+	if err != nil {
+		return fmt.Errorf("loading schemas: %w", err)
+	}
+	service := &ailink.Service{Registry: registry, Catalog: catalog}
+
+	report := traceReplayReport{File: path, Outcomes: map[string]int{}, Entries: []traceReplayEntry{}}
+	for i, entry := range entries {
+		n := i + 1
+		if len(selected) > 0 && !selected[n] {
+			continue
+		}
+		result := service.Replay(cmd.Context(), entry, slug)
+		if !showRaw && format == output.FormatTable {
+			result.Raw = nil
+		}
+		report.Entries = append(report.Entries, traceReplayEntry{Entry: n, ReplayResult: result})
+		report.Outcomes[result.Outcome]++
+
+		if fixturesDir != "" {
+			if err := writeReplayFixture(fixturesDir, n, entry, result); err != nil {
+				return err
+			}
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if format == output.FormatJSON {
+		payload, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, string(payload))
+	} else if err := renderTraceReplay(out, report); err != nil {
+		return err
+	}
+
+	if strict {
+		if failed := len(report.Entries) - report.Outcomes[ailink.ReplayValid]; failed > 0 {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("%d of %d replayed entries were not valid", failed, len(report.Entries))
+		}
+	}
+	return nil
+}
+
+// writeReplayFixture saves entry as a one-line trace under dir/<outcome>/,
+// the layout the AILink replay tests read.
+func writeReplayFixture(dir string, n int, entry driver.TraceEntry, result ailink.ReplayResult) error {
+	name := result.PromptSlug
+	if name == "" {
+		name = result.Driver
+	}
+	target := filepath.Join(dir, result.Outcome)
+	if err := os.MkdirAll(target, 0755); err != nil { // #nosec G301 -- user-provided fixtures dir
+		return fmt.Errorf("create fixtures dir: %w", err)
+	}
+	if entry.PromptSlug == "" {
+		entry.PromptSlug = result.PromptSlug
+	}
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(target, fmt.Sprintf("%s-%03d.ndjson", sanitizeFilename(name), n))
+	if err := os.WriteFile(file, append(payload, '\n'), 0600); err != nil {
+		return fmt.Errorf("write fixture: %w", err)
+	}
+	return nil
+}
+
+func renderTraceReplay(w io.Writer, report traceReplayReport) error {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "ENTRY\tDRIVER\tMODEL\tPROMPT\tOUTCOME\tDETAIL") // nolint:errcheck // tabwriter buffers; errors surface at Flush
+	for _, entry := range report.Entries {
+		slug := entry.PromptSlug
+		if slug == "" {
+			slug = "-"
+		}
+		_, _ = fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%s\t%s\n", entry.Entry, entry.Driver, entry.Model, slug, entry.Outcome, firstLine(entry.Error)) // nolint:errcheck // tabwriter buffers
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	for _, entry := range report.Entries {
+		if len(entry.Raw) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n--- entry %d (%s) ---\n%s\n", entry.Entry, entry.Outcome, strings.TrimSpace(string(entry.Raw)))
+	}
+
+	parts := make([]string, 0, len(report.Outcomes))
+	for _, outcome := range []string{ailink.ReplayValid, ailink.ReplaySchemaInvalid, ailink.ReplayProviderError, ailink.ReplayError, ailink.ReplayUnchecked} {
+		if count := report.Outcomes[outcome]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, outcome))
+		}
+	}
+	summary := "none"
+	if len(parts) > 0 {
+		summary = strings.Join(parts, ", ")
+	}
+	_, err := fmt.Fprintf(w, "\n%d entries replayed: %s\n", len(report.Entries), summary)
+	return err
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
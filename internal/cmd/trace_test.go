@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/ailink/driver"
+)
+
+func TestWriteReplayFixture(t *testing.T) {
+	dir := t.TempDir()
+	entry := driver.TraceEntry{Driver: "xai", StatusCode: 200}
+	result := ailink.ReplayResult{Driver: "xai", PromptSlug: "name-phonetics", Outcome: ailink.ReplaySchemaInvalid}
+
+	require.NoError(t, writeReplayFixture(dir, 7, entry, result))
+
+	f, err := os.Open(filepath.Join(dir, "schema_invalid", "name-phonetics-007.ndjson"))
+	require.NoError(t, err)
+	defer f.Close() //nolint:errcheck
+	entries, err := driver.ReadTrace(f)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	// The slug given with --prompt is kept so the fixture replays on its own
+	require.Equal(t, "name-phonetics", entries[0].PromptSlug)
+}
+
+func TestRenderTraceReplay(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderTraceReplay(&buf, traceReplayReport{
+		Outcomes: map[string]int{ailink.ReplayValid: 1, ailink.ReplayProviderError: 1},
+		Entries: []traceReplayEntry{
+			{Entry: 1, ReplayResult: ailink.ReplayResult{Driver: "openai", Model: "gpt-4.1-mini", PromptSlug: "brand-plan", Outcome: ailink.ReplayValid}},
+			{Entry: 2, ReplayResult: ailink.ReplayResult{Driver: "anthropic", Outcome: ailink.ReplayProviderError, Error: "status 429\nbody"}},
+		},
+	}))
+	out := buf.String()
+	require.Contains(t, out, "brand-plan")
+	require.Contains(t, out, "status 429")
+	require.NotContains(t, out, "body")
+	require.Contains(t, out, "2 entries replayed: 1 valid, 1 provider_error")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/observability"
+	"github.com/namelens/namelens/internal/tracing"
+)
+
+// tracingFlushTimeout bounds how long exiting waits to export spans.
+const tracingFlushTimeout = 5 * time.Second
+
+// shutdownTracing flushes exported spans; it is a no-op until startTracing
+// sets it up.
+var shutdownTracing = func(context.Context) error { return nil }
+
+// startObservability turns on tracing and the request audit log before a
+// command runs. Neither stops a command: configuration errors are left to
+// the command, and setup errors only warn.
+func startObservability(cmd *cobra.Command, _ []string) {
+	cfg, err := config.Load(cmd.Context())
+	if err != nil || cfg == nil {
+		return
+	}
+	startTracing(cmd.Context(), cfg)
+	startAuditLog(cfg)
+}
+
+// startTracing exports OpenTelemetry spans when tracing is enabled.
+func startTracing(ctx context.Context, cfg *config.Config) {
+	if !cfg.Tracing.Enabled {
+		return
+	}
+
+	headers := make(map[string]string, len(cfg.Tracing.Headers))
+	for name, value := range cfg.Tracing.Headers {
+		headers[name] = os.ExpandEnv(value)
+	}
+	serviceName := "namelens"
+	if appIdentity != nil && appIdentity.BinaryName != "" {
+		serviceName = appIdentity.BinaryName
+	}
+
+	shutdown, err := tracing.Setup(ctx, tracing.Options{
+		Endpoint:       cfg.Tracing.Endpoint,
+		Protocol:       cfg.Tracing.Protocol,
+		Insecure:       cfg.Tracing.Insecure,
+		Headers:        headers,
+		SampleRatio:    cfg.Tracing.SampleRatio,
+		ServiceName:    serviceName,
+		ServiceVersion: versionInfo.Version,
+	})
+	if err != nil {
+		if observability.CLILogger != nil {
+			observability.CLILogger.Warn("Failed to enable OpenTelemetry tracing", zap.Error(err))
+		}
+		return
+	}
+	shutdownTracing = shutdown
+}
+
+// flushTracing exports buffered spans before the process exits.
+func flushTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer cancel()
+	_ = shutdownTracing(ctx)
+}
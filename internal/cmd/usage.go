@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/api"
+	"github.com/namelens/namelens/internal/config"
+	corestore "github.com/namelens/namelens/internal/core/store"
+	"github.com/namelens/namelens/internal/output"
+)
+
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show AI calls against quotas per API key and workspace",
+	Long: `Show AI calls for the current UTC day and month per quota subject, with the
+limits from ailink.quotas.
+
+Subjects are API key names (server.api_keys) for server requests and the
+workspace (ailink.quotas.workspace) for CLI runs. Configured subjects are
+listed even without calls. A limit of 0 is unlimited.`,
+	Args: cobra.NoArgs,
+	RunE: runUsage,
+}
+
+func init() {
+	rootCmd.AddCommand(usageCmd)
+
+	usageCmd.Flags().String("subject", "", "Show a single API key name or workspace")
+	usageCmd.Flags().String("output-format", "table", "Output format: table, json, markdown")
+	usageCmd.Flags().String("out", "", "Write output to a file (default stdout)")
+}
+
+func runUsage(cmd *cobra.Command, args []string) error {
+	subject, err := cmd.Flags().GetString("subject")
+	if err != nil {
+		return err
+	}
+	format, err := resolveOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close() //nolint:errcheck
+
+	cfg := config.GetConfig()
+	if cfg == nil {
+		return errors.New("config not loaded")
+	}
+
+	usage, err := aiQuotaUsage(ctx, cfg, store, strings.TrimSpace(subject), time.Now())
+	if err != nil {
+		return err
+	}
+
+	sink, err := openSink(outPath)
+	if err != nil {
+		return err
+	}
+	defer sink.close() //nolint:errcheck
+
+	return renderUsage(sink.writer, usage, format)
+}
+
+// aiQuotaUsage reports AI calls and limits for subject, or for every subject
+// with calls this month, the workspace, and configured subjects when subject
+// is empty.
+func aiQuotaUsage(ctx context.Context, cfg *config.Config, store *corestore.Store, subject string, now time.Time) ([]api.QuotaUsage, error) {
+	quotas := cfg.AILink.Quotas
+	usage := func(name string, daily, monthly int) api.QuotaUsage {
+		limit := quotas.LimitFor(name)
+		return api.QuotaUsage{
+			Subject:      name,
+			Daily:        daily,
+			DailyLimit:   limit.Daily,
+			Monthly:      monthly,
+			MonthlyLimit: limit.Monthly,
+		}
+	}
+
+	if subject != "" {
+		daily, monthly, err := store.AIUsage(ctx, subject, now)
+		if err != nil {
+			return nil, err
+		}
+		return []api.QuotaUsage{usage(subject, daily, monthly)}, nil
+	}
+
+	records, err := store.ListAIUsage(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	bySubject := make(map[string]api.QuotaUsage, len(records))
+	for _, record := range records {
+		bySubject[record.Subject] = usage(record.Subject, record.Daily, record.Monthly)
+	}
+	for _, name := range append([]string{quotas.WorkspaceName()}, mapKeys(quotas.Subjects)...) {
+		if _, ok := bySubject[name]; !ok {
+			bySubject[name] = usage(name, 0, 0)
+		}
+	}
+
+	result := make([]api.QuotaUsage, 0, len(bySubject))
+	for _, name := range mapKeys(bySubject) {
+		result = append(result, bySubject[name])
+	}
+	return result, nil
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func renderUsage(w io.Writer, usage []api.QuotaUsage, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		payload, err := json.MarshalIndent(api.UsageResponse{Subjects: usage}, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(payload))
+		return err
+	case output.FormatMarkdown:
+		_, _ = fmt.Fprintln(w, "| Subject | Today | Daily limit | This month | Monthly limit |")
+		_, _ = fmt.Fprintln(w, "|---------|-------|-------------|------------|---------------|")
+		for _, row := range usage {
+			_, _ = fmt.Fprintf(w, "| %s | %d | %s | %d | %s |\n",
+				row.Subject, row.Daily, formatQuotaLimit(row.DailyLimit), row.Monthly, formatQuotaLimit(row.MonthlyLimit))
+		}
+		return nil
+	default:
+		t := table.NewWriter()
+		t.SetOutputMirror(w)
+		t.SetStyle(table.StyleRounded)
+		t.AppendHeader(table.Row{"Subject", "Today", "Daily limit", "This month", "Monthly limit"})
+		for _, row := range usage {
+			t.AppendRow(table.Row{row.Subject, row.Daily, formatQuotaLimit(row.DailyLimit), row.Monthly, formatQuotaLimit(row.MonthlyLimit)})
+		}
+		t.Render()
+		return nil
+	}
+}
+
+func formatQuotaLimit(limit int) string {
+	if limit <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", limit)
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/api"
+	"github.com/namelens/namelens/internal/output"
+)
+
+func TestRenderUsage(t *testing.T) {
+	usage := []api.QuotaUsage{
+		{Subject: "ci", Daily: 3, DailyLimit: 10, Monthly: 42, MonthlyLimit: 0},
+	}
+
+	var md bytes.Buffer
+	require.NoError(t, renderUsage(&md, usage, output.FormatMarkdown))
+	require.Contains(t, md.String(), "| ci | 3 | 10 | 42 | unlimited |")
+
+	var table bytes.Buffer
+	require.NoError(t, renderUsage(&table, usage, output.FormatTable))
+	require.Contains(t, table.String(), "unlimited")
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+	"github.com/namelens/namelens/internal/similarity"
+)
+
+func addVariantsFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("variants", false, "Check .com for typo and look-alike variants of each name (typo-squat risk)")
+	cmd.Flags().Int("variants-limit", 10, "Number of variants checked per name with --variants")
+}
+
+// resolveVariants returns how many variants to check per name, or 0 without
+// --variants.
+func resolveVariants(cmd *cobra.Command) (int, error) {
+	enabled, err := cmd.Flags().GetBool("variants")
+	if err != nil {
+		return 0, err
+	}
+	limit, err := cmd.Flags().GetInt("variants-limit")
+	if err != nil {
+		return 0, err
+	}
+	if !enabled {
+		return 0, nil
+	}
+	if limit < 1 {
+		return 0, errors.New("variants-limit must be at least 1")
+	}
+	return limit, nil
+}
+
+// checkVariants checks .com for up to limit typo and look-alike variants of
+// name. Like alternatives, results come from the cache when available and are
+// not recorded in history.
+func checkVariants(ctx context.Context, orchestrator *engine.Orchestrator, name string, limit int) []core.VariantCheck {
+	variants := similarity.Variants(name, limit)
+	domains := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		domains = append(domains, variant.Name+".com")
+	}
+
+	byDomain := map[string]*core.CheckResult{}
+	for _, result := range checkAlternatives(ctx, orchestrator, domains) {
+		byDomain[strings.ToLower(result.Name)] = result
+	}
+	checks := make([]core.VariantCheck, 0, len(variants))
+	for i, variant := range variants {
+		if result, ok := byDomain[domains[i]]; ok {
+			checks = append(checks, core.VariantCheck{Name: domains[i], Kind: variant.Kind, Result: result})
+		}
+	}
+	return checks
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+// registeredChecker reports the listed domains taken and others available.
+type registeredChecker map[string]bool
+
+func (r registeredChecker) Check(_ context.Context, name string) (*core.CheckResult, error) {
+	available := core.AvailabilityAvailable
+	if r[name] {
+		available = core.AvailabilityTaken
+	}
+	return &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, Available: available}, nil
+}
+
+func (r registeredChecker) Type() core.CheckType { return core.CheckTypeDomain }
+
+func (r registeredChecker) SupportsName(string) bool { return true }
+
+func TestCheckVariants(t *testing.T) {
+	orchestrator := &engine.Orchestrator{
+		Checkers: map[core.CheckType]engine.Checker{core.CheckTypeDomain: registeredChecker{"5tripe.com": true}},
+	}
+
+	checks := checkVariants(context.Background(), orchestrator, "stripe", 3)
+	require.Len(t, checks, 3)
+	require.Equal(t, "strlpe.com", checks[0].Name)
+	require.Equal(t, "homoglyph", checks[0].Kind)
+	require.Equal(t, core.AvailabilityAvailable, checks[0].Result.Available)
+	require.Equal(t, "5tripe.com", checks[1].Name)
+	require.Equal(t, core.AvailabilityTaken, checks[1].Result.Available)
+
+	require.Empty(t, checkVariants(context.Background(), &engine.Orchestrator{}, "stripe", 3))
+}
+
+func TestResolveVariants(t *testing.T) {
+	parse := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		addVariantsFlags(cmd)
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	limit, err := resolveVariants(parse())
+	require.NoError(t, err)
+	require.Zero(t, limit)
+
+	limit, err = resolveVariants(parse("--variants"))
+	require.NoError(t, err)
+	require.Equal(t, 10, limit)
+
+	_, err = resolveVariants(parse("--variants", "--variants-limit", "0"))
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/checker"
+	"github.com/namelens/namelens/internal/core/engine"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+func addVerifyTakenFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("verify-taken", false, "Re-check available domains via DNS, an HTTP probe, and a second RDAP server, reporting disagreements")
+	cmd.Flags().Int("verify-sample", 0, "With --verify-taken, re-check a random sample of this many available domains (0 = all)")
+}
+
+// resolveVerifyTaken returns how many available verdicts to re-check: -1 for
+// none, 0 for all.
+func resolveVerifyTaken(cmd *cobra.Command) (int, error) {
+	enabled, err := cmd.Flags().GetBool("verify-taken")
+	if err != nil {
+		return 0, err
+	}
+	sample, err := cmd.Flags().GetInt("verify-sample")
+	if err != nil {
+		return 0, err
+	}
+	if sample < 0 {
+		return 0, errors.New("--verify-sample must be 0 or greater")
+	}
+	if !enabled {
+		if cmd.Flags().Changed("verify-sample") {
+			return 0, errors.New("--verify-sample requires --verify-taken")
+		}
+		return -1, nil
+	}
+	return sample, nil
+}
+
+// verifyAvailable re-checks available domain verdicts across batches, or a
+// random sample of them, and sets their Verification. It returns how many
+// were verified and how many of those were disputed.
+func verifyAvailable(ctx context.Context, orchestrator *engine.Orchestrator, batches []*core.BatchResult, sample int) (int, int) {
+	if sample < 0 || orchestrator == nil {
+		return 0, 0
+	}
+	domainChecker, ok := orchestrator.Checkers[core.CheckTypeDomain].(*checker.DomainChecker)
+	if !ok {
+		return 0, 0
+	}
+
+	candidates := verifyCandidates(batches, sample, rand.Shuffle)
+	disputed := 0
+	for _, result := range candidates {
+		if ctx.Err() != nil {
+			break
+		}
+		result.Verification = domainChecker.Verify(ctx, result)
+		if result.Verification == nil {
+			continue
+		}
+		if sources := result.Verification.Disputes(); len(sources) > 0 {
+			disputed++
+			observability.CLILogger.Warn("Available verdict disputed by independent sources",
+				zap.String("domain", result.Name),
+				zap.String("sources", strings.Join(sources, ", ")),
+			)
+		}
+	}
+	observability.CLILogger.Info("Verified available domain verdicts",
+		zap.Int("verified", len(candidates)),
+		zap.Int("disputed", disputed),
+	)
+	return len(candidates), disputed
+}
+
+// verifyCandidates returns the available domain results to re-check: all of
+// them, or sample of them picked with shuffle.
+func verifyCandidates(batches []*core.BatchResult, sample int, shuffle func(n int, swap func(i, j int))) []*core.CheckResult {
+	var candidates []*core.CheckResult
+	for _, batch := range batches {
+		if batch == nil {
+			continue
+		}
+		for _, result := range batch.Results {
+			if result != nil && result.CheckType == core.CheckTypeDomain && result.Available == core.AvailabilityAvailable {
+				candidates = append(candidates, result)
+			}
+		}
+	}
+	if sample > 0 && sample < len(candidates) {
+		shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		candidates = candidates[:sample]
+	}
+	return candidates
+}
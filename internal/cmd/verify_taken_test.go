@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestVerifyCandidates(t *testing.T) {
+	batches := []*core.BatchResult{
+		{Results: []*core.CheckResult{
+			{Name: "acme.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+			{Name: "acme.io", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken},
+			{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+		}},
+		nil,
+		{Results: []*core.CheckResult{
+			{Name: "zentro.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+			{Name: "zentro.dev", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+		}},
+	}
+	noShuffle := func(int, func(i, j int)) {}
+	reverse := func(n int, swap func(i, j int)) {
+		for i := 0; i < n/2; i++ {
+			swap(i, n-1-i)
+		}
+	}
+
+	names := func(results []*core.CheckResult) []string {
+		out := make([]string, 0, len(results))
+		for _, result := range results {
+			out = append(out, result.Name)
+		}
+		return out
+	}
+
+	require.Equal(t, []string{"acme.com", "zentro.com", "zentro.dev"}, names(verifyCandidates(batches, 0, noShuffle)))
+	require.Equal(t, []string{"acme.com", "zentro.com", "zentro.dev"}, names(verifyCandidates(batches, 5, reverse)))
+	require.Equal(t, []string{"zentro.dev"}, names(verifyCandidates(batches, 1, reverse)))
+}
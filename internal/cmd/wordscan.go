@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/wordscan"
+)
+
+// wordScan is a resolved unfortunate-word scan.
+type wordScan struct {
+	scanner     *wordscan.Scanner
+	sensitivity string
+}
+
+func addWordScanFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-word-scan", false, "Skip the scan for unfortunate words hidden in names")
+}
+
+// resolveWordScan builds the word scanner from the word_scan settings and
+// flags. An explicit --sensitivity, on commands that have one, overrides the
+// configured sensitivity. It returns nil when the scan is off.
+func resolveWordScan(cmd *cobra.Command, cfg *config.Config) (*wordScan, error) {
+	skip, err := cmd.Flags().GetBool("no-word-scan")
+	if err != nil {
+		return nil, err
+	}
+	if skip || !cfg.WordScan.Enabled {
+		return nil, nil
+	}
+
+	sensitivity := strings.ToLower(strings.TrimSpace(cfg.WordScan.Sensitivity))
+	if flag := cmd.Flags().Lookup("sensitivity"); flag != nil && flag.Changed {
+		sensitivity = strings.ToLower(strings.TrimSpace(flag.Value.String()))
+	}
+	if sensitivity == "" {
+		sensitivity = wordscan.SensitivityStandard
+	}
+	if !wordscan.ValidSensitivity(sensitivity) {
+		return nil, fmt.Errorf("invalid word scan sensitivity %q (use minimal, standard, or strict)", sensitivity)
+	}
+
+	scanner, err := wordscan.NewScanner(cfg.WordScan.Languages)
+	if err != nil {
+		return nil, fmt.Errorf("loading word lists: %w", err)
+	}
+	return &wordScan{scanner: scanner, sensitivity: sensitivity}, nil
+}
+
+// applyWordScan records the unfortunate words hidden in each name.
+func applyWordScan(batches []*core.BatchResult, scan *wordScan) {
+	if scan == nil {
+		return
+	}
+	for _, batch := range batches {
+		if batch != nil {
+			batch.UnfortunateWords = scan.scanner.Scan(batch.Name, scan.sensitivity)
+		}
+	}
+}
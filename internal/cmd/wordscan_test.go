@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func wordScanTestCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	addWordScanFlags(cmd)
+	cmd.Flags().String("sensitivity", "", "")
+	require.NoError(t, cmd.Flags().Parse(args))
+	return cmd
+}
+
+func TestResolveWordScan(t *testing.T) {
+	cfg := &config.Config{WordScan: config.WordScanConfig{Enabled: true, Sensitivity: "standard"}}
+
+	scan, err := resolveWordScan(wordScanTestCommand(t, "--no-word-scan"), cfg)
+	require.NoError(t, err)
+	require.Nil(t, scan)
+
+	scan, err = resolveWordScan(wordScanTestCommand(t), &config.Config{})
+	require.NoError(t, err)
+	require.Nil(t, scan)
+
+	scan, err = resolveWordScan(wordScanTestCommand(t), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, scan)
+
+	batches := []*core.BatchResult{{Name: "analytics"}, {Name: "stripe"}, nil}
+	applyWordScan(batches, scan)
+	require.NotEmpty(t, batches[0].UnfortunateWords)
+	require.Equal(t, "anal", batches[0].UnfortunateWords[0].Word)
+	require.Empty(t, batches[1].UnfortunateWords)
+
+	// --sensitivity overrides the configured level
+	scan, err = resolveWordScan(wordScanTestCommand(t, "--sensitivity", "minimal"), cfg)
+	require.NoError(t, err)
+	require.Equal(t, "minimal", scan.sensitivity)
+
+	_, err = resolveWordScan(wordScanTestCommand(t, "--sensitivity", "loud"), cfg)
+	require.ErrorContains(t, err, "invalid word scan sensitivity")
+
+	cfg.WordScan.Languages = []string{"xx"}
+	_, err = resolveWordScan(wordScanTestCommand(t), cfg)
+	require.ErrorContains(t, err, "loading word lists")
+}
@@ -18,14 +18,47 @@ type Config struct {
 	Domain  DomainConfig  `mapstructure:"domain"`
 	AILink  ailink.Config `mapstructure:"ailink"`
 	Expert  ExpertConfig  `mapstructure:"expert"`
+	Review  ReviewConfig  `mapstructure:"review"`
+	Report  ReportConfig  `mapstructure:"report"`
+	Pricing PricingConfig `mapstructure:"pricing"`
 	Logging LoggingConfig `mapstructure:"logging"`
 	Metrics MetricsConfig `mapstructure:"metrics"`
+	Tracing TracingConfig `mapstructure:"tracing"`
 	Health  HealthConfig  `mapstructure:"health"`
 	Debug   DebugConfig   `mapstructure:"debug"`
 	Workers int           `mapstructure:"workers"`
 
-	RateLimits      map[string]int `mapstructure:"rate_limits"`
-	RateLimitMargin float64        `mapstructure:"rate_limit_margin"`
+	Checkers  CheckersConfig  `mapstructure:"checkers"`
+	Network   NetworkConfig   `mapstructure:"network"`
+	Bootstrap BootstrapConfig `mapstructure:"bootstrap"`
+	Redaction RedactionConfig `mapstructure:"redaction"`
+	// Telemetry sends opt-in anonymous usage stats; see namelens telemetry.
+	Telemetry TelemetryConfig `mapstructure:"telemetry"`
+	// Similarity flags names close to well-known or user-listed brands.
+	Similarity SimilarityConfig `mapstructure:"similarity"`
+	// WordScan flags unfortunate words hidden in names.
+	WordScan WordScanConfig `mapstructure:"word_scan"`
+	// Offline answers checks from cache only and skips AI calls without a
+	// cached response; the --offline flag overrides it.
+	Offline bool `mapstructure:"offline"`
+
+	// Markets lists target market country codes (e.g. US, DE, JP) that
+	// expand into locales, keyboards, ccTLDs, and sentiment languages; the
+	// --markets flag overrides it.
+	Markets []string `mapstructure:"markets"`
+
+	RateLimits      map[string]int    `mapstructure:"rate_limits"`
+	RateLimitMargin float64           `mapstructure:"rate_limit_margin"`
+	RateLimiter     RateLimiterConfig `mapstructure:"rate_limiter"`
+}
+
+// RateLimiterConfig selects where per-endpoint rate limit state lives:
+// "store" (the default) or "redis", which shares limits across server
+// replicas.
+type RateLimiterConfig struct {
+	Backend string `mapstructure:"backend"`
+	// URL is the Redis URL for the redis backend.
+	URL string `mapstructure:"url"`
 }
 
 // ServerConfig contains HTTP server configuration
@@ -36,6 +69,54 @@ type ServerConfig struct {
 	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
 	IdleTimeout     time.Duration `mapstructure:"idle_timeout"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	// APIKeys are control plane API keys scoped to a role (read-only, check,
+	// or admin). NAMELENS_CONTROL_PLANE_API_KEY remains an admin key.
+	APIKeys []APIKeyConfig `mapstructure:"api_keys"`
+	// Share controls the anonymous share links of serve mode.
+	Share ShareConfig `mapstructure:"share"`
+	// ReadOnly serves only cached and stored data: lookups and AI analyses
+	// never reach the network and mutating endpoints are rejected.
+	ReadOnly bool `mapstructure:"read_only"`
+	// CORS lets browser frontends on other origins call the API.
+	CORS CORSConfig `mapstructure:"cors"`
+	// MaxBodyBytes caps request bodies; larger requests get 413. Zero or
+	// less disables the limit.
+	MaxBodyBytes int64 `mapstructure:"max_body_bytes"`
+}
+
+// CORSConfig controls cross-origin requests from browsers. AllowedOrigins
+// are exact origins such as https://app.example.com, or "*" for any; when
+// empty no CORS headers are sent and browsers keep to same-origin requests.
+type CORSConfig struct {
+	AllowedOrigins []string      `mapstructure:"allowed_origins"`
+	AllowedMethods []string      `mapstructure:"allowed_methods"`
+	AllowedHeaders []string      `mapstructure:"allowed_headers"`
+	MaxAge         time.Duration `mapstructure:"max_age"`
+}
+
+// ShareConfig controls share links: read-only snapshots of results behind a
+// signed, expiring URL. Secret signs the links and supports ${ENV}
+// expansion; when empty a random secret is used, so links stop working when
+// the server restarts. BaseURL is the public URL of the server for links
+// behind a proxy; empty uses the request's host.
+type ShareConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Secret  string        `mapstructure:"secret"`
+	TTL     time.Duration `mapstructure:"ttl"`
+	MaxTTL  time.Duration `mapstructure:"max_ttl"`
+	BaseURL string        `mapstructure:"base_url"`
+}
+
+// APIKeyConfig is a control plane API key and its role. Key supports ${ENV}
+// expansion so keys can stay out of the config file.
+type APIKeyConfig struct {
+	Name string `mapstructure:"name"`
+	Key  string `mapstructure:"key"`
+	Role string `mapstructure:"role"`
+	// RateLimit caps live lookups per minute; zero is unlimited.
+	RateLimit int `mapstructure:"rate_limit"`
+	// DailyQuota caps live lookups per UTC day; zero is unlimited.
+	DailyQuota int `mapstructure:"daily_quota"`
 }
 
 // StoreConfig contains database configuration for libsql/Turso
@@ -44,6 +125,18 @@ type StoreConfig struct {
 	Path      string `mapstructure:"path"`
 	URL       string `mapstructure:"url"`
 	AuthToken string `mapstructure:"auth_token"`
+	// BusyTimeout is how long a local SQLite connection waits for a write
+	// lock held by another connection before failing.
+	BusyTimeout time.Duration   `mapstructure:"busy_timeout"`
+	Pool        StorePoolConfig `mapstructure:"pool"`
+}
+
+// StorePoolConfig sizes the store's connection pool. Zero values use the
+// store package defaults.
+type StorePoolConfig struct {
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
 }
 
 // CacheConfig contains result cache TTL configuration.
@@ -51,12 +144,51 @@ type CacheConfig struct {
 	AvailableTTL time.Duration `mapstructure:"available_ttl"`
 	TakenTTL     time.Duration `mapstructure:"taken_ttl"`
 	ErrorTTL     time.Duration `mapstructure:"error_ttl"`
+	// StaleWhileRevalidate serves expired available/taken results for this
+	// long while they refresh in the background; zero disables it.
+	StaleWhileRevalidate time.Duration `mapstructure:"stale_while_revalidate"`
+	// Memory is serve's in-process LRU in front of the cache table.
+	Memory MemoryCacheConfig `mapstructure:"memory"`
+}
+
+// MemoryCacheConfig sizes the in-memory LRU of cached check results used by
+// serve. Size 0 disables it; TTL caps how long an entry is kept, so changes
+// made by other processes show up within it.
+type MemoryCacheConfig struct {
+	Size int           `mapstructure:"size"`
+	TTL  time.Duration `mapstructure:"ttl"`
 }
 
 // DomainConfig contains domain checker configuration.
 type DomainConfig struct {
 	WhoisFallback WhoisFallbackConfig `mapstructure:"whois_fallback"`
 	DNSFallback   DNSFallbackConfig   `mapstructure:"dns_fallback"`
+	Alternatives  AlternativesConfig  `mapstructure:"alternatives"`
+	RDAPRetry     RDAPRetryConfig     `mapstructure:"rdap_retry"`
+	DropLists     DropListConfig      `mapstructure:"drop_lists"`
+}
+
+// DropListConfig configures pending-delete lists that taken domains are
+// looked up in. Sources maps a list name to a file path or http(s) URL.
+type DropListConfig struct {
+	Enabled bool              `mapstructure:"enabled"`
+	Sources map[string]string `mapstructure:"sources"`
+	Refresh time.Duration     `mapstructure:"refresh"`
+}
+
+// RetryPolicyConfig configures retries with exponential backoff and jitter.
+type RetryPolicyConfig struct {
+	MaxAttempts int           `mapstructure:"max_attempts"`
+	BaseDelay   time.Duration `mapstructure:"base_delay"`
+	MaxDelay    time.Duration `mapstructure:"max_delay"`
+	Jitter      float64       `mapstructure:"jitter"`
+}
+
+// RDAPRetryConfig configures retries of transient RDAP failures. Endpoints
+// overrides the policy per server host.
+type RDAPRetryConfig struct {
+	RetryPolicyConfig `mapstructure:",squash"`
+	Endpoints         map[string]RetryPolicyConfig `mapstructure:"endpoints"`
 }
 
 // WhoisFallbackConfig configures RDAP fallback behavior.
@@ -69,13 +201,114 @@ type WhoisFallbackConfig struct {
 	Servers           map[string]string `mapstructure:"servers"`
 	AvailablePatterns []string          `mapstructure:"available_patterns"`
 	TakenPatterns     []string          `mapstructure:"taken_patterns"`
+	// MaxPerServer caps concurrent queries to one WHOIS server, and Delay
+	// spaces them out; ServerDelays overrides Delay per server host.
+	MaxPerServer int                      `mapstructure:"max_per_server"`
+	Delay        time.Duration            `mapstructure:"delay"`
+	ServerDelays map[string]time.Duration `mapstructure:"server_delays"`
 }
 
 // DNSFallbackConfig configures DNS-based fallback checks.
 type DNSFallbackConfig struct {
-	Enabled  bool          `mapstructure:"enabled"`
-	CacheTTL time.Duration `mapstructure:"cache_ttl"`
-	Timeout  time.Duration `mapstructure:"timeout"`
+	Enabled        bool          `mapstructure:"enabled"`
+	CacheTTL       time.Duration `mapstructure:"cache_ttl"`
+	Timeout        time.Duration `mapstructure:"timeout"`
+	Records        []string      `mapstructure:"records"`
+	DetectWildcard bool          `mapstructure:"detect_wildcard"`
+	// Resolvers overrides network.resolvers for the DNS fallback only.
+	Resolvers []string `mapstructure:"resolvers"`
+}
+
+// CheckersConfig configures additional availability checkers.
+type CheckersConfig struct {
+	// Custom maps a registry name, usable in profiles and --registries, to an
+	// HTTP plugin checker.
+	Custom map[string]CustomCheckerConfig `mapstructure:"custom"`
+	// Catalogs maps a registry name to an internal service catalog; names
+	// that collide with a catalog entry are reported as taken.
+	Catalogs map[string]CatalogConfig `mapstructure:"catalogs"`
+}
+
+// CatalogConfig points at an internal service catalog: Backstage
+// catalog-info YAML files or a CSV, as a file or a directory.
+type CatalogConfig struct {
+	Path string `mapstructure:"path"`
+	// Format is "backstage" or "csv"; empty picks by file extension.
+	Format string `mapstructure:"format"`
+}
+
+// CustomCheckerConfig describes an HTTP endpoint that reports whether a name is
+// taken. "{name}" in URL and Body is replaced with the checked name.
+type CustomCheckerConfig struct {
+	URL             string            `mapstructure:"url"`
+	Method          string            `mapstructure:"method"`
+	Headers         map[string]string `mapstructure:"headers"`
+	Body            string            `mapstructure:"body"`
+	AvailableStatus []int             `mapstructure:"available_status"`
+	TakenStatus     []int             `mapstructure:"taken_status"`
+	NamePattern     string            `mapstructure:"name_pattern"`
+	Timeout         time.Duration     `mapstructure:"timeout"`
+}
+
+// NetworkConfig contains settings shared by network lookups.
+type NetworkConfig struct {
+	// Resolvers replaces the system resolver for the DNS fallback and
+	// connectivity checks: IPs or host:port for plain DNS, tls:// DoT
+	// servers, or https:// DoH endpoints. Empty uses the system resolver.
+	Resolvers []string `mapstructure:"resolvers"`
+	// IPStrategy selects how outbound checker connections pick an address
+	// family: race (Happy Eyeballs), prefer_ipv4, or prefer_ipv6.
+	IPStrategy string `mapstructure:"ip_strategy"`
+	// Proxy routes checker connections: empty follows HTTP_PROXY,
+	// HTTPS_PROXY, and NO_PROXY; "direct" ignores them; otherwise an http,
+	// https, socks5, or socks5h URL. WHOIS connections only use SOCKS5.
+	Proxy string `mapstructure:"proxy"`
+	// CheckerProxies overrides Proxy per checker kind: rdap, whois,
+	// registry, handle, or http.
+	CheckerProxies map[string]string `mapstructure:"checker_proxies"`
+}
+
+// BootstrapConfig controls refreshing of the cached IANA RDAP bootstrap data.
+type BootstrapConfig struct {
+	// AutoRefresh updates the data when it is older than MaxAge: in the
+	// background for CLI commands and periodically for the server.
+	AutoRefresh bool          `mapstructure:"auto_refresh"`
+	MaxAge      time.Duration `mapstructure:"max_age"`
+}
+
+// RedactionConfig controls scrubbing of credentials from command output,
+// AILink traces, evidence captures, and logs. Configured API keys and tokens
+// are always redacted when enabled; Patterns adds regular expressions.
+type RedactionConfig struct {
+	Enabled  bool     `mapstructure:"enabled"`
+	Patterns []string `mapstructure:"patterns"`
+}
+
+// SimilarityConfig controls the local near-collision check of names against
+// a bundled brand list plus Brands and the lines of BrandsFile.
+type SimilarityConfig struct {
+	Enabled    bool     `mapstructure:"enabled"`
+	Brands     []string `mapstructure:"brands"`
+	BrandsFile string   `mapstructure:"brands_file"`
+	MinScore   int      `mapstructure:"min_score"`
+}
+
+// WordScanConfig controls the offline scan of names for unfortunate words in
+// the bundled word lists. Languages are codes or locales; empty scans every
+// list. Sensitivity is minimal, standard, or strict.
+type WordScanConfig struct {
+	Enabled     bool     `mapstructure:"enabled"`
+	Languages   []string `mapstructure:"languages"`
+	Sensitivity string   `mapstructure:"sensitivity"`
+}
+
+// AlternativesConfig controls domain suggestions shown when the .com is taken.
+type AlternativesConfig struct {
+	Enabled  bool     `mapstructure:"enabled"`
+	TLDs     []string `mapstructure:"tlds"`
+	Prefixes []string `mapstructure:"prefixes"`
+	Suffixes []string `mapstructure:"suffixes"`
+	Max      int      `mapstructure:"max"`
 }
 
 // ExpertConfig contains NameLens expert feature settings.
@@ -87,6 +320,50 @@ type ExpertConfig struct {
 	DefaultPrompt string `mapstructure:"default_prompt"`
 }
 
+// ReviewConfig contains settings for the review command.
+type ReviewConfig struct {
+	// TemplatesDir holds custom review templates (*.yaml). Empty uses
+	// review-templates/ under the user config directory.
+	TemplatesDir string `mapstructure:"templates_dir"`
+}
+
+// ReportConfig contains settings for HTML reports.
+type ReportConfig struct {
+	// PDFCommand converts an HTML report to PDF for --pdf, with {html} and
+	// {pdf} replaced by the file paths. Empty looks for wkhtmltopdf or a
+	// headless Chromium.
+	PDFCommand string `mapstructure:"pdf_command"`
+}
+
+// PricingConfig configures registrar price quotes for available domains.
+type PricingConfig struct {
+	// Enabled prices available domains on every check and batch run; the
+	// --prices flag turns it on for one run.
+	Enabled bool `mapstructure:"enabled"`
+	// PremiumThreshold flags quotes whose registration price is at or above
+	// it as premium, in the quote's currency; 0 disables the threshold.
+	PremiumThreshold float64 `mapstructure:"premium_threshold"`
+	// Providers maps a provider name to a pricing API. Providers are tried
+	// in name order until one returns a quote.
+	Providers map[string]PriceProviderConfig `mapstructure:"providers"`
+}
+
+// PriceProviderConfig describes a registrar pricing API. "{domain}",
+// "{name}", and "{tld}" in URL and Body are replaced; the field settings are
+// dot-separated paths into the JSON response.
+type PriceProviderConfig struct {
+	URL           string            `mapstructure:"url"`
+	Method        string            `mapstructure:"method"`
+	Headers       map[string]string `mapstructure:"headers"`
+	Body          string            `mapstructure:"body"`
+	PriceField    string            `mapstructure:"price_field"`
+	RenewalField  string            `mapstructure:"renewal_field"`
+	PremiumField  string            `mapstructure:"premium_field"`
+	CurrencyField string            `mapstructure:"currency_field"`
+	Currency      string            `mapstructure:"currency"`
+	Timeout       time.Duration     `mapstructure:"timeout"`
+}
+
 // LoggingConfig contains logging configuration
 // Supports progressive logging profiles per Fulmen Forge Workhorse Standard:
 // - SIMPLE: Console output only, minimal configuration (CLI tools)
@@ -101,6 +378,21 @@ type LoggingConfig struct {
 	// Valid values: SIMPLE, STRUCTURED, ENTERPRISE
 	// See: gofulmen/docs/crucible-go/standards/observability/logging.md
 	Profile string `mapstructure:"profile"`
+
+	// Audit records every outbound request to an NDJSON file
+	Audit AuditLogConfig `mapstructure:"audit"`
+}
+
+// AuditLogConfig controls the request audit log: one NDJSON line per
+// outbound RDAP, WHOIS, DNS, registry, handle, or AI request, with its URL,
+// status, latency, and rate-limit state.
+type AuditLogConfig struct {
+	// Enabled controls whether requests are recorded
+	Enabled bool `mapstructure:"enabled"`
+
+	// Path is the file entries are appended to; empty uses audit.ndjson in
+	// the data directory
+	Path string `mapstructure:"path"`
 }
 
 // MetricsConfig contains Prometheus metrics configuration
@@ -113,10 +405,53 @@ type MetricsConfig struct {
 	Port int `mapstructure:"port"`
 }
 
+// TracingConfig contains OpenTelemetry tracing configuration. Spans cover
+// orchestrator checks, each checker, RDAP/WHOIS/DNS requests, and AILink
+// completions, and are exported over OTLP.
+type TracingConfig struct {
+	// Enabled controls whether spans are exported
+	Enabled bool `mapstructure:"enabled"`
+
+	// Endpoint is the OTLP collector as host:port or a URL; empty uses
+	// OTEL_EXPORTER_OTLP_ENDPOINT or the exporter default
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Protocol is the OTLP transport: http/protobuf or grpc
+	Protocol string `mapstructure:"protocol"`
+
+	// Insecure disables TLS to the collector
+	Insecure bool `mapstructure:"insecure"`
+
+	// Headers are sent with every export, e.g. collector credentials; values
+	// support ${ENV} expansion
+	Headers map[string]string `mapstructure:"headers"`
+
+	// SampleRatio is the fraction of traces recorded, from 0 to 1
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+// TelemetryConfig controls where opt-in usage stats are sent. Recording is
+// turned on by namelens telemetry on, not by config.
+type TelemetryConfig struct {
+	// Endpoint receives queued events as a JSON POST; empty keeps them local
+	Endpoint string `mapstructure:"endpoint"`
+
+	// FlushInterval is the minimum time between automatic flushes at the
+	// end of a command; 0 only flushes on namelens telemetry flush
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
 // HealthConfig contains health check configuration
 type HealthConfig struct {
 	// Enabled controls whether health endpoints are exposed
 	Enabled bool `mapstructure:"enabled"`
+	// RequireWarmup keeps the readiness probe failing in serve mode until RDAP
+	// bootstrap data is present and a check of WarmupCanary succeeds
+	RequireWarmup bool `mapstructure:"require_warmup"`
+	// WarmupCanary is a registered domain used for the warm-up check
+	WarmupCanary string `mapstructure:"warmup_canary"`
+	// WarmupRetryInterval is the wait between failed warm-up attempts
+	WarmupRetryInterval time.Duration `mapstructure:"warmup_retry_interval"`
 }
 
 // DebugConfig contains debug and profiling configuration
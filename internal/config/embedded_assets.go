@@ -15,6 +15,9 @@ var (
 	//go:embed embedded/schemas/namelens/v0/config.schema.json
 	embeddedConfigSchemaJSON []byte
 
+	//go:embed embedded/schemas/namelens/v0/profile.schema.json
+	embeddedProfileSchemaJSON []byte
+
 	standaloneAssetsOnce sync.Once
 	standaloneRoot       string
 	standaloneRootErr    error
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gfconfig "github.com/fulmenhq/gofulmen/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Config layers, lowest precedence first.
+const (
+	SourceDefault = "default"
+	SourceUser    = "user"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// ExplainedValue is one effective config value and the layer that set it.
+type ExplainedValue struct {
+	Key    string `json:"key"`
+	Value  any    `json:"value"`
+	Source string `json:"source"`
+	// Origin is the file or environment variable behind the value.
+	Origin string `json:"origin,omitempty"`
+}
+
+// Explanation is the merged effective config with the provenance of each
+// value, keyed by dotted path.
+type Explanation struct {
+	DefaultsFile string           `json:"defaults_file"`
+	UserFile     string           `json:"user_file,omitempty"`
+	Values       []ExplainedValue `json:"values"`
+}
+
+// Explain merges the config layers like Load and reports which layer set each
+// leaf value. Lists are leaves: a layer that sets one replaces it whole.
+func Explain(ctx context.Context, runtimeOverrides ...map[string]any) (*Explanation, error) {
+	opts, err := layeredOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	envOverrides, err := loadEnvOverrides()
+	if err != nil {
+		return nil, err
+	}
+
+	merged, _, err := gfconfig.LoadLayeredConfig(opts, append([]map[string]any{envOverrides}, runtimeOverrides...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load layered config: %w", err)
+	}
+
+	explanation := &Explanation{
+		DefaultsFile: filepath.Join(opts.DefaultsRoot, opts.Category, opts.Version, opts.DefaultsFile),
+	}
+	var user map[string]any
+	for _, path := range opts.UserPaths {
+		if _, statErr := os.Stat(path); statErr != nil {
+			continue
+		}
+		user, err = readConfigLayer(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		explanation.UserFile = path
+		break
+	}
+
+	userKeys := flattenConfig(user)
+	envKeys := flattenConfig(envOverrides)
+	flagKeys := map[string]any{}
+	for _, override := range runtimeOverrides {
+		for key, value := range flattenConfig(override) {
+			flagKeys[key] = value
+		}
+	}
+	envNames := envVarNames()
+
+	effective := flattenConfig(merged)
+	keys := make([]string, 0, len(effective))
+	for key := range effective {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := ExplainedValue{Key: key, Value: effective[key], Source: SourceDefault, Origin: explanation.DefaultsFile}
+		switch {
+		case hasKey(flagKeys, key):
+			value.Source, value.Origin = SourceFlag, ""
+		case hasKey(envKeys, key):
+			value.Source, value.Origin = SourceEnv, envNames[key]
+		case hasKey(userKeys, key):
+			value.Source, value.Origin = SourceUser, explanation.UserFile
+		}
+		explanation.Values = append(explanation.Values, value)
+	}
+	return explanation, nil
+}
+
+func hasKey(values map[string]any, key string) bool {
+	_, ok := values[key]
+	return ok
+}
+
+// readConfigLayer reads a YAML or JSON config file into a map.
+func readConfigLayer(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- config path from XDG discovery
+	if err != nil {
+		return nil, err
+	}
+	layer := map[string]any{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &layer)
+	} else {
+		err = yaml.Unmarshal(data, &layer)
+	}
+	return layer, err
+}
+
+// flattenConfig maps each leaf of a nested config map to its dotted path.
+// Empty maps are kept as leaves so that keys like servers: {} still show.
+func flattenConfig(values map[string]any) map[string]any {
+	flat := map[string]any{}
+	var walk func(prefix string, value any)
+	walk = func(prefix string, value any) {
+		nested, ok := value.(map[string]any)
+		if !ok || len(nested) == 0 {
+			flat[prefix] = value
+			return
+		}
+		for key, child := range nested {
+			walk(prefix+"."+key, child)
+		}
+	}
+	for key, value := range values {
+		walk(key, value)
+	}
+	return flat
+}
+
+// envVarNames maps dotted config paths to the environment variables set for
+// them. Dynamic AILink variables are matched by their prefix.
+func envVarNames() map[string]string {
+	names := map[string]string{}
+	for _, spec := range getEnvSpecs() {
+		if _, ok := os.LookupEnv(spec.Name); ok {
+			names[strings.Join(spec.Path, ".")] = spec.Name
+		}
+	}
+	if appIdentity == nil {
+		return names
+	}
+	prefix := appIdentity.EnvPrefix
+	if !strings.HasSuffix(prefix, "_") {
+		prefix += "_"
+	}
+	if _, ok := os.LookupEnv(prefix + "RATE_LIMIT_MARGIN"); ok {
+		names["rate_limit_margin"] = prefix + "RATE_LIMIT_MARGIN"
+	}
+	dynamic := map[string]any{}
+	applyAILinkDynamicEnvOverrides(prefix, dynamic)
+	for key := range flattenConfig(dynamic) {
+		if _, ok := names[key]; !ok {
+			names[key] = prefix + "AILINK_*"
+		}
+	}
+	return names
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	userFile := filepath.Join(configHome, "namelens", "config.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(userFile), 0o755))
+	require.NoError(t, os.WriteFile(userFile, []byte(`domain:
+  whois_fallback:
+    enabled: false
+    tlds: [io]
+server:
+  port: 9000
+`), 0o600))
+	t.Setenv("NAMELENS_LOG_LEVEL", "warn")
+	t.Setenv("NAMELENS_PORT", "9100")
+
+	explanation, err := Explain(context.Background(), map[string]any{"server": map[string]any{"host": "0.0.0.0"}})
+	require.NoError(t, err)
+	require.Equal(t, userFile, explanation.UserFile)
+
+	values := map[string]ExplainedValue{}
+	for _, value := range explanation.Values {
+		values[value.Key] = value
+	}
+
+	require.Equal(t, ExplainedValue{Key: "domain.whois_fallback.enabled", Value: false, Source: SourceUser, Origin: userFile}, values["domain.whois_fallback.enabled"])
+	require.Equal(t, SourceUser, values["domain.whois_fallback.tlds"].Source)
+	require.Equal(t, ExplainedValue{Key: "logging.level", Value: "warn", Source: SourceEnv, Origin: "NAMELENS_LOG_LEVEL"}, values["logging.level"])
+	require.Equal(t, SourceEnv, values["server.port"].Source)
+	require.Equal(t, ExplainedValue{Key: "server.host", Value: "0.0.0.0", Source: SourceFlag}, values["server.host"])
+
+	timeout := values["domain.whois_fallback.timeout"]
+	require.Equal(t, SourceDefault, timeout.Source)
+	require.Equal(t, explanation.DefaultsFile, timeout.Origin)
+}
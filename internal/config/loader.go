@@ -98,6 +98,7 @@ const (
 	EnvString = gfconfig.EnvString
 	EnvInt    = gfconfig.EnvInt
 	EnvBool   = gfconfig.EnvBool
+	EnvFloat  = gfconfig.EnvFloat
 )
 
 // Load loads configuration using the three-layer pattern:
@@ -107,59 +108,14 @@ const (
 //
 // This function is safe to call multiple times (e.g., for config reload)
 func Load(ctx context.Context, runtimeOverrides ...map[string]any) (*Config, error) {
-	// Get app identity if not already loaded
-	if appIdentity == nil {
-		identity, err := appid.Get(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load app identity: %w", err)
-		}
-		appIdentity = identity
-	}
-
-	// Resolve root for config defaults/schema assets.
-	// Prefer repository assets when running in-tree; fall back to embedded assets
-	// when running a standalone binary outside a repository.
-	assetRoot, err := resolveConfigAssetRoot()
+	opts, err := layeredOptions(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve config assets: %w", err)
-	}
-
-	// Build layered config options
-	// NameLens uses its own schema located in schemas/namelens/
-	// Defaults are in config/namelens/v0/namelens-defaults.yaml
-	// Using absolute paths ensures this works from any working directory
-	catalog := schema.NewCatalog(filepath.Join(assetRoot, "schemas"))
-	opts := gfconfig.LayeredConfigOptions{
-		Category:     "namelens",
-		Version:      "v0",
-		DefaultsFile: "namelens-defaults.yaml",
-		SchemaID:     "namelens/v0/config",
-		UserPaths:    getUserConfigPaths(),
-		Catalog:      catalog,
-		DefaultsRoot: filepath.Join(assetRoot, "config"), // Absolute path for Layer 1 defaults
+		return nil, err
 	}
 
-	// Load environment variable overrides
-	envOverrides, err := gfconfig.LoadEnvOverrides(getEnvSpecs())
+	envOverrides, err := loadEnvOverrides()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load environment overrides: %w", err)
-	}
-
-	if appIdentity != nil {
-		prefix := appIdentity.EnvPrefix
-		if !strings.HasSuffix(prefix, "_") {
-			prefix += "_"
-		}
-
-		applyAILinkDynamicEnvOverrides(prefix, envOverrides)
-
-		if value := strings.TrimSpace(os.Getenv(prefix + "RATE_LIMIT_MARGIN")); value != "" {
-			margin, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				return nil, fmt.Errorf("invalid rate limit margin: %w", err)
-			}
-			envOverrides["rate_limit_margin"] = margin
-		}
+		return nil, err
 	}
 
 	// Combine environment overrides with runtime overrides
@@ -202,12 +158,78 @@ func Load(ctx context.Context, runtimeOverrides ...map[string]any) (*Config, err
 		cfg.Store.Path = defaultStorePath()
 	}
 
+	if err := configureRedaction(cfg); err != nil {
+		return nil, fmt.Errorf("invalid redaction config: %w", err)
+	}
+
 	// Store the loaded config
 	setConfig(cfg)
 
 	return cfg, nil
 }
 
+// layeredOptions returns the options for the three config layers: crucible
+// defaults, the first user config file found, and overrides.
+func layeredOptions(ctx context.Context) (gfconfig.LayeredConfigOptions, error) {
+	// Get app identity if not already loaded
+	if appIdentity == nil {
+		identity, err := appid.Get(ctx)
+		if err != nil {
+			return gfconfig.LayeredConfigOptions{}, fmt.Errorf("failed to load app identity: %w", err)
+		}
+		appIdentity = identity
+	}
+
+	// Resolve root for config defaults/schema assets.
+	// Prefer repository assets when running in-tree; fall back to embedded assets
+	// when running a standalone binary outside a repository.
+	assetRoot, err := resolveConfigAssetRoot()
+	if err != nil {
+		return gfconfig.LayeredConfigOptions{}, fmt.Errorf("failed to resolve config assets: %w", err)
+	}
+
+	// Build layered config options
+	// NameLens uses its own schema located in schemas/namelens/
+	// Defaults are in config/namelens/v0/namelens-defaults.yaml
+	// Using absolute paths ensures this works from any working directory
+	catalog := schema.NewCatalog(filepath.Join(assetRoot, "schemas"))
+	return gfconfig.LayeredConfigOptions{
+		Category:     "namelens",
+		Version:      "v0",
+		DefaultsFile: "namelens-defaults.yaml",
+		SchemaID:     "namelens/v0/config",
+		UserPaths:    getUserConfigPaths(),
+		Catalog:      catalog,
+		DefaultsRoot: filepath.Join(assetRoot, "config"), // Absolute path for Layer 1 defaults
+	}, nil
+}
+
+// loadEnvOverrides returns the overrides set by environment variables.
+func loadEnvOverrides() (map[string]any, error) {
+	envOverrides, err := gfconfig.LoadEnvOverrides(getEnvSpecs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load environment overrides: %w", err)
+	}
+
+	if appIdentity != nil {
+		prefix := appIdentity.EnvPrefix
+		if !strings.HasSuffix(prefix, "_") {
+			prefix += "_"
+		}
+
+		applyAILinkDynamicEnvOverrides(prefix, envOverrides)
+
+		if value := strings.TrimSpace(os.Getenv(prefix + "RATE_LIMIT_MARGIN")); value != "" {
+			margin, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rate limit margin: %w", err)
+			}
+			envOverrides["rate_limit_margin"] = margin
+		}
+	}
+	return envOverrides, nil
+}
+
 // GetConfig returns the current application configuration (thread-safe)
 func GetConfig() *Config {
 	configMu.RLock()
@@ -266,16 +288,38 @@ func getEnvSpecs() []EnvVarSpec {
 		{Name: prefix + "WRITE_TIMEOUT", Path: []string{"server", "write_timeout"}, Type: EnvString},
 		{Name: prefix + "IDLE_TIMEOUT", Path: []string{"server", "idle_timeout"}, Type: EnvString},
 		{Name: prefix + "SHUTDOWN_TIMEOUT", Path: []string{"server", "shutdown_timeout"}, Type: EnvString},
+		{Name: prefix + "SHARE_ENABLED", Path: []string{"server", "share", "enabled"}, Type: EnvBool},
+		{Name: prefix + "SHARE_SECRET", Path: []string{"server", "share", "secret"}, Type: EnvString},
+		{Name: prefix + "SHARE_TTL", Path: []string{"server", "share", "ttl"}, Type: EnvString},
+		{Name: prefix + "SHARE_BASE_URL", Path: []string{"server", "share", "base_url"}, Type: EnvString},
+		{Name: prefix + "SERVER_READ_ONLY", Path: []string{"server", "read_only"}, Type: EnvBool},
+		{Name: prefix + "SERVER_CORS_ALLOWED_ORIGINS", Path: []string{"server", "cors", "allowed_origins"}, Type: EnvString},
+		{Name: prefix + "SERVER_MAX_BODY_BYTES", Path: []string{"server", "max_body_bytes"}, Type: EnvInt},
 
 		// Logging config (REQUIRED per Workhorse Standard)
 		{Name: prefix + "LOG_LEVEL", Path: []string{"logging", "level"}, Type: EnvString},
 		{Name: prefix + "LOG_PROFILE", Path: []string{"logging", "profile"}, Type: EnvString},
+		{Name: prefix + "LOG_AUDIT_ENABLED", Path: []string{"logging", "audit", "enabled"}, Type: EnvBool},
+		{Name: prefix + "LOG_AUDIT_PATH", Path: []string{"logging", "audit", "path"}, Type: EnvString},
 
 		// Store config
 		{Name: prefix + "DB_DRIVER", Path: []string{"store", "driver"}, Type: EnvString},
 		{Name: prefix + "DB_PATH", Path: []string{"store", "path"}, Type: EnvString},
 		{Name: prefix + "DB_URL", Path: []string{"store", "url"}, Type: EnvString},
 		{Name: prefix + "DB_AUTH_TOKEN", Path: []string{"store", "auth_token"}, Type: EnvString},
+		{Name: prefix + "DB_BUSY_TIMEOUT", Path: []string{"store", "busy_timeout"}, Type: EnvString},
+		{Name: prefix + "DB_MAX_OPEN_CONNS", Path: []string{"store", "pool", "max_open_conns"}, Type: EnvInt},
+		{Name: prefix + "DB_MAX_IDLE_CONNS", Path: []string{"store", "pool", "max_idle_conns"}, Type: EnvInt},
+		{Name: prefix + "DB_CONN_MAX_IDLE_TIME", Path: []string{"store", "pool", "conn_max_idle_time"}, Type: EnvString},
+
+		// Rate limiter config
+		{Name: prefix + "RATE_LIMITER_BACKEND", Path: []string{"rate_limiter", "backend"}, Type: EnvString},
+		{Name: prefix + "RATE_LIMITER_URL", Path: []string{"rate_limiter", "url"}, Type: EnvString},
+
+		// Cache config
+		{Name: prefix + "CACHE_STALE_WHILE_REVALIDATE", Path: []string{"cache", "stale_while_revalidate"}, Type: EnvString},
+		{Name: prefix + "CACHE_MEMORY_SIZE", Path: []string{"cache", "memory", "size"}, Type: EnvInt},
+		{Name: prefix + "CACHE_MEMORY_TTL", Path: []string{"cache", "memory", "ttl"}, Type: EnvString},
 
 		// Domain fallback config
 		{Name: prefix + "DOMAIN_WHOIS_FALLBACK_ENABLED", Path: []string{"domain", "whois_fallback", "enabled"}, Type: EnvBool},
@@ -283,10 +327,40 @@ func getEnvSpecs() []EnvVarSpec {
 		{Name: prefix + "DOMAIN_WHOIS_FALLBACK_REQUIRE_EXPLICIT", Path: []string{"domain", "whois_fallback", "require_explicit"}, Type: EnvBool},
 		{Name: prefix + "DOMAIN_WHOIS_FALLBACK_CACHE_TTL", Path: []string{"domain", "whois_fallback", "cache_ttl"}, Type: EnvString},
 		{Name: prefix + "DOMAIN_WHOIS_FALLBACK_TIMEOUT", Path: []string{"domain", "whois_fallback", "timeout"}, Type: EnvString},
+		{Name: prefix + "DOMAIN_WHOIS_FALLBACK_MAX_PER_SERVER", Path: []string{"domain", "whois_fallback", "max_per_server"}, Type: EnvInt},
+		{Name: prefix + "DOMAIN_WHOIS_FALLBACK_DELAY", Path: []string{"domain", "whois_fallback", "delay"}, Type: EnvString},
 
 		{Name: prefix + "DOMAIN_DNS_FALLBACK_ENABLED", Path: []string{"domain", "dns_fallback", "enabled"}, Type: EnvBool},
 		{Name: prefix + "DOMAIN_DNS_FALLBACK_CACHE_TTL", Path: []string{"domain", "dns_fallback", "cache_ttl"}, Type: EnvString},
 		{Name: prefix + "DOMAIN_DNS_FALLBACK_TIMEOUT", Path: []string{"domain", "dns_fallback", "timeout"}, Type: EnvString},
+		{Name: prefix + "DOMAIN_DNS_FALLBACK_DETECT_WILDCARD", Path: []string{"domain", "dns_fallback", "detect_wildcard"}, Type: EnvBool},
+		{Name: prefix + "DOMAIN_DNS_FALLBACK_RESOLVERS", Path: []string{"domain", "dns_fallback", "resolvers"}, Type: EnvString},
+		{Name: prefix + "DOMAIN_ALTERNATIVES_ENABLED", Path: []string{"domain", "alternatives", "enabled"}, Type: EnvBool},
+		{Name: prefix + "DOMAIN_RDAP_RETRY_MAX_ATTEMPTS", Path: []string{"domain", "rdap_retry", "max_attempts"}, Type: EnvInt},
+		{Name: prefix + "DOMAIN_RDAP_RETRY_BASE_DELAY", Path: []string{"domain", "rdap_retry", "base_delay"}, Type: EnvString},
+		{Name: prefix + "DOMAIN_DROP_LISTS_ENABLED", Path: []string{"domain", "drop_lists", "enabled"}, Type: EnvBool},
+		{Name: prefix + "DOMAIN_DROP_LISTS_REFRESH", Path: []string{"domain", "drop_lists", "refresh"}, Type: EnvString},
+
+		// Network config
+		{Name: prefix + "NETWORK_RESOLVERS", Path: []string{"network", "resolvers"}, Type: EnvString},
+		{Name: prefix + "NETWORK_IP_STRATEGY", Path: []string{"network", "ip_strategy"}, Type: EnvString},
+		{Name: prefix + "NETWORK_PROXY", Path: []string{"network", "proxy"}, Type: EnvString},
+
+		// Bootstrap config
+		{Name: prefix + "BOOTSTRAP_AUTO_REFRESH", Path: []string{"bootstrap", "auto_refresh"}, Type: EnvBool},
+		{Name: prefix + "BOOTSTRAP_MAX_AGE", Path: []string{"bootstrap", "max_age"}, Type: EnvString},
+
+		// Redaction config
+		{Name: prefix + "REDACTION_ENABLED", Path: []string{"redaction", "enabled"}, Type: EnvBool},
+
+		// Similarity config
+		{Name: prefix + "SIMILARITY_ENABLED", Path: []string{"similarity", "enabled"}, Type: EnvBool},
+		{Name: prefix + "SIMILARITY_BRANDS_FILE", Path: []string{"similarity", "brands_file"}, Type: EnvString},
+		{Name: prefix + "SIMILARITY_MIN_SCORE", Path: []string{"similarity", "min_score"}, Type: EnvInt},
+
+		// Word scan config
+		{Name: prefix + "WORD_SCAN_ENABLED", Path: []string{"word_scan", "enabled"}, Type: EnvBool},
+		{Name: prefix + "WORD_SCAN_SENSITIVITY", Path: []string{"word_scan", "sensitivity"}, Type: EnvString},
 
 		// AILink config
 		{Name: prefix + "AILINK_DEFAULT_PROVIDER", Path: []string{"ailink", "default_provider"}, Type: EnvString},
@@ -295,18 +369,41 @@ func getEnvSpecs() []EnvVarSpec {
 		{Name: prefix + "AILINK_PROMPTS_DIR", Path: []string{"ailink", "prompts_dir"}, Type: EnvString},
 		{Name: prefix + "AILINK_DEBUG_CAPTURE_RAW_ENABLED", Path: []string{"ailink", "debug", "capture_raw_enabled"}, Type: EnvBool},
 		{Name: prefix + "AILINK_DEBUG_CAPTURE_RAW_MAX_BYTES", Path: []string{"ailink", "debug", "capture_raw_max_bytes"}, Type: EnvInt},
+		{Name: prefix + "AILINK_QUOTAS_WORKSPACE", Path: []string{"ailink", "quotas", "workspace"}, Type: EnvString},
+		{Name: prefix + "AILINK_QUOTAS_DAILY", Path: []string{"ailink", "quotas", "default", "daily"}, Type: EnvInt},
+		{Name: prefix + "AILINK_QUOTAS_MONTHLY", Path: []string{"ailink", "quotas", "default", "monthly"}, Type: EnvInt},
+		{Name: prefix + "AILINK_BUDGET_MAX_CALLS_PER_RUN", Path: []string{"ailink", "budget", "max_calls_per_run"}, Type: EnvInt},
+		{Name: prefix + "AILINK_BUDGET_MAX_COST_PER_RUN_USD", Path: []string{"ailink", "budget", "max_cost_per_run_usd"}, Type: EnvFloat},
+		{Name: prefix + "AILINK_BUDGET_MAX_CALLS_PER_DAY", Path: []string{"ailink", "budget", "max_calls_per_day"}, Type: EnvInt},
+		{Name: prefix + "AILINK_BUDGET_MAX_COST_PER_DAY_USD", Path: []string{"ailink", "budget", "max_cost_per_day_usd"}, Type: EnvFloat},
 
 		// Expert feature config
 		{Name: prefix + "EXPERT_ENABLED", Path: []string{"expert", "enabled"}, Type: EnvBool},
 		{Name: prefix + "EXPERT_ROLE", Path: []string{"expert", "role"}, Type: EnvString},
 		{Name: prefix + "EXPERT_DEFAULT_PROMPT", Path: []string{"expert", "default_prompt"}, Type: EnvString},
 
+		// Review config
+		{Name: prefix + "REVIEW_TEMPLATES_DIR", Path: []string{"review", "templates_dir"}, Type: EnvString},
+		{Name: prefix + "REPORT_PDF_COMMAND", Path: []string{"report", "pdf_command"}, Type: EnvString},
+		{Name: prefix + "PRICING_ENABLED", Path: []string{"pricing", "enabled"}, Type: EnvBool},
+		{Name: prefix + "PRICING_PREMIUM_THRESHOLD", Path: []string{"pricing", "premium_threshold"}, Type: EnvFloat},
+
 		// Metrics config
 		{Name: prefix + "METRICS_ENABLED", Path: []string{"metrics", "enabled"}, Type: EnvBool},
 		{Name: prefix + "METRICS_PORT", Path: []string{"metrics", "port"}, Type: EnvInt},
+		{Name: prefix + "TRACING_ENABLED", Path: []string{"tracing", "enabled"}, Type: EnvBool},
+		{Name: prefix + "TRACING_ENDPOINT", Path: []string{"tracing", "endpoint"}, Type: EnvString},
+		{Name: prefix + "TRACING_PROTOCOL", Path: []string{"tracing", "protocol"}, Type: EnvString},
+		{Name: prefix + "TRACING_INSECURE", Path: []string{"tracing", "insecure"}, Type: EnvBool},
+		{Name: prefix + "TRACING_SAMPLE_RATIO", Path: []string{"tracing", "sample_ratio"}, Type: EnvFloat},
+		{Name: prefix + "TELEMETRY_ENDPOINT", Path: []string{"telemetry", "endpoint"}, Type: EnvString},
+		{Name: prefix + "TELEMETRY_FLUSH_INTERVAL", Path: []string{"telemetry", "flush_interval"}, Type: EnvString},
 
 		// Health config
 		{Name: prefix + "HEALTH_ENABLED", Path: []string{"health", "enabled"}, Type: EnvBool},
+		{Name: prefix + "HEALTH_REQUIRE_WARMUP", Path: []string{"health", "require_warmup"}, Type: EnvBool},
+		{Name: prefix + "HEALTH_WARMUP_CANARY", Path: []string{"health", "warmup_canary"}, Type: EnvString},
+		{Name: prefix + "HEALTH_WARMUP_RETRY_INTERVAL", Path: []string{"health", "warmup_retry_interval"}, Type: EnvString},
 
 		// Debug config
 		{Name: prefix + "DEBUG_ENABLED", Path: []string{"debug", "enabled"}, Type: EnvBool},
@@ -314,6 +411,12 @@ func getEnvSpecs() []EnvVarSpec {
 
 		// Workers
 		{Name: prefix + "WORKERS", Path: []string{"workers"}, Type: EnvInt},
+
+		// Offline mode
+		{Name: prefix + "OFFLINE", Path: []string{"offline"}, Type: EnvBool},
+
+		// Target markets (comma-separated country codes)
+		{Name: prefix + "MARKETS", Path: []string{"markets"}, Type: EnvString},
 	}
 }
 
@@ -345,12 +448,52 @@ func DefaultConfigPath() string {
 	return filepath.Join(configDir, "config.yaml")
 }
 
+// DefaultReviewTemplatesDir returns the XDG-compliant directory for custom review templates.
+func DefaultReviewTemplatesDir() string {
+	configName, _ := appNamesForPaths()
+	configDir := gfconfig.GetAppConfigDir(configName)
+	if strings.TrimSpace(configDir) == "" {
+		return ""
+	}
+	return filepath.Join(configDir, "review-templates")
+}
+
+// DefaultSchemasDir returns the XDG-compliant directory for user response
+// schemas, which are read from its user/ subdirectory.
+func DefaultSchemasDir() string {
+	configName, _ := appNamesForPaths()
+	configDir := gfconfig.GetAppConfigDir(configName)
+	if strings.TrimSpace(configDir) == "" {
+		return ""
+	}
+	return filepath.Join(configDir, "schemas")
+}
+
 // DefaultDataDir returns the XDG-compliant data directory for the app.
 func DefaultDataDir() string {
 	configName, _ := appNamesForPaths()
 	return gfconfig.GetAppDataDir(configName)
 }
 
+// DefaultAuditLogPath returns the XDG-compliant path to the request audit log.
+func DefaultAuditLogPath() string {
+	dataDir := DefaultDataDir()
+	if strings.TrimSpace(dataDir) == "" {
+		return "./audit.ndjson"
+	}
+	return filepath.Join(dataDir, "audit.ndjson")
+}
+
+// DefaultTelemetryDir returns the XDG-compliant directory for usage stats
+// consent and the event queue.
+func DefaultTelemetryDir() string {
+	dataDir := DefaultDataDir()
+	if strings.TrimSpace(dataDir) == "" {
+		return "./telemetry"
+	}
+	return filepath.Join(dataDir, "telemetry")
+}
+
 // DefaultCacheDir returns the XDG-compliant cache directory for the app.
 func DefaultCacheDir() string {
 	configName, _ := appNamesForPaths()
@@ -394,8 +537,19 @@ func applyAILinkDynamicEnvOverrides(prefix string, envOverrides map[string]any)
 	}
 }
 
+// configAssets are the files Load reads from the asset root. A project root
+// without them is some other repository, such as one a go install-ed binary
+// runs in.
+var configAssets = []string{
+	"config/namelens/v0/namelens-defaults.yaml",
+	"schemas/namelens/v0/config.schema.json",
+}
+
 func resolveConfigAssetRoot() (string, error) {
 	projectRoot, err := findProjectRoot()
+	if err == nil {
+		err = requireAssets(projectRoot, configAssets)
+	}
 	if err == nil {
 		return projectRoot, nil
 	}
@@ -408,6 +562,16 @@ func resolveConfigAssetRoot() (string, error) {
 	return fallbackRoot, nil
 }
 
+// requireAssets reports an error naming the first of paths missing under root.
+func requireAssets(root string, paths []string) error {
+	for _, path := range paths {
+		if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+			return fmt.Errorf("%s has no %s", root, path)
+		}
+	}
+	return nil
+}
+
 func applyAILinkRoutingOverride(envOverrides map[string]any, rawRole string, providerID string) {
 	role := toSlug(rawRole)
 	providerID = strings.TrimSpace(providerID)
@@ -49,6 +49,28 @@ func TestLoad(t *testing.T) {
 		require.NotNil(t, cfg)
 	})
 
+	// A go install-ed binary run inside another repository must not look for
+	// defaults in that repository.
+	t.Run("OtherRepository", func(t *testing.T) {
+		home := t.TempDir()
+		project := filepath.Join(home, "project")
+		require.NoError(t, os.MkdirAll(project, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(project, "go.mod"), []byte("module example.com/project\n"), 0o600))
+		t.Setenv("HOME", home)
+		t.Setenv("XDG_DATA_HOME", t.TempDir())
+		origDir, err := os.Getwd()
+		require.NoError(t, err)
+		require.NoError(t, os.Chdir(project))
+		t.Cleanup(func() {
+			_ = os.Chdir(origDir)
+			_ = CleanupStandaloneAssets()
+		})
+
+		cfg, err := Load(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 8080, cfg.Server.Port)
+	})
+
 	// Test basic config loading with defaults
 	t.Run("LoadDefaults", func(t *testing.T) {
 		t.Setenv("XDG_DATA_HOME", t.TempDir())
@@ -61,12 +83,32 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, "localhost", cfg.Server.Host)
 		assert.Equal(t, 8080, cfg.Server.Port)
 		assert.Equal(t, 30*time.Second, cfg.Server.ReadTimeout)
+		assert.Empty(t, cfg.Server.APIKeys)
 		assert.Equal(t, 30*time.Second, cfg.Server.WriteTimeout)
 		assert.Equal(t, 120*time.Second, cfg.Server.IdleTimeout)
 		assert.Equal(t, 10*time.Second, cfg.Server.ShutdownTimeout)
+		assert.True(t, cfg.Server.Share.Enabled)
+		assert.Empty(t, cfg.Server.Share.Secret)
+		assert.Equal(t, 168*time.Hour, cfg.Server.Share.TTL)
+		assert.Equal(t, 720*time.Hour, cfg.Server.Share.MaxTTL)
+		assert.False(t, cfg.Server.ReadOnly)
+		assert.Empty(t, cfg.Server.CORS.AllowedOrigins)
+		assert.Equal(t, []string{"GET", "POST"}, cfg.Server.CORS.AllowedMethods)
+		assert.Equal(t, 10*time.Minute, cfg.Server.CORS.MaxAge)
+		assert.Equal(t, int64(1<<20), cfg.Server.MaxBodyBytes)
+		assert.False(t, cfg.Tracing.Enabled)
+		assert.Equal(t, "http/protobuf", cfg.Tracing.Protocol)
+		assert.Equal(t, 1.0, cfg.Tracing.SampleRatio)
+
+		// Verify AI quota defaults
+		assert.Equal(t, "default", cfg.AILink.Quotas.WorkspaceName())
+		assert.False(t, cfg.AILink.Quotas.Enabled())
+		assert.False(t, cfg.AILink.Budget.Enabled())
 
 		// Verify store defaults
 		assert.Equal(t, "libsql", cfg.Store.Driver)
+		assert.Equal(t, 5*time.Second, cfg.Store.BusyTimeout)
+		assert.Equal(t, 4, cfg.Store.Pool.MaxOpenConns)
 		expectedStorePath := filepath.Join(gfconfig.GetAppDataDir("namelens"), "namelens.db")
 		assert.Equal(t, expectedStorePath, cfg.Store.Path)
 		assert.Equal(t, "", cfg.Store.URL)
@@ -76,13 +118,19 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, 5*time.Minute, cfg.Cache.AvailableTTL)
 		assert.Equal(t, time.Hour, cfg.Cache.TakenTTL)
 		assert.Equal(t, 30*time.Second, cfg.Cache.ErrorTTL)
+		assert.Zero(t, cfg.Cache.StaleWhileRevalidate)
+		assert.Equal(t, 10000, cfg.Cache.Memory.Size)
+		assert.Equal(t, time.Minute, cfg.Cache.Memory.TTL)
 
 		// Verify rate limit defaults
 		assert.Equal(t, 0.9, cfg.RateLimitMargin)
+		assert.Equal(t, "store", cfg.RateLimiter.Backend)
 
 		// Verify logging defaults
 		assert.Equal(t, "info", cfg.Logging.Level)
 		assert.Equal(t, "SIMPLE", cfg.Logging.Profile)
+		assert.False(t, cfg.Logging.Audit.Enabled)
+		assert.Empty(t, cfg.Logging.Audit.Path)
 
 		// Verify metrics defaults
 		assert.True(t, cfg.Metrics.Enabled)
@@ -90,6 +138,9 @@ func TestLoad(t *testing.T) {
 
 		// Verify health defaults
 		assert.True(t, cfg.Health.Enabled)
+		assert.False(t, cfg.Health.RequireWarmup)
+		assert.Equal(t, "example.com", cfg.Health.WarmupCanary)
+		assert.Equal(t, 15*time.Second, cfg.Health.WarmupRetryInterval)
 
 		// Verify debug defaults
 		assert.False(t, cfg.Debug.Enabled)
@@ -97,6 +148,45 @@ func TestLoad(t *testing.T) {
 
 		// Verify workers default
 		assert.Equal(t, 4, cfg.Workers)
+
+		// Verify markets default
+		assert.Empty(t, cfg.Markets)
+
+		// Verify RDAP retry defaults
+		assert.Equal(t, 3, cfg.Domain.RDAPRetry.MaxAttempts)
+		assert.Equal(t, 500*time.Millisecond, cfg.Domain.RDAPRetry.BaseDelay)
+		assert.Equal(t, 5*time.Second, cfg.Domain.RDAPRetry.MaxDelay)
+		assert.Equal(t, 0.2, cfg.Domain.RDAPRetry.Jitter)
+
+		// Verify WHOIS politeness defaults
+		assert.Equal(t, 2, cfg.Domain.WhoisFallback.MaxPerServer)
+		assert.Equal(t, 500*time.Millisecond, cfg.Domain.WhoisFallback.Delay)
+
+		// Verify drop list defaults
+		assert.False(t, cfg.Domain.DropLists.Enabled)
+		assert.Empty(t, cfg.Domain.DropLists.Sources)
+		assert.Equal(t, 24*time.Hour, cfg.Domain.DropLists.Refresh)
+
+		// Verify bootstrap defaults
+		assert.True(t, cfg.Bootstrap.AutoRefresh)
+		assert.Equal(t, 168*time.Hour, cfg.Bootstrap.MaxAge)
+
+		// Verify redaction defaults
+		assert.True(t, cfg.Redaction.Enabled)
+		assert.Empty(t, cfg.Redaction.Patterns)
+
+		// Verify similarity defaults
+		assert.True(t, cfg.Similarity.Enabled)
+		assert.Empty(t, cfg.Similarity.Brands)
+		assert.Empty(t, cfg.Similarity.BrandsFile)
+		assert.Equal(t, 75, cfg.Similarity.MinScore)
+		assert.True(t, cfg.WordScan.Enabled)
+		assert.Empty(t, cfg.WordScan.Languages)
+		assert.Equal(t, "standard", cfg.WordScan.Sensitivity)
+		assert.Empty(t, cfg.Report.PDFCommand)
+		assert.False(t, cfg.Pricing.Enabled)
+		assert.Equal(t, 200.0, cfg.Pricing.PremiumThreshold)
+		assert.Empty(t, cfg.Pricing.Providers)
 	})
 
 	// Test runtime overrides
@@ -132,11 +222,53 @@ func TestLoad(t *testing.T) {
 		require.NoError(t, os.Setenv("NAMELENS_LOG_LEVEL", "warn"))
 		require.NoError(t, os.Setenv("NAMELENS_METRICS_ENABLED", "false"))
 		require.NoError(t, os.Setenv("NAMELENS_RATE_LIMIT_MARGIN", "0.8"))
+		require.NoError(t, os.Setenv("NAMELENS_AILINK_BUDGET_MAX_COST_PER_DAY_USD", "2.5"))
+		require.NoError(t, os.Setenv("NAMELENS_HEALTH_REQUIRE_WARMUP", "true"))
+		require.NoError(t, os.Setenv("NAMELENS_MARKETS", "US,DE"))
+		require.NoError(t, os.Setenv("NAMELENS_SIMILARITY_MIN_SCORE", "85"))
+		require.NoError(t, os.Setenv("NAMELENS_WORD_SCAN_SENSITIVITY", "strict"))
+		require.NoError(t, os.Setenv("NAMELENS_SHARE_TTL", "24h"))
+		require.NoError(t, os.Setenv("NAMELENS_TRACING_SAMPLE_RATIO", "0.25"))
+		require.NoError(t, os.Setenv("NAMELENS_LOG_AUDIT_ENABLED", "true"))
+		require.NoError(t, os.Setenv("NAMELENS_CACHE_STALE_WHILE_REVALIDATE", "1h"))
+		require.NoError(t, os.Setenv("NAMELENS_SERVER_READ_ONLY", "true"))
+		require.NoError(t, os.Setenv("NAMELENS_CACHE_MEMORY_SIZE", "500"))
+		require.NoError(t, os.Setenv("NAMELENS_RATE_LIMITER_BACKEND", "redis"))
+		require.NoError(t, os.Setenv("NAMELENS_DB_MAX_OPEN_CONNS", "8"))
+		require.NoError(t, os.Setenv("NAMELENS_SERVER_CORS_ALLOWED_ORIGINS", "https://app.example.com,http://localhost:5173"))
+		require.NoError(t, os.Setenv("NAMELENS_SERVER_MAX_BODY_BYTES", "4096"))
+		require.NoError(t, os.Setenv("NAMELENS_REPORT_PDF_COMMAND", "weasyprint {html} {pdf}"))
+		require.NoError(t, os.Setenv("NAMELENS_PRICING_PREMIUM_THRESHOLD", "500"))
+		require.NoError(t, os.Setenv("NAMELENS_DOMAIN_DROP_LISTS_REFRESH", "6h"))
+		require.NoError(t, os.Setenv("NAMELENS_DOMAIN_WHOIS_FALLBACK_MAX_PER_SERVER", "4"))
+		require.NoError(t, os.Setenv("NAMELENS_DOMAIN_DNS_FALLBACK_RESOLVERS", "tls://1.1.1.1,9.9.9.9"))
+		require.NoError(t, os.Setenv("NAMELENS_NETWORK_PROXY", "socks5://proxy.example.com:1080"))
 		defer func() {
 			_ = os.Unsetenv("NAMELENS_PORT")
 			_ = os.Unsetenv("NAMELENS_LOG_LEVEL")
 			_ = os.Unsetenv("NAMELENS_METRICS_ENABLED")
 			_ = os.Unsetenv("NAMELENS_RATE_LIMIT_MARGIN")
+			_ = os.Unsetenv("NAMELENS_AILINK_BUDGET_MAX_COST_PER_DAY_USD")
+			_ = os.Unsetenv("NAMELENS_HEALTH_REQUIRE_WARMUP")
+			_ = os.Unsetenv("NAMELENS_MARKETS")
+			_ = os.Unsetenv("NAMELENS_SIMILARITY_MIN_SCORE")
+			_ = os.Unsetenv("NAMELENS_WORD_SCAN_SENSITIVITY")
+			_ = os.Unsetenv("NAMELENS_SHARE_TTL")
+			_ = os.Unsetenv("NAMELENS_TRACING_SAMPLE_RATIO")
+			_ = os.Unsetenv("NAMELENS_LOG_AUDIT_ENABLED")
+			_ = os.Unsetenv("NAMELENS_CACHE_STALE_WHILE_REVALIDATE")
+			_ = os.Unsetenv("NAMELENS_SERVER_READ_ONLY")
+			_ = os.Unsetenv("NAMELENS_CACHE_MEMORY_SIZE")
+			_ = os.Unsetenv("NAMELENS_RATE_LIMITER_BACKEND")
+			_ = os.Unsetenv("NAMELENS_DB_MAX_OPEN_CONNS")
+			_ = os.Unsetenv("NAMELENS_SERVER_CORS_ALLOWED_ORIGINS")
+			_ = os.Unsetenv("NAMELENS_SERVER_MAX_BODY_BYTES")
+			_ = os.Unsetenv("NAMELENS_REPORT_PDF_COMMAND")
+			_ = os.Unsetenv("NAMELENS_PRICING_PREMIUM_THRESHOLD")
+			_ = os.Unsetenv("NAMELENS_DOMAIN_DROP_LISTS_REFRESH")
+			_ = os.Unsetenv("NAMELENS_DOMAIN_WHOIS_FALLBACK_MAX_PER_SERVER")
+			_ = os.Unsetenv("NAMELENS_DOMAIN_DNS_FALLBACK_RESOLVERS")
+			_ = os.Unsetenv("NAMELENS_NETWORK_PROXY")
 		}()
 
 		cfg, err := Load(ctx)
@@ -148,6 +280,27 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, "warn", cfg.Logging.Level)
 		assert.False(t, cfg.Metrics.Enabled)
 		assert.Equal(t, 0.8, cfg.RateLimitMargin)
+		assert.Equal(t, "redis", cfg.RateLimiter.Backend)
+		assert.Equal(t, 8, cfg.Store.Pool.MaxOpenConns)
+		assert.Equal(t, 2.5, cfg.AILink.Budget.MaxCostPerDayUSD)
+		assert.True(t, cfg.Health.RequireWarmup)
+		assert.Equal(t, []string{"US", "DE"}, cfg.Markets)
+		assert.Equal(t, 85, cfg.Similarity.MinScore)
+		assert.Equal(t, "strict", cfg.WordScan.Sensitivity)
+		assert.Equal(t, 24*time.Hour, cfg.Server.Share.TTL)
+		assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+		assert.True(t, cfg.Logging.Audit.Enabled)
+		assert.Equal(t, time.Hour, cfg.Cache.StaleWhileRevalidate)
+		assert.True(t, cfg.Server.ReadOnly)
+		assert.Equal(t, []string{"https://app.example.com", "http://localhost:5173"}, cfg.Server.CORS.AllowedOrigins)
+		assert.Equal(t, int64(4096), cfg.Server.MaxBodyBytes)
+		assert.Equal(t, "weasyprint {html} {pdf}", cfg.Report.PDFCommand)
+		assert.Equal(t, 500.0, cfg.Pricing.PremiumThreshold)
+		assert.Equal(t, 6*time.Hour, cfg.Domain.DropLists.Refresh)
+		assert.Equal(t, 4, cfg.Domain.WhoisFallback.MaxPerServer)
+		assert.Equal(t, []string{"tls://1.1.1.1", "9.9.9.9"}, cfg.Domain.DNSFallback.Resolvers)
+		assert.Equal(t, "socks5://proxy.example.com:1080", cfg.Network.Proxy)
+		assert.Equal(t, 500, cfg.Cache.Memory.Size)
 	})
 
 	// Test config precedence: runtime > env > defaults
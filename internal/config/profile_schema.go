@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/fulmenhq/gofulmen/schema"
+)
+
+var (
+	profileValidatorOnce sync.Once
+	profileValidator     *schema.Validator
+	profileValidatorErr  error
+)
+
+// ValidateProfileJSON validates an encoded check profile against the embedded
+// namelens/v0/profile schema.
+func ValidateProfileJSON(payload []byte) error {
+	profileValidatorOnce.Do(func() {
+		profileValidator, profileValidatorErr = schema.NewValidator(embeddedProfileSchemaJSON)
+	})
+	if profileValidatorErr != nil {
+		return fmt.Errorf("compile profile schema: %w", profileValidatorErr)
+	}
+
+	diagnostics, err := profileValidator.ValidateJSON(payload)
+	if err != nil {
+		return err
+	}
+	if len(diagnostics) == 0 {
+		return nil
+	}
+
+	// The first diagnostic is usually the generic root failure; prefer the
+	// first one that points at a field.
+	d := diagnostics[0]
+	for _, candidate := range diagnostics {
+		if candidate.Pointer != "" {
+			d = candidate
+			break
+		}
+	}
+	if d.Pointer != "" {
+		return fmt.Errorf("invalid profile: %s: %s", d.Pointer, d.Message)
+	}
+	return fmt.Errorf("invalid profile: %s", d.Message)
+}
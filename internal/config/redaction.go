@@ -0,0 +1,69 @@
+package config
+
+import (
+	"net/url"
+	"os"
+
+	"github.com/namelens/namelens/internal/redact"
+)
+
+// Secrets returns the credential values present in the configuration: AILink
+// API keys (including those in a readable api_key_file or the OS keychain),
+// the store auth token, store, rate limiter, and proxy URL passwords, control
+// plane API keys, and sensitive custom checker headers (after ${ENV}
+// expansion).
+func (c *Config) Secrets() []string {
+	if c == nil {
+		return nil
+	}
+	var secrets []string
+	for id, provider := range c.AILink.Providers {
+		for _, credential := range provider.Credentials {
+			if loaded, err := credential.ResolveKey(id); err == nil {
+				credential = loaded
+			}
+			secrets = append(secrets, credential.APIKey)
+		}
+	}
+	secrets = append(secrets, c.Store.AuthToken)
+	// Postgres and Redis URLs usually carry a password
+	secrets = append(secrets, urlPassword(c.Store.URL), urlPassword(c.RateLimiter.URL))
+	secrets = append(secrets, urlPassword(c.Network.Proxy))
+	for _, proxy := range c.Network.CheckerProxies {
+		secrets = append(secrets, urlPassword(proxy))
+	}
+	for _, key := range c.Server.APIKeys {
+		secrets = append(secrets, os.ExpandEnv(key.Key))
+	}
+	for _, custom := range c.Checkers.Custom {
+		for header, value := range custom.Headers {
+			if redact.IsSensitiveKey(header) {
+				secrets = append(secrets, os.ExpandEnv(value))
+			}
+		}
+	}
+	return secrets
+}
+
+func urlPassword(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.User == nil {
+		return ""
+	}
+	password, _ := parsed.User.Password()
+	return password
+}
+
+// configureRedaction installs the process-wide redactor for cfg.
+func configureRedaction(cfg *Config) error {
+	if !cfg.Redaction.Enabled {
+		redact.SetDefault(nil)
+		return nil
+	}
+	redactor, err := redact.New(cfg.Secrets(), cfg.Redaction.Patterns)
+	if err != nil {
+		return err
+	}
+	redact.SetDefault(redactor)
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/similarity"
+	"github.com/namelens/namelens/internal/wordscan"
 )
 
 // BatchResult captures the results for a single name check.
@@ -21,4 +23,36 @@ type BatchResult struct {
 	PhoneticsError   *ailink.SearchError    `json:"phonetics_error,omitempty"`
 	Suitability      json.RawMessage        `json:"suitability,omitempty"`
 	SuitabilityError *ailink.SearchError    `json:"suitability_error,omitempty"`
+	Alternatives     []*CheckResult         `json:"alternatives,omitempty"`
+	// LocaleMatrix breaks the suitability analysis down by requested locale.
+	LocaleMatrix []LocaleSuitability `json:"locale_matrix,omitempty"`
+	// SimilarBrands lists existing brands the name is a near collision with.
+	SimilarBrands []similarity.Match `json:"similar_brands,omitempty"`
+	// UnfortunateWords lists words hidden in the name that read badly in one
+	// of the scanned languages.
+	UnfortunateWords []wordscan.Finding `json:"unfortunate_words,omitempty"`
+	// Variants are .com checks of the name's typo and look-alike variants.
+	Variants []VariantCheck `json:"variants,omitempty"`
+	// Requests counts external requests and cache hits made for this name by category.
+	Requests map[string]int `json:"requests,omitempty"`
+	// AIUsage totals the AI provider calls made for this name. Calls shared by
+	// several names, such as --expert-bulk, count only in the run summary.
+	AIUsage *ailink.Usage `json:"ai_usage,omitempty"`
+}
+
+// LocaleSuitability is one locale's row in a name's suitability matrix.
+type LocaleSuitability struct {
+	Locale string `json:"locale"`
+	// Rating is the locale's severity level (clear, low, medium, high,
+	// blocker), or "not assessed" when the analysis skipped the locale.
+	Rating   string   `json:"rating"`
+	Score    *int     `json:"score,omitempty"`
+	Concerns []string `json:"concerns,omitempty"`
+}
+
+// VariantCheck is the .com check of a typo or look-alike variant of a name.
+type VariantCheck struct {
+	Name   string       `json:"name"`
+	Kind   string       `json:"kind"`
+	Result *CheckResult `json:"result"`
 }
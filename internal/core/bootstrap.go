@@ -0,0 +1,69 @@
+package core
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// BootstrapSnapshot is the RDAP bootstrap data stored by one update.
+type BootstrapSnapshot struct {
+	ID          int64     `json:"id"`
+	Version     string    `json:"version,omitempty"`
+	Publication string    `json:"publication,omitempty"`
+	Source      string    `json:"source,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at"`
+	TLDCount    int       `json:"tld_count"`
+	// Servers maps each TLD to its RDAP server URLs; listings leave it empty
+	Servers map[string][]string `json:"servers,omitempty"`
+}
+
+// BootstrapChange is one TLD whose RDAP servers differ between two snapshots.
+type BootstrapChange struct {
+	TLD    string   `json:"tld"`
+	Before []string `json:"before,omitempty"`
+	After  []string `json:"after,omitempty"`
+}
+
+// BootstrapDiff lists the TLD to RDAP server mappings that were added,
+// removed, or changed between two snapshots, each sorted by TLD.
+type BootstrapDiff struct {
+	Added   []BootstrapChange `json:"added"`
+	Removed []BootstrapChange `json:"removed"`
+	Changed []BootstrapChange `json:"changed"`
+}
+
+// Count returns the number of TLDs that differ.
+func (d BootstrapDiff) Count() int {
+	return len(d.Added) + len(d.Removed) + len(d.Changed)
+}
+
+// DiffBootstrap compares two TLD to RDAP server mappings. Server order
+// matters: lookups use the first server, so a reordering is a change.
+func DiffBootstrap(before, after map[string][]string) BootstrapDiff {
+	diff := BootstrapDiff{
+		Added:   []BootstrapChange{},
+		Removed: []BootstrapChange{},
+		Changed: []BootstrapChange{},
+	}
+	for tld, servers := range after {
+		previous, ok := before[tld]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, BootstrapChange{TLD: tld, After: servers})
+		case !slices.Equal(previous, servers):
+			diff.Changed = append(diff.Changed, BootstrapChange{TLD: tld, Before: previous, After: servers})
+		}
+	}
+	for tld, servers := range before {
+		if _, ok := after[tld]; !ok {
+			diff.Removed = append(diff.Removed, BootstrapChange{TLD: tld, Before: servers})
+		}
+	}
+	for _, changes := range [][]BootstrapChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool {
+			return changes[i].TLD < changes[j].TLD
+		})
+	}
+	return diff
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffBootstrap(t *testing.T) {
+	before := map[string][]string{
+		"com":  {"https://rdap.verisign.com/com/v1/"},
+		"net":  {"https://rdap.verisign.com/net/v1/"},
+		"old":  {"https://rdap.old.example/"},
+		"pair": {"https://a.example/", "https://b.example/"},
+	}
+	after := map[string][]string{
+		"com":  {"https://rdap.verisign.com/com/v1/"},
+		"net":  {"https://rdap.example.net/"},
+		"new":  {"https://rdap.new.example/"},
+		"pair": {"https://b.example/", "https://a.example/"},
+	}
+
+	diff := DiffBootstrap(before, after)
+	require.Equal(t, 4, diff.Count())
+	require.Equal(t, []BootstrapChange{{TLD: "new", After: []string{"https://rdap.new.example/"}}}, diff.Added)
+	require.Equal(t, []BootstrapChange{{TLD: "old", Before: []string{"https://rdap.old.example/"}}}, diff.Removed)
+	require.Len(t, diff.Changed, 2)
+	require.Equal(t, "net", diff.Changed[0].TLD)
+	require.Equal(t, []string{"https://rdap.verisign.com/net/v1/"}, diff.Changed[0].Before)
+	require.Equal(t, []string{"https://rdap.example.net/"}, diff.Changed[0].After)
+	// Lookups use the first server, so reordering counts as a change
+	require.Equal(t, "pair", diff.Changed[1].TLD)
+
+	require.Zero(t, DiffBootstrap(after, after).Count())
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Request categories counted by RequestBudget.
+const (
+	RequestRDAP     = "rdap"
+	RequestWHOIS    = "whois"
+	RequestDNS      = "dns"
+	RequestRegistry = "registry"
+	RequestHandle   = "handle"
+	RequestAI       = "ai"
+	RequestCacheHit = "cache_hit"
+)
+
+// requestOrder and requestLabels control how Summary renders categories.
+var requestOrder = []string{RequestRDAP, RequestWHOIS, RequestDNS, RequestRegistry, RequestHandle, RequestAI, RequestCacheHit}
+
+var requestLabels = map[string][2]string{
+	RequestRDAP:     {"RDAP", "RDAP"},
+	RequestWHOIS:    {"WHOIS", "WHOIS"},
+	RequestDNS:      {"DNS", "DNS"},
+	RequestRegistry: {"registry", "registry"},
+	RequestHandle:   {"handle", "handle"},
+	RequestAI:       {"AI call", "AI calls"},
+	RequestCacheHit: {"cache hit", "cache hits"},
+}
+
+// RequestBudget counts the external requests a run makes by category so users
+// can keep an eye on upstream terms of service. The zero value is ready to use
+// and a nil budget ignores counts.
+type RequestBudget struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// Add records n requests in a category.
+func (b *RequestBudget) Add(category string, n int) {
+	if b == nil || category == "" || n <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.counts == nil {
+		b.counts = make(map[string]int)
+	}
+	b.counts[category] += n
+}
+
+// Merge adds counts from another run or name.
+func (b *RequestBudget) Merge(counts map[string]int) {
+	for category, n := range counts {
+		b.Add(category, n)
+	}
+}
+
+// Counts returns a copy of the recorded counts, or nil when nothing was recorded.
+func (b *RequestBudget) Counts() map[string]int {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.counts) == 0 {
+		return nil
+	}
+	counts := make(map[string]int, len(b.counts))
+	for category, n := range b.counts {
+		counts[category] = n
+	}
+	return counts
+}
+
+// Summary renders the counts as "42 RDAP, 12 registry, 3 AI calls, 18 cache hits".
+func (b *RequestBudget) Summary() string {
+	return RequestSummary(b.Counts())
+}
+
+// RequestSummary renders request counts in a fixed category order, followed
+// by any unknown categories alphabetically.
+func RequestSummary(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "no external requests"
+	}
+
+	categories := append([]string{}, requestOrder...)
+	extra := make([]string, 0)
+	for category := range counts {
+		if _, ok := requestLabels[category]; !ok {
+			extra = append(extra, category)
+		}
+	}
+	sort.Strings(extra)
+	categories = append(categories, extra...)
+
+	parts := make([]string, 0, len(counts))
+	for _, category := range categories {
+		n := counts[category]
+		if n <= 0 {
+			continue
+		}
+		label, ok := requestLabels[category]
+		if !ok {
+			label = [2]string{category, category}
+		}
+		if n == 1 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, label[0]))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", n, label[1]))
+	}
+	if len(parts) == 0 {
+		return "no external requests"
+	}
+	return strings.Join(parts, ", ")
+}
+
+type requestBudgetKey struct{}
+
+// WithRequestBudget attaches a budget to the context so checkers and AI calls
+// made with it are counted.
+func WithRequestBudget(ctx context.Context, budget *RequestBudget) context.Context {
+	return context.WithValue(ctx, requestBudgetKey{}, budget)
+}
+
+// RequestBudgetFrom returns the context's budget, or nil.
+func RequestBudgetFrom(ctx context.Context) *RequestBudget {
+	if ctx == nil {
+		return nil
+	}
+	budget, _ := ctx.Value(requestBudgetKey{}).(*RequestBudget)
+	return budget
+}
+
+// CountRequest records one request in the context's budget, if any.
+func CountRequest(ctx context.Context, category string) {
+	RequestBudgetFrom(ctx).Add(category, 1)
+}
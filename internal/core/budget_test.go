@@ -0,0 +1,32 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestBudgetSummary(t *testing.T) {
+	budget := &RequestBudget{}
+	ctx := WithRequestBudget(context.Background(), budget)
+	for range 42 {
+		CountRequest(ctx, RequestRDAP)
+	}
+	budget.Add(RequestCacheHit, 18)
+	budget.Add(RequestAI, 3)
+	budget.Merge(map[string]int{RequestRegistry: 12, "custom": 1})
+
+	require.Equal(t, "42 RDAP, 12 registry, 3 AI calls, 18 cache hits, 1 custom", budget.Summary())
+	require.Equal(t, "1 AI call", RequestSummary(map[string]int{RequestAI: 1}))
+	require.Equal(t, "no external requests", RequestSummary(nil))
+}
+
+func TestRequestBudgetNil(t *testing.T) {
+	CountRequest(context.Background(), RequestRDAP)
+
+	var budget *RequestBudget
+	budget.Add(RequestRDAP, 1)
+	require.Nil(t, budget.Counts())
+	require.Nil(t, (&RequestBudget{}).Counts())
+}
@@ -6,8 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/namelens/namelens/internal/audit"
+	"github.com/namelens/namelens/internal/core"
 )
 
 const defaultBootstrapURL = "https://data.iana.org/rdap/dns.json"
@@ -19,6 +24,16 @@ const (
 	bootstrapMetaSource      = "bootstrap_source"
 )
 
+// bootstrapSnapshotKeep is how many past updates are kept for diffing.
+const bootstrapSnapshotKeep = 10
+
+// Update stages reported to BootstrapService.Progress.
+const (
+	BootstrapStageFetch = "fetch"
+	BootstrapStageParse = "parse"
+	BootstrapStageStore = "store"
+)
+
 // BootstrapStore provides persistence for bootstrap data.
 type BootstrapStore interface {
 	SetRDAPServers(ctx context.Context, tld string, servers []string, updatedAt time.Time) error
@@ -28,12 +43,27 @@ type BootstrapStore interface {
 	CountBootstrapTLDs(ctx context.Context) (int, error)
 }
 
+// BootstrapSnapshotStore is implemented by stores that can read and replace
+// the whole TLD mapping and keep snapshots of past updates. Update uses it,
+// when the store supports it, to drop TLDs IANA no longer lists and to report
+// what changed.
+type BootstrapSnapshotStore interface {
+	ListRDAPServers(ctx context.Context) (map[string][]string, error)
+	ReplaceRDAPServers(ctx context.Context, servers map[string][]string, updatedAt time.Time) error
+	SaveBootstrapSnapshot(ctx context.Context, snapshot core.BootstrapSnapshot, keep int) error
+}
+
 // BootstrapService fetches and caches IANA RDAP bootstrap data.
 type BootstrapService struct {
 	Store      BootstrapStore
 	HTTPClient *http.Client
 	BaseURL    string
 	Clock      func() time.Time
+	// Workers parse the bootstrap services concurrently (default: CPU count)
+	Workers int
+	// Progress, when set, receives the current stage and how many of its
+	// total units are done; it may be called from several goroutines.
+	Progress func(stage string, done, total int)
 }
 
 // BootstrapDocument represents the IANA RDAP DNS bootstrap response.
@@ -49,6 +79,9 @@ type BootstrapSummary struct {
 	Version     string
 	Publication time.Time
 	FetchedAt   time.Time
+	// Diff lists the mappings that changed since the previous update; it is
+	// nil on the first update or when the store keeps no snapshots.
+	Diff *core.BootstrapDiff
 }
 
 // BootstrapStatus reports cached bootstrap metadata.
@@ -60,7 +93,8 @@ type BootstrapStatus struct {
 	Source      string
 }
 
-// Update fetches bootstrap data from IANA and stores it.
+// Update fetches bootstrap data from IANA and stores it. When the store keeps
+// snapshots, the summary includes what changed since the previous update.
 func (b *BootstrapService) Update(ctx context.Context) (*BootstrapSummary, error) {
 	if b == nil || b.Store == nil {
 		return nil, errors.New("bootstrap store is not configured")
@@ -68,7 +102,7 @@ func (b *BootstrapService) Update(ctx context.Context) (*BootstrapSummary, error
 
 	client := b.HTTPClient
 	if client == nil {
-		client = &http.Client{Timeout: 15 * time.Second}
+		client = &http.Client{Timeout: 15 * time.Second, Transport: audit.NewTransport(audit.KindBootstrap, nil)}
 	}
 
 	baseURL := strings.TrimSpace(b.BaseURL)
@@ -80,6 +114,75 @@ func (b *BootstrapService) Update(ctx context.Context) (*BootstrapSummary, error
 		ctx = context.Background()
 	}
 
+	// Read the current mapping while the new one downloads
+	snapshots, _ := b.Store.(BootstrapSnapshotStore)
+	var (
+		previous    map[string][]string
+		previousErr error
+		wg          sync.WaitGroup
+	)
+	if snapshots != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			previous, previousErr = snapshots.ListRDAPServers(ctx)
+		}()
+	}
+
+	b.report(BootstrapStageFetch, 0, 1)
+	doc, err := fetchBootstrap(ctx, client, baseURL)
+	wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+	if previousErr != nil {
+		return nil, previousErr
+	}
+	b.report(BootstrapStageFetch, 1, 1)
+
+	servers := b.parseServices(doc.Services)
+	if len(servers) == 0 {
+		return nil, errors.New("bootstrap data lists no RDAP services")
+	}
+
+	updatedAt := b.now()
+	if err := b.storeServers(ctx, snapshots, servers, updatedAt); err != nil {
+		return nil, err
+	}
+
+	_ = b.Store.SetBootstrapMeta(ctx, bootstrapMetaVersion, doc.Version)
+	_ = b.Store.SetBootstrapMeta(ctx, bootstrapMetaPublication, doc.Publication)
+	_ = b.Store.SetBootstrapMeta(ctx, bootstrapMetaFetchedAt, updatedAt.Format(time.RFC3339))
+	_ = b.Store.SetBootstrapMeta(ctx, bootstrapMetaSource, baseURL)
+
+	summary := &BootstrapSummary{
+		TLDCount:    len(servers),
+		Version:     doc.Version,
+		Publication: parseTime(doc.Publication),
+		FetchedAt:   updatedAt,
+	}
+	if snapshots == nil {
+		return summary, nil
+	}
+
+	if err := snapshots.SaveBootstrapSnapshot(ctx, core.BootstrapSnapshot{
+		Version:     doc.Version,
+		Publication: doc.Publication,
+		Source:      baseURL,
+		FetchedAt:   updatedAt,
+		TLDCount:    len(servers),
+		Servers:     servers,
+	}, bootstrapSnapshotKeep); err != nil {
+		return nil, err
+	}
+	if len(previous) > 0 {
+		diff := core.DiffBootstrap(previous, servers)
+		summary.Diff = &diff
+	}
+	return summary, nil
+}
+
+func fetchBootstrap(ctx context.Context, client *http.Client, baseURL string) (*BootstrapDocument, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("build bootstrap request: %w", err)
@@ -99,41 +202,107 @@ func (b *BootstrapService) Update(ctx context.Context) (*BootstrapSummary, error
 	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
 		return nil, fmt.Errorf("decode bootstrap data: %w", err)
 	}
+	return &doc, nil
+}
 
-	updatedAt := b.now()
-	tldCount := 0
+// parseServices maps each TLD to its RDAP servers, parsing the services on
+// Workers goroutines. A TLD listed by several services keeps the first.
+func (b *BootstrapService) parseServices(services [][][]string) map[string][]string {
+	workers := b.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
 
-	for _, service := range doc.Services {
-		if len(service) != 2 {
-			continue
-		}
-		tlds := service[0]
-		urls := service[1]
-		if len(tlds) == 0 || len(urls) == 0 {
-			continue
+	parsed := make([]map[string][]string, len(services))
+	indexes := make(chan int)
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+	)
+	b.report(BootstrapStageParse, 0, len(services))
+	for range min(workers, max(len(services), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				parsed[i] = parseService(services[i])
+				mu.Lock()
+				done++
+				b.report(BootstrapStageParse, done, len(services))
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range services {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	servers := make(map[string][]string)
+	for _, entries := range parsed {
+		for tld, urls := range entries {
+			if _, ok := servers[tld]; !ok {
+				servers[tld] = urls
+			}
 		}
+	}
+	return servers
+}
 
-		for _, tld := range tlds {
-			if err := b.Store.SetRDAPServers(ctx, tld, urls, updatedAt); err != nil {
-				return nil, err
-			}
-			tldCount++
+// parseService reads one [[tlds...], [urls...]] bootstrap entry, skipping
+// malformed entries and blank values.
+func parseService(service [][]string) map[string][]string {
+	if len(service) != 2 {
+		return nil
+	}
+	var urls []string
+	for _, url := range service[1] {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
 		}
 	}
+	if len(urls) == 0 {
+		return nil
+	}
+	entries := make(map[string][]string, len(service[0]))
+	for _, tld := range service[0] {
+		tld = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(tld)), ".")
+		if tld != "" {
+			entries[tld] = urls
+		}
+	}
+	return entries
+}
 
-	_ = b.Store.SetBootstrapMeta(ctx, bootstrapMetaVersion, doc.Version)
-	_ = b.Store.SetBootstrapMeta(ctx, bootstrapMetaPublication, doc.Publication)
-	_ = b.Store.SetBootstrapMeta(ctx, bootstrapMetaFetchedAt, updatedAt.Format(time.RFC3339))
-	_ = b.Store.SetBootstrapMeta(ctx, bootstrapMetaSource, baseURL)
+// storeServers writes the mapping, replacing it as a whole when the store
+// supports snapshots and one TLD at a time otherwise.
+func (b *BootstrapService) storeServers(ctx context.Context, snapshots BootstrapSnapshotStore, servers map[string][]string, updatedAt time.Time) error {
+	b.report(BootstrapStageStore, 0, len(servers))
+	if snapshots != nil {
+		if err := snapshots.ReplaceRDAPServers(ctx, servers, updatedAt); err != nil {
+			return err
+		}
+		b.report(BootstrapStageStore, len(servers), len(servers))
+		return nil
+	}
 
-	publication := parseTime(doc.Publication)
+	done := 0
+	for tld, urls := range servers {
+		if err := b.Store.SetRDAPServers(ctx, tld, urls, updatedAt); err != nil {
+			return err
+		}
+		done++
+		b.report(BootstrapStageStore, done, len(servers))
+	}
+	return nil
+}
 
-	return &BootstrapSummary{
-		TLDCount:    tldCount,
-		Version:     doc.Version,
-		Publication: publication,
-		FetchedAt:   updatedAt,
-	}, nil
+func (b *BootstrapService) report(stage string, done, total int) {
+	if b.Progress != nil {
+		b.Progress(stage, done, total)
+	}
 }
 
 // Status returns cached bootstrap metadata.
@@ -180,6 +349,29 @@ func (b *BootstrapService) Status(ctx context.Context) (*BootstrapStatus, error)
 	}, nil
 }
 
+// Stale reports whether the status describes missing bootstrap data or data
+// fetched more than maxAge ago. A non-positive maxAge only treats missing data
+// as stale.
+func (s *BootstrapStatus) Stale(maxAge time.Duration, now time.Time) bool {
+	if s == nil || s.TLDCount == 0 || s.FetchedAt.IsZero() {
+		return true
+	}
+	return maxAge > 0 && now.Sub(s.FetchedAt) > maxAge
+}
+
+// RefreshIfStale updates the bootstrap data when it is missing or older than
+// maxAge. It returns a nil summary when the cached data is still fresh.
+func (b *BootstrapService) RefreshIfStale(ctx context.Context, maxAge time.Duration) (*BootstrapSummary, error) {
+	status, err := b.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !status.Stale(maxAge, b.now()) {
+		return nil, nil
+	}
+	return b.Update(ctx)
+}
+
 // LookupServers returns cached RDAP servers for the TLD.
 func (b *BootstrapService) LookupServers(ctx context.Context, tld string) ([]string, error) {
 	if b == nil || b.Store == nil {
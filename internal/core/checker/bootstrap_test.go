@@ -4,10 +4,13 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
 )
 
 type memoryBootstrapStore struct {
@@ -49,6 +52,33 @@ func (m *memoryBootstrapStore) CountBootstrapTLDs(ctx context.Context) (int, err
 	return len(m.servers), nil
 }
 
+// snapshotBootstrapStore also replaces the whole mapping and keeps snapshots.
+type snapshotBootstrapStore struct {
+	memoryBootstrapStore
+	snapshots []core.BootstrapSnapshot
+}
+
+func (m *snapshotBootstrapStore) ListRDAPServers(ctx context.Context) (map[string][]string, error) {
+	servers := make(map[string][]string, len(m.servers))
+	for tld, urls := range m.servers {
+		servers[tld] = urls
+	}
+	return servers, nil
+}
+
+func (m *snapshotBootstrapStore) ReplaceRDAPServers(ctx context.Context, servers map[string][]string, updatedAt time.Time) error {
+	m.servers = make(map[string][]string, len(servers))
+	for tld, urls := range servers {
+		m.servers[tld] = urls
+	}
+	return nil
+}
+
+func (m *snapshotBootstrapStore) SaveBootstrapSnapshot(ctx context.Context, snapshot core.BootstrapSnapshot, keep int) error {
+	m.snapshots = append(m.snapshots, snapshot)
+	return nil
+}
+
 func TestBootstrapUpdate(t *testing.T) {
 	payload := `{
   "version": "1.0",
@@ -100,3 +130,127 @@ func TestBootstrapStatus(t *testing.T) {
 	require.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), status.Publication)
 	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), status.FetchedAt)
 }
+
+func TestBootstrapStatusStale(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	fresh := &BootstrapStatus{TLDCount: 1, FetchedAt: now.Add(-24 * time.Hour)}
+	require.False(t, fresh.Stale(7*24*time.Hour, now))
+	require.True(t, fresh.Stale(time.Hour, now))
+	require.False(t, fresh.Stale(0, now))
+
+	require.True(t, (&BootstrapStatus{}).Stale(7*24*time.Hour, now))
+	require.True(t, (&BootstrapStatus{TLDCount: 1}).Stale(7*24*time.Hour, now))
+}
+
+func TestBootstrapRefreshIfStale(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "1.0", "services": [[["com"], ["https://rdap.example.com/"]]]}`))
+	}))
+	defer server.Close()
+
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	store := &memoryBootstrapStore{
+		servers: map[string][]string{"com": {"https://rdap.example.com/"}},
+		meta:    map[string]string{bootstrapMetaFetchedAt: "2025-01-09T00:00:00Z"},
+	}
+	service := &BootstrapService{
+		Store:      store,
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Clock:      func() time.Time { return now },
+	}
+
+	summary, err := service.RefreshIfStale(context.Background(), 7*24*time.Hour)
+	require.NoError(t, err)
+	require.Nil(t, summary)
+	require.Zero(t, hits)
+
+	summary, err = service.RefreshIfStale(context.Background(), time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, summary)
+	require.Equal(t, 1, hits)
+	require.Equal(t, now.Format(time.RFC3339), store.meta[bootstrapMetaFetchedAt])
+}
+
+func TestBootstrapUpdateDiff(t *testing.T) {
+	payload := `{"version": "1.0", "services": [
+  [["com", "net"], ["https://rdap.example.com/"]],
+  [["dev"], ["https://rdap.nic.google/"]]
+]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	var (
+		mu     sync.Mutex
+		stages = map[string][2]int{}
+	)
+	store := &snapshotBootstrapStore{}
+	service := &BootstrapService{
+		Store:      store,
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Workers:    2,
+		Progress: func(stage string, done, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			stages[stage] = [2]int{done, total}
+		},
+	}
+
+	// The first update has nothing to compare with
+	summary, err := service.Update(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, summary.TLDCount)
+	require.Nil(t, summary.Diff)
+	require.Len(t, store.snapshots, 1)
+	require.Equal(t, 3, store.snapshots[0].TLDCount)
+	require.Equal(t, [2]int{1, 1}, stages[BootstrapStageFetch])
+	require.Equal(t, [2]int{2, 2}, stages[BootstrapStageParse])
+	require.Equal(t, [2]int{3, 3}, stages[BootstrapStageStore])
+
+	// IANA drops .net, moves .dev, and adds .app; a TLD listed twice keeps
+	// its first service
+	payload = `{"version": "1.0", "services": [
+  [[".COM"], ["https://rdap.example.com/"]],
+  [["dev", "app"], ["https://rdap.example.dev/"]],
+  [["com"], ["https://rdap.duplicate.example/"]],
+  [["malformed"]]
+]}`
+	summary, err = service.Update(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, summary.TLDCount)
+	require.NotNil(t, summary.Diff)
+	require.Equal(t, []core.BootstrapChange{{TLD: "app", After: []string{"https://rdap.example.dev/"}}}, summary.Diff.Added)
+	require.Equal(t, []core.BootstrapChange{{TLD: "net", Before: []string{"https://rdap.example.com/"}}}, summary.Diff.Removed)
+	require.Equal(t, []core.BootstrapChange{{
+		TLD:    "dev",
+		Before: []string{"https://rdap.nic.google/"},
+		After:  []string{"https://rdap.example.dev/"},
+	}}, summary.Diff.Changed)
+	require.NotContains(t, store.servers, "net")
+	require.Equal(t, []string{"https://rdap.example.com/"}, store.servers["com"])
+	require.Len(t, store.snapshots, 2)
+}
+
+func TestBootstrapUpdateRejectsEmptyData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version": "1.0", "services": []}`))
+	}))
+	defer server.Close()
+
+	store := &snapshotBootstrapStore{memoryBootstrapStore: memoryBootstrapStore{
+		servers: map[string][]string{"com": {"https://rdap.example.com/"}},
+	}}
+	service := &BootstrapService{Store: store, BaseURL: server.URL, HTTPClient: server.Client()}
+
+	_, err := service.Update(context.Background())
+	require.Error(t, err)
+	require.Contains(t, store.servers, "com")
+	require.Empty(t, store.snapshots)
+}
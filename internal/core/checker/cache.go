@@ -1,9 +1,12 @@
 package checker
 
 import (
+	"context"
+	"strings"
 	"time"
 
 	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
 )
 
 // CachePolicy controls cache TTLs for check results.
@@ -11,6 +14,21 @@ type CachePolicy struct {
 	AvailableTTL time.Duration
 	TakenTTL     time.Duration
 	ErrorTTL     time.Duration
+	// StaleWhileRevalidate serves available and taken results for this long
+	// after they expire, refreshing them on Revalidator in the background.
+	// Zero, or a nil Revalidator, disables it.
+	StaleWhileRevalidate time.Duration
+	Revalidator          *engine.Revalidator
+}
+
+// StaleCacheStore is implemented by stores that can return cached results up
+// to maxStale past their expiry, which stale-while-revalidate needs.
+type StaleCacheStore interface {
+	GetStaleCachedResult(ctx context.Context, name string, checkType core.CheckType, tld string, maxStale time.Duration) (*core.CheckResult, error)
+}
+
+type cacheReader interface {
+	GetCachedResult(ctx context.Context, name string, checkType core.CheckType, tld string) (*core.CheckResult, error)
 }
 
 func cachePolicyWithDefaults(policy CachePolicy) CachePolicy {
@@ -26,7 +44,9 @@ func cachePolicyWithDefaults(policy CachePolicy) CachePolicy {
 	return policy
 }
 
-func cacheTTL(policy CachePolicy, availability core.Availability) time.Duration {
+// cacheTTL returns how long to cache a result. Background refreshes only
+// persist definitive answers so a failed refresh keeps the stale result.
+func cacheTTL(ctx context.Context, policy CachePolicy, availability core.Availability) time.Duration {
 	policy = cachePolicyWithDefaults(policy)
 
 	switch availability {
@@ -34,9 +54,41 @@ func cacheTTL(policy CachePolicy, availability core.Availability) time.Duration
 		return policy.AvailableTTL
 	case core.AvailabilityTaken:
 		return policy.TakenTTL
-	case core.AvailabilityError, core.AvailabilityRateLimited:
-		return policy.ErrorTTL
-	default:
-		return policy.ErrorTTL
 	}
+	if engine.IsRevalidating(ctx) {
+		return 0
+	}
+	return policy.ErrorTTL
+}
+
+// cachedResult returns the cached result for a check, or nil on a miss.
+// Within the policy's stale-while-revalidate window an expired available or
+// taken result is returned too, marked stale, and refresh re-runs the check
+// in the background to replace it (never in offline mode). Background
+// refreshes always miss so they reach the network.
+func cachedResult(ctx context.Context, store cacheReader, policy CachePolicy, name string, checkType core.CheckType, tld string, refresh func(ctx context.Context)) *core.CheckResult {
+	if store == nil || engine.IsRevalidating(ctx) {
+		return nil
+	}
+	if cached, err := store.GetCachedResult(ctx, name, checkType, tld); err == nil && cached != nil {
+		return cached
+	}
+
+	staleStore, ok := store.(StaleCacheStore)
+	if !ok || policy.StaleWhileRevalidate <= 0 || policy.Revalidator == nil {
+		return nil
+	}
+	cached, err := staleStore.GetStaleCachedResult(ctx, name, checkType, tld, policy.StaleWhileRevalidate)
+	if err != nil || cached == nil {
+		return nil
+	}
+	if cached.Available != core.AvailabilityAvailable && cached.Available != core.AvailabilityTaken {
+		return nil
+	}
+	cached.Provenance.CacheStale = true
+	if !core.IsOffline(ctx) {
+		key := strings.Join([]string{string(checkType), tld, name}, "|")
+		policy.Revalidator.Go(ctx, key, refresh)
+	}
+	return cached
 }
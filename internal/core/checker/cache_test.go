@@ -0,0 +1,174 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+// staleRegistryStore holds one entry per key that is either fresh or stale.
+type staleRegistryStore struct {
+	mu     sync.Mutex
+	fresh  map[string]*core.CheckResult
+	stale  map[string]*core.CheckResult
+	writes int
+}
+
+func (s *staleRegistryStore) GetCachedResult(ctx context.Context, name string, checkType core.CheckType, tld string) (*core.CheckResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyResult(s.fresh[name+string(checkType)]), nil
+}
+
+func (s *staleRegistryStore) GetStaleCachedResult(ctx context.Context, name string, checkType core.CheckType, tld string, maxStale time.Duration) (*core.CheckResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := name + string(checkType)
+	if result := s.fresh[key]; result != nil {
+		return copyResult(result), nil
+	}
+	return copyResult(s.stale[key]), nil
+}
+
+func (s *staleRegistryStore) SetCachedResult(ctx context.Context, name string, result *core.CheckResult, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fresh == nil {
+		s.fresh = make(map[string]*core.CheckResult)
+	}
+	key := name + string(result.CheckType)
+	s.fresh[key] = copyResult(result)
+	delete(s.stale, key)
+	s.writes++
+	return nil
+}
+
+func (s *staleRegistryStore) GetRateLimit(ctx context.Context, endpoint string) (*core.RateLimitState, error) {
+	return nil, nil
+}
+
+func (s *staleRegistryStore) UpdateRateLimit(ctx context.Context, endpoint string, state *core.RateLimitState) error {
+	return nil
+}
+
+func copyResult(result *core.CheckResult) *core.CheckResult {
+	if result == nil {
+		return nil
+	}
+	clone := *result
+	return &clone
+}
+
+func newStaleStore(available core.Availability) *staleRegistryStore {
+	return &staleRegistryStore{stale: map[string]*core.CheckResult{
+		"example" + string(core.CheckTypeNPM): {Name: "example", CheckType: core.CheckTypeNPM, Available: available},
+	}}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	store := newStaleStore(core.AvailabilityTaken)
+	revalidator := &engine.Revalidator{}
+	checker := &NPMChecker{
+		Store:       store,
+		Client:      server.Client(),
+		BaseURL:     server.URL,
+		UseCache:    true,
+		CachePolicy: CachePolicy{StaleWhileRevalidate: time.Hour, Revalidator: revalidator},
+	}
+
+	// The stale answer comes back at once; the refresh replaces it
+	result, err := checker.Check(context.Background(), "example")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.True(t, result.Provenance.FromCache)
+	require.True(t, result.Provenance.CacheStale)
+
+	revalidator.Wait()
+	require.Equal(t, int32(1), hits.Load())
+
+	result, err = checker.Check(context.Background(), "example")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+	require.True(t, result.Provenance.FromCache)
+	require.False(t, result.Provenance.CacheStale)
+	require.Equal(t, int32(1), hits.Load())
+}
+
+func TestStaleWhileRevalidateKeepsStaleOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	store := newStaleStore(core.AvailabilityTaken)
+	revalidator := &engine.Revalidator{}
+	checker := &NPMChecker{
+		Store:       store,
+		Client:      server.Client(),
+		BaseURL:     server.URL,
+		UseCache:    true,
+		CachePolicy: CachePolicy{StaleWhileRevalidate: time.Hour, Revalidator: revalidator},
+	}
+
+	result, err := checker.Check(context.Background(), "example")
+	require.NoError(t, err)
+	require.True(t, result.Provenance.CacheStale)
+	revalidator.Wait()
+	require.Zero(t, store.writes)
+
+	result, err = checker.Check(context.Background(), "example")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.True(t, result.Provenance.CacheStale)
+	revalidator.Wait()
+}
+
+func TestStaleWhileRevalidateLimits(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	revalidator := &engine.Revalidator{}
+	newChecker := func(store *staleRegistryStore, policy CachePolicy) *NPMChecker {
+		return &NPMChecker{Store: store, Client: server.Client(), BaseURL: server.URL, UseCache: true, CachePolicy: policy}
+	}
+
+	// Disabled: the stale entry is a miss
+	result, err := newChecker(newStaleStore(core.AvailabilityTaken), CachePolicy{}).Check(context.Background(), "example")
+	require.NoError(t, err)
+	require.False(t, result.Provenance.FromCache)
+	require.Equal(t, int32(1), hits.Load())
+
+	// Errors are never served stale
+	policy := CachePolicy{StaleWhileRevalidate: time.Hour, Revalidator: revalidator}
+	result, err = newChecker(newStaleStore(core.AvailabilityError), policy).Check(context.Background(), "example")
+	require.NoError(t, err)
+	require.False(t, result.Provenance.FromCache)
+	require.Equal(t, int32(2), hits.Load())
+
+	// Offline serves the stale entry without refreshing it
+	result, err = newChecker(newStaleStore(core.AvailabilityTaken), policy).Check(core.WithOffline(context.Background()), "example")
+	require.NoError(t, err)
+	require.True(t, result.Provenance.CacheStale)
+	revalidator.Wait()
+	require.Equal(t, int32(2), hits.Load())
+}
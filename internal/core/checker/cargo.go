@@ -33,6 +33,13 @@ type CargoChecker struct {
 
 // Check performs a crates.io availability check.
 func (c *CargoChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	started := time.Now()
+	result, err := c.check(ctx, name)
+	observeCheck(core.CheckTypeCargo, result, started)
+	return result, err
+}
+
+func (c *CargoChecker) check(ctx context.Context, name string) (*core.CheckResult, error) {
 	if c == nil || c.Store == nil {
 		return nil, errors.New("cargo checker is not configured")
 	}
@@ -50,13 +57,18 @@ func (c *CargoChecker) Check(ctx context.Context, name string) (*core.CheckResul
 
 	requestedAt := c.now()
 
-	if c.UseCache {
-		if cached, err := c.Store.GetCachedResult(ctx, value, core.CheckTypeCargo, ""); err == nil && cached != nil {
+	if c.UseCache || core.IsOffline(ctx) {
+		refresh := func(ctx context.Context) { _, _ = c.Check(ctx, name) }
+		if cached := cachedResult(ctx, c.Store, c.CachePolicy, value, core.CheckTypeCargo, "", refresh); cached != nil {
 			cached.Name = value
 			cached.Provenance.FromCache = true
+			cached.Provenance.Source = cargoSource
 			return cached, nil
 		}
 	}
+	if core.IsOffline(ctx) {
+		return c.result(value, core.AvailabilityUnknown, 0, core.OfflineMessage, nil, requestedAt, c.now(), ""), nil
+	}
 
 	baseURL := c.baseURL()
 	endpoint := baseURL.Hostname()
@@ -156,7 +168,7 @@ func (c *CargoChecker) cacheResult(ctx context.Context, name string, result *cor
 		return
 	}
 
-	ttl := cacheTTL(c.CachePolicy, result.Available)
+	ttl := cacheTTL(ctx, c.CachePolicy, result.Available)
 	if ttl <= 0 {
 		return
 	}
@@ -0,0 +1,369 @@
+package checker
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+const catalogSource = "catalog"
+
+// Catalog formats.
+const (
+	CatalogFormatBackstage = "backstage"
+	CatalogFormatCSV       = "csv"
+)
+
+// CatalogEntry is a service, API, or other entity in an internal service
+// catalog.
+type CatalogEntry struct {
+	Name  string
+	Title string
+	// Kind is the entity type, e.g. "service" or "api".
+	Kind  string
+	Owner string
+}
+
+// backstageEntity holds the catalog-info.yaml fields used for collisions.
+type backstageEntity struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name  string `yaml:"name"`
+		Title string `yaml:"title"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Type  string `yaml:"type"`
+		Owner string `yaml:"owner"`
+	} `yaml:"spec"`
+}
+
+// backstageSkipKinds are entity kinds that do not name a service.
+var backstageSkipKinds = map[string]bool{
+	"location": true,
+	"user":     true,
+	"group":    true,
+	"template": true,
+}
+
+// LoadCatalog reads catalog entries from a file or, recursively, from the
+// .yaml, .yml, and .csv files of a directory. format is "backstage" or
+// "csv"; empty picks by file extension.
+func LoadCatalog(path, format string) ([]CatalogEntry, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("catalog path is required")
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != "" && format != CatalogFormatBackstage && format != CatalogFormatCSV {
+		return nil, fmt.Errorf("unsupported catalog format %q (want backstage or csv)", format)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("read catalog: %w", err)
+	}
+	if !info.IsDir() {
+		return loadCatalogFile(path, catalogFileFormat(path, format))
+	}
+
+	var entries []CatalogEntry
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml", ".csv":
+		default:
+			return nil
+		}
+		loaded, err := loadCatalogFile(file, catalogFileFormat(file, format))
+		if err != nil {
+			return err
+		}
+		entries = append(entries, loaded...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func catalogFileFormat(path, format string) string {
+	if format != "" {
+		return format
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return CatalogFormatCSV
+	}
+	return CatalogFormatBackstage
+}
+
+func loadCatalogFile(path, format string) ([]CatalogEntry, error) {
+	file, err := os.Open(path) // #nosec G304 -- user-configured catalog path
+	if err != nil {
+		return nil, fmt.Errorf("read catalog: %w", err)
+	}
+	defer file.Close() // nolint:errcheck // read-only file
+
+	var entries []CatalogEntry
+	if format == CatalogFormatCSV {
+		entries, err = parseCSVCatalog(file)
+	} else {
+		entries, err = parseBackstageCatalog(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse catalog %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// parseBackstageCatalog reads the entities of a multi-document
+// catalog-info.yaml, skipping users, groups, locations, and templates.
+func parseBackstageCatalog(r io.Reader) ([]CatalogEntry, error) {
+	decoder := yaml.NewDecoder(r)
+	var entries []CatalogEntry
+	for {
+		var entity backstageEntity
+		err := decoder.Decode(&entity)
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		kind := strings.ToLower(strings.TrimSpace(entity.Kind))
+		name := strings.TrimSpace(entity.Metadata.Name)
+		if name == "" || backstageSkipKinds[kind] {
+			continue
+		}
+		if entityType := strings.TrimSpace(entity.Spec.Type); entityType != "" && kind == "component" {
+			kind = strings.ToLower(entityType)
+		}
+		entries = append(entries, CatalogEntry{
+			Name:  name,
+			Title: strings.TrimSpace(entity.Metadata.Title),
+			Kind:  kind,
+			Owner: entityRefName(entity.Spec.Owner),
+		})
+	}
+}
+
+// entityRefName returns the name of a Backstage entity reference such as
+// group:default/team-payments.
+func entityRefName(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if _, rest, ok := strings.Cut(ref, ":"); ok {
+		ref = rest
+	}
+	if i := strings.LastIndex(ref, "/"); i >= 0 {
+		ref = ref[i+1:]
+	}
+	return ref
+}
+
+// parseCSVCatalog reads a CSV with a header row naming a required name column
+// and optional owner, kind (or type), and title columns.
+func parseCSVCatalog(r io.Reader) ([]CatalogEntry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("csv catalog needs a name column")
+	}
+	if _, ok := columns["kind"]; !ok {
+		if i, ok := columns["type"]; ok {
+			columns["kind"] = i
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var entries []CatalogEntry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := field(record, "name")
+		if name == "" {
+			continue
+		}
+		entries = append(entries, CatalogEntry{
+			Name:  name,
+			Title: field(record, "title"),
+			Kind:  strings.ToLower(field(record, "kind")),
+			Owner: field(record, "owner"),
+		})
+	}
+}
+
+// CatalogChecker reports a name as taken when it collides with an entry in
+// an internal service catalog. Names match ignoring case and punctuation, so
+// payments-api collides with PaymentsAPI.
+type CatalogChecker struct {
+	Key         string
+	Path        string
+	ToolVersion string
+	Clock       func() time.Time
+
+	index map[string][]CatalogEntry
+}
+
+// NewCatalogChecker indexes entries by name and title.
+func NewCatalogChecker(key, path string, entries []CatalogEntry) *CatalogChecker {
+	index := map[string][]CatalogEntry{}
+	for _, entry := range entries {
+		keys := []string{catalogKey(entry.Name)}
+		if title := catalogKey(entry.Title); title != "" && title != keys[0] {
+			keys = append(keys, title)
+		}
+		for _, key := range keys {
+			if key != "" {
+				index[key] = append(index[key], entry)
+			}
+		}
+	}
+	return &CatalogChecker{
+		Key:   strings.ToLower(strings.TrimSpace(key)),
+		Path:  path,
+		index: index,
+	}
+}
+
+// Check looks the name up in the catalog.
+func (c *CatalogChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	if c == nil {
+		return nil, errors.New("catalog checker is not configured")
+	}
+	value := strings.TrimSpace(name)
+	if !c.SupportsName(value) {
+		return nil, fmt.Errorf("unsupported %s name: %q", c.Key, name)
+	}
+
+	requestedAt := c.now()
+	matches := c.index[catalogKey(value)]
+	if len(matches) == 0 {
+		return c.result(value, core.AvailabilityAvailable, "no internal service named "+value, nil, requestedAt), nil
+	}
+
+	collisions := make([]string, 0, len(matches))
+	for _, entry := range matches {
+		collisions = append(collisions, catalogCollision(entry))
+	}
+	first := matches[0]
+	extra := map[string]any{
+		"catalog": c.Key,
+		"service": first.Name,
+	}
+	if first.Owner != "" {
+		extra["owner"] = first.Owner
+	}
+	if first.Kind != "" {
+		extra["kind"] = first.Kind
+	}
+	return c.result(value, core.AvailabilityTaken, strings.Join(collisions, "; "), extra, requestedAt), nil
+}
+
+// Type returns the configured catalog name as the check type.
+func (c *CatalogChecker) Type() core.CheckType {
+	return core.CheckType(c.Key)
+}
+
+// SupportsName accepts any name with a letter or digit.
+func (c *CatalogChecker) SupportsName(name string) bool {
+	return catalogKey(name) != ""
+}
+
+// catalogCollision describes a match, e.g. "collides with internal service
+// payments-api owned by team-payments".
+func catalogCollision(entry CatalogEntry) string {
+	kind := entry.Kind
+	if kind == "" {
+		kind = "service"
+	}
+	message := fmt.Sprintf("collides with internal %s %s", kind, entry.Name)
+	if entry.Owner != "" {
+		message += " owned by " + entry.Owner
+	}
+	return message
+}
+
+// catalogKey lowercases name and drops everything but letters and digits.
+func catalogKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func (c *CatalogChecker) result(name string, availability core.Availability, message string, extra map[string]any, requestedAt time.Time) *core.CheckResult {
+	return &core.CheckResult{
+		Name:      name,
+		CheckType: c.Type(),
+		Available: availability,
+		Message:   message,
+		ExtraData: extra,
+		Provenance: core.Provenance{
+			CheckID:     uuid.New().String(),
+			RequestedAt: requestedAt,
+			ResolvedAt:  c.now(),
+			Source:      catalogSource,
+			Server:      c.Path,
+			ToolVersion: c.toolVersion(),
+		},
+	}
+}
+
+func (c *CatalogChecker) now() time.Time {
+	if c != nil && c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now().UTC()
+}
+
+func (c *CatalogChecker) toolVersion() string {
+	if c != nil && c.ToolVersion != "" {
+		return c.ToolVersion
+	}
+	return "unknown"
+}
@@ -0,0 +1,86 @@
+package checker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+const testBackstageCatalog = `apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: payments-api
+  title: Payments API
+spec:
+  type: service
+  owner: group:default/team-payments
+---
+apiVersion: backstage.io/v1alpha1
+kind: API
+metadata:
+  name: ledger
+spec:
+  owner: team-finance
+---
+apiVersion: backstage.io/v1alpha1
+kind: Group
+metadata:
+  name: team-payments
+`
+
+func TestLoadCatalogBackstageAndCSV(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "payments"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "payments", "catalog-info.yaml"), []byte(testBackstageCatalog), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "services.csv"), []byte("Name,Owner,Type\nbilling,team-billing,worker\n,nobody,\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a catalog"), 0o600))
+
+	entries, err := LoadCatalog(dir, "")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []CatalogEntry{
+		{Name: "payments-api", Title: "Payments API", Kind: "service", Owner: "team-payments"},
+		{Name: "ledger", Kind: "api", Owner: "team-finance"},
+		{Name: "billing", Kind: "worker", Owner: "team-billing"},
+	}, entries)
+
+	_, err = LoadCatalog(filepath.Join(dir, "services.csv"), "xml")
+	require.Error(t, err)
+	_, err = LoadCatalog(filepath.Join(dir, "missing.yaml"), "")
+	require.Error(t, err)
+
+	bad := filepath.Join(dir, "bad.csv")
+	require.NoError(t, os.WriteFile(bad, []byte("service,owner\nx,y\n"), 0o600))
+	_, err = LoadCatalog(bad, "")
+	require.ErrorContains(t, err, "name column")
+}
+
+func TestCatalogCheckerCollisions(t *testing.T) {
+	checker := NewCatalogChecker("Internal", "catalog.yaml", []CatalogEntry{
+		{Name: "payments-api", Title: "Payments API", Kind: "service", Owner: "team-payments"},
+		{Name: "ledger"},
+	})
+	require.Equal(t, core.CheckType("internal"), checker.Type())
+
+	result, err := checker.Check(context.Background(), "PaymentsAPI")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.Equal(t, "collides with internal service payments-api owned by team-payments", result.Message)
+	require.Equal(t, "team-payments", result.ExtraData["owner"])
+	require.Equal(t, "internal", result.ExtraData["catalog"])
+	require.Equal(t, "catalog", result.Provenance.Source)
+
+	result, err = checker.Check(context.Background(), "ledger")
+	require.NoError(t, err)
+	require.Equal(t, "collides with internal service ledger", result.Message)
+
+	result, err = checker.Check(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+
+	require.False(t, checker.SupportsName("--"))
+}
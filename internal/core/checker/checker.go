@@ -2,8 +2,10 @@ package checker
 
 import (
 	"context"
+	"time"
 
 	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/metrics"
 )
 
 // Checker is the interface all availability checkers implement.
@@ -17,3 +19,13 @@ type Checker interface {
 	// SupportsName returns true if this checker can handle the name.
 	SupportsName(name string) bool
 }
+
+// observeCheck records checker metrics for a finished check. A check that
+// failed without a result counts as an error.
+func observeCheck(checkType core.CheckType, result *core.CheckResult, started time.Time) {
+	if result == nil {
+		metrics.RecordCheck(string(checkType), "", core.AvailabilityError.String(), false, time.Since(started))
+		return
+	}
+	metrics.RecordCheck(string(checkType), result.Provenance.Source, result.Available.String(), result.Provenance.FromCache, time.Since(started))
+}
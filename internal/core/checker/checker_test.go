@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/fulmenhq/gofulmen/telemetry"
+	telemetrytesting "github.com/fulmenhq/gofulmen/telemetry/testing"
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/metrics"
+	"github.com/namelens/namelens/internal/observability"
+)
+
+func setupCheckerTelemetry(t *testing.T) *telemetrytesting.FakeCollector {
+	t.Helper()
+
+	collector := telemetrytesting.NewFakeCollector()
+	sys, err := telemetry.NewSystem(&telemetry.Config{Enabled: true, Emitter: collector})
+	require.NoError(t, err)
+
+	original := observability.TelemetrySystem
+	observability.TelemetrySystem = sys
+	t.Cleanup(func() {
+		observability.TelemetrySystem = original
+	})
+	return collector
+}
+
+func TestCheckerMetrics(t *testing.T) {
+	collector := setupCheckerTelemetry(t)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer fallback.Close()
+
+	domain := &DomainChecker{
+		Store:         &stubBootstrapStore{},
+		RDAPOverrides: map[string][]string{"dev": {failing.URL, fallback.URL}},
+	}
+	_, err := domain.Check(context.Background(), "example.dev")
+	require.NoError(t, err)
+
+	checks := collector.GetMetricsByName(metrics.ChecksTotal)
+	require.Len(t, checks, 1)
+	require.Equal(t, map[string]string{"type": "domain", "source": rdapSource, "availability": "available"}, checks[0].Tags)
+	require.Equal(t, 1, collector.CountMetricsByName(metrics.CheckDuration))
+
+	serverErrors := collector.GetMetricsByName(metrics.RDAPServerErrorsTotal)
+	require.Len(t, serverErrors, 1)
+	failingURL, err := url.Parse(failing.URL)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"server": failingURL.Hostname(), "status": "500"}, serverErrors[0].Tags)
+
+	// A cache hit counts as a check and a cache hit, without a duration
+	collector.Reset()
+	npm := &NPMChecker{
+		Store: &stubRegistryStore{cached: map[string]*core.CheckResult{
+			"example" + string(core.CheckTypeNPM): {Name: "example", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken},
+		}},
+		UseCache: true,
+	}
+	_, err = npm.Check(context.Background(), "example")
+	require.NoError(t, err)
+
+	checks = collector.GetMetricsByName(metrics.ChecksTotal)
+	require.Len(t, checks, 1)
+	require.Equal(t, map[string]string{"type": "npm", "source": npmSource, "availability": "taken"}, checks[0].Tags)
+	require.Equal(t, 1, collector.CountMetricsByName(metrics.CacheHitsTotal))
+	require.Zero(t, collector.CountMetricsByName(metrics.CheckDuration))
+}
@@ -4,17 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/openrdap/rdap"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/namelens/namelens/internal/core"
 	"github.com/namelens/namelens/internal/core/engine"
+	"github.com/namelens/namelens/internal/metrics"
+	"github.com/namelens/namelens/internal/tracing"
 )
 
 const rdapSource = "rdap"
@@ -37,10 +42,41 @@ type DomainChecker struct {
 	Whois       WhoisClient
 	WhoisCfg    WhoisFallbackConfig
 	DNSCfg      DNSFallbackConfig
+	// DNS resolves fallback records; nil uses net.DefaultResolver.
+	DNS DNSResolver
+	// Dial opens WHOIS connections; nil uses a net.Dialer.
+	Dial func(ctx context.Context, network, address string) (net.Conn, error)
+	// Probe sends the HTTP liveness probe used by Verify; nil uses a client
+	// with a 5s timeout.
+	Probe *http.Client
 
 	// RDAPOverrides allows routing specific TLDs to known-good RDAP servers.
 	// Keys are normalized TLDs without a leading dot.
 	RDAPOverrides map[string][]string
+
+	// Retry applies to transient failures from every RDAP server;
+	// RetryEndpoints overrides it per server host (lowercase).
+	Retry          RetryPolicy
+	RetryEndpoints map[string]RetryPolicy
+	// Sleep waits between retries; nil uses a context-aware timer.
+	Sleep func(ctx context.Context, d time.Duration) error
+
+	// BootstrapMaxAge marks RDAP results routed with older bootstrap data as
+	// stale in provenance; zero never marks them stale.
+	BootstrapMaxAge time.Duration
+
+	// DropList marks taken domains that are on a pending-delete list; nil
+	// skips the lookup.
+	DropList *DropList
+
+	bootstrapOnce      sync.Once
+	bootstrapFetchedAt time.Time
+
+	whoisOnce    sync.Once
+	defaultWhois *DefaultWhoisClient
+
+	wildcardMu sync.Mutex
+	wildcards  map[string]dnsRecordSet
 }
 
 // DomainStore combines bootstrap, cache, and rate limit persistence.
@@ -65,6 +101,16 @@ func (d *DomainChecker) SupportsName(name string) bool {
 
 // Check performs a domain availability check using RDAP.
 func (d *DomainChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	started := time.Now()
+	result, err := d.check(ctx, name)
+	if err == nil {
+		d.annotateDropList(ctx, result)
+	}
+	observeCheck(core.CheckTypeDomain, result, started)
+	return result, err
+}
+
+func (d *DomainChecker) check(ctx context.Context, name string) (*core.CheckResult, error) {
 	if d == nil || d.Store == nil {
 		return nil, errors.New("domain checker is not configured")
 	}
@@ -85,16 +131,19 @@ func (d *DomainChecker) Check(ctx context.Context, name string) (*core.CheckResu
 		return nil, err
 	}
 
+	fromBootstrap := true
 	if override := d.rdapOverrideServers(tld); len(override) > 0 {
 		servers = override
+		fromBootstrap = false
 	}
 
 	rdapAvailable := len(servers) > 0
 	whoisAllowed := d.whoisAllowed(tld)
 	dnsAllowed := d.DNSCfg.Enabled
 
-	if d.UseCache {
-		if cached, err := d.Store.GetCachedResult(ctx, baseName, core.CheckTypeDomain, tld); err == nil && cached != nil {
+	if d.UseCache || core.IsOffline(ctx) {
+		refresh := func(ctx context.Context) { _, _ = d.Check(ctx, name) }
+		if cached := cachedResult(ctx, d.Store, d.CachePolicy, baseName, core.CheckTypeDomain, tld, refresh); cached != nil {
 			source := cachedResolutionSource(cached)
 			if d.cacheAllowed(source, rdapAvailable, whoisAllowed, dnsAllowed) {
 				cached.Name = name
@@ -131,6 +180,10 @@ func (d *DomainChecker) Check(ctx context.Context, name string) (*core.CheckResu
 		}
 	}
 
+	if core.IsOffline(ctx) {
+		return d.result(name, tld, core.AvailabilityUnknown, 0, core.OfflineMessage, nil, requestedAt, d.now(), "", ""), nil
+	}
+
 	if !rdapAvailable {
 		if whoisAllowed {
 			result := d.checkWhois(ctx, name, tld, requestedAt)
@@ -151,6 +204,8 @@ func (d *DomainChecker) Check(ctx context.Context, name string) (*core.CheckResu
 	}
 
 	var lastResult *core.CheckResult
+	attempts, queried := 0, 0
+servers:
 	for i, serverBase := range servers {
 		serverURL, err := url.Parse(serverBase)
 		if err != nil {
@@ -158,36 +213,63 @@ func (d *DomainChecker) Check(ctx context.Context, name string) (*core.CheckResu
 		}
 		endpoint := serverURL.Hostname()
 		rdapRequestURL := rdapDomainURL(serverURL, name)
+		policy := d.retryPolicy(endpoint)
+
+		var (
+			resp       *rdap.Response
+			reqErr     error
+			statusCode int
+			server     string
+		)
+		for attempt := 1; ; attempt++ {
+			if attempt > 1 {
+				if err := d.waitRetry(ctx, policy.backoff(attempt-1, retryJitter())); err != nil {
+					break
+				}
+			}
 
-		if d.Limiter != nil && endpoint != "" {
-			allowed, wait, err := d.Limiter.Allow(ctx, endpoint)
-			if err != nil {
-				return nil, err
+			if d.Limiter != nil && endpoint != "" {
+				allowed, wait, err := d.Limiter.Allow(ctx, endpoint)
+				if err != nil {
+					return nil, err
+				}
+				if !allowed {
+					if attempt == 1 {
+						lastResult = d.result(name, tld, core.AvailabilityRateLimited, 429, fmt.Sprintf("rate limited, retry in %s", wait.Round(time.Second)), nil, requestedAt, d.now(), rdapSource, rdapRequestURL)
+						continue servers
+					}
+					// Out of budget for retries; report the last failure.
+					break
+				}
 			}
-			if !allowed {
-				lastResult = d.result(name, tld, core.AvailabilityRateLimited, 429, fmt.Sprintf("rate limited, retry in %s", wait.Round(time.Second)), nil, requestedAt, d.now(), rdapSource, rdapRequestURL)
-				continue
+
+			req := rdap.NewDomainRequest(name).WithServer(serverURL)
+			if d.Timeout > 0 {
+				req.Timeout = d.Timeout
 			}
-		}
+			req = req.WithContext(ctx)
 
-		req := rdap.NewDomainRequest(name).WithServer(serverURL)
-		if d.Timeout > 0 {
-			req.Timeout = d.Timeout
-		}
-		req = req.WithContext(ctx)
+			if d.Limiter != nil && endpoint != "" {
+				if err := d.Limiter.Record(ctx, endpoint); err != nil {
+					return nil, err
+				}
+			}
 
-		if d.Limiter != nil && endpoint != "" {
-			if err := d.Limiter.Record(ctx, endpoint); err != nil {
-				return nil, err
+			if attempt == 1 {
+				queried++
+			}
+			attempts++
+			resp, statusCode, server, reqErr = doRDAP(ctx, client, req, endpoint, attempt, rdapRequestURL)
+			if attempt >= policy.attempts() || !retryableRDAP(ctx, resp, reqErr, statusCode) {
+				break
 			}
 		}
 
-		resp, reqErr := client.Do(req)
-		statusCode, server := responseStatus(resp, rdapRequestURL)
-
 		if reqErr != nil {
 			if isNotFound(reqErr) || statusCode == 404 {
 				result := d.result(name, tld, core.AvailabilityAvailable, statusCode, "rdap not found", nil, requestedAt, d.now(), rdapSource, server)
+				d.annotateRDAP(ctx, result, attempts, fromBootstrap)
+				annotateServers(result, queried)
 				d.cacheResult(ctx, baseName, result)
 				return result, nil
 			}
@@ -201,6 +283,9 @@ func (d *DomainChecker) Check(ctx context.Context, name string) (*core.CheckResu
 				continue
 			}
 
+			if ctx.Err() == nil {
+				metrics.RecordRDAPServerError(endpoint, statusCode)
+			}
 			if statusCode >= 500 && statusCode <= 599 {
 				lastResult = d.result(name, tld, core.AvailabilityError, statusCode, "rdap server error", nil, requestedAt, d.now(), rdapSource, server)
 				continue
@@ -212,7 +297,10 @@ func (d *DomainChecker) Check(ctx context.Context, name string) (*core.CheckResu
 
 		if domain, ok := resp.Object.(*rdap.Domain); ok {
 			extra := domainExtra(domain)
+			addDropWindow(extra, tld, d.now())
 			result := d.result(name, tld, core.AvailabilityTaken, statusCode, "domain found", extra, requestedAt, d.now(), rdapSource, server)
+			d.annotateRDAP(ctx, result, attempts, fromBootstrap)
+			annotateServers(result, queried)
 			d.cacheResult(ctx, baseName, result)
 			return result, nil
 		}
@@ -227,6 +315,7 @@ func (d *DomainChecker) Check(ctx context.Context, name string) (*core.CheckResu
 	if lastResult == nil {
 		lastResult = d.result(name, tld, core.AvailabilityError, 0, fmt.Sprintf("no rdap servers responded successfully (tried %d server(s))", len(servers)), nil, requestedAt, d.now(), rdapSource, "")
 	}
+	d.annotateRDAP(ctx, lastResult, attempts, fromBootstrap)
 	d.cacheResult(ctx, baseName, lastResult)
 	return lastResult, nil
 }
@@ -261,6 +350,33 @@ func (d *DomainChecker) result(name, tld string, availability core.Availability,
 	}
 }
 
+// annotateServers records that the last of the queried servers returned the
+// verdict; earlier servers failed to answer.
+func annotateServers(result *core.CheckResult, queried int) {
+	result.Provenance.ServersConsulted = queried
+	result.Provenance.ServersAgreed = 1
+}
+
+// annotateRDAP records request attempts and, for servers taken from the
+// bootstrap data, how fresh that data was.
+func (d *DomainChecker) annotateRDAP(ctx context.Context, result *core.CheckResult, attempts int, fromBootstrap bool) {
+	result.Provenance.Attempts = attempts
+	if !fromBootstrap {
+		return
+	}
+	d.bootstrapOnce.Do(func() {
+		if value, err := d.Store.GetBootstrapMeta(ctx, bootstrapMetaFetchedAt); err == nil {
+			d.bootstrapFetchedAt = parseTime(value)
+		}
+	})
+	if d.bootstrapFetchedAt.IsZero() {
+		return
+	}
+	fetchedAt := d.bootstrapFetchedAt
+	result.Provenance.BootstrapFetchedAt = &fetchedAt
+	result.Provenance.BootstrapStale = d.BootstrapMaxAge > 0 && d.now().Sub(fetchedAt) > d.BootstrapMaxAge
+}
+
 func (d *DomainChecker) now() time.Time {
 	if d != nil && d.Clock != nil {
 		return d.Clock()
@@ -268,6 +384,28 @@ func (d *DomainChecker) now() time.Time {
 	return time.Now().UTC()
 }
 
+// doRDAP sends one RDAP request in a span and returns the response with its
+// status code and server URL.
+func doRDAP(ctx context.Context, client *rdap.Client, req *rdap.Request, endpoint string, attempt int, requestURL string) (*rdap.Response, int, string, error) {
+	_, span := tracing.Start(ctx, "rdap.query",
+		attribute.String("server.address", endpoint),
+		attribute.String("url.full", requestURL),
+		attribute.Int("namelens.attempt", attempt),
+	)
+	resp, err := client.Do(req)
+	statusCode, server := responseStatus(resp, requestURL)
+	if statusCode > 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+	}
+	spanErr := err
+	if isNotFound(err) || statusCode == 404 {
+		// Not found is the answer for an available domain, not a failure
+		spanErr = nil
+	}
+	tracing.End(span, spanErr)
+	return resp, statusCode, server, err
+}
+
 func rdapDomainURL(server *url.URL, domain string) string {
 	if server == nil {
 		return ""
@@ -362,6 +500,7 @@ func domainExtra(domain *rdap.Domain) map[string]any {
 	extra := map[string]any{}
 	if len(domain.Status) > 0 {
 		extra["status"] = domain.Status
+		extra["status_details"] = core.ExplainDomainStatuses(domain.Status)
 	}
 
 	registrar := findRegistrar(domain)
@@ -369,13 +508,33 @@ func domainExtra(domain *rdap.Domain) map[string]any {
 		extra["registrar"] = registrar
 	}
 
+	if created := findEventDate(domain.Events, "registration"); created != "" {
+		extra["creation"] = created
+	}
+
+	if changed := findEventDate(domain.Events, "last changed"); changed != "" {
+		extra["last_changed"] = changed
+	}
+
 	if expiry := findEventDate(domain.Events, "expiration"); expiry != "" {
 		extra["expiration"] = expiry
 	}
 
+	if deletion := findEventDate(domain.Events, "deletion"); deletion != "" {
+		extra["deletion"] = deletion
+	}
+
 	return extra
 }
 
+// addDropWindow records when a domain in redemption or pendingDelete is
+// expected to be released.
+func addDropWindow(extra map[string]any, tld string, checkedAt time.Time) {
+	if window, ok := core.PredictDrop(tld, extra, checkedAt); ok {
+		extra["drop_window"] = core.DropWindowExtra(window)
+	}
+}
+
 func findRegistrar(domain *rdap.Domain) string {
 	if domain == nil {
 		return ""
@@ -419,7 +578,7 @@ func (d *DomainChecker) cacheResult(ctx context.Context, name string, result *co
 		return
 	}
 
-	ttl := d.cacheTTL(result)
+	ttl := d.cacheTTL(ctx, result)
 	if ttl <= 0 {
 		return
 	}
@@ -427,10 +586,14 @@ func (d *DomainChecker) cacheResult(ctx context.Context, name string, result *co
 	_ = d.Store.SetCachedResult(ctx, name, result, ttl)
 }
 
-func (d *DomainChecker) cacheTTL(result *core.CheckResult) time.Duration {
+func (d *DomainChecker) cacheTTL(ctx context.Context, result *core.CheckResult) time.Duration {
 	if result == nil {
 		return 0
 	}
+	definitive := result.Available == core.AvailabilityAvailable || result.Available == core.AvailabilityTaken
+	if engine.IsRevalidating(ctx) && !definitive {
+		return 0
+	}
 	switch result.Provenance.Source {
 	case whoisSource:
 		if d.WhoisCfg.CacheTTL > 0 {
@@ -441,7 +604,7 @@ func (d *DomainChecker) cacheTTL(result *core.CheckResult) time.Duration {
 			return d.DNSCfg.CacheTTL
 		}
 	}
-	return cacheTTL(d.CachePolicy, result.Available)
+	return cacheTTL(ctx, d.CachePolicy, result.Available)
 }
 
 func httpParseTime(value string) (time.Time, error) {
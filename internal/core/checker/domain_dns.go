@@ -0,0 +1,256 @@
+package checker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/namelens/namelens/internal/audit"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/tracing"
+)
+
+// DNS record types queried by the DNS fallback.
+const (
+	dnsRecordNS = "ns"
+	dnsRecordA  = "a"
+	dnsRecordMX = "mx"
+)
+
+var defaultDNSRecords = []string{dnsRecordNS, dnsRecordA, dnsRecordMX}
+
+// DNSResolver performs the lookups used by the DNS fallback. *net.Resolver
+// satisfies it.
+type DNSResolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// dnsRecordSet maps a record type to the values found, sorted.
+type dnsRecordSet map[string][]string
+
+func (d *DomainChecker) checkDNS(ctx context.Context, name, tld string, requestedAt time.Time) *core.CheckResult {
+	if d.DNSCfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.DNSCfg.Timeout)
+		defer cancel()
+	}
+
+	records, notFound, err := d.lookupDNS(ctx, name)
+	if err != nil {
+		return d.result(name, tld, core.AvailabilityError, 0, fmt.Sprintf("dns lookup failed: %v", err), nil, requestedAt, d.now(), dnsSource, "")
+	}
+	if len(records) == 0 {
+		if notFound {
+			extra := map[string]any{"dns_status": "nxdomain"}
+			return d.result(name, tld, core.AvailabilityUnknown, 0, "dns nxdomain (non-authoritative)", extra, requestedAt, d.now(), dnsSource, "")
+		}
+		extra := map[string]any{"dns_status": "no_records"}
+		return d.result(name, tld, core.AvailabilityUnknown, 0, "dns no records (non-authoritative)", extra, requestedAt, d.now(), dnsSource, "")
+	}
+
+	extra := map[string]any{"dns_records": map[string][]string(records)}
+
+	var wildcardTypes []string
+	if d.DNSCfg.DetectWildcard {
+		if wildcard := d.tldWildcard(ctx, tld); len(wildcard) > 0 {
+			extra["dns_wildcard"] = true
+			wildcardTypes = records.coveredBy(wildcard)
+			if len(wildcardTypes) > 0 {
+				extra["dns_wildcard_records"] = wildcardTypes
+			}
+		}
+	}
+
+	found := records.typesExcept(wildcardTypes)
+	if len(found) == 0 {
+		extra["dns_status"] = "wildcard"
+		return d.result(name, tld, core.AvailabilityUnknown, 0, "dns wildcard match only (non-authoritative)", extra, requestedAt, d.now(), dnsSource, "")
+	}
+
+	extra["dns_status"] = "records_present"
+	extra["dns_records_found"] = found
+	message := fmt.Sprintf("dns records present: %s (non-authoritative)", strings.Join(found, ", "))
+	return d.result(name, tld, core.AvailabilityTaken, 0, message, extra, requestedAt, d.now(), dnsSource, "")
+}
+
+// lookupDNS queries the configured record types. notFound reports whether every
+// lookup returned NXDOMAIN; err is set only when every lookup failed otherwise.
+func (d *DomainChecker) lookupDNS(ctx context.Context, name string) (dnsRecordSet, bool, error) {
+	resolver := d.DNS
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	types := d.DNSCfg.Records
+	if len(types) == 0 {
+		types = defaultDNSRecords
+	}
+
+	records := dnsRecordSet{}
+	var firstErr error
+	notFound, failed := 0, 0
+	for _, recordType := range types {
+		recordType = strings.ToLower(strings.TrimSpace(recordType))
+		values, err := lookupDNSRecord(ctx, resolver, recordType, name)
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				notFound++
+				continue
+			}
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if len(values) > 0 {
+			sort.Strings(values)
+			records[recordType] = values
+		}
+	}
+
+	if len(records) == 0 && failed > 0 && notFound == 0 {
+		return nil, false, firstErr
+	}
+	return records, len(records) == 0 && notFound > 0 && failed == 0, nil
+}
+
+func lookupDNSRecord(ctx context.Context, resolver DNSResolver, recordType, name string) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "dns.lookup",
+		attribute.String("dns.question.type", recordType),
+		attribute.String("dns.question.name", name),
+	)
+	start := time.Now()
+	values, err := resolveDNSRecord(ctx, resolver, recordType, name)
+	recordLookup(ctx, audit.KindDNS, "dns:"+name+"?type="+strings.ToUpper(recordType), start, err)
+	span.SetAttributes(attribute.Int("namelens.records", len(values)))
+	spanErr := err
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		// NXDOMAIN is an answer, not a failure
+		spanErr = nil
+	}
+	tracing.End(span, spanErr)
+	return values, err
+}
+
+func resolveDNSRecord(ctx context.Context, resolver DNSResolver, recordType, name string) ([]string, error) {
+	values := make([]string, 0)
+	switch recordType {
+	case dnsRecordNS:
+		records, err := resolver.LookupNS(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			values = append(values, strings.TrimSuffix(strings.ToLower(record.Host), "."))
+		}
+	case dnsRecordA:
+		return resolver.LookupHost(ctx, name)
+	case dnsRecordMX:
+		records, err := resolver.LookupMX(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			values = append(values, strings.TrimSuffix(strings.ToLower(record.Host), "."))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported dns record type %q", recordType)
+	}
+	return values, nil
+}
+
+// tldWildcard returns the records a random, unregistered label under the TLD
+// resolves to. A non-empty set means the registry serves wildcard records.
+// Probes are cached per TLD for the life of the checker.
+func (d *DomainChecker) tldWildcard(ctx context.Context, tld string) dnsRecordSet {
+	d.wildcardMu.Lock()
+	if cached, ok := d.wildcards[tld]; ok {
+		d.wildcardMu.Unlock()
+		return cached
+	}
+	d.wildcardMu.Unlock()
+
+	label := make([]byte, 8)
+	if _, err := rand.Read(label); err != nil {
+		return nil
+	}
+	probe, _, err := d.lookupDNS(ctx, "namelens-probe-"+hex.EncodeToString(label)+"."+tld)
+	if err != nil || ctx.Err() != nil {
+		// Do not cache inconclusive probes.
+		return nil
+	}
+
+	d.wildcardMu.Lock()
+	defer d.wildcardMu.Unlock()
+	if d.wildcards == nil {
+		d.wildcards = make(map[string]dnsRecordSet)
+	}
+	d.wildcards[tld] = probe
+	return probe
+}
+
+// coveredBy lists the record types whose values all appear in the wildcard set.
+func (r dnsRecordSet) coveredBy(wildcard dnsRecordSet) []string {
+	covered := make([]string, 0)
+	for _, recordType := range r.types() {
+		wildcardValues := wildcard[recordType]
+		if len(wildcardValues) == 0 {
+			continue
+		}
+		if subset(r[recordType], wildcardValues) {
+			covered = append(covered, recordType)
+		}
+	}
+	return covered
+}
+
+func (r dnsRecordSet) types() []string {
+	return r.typesExcept(nil)
+}
+
+// typesExcept returns the record types present, in query order, skipping excluded ones.
+func (r dnsRecordSet) typesExcept(excluded []string) []string {
+	types := make([]string, 0, len(r))
+	for _, recordType := range defaultDNSRecords {
+		if _, ok := r[recordType]; !ok {
+			continue
+		}
+		skip := false
+		for _, ex := range excluded {
+			if ex == recordType {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			types = append(types, recordType)
+		}
+	}
+	return types
+}
+
+func subset(values, of []string) bool {
+	set := make(map[string]struct{}, len(of))
+	for _, value := range of {
+		set[value] = struct{}{}
+	}
+	for _, value := range values {
+		if _, ok := set[value]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,124 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// stubDNSResolver answers from fixed tables; names starting with
+// "namelens-probe-" get the wildcard answers.
+type stubDNSResolver struct {
+	ns, hosts, mx map[string][]string
+	wildcardHosts []string
+	probes        atomic.Int32
+	fail          bool
+}
+
+func (s *stubDNSResolver) answer(table map[string][]string, name string, wildcard []string) ([]string, error) {
+	if s.fail {
+		return nil, &net.DNSError{Err: "server misbehaving", Name: name, IsTemporary: true}
+	}
+	if strings.HasPrefix(name, "namelens-probe-") {
+		s.probes.Add(1)
+		if len(wildcard) > 0 {
+			return wildcard, nil
+		}
+	} else if values, ok := table[name]; ok {
+		return values, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (s *stubDNSResolver) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	values, err := s.answer(s.ns, name, nil)
+	records := make([]*net.NS, 0, len(values))
+	for _, v := range values {
+		records = append(records, &net.NS{Host: v})
+	}
+	return records, err
+}
+
+func (s *stubDNSResolver) LookupHost(_ context.Context, name string) ([]string, error) {
+	return s.answer(s.hosts, name, s.wildcardHosts)
+}
+
+func (s *stubDNSResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	values, err := s.answer(s.mx, name, nil)
+	records := make([]*net.MX, 0, len(values))
+	for _, v := range values {
+		records = append(records, &net.MX{Host: v})
+	}
+	return records, err
+}
+
+func TestCheckDNSReportsRecords(t *testing.T) {
+	checker := &DomainChecker{
+		DNS: &stubDNSResolver{
+			ns:    map[string][]string{"acme.ws": {"NS1.EXAMPLE.NET."}},
+			hosts: map[string][]string{"acme.ws": {"192.0.2.10"}},
+			mx:    map[string][]string{"acme.ws": {"mail.acme.ws."}},
+		},
+		DNSCfg: DNSFallbackConfig{Enabled: true, DetectWildcard: true},
+	}
+
+	result := checker.checkDNS(context.Background(), "acme.ws", "ws", time.Now())
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.Equal(t, "dns records present: ns, a, mx (non-authoritative)", result.Message)
+	require.Equal(t, "records_present", result.ExtraData["dns_status"])
+	require.Equal(t, []string{"ns", "a", "mx"}, result.ExtraData["dns_records_found"])
+	require.Equal(t, map[string][]string{
+		"ns": {"ns1.example.net"},
+		"a":  {"192.0.2.10"},
+		"mx": {"mail.acme.ws"},
+	}, result.ExtraData["dns_records"])
+	require.NotContains(t, result.ExtraData, "dns_wildcard")
+}
+
+func TestCheckDNSWildcardOnly(t *testing.T) {
+	resolver := &stubDNSResolver{
+		hosts:         map[string][]string{"acme.ws": {"192.0.2.99"}, "real.ws": {"192.0.2.10"}},
+		ns:            map[string][]string{"real.ws": {"ns1.example.net"}},
+		wildcardHosts: []string{"192.0.2.99"},
+	}
+	checker := &DomainChecker{
+		DNS:    resolver,
+		DNSCfg: DNSFallbackConfig{Enabled: true, DetectWildcard: true},
+	}
+
+	result := checker.checkDNS(context.Background(), "acme.ws", "ws", time.Now())
+	require.Equal(t, core.AvailabilityUnknown, result.Available, "wildcard answers are not evidence of registration")
+	require.Equal(t, "wildcard", result.ExtraData["dns_status"])
+	require.Equal(t, true, result.ExtraData["dns_wildcard"])
+	require.Equal(t, []string{"a"}, result.ExtraData["dns_wildcard_records"])
+
+	result = checker.checkDNS(context.Background(), "real.ws", "ws", time.Now())
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.Equal(t, []string{"ns", "a"}, result.ExtraData["dns_records_found"])
+	require.Equal(t, int32(3), resolver.probes.Load(), "wildcard probe runs once per TLD")
+
+	checker.DNSCfg.DetectWildcard = false
+	result = checker.checkDNS(context.Background(), "acme.ws", "ws", time.Now())
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+}
+
+func TestCheckDNSNoRecordsAndErrors(t *testing.T) {
+	checker := &DomainChecker{
+		DNS:    &stubDNSResolver{},
+		DNSCfg: DNSFallbackConfig{Enabled: true, Records: []string{"ns"}},
+	}
+	result := checker.checkDNS(context.Background(), "acme.ws", "ws", time.Now())
+	require.Equal(t, core.AvailabilityUnknown, result.Available)
+	require.Equal(t, "nxdomain", result.ExtraData["dns_status"])
+
+	checker.DNS = &stubDNSResolver{fail: true}
+	result = checker.checkDNS(context.Background(), "acme.ws", "ws", time.Now())
+	require.Equal(t, core.AvailabilityError, result.Available)
+}
@@ -2,9 +2,7 @@ package checker
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"net"
 	"strings"
 	"time"
 
@@ -66,13 +64,7 @@ func (d *DomainChecker) checkWhois(ctx context.Context, name, tld string, reques
 		defer cancel()
 	}
 
-	client := d.Whois
-	if client == nil {
-		client = &DefaultWhoisClient{
-			Servers: d.WhoisCfg.Servers,
-			Timeout: d.WhoisCfg.Timeout,
-		}
-	}
+	client := d.whoisClient()
 
 	server := ""
 	if resolver, ok := client.(WhoisResolver); ok {
@@ -135,33 +127,36 @@ func (d *DomainChecker) checkWhois(ctx context.Context, name, tld string, reques
 		"whois_server":   resp.Server,
 		"whois_raw_hash": whoisHash(resp.Body),
 	}
+	if availability != core.AvailabilityAvailable {
+		record := parseWhois(resp.Body)
+		// Registration data means the domain exists even when no taken
+		// pattern matched.
+		if availability == core.AvailabilityUnknown && (record.Registrar != "" || record.Created != "" || record.Expiration != "") {
+			availability, message = core.AvailabilityTaken, "whois found"
+		}
+		record.addExtra(extra)
+		addDropWindow(extra, tld, d.now())
+	}
 
 	result := d.result(name, tld, availability, 0, message, extra, requestedAt, d.now(), whoisSource, resp.Server)
 	return result
 }
 
-func (d *DomainChecker) checkDNS(ctx context.Context, name, tld string, requestedAt time.Time) *core.CheckResult {
-	if d.DNSCfg.Timeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, d.DNSCfg.Timeout)
-		defer cancel()
-	}
-
-	records, err := net.DefaultResolver.LookupNS(ctx, name)
-	if err != nil {
-		var dnsErr *net.DNSError
-		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
-			extra := map[string]any{"dns_status": "nxdomain"}
-			return d.result(name, tld, core.AvailabilityUnknown, 0, "dns nxdomain (non-authoritative)", extra, requestedAt, d.now(), dnsSource, "")
+// whoisClient returns Whois, or a default client shared by all of d's
+// lookups so its per-server pools pace them together.
+func (d *DomainChecker) whoisClient() WhoisClient {
+	if d.Whois != nil {
+		return d.Whois
+	}
+	d.whoisOnce.Do(func() {
+		d.defaultWhois = &DefaultWhoisClient{
+			Servers:      d.WhoisCfg.Servers,
+			Timeout:      d.WhoisCfg.Timeout,
+			Dial:         d.Dial,
+			MaxPerServer: d.WhoisCfg.MaxPerServer,
+			Delay:        d.WhoisCfg.Delay,
+			ServerDelays: d.WhoisCfg.ServerDelays,
 		}
-		return d.result(name, tld, core.AvailabilityError, 0, fmt.Sprintf("dns lookup failed: %v", err), nil, requestedAt, d.now(), dnsSource, "")
-	}
-
-	if len(records) == 0 {
-		extra := map[string]any{"dns_status": "no_records"}
-		return d.result(name, tld, core.AvailabilityUnknown, 0, "dns no records (non-authoritative)", extra, requestedAt, d.now(), dnsSource, "")
-	}
-
-	extra := map[string]any{"dns_status": "records_present"}
-	return d.result(name, tld, core.AvailabilityTaken, 0, "dns records present (non-authoritative)", extra, requestedAt, d.now(), dnsSource, "")
+	})
+	return d.defaultWhois
 }
@@ -15,6 +15,7 @@ import (
 type stubBootstrapStore struct {
 	servers map[string][]string
 	cached  map[string]*core.CheckResult
+	meta    map[string]string
 }
 
 func (s *stubBootstrapStore) SetRDAPServers(ctx context.Context, tld string, servers []string, updatedAt time.Time) error {
@@ -37,7 +38,7 @@ func (s *stubBootstrapStore) SetBootstrapMeta(ctx context.Context, key, value st
 }
 
 func (s *stubBootstrapStore) GetBootstrapMeta(ctx context.Context, key string) (string, error) {
-	return "", nil
+	return s.meta[key], nil
 }
 
 func (s *stubBootstrapStore) CountBootstrapTLDs(ctx context.Context) (int, error) {
@@ -198,6 +199,8 @@ func TestDomainCheckerRDAPOverrideFallbackServer(t *testing.T) {
 	require.Equal(t, core.AvailabilityAvailable, result.Available)
 	require.Equal(t, http.StatusNotFound, result.StatusCode)
 	require.Equal(t, fallback.URL+"/domain/example.dev", result.Provenance.Server)
+	require.Equal(t, 2, result.Provenance.ServersConsulted)
+	require.Equal(t, 1, result.Provenance.ServersAgreed)
 }
 
 func TestDomainCheckerRDAPOverrideCacheProvenance(t *testing.T) {
@@ -270,3 +273,53 @@ func TestDomainCheckerWhoisFallback(t *testing.T) {
 	require.Equal(t, core.AvailabilityAvailable, result.Available)
 	require.Equal(t, whoisSource, result.Provenance.Source)
 }
+
+func TestDomainCheckerBootstrapProvenance(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	store := &stubBootstrapStore{
+		servers: map[string][]string{"com": {server.URL}},
+		meta:    map[string]string{bootstrapMetaFetchedAt: "2025-01-01T00:00:00Z"},
+	}
+	checker := &DomainChecker{Store: store, BootstrapMaxAge: 7 * 24 * time.Hour, Clock: func() time.Time { return now }}
+
+	result, err := checker.Check(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.NotNil(t, result.Provenance.BootstrapFetchedAt)
+	require.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), *result.Provenance.BootstrapFetchedAt)
+	require.True(t, result.Provenance.BootstrapStale)
+
+	checker = &DomainChecker{Store: store, BootstrapMaxAge: 30 * 24 * time.Hour, Clock: func() time.Time { return now }}
+	result, err = checker.Check(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.False(t, result.Provenance.BootstrapStale)
+
+	// Override servers do not depend on the bootstrap data.
+	checker = &DomainChecker{Store: store, BootstrapMaxAge: time.Hour, RDAPOverrides: map[string][]string{"com": {server.URL}}}
+	result, err = checker.Check(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.Nil(t, result.Provenance.BootstrapFetchedAt)
+	require.False(t, result.Provenance.BootstrapStale)
+}
+
+func TestDomainCheckerOffline(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	store := &stubBootstrapStore{servers: map[string][]string{"com": {server.URL}}}
+	checker := &DomainChecker{Store: store, WhoisCfg: WhoisFallbackConfig{Enabled: true}}
+
+	result, err := checker.Check(core.WithOffline(context.Background()), "example.com")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityUnknown, result.Available)
+	require.Equal(t, core.OfflineMessage, result.Message)
+	require.Zero(t, hits)
+}
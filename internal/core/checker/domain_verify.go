@@ -0,0 +1,212 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openrdap/rdap"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+const (
+	verifySourceDNS  = "dns"
+	verifySourceHTTP = "http"
+	verifySourceRDAP = "rdap-mirror"
+
+	defaultProbeTimeout = 5 * time.Second
+)
+
+// verifyRDAPMirror answers for any TLD by redirecting to the registry, so
+// verification has a second RDAP path when bootstrap lists a single server.
+var verifyRDAPMirror = "https://www.rdap.net/rdap"
+
+// Verify re-checks an available domain verdict against sources independent of
+// the one that produced it: a DNS lookup, an HTTP liveness probe, and a second
+// RDAP server. It returns nil for results that are not available domains and
+// when offline.
+func (d *DomainChecker) Verify(ctx context.Context, result *core.CheckResult) *core.Verification {
+	if d == nil || result == nil || result.CheckType != core.CheckTypeDomain || result.Available != core.AvailabilityAvailable {
+		return nil
+	}
+	if core.IsOffline(ctx) {
+		return nil
+	}
+
+	name := strings.ToLower(strings.TrimSpace(result.Name))
+	checks := []core.VerificationCheck{
+		d.verifyDNS(ctx, name, result.TLD),
+		d.verifyHTTP(ctx, name),
+		d.verifyRDAP(ctx, name, result.TLD, result.Provenance.Server),
+	}
+	return &core.Verification{Status: verificationStatus(checks), Checks: checks}
+}
+
+func verificationStatus(checks []core.VerificationCheck) string {
+	agreed := false
+	for _, check := range checks {
+		switch check.Verdict {
+		case core.AvailabilityTaken.String():
+			return core.VerificationDisputed
+		case core.AvailabilityAvailable.String():
+			agreed = true
+		}
+	}
+	if agreed {
+		return core.VerificationConfirmed
+	}
+	return core.VerificationInconclusive
+}
+
+func verifyCheck(source string, availability core.Availability, detail string) core.VerificationCheck {
+	verdict := core.AvailabilityUnknown.String()
+	if availability == core.AvailabilityAvailable || availability == core.AvailabilityTaken {
+		verdict = availability.String()
+	}
+	return core.VerificationCheck{Source: source, Verdict: verdict, Detail: detail}
+}
+
+// verifyDNS treats records other than TLD wildcard matches as taken and
+// NXDOMAIN as available.
+func (d *DomainChecker) verifyDNS(ctx context.Context, name, tld string) core.VerificationCheck {
+	if d.DNSCfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.DNSCfg.Timeout)
+		defer cancel()
+	}
+
+	records, notFound, err := d.lookupDNS(ctx, name)
+	switch {
+	case err != nil:
+		return verifyCheck(verifySourceDNS, core.AvailabilityUnknown, fmt.Sprintf("lookup failed: %v", err))
+	case notFound:
+		return verifyCheck(verifySourceDNS, core.AvailabilityAvailable, "nxdomain")
+	case len(records) == 0:
+		return verifyCheck(verifySourceDNS, core.AvailabilityUnknown, "no records")
+	}
+
+	var wildcardTypes []string
+	if d.DNSCfg.DetectWildcard {
+		wildcardTypes = records.coveredBy(d.tldWildcard(ctx, tld))
+	}
+	found := records.typesExcept(wildcardTypes)
+	if len(found) == 0 {
+		return verifyCheck(verifySourceDNS, core.AvailabilityUnknown, "wildcard match only")
+	}
+	return verifyCheck(verifySourceDNS, core.AvailabilityTaken, "records present: "+strings.Join(found, ", "))
+}
+
+// verifyHTTP reports the domain taken when a web server answers for it. No
+// answer is not evidence of availability.
+func (d *DomainChecker) verifyHTTP(ctx context.Context, name string) core.VerificationCheck {
+	client := d.Probe
+	if client == nil {
+		client = &http.Client{Timeout: defaultProbeTimeout}
+	}
+	probe := *client
+	probe.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	if probe.Timeout <= 0 {
+		probe.Timeout = defaultProbeTimeout
+	}
+
+	var lastErr error
+	for _, scheme := range []string{"https", "http"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, scheme+"://"+name+"/", nil)
+		if err != nil {
+			return verifyCheck(verifySourceHTTP, core.AvailabilityUnknown, err.Error())
+		}
+		resp, err := probe.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		_ = resp.Body.Close()
+		return verifyCheck(verifySourceHTTP, core.AvailabilityTaken, fmt.Sprintf("%s responded %d", scheme, resp.StatusCode))
+	}
+	detail := "no response"
+	var urlErr *url.Error
+	if errors.As(lastErr, &urlErr) && urlErr.Timeout() {
+		detail = "timed out"
+	}
+	return verifyCheck(verifySourceHTTP, core.AvailabilityUnknown, detail)
+}
+
+// verifyRDAP queries an RDAP server other than the one behind the verdict.
+func (d *DomainChecker) verifyRDAP(ctx context.Context, name, tld, usedServer string) core.VerificationCheck {
+	serverBase := d.mirrorServer(ctx, tld, usedServer)
+	if serverBase == "" {
+		return verifyCheck(verifySourceRDAP, core.AvailabilityUnknown, "no second rdap server")
+	}
+	serverURL, err := url.Parse(serverBase)
+	if err != nil {
+		return verifyCheck(verifySourceRDAP, core.AvailabilityUnknown, fmt.Sprintf("invalid rdap server url: %v", err))
+	}
+	endpoint := serverURL.Hostname()
+
+	if d.Limiter != nil && endpoint != "" {
+		allowed, wait, err := d.Limiter.Allow(ctx, endpoint)
+		if err != nil {
+			return verifyCheck(verifySourceRDAP, core.AvailabilityUnknown, err.Error())
+		}
+		if !allowed {
+			return verifyCheck(verifySourceRDAP, core.AvailabilityUnknown, fmt.Sprintf("%s rate limited, retry in %s", endpoint, wait.Round(time.Second)))
+		}
+		if err := d.Limiter.Record(ctx, endpoint); err != nil {
+			return verifyCheck(verifySourceRDAP, core.AvailabilityUnknown, err.Error())
+		}
+	}
+
+	client := d.Client
+	if client == nil {
+		client = &rdap.Client{}
+	}
+	req := rdap.NewDomainRequest(name).WithServer(serverURL)
+	if d.Timeout > 0 {
+		req.Timeout = d.Timeout
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	statusCode, _ := responseStatus(resp, "")
+	if err != nil {
+		if isNotFound(err) || statusCode == 404 {
+			return verifyCheck(verifySourceRDAP, core.AvailabilityAvailable, endpoint+": not found")
+		}
+		return verifyCheck(verifySourceRDAP, core.AvailabilityUnknown, fmt.Sprintf("%s: %v", endpoint, err))
+	}
+	if _, ok := resp.Object.(*rdap.Domain); ok {
+		return verifyCheck(verifySourceRDAP, core.AvailabilityTaken, endpoint+": domain found")
+	}
+	return verifyCheck(verifySourceRDAP, core.AvailabilityUnknown, endpoint+": unexpected response")
+}
+
+// mirrorServer returns the first known RDAP server for tld on a different
+// host than usedServer, falling back to verifyRDAPMirror.
+func (d *DomainChecker) mirrorServer(ctx context.Context, tld, usedServer string) string {
+	usedHost := ""
+	if parsed, err := url.Parse(usedServer); err == nil {
+		usedHost = strings.ToLower(parsed.Hostname())
+	}
+
+	candidates := append([]string(nil), d.rdapOverrideServers(tld)...)
+	if d.Store != nil {
+		if servers, err := d.Store.GetRDAPServers(ctx, tld); err == nil {
+			candidates = append(candidates, servers...)
+		}
+	}
+	candidates = append(candidates, verifyRDAPMirror)
+
+	for _, candidate := range candidates {
+		parsed, err := url.Parse(candidate)
+		if err != nil || parsed.Hostname() == "" {
+			continue
+		}
+		if strings.ToLower(parsed.Hostname()) != usedHost {
+			return candidate
+		}
+	}
+	return ""
+}
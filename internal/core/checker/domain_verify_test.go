@@ -0,0 +1,99 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+type probeTransport func(*http.Request) (*http.Response, error)
+
+func (p probeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return p(req)
+}
+
+func availableDomainResult() *core.CheckResult {
+	return &core.CheckResult{
+		Name:       "example.com",
+		CheckType:  core.CheckTypeDomain,
+		TLD:        "com",
+		Available:  core.AvailabilityAvailable,
+		Provenance: core.Provenance{Server: "https://rdap.primary.test/domain/example.com"},
+	}
+}
+
+func TestDomainCheckerVerifyConfirmed(t *testing.T) {
+	var mirrorHits int
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorHits++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mirror.Close()
+
+	checker := &DomainChecker{
+		Store: &stubBootstrapStore{servers: map[string][]string{"com": {"https://rdap.primary.test", mirror.URL}}},
+		DNS:   &stubDNSResolver{},
+		Probe: &http.Client{Transport: probeTransport(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})},
+	}
+
+	verification := checker.Verify(context.Background(), availableDomainResult())
+	require.NotNil(t, verification)
+	require.Equal(t, core.VerificationConfirmed, verification.Status)
+	require.Empty(t, verification.Disputes())
+	require.Equal(t, 1, mirrorHits)
+	require.Equal(t, []core.VerificationCheck{
+		{Source: "dns", Verdict: "available", Detail: "nxdomain"},
+		{Source: "http", Verdict: "unknown", Detail: "no response"},
+		{Source: "rdap-mirror", Verdict: "available", Detail: "127.0.0.1: not found"},
+	}, verification.Checks)
+}
+
+func TestDomainCheckerVerifyDisputed(t *testing.T) {
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rdap+json")
+		_, _ = w.Write([]byte(`{"objectClassName": "domain", "ldhName": "example.com"}`))
+	}))
+	defer mirror.Close()
+
+	checker := &DomainChecker{
+		Store: &stubBootstrapStore{servers: map[string][]string{"com": {mirror.URL}}},
+		DNS:   &stubDNSResolver{ns: map[string][]string{"example.com": {"ns1.example.net."}}},
+		Probe: &http.Client{Transport: probeTransport(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+		})},
+	}
+
+	verification := checker.Verify(context.Background(), availableDomainResult())
+	require.NotNil(t, verification)
+	require.Equal(t, core.VerificationDisputed, verification.Status)
+	require.Equal(t, []string{"dns", "http", "rdap-mirror"}, verification.Disputes())
+}
+
+func TestDomainCheckerVerifySkipsNonAvailable(t *testing.T) {
+	checker := &DomainChecker{Store: &stubBootstrapStore{}}
+
+	taken := availableDomainResult()
+	taken.Available = core.AvailabilityTaken
+	require.Nil(t, checker.Verify(context.Background(), taken))
+	require.Nil(t, checker.Verify(core.WithOffline(context.Background()), availableDomainResult()))
+}
+
+func TestMirrorServerSkipsUsedHost(t *testing.T) {
+	checker := &DomainChecker{
+		Store:         &stubBootstrapStore{servers: map[string][]string{"com": {"https://rdap.primary.test"}}},
+		RDAPOverrides: map[string][]string{},
+	}
+
+	require.Equal(t, verifyRDAPMirror, checker.mirrorServer(context.Background(), "com", "https://rdap.primary.test/domain/example.com"))
+	require.Equal(t, "https://rdap.primary.test", checker.mirrorServer(context.Background(), "com", "https://other.test/domain/example.com"))
+}
@@ -0,0 +1,250 @@
+package checker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// dropListDateLayouts are the drop date formats accepted in drop lists.
+var dropListDateLayouts = []string{"2006-01-02", time.RFC3339, "2006-01-02 15:04:05", "01/02/2006"}
+
+// DropListSource is a pending-delete list: a local file or an http(s) URL
+// with one domain per line, optionally followed by its drop date and
+// separated by a comma, tab, or spaces. Blank lines, # comments, and header
+// lines are skipped.
+type DropListSource struct {
+	Name     string
+	Location string
+}
+
+// DropListEntry is a domain found on a drop list. DropDate is zero when the
+// list gives no date.
+type DropListEntry struct {
+	Source   string
+	DropDate time.Time
+}
+
+// DropList answers whether a domain is on one of its pending-delete lists.
+// Sources are loaded on the first lookup and reloaded once Refresh has
+// passed; a source that fails to load keeps its previous entries. Offline
+// lookups never load sources.
+type DropList struct {
+	Sources []DropListSource
+	// Refresh is how long loaded lists are used; zero loads them once.
+	Refresh time.Duration
+	// Client fetches URL sources; nil uses a client with a 30s timeout.
+	Client *http.Client
+	// OnError is called for each source that fails to load.
+	OnError func(source string, err error)
+	Clock   func() time.Time
+
+	mu       sync.Mutex
+	loadedAt time.Time
+	lists    map[string]map[string]DropListEntry
+	// loading is closed when the load in progress finishes; nil when idle.
+	loading chan struct{}
+}
+
+// Lookup returns the drop list entry for domain. When several lists carry
+// the domain, the first source in Sources wins.
+func (l *DropList) Lookup(ctx context.Context, domain string) (DropListEntry, bool) {
+	if l == nil || len(l.Sources) == 0 {
+		return DropListEntry{}, false
+	}
+	domain = dropListKey(domain)
+	l.refresh(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, source := range l.Sources {
+		if entry, ok := l.lists[source.Name][domain]; ok {
+			return entry, true
+		}
+	}
+	return DropListEntry{}, false
+}
+
+// refresh loads the sources when they are due. Only one caller loads, outside
+// the lock; the others keep using the current lists, or wait for the first
+// load when there are none yet.
+func (l *DropList) refresh(ctx context.Context) {
+	if core.IsOffline(ctx) {
+		return
+	}
+
+	l.mu.Lock()
+	now := l.now()
+	if l.lists != nil && (l.Refresh <= 0 || now.Sub(l.loadedAt) < l.Refresh) {
+		l.mu.Unlock()
+		return
+	}
+	if wait := l.loading; wait != nil {
+		first := l.lists == nil
+		l.mu.Unlock()
+		if first {
+			select {
+			case <-wait:
+			case <-ctx.Done():
+			}
+		}
+		return
+	}
+	done := make(chan struct{})
+	l.loading = done
+	l.mu.Unlock()
+
+	loaded := l.load(ctx)
+
+	l.mu.Lock()
+	if l.lists == nil {
+		l.lists = map[string]map[string]DropListEntry{}
+	}
+	for name, entries := range loaded {
+		l.lists[name] = entries
+	}
+	l.loadedAt = now
+	l.loading = nil
+	l.mu.Unlock()
+	close(done)
+}
+
+// load reads every source and returns the ones that loaded.
+func (l *DropList) load(ctx context.Context) map[string]map[string]DropListEntry {
+	loaded := map[string]map[string]DropListEntry{}
+	for _, source := range l.Sources {
+		entries, err := l.loadSource(ctx, source)
+		if err != nil {
+			if l.OnError != nil {
+				l.OnError(source.Name, err)
+			}
+			continue
+		}
+		loaded[source.Name] = entries
+	}
+	return loaded
+}
+
+func (l *DropList) loadSource(ctx context.Context, source DropListSource) (map[string]DropListEntry, error) {
+	location := strings.TrimSpace(source.Location)
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		file, err := os.Open(location) // #nosec G304 -- drop list path comes from user config
+		if err != nil {
+			return nil, fmt.Errorf("read drop list: %w", err)
+		}
+		defer file.Close() // nolint:errcheck // read-only file
+		return parseDropList(file, source.Name)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := l.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch drop list: %w", err)
+	}
+	defer resp.Body.Close() // nolint:errcheck // best-effort cleanup on HTTP response body
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch drop list: unexpected status %d", resp.StatusCode)
+	}
+	return parseDropList(resp.Body, source.Name)
+}
+
+func (l *DropList) now() time.Time {
+	if l.Clock != nil {
+		return l.Clock()
+	}
+	return time.Now().UTC()
+}
+
+// parseDropList reads a drop list. Lines whose first field is not a domain,
+// such as CSV headers, are skipped, as are dates in unknown formats.
+func parseDropList(r io.Reader, source string) (map[string]DropListEntry, error) {
+	entries := map[string]DropListEntry{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == '\t' || r == ';'
+		})
+		if len(fields) == 1 {
+			fields = strings.Fields(fields[0])
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		domain := dropListKey(strings.Trim(fields[0], `"`))
+		if !strings.Contains(domain, ".") || strings.ContainsAny(domain, " /") {
+			continue
+		}
+		entry := DropListEntry{Source: source}
+		if len(fields) > 1 {
+			entry.DropDate = parseDropDate(strings.Trim(strings.TrimSpace(fields[1]), `"`))
+		}
+		if _, exists := entries[domain]; !exists {
+			entries[domain] = entry
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func parseDropDate(value string) time.Time {
+	for _, layout := range dropListDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+func dropListKey(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// annotateDropList records a taken domain found on a drop list under the
+// "drop_list" ExtraData key. A listed drop date replaces any drop window
+// estimated from RDAP, since the list comes from the registry's deletion
+// schedule. Cached results and offline checks are left as they are.
+func (d *DomainChecker) annotateDropList(ctx context.Context, result *core.CheckResult) {
+	if d.DropList == nil || result == nil || result.Available != core.AvailabilityTaken || result.Provenance.FromCache {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if core.IsOffline(ctx) {
+		return
+	}
+	entry, ok := d.DropList.Lookup(ctx, result.Name)
+	if !ok {
+		return
+	}
+	if result.ExtraData == nil {
+		result.ExtraData = map[string]any{}
+	}
+	listed := map[string]any{"source": entry.Source}
+	if !entry.DropDate.IsZero() {
+		listed["drop_date"] = entry.DropDate.Format(time.RFC3339)
+		result.ExtraData["drop_window"] = core.DropWindowExtra(core.DropListWindow(entry.Source, entry.DropDate))
+	}
+	result.ExtraData["drop_list"] = listed
+}
@@ -0,0 +1,223 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestParseDropList(t *testing.T) {
+	entries, err := parseDropList(strings.NewReader(`# pending delete, 2026-10-16
+domain,drop_date
+Acme.com,2026-10-21
+"zentro.io","2026-10-22T18:00:00Z"
+brightly.dev	10/23/2026
+plain.net
+spaced.org 2026-10-24
+acme.com,2026-11-30
+odd.co,soon
+`), "zonedrops")
+	require.NoError(t, err)
+
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	require.Equal(t, map[string]DropListEntry{
+		"acme.com":     {Source: "zonedrops", DropDate: day(21)},
+		"zentro.io":    {Source: "zonedrops", DropDate: day(22).Add(18 * time.Hour)},
+		"brightly.dev": {Source: "zonedrops", DropDate: day(23)},
+		"plain.net":    {Source: "zonedrops"},
+		"spaced.org":   {Source: "zonedrops", DropDate: day(24)},
+		"odd.co":       {Source: "zonedrops"},
+	}, entries)
+}
+
+func TestDropListLookup(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.txt")
+	second := filepath.Join(dir, "second.csv")
+	require.NoError(t, os.WriteFile(first, []byte("acme.com\n"), 0o600))
+	require.NoError(t, os.WriteFile(second, []byte("acme.com,2026-10-21\nzentro.io,2026-10-22\n"), 0o600))
+
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	var failed []string
+	list := &DropList{
+		Sources: []DropListSource{{Name: "first", Location: first}, {Name: "second", Location: second}},
+		Refresh: time.Hour,
+		Clock:   func() time.Time { return now },
+		OnError: func(source string, err error) { failed = append(failed, source) },
+	}
+
+	entry, ok := list.Lookup(context.Background(), "ACME.com.")
+	require.True(t, ok)
+	require.Equal(t, DropListEntry{Source: "first"}, entry)
+
+	entry, ok = list.Lookup(context.Background(), "zentro.io")
+	require.True(t, ok)
+	require.Equal(t, "second", entry.Source)
+
+	_, ok = list.Lookup(context.Background(), "other.com")
+	require.False(t, ok)
+
+	// A source that disappears keeps its last entries; new entries appear
+	// after the refresh interval.
+	require.NoError(t, os.Remove(first))
+	require.NoError(t, os.WriteFile(second, []byte("other.com\n"), 0o600))
+	_, ok = list.Lookup(context.Background(), "other.com")
+	require.False(t, ok)
+
+	now = now.Add(time.Hour)
+	_, ok = list.Lookup(context.Background(), "other.com")
+	require.True(t, ok)
+	entry, ok = list.Lookup(context.Background(), "acme.com")
+	require.True(t, ok)
+	require.Equal(t, "first", entry.Source)
+	require.Equal(t, []string{"first"}, failed)
+}
+
+func TestDropListLookupURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/drops.csv" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("acme.com,2026-10-21\n"))
+	}))
+	defer server.Close()
+
+	var failed []string
+	list := &DropList{
+		Sources: []DropListSource{{Name: "broken", Location: server.URL + "/missing"}, {Name: "feed", Location: server.URL + "/drops.csv"}},
+		OnError: func(source string, err error) { failed = append(failed, source) },
+	}
+	entry, ok := list.Lookup(context.Background(), "acme.com")
+	require.True(t, ok)
+	require.Equal(t, "feed", entry.Source)
+	require.Equal(t, []string{"broken"}, failed)
+}
+
+func TestDomainCheckerDropList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rdap+json")
+		_, _ = w.Write([]byte(`{"objectClassName": "domain", "ldhName": "example.com", "status": ["active"]}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "drops.csv")
+	require.NoError(t, os.WriteFile(path, []byte("example.com,2026-10-21\n"), 0o600))
+
+	store := &stubBootstrapStore{servers: map[string][]string{"com": {server.URL}, "net": {server.URL}}}
+	checker := &DomainChecker{
+		Store:    store,
+		DropList: &DropList{Sources: []DropListSource{{Name: "zonedrops", Location: path}}},
+	}
+
+	result, err := checker.Check(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.Equal(t, map[string]any{"source": "zonedrops", "drop_date": "2026-10-21T00:00:00Z"}, result.ExtraData["drop_list"])
+
+	window, ok := core.ParseDropWindow(result.ExtraData)
+	require.True(t, ok)
+	require.Equal(t, core.DropListWindow("zonedrops", time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)), window)
+
+	result, err = checker.Check(context.Background(), "example.net")
+	require.NoError(t, err)
+	require.NotContains(t, result.ExtraData, "drop_list")
+}
+
+func TestDropListLookupOfflineSkipsLoad(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("acme.com\n"))
+	}))
+	defer server.Close()
+
+	list := &DropList{Sources: []DropListSource{{Name: "feed", Location: server.URL}}}
+	_, ok := list.Lookup(core.WithOffline(context.Background()), "acme.com")
+	require.False(t, ok)
+	require.Zero(t, requests)
+
+	_, ok = list.Lookup(context.Background(), "acme.com")
+	require.True(t, ok)
+	require.Equal(t, 1, requests)
+}
+
+func TestDropListRefreshDoesNotBlockLookups(t *testing.T) {
+	release := make(chan struct{})
+	var slow bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow {
+			<-release
+		}
+		_, _ = w.Write([]byte("acme.com\n"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	var clockMu sync.Mutex
+	list := &DropList{
+		Sources: []DropListSource{{Name: "feed", Location: server.URL}},
+		Refresh: time.Hour,
+		Clock: func() time.Time {
+			clockMu.Lock()
+			defer clockMu.Unlock()
+			return now
+		},
+	}
+	_, ok := list.Lookup(context.Background(), "acme.com")
+	require.True(t, ok)
+
+	// A due refresh stuck on a slow source must not hold up other lookups,
+	// which keep answering from the loaded lists.
+	slow = true
+	clockMu.Lock()
+	now = now.Add(time.Hour)
+	clockMu.Unlock()
+	go list.Lookup(context.Background(), "acme.com") // loads from the slow source
+	require.Eventually(t, func() bool {
+		list.mu.Lock()
+		defer list.mu.Unlock()
+		return list.loading != nil
+	}, time.Second, 5*time.Millisecond)
+
+	found := make(chan bool, 1)
+	go func() {
+		_, ok := list.Lookup(context.Background(), "acme.com")
+		found <- ok
+	}()
+	select {
+	case ok := <-found:
+		require.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("lookup waited for the refresh")
+	}
+}
+
+func TestDomainCheckerDropListSkipsCachedAndOffline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "drops.csv")
+	require.NoError(t, os.WriteFile(path, []byte("example.com,2026-10-21\n"), 0o600))
+	checker := &DomainChecker{DropList: &DropList{Sources: []DropListSource{{Name: "zonedrops", Location: path}}}}
+
+	cached := &core.CheckResult{Name: "example.com", Available: core.AvailabilityTaken, Provenance: core.Provenance{FromCache: true}}
+	checker.annotateDropList(context.Background(), cached)
+	require.NotContains(t, cached.ExtraData, "drop_list")
+
+	offline := &core.CheckResult{Name: "example.com", Available: core.AvailabilityTaken}
+	checker.annotateDropList(core.WithOffline(context.Background()), offline)
+	require.NotContains(t, offline.ExtraData, "drop_list")
+
+	live := &core.CheckResult{Name: "example.com", Available: core.AvailabilityTaken}
+	checker.annotateDropList(context.Background(), live)
+	require.Contains(t, live.ExtraData, "drop_list")
+}
@@ -34,6 +34,13 @@ type GitHubChecker struct {
 
 // Check performs a GitHub handle availability check.
 func (c *GitHubChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	started := time.Now()
+	result, err := c.check(ctx, name)
+	observeCheck(core.CheckTypeGitHub, result, started)
+	return result, err
+}
+
+func (c *GitHubChecker) check(ctx context.Context, name string) (*core.CheckResult, error) {
 	if c == nil || c.Store == nil {
 		return nil, errors.New("github checker is not configured")
 	}
@@ -48,13 +55,18 @@ func (c *GitHubChecker) Check(ctx context.Context, name string) (*core.CheckResu
 
 	requestedAt := c.now()
 
-	if c.UseCache {
-		if cached, err := c.Store.GetCachedResult(ctx, value, core.CheckTypeGitHub, ""); err == nil && cached != nil {
+	if c.UseCache || core.IsOffline(ctx) {
+		refresh := func(ctx context.Context) { _, _ = c.Check(ctx, name) }
+		if cached := cachedResult(ctx, c.Store, c.CachePolicy, value, core.CheckTypeGitHub, "", refresh); cached != nil {
 			cached.Name = value
 			cached.Provenance.FromCache = true
+			cached.Provenance.Source = githubSource
 			return cached, nil
 		}
 	}
+	if core.IsOffline(ctx) {
+		return c.result(value, core.AvailabilityUnknown, 0, core.OfflineMessage, nil, requestedAt, c.now(), ""), nil
+	}
 
 	baseURL := c.baseURL()
 	endpoint := baseURL.Hostname()
@@ -161,7 +173,7 @@ func (c *GitHubChecker) cacheResult(ctx context.Context, name string, result *co
 		return
 	}
 
-	ttl := cacheTTL(c.CachePolicy, result.Available)
+	ttl := cacheTTL(ctx, c.CachePolicy, result.Available)
 	if ttl <= 0 {
 		return
 	}
@@ -201,10 +213,14 @@ func githubExtra(resp *http.Response) map[string]any {
 	}
 
 	var payload struct {
-		Login   string `json:"login"`
-		ID      int    `json:"id"`
-		HTMLURL string `json:"html_url"`
-		Type    string `json:"type"`
+		Login       string `json:"login"`
+		ID          int    `json:"id"`
+		HTMLURL     string `json:"html_url"`
+		Type        string `json:"type"`
+		CreatedAt   string `json:"created_at"`
+		UpdatedAt   string `json:"updated_at"`
+		PublicRepos *int   `json:"public_repos"`
+		Followers   *int   `json:"followers"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
@@ -224,6 +240,18 @@ func githubExtra(resp *http.Response) map[string]any {
 	if payload.Type != "" {
 		extra["type"] = payload.Type
 	}
+	if payload.CreatedAt != "" {
+		extra["created_at"] = payload.CreatedAt
+	}
+	if payload.UpdatedAt != "" {
+		extra["updated_at"] = payload.UpdatedAt
+	}
+	if payload.PublicRepos != nil {
+		extra["public_repos"] = *payload.PublicRepos
+	}
+	if payload.Followers != nil {
+		extra["followers"] = *payload.Followers
+	}
 
 	if len(extra) == 0 {
 		return nil
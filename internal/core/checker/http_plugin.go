@@ -0,0 +1,281 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+const (
+	httpPluginSource      = "http-plugin"
+	httpPluginPlaceholder = "{name}"
+)
+
+var defaultHTTPPluginNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,127}$`)
+
+// HTTPPluginConfig describes a user-configured HTTP endpoint that answers
+// whether a name is taken. "{name}" in URL and Body is replaced with the
+// checked name; header values expand ${ENV} variables.
+type HTTPPluginConfig struct {
+	URL             string
+	Method          string
+	Headers         map[string]string
+	Body            string
+	AvailableStatus []int
+	TakenStatus     []int
+	NamePattern     string
+	Timeout         time.Duration
+}
+
+// HTTPPluginChecker checks names against a custom registry over HTTP, such as
+// a corporate naming system or private package repository.
+type HTTPPluginChecker struct {
+	Key         string
+	Config      HTTPPluginConfig
+	Store       RegistryStore
+	Client      *http.Client
+	Limiter     *engine.RateLimiter
+	CachePolicy CachePolicy
+	UseCache    bool
+	ToolVersion string
+	Clock       func() time.Time
+
+	pattern *regexp.Regexp
+}
+
+// NewHTTPPluginChecker validates the configuration and applies defaults:
+// GET, 404 means available, 200 means taken, and a 10s timeout.
+func NewHTTPPluginChecker(key string, cfg HTTPPluginConfig) (*HTTPPluginChecker, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key == "" {
+		return nil, errors.New("custom checker name is required")
+	}
+
+	cfg.URL = strings.TrimSpace(cfg.URL)
+	parsed, err := url.Parse(strings.ReplaceAll(cfg.URL, httpPluginPlaceholder, "name"))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("custom checker %s: url must be an http(s) URL", key)
+	}
+	if !strings.Contains(cfg.URL, httpPluginPlaceholder) && !strings.Contains(cfg.Body, httpPluginPlaceholder) {
+		return nil, fmt.Errorf("custom checker %s: url or body must contain %s", key, httpPluginPlaceholder)
+	}
+
+	cfg.Method = strings.ToUpper(strings.TrimSpace(cfg.Method))
+	if cfg.Method == "" {
+		cfg.Method = http.MethodGet
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if len(cfg.AvailableStatus) == 0 {
+		cfg.AvailableStatus = []int{http.StatusNotFound}
+	}
+	if len(cfg.TakenStatus) == 0 {
+		cfg.TakenStatus = []int{http.StatusOK}
+	}
+	for _, status := range cfg.AvailableStatus {
+		if containsStatus(cfg.TakenStatus, status) {
+			return nil, fmt.Errorf("custom checker %s: status %d is both available and taken", key, status)
+		}
+	}
+
+	pattern := defaultHTTPPluginNamePattern
+	if strings.TrimSpace(cfg.NamePattern) != "" {
+		pattern, err = regexp.Compile(cfg.NamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("custom checker %s: invalid name_pattern: %w", key, err)
+		}
+	}
+
+	return &HTTPPluginChecker{Key: key, Config: cfg, pattern: pattern}, nil
+}
+
+// Check queries the configured endpoint for the name.
+func (c *HTTPPluginChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	if c == nil || c.Store == nil {
+		return nil, errors.New("custom checker is not configured")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	value := strings.TrimSpace(name)
+	if value == "" {
+		return nil, errors.New("name is required")
+	}
+	if !c.SupportsName(value) {
+		return nil, fmt.Errorf("unsupported %s name: %q", c.Key, name)
+	}
+
+	requestedAt := c.now()
+
+	if c.UseCache || core.IsOffline(ctx) {
+		refresh := func(ctx context.Context) { _, _ = c.Check(ctx, name) }
+		if cached := cachedResult(ctx, c.Store, c.CachePolicy, value, c.Type(), "", refresh); cached != nil {
+			cached.Name = value
+			cached.Provenance.FromCache = true
+			cached.Provenance.Source = httpPluginSource
+			return cached, nil
+		}
+	}
+	if core.IsOffline(ctx) {
+		return c.result(value, core.AvailabilityUnknown, 0, core.OfflineMessage, nil, requestedAt, c.now(), ""), nil
+	}
+
+	target := strings.ReplaceAll(c.Config.URL, httpPluginPlaceholder, url.PathEscape(value))
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := parsed.Hostname()
+	server := parsed.Scheme + "://" + parsed.Host
+
+	if c.Limiter != nil && endpoint != "" {
+		allowed, wait, err := c.Limiter.Allow(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			result := c.result(value, core.AvailabilityRateLimited, http.StatusTooManyRequests, fmt.Sprintf("rate limited, retry in %s", wait.Round(time.Second)), nil, requestedAt, c.now(), server)
+			c.cacheResult(ctx, value, result)
+			return result, nil
+		}
+	}
+
+	var body io.Reader
+	if c.Config.Body != "" {
+		body = strings.NewReader(strings.ReplaceAll(c.Config.Body, httpPluginPlaceholder, value))
+	}
+	req, err := http.NewRequestWithContext(ctx, c.Config.Method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "namelens/"+c.toolVersion())
+	for key, headerValue := range c.Config.Headers {
+		req.Header.Set(key, os.ExpandEnv(headerValue))
+	}
+
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: c.Config.Timeout}
+	}
+
+	if c.Limiter != nil && endpoint != "" {
+		if err := c.Limiter.Record(ctx, endpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result := c.result(value, core.AvailabilityError, 0, err.Error(), nil, requestedAt, c.now(), server)
+		c.cacheResult(ctx, value, result)
+		return result, nil
+	}
+	defer resp.Body.Close() // nolint:errcheck // best-effort cleanup on HTTP response body
+
+	switch {
+	case containsStatus(c.Config.AvailableStatus, resp.StatusCode):
+		result := c.result(value, core.AvailabilityAvailable, resp.StatusCode, c.Key+" name not found", nil, requestedAt, c.now(), server)
+		c.cacheResult(ctx, value, result)
+		return result, nil
+	case containsStatus(c.Config.TakenStatus, resp.StatusCode):
+		result := c.result(value, core.AvailabilityTaken, resp.StatusCode, c.Key+" name found", nil, requestedAt, c.now(), server)
+		c.cacheResult(ctx, value, result)
+		return result, nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		wait, extra := retryAfterHeader(resp)
+		if c.Limiter != nil && endpoint != "" && wait > 0 {
+			_ = c.Limiter.Record429(ctx, endpoint, wait)
+		}
+		result := c.result(value, core.AvailabilityRateLimited, resp.StatusCode, c.Key+" rate limited", extra, requestedAt, c.now(), server)
+		c.cacheResult(ctx, value, result)
+		return result, nil
+	default:
+		result := c.result(value, core.AvailabilityError, resp.StatusCode, fmt.Sprintf("unexpected %s response", c.Key), nil, requestedAt, c.now(), server)
+		c.cacheResult(ctx, value, result)
+		return result, nil
+	}
+}
+
+// Type returns the configured checker name as the check type.
+func (c *HTTPPluginChecker) Type() core.CheckType {
+	return core.CheckType(c.Key)
+}
+
+// SupportsName validates the name against name_pattern, or a conservative
+// default of letters, digits, '.', '_' and '-' up to 128 characters.
+func (c *HTTPPluginChecker) SupportsName(name string) bool {
+	pattern := defaultHTTPPluginNamePattern
+	if c != nil && c.pattern != nil {
+		pattern = c.pattern
+	}
+	return pattern.MatchString(strings.TrimSpace(name))
+}
+
+func (c *HTTPPluginChecker) cacheResult(ctx context.Context, name string, result *core.CheckResult) {
+	if c == nil || c.Store == nil || !c.UseCache || result == nil {
+		return
+	}
+
+	ttl := cacheTTL(ctx, c.CachePolicy, result.Available)
+	if ttl <= 0 {
+		return
+	}
+
+	_ = c.Store.SetCachedResult(ctx, name, result, ttl)
+}
+
+func (c *HTTPPluginChecker) result(name string, availability core.Availability, statusCode int, message string, extra map[string]any, requestedAt, resolvedAt time.Time, server string) *core.CheckResult {
+	return &core.CheckResult{
+		Name:       name,
+		CheckType:  c.Type(),
+		Available:  availability,
+		StatusCode: statusCode,
+		Message:    message,
+		ExtraData:  extra,
+		Provenance: core.Provenance{
+			CheckID:     uuid.New().String(),
+			RequestedAt: requestedAt,
+			ResolvedAt:  resolvedAt,
+			Source:      httpPluginSource,
+			Server:      server,
+			ToolVersion: c.toolVersion(),
+		},
+	}
+}
+
+func (c *HTTPPluginChecker) now() time.Time {
+	if c != nil && c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now().UTC()
+}
+
+func (c *HTTPPluginChecker) toolVersion() string {
+	if c != nil && c.ToolVersion != "" {
+		return c.ToolVersion
+	}
+	return "unknown"
+}
+
+func containsStatus(statuses []int, status int) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
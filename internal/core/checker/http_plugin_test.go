@@ -0,0 +1,102 @@
+package checker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestHTTPPluginCheckerStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/names/taken":
+			w.WriteHeader(http.StatusOK)
+		case "/names/free":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	checker, err := NewHTTPPluginChecker("CorpNames", HTTPPluginConfig{URL: server.URL + "/names/{name}"})
+	require.NoError(t, err)
+	checker.Store = &stubRegistryStore{}
+	checker.Client = server.Client()
+	require.Equal(t, core.CheckType("corpnames"), checker.Type())
+
+	result, err := checker.Check(context.Background(), "taken")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.Equal(t, core.CheckType("corpnames"), result.CheckType)
+	require.Equal(t, "http-plugin", result.Provenance.Source)
+
+	result, err = checker.Check(context.Background(), "free")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+
+	result, err = checker.Check(context.Background(), "broken")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityError, result.Available)
+	require.Equal(t, http.StatusInternalServerError, result.StatusCode)
+}
+
+func TestHTTPPluginCheckerPostBodyAndHeaders(t *testing.T) {
+	t.Setenv("CORP_TOKEN", "secret")
+
+	var gotBody, gotAuth, gotMethod string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotAuth = r.Header.Get("Authorization")
+		gotMethod = r.Method
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	checker, err := NewHTTPPluginChecker("corp", HTTPPluginConfig{
+		URL:             server.URL + "/lookup",
+		Method:          "post",
+		Headers:         map[string]string{"Authorization": "Bearer ${CORP_TOKEN}"},
+		Body:            `{"name":"{name}"}`,
+		AvailableStatus: []int{http.StatusNoContent},
+		TakenStatus:     []int{http.StatusConflict},
+	})
+	require.NoError(t, err)
+	checker.Store = &stubRegistryStore{}
+	checker.Client = server.Client()
+
+	result, err := checker.Check(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+	require.Equal(t, http.MethodPost, gotMethod)
+	require.Equal(t, `{"name":"acme"}`, gotBody)
+	require.Equal(t, "Bearer secret", gotAuth)
+}
+
+func TestNewHTTPPluginCheckerValidation(t *testing.T) {
+	tests := map[string]HTTPPluginConfig{
+		"missing url":       {},
+		"non-http url":      {URL: "ftp://example.com/{name}"},
+		"no placeholder":    {URL: "https://example.com/names"},
+		"overlapping codes": {URL: "https://example.com/{name}", AvailableStatus: []int{200}, TakenStatus: []int{200}},
+		"bad name pattern":  {URL: "https://example.com/{name}", NamePattern: "("},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewHTTPPluginChecker("corp", cfg)
+			require.Error(t, err)
+		})
+	}
+
+	checker, err := NewHTTPPluginChecker("corp", HTTPPluginConfig{URL: "https://example.com/{name}", NamePattern: "^[a-z]+$"})
+	require.NoError(t, err)
+	require.True(t, checker.SupportsName("acme"))
+	require.False(t, checker.SupportsName("acme-2"))
+}
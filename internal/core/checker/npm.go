@@ -41,6 +41,13 @@ type RegistryStore interface {
 
 // Check performs an npm registry availability check.
 func (c *NPMChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	started := time.Now()
+	result, err := c.check(ctx, name)
+	observeCheck(core.CheckTypeNPM, result, started)
+	return result, err
+}
+
+func (c *NPMChecker) check(ctx context.Context, name string) (*core.CheckResult, error) {
 	if c == nil || c.Store == nil {
 		return nil, errors.New("npm checker is not configured")
 	}
@@ -55,13 +62,18 @@ func (c *NPMChecker) Check(ctx context.Context, name string) (*core.CheckResult,
 
 	requestedAt := c.now()
 
-	if c.UseCache {
-		if cached, err := c.Store.GetCachedResult(ctx, value, core.CheckTypeNPM, ""); err == nil && cached != nil {
+	if c.UseCache || core.IsOffline(ctx) {
+		refresh := func(ctx context.Context) { _, _ = c.Check(ctx, name) }
+		if cached := cachedResult(ctx, c.Store, c.CachePolicy, value, core.CheckTypeNPM, "", refresh); cached != nil {
 			cached.Name = value
 			cached.Provenance.FromCache = true
+			cached.Provenance.Source = npmSource
 			return cached, nil
 		}
 	}
+	if core.IsOffline(ctx) {
+		return c.result(value, core.AvailabilityUnknown, 0, core.OfflineMessage, nil, requestedAt, c.now(), ""), nil
+	}
 
 	baseURL := c.baseURL()
 	endpoint := baseURL.Hostname()
@@ -161,7 +173,7 @@ func (c *NPMChecker) cacheResult(ctx context.Context, name string, result *core.
 		return
 	}
 
-	ttl := cacheTTL(c.CachePolicy, result.Available)
+	ttl := cacheTTL(ctx, c.CachePolicy, result.Available)
 	if ttl <= 0 {
 		return
 	}
@@ -201,8 +213,13 @@ func npmExtra(resp *http.Response) map[string]any {
 	}
 
 	var payload struct {
-		Name     string            `json:"name"`
-		DistTags map[string]string `json:"dist-tags"`
+		Name        string            `json:"name"`
+		Description string            `json:"description"`
+		DistTags    map[string]string `json:"dist-tags"`
+		Time        map[string]string `json:"time"`
+		Maintainers []struct {
+			Name string `json:"name"`
+		} `json:"maintainers"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
@@ -216,6 +233,24 @@ func npmExtra(resp *http.Response) map[string]any {
 	if latest, ok := payload.DistTags["latest"]; ok {
 		extra["latest_version"] = latest
 	}
+	if payload.Description != "" {
+		extra["description"] = payload.Description
+	}
+	if created := payload.Time["created"]; created != "" {
+		extra["created"] = created
+	}
+	if modified := payload.Time["modified"]; modified != "" {
+		extra["modified"] = modified
+	}
+	if len(payload.Maintainers) > 0 {
+		maintainers := make([]string, 0, len(payload.Maintainers))
+		for _, m := range payload.Maintainers {
+			if m.Name != "" {
+				maintainers = append(maintainers, m.Name)
+			}
+		}
+		extra["maintainers"] = maintainers
+	}
 
 	if len(extra) == 0 {
 		return nil
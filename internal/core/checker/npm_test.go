@@ -60,7 +60,7 @@ func TestNPMCheckerAvailable(t *testing.T) {
 func TestNPMCheckerTaken(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = w.Write([]byte(`{"name":"example","dist-tags":{"latest":"1.2.3"}}`))
+		_, _ = w.Write([]byte(`{"name":"example","dist-tags":{"latest":"1.2.3"},"time":{"created":"2015-01-01T00:00:00.000Z","modified":"2015-02-01T00:00:00.000Z"},"maintainers":[{"name":"owner"}]}`))
 	}))
 	defer server.Close()
 
@@ -75,4 +75,33 @@ func TestNPMCheckerTaken(t *testing.T) {
 	require.Equal(t, core.AvailabilityTaken, result.Available)
 	require.Equal(t, http.StatusOK, result.StatusCode)
 	require.Equal(t, "1.2.3", result.ExtraData["latest_version"])
+	require.Equal(t, "2015-02-01T00:00:00.000Z", result.ExtraData["modified"])
+	require.Equal(t, []string{"owner"}, result.ExtraData["maintainers"])
+}
+
+func TestNPMCheckerOffline(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	store := &stubRegistryStore{}
+	checker := &NPMChecker{Store: store, Client: server.Client(), BaseURL: server.URL}
+	ctx := core.WithOffline(context.Background())
+
+	result, err := checker.Check(ctx, "example")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityUnknown, result.Available)
+	require.Equal(t, core.OfflineMessage, result.Message)
+
+	// Offline reads the cache even when the checker does not use it.
+	cached := &core.CheckResult{Name: "example", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken}
+	require.NoError(t, store.SetCachedResult(ctx, "example", cached, time.Hour))
+	result, err = checker.Check(ctx, "example")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.True(t, result.Provenance.FromCache)
+	require.Zero(t, hits)
 }
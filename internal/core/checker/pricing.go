@@ -0,0 +1,216 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// maxPriceResponseBytes caps how much of a pricing API response is read.
+const maxPriceResponseBytes = 1 << 20
+
+// PriceProviderConfig describes a registrar pricing API that quotes a price
+// for one domain per request. "{domain}", "{name}", and "{tld}" in URL and
+// Body are replaced with the domain and its parts; header values expand
+// ${ENV} variables. The field settings are dot-separated paths into the JSON
+// response, with numeric segments indexing arrays (e.g. "data.0.price").
+type PriceProviderConfig struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	Body    string
+	// PriceField locates the registration price (required).
+	PriceField    string
+	RenewalField  string
+	PremiumField  string
+	CurrencyField string
+	// Currency is used when CurrencyField is empty or missing.
+	Currency string
+	Timeout  time.Duration
+}
+
+// HTTPPriceProvider quotes registration prices from a pricing API.
+type HTTPPriceProvider struct {
+	Key         string
+	Config      PriceProviderConfig
+	Client      *http.Client
+	ToolVersion string
+}
+
+// NewHTTPPriceProvider validates the configuration and applies defaults: GET,
+// USD, and a 10s timeout.
+func NewHTTPPriceProvider(key string, cfg PriceProviderConfig) (*HTTPPriceProvider, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key == "" {
+		return nil, errors.New("price provider name is required")
+	}
+
+	cfg.URL = strings.TrimSpace(cfg.URL)
+	parsed, err := url.Parse(priceReplacer("example.com").Replace(cfg.URL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("price provider %s: url must be an http(s) URL", key)
+	}
+	if !strings.Contains(cfg.URL+cfg.Body, "{domain}") && !strings.Contains(cfg.URL+cfg.Body, "{name}") {
+		return nil, fmt.Errorf("price provider %s: url or body must contain {domain} or {name}", key)
+	}
+	cfg.PriceField = strings.TrimSpace(cfg.PriceField)
+	if cfg.PriceField == "" {
+		return nil, fmt.Errorf("price provider %s: price_field is required", key)
+	}
+
+	cfg.Method = strings.ToUpper(strings.TrimSpace(cfg.Method))
+	if cfg.Method == "" {
+		cfg.Method = http.MethodGet
+	}
+	cfg.Currency = strings.ToUpper(strings.TrimSpace(cfg.Currency))
+	if cfg.Currency == "" {
+		cfg.Currency = "USD"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	return &HTTPPriceProvider{Key: key, Config: cfg}, nil
+}
+
+// Quote asks the API for the registration price of domain. Premium is set
+// only when the API flags it; callers apply their own price threshold.
+func (p *HTTPPriceProvider) Quote(ctx context.Context, domain string) (*core.Pricing, error) {
+	if p == nil {
+		return nil, errors.New("price provider is not configured")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		return nil, errors.New("domain is required")
+	}
+
+	replacer := priceReplacer(domain)
+	var body io.Reader
+	if p.Config.Body != "" {
+		body = strings.NewReader(replacer.Replace(p.Config.Body))
+	}
+	req, err := http.NewRequestWithContext(ctx, p.Config.Method, replacer.Replace(p.Config.URL), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "namelens/"+p.toolVersion())
+	req.Header.Set("Accept", "application/json")
+	for key, value := range p.Config.Headers {
+		req.Header.Set(key, os.ExpandEnv(value))
+	}
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: p.Config.Timeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint:errcheck // best-effort cleanup on HTTP response body
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price provider %s: unexpected status %d", p.Key, resp.StatusCode)
+	}
+	var doc any
+	decoder := json.NewDecoder(io.LimitReader(resp.Body, maxPriceResponseBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("price provider %s: decode response: %w", p.Key, err)
+	}
+
+	price, ok := priceNumber(jsonField(doc, p.Config.PriceField))
+	if !ok {
+		return nil, fmt.Errorf("price provider %s: no price at %s", p.Key, p.Config.PriceField)
+	}
+	pricing := &core.Pricing{Provider: p.Key, Currency: p.Config.Currency, Registration: price}
+	if p.Config.RenewalField != "" {
+		pricing.Renewal, _ = priceNumber(jsonField(doc, p.Config.RenewalField))
+	}
+	if p.Config.PremiumField != "" {
+		pricing.Premium = priceFlag(jsonField(doc, p.Config.PremiumField))
+	}
+	if p.Config.CurrencyField != "" {
+		if currency, ok := jsonField(doc, p.Config.CurrencyField).(string); ok && strings.TrimSpace(currency) != "" {
+			pricing.Currency = strings.ToUpper(strings.TrimSpace(currency))
+		}
+	}
+	return pricing, nil
+}
+
+func (p *HTTPPriceProvider) toolVersion() string {
+	if p != nil && p.ToolVersion != "" {
+		return p.ToolVersion
+	}
+	return "unknown"
+}
+
+func priceReplacer(domain string) *strings.Replacer {
+	name, tld, _ := strings.Cut(domain, ".")
+	return strings.NewReplacer("{domain}", domain, "{name}", name, "{tld}", tld)
+}
+
+// jsonField walks a dot-separated path through decoded JSON; numeric
+// segments index arrays. It returns nil when the path does not resolve.
+func jsonField(doc any, path string) any {
+	current := doc
+	for _, segment := range strings.Split(path, ".") {
+		switch value := current.(type) {
+		case map[string]any:
+			current = value[segment]
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(value) {
+				return nil
+			}
+			current = value[index]
+		default:
+			return nil
+		}
+	}
+	return current
+}
+
+// priceNumber reads a JSON number or numeric string, such as "12.99".
+func priceNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// priceFlag reads a premium flag: a boolean, a boolean string, or a tier
+// name such as "premium".
+func priceFlag(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		normalized := strings.ToLower(strings.TrimSpace(v))
+		if flag, err := strconv.ParseBool(normalized); err == nil {
+			return flag
+		}
+		return normalized == "premium"
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPPriceProviderQuote(t *testing.T) {
+	t.Setenv("REGISTRAR_TOKEN", "secret")
+
+	var gotPath, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		switch r.URL.Path {
+		case "/price/acme.ai":
+			_, _ = w.Write([]byte(`{"data": {"prices": [{"register": "9800.00", "renew": 90}], "tier": "premium", "currency": "eur"}}`))
+		case "/price/acme.com":
+			_, _ = w.Write([]byte(`{"data": {"prices": [{"register": 11.5}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPPriceProvider("Registrar", PriceProviderConfig{
+		URL:           server.URL + "/price/{domain}",
+		Headers:       map[string]string{"Authorization": "Bearer ${REGISTRAR_TOKEN}"},
+		PriceField:    "data.prices.0.register",
+		RenewalField:  "data.prices.0.renew",
+		PremiumField:  "data.tier",
+		CurrencyField: "data.currency",
+	})
+	require.NoError(t, err)
+	provider.Client = server.Client()
+
+	pricing, err := provider.Quote(context.Background(), "ACME.ai")
+	require.NoError(t, err)
+	require.Equal(t, "/price/acme.ai", gotPath)
+	require.Equal(t, "Bearer secret", gotAuth)
+	require.Equal(t, "registrar", pricing.Provider)
+	require.Equal(t, 9800.0, pricing.Registration)
+	require.Equal(t, 90.0, pricing.Renewal)
+	require.True(t, pricing.Premium)
+	require.Equal(t, "EUR", pricing.Currency)
+
+	pricing, err = provider.Quote(context.Background(), "acme.com")
+	require.NoError(t, err)
+	require.Equal(t, 11.5, pricing.Registration)
+	require.Zero(t, pricing.Renewal)
+	require.False(t, pricing.Premium)
+	require.Equal(t, "USD", pricing.Currency)
+
+	_, err = provider.Quote(context.Background(), "acme.io")
+	require.ErrorContains(t, err, "unexpected status 404")
+}
+
+func TestNewHTTPPriceProviderValidates(t *testing.T) {
+	_, err := NewHTTPPriceProvider("registrar", PriceProviderConfig{URL: "ftp://prices.example/{domain}", PriceField: "price"})
+	require.ErrorContains(t, err, "http(s) URL")
+
+	_, err = NewHTTPPriceProvider("registrar", PriceProviderConfig{URL: "https://prices.example/quote", PriceField: "price"})
+	require.ErrorContains(t, err, "{domain} or {name}")
+
+	_, err = NewHTTPPriceProvider("registrar", PriceProviderConfig{URL: "https://prices.example/{name}?tld={tld}"})
+	require.ErrorContains(t, err, "price_field is required")
+
+	provider, err := NewHTTPPriceProvider("registrar", PriceProviderConfig{URL: "https://prices.example/{name}?tld={tld}", PriceField: "price", Method: "post"})
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, provider.Config.Method)
+}
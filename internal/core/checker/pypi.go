@@ -33,6 +33,13 @@ type PyPIChecker struct {
 
 // Check performs a PyPI availability check.
 func (c *PyPIChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	started := time.Now()
+	result, err := c.check(ctx, name)
+	observeCheck(core.CheckTypePyPI, result, started)
+	return result, err
+}
+
+func (c *PyPIChecker) check(ctx context.Context, name string) (*core.CheckResult, error) {
 	if c == nil || c.Store == nil {
 		return nil, errors.New("pypi checker is not configured")
 	}
@@ -47,13 +54,18 @@ func (c *PyPIChecker) Check(ctx context.Context, name string) (*core.CheckResult
 
 	requestedAt := c.now()
 
-	if c.UseCache {
-		if cached, err := c.Store.GetCachedResult(ctx, value, core.CheckTypePyPI, ""); err == nil && cached != nil {
+	if c.UseCache || core.IsOffline(ctx) {
+		refresh := func(ctx context.Context) { _, _ = c.Check(ctx, name) }
+		if cached := cachedResult(ctx, c.Store, c.CachePolicy, value, core.CheckTypePyPI, "", refresh); cached != nil {
 			cached.Name = value
 			cached.Provenance.FromCache = true
+			cached.Provenance.Source = pypiSource
 			return cached, nil
 		}
 	}
+	if core.IsOffline(ctx) {
+		return c.result(value, core.AvailabilityUnknown, 0, core.OfflineMessage, nil, requestedAt, c.now(), ""), nil
+	}
 
 	baseURL := c.baseURL()
 	endpoint := baseURL.Hostname()
@@ -151,7 +163,7 @@ func (c *PyPIChecker) cacheResult(ctx context.Context, name string, result *core
 		return
 	}
 
-	ttl := cacheTTL(c.CachePolicy, result.Available)
+	ttl := cacheTTL(ctx, c.CachePolicy, result.Available)
 	if ttl <= 0 {
 		return
 	}
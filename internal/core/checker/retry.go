@@ -0,0 +1,129 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/openrdap/rdap"
+)
+
+// RetryPolicy controls how often a transient RDAP failure (5xx or network
+// error) is retried against the same server before moving on. The zero value
+// makes a single attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of requests per server, including the
+	// first one.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; each further retry doubles it.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff; zero means no cap.
+	MaxDelay time.Duration
+	// Jitter randomizes each delay by up to ±Jitter of its value (0 to 1).
+	Jitter float64
+}
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns the delay before the given retry (1 for the first retry),
+// with rnd in [0, 1) selecting the jitter.
+func (p RetryPolicy) backoff(retry int, rnd float64) time.Duration {
+	if retry < 1 || p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if p.MaxDelay > 0 && delay >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	jitter := min(max(p.Jitter, 0), 1)
+	if jitter > 0 {
+		delay += time.Duration(float64(delay) * jitter * (2*rnd - 1))
+	}
+	return max(delay, 0)
+}
+
+// merge overlays the non-zero fields of override onto p.
+func (p RetryPolicy) merge(override RetryPolicy) RetryPolicy {
+	if override.MaxAttempts > 0 {
+		p.MaxAttempts = override.MaxAttempts
+	}
+	if override.BaseDelay > 0 {
+		p.BaseDelay = override.BaseDelay
+	}
+	if override.MaxDelay > 0 {
+		p.MaxDelay = override.MaxDelay
+	}
+	if override.Jitter > 0 {
+		p.Jitter = override.Jitter
+	}
+	return p
+}
+
+// retryPolicy returns the policy for an RDAP endpoint host.
+func (d *DomainChecker) retryPolicy(endpoint string) RetryPolicy {
+	policy := d.Retry
+	if override, ok := d.RetryEndpoints[strings.ToLower(endpoint)]; ok {
+		policy = policy.merge(override)
+	}
+	return policy
+}
+
+// waitRetry sleeps before a retry, returning early if ctx is done.
+func (d *DomainChecker) waitRetry(ctx context.Context, delay time.Duration) error {
+	if d.Sleep != nil {
+		return d.Sleep(ctx, delay)
+	}
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func retryJitter() float64 {
+	return rand.Float64() // #nosec G404 -- backoff jitter, not security sensitive
+}
+
+// retryableRDAP reports whether an RDAP failure is worth retrying: server
+// errors and network failures, but not answers such as 404 or 429, and never
+// once the caller's context is done.
+func retryableRDAP(ctx context.Context, resp *rdap.Response, reqErr error, statusCode int) bool {
+	if reqErr == nil || ctx.Err() != nil {
+		return false
+	}
+	if statusCode >= 500 && statusCode <= 599 {
+		return true
+	}
+	if statusCode != 0 {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(reqErr, &netErr) {
+		return true
+	}
+	if resp != nil && len(resp.HTTP) > 0 && resp.HTTP[0] != nil {
+		return errors.As(resp.HTTP[0].Error, &netErr)
+	}
+	return false
+}
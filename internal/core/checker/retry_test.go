@@ -0,0 +1,122 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 350 * time.Millisecond}
+	require.Equal(t, 100*time.Millisecond, policy.backoff(1, 0.5))
+	require.Equal(t, 200*time.Millisecond, policy.backoff(2, 0.5))
+	require.Equal(t, 350*time.Millisecond, policy.backoff(3, 0.5))
+	require.Equal(t, 350*time.Millisecond, policy.backoff(30, 0.5))
+
+	policy.Jitter = 0.5
+	require.Equal(t, 50*time.Millisecond, policy.backoff(1, 0))
+	require.Equal(t, 100*time.Millisecond, policy.backoff(1, 0.5))
+	require.InDelta(t, float64(150*time.Millisecond), float64(policy.backoff(1, 0.999999)), float64(time.Millisecond))
+
+	require.Equal(t, 1, RetryPolicy{}.attempts())
+	require.Zero(t, RetryPolicy{}.backoff(1, 0.5))
+}
+
+func TestDomainCheckerRetryEndpointOverride(t *testing.T) {
+	checker := &DomainChecker{
+		Retry:          RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, Jitter: 0.2},
+		RetryEndpoints: map[string]RetryPolicy{"rdap.example": {MaxAttempts: 5}},
+	}
+	require.Equal(t, RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, Jitter: 0.2}, checker.retryPolicy("RDAP.example"))
+	require.Equal(t, checker.Retry, checker.retryPolicy("other.example"))
+}
+
+func TestDomainCheckerRetriesServerErrors(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/rdap+json")
+		_, _ = w.Write([]byte(`{"objectClassName": "domain", "ldhName": "example.com"}`))
+	}))
+	defer server.Close()
+
+	var delays []time.Duration
+	store := &stubBootstrapStore{servers: map[string][]string{"com": {server.URL}}}
+	checker := &DomainChecker{
+		Store: store,
+		Retry: RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond},
+		Sleep: func(ctx context.Context, d time.Duration) error {
+			delays = append(delays, d)
+			return nil
+		},
+	}
+
+	result, err := checker.Check(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.Equal(t, 3, result.Provenance.Attempts)
+	require.Equal(t, 1, result.Provenance.ServersConsulted)
+	require.Equal(t, int32(3), hits.Load())
+	require.Equal(t, []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}, delays)
+}
+
+func TestDomainCheckerRetryGivesUp(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	store := &stubBootstrapStore{servers: map[string][]string{"com": {server.URL}}}
+	checker := &DomainChecker{
+		Store: store,
+		Retry: RetryPolicy{MaxAttempts: 2},
+	}
+
+	result, err := checker.Check(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityError, result.Available)
+	require.Equal(t, http.StatusBadGateway, result.StatusCode)
+	require.Equal(t, 2, result.Provenance.Attempts)
+	require.Equal(t, int32(2), hits.Load())
+}
+
+func TestDomainCheckerDoesNotRetryNotFound(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	store := &stubBootstrapStore{servers: map[string][]string{"com": {server.URL}}}
+	checker := &DomainChecker{Store: store, Retry: RetryPolicy{MaxAttempts: 3}}
+
+	result, err := checker.Check(context.Background(), "example.com")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+	require.Equal(t, 1, result.Provenance.Attempts)
+	require.Equal(t, int32(1), hits.Load())
+}
+
+func TestRetryableRDAPNetworkError(t *testing.T) {
+	netErr := &url.Error{Op: "Get", URL: "https://rdap.example", Err: context.DeadlineExceeded}
+	require.True(t, retryableRDAP(context.Background(), nil, netErr, 0))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.False(t, retryableRDAP(ctx, nil, netErr, 0))
+	require.False(t, retryableRDAP(context.Background(), nil, netErr, http.StatusTooManyRequests))
+}
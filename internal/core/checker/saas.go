@@ -0,0 +1,388 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+// SaaS identity services checked by SaaSChecker.
+const (
+	SaaSSlack           = "slack"
+	SaaSDiscord         = "discord"
+	SaaSGoogleWorkspace = "google-workspace"
+)
+
+// SaaSServices lists the supported services in display order.
+var SaaSServices = []string{SaaSSlack, SaaSDiscord, SaaSGoogleWorkspace}
+
+const (
+	defaultSlackURL     = "https://{name}.slack.com/"
+	defaultDiscordURL   = "https://discord.com/api/v10"
+	defaultWorkspaceTLD = "com"
+)
+
+var (
+	slackNamePattern   = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,19}[a-z0-9])?$`)
+	discordNamePattern = regexp.MustCompile(`^[a-z0-9-]{2,32}$`)
+)
+
+// SaaSChecker gives availability hints for SaaS identities a launch usually
+// needs:
+//
+//   - slack: whether <name>.slack.com belongs to a workspace. Slack sends
+//     unknown workspace URLs back to slack.com; known ones serve or redirect
+//     to their own sign-in page.
+//   - discord: whether discord.gg/<name> is in use as an invite or vanity
+//     URL. Claiming a vanity URL also needs a level 3 boosted server.
+//   - google-workspace: whether <name>.<TLD> already routes mail to Google,
+//     which means a Workspace account exists for the domain.
+type SaaSChecker struct {
+	Service     string
+	Store       RegistryStore
+	Client      *http.Client
+	Resolver    DNSResolver
+	Limiter     *engine.RateLimiter
+	CachePolicy CachePolicy
+	UseCache    bool
+	// SlackURL is the workspace URL template; {name} is replaced.
+	SlackURL string
+	// DiscordURL is the Discord API base URL.
+	DiscordURL string
+	// TLD is the domain suffix checked for Google Workspace (default com).
+	// Names that already contain a dot are checked as given.
+	TLD         string
+	ToolVersion string
+	Clock       func() time.Time
+}
+
+// NewSaaSChecker returns a checker for service, or an error for an unknown
+// service.
+func NewSaaSChecker(service string) (*SaaSChecker, error) {
+	service = strings.ToLower(strings.TrimSpace(service))
+	for _, known := range SaaSServices {
+		if service == known {
+			return &SaaSChecker{Service: service}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown SaaS service %q (valid: %s)", service, strings.Join(SaaSServices, ", "))
+}
+
+// Check looks up the name on the checker's service.
+func (c *SaaSChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	if c == nil || c.Store == nil {
+		return nil, errors.New("saas checker is not configured")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	value := c.target(name)
+	if value == "" {
+		return nil, errors.New("name is required")
+	}
+	if !c.SupportsName(name) {
+		return nil, fmt.Errorf("unsupported %s name: %q", c.Service, name)
+	}
+
+	requestedAt := c.now()
+
+	if c.UseCache || core.IsOffline(ctx) {
+		refresh := func(ctx context.Context) { _, _ = c.Check(ctx, name) }
+		if cached := cachedResult(ctx, c.Store, c.CachePolicy, value, c.Type(), "", refresh); cached != nil {
+			cached.Name = value
+			cached.Provenance.FromCache = true
+			cached.Provenance.Source = c.Service
+			return cached, nil
+		}
+	}
+	if core.IsOffline(ctx) {
+		return c.result(value, core.AvailabilityUnknown, 0, core.OfflineMessage, nil, requestedAt, ""), nil
+	}
+
+	var result *core.CheckResult
+	var err error
+	switch c.Service {
+	case SaaSSlack:
+		result, err = c.checkSlack(ctx, value, requestedAt)
+	case SaaSDiscord:
+		result, err = c.checkDiscord(ctx, value, requestedAt)
+	case SaaSGoogleWorkspace:
+		result = c.checkWorkspace(ctx, value, requestedAt)
+	default:
+		return nil, fmt.Errorf("unknown SaaS service %q", c.Service)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.cacheResult(ctx, value, result)
+	return result, nil
+}
+
+// Type returns the checker type.
+func (c *SaaSChecker) Type() core.CheckType {
+	return core.CheckType(c.Service)
+}
+
+// SupportsName applies each service's naming rules: Slack workspace URLs are
+// up to 21 lowercase letters, digits, and inner hyphens; Discord vanity URLs
+// are 2-32 letters, digits, and hyphens.
+func (c *SaaSChecker) SupportsName(name string) bool {
+	if c == nil {
+		return false
+	}
+	value := strings.ToLower(strings.TrimSpace(name))
+	switch c.Service {
+	case SaaSSlack:
+		return slackNamePattern.MatchString(value)
+	case SaaSDiscord:
+		return discordNamePattern.MatchString(value)
+	case SaaSGoogleWorkspace:
+		return value != "" && !strings.ContainsAny(value, " /@")
+	default:
+		return false
+	}
+}
+
+// target is the identity checked for name: the domain for Google Workspace,
+// otherwise the lowercased name.
+func (c *SaaSChecker) target(name string) string {
+	value := strings.ToLower(strings.TrimSpace(name))
+	if value == "" || c.Service != SaaSGoogleWorkspace || strings.Contains(value, ".") {
+		return value
+	}
+	tld := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(c.TLD)), ".")
+	if tld == "" {
+		tld = defaultWorkspaceTLD
+	}
+	return value + "." + tld
+}
+
+func (c *SaaSChecker) checkSlack(ctx context.Context, name string, requestedAt time.Time) (*core.CheckResult, error) {
+	template := c.SlackURL
+	if template == "" {
+		template = defaultSlackURL
+	}
+	reqURL := strings.ReplaceAll(template, "{name}", name)
+	parsed, err := url.Parse(reqURL)
+	if err != nil {
+		return nil, err
+	}
+	extra := map[string]any{"url": reqURL}
+
+	if limited, err := c.rateLimit(ctx, "slack.com", name, requestedAt, reqURL); err != nil || limited != nil {
+		return limited, err
+	}
+	resp, err := c.do(ctx, reqURL, "slack.com", false)
+	if err != nil {
+		return c.result(name, core.AvailabilityError, 0, err.Error(), extra, requestedAt, reqURL), nil
+	}
+	defer resp.Body.Close() // nolint:errcheck // best-effort cleanup on HTTP response body
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return c.result(name, core.AvailabilityTaken, resp.StatusCode, "workspace URL in use", extra, requestedAt, reqURL), nil
+	case resp.StatusCode == http.StatusNotFound:
+		return c.result(name, core.AvailabilityAvailable, resp.StatusCode, "no workspace at this URL", extra, requestedAt, reqURL), nil
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		location, err := resp.Location()
+		if err != nil {
+			return c.result(name, core.AvailabilityError, resp.StatusCode, "redirect without location", extra, requestedAt, reqURL), nil
+		}
+		extra["redirect"] = location.String()
+		// A workspace redirects to its own sign-in page; unknown URLs go to slack.com
+		if strings.EqualFold(location.Hostname(), parsed.Hostname()) {
+			return c.result(name, core.AvailabilityTaken, resp.StatusCode, "workspace URL in use", extra, requestedAt, reqURL), nil
+		}
+		return c.result(name, core.AvailabilityAvailable, resp.StatusCode, "no workspace at this URL", extra, requestedAt, reqURL), nil
+	case resp.StatusCode == http.StatusTooManyRequests:
+		c.record429(ctx, "slack.com", resp, extra)
+		return c.result(name, core.AvailabilityRateLimited, resp.StatusCode, "slack rate limited", extra, requestedAt, reqURL), nil
+	default:
+		return c.result(name, core.AvailabilityError, resp.StatusCode, "unexpected slack response", extra, requestedAt, reqURL), nil
+	}
+}
+
+func (c *SaaSChecker) checkDiscord(ctx context.Context, name string, requestedAt time.Time) (*core.CheckResult, error) {
+	base := c.DiscordURL
+	if base == "" {
+		base = defaultDiscordURL
+	}
+	reqURL := strings.TrimRight(base, "/") + "/invites/" + url.PathEscape(name)
+	extra := map[string]any{"url": "https://discord.gg/" + name}
+
+	if limited, err := c.rateLimit(ctx, "discord.com", name, requestedAt, reqURL); err != nil || limited != nil {
+		return limited, err
+	}
+	resp, err := c.do(ctx, reqURL, "discord.com", true)
+	if err != nil {
+		return c.result(name, core.AvailabilityError, 0, err.Error(), extra, requestedAt, reqURL), nil
+	}
+	defer resp.Body.Close() // nolint:errcheck // best-effort cleanup on HTTP response body
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return c.result(name, core.AvailabilityAvailable, resp.StatusCode, "vanity URL not in use (claiming it needs a level 3 boosted server)", extra, requestedAt, reqURL), nil
+	case http.StatusOK:
+		var payload struct {
+			Guild *struct {
+				Name string `json:"name"`
+			} `json:"guild"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err == nil && payload.Guild != nil && payload.Guild.Name != "" {
+			extra["guild"] = payload.Guild.Name
+		}
+		return c.result(name, core.AvailabilityTaken, resp.StatusCode, "invite or vanity URL in use", extra, requestedAt, reqURL), nil
+	case http.StatusTooManyRequests:
+		c.record429(ctx, "discord.com", resp, extra)
+		return c.result(name, core.AvailabilityRateLimited, resp.StatusCode, "discord rate limited", extra, requestedAt, reqURL), nil
+	default:
+		return c.result(name, core.AvailabilityError, resp.StatusCode, "unexpected discord response", extra, requestedAt, reqURL), nil
+	}
+}
+
+// checkWorkspace reads the domain's MX records: mail routed to Google means
+// the domain is already attached to a Google Workspace account.
+func (c *SaaSChecker) checkWorkspace(ctx context.Context, domain string, requestedAt time.Time) *core.CheckResult {
+	resolver := c.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	records, err := resolver.LookupMX(ctx, domain)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return c.result(domain, core.AvailabilityAvailable, 0, "no mail records; domain not set up for Google Workspace", nil, requestedAt, "")
+		}
+		return c.result(domain, core.AvailabilityError, 0, fmt.Sprintf("mx lookup failed: %v", err), nil, requestedAt, "")
+	}
+
+	hosts := make([]string, 0, len(records))
+	google := false
+	for _, record := range records {
+		host := strings.ToLower(strings.TrimSuffix(record.Host, "."))
+		if host == "" {
+			continue
+		}
+		hosts = append(hosts, host)
+		if strings.HasSuffix(host, ".google.com") || strings.HasSuffix(host, ".googlemail.com") {
+			google = true
+		}
+	}
+	sort.Strings(hosts)
+	extra := map[string]any{"mx": hosts}
+
+	switch {
+	case google:
+		return c.result(domain, core.AvailabilityTaken, 0, "mail is hosted on Google Workspace", extra, requestedAt, "")
+	case len(hosts) == 0:
+		return c.result(domain, core.AvailabilityAvailable, 0, "no mail records; domain not set up for Google Workspace", nil, requestedAt, "")
+	default:
+		return c.result(domain, core.AvailabilityAvailable, 0, "mail is hosted elsewhere ("+hosts[0]+")", extra, requestedAt, "")
+	}
+}
+
+// rateLimit returns a rate-limited result when the limiter denies endpoint.
+func (c *SaaSChecker) rateLimit(ctx context.Context, endpoint, name string, requestedAt time.Time, server string) (*core.CheckResult, error) {
+	if c.Limiter == nil {
+		return nil, nil
+	}
+	allowed, wait, err := c.Limiter.Allow(ctx, endpoint)
+	if err != nil || allowed {
+		return nil, err
+	}
+	return c.result(name, core.AvailabilityRateLimited, http.StatusTooManyRequests, fmt.Sprintf("rate limited, retry in %s", wait.Round(time.Second)), nil, requestedAt, server), nil
+}
+
+// do sends a GET request. Redirects are followed only when follow is set, so
+// the Slack check can inspect where a workspace URL points.
+func (c *SaaSChecker) do(ctx context.Context, reqURL, endpoint string, follow bool) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, text/html")
+
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if !follow {
+		noRedirect := *client
+		noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		client = &noRedirect
+	}
+
+	if c.Limiter != nil {
+		if err := c.Limiter.Record(ctx, endpoint); err != nil {
+			return nil, err
+		}
+	}
+	return client.Do(req)
+}
+
+// record429 backs the limiter off by the response's Retry-After and adds
+// the retry details to extra.
+func (c *SaaSChecker) record429(ctx context.Context, endpoint string, resp *http.Response, extra map[string]any) {
+	wait, retry := retryAfterHeader(resp)
+	if c.Limiter != nil && wait > 0 {
+		_ = c.Limiter.Record429(ctx, endpoint, wait)
+	}
+	for k, v := range retry {
+		extra[k] = v
+	}
+}
+
+func (c *SaaSChecker) cacheResult(ctx context.Context, name string, result *core.CheckResult) {
+	if c == nil || c.Store == nil || !c.UseCache || result == nil {
+		return
+	}
+
+	ttl := cacheTTL(ctx, c.CachePolicy, result.Available)
+	if ttl <= 0 {
+		return
+	}
+
+	_ = c.Store.SetCachedResult(ctx, name, result, ttl)
+}
+
+func (c *SaaSChecker) result(name string, availability core.Availability, statusCode int, message string, extra map[string]any, requestedAt time.Time, server string) *core.CheckResult {
+	return &core.CheckResult{
+		Name:       name,
+		CheckType:  c.Type(),
+		Available:  availability,
+		StatusCode: statusCode,
+		Message:    message,
+		ExtraData:  extra,
+		Provenance: core.Provenance{
+			CheckID:     uuid.New().String(),
+			RequestedAt: requestedAt,
+			ResolvedAt:  c.now(),
+			Source:      c.Service,
+			Server:      server,
+			ToolVersion: c.ToolVersion,
+		},
+	}
+}
+
+func (c *SaaSChecker) now() time.Time {
+	if c != nil && c.Clock != nil {
+		return c.Clock()
+	}
+	return time.Now().UTC()
+}
@@ -0,0 +1,121 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestSaaSCheckerSlack(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/acme/":
+			http.Redirect(w, r, "/acme/signin", http.StatusFound)
+		case "/fresh/":
+			http.Redirect(w, r, "https://slack.com/get-started", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	checker := &SaaSChecker{
+		Service:  SaaSSlack,
+		Store:    &stubRegistryStore{},
+		Client:   server.Client(),
+		SlackURL: server.URL + "/{name}/",
+	}
+
+	result, err := checker.Check(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.Equal(t, core.CheckTypeSlack, result.CheckType)
+
+	result, err = checker.Check(context.Background(), "fresh")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+	require.Equal(t, "https://slack.com/get-started", result.ExtraData["redirect"])
+
+	result, err = checker.Check(context.Background(), "busy")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityRateLimited, result.Available)
+}
+
+func TestSaaSCheckerDiscord(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/invites/acme" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"code":"acme","guild":{"id":"1","name":"Acme HQ"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	checker := &SaaSChecker{
+		Service:    SaaSDiscord,
+		Store:      &stubRegistryStore{},
+		Client:     server.Client(),
+		DiscordURL: server.URL,
+	}
+
+	result, err := checker.Check(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+	require.Equal(t, "Acme HQ", result.ExtraData["guild"])
+
+	result, err = checker.Check(context.Background(), "fresh")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+	require.Contains(t, result.Message, "level 3")
+}
+
+func TestSaaSCheckerGoogleWorkspace(t *testing.T) {
+	checker := &SaaSChecker{
+		Service: SaaSGoogleWorkspace,
+		Store:   &stubRegistryStore{},
+		Resolver: &stubDNSResolver{mx: map[string][]string{
+			"acme.com":  {"ASPMX.L.GOOGLE.COM.", "alt1.aspmx.l.google.com."},
+			"other.com": {"mx1.mailhost.example."},
+		}},
+	}
+
+	result, err := checker.Check(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Equal(t, "acme.com", result.Name)
+	require.Equal(t, core.AvailabilityTaken, result.Available)
+
+	result, err = checker.Check(context.Background(), "other")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+	require.Contains(t, result.Message, "mx1.mailhost.example")
+
+	result, err = checker.Check(context.Background(), "fresh")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, result.Available)
+
+	checker.Resolver = &stubDNSResolver{fail: true}
+	result, err = checker.Check(context.Background(), "acme")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityError, result.Available)
+}
+
+func TestSaaSCheckerSupportsName(t *testing.T) {
+	slack := &SaaSChecker{Service: SaaSSlack}
+	require.True(t, slack.SupportsName("acme-labs"))
+	require.False(t, slack.SupportsName("-acme"))
+	require.False(t, slack.SupportsName("acme_labs"))
+	require.False(t, slack.SupportsName("a-very-long-workspace-name"))
+
+	discord := &SaaSChecker{Service: SaaSDiscord}
+	require.True(t, discord.SupportsName("acme"))
+	require.False(t, discord.SupportsName("a"))
+
+	_, err := NewSaaSChecker("teams")
+	require.Error(t, err)
+}
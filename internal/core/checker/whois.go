@@ -10,9 +10,15 @@ import (
 	"io"
 	"net"
 	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/namelens/namelens/internal/audit"
 	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/network"
+	"github.com/namelens/namelens/internal/tracing"
 )
 
 const (
@@ -34,6 +40,11 @@ type WhoisFallbackConfig struct {
 	Servers           map[string]string
 	AvailablePatterns []string
 	TakenPatterns     []string
+	// MaxPerServer, Delay, and ServerDelays pace queries per WHOIS server;
+	// see DefaultWhoisClient.
+	MaxPerServer int
+	Delay        time.Duration
+	ServerDelays map[string]time.Duration
 }
 
 // DNSFallbackConfig controls DNS fallback behavior.
@@ -41,6 +52,12 @@ type DNSFallbackConfig struct {
 	Enabled  bool
 	CacheTTL time.Duration
 	Timeout  time.Duration
+	// Records lists the record types to query: ns, a (A/AAAA), mx.
+	// Empty queries all three.
+	Records []string
+	// DetectWildcard probes a random label under the TLD so records served
+	// by a registry wildcard are not reported as taken.
+	DetectWildcard bool
 }
 
 // WhoisClient performs WHOIS lookups.
@@ -65,9 +82,26 @@ type WhoisResponse struct {
 }
 
 // DefaultWhoisClient is a TCP WHOIS client with optional server overrides.
+// Queries to each server share a pool that caps their concurrency and spaces
+// them out, and servers that IANA refers to are remembered per TLD. WHOIS
+// servers close the connection after each answer (RFC 3912), so every query
+// dials its own connection. Reuse one client across lookups for the pool to
+// take effect.
 type DefaultWhoisClient struct {
 	Servers map[string]string
 	Timeout time.Duration
+	// Dial opens WHOIS connections; nil uses a net.Dialer.
+	Dial func(ctx context.Context, network, address string) (net.Conn, error)
+	// MaxPerServer caps concurrent queries to one server; zero is unlimited.
+	MaxPerServer int
+	// Delay is the minimum time between the starts of two queries to one
+	// server; ServerDelays overrides it per server host (lowercase).
+	Delay        time.Duration
+	ServerDelays map[string]time.Duration
+
+	mu        sync.Mutex
+	pools     map[string]*whoisServerPool
+	referrals map[string]string
 }
 
 // Lookup queries a WHOIS server for the given domain.
@@ -81,7 +115,7 @@ func (c *DefaultWhoisClient) Lookup(ctx context.Context, tld, domain string) (*W
 		return nil, err
 	}
 
-	body, err := queryWhois(ctx, server, domain, c.Timeout)
+	body, err := c.query(ctx, server, domain)
 	if err != nil {
 		return nil, err
 	}
@@ -95,13 +129,14 @@ func (c *DefaultWhoisClient) ResolveServer(ctx context.Context, tld string) (str
 	if tld == "" {
 		return "", errors.New("whois tld is required")
 	}
-	if c != nil && len(c.Servers) > 0 {
-		if server := strings.TrimSpace(c.Servers[tld]); server != "" {
-			return server, nil
-		}
+	if server := strings.TrimSpace(c.Servers[tld]); server != "" {
+		return server, nil
+	}
+	if server, ok := c.referral(tld); ok {
+		return server, nil
 	}
 
-	response, err := queryWhois(ctx, whoisIanaServer, tld, c.Timeout)
+	response, err := c.query(ctx, whoisIanaServer, tld)
 	if err != nil {
 		return "", fmt.Errorf("whois iana query failed: %w", err)
 	}
@@ -112,7 +147,9 @@ func (c *DefaultWhoisClient) ResolveServer(ctx context.Context, tld string) (str
 		if strings.HasPrefix(lower, "refer:") || strings.HasPrefix(lower, "whois:") {
 			parts := strings.SplitN(trimmed, ":", 2)
 			if len(parts) == 2 {
-				return strings.TrimSpace(parts[1]), nil
+				server := strings.TrimSpace(parts[1])
+				c.setReferral(tld, server)
+				return server, nil
 			}
 		}
 	}
@@ -125,29 +162,59 @@ func (c *DefaultWhoisClient) LookupWithServer(ctx context.Context, server, domai
 	if strings.TrimSpace(domain) == "" {
 		return nil, errors.New("whois domain is required")
 	}
-	body, err := queryWhois(ctx, server, domain, c.Timeout)
+	body, err := c.query(ctx, server, domain)
 	if err != nil {
 		return nil, err
 	}
 	return &WhoisResponse{Server: server, Body: body}, nil
 }
 
-func queryWhois(ctx context.Context, server, query string, timeout time.Duration) (string, error) {
+func queryWhois(ctx context.Context, dial func(ctx context.Context, network, address string) (net.Conn, error), server, query string, timeout time.Duration) (string, error) {
 	server = strings.TrimSpace(server)
 	if server == "" {
 		return "", errors.New("whois server is required")
 	}
 
-	dialer := &net.Dialer{}
-	if timeout > 0 {
-		dialer.Timeout = timeout
+	ctx, span := tracing.Start(ctx, "whois.query", attribute.String("server.address", server))
+	start := time.Now()
+	body, err := readWhois(ctx, dial, server, query, timeout)
+	span.SetAttributes(attribute.Int("namelens.response_bytes", len(body)))
+	tracing.End(span, err)
+	recordLookup(ctx, audit.KindWHOIS, "whois://"+server+"/"+query, start, err)
+	return body, err
+}
+
+// recordLookup adds a WHOIS or DNS lookup, which has no HTTP status, to the
+// request audit log.
+func recordLookup(ctx context.Context, kind, target string, start time.Time, err error) {
+	entry := audit.Entry{
+		Timestamp:  start.UTC(),
+		Kind:       kind,
+		URL:        target,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	audit.Record(ctx, entry)
+}
+
+func readWhois(ctx context.Context, dial func(ctx context.Context, network, address string) (net.Conn, error), server, query string, timeout time.Duration) (string, error) {
+
+	if dial == nil {
+		dialer := &net.Dialer{}
+		if timeout > 0 {
+			dialer.Timeout = timeout
+		}
+		dial = dialer.DialContext
 	}
 
-	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(server, whoisPort))
+	conn, err := dial(ctx, "tcp", net.JoinHostPort(server, whoisPort))
 	if err != nil {
 		return "", fmt.Errorf("whois dial failed: %w", err)
 	}
 	defer conn.Close() // nolint:errcheck // best-effort cleanup on network connection
+	network.RecordAddressFamily(ctx, conn.RemoteAddr())
 
 	if timeout > 0 {
 		_ = conn.SetDeadline(time.Now().Add(timeout))
@@ -0,0 +1,242 @@
+package checker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// WhoisRecord holds registration details extracted from a WHOIS response.
+// Dates are RFC3339 when the registry format is recognized and the raw value
+// otherwise.
+type WhoisRecord struct {
+	Registrar   string
+	Created     string
+	Updated     string
+	Expiration  string
+	Status      []string
+	NameServers []string
+}
+
+// Field labels used by common registry formats (ICANN gTLD, CNNIC, Nominet,
+// DENIC, AFNIC, EURid, JPRS, RU-CENTER, Registro.br), lower-cased and with
+// JPRS-style brackets removed.
+var (
+	whoisRegistrarKeys = []string{"registrar", "sponsoring registrar", "registrar name", "registrar organization"}
+	whoisCreatedKeys   = []string{"creation date", "created", "created on", "created date", "registered on", "registered", "registration time", "registration date", "domain registration date"}
+	whoisUpdatedKeys   = []string{"updated date", "updated", "last updated", "last updated on", "last modified", "last-update", "changed", "modified"}
+	whoisExpiryKeys    = []string{"registry expiry date", "registrar registration expiration date", "registry expiration date", "expiry date", "expiration date", "expiration time", "expires on", "expires", "expire date", "paid-till", "renewal date", "domain expiration date"}
+	whoisStatusKeys    = []string{"domain status", "status", "registration status", "state"}
+	whoisNSKeys        = []string{"name server", "name servers", "nameserver", "nameservers", "nserver"}
+)
+
+var whoisDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"2006.01.02 15:04:05",
+	"2006.01.02",
+	"02-Jan-2006",
+	"02-Jan-2006 15:04:05",
+	"02.01.2006",
+	"02/01/2006",
+	"20060102",
+	"January 2 2006",
+	"Mon Jan 2 15:04:05 MST 2006",
+}
+
+// parseWhois extracts registrar, dates, status codes, and name servers from a
+// WHOIS response. It handles "Key: value" lines, JPRS "[Key] value" lines, and
+// block layouts where a "Key:" header is followed by indented values.
+func parseWhois(body string) WhoisRecord {
+	var record WhoisRecord
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	for i, line := range lines {
+		key, value, ok := splitWhoisLine(line)
+		if !ok {
+			continue
+		}
+
+		values := []string{value}
+		if value == "" {
+			// Block layout: the values follow on more deeply indented lines.
+			// Block lines are still visited by the loop, so nested "Key: value"
+			// lines (Nominet "Relevant dates:") are parsed on their own.
+			values = whoisBlock(lines[i+1:], indentOf(line))
+			if len(values) == 0 {
+				continue
+			}
+		}
+
+		switch {
+		case matchesKey(key, whoisRegistrarKeys):
+			if record.Registrar == "" {
+				record.Registrar = cleanRegistrar(values[0])
+			}
+		case matchesKey(key, whoisCreatedKeys):
+			if record.Created == "" {
+				record.Created = normalizeWhoisDate(values[0])
+			}
+		case matchesKey(key, whoisUpdatedKeys):
+			if record.Updated == "" {
+				record.Updated = normalizeWhoisDate(values[0])
+			}
+		case matchesKey(key, whoisExpiryKeys):
+			if record.Expiration == "" {
+				record.Expiration = normalizeWhoisDate(values[0])
+			}
+		case matchesKey(key, whoisStatusKeys):
+			for _, entry := range values {
+				record.Status = appendUnique(record.Status, cleanWhoisStatus(entry))
+			}
+		case matchesKey(key, whoisNSKeys):
+			for _, entry := range values {
+				record.NameServers = appendUnique(record.NameServers, cleanNameServer(entry))
+			}
+		}
+	}
+
+	return record
+}
+
+// addExtra records the parsed fields in ExtraData using the same keys as RDAP.
+func (r WhoisRecord) addExtra(extra map[string]any) {
+	if r.Registrar != "" {
+		extra["registrar"] = r.Registrar
+	}
+	if r.Created != "" {
+		extra["creation"] = r.Created
+	}
+	if r.Updated != "" {
+		extra["last_changed"] = r.Updated
+	}
+	if r.Expiration != "" {
+		extra["expiration"] = r.Expiration
+	}
+	if len(r.Status) > 0 {
+		extra["status"] = r.Status
+		extra["status_details"] = core.ExplainDomainStatuses(r.Status)
+	}
+	if len(r.NameServers) > 0 {
+		extra["nameservers"] = r.NameServers
+	}
+}
+
+func splitWhoisLine(line string) (string, string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "%") || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ">>>") {
+		return "", "", false
+	}
+
+	// JPRS: "[Expires on]    2026/03/31"
+	if strings.HasPrefix(trimmed, "[") {
+		end := strings.Index(trimmed, "]")
+		if end < 0 {
+			return "", "", false
+		}
+		return strings.ToLower(strings.TrimSpace(trimmed[1:end])), strings.TrimSpace(trimmed[end+1:]), true
+	}
+
+	key, value, ok := strings.Cut(trimmed, ":")
+	if !ok {
+		return "", "", false
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	if key == "" {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(value), true
+}
+
+// whoisBlock returns the non-empty lines indented deeper than the header.
+func whoisBlock(lines []string, headerIndent int) []string {
+	block := make([]string, 0)
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" || indentOf(line) <= headerIndent {
+			break
+		}
+		block = append(block, strings.TrimSpace(line))
+	}
+	return block
+}
+
+func matchesKey(key string, keys []string) bool {
+	for _, candidate := range keys {
+		if key == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// cleanRegistrar strips Nominet tags ("Name [Tag = X]") and EURid-style
+// "Name:" prefixes.
+func cleanRegistrar(value string) string {
+	if key, rest, ok := strings.Cut(value, ":"); ok && strings.EqualFold(strings.TrimSpace(key), "name") {
+		value = rest
+	}
+	if idx := strings.Index(value, " [Tag ="); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value)
+}
+
+// cleanWhoisStatus drops the ICANN EPP status URL ("clientHold https://icann.org/epp#clientHold").
+func cleanWhoisStatus(value string) string {
+	if idx := strings.Index(value, " http"); idx >= 0 {
+		value = value[:idx]
+	}
+	if idx := strings.Index(value, " ("); idx >= 0 && strings.Contains(value[idx:], "http") {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value)
+}
+
+// cleanNameServer keeps the host name, dropping glue addresses and the trailing dot.
+func cleanNameServer(value string) string {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(strings.ToLower(fields[0]), ".")
+}
+
+func normalizeWhoisDate(value string) string {
+	value = strings.TrimSpace(value)
+	// Drop trailing comments and zone annotations: "20250101 #12345", "2024/02/01 01:05:03 (JST)".
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+	if idx := strings.Index(value, " ("); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+	for _, layout := range whoisDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC().Format(time.RFC3339)
+		}
+	}
+	return value
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, existing := range values {
+		if strings.EqualFold(existing, value) {
+			return values
+		}
+	}
+	return append(values, value)
+}
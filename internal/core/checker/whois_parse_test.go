@@ -0,0 +1,169 @@
+package checker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestParseWhoisICANN(t *testing.T) {
+	body := `Domain Name: EXAMPLE.IO
+Registry Domain ID: 123
+Registrar WHOIS Server: whois.example-registrar.com
+Updated Date: 2024-05-01T10:00:00Z
+Creation Date: 2015-03-02T18:04:11Z
+Registry Expiry Date: 2026-03-02T18:04:11Z
+Registrar: Example Registrar, LLC
+Registrar IANA Id: 1234
+Domain Status: clientTransferProhibited https://icann.org/epp#clientTransferProhibited
+Domain Status: clientDeleteProhibited https://icann.org/epp#clientDeleteProhibited
+Name Server: NS1.EXAMPLE.NET
+Name Server: NS2.EXAMPLE.NET
+>>> Last update of WHOIS database: 2026-01-01T00:00:00Z <<<`
+
+	record := parseWhois(body)
+	require.Equal(t, "Example Registrar, LLC", record.Registrar)
+	require.Equal(t, "2015-03-02T18:04:11Z", record.Created)
+	require.Equal(t, "2024-05-01T10:00:00Z", record.Updated)
+	require.Equal(t, "2026-03-02T18:04:11Z", record.Expiration)
+	require.Equal(t, []string{"clientTransferProhibited", "clientDeleteProhibited"}, record.Status)
+	require.Equal(t, []string{"ns1.example.net", "ns2.example.net"}, record.NameServers)
+}
+
+func TestParseWhoisNominet(t *testing.T) {
+	body := `
+    Domain name:
+        example.co.uk
+
+    Registrar:
+        Example Ltd [Tag = EXAMPLE]
+        URL: https://www.example.co.uk
+
+    Relevant dates:
+        Registered on: 14-Apr-2001
+        Expiry date:  14-Apr-2027
+        Last updated:  10-Mar-2025
+
+    Registration status:
+        Registered until expiry date.
+
+    Name servers:
+        ns1.example.net
+        ns2.example.net    192.0.2.1
+`
+
+	record := parseWhois(body)
+	require.Equal(t, "Example Ltd", record.Registrar)
+	require.Equal(t, "2001-04-14T00:00:00Z", record.Created)
+	require.Equal(t, "2027-04-14T00:00:00Z", record.Expiration)
+	require.Equal(t, "2025-03-10T00:00:00Z", record.Updated)
+	require.Equal(t, []string{"Registered until expiry date."}, record.Status)
+	require.Equal(t, []string{"ns1.example.net", "ns2.example.net"}, record.NameServers)
+}
+
+func TestParseWhoisRegistryFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected WhoisRecord
+	}{
+		{
+			name: "jprs",
+			body: "[Domain Name]                   EXAMPLE.JP\n[Name Server]                   ns1.example.jp\n[Created on]                    2001/05/10\n[Expires on]                    2026/05/31\n[Status]                        Active\n[Last Updated]                  2025/06/01 01:05:03 (JST)\n",
+			expected: WhoisRecord{
+				Created:     "2001-05-10T00:00:00Z",
+				Updated:     "2025-06-01T01:05:03Z",
+				Expiration:  "2026-05-31T00:00:00Z",
+				Status:      []string{"Active"},
+				NameServers: []string{"ns1.example.jp"},
+			},
+		},
+		{
+			name: "denic",
+			body: "Domain: example.de\nNserver: ns1.example.net\nStatus: connect\nChanged: 2020-07-13T12:17:51+02:00\n",
+			expected: WhoisRecord{
+				Updated:     "2020-07-13T10:17:51Z",
+				Status:      []string{"connect"},
+				NameServers: []string{"ns1.example.net"},
+			},
+		},
+		{
+			name: "afnic",
+			body: "%% comment: ignored\ndomain:      example.fr\nstatus:      ACTIVE\nregistrar:   EXAMPLE SAS\nExpiry Date: 2026-01-09T15:26:42Z\ncreated:     2000-01-10T00:00:00Z\n\nnic-hdl:     ABC123-FRNIC\ncreated:     1999-01-01T00:00:00Z\n",
+			expected: WhoisRecord{
+				Registrar:  "EXAMPLE SAS",
+				Created:    "2000-01-10T00:00:00Z",
+				Expiration: "2026-01-09T15:26:42Z",
+				Status:     []string{"ACTIVE"},
+			},
+		},
+		{
+			name: "cnnic",
+			body: "Domain Name: example.cn\nDomain Status: ok\nSponsoring Registrar: Example Network Co., Ltd\nRegistration Time: 2003-03-17 12:20:05\nExpiration Time: 2027-03-17 12:48:36\n",
+			expected: WhoisRecord{
+				Registrar:  "Example Network Co., Ltd",
+				Created:    "2003-03-17T12:20:05Z",
+				Expiration: "2027-03-17T12:48:36Z",
+				Status:     []string{"ok"},
+			},
+		},
+		{
+			name: "registro.br",
+			body: "domain:      example.com.br\nnserver:     a.dns.br\ncreated:     19990101 #12345\nexpires:     20270101\nstatus:      published\n",
+			expected: WhoisRecord{
+				Created:     "1999-01-01T00:00:00Z",
+				Expiration:  "2027-01-01T00:00:00Z",
+				Status:      []string{"published"},
+				NameServers: []string{"a.dns.br"},
+			},
+		},
+		{
+			name: "eurid",
+			body: "Domain: example.eu\n\nRegistrar:\n        Name: Example BV\n        Website: https://example.eu\n\nName servers:\n        ns1.example.eu\n",
+			expected: WhoisRecord{
+				Registrar:   "Example BV",
+				NameServers: []string{"ns1.example.eu"},
+			},
+		},
+		{
+			name:     "unparseable date kept raw",
+			body:     "Expiration Date: before Aug-1996\n",
+			expected: WhoisRecord{Expiration: "before Aug-1996"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, parseWhois(tt.body))
+		})
+	}
+}
+
+func TestDomainCheckerWhoisExtraData(t *testing.T) {
+	checker := &DomainChecker{
+		Store: &stubBootstrapStore{},
+		Whois: &stubWhoisClient{
+			response: &WhoisResponse{
+				Server: "whois.example",
+				Body:   "[Domain Name] EXAMPLE.JP\n[Created on] 2001/05/10\n[Expires on] 2026/05/31\n[Status] Active\n",
+			},
+		},
+		WhoisCfg: WhoisFallbackConfig{
+			Enabled:           true,
+			TLDs:              []string{"jp"},
+			RequireExplicit:   true,
+			AvailablePatterns: []string{"no match!!"},
+			TakenPatterns:     []string{"registrant:"},
+		},
+	}
+
+	result, err := checker.Check(context.Background(), "example.jp")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityTaken, result.Available, "registration data implies taken")
+	require.Equal(t, "2001-05-10T00:00:00Z", result.ExtraData["creation"])
+	require.Equal(t, "2026-05-31T00:00:00Z", result.ExtraData["expiration"])
+	require.Equal(t, []string{"Active"}, result.ExtraData["status"])
+}
@@ -0,0 +1,111 @@
+package checker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// whoisServerPool paces the queries sent to one WHOIS server: slots caps how
+// many run at once and next is the earliest start of the following query.
+type whoisServerPool struct {
+	slots chan struct{}
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// query sends one query through the server's pool, waiting for a free slot
+// and for the politeness delay since the previous query to pass.
+func (c *DefaultWhoisClient) query(ctx context.Context, server, query string) (string, error) {
+	release, err := c.acquire(ctx, server)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return queryWhois(ctx, c.Dial, server, query, c.Timeout)
+}
+
+func (c *DefaultWhoisClient) acquire(ctx context.Context, server string) (func(), error) {
+	pool := c.serverPool(server)
+	if pool.slots != nil {
+		select {
+		case pool.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if pool.slots != nil {
+			<-pool.slots
+		}
+	}
+
+	delay := c.serverDelay(server)
+	if delay <= 0 {
+		return release, nil
+	}
+	pool.mu.Lock()
+	now := time.Now()
+	start := pool.next
+	if start.Before(now) {
+		start = now
+	}
+	pool.next = start.Add(delay)
+	pool.mu.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+func (c *DefaultWhoisClient) serverPool(server string) *whoisServerPool {
+	key := strings.ToLower(strings.TrimSpace(server))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pools == nil {
+		c.pools = map[string]*whoisServerPool{}
+	}
+	pool, ok := c.pools[key]
+	if !ok {
+		pool = &whoisServerPool{}
+		if c.MaxPerServer > 0 {
+			pool.slots = make(chan struct{}, c.MaxPerServer)
+		}
+		c.pools[key] = pool
+	}
+	return pool
+}
+
+func (c *DefaultWhoisClient) serverDelay(server string) time.Duration {
+	if delay, ok := c.ServerDelays[strings.ToLower(strings.TrimSpace(server))]; ok {
+		return delay
+	}
+	return c.Delay
+}
+
+// referral returns the WHOIS server IANA named for tld in an earlier lookup.
+func (c *DefaultWhoisClient) referral(tld string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	server, ok := c.referrals[tld]
+	return server, ok
+}
+
+func (c *DefaultWhoisClient) setReferral(tld, server string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.referrals == nil {
+		c.referrals = map[string]string{}
+	}
+	c.referrals[tld] = server
+}
@@ -0,0 +1,120 @@
+package checker
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWhoisDial answers WHOIS queries over in-memory pipes, recording each
+// query and the peak number of open connections per server.
+type fakeWhoisDial struct {
+	hold time.Duration
+
+	mu      sync.Mutex
+	queries []string
+	starts  map[string][]time.Time
+	open    map[string]int
+	peak    map[string]int
+}
+
+func (f *fakeWhoisDial) dial(_ context.Context, _, address string) (net.Conn, error) {
+	server, _, _ := net.SplitHostPort(address)
+	client, conn := net.Pipe()
+	go func() {
+		defer conn.Close() //nolint:errcheck
+		query, _ := bufio.NewReader(conn).ReadString('\n')
+		query = strings.TrimSpace(query)
+
+		f.mu.Lock()
+		if f.starts == nil {
+			f.starts, f.open, f.peak = map[string][]time.Time{}, map[string]int{}, map[string]int{}
+		}
+		f.queries = append(f.queries, server+" "+query)
+		f.starts[server] = append(f.starts[server], time.Now())
+		f.open[server]++
+		if f.open[server] > f.peak[server] {
+			f.peak[server] = f.open[server]
+		}
+		f.mu.Unlock()
+
+		time.Sleep(f.hold)
+		if server == whoisIanaServer {
+			_, _ = conn.Write([]byte("refer: whois.nic.example\n"))
+		} else {
+			_, _ = conn.Write([]byte("Domain Name: " + query + "\n"))
+		}
+
+		f.mu.Lock()
+		f.open[server]--
+		f.mu.Unlock()
+	}()
+	return client, nil
+}
+
+func TestDefaultWhoisClientCachesReferrals(t *testing.T) {
+	fake := &fakeWhoisDial{}
+	client := &DefaultWhoisClient{Dial: fake.dial, Timeout: time.Second}
+
+	for _, domain := range []string{"acme.example", "zentro.example"} {
+		resp, err := client.Lookup(context.Background(), "example", domain)
+		require.NoError(t, err)
+		require.Equal(t, "whois.nic.example", resp.Server)
+	}
+	require.Equal(t, []string{
+		"whois.iana.org example",
+		"whois.nic.example acme.example",
+		"whois.nic.example zentro.example",
+	}, fake.queries)
+}
+
+func TestDefaultWhoisClientPacesServers(t *testing.T) {
+	fake := &fakeWhoisDial{hold: 20 * time.Millisecond}
+	client := &DefaultWhoisClient{
+		Servers:      map[string]string{"example": "whois.nic.example", "test": "whois.nic.test"},
+		Dial:         fake.dial,
+		Timeout:      time.Second,
+		MaxPerServer: 2,
+		ServerDelays: map[string]time.Duration{"whois.nic.test": 30 * time.Millisecond},
+	}
+
+	var wg sync.WaitGroup
+	var failed int32
+	for i := 0; i < 6; i++ {
+		for _, tld := range []string{"example", "test"} {
+			wg.Add(1)
+			go func(domain, tld string) {
+				defer wg.Done()
+				if _, err := client.Lookup(context.Background(), tld, domain); err != nil {
+					atomic.AddInt32(&failed, 1)
+				}
+			}("name"+string(rune('a'+i))+"."+tld, tld)
+		}
+	}
+	wg.Wait()
+	require.Zero(t, failed)
+
+	require.LessOrEqual(t, fake.peak["whois.nic.example"], 2)
+	starts := fake.starts["whois.nic.test"]
+	require.Len(t, starts, 6)
+	require.GreaterOrEqual(t, starts[5].Sub(starts[0]), 140*time.Millisecond)
+}
+
+func TestDefaultWhoisClientPoolHonorsContext(t *testing.T) {
+	client := &DefaultWhoisClient{Delay: time.Hour}
+	release, err := client.acquire(context.Background(), "whois.nic.example")
+	require.NoError(t, err)
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.acquire(ctx, "WHOIS.nic.example")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Confidence levels derived from the score.
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// Confidence rates how far an available/taken verdict can be trusted.
+type Confidence struct {
+	// Score runs from 0 (no trust) to 1.
+	Score float64 `json:"score"`
+	Level string  `json:"level"`
+	// Factors lists what shaped the score, e.g. "source: whois".
+	Factors []string `json:"factors,omitempty"`
+}
+
+// sourceConfidence is the starting score per resolution source. RDAP, the
+// registry APIs, and service catalogs are authoritative; WHOIS text is parsed
+// heuristically and DNS only shows that something is delegated. Slack and
+// Google Workspace verdicts are hints from URL redirects and MX records.
+var sourceConfidence = map[string]float64{
+	"rdap":             0.95,
+	"npm":              0.95,
+	"pypi":             0.95,
+	"cargo":            0.95,
+	"catalog":          0.95,
+	"github":           0.9,
+	"discord":          0.85,
+	"whois":            0.8,
+	"http-plugin":      0.8,
+	"slack":            0.7,
+	"google-workspace": 0.6,
+	"dns":              0.55,
+}
+
+const (
+	defaultSourceConfidence = 0.7
+	// A cached verdict loses up to maxCacheAgePenalty as it approaches
+	// cacheAgeHorizon.
+	maxCacheAgePenalty = 0.25
+	cacheAgeHorizon    = 7 * 24 * time.Hour
+	// agreementWeight is the share of the score lost when none of the
+	// consulted servers confirmed the verdict.
+	agreementWeight = 0.3
+
+	highConfidence   = 0.85
+	mediumConfidence = 0.6
+)
+
+// ScoreConfidence rates a result from its resolution source, the age of a
+// cached answer, and how many consulted servers agreed. It returns nil for
+// results without an available/taken verdict.
+func ScoreConfidence(result *CheckResult, now time.Time) *Confidence {
+	if result == nil || (result.Available != AvailabilityAvailable && result.Available != AvailabilityTaken) {
+		return nil
+	}
+	provenance := result.Provenance
+
+	source := strings.ToLower(strings.TrimSpace(provenance.Source))
+	score, ok := sourceConfidence[source]
+	if !ok {
+		score = defaultSourceConfidence
+	}
+	if source == "" {
+		source = "unknown"
+	}
+	factors := []string{"source: " + source}
+
+	if provenance.FromCache && !provenance.ResolvedAt.IsZero() {
+		if age := now.Sub(provenance.ResolvedAt); age > 0 {
+			score -= maxCacheAgePenalty * math.Min(float64(age)/float64(cacheAgeHorizon), 1)
+			factors = append(factors, "cached "+formatAge(age)+" ago")
+		}
+		if provenance.CacheStale {
+			factors = append(factors, "stale, refreshing")
+		}
+	}
+
+	if provenance.ServersConsulted > 1 {
+		agreed := min(max(provenance.ServersAgreed, 0), provenance.ServersConsulted)
+		ratio := float64(agreed) / float64(provenance.ServersConsulted)
+		score *= 1 - agreementWeight*(1-ratio)
+		factors = append(factors, fmt.Sprintf("%d/%d servers agree", agreed, provenance.ServersConsulted))
+	}
+
+	score = math.Round(math.Min(math.Max(score, 0), 1)*100) / 100
+	return &Confidence{Score: score, Level: confidenceLevel(score), Factors: factors}
+}
+
+func confidenceLevel(score float64) string {
+	switch {
+	case score >= highConfidence:
+		return ConfidenceHigh
+	case score >= mediumConfidence:
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age/time.Minute))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(age/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(age/(24*time.Hour)))
+	}
+}
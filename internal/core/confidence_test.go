@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreConfidenceBySource(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	score := func(source string) float64 {
+		c := ScoreConfidence(&CheckResult{Available: AvailabilityTaken, Provenance: Provenance{Source: source}}, now)
+		require.NotNil(t, c)
+		return c.Score
+	}
+
+	require.Greater(t, score("rdap"), score("whois"))
+	require.Greater(t, score("whois"), score("dns"))
+
+	rdap := ScoreConfidence(&CheckResult{Available: AvailabilityAvailable, Provenance: Provenance{Source: "rdap"}}, now)
+	require.Equal(t, ConfidenceHigh, rdap.Level)
+	require.Equal(t, []string{"source: rdap"}, rdap.Factors)
+
+	dns := ScoreConfidence(&CheckResult{Available: AvailabilityTaken, Provenance: Provenance{Source: "dns"}}, now)
+	require.Equal(t, ConfidenceLow, dns.Level)
+}
+
+func TestScoreConfidenceCacheAge(t *testing.T) {
+	now := time.Date(2026, 1, 8, 0, 0, 0, 0, time.UTC)
+	fresh := ScoreConfidence(&CheckResult{Available: AvailabilityTaken, Provenance: Provenance{Source: "rdap"}}, now)
+	cached := ScoreConfidence(&CheckResult{
+		Available:  AvailabilityTaken,
+		Provenance: Provenance{Source: "rdap", FromCache: true, ResolvedAt: now.Add(-84 * time.Hour)},
+	}, now)
+	stale := ScoreConfidence(&CheckResult{
+		Available:  AvailabilityTaken,
+		Provenance: Provenance{Source: "rdap", FromCache: true, ResolvedAt: now.Add(-30 * 24 * time.Hour)},
+	}, now)
+
+	require.Equal(t, 0.83, cached.Score)
+	require.Contains(t, cached.Factors, "cached 3d ago")
+	require.Equal(t, 0.7, stale.Score)
+	require.Greater(t, fresh.Score, cached.Score)
+}
+
+func TestScoreConfidenceServerAgreement(t *testing.T) {
+	now := time.Now()
+	c := ScoreConfidence(&CheckResult{
+		Available:  AvailabilityTaken,
+		Provenance: Provenance{Source: "rdap", ServersConsulted: 2, ServersAgreed: 1},
+	}, now)
+	require.Equal(t, 0.81, c.Score)
+	require.Equal(t, ConfidenceMedium, c.Level)
+	require.Contains(t, c.Factors, "1/2 servers agree")
+
+	single := ScoreConfidence(&CheckResult{
+		Available:  AvailabilityTaken,
+		Provenance: Provenance{Source: "rdap", ServersConsulted: 1, ServersAgreed: 1},
+	}, now)
+	require.Equal(t, 0.95, single.Score)
+}
+
+func TestScoreConfidenceWithoutVerdict(t *testing.T) {
+	require.Nil(t, ScoreConfidence(nil, time.Now()))
+	for _, state := range []Availability{AvailabilityUnknown, AvailabilityError, AvailabilityRateLimited, AvailabilityUnsupported} {
+		require.Nil(t, ScoreConfidence(&CheckResult{Available: state, Provenance: Provenance{Source: "rdap"}}, time.Now()))
+	}
+}
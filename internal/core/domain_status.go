@@ -0,0 +1,105 @@
+package core
+
+import "strings"
+
+// DomainStatus explains an EPP or RDAP domain status code.
+type DomainStatus struct {
+	// Code is the status as reported by RDAP or WHOIS.
+	Code string `json:"code"`
+	// SetBy is "registrar" for client* codes and "registry" for server*
+	// codes; empty for lifecycle states.
+	SetBy string `json:"set_by,omitempty"`
+	// Label is a short form for table notes; empty for codes that do not
+	// matter when acquiring a domain (ok, active).
+	Label   string `json:"label,omitempty"`
+	Meaning string `json:"meaning"`
+}
+
+type domainStatusInfo struct {
+	label   string
+	meaning string
+}
+
+// domainStatuses is keyed by the lowercase code without spaces, so the EPP
+// form (clientTransferProhibited) and the RDAP form (client transfer
+// prohibited) share an entry. Codes that differ only by client/server prefix
+// share the base entry.
+var domainStatuses = map[string]domainStatusInfo{
+	"ok":                 {meaning: "No restrictions or pending operations"},
+	"active":             {meaning: "No restrictions or pending operations"},
+	"inactive":           {label: "inactive", meaning: "No name servers are delegated; the domain does not resolve"},
+	"addperiod":          {label: "new", meaning: "Registered within the last few days; the registrar can still cancel it for a refund"},
+	"autorenewperiod":    {label: "auto-renewed", meaning: "Recently auto-renewed; the registrar can still reverse the renewal"},
+	"renewperiod":        {label: "renewed", meaning: "Recently renewed; the renewal can still be reversed"},
+	"transferperiod":     {label: "transferred", meaning: "Recently moved to a new registrar and cannot move again yet"},
+	"redemptionperiod":   {label: "redemption", meaning: "Deleted but restorable by the owner for about 30 days before it is released"},
+	"pendingcreate":      {label: "pending create", meaning: "A registration request is being processed"},
+	"pendingdelete":      {label: "pending delete", meaning: "Scheduled for deletion; usually released for registration within about 5 days"},
+	"pendingrenew":       {label: "pending renew", meaning: "A renewal request is being processed"},
+	"pendingrestore":     {label: "pending restore", meaning: "The owner asked to restore the domain from redemption"},
+	"pendingtransfer":    {label: "pending transfer", meaning: "A transfer to another registrar is in progress"},
+	"pendingupdate":      {label: "pending update", meaning: "An update request is being processed"},
+	"deleteprohibited":   {label: "delete lock", meaning: "Cannot be deleted"},
+	"hold":               {label: "on hold", meaning: "Removed from DNS and does not resolve, often for non-payment, a dispute, or abuse"},
+	"renewprohibited":    {label: "renew lock", meaning: "Cannot be renewed, often during a dispute or before deletion"},
+	"transferprohibited": {label: "transfer lock", meaning: "Cannot be transferred to another registrar until the lock is removed"},
+	"updateprohibited":   {label: "update lock", meaning: "Contacts, name servers, and other details cannot be changed"},
+}
+
+// ExplainDomainStatus explains a status code. Unknown codes keep an empty
+// Meaning and Label.
+func ExplainDomainStatus(code string) DomainStatus {
+	status := DomainStatus{Code: strings.TrimSpace(code)}
+	key := strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(status.Code))
+
+	switch {
+	case strings.HasPrefix(key, "client"):
+		status.SetBy = "registrar"
+		key = strings.TrimPrefix(key, "client")
+	case strings.HasPrefix(key, "server"):
+		status.SetBy = "registry"
+		key = strings.TrimPrefix(key, "server")
+	}
+
+	info, ok := domainStatuses[key]
+	if !ok {
+		status.SetBy = ""
+		return status
+	}
+	status.Label = info.label
+	status.Meaning = info.meaning
+	return status
+}
+
+// ExplainDomainStatuses explains each code in order, skipping blanks.
+func ExplainDomainStatuses(codes []string) []DomainStatus {
+	statuses := make([]DomainStatus, 0, len(codes))
+	for _, code := range codes {
+		if strings.TrimSpace(code) == "" {
+			continue
+		}
+		statuses = append(statuses, ExplainDomainStatus(code))
+	}
+	return statuses
+}
+
+// DomainStatusCodes returns the status codes of a domain result's ExtraData,
+// which hold []string when fresh and []any when read back from the cache.
+func DomainStatusCodes(extra map[string]any) []string {
+	switch values := extra["status"].(type) {
+	case []string:
+		return values
+	case []any:
+		codes := make([]string, 0, len(values))
+		for _, value := range values {
+			if code, ok := value.(string); ok {
+				codes = append(codes, code)
+			}
+		}
+		return codes
+	case string:
+		return []string{values}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainDomainStatus(t *testing.T) {
+	epp := ExplainDomainStatus("clientTransferProhibited")
+	require.Equal(t, "registrar", epp.SetBy)
+	require.Equal(t, "transfer lock", epp.Label)
+	require.NotEmpty(t, epp.Meaning)
+
+	rdap := ExplainDomainStatus("server hold")
+	require.Equal(t, "server hold", rdap.Code)
+	require.Equal(t, "registry", rdap.SetBy)
+	require.Equal(t, "on hold", rdap.Label)
+
+	require.Equal(t, "pending delete", ExplainDomainStatus("pendingDelete").Label)
+	require.Empty(t, ExplainDomainStatus("active").Label)
+	require.NotEmpty(t, ExplainDomainStatus("active").Meaning)
+
+	unknown := ExplainDomainStatus("clientSomethingNew")
+	require.Empty(t, unknown.SetBy)
+	require.Empty(t, unknown.Meaning)
+}
+
+func TestDomainStatusCodes(t *testing.T) {
+	require.Equal(t, []string{"ok"}, DomainStatusCodes(map[string]any{"status": []string{"ok"}}))
+	require.Equal(t, []string{"ok", "serverHold"}, DomainStatusCodes(map[string]any{"status": []any{"ok", 1, "serverHold"}}))
+	require.Nil(t, DomainStatusCodes(nil))
+
+	require.Len(t, ExplainDomainStatuses([]string{"ok", " ", "clientHold"}), 2)
+}
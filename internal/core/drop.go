@@ -0,0 +1,171 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Drop phases reported in DropWindow.Phase.
+const (
+	DropPhaseRedemption    = "redemption"
+	DropPhasePendingDelete = "pending delete"
+)
+
+// dropSlack widens estimated drop dates, since registries release names in
+// batches at varying times of day.
+const dropSlack = 24 * time.Hour
+
+// DropWindow is the expected time range in which a deleted domain is
+// released for registration.
+type DropWindow struct {
+	Earliest time.Time `json:"earliest"`
+	Latest   time.Time `json:"latest"`
+	Phase    string    `json:"phase"`
+	// Basis explains which dates and registry policy the estimate used.
+	Basis string `json:"basis"`
+}
+
+// dropPolicy is a registry's deletion lifecycle after a domain is deleted.
+type dropPolicy struct {
+	redemption    time.Duration
+	pendingDelete time.Duration
+}
+
+// defaultDropPolicy is the ICANN gTLD lifecycle: a 30-day redemption grace
+// period followed by 5 days of pendingDelete.
+var defaultDropPolicy = dropPolicy{redemption: 30 * 24 * time.Hour, pendingDelete: 5 * 24 * time.Hour}
+
+// dropPolicies lists ccTLD registries whose lifecycle differs from the gTLD
+// default.
+var dropPolicies = map[string]dropPolicy{
+	// DENIC: 30-day redemption grace period, released right after.
+	"de": {redemption: 30 * 24 * time.Hour},
+	// EURid: 40-day quarantine, released right after.
+	"eu": {redemption: 40 * 24 * time.Hour},
+}
+
+func dropPolicyFor(tld string) dropPolicy {
+	if policy, ok := dropPolicies[strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tld), "."))]; ok {
+		return policy
+	}
+	return defaultDropPolicy
+}
+
+// PredictDrop estimates when a domain in redemption or pendingDelete is
+// released, from the status codes and dates of a domain result's ExtraData
+// and the registry policy for tld. A registry-published deletion date wins;
+// otherwise the phase is assumed to have started at the last change, or at
+// checkedAt when that is unknown. It returns false for other domains.
+func PredictDrop(tld string, extra map[string]any, checkedAt time.Time) (DropWindow, bool) {
+	phase := ""
+	for _, code := range DomainStatusCodes(extra) {
+		switch ExplainDomainStatus(code).Label {
+		case "pending delete":
+			phase = DropPhasePendingDelete
+		case "redemption":
+			if phase == "" {
+				phase = DropPhaseRedemption
+			}
+		}
+	}
+	if phase == "" {
+		return DropWindow{}, false
+	}
+
+	if deletion, ok := extraTime(extra, "deletion"); ok {
+		return DropWindow{
+			Earliest: deletion.Add(-dropSlack),
+			Latest:   deletion.Add(dropSlack),
+			Phase:    phase,
+			Basis:    "registry deletion date",
+		}, true
+	}
+
+	policy := dropPolicyFor(tld)
+	remaining := policy.pendingDelete
+	if phase == DropPhaseRedemption {
+		remaining += policy.redemption
+	}
+
+	if changed, ok := extraTime(extra, "last_changed"); ok && !changed.After(checkedAt) {
+		drop := changed.Add(remaining)
+		return DropWindow{
+			Earliest: drop.Add(-dropSlack),
+			Latest:   drop.Add(dropSlack),
+			Phase:    phase,
+			Basis:    phase + " since last change, " + policyBasis(policy),
+		}, true
+	}
+
+	// Without a start date the phase may end any time within its length.
+	earliest := checkedAt
+	if phase == DropPhaseRedemption {
+		earliest = checkedAt.Add(policy.pendingDelete)
+	}
+	return DropWindow{
+		Earliest: earliest,
+		Latest:   checkedAt.Add(remaining + dropSlack),
+		Phase:    phase,
+		Basis:    phase + " start unknown, " + policyBasis(policy),
+	}, true
+}
+
+// DropListWindow is the drop window for a domain that a drop list says is
+// released on date.
+func DropListWindow(source string, date time.Time) DropWindow {
+	return DropWindow{
+		Earliest: date.UTC(),
+		Latest:   date.UTC().Add(dropSlack),
+		Phase:    DropPhasePendingDelete,
+		Basis:    source + " drop list",
+	}
+}
+
+// DropWindowExtra returns window in the ExtraData form stored on results.
+func DropWindowExtra(window DropWindow) map[string]any {
+	return map[string]any{
+		"earliest": window.Earliest.UTC().Format(time.RFC3339),
+		"latest":   window.Latest.UTC().Format(time.RFC3339),
+		"phase":    window.Phase,
+		"basis":    window.Basis,
+	}
+}
+
+// ParseDropWindow reads the "drop_window" entry of a domain result's
+// ExtraData.
+func ParseDropWindow(extra map[string]any) (DropWindow, bool) {
+	raw, ok := extra["drop_window"].(map[string]any)
+	if !ok {
+		return DropWindow{}, false
+	}
+	earliest, okEarliest := extraTime(raw, "earliest")
+	latest, okLatest := extraTime(raw, "latest")
+	if !okEarliest || !okLatest {
+		return DropWindow{}, false
+	}
+	window := DropWindow{Earliest: earliest, Latest: latest}
+	window.Phase, _ = raw["phase"].(string)
+	window.Basis, _ = raw["basis"].(string)
+	return window, true
+}
+
+func policyBasis(policy dropPolicy) string {
+	days := func(d time.Duration) int { return int(d / (24 * time.Hour)) }
+	if policy.pendingDelete == 0 {
+		return fmt.Sprintf("registry releases after %d-day redemption", days(policy.redemption))
+	}
+	return fmt.Sprintf("registry policy %d-day redemption + %d-day pendingDelete", days(policy.redemption), days(policy.pendingDelete))
+}
+
+func extraTime(extra map[string]any, key string) (time.Time, bool) {
+	value, ok := extra[key].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return parsed.UTC(), true
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredictDrop(t *testing.T) {
+	checkedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	cases := []struct {
+		name     string
+		tld      string
+		extra    map[string]any
+		ok       bool
+		phase    string
+		earliest time.Time
+		latest   time.Time
+	}{
+		{
+			name:  "active domain",
+			tld:   "com",
+			extra: map[string]any{"status": []string{"client transfer prohibited"}},
+		},
+		{
+			name:     "pending delete since last change",
+			tld:      "com",
+			extra:    map[string]any{"status": []any{"pending delete", "redemption period"}, "last_changed": "2026-10-14T00:00:00Z"},
+			ok:       true,
+			phase:    DropPhasePendingDelete,
+			earliest: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			latest:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "redemption since last change",
+			tld:      "com",
+			extra:    map[string]any{"status": []string{"redemptionPeriod"}, "last_changed": "2026-10-01T00:00:00Z"},
+			ok:       true,
+			phase:    DropPhaseRedemption,
+			earliest: time.Date(2026, 11, 4, 0, 0, 0, 0, time.UTC),
+			latest:   time.Date(2026, 11, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "registry deletion date wins",
+			tld:      "com",
+			extra:    map[string]any{"status": []string{"pendingDelete"}, "last_changed": "2026-10-01T00:00:00Z", "deletion": "2026-10-17T19:00:00Z"},
+			ok:       true,
+			phase:    DropPhasePendingDelete,
+			earliest: time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC),
+			latest:   time.Date(2026, 10, 18, 19, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "redemption start unknown",
+			tld:      "com",
+			extra:    map[string]any{"status": "redemptionPeriod"},
+			ok:       true,
+			phase:    DropPhaseRedemption,
+			earliest: checkedAt.Add(5 * day),
+			latest:   checkedAt.Add(36 * day),
+		},
+		{
+			name:     "eurid quarantine has no pending delete",
+			tld:      "eu",
+			extra:    map[string]any{"status": []string{"redemptionPeriod"}, "last_changed": "2026-10-01T00:00:00Z"},
+			ok:       true,
+			phase:    DropPhaseRedemption,
+			earliest: time.Date(2026, 11, 9, 0, 0, 0, 0, time.UTC),
+			latest:   time.Date(2026, 11, 11, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			window, ok := PredictDrop(tc.tld, tc.extra, checkedAt)
+			require.Equal(t, tc.ok, ok)
+			if !tc.ok {
+				return
+			}
+			require.Equal(t, tc.phase, window.Phase)
+			require.Equal(t, tc.earliest, window.Earliest)
+			require.Equal(t, tc.latest, window.Latest)
+			require.NotEmpty(t, window.Basis)
+		})
+	}
+}
+
+func TestDropWindowExtraRoundTrip(t *testing.T) {
+	window := DropWindow{
+		Earliest: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		Latest:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+		Phase:    DropPhasePendingDelete,
+		Basis:    "registry deletion date",
+	}
+	parsed, ok := ParseDropWindow(map[string]any{"drop_window": DropWindowExtra(window)})
+	require.True(t, ok)
+	require.Equal(t, window, parsed)
+
+	_, ok = ParseDropWindow(map[string]any{"drop_window": "soon"})
+	require.False(t, ok)
+}
+
+func TestDropListWindow(t *testing.T) {
+	date := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+	window := DropListWindow("zonedrops", date)
+	require.Equal(t, date, window.Earliest)
+	require.Equal(t, date.Add(24*time.Hour), window.Latest)
+	require.Equal(t, DropPhasePendingDelete, window.Phase)
+	require.Equal(t, "zonedrops drop list", window.Basis)
+}
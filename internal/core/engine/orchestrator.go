@@ -2,11 +2,16 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/network"
+	"github.com/namelens/namelens/internal/tracing"
 )
 
 // Orchestrator coordinates checks across available checkers.
@@ -15,7 +20,33 @@ type Orchestrator struct {
 	RegistryCheckers   map[string]Checker
 	HandleCheckers     map[string]Checker
 	IncludeUnsupported bool
+	History            HistoryRecorder
 	Clock              func() time.Time
+	// Offline answers every check from cache only; misses come back Unknown
+	// with core.OfflineMessage instead of reaching the network.
+	Offline bool
+	// CheckTimeout bounds each check; a check that runs longer resolves
+	// Unknown with a timeout message. Zero means no per-check limit.
+	CheckTimeout time.Duration
+	// Deadline bounds the whole run: checks in flight at the deadline resolve
+	// Unknown, and later checks are not started. Zero means no deadline.
+	Deadline time.Time
+	// Revalidator runs the background refreshes of stale cached results the
+	// checkers served; see Wait.
+	Revalidator *Revalidator
+}
+
+// Wait blocks until background cache refreshes finish. Call it before
+// closing the store they write to.
+func (o *Orchestrator) Wait() {
+	if o != nil {
+		o.Revalidator.Wait()
+	}
+}
+
+// HistoryRecorder persists fresh check results so changes can be compared over time.
+type HistoryRecorder interface {
+	RecordHistory(ctx context.Context, name string, results []*core.CheckResult) (string, error)
 }
 
 // Checker describes a name availability checker.
@@ -26,7 +57,7 @@ type Checker interface {
 }
 
 // Check runs checks based on the provided profile.
-func (o *Orchestrator) Check(ctx context.Context, name string, profile core.Profile) ([]*core.CheckResult, error) {
+func (o *Orchestrator) Check(ctx context.Context, name string, profile core.Profile) (results []*core.CheckResult, err error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -35,8 +66,20 @@ func (o *Orchestrator) Check(ctx context.Context, name string, profile core.Prof
 	if baseName == "" {
 		return nil, fmt.Errorf("name is required")
 	}
+	ctx, span := tracing.Start(ctx, "orchestrator.Check",
+		attribute.String("namelens.name", baseName),
+		attribute.String("namelens.profile", profile.Name),
+		attribute.Int("namelens.tlds", len(profile.TLDs)),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int("namelens.results", len(results)))
+		tracing.End(span, err)
+	}()
+	if o.Offline {
+		ctx = core.WithOffline(ctx)
+	}
 
-	results := make([]*core.CheckResult, 0)
+	results = make([]*core.CheckResult, 0)
 
 	if len(profile.TLDs) > 0 {
 		domainChecker := o.getChecker(core.CheckTypeDomain)
@@ -46,12 +89,13 @@ func (o *Orchestrator) Check(ctx context.Context, name string, profile core.Prof
 				continue
 			}
 			domain := fmt.Sprintf("%s.%s", baseName, normalized)
-			result, err := o.runChecker(ctx, domainChecker, core.CheckTypeDomain, domain)
+			result, err := o.runChecker(ctx, domainChecker, core.CheckTypeDomain, domain, "")
 			if err != nil {
 				return nil, err
 			}
 			if result != nil {
 				results = append(results, result)
+				core.ReportResult(ctx, result)
 			}
 		}
 	}
@@ -62,12 +106,13 @@ func (o *Orchestrator) Check(ctx context.Context, name string, profile core.Prof
 			continue
 		}
 		checker := o.getNamedChecker(o.RegistryCheckers, key)
-		result, err := o.runNamedChecker(ctx, checker, key, baseName)
+		result, err := o.runNamedChecker(ctx, checker, key, baseName, core.RequestRegistry)
 		if err != nil {
 			return nil, err
 		}
 		if result != nil {
 			results = append(results, result)
+			core.ReportResult(ctx, result)
 		}
 	}
 
@@ -77,19 +122,46 @@ func (o *Orchestrator) Check(ctx context.Context, name string, profile core.Prof
 			continue
 		}
 		checker := o.getNamedChecker(o.HandleCheckers, key)
-		result, err := o.runNamedChecker(ctx, checker, key, baseName)
+		result, err := o.runNamedChecker(ctx, checker, key, baseName, core.RequestHandle)
 		if err != nil {
 			return nil, err
 		}
 		if result != nil {
 			results = append(results, result)
+			core.ReportResult(ctx, result)
 		}
 	}
 
+	o.recordHistory(ctx, baseName, results)
+
 	return results, nil
 }
 
-func (o *Orchestrator) runChecker(ctx context.Context, c Checker, checkType core.CheckType, name string) (*core.CheckResult, error) {
+// recordHistory stores results that were resolved by this run. Cached and
+// unsupported results, and offline runs, are skipped; history is best-effort
+// and never fails a check.
+func (o *Orchestrator) recordHistory(ctx context.Context, name string, results []*core.CheckResult) {
+	if o == nil || o.History == nil || core.IsOffline(ctx) {
+		return
+	}
+
+	fresh := make([]*core.CheckResult, 0, len(results))
+	for _, result := range results {
+		if result == nil || result.Provenance.FromCache || result.Available == core.AvailabilityUnsupported {
+			continue
+		}
+		fresh = append(fresh, result)
+	}
+	if len(fresh) == 0 {
+		return
+	}
+
+	_, _ = o.History.RecordHistory(ctx, name, fresh)
+}
+
+// runChecker runs one check. category is the request budget category; an
+// empty category counts domain checks by the source that answered.
+func (o *Orchestrator) runChecker(ctx context.Context, c Checker, checkType core.CheckType, name, category string) (result *core.CheckResult, err error) {
 	if c == nil {
 		if !o.IncludeUnsupported {
 			return nil, nil
@@ -104,7 +176,44 @@ func (o *Orchestrator) runChecker(ctx context.Context, c Checker, checkType core
 		return o.unsupportedResult(name, checkType, "checker does not support name"), nil
 	}
 
-	result, err := c.Check(ctx, name)
+	if !o.Deadline.IsZero() && !time.Now().Before(o.Deadline) {
+		return o.timeoutResult(name, checkType, "run deadline exceeded; check not started"), nil
+	}
+
+	core.ReportProgress(ctx, string(checkType)+" "+name)
+	ctx, span := tracing.Start(ctx, "checker.Check",
+		attribute.String("namelens.check_type", string(checkType)),
+		attribute.String("namelens.name", name),
+	)
+	// The span ends with the checker's own error, which is usually turned
+	// into an error result rather than returned
+	var checkErr error
+	defer func() {
+		if result != nil {
+			result.Provenance.TraceID = tracing.TraceID(ctx)
+			span.SetAttributes(
+				attribute.String("namelens.availability", result.Available.String()),
+				attribute.String("namelens.source", result.Provenance.Source),
+				attribute.Bool("namelens.from_cache", result.Provenance.FromCache),
+			)
+		}
+		tracing.End(span, checkErr)
+	}()
+	checkCtx, addressFamily := network.WithAddressFamily(ctx)
+	checkCtx, cancel := o.checkContext(checkCtx)
+	defer cancel()
+	result, err = runWithContext(checkCtx, c, name)
+	checkErr = err
+	if message, timedOut := o.timeoutMessage(ctx, checkCtx, result, err); timedOut {
+		core.CountRequest(ctx, requestCategory(category, nil))
+		return o.timeoutResult(name, checkType, message), nil
+	}
+	if result != nil && !result.Provenance.FromCache && result.Provenance.AddressFamily == "" {
+		result.Provenance.AddressFamily = addressFamily()
+	}
+	if !core.IsOffline(ctx) || (result != nil && result.Provenance.FromCache) {
+		core.CountRequest(ctx, requestCategory(category, result))
+	}
 	if err != nil {
 		if !o.IncludeUnsupported {
 			return &core.CheckResult{
@@ -122,9 +231,85 @@ func (o *Orchestrator) runChecker(ctx context.Context, c Checker, checkType core
 		return nil, err
 	}
 
+	if result != nil {
+		result.Confidence = core.ScoreConfidence(result, o.now())
+	}
 	return result, nil
 }
 
+// checkContext applies CheckTimeout and Deadline to ctx.
+func (o *Orchestrator) checkContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	cancels := []context.CancelFunc{}
+	if o.CheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.CheckTimeout)
+		cancels = append(cancels, cancel)
+	}
+	if !o.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, o.Deadline)
+		cancels = append(cancels, cancel)
+	}
+	return ctx, func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+// runWithContext runs a check and returns when it finishes or ctx is done,
+// so a checker that ignores its context cannot stall the run.
+func runWithContext(ctx context.Context, c Checker, name string) (*core.CheckResult, error) {
+	type outcome struct {
+		result *core.CheckResult
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := c.Check(ctx, name)
+		done <- outcome{result: result, err: err}
+	}()
+	select {
+	case out := <-done:
+		return out.result, out.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// timeoutMessage reports whether a check failed because CheckTimeout or
+// Deadline expired, rather than because the caller gave up or the check
+// failed on its own. A checker that returned a non-error verdict in time
+// keeps it.
+func (o *Orchestrator) timeoutMessage(parent, checkCtx context.Context, result *core.CheckResult, err error) (string, bool) {
+	if parent.Err() != nil || !errors.Is(checkCtx.Err(), context.DeadlineExceeded) {
+		return "", false
+	}
+	if err == nil && result != nil && result.Available != core.AvailabilityError {
+		return "", false
+	}
+	if !o.Deadline.IsZero() && !time.Now().Before(o.Deadline) {
+		return "run deadline exceeded", true
+	}
+	return fmt.Sprintf("check timed out after %s", o.CheckTimeout), true
+}
+
+func (o *Orchestrator) timeoutResult(name string, checkType core.CheckType, message string) *core.CheckResult {
+	now := o.now()
+	return &core.CheckResult{
+		Name:      name,
+		CheckType: checkType,
+		Available: core.AvailabilityUnknown,
+		Message:   message,
+		ExtraData: map[string]any{"timed_out": true},
+		Provenance: core.Provenance{
+			RequestedAt: now,
+			ResolvedAt:  now,
+			Source:      "orchestrator",
+		},
+	}
+}
+
 func (o *Orchestrator) getChecker(checkType core.CheckType) Checker {
 	if o == nil || o.Checkers == nil {
 		return nil
@@ -139,12 +324,32 @@ func (o *Orchestrator) getNamedChecker(group map[string]Checker, key string) Che
 	return group[key]
 }
 
-func (o *Orchestrator) runNamedChecker(ctx context.Context, c Checker, key string, name string) (*core.CheckResult, error) {
+func (o *Orchestrator) runNamedChecker(ctx context.Context, c Checker, key, name, category string) (*core.CheckResult, error) {
 	checkType, ok := checkTypeForKey(key)
 	if !ok {
-		return nil, nil
+		// Custom checkers are registered under their own key.
+		if c == nil {
+			return nil, nil
+		}
+		checkType = c.Type()
+	}
+	return o.runChecker(ctx, c, checkType, name, category)
+}
+
+func requestCategory(category string, result *core.CheckResult) string {
+	if result != nil && result.Provenance.FromCache {
+		return core.RequestCacheHit
+	}
+	if category != "" {
+		return category
+	}
+	if result != nil {
+		switch result.Provenance.Source {
+		case core.RequestWHOIS, core.RequestDNS:
+			return result.Provenance.Source
+		}
 	}
-	return o.runChecker(ctx, c, checkType, name)
+	return core.RequestRDAP
 }
 
 func normalizeKey(value string) string {
@@ -161,6 +366,12 @@ func checkTypeForKey(key string) (core.CheckType, bool) {
 		return core.CheckTypeCargo, true
 	case "github":
 		return core.CheckTypeGitHub, true
+	case "slack":
+		return core.CheckTypeSlack, true
+	case "discord":
+		return core.CheckTypeDiscord, true
+	case "google-workspace":
+		return core.CheckTypeGoogleWorkspace, true
 	default:
 		return "", false
 	}
@@ -2,10 +2,18 @@ package engine
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	"github.com/namelens/namelens/internal/core"
 )
@@ -50,3 +58,249 @@ func TestOrchestratorDomains(t *testing.T) {
 	require.Len(t, results, 2)
 	require.Equal(t, []string{"example.com", "example.io"}, checker.seen)
 }
+
+type stubHistory struct {
+	name    string
+	results []*core.CheckResult
+}
+
+func (s *stubHistory) RecordHistory(ctx context.Context, name string, results []*core.CheckResult) (string, error) {
+	s.name = name
+	s.results = results
+	return "run", nil
+}
+
+func TestOrchestratorRecordsFreshHistory(t *testing.T) {
+	history := &stubHistory{}
+	orchestrator := &Orchestrator{
+		Checkers: map[core.CheckType]Checker{
+			core.CheckTypeDomain: &stubChecker{},
+		},
+		HandleCheckers:     map[string]Checker{},
+		IncludeUnsupported: true,
+		History:            history,
+	}
+
+	profile := core.Profile{
+		Name:    "test",
+		TLDs:    []string{"com"},
+		Handles: []string{"github"},
+	}
+
+	results, err := orchestrator.Check(context.Background(), "example", profile)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "example", history.name)
+	require.Len(t, history.results, 1)
+	require.Equal(t, "example.com", history.results[0].Name)
+}
+
+type customChecker struct {
+	stubChecker
+}
+
+func (c *customChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	c.seen = append(c.seen, name)
+	return &core.CheckResult{Name: name, CheckType: c.Type(), Available: core.AvailabilityAvailable}, nil
+}
+
+func (c *customChecker) Type() core.CheckType {
+	return core.CheckType("corpnames")
+}
+
+func TestOrchestratorCustomRegistry(t *testing.T) {
+	checker := &customChecker{}
+	orchestrator := &Orchestrator{
+		RegistryCheckers: map[string]Checker{"corpnames": checker},
+	}
+
+	profile := core.Profile{Name: "test", Registries: []string{"CorpNames", "rubygems"}}
+
+	results, err := orchestrator.Check(context.Background(), "example", profile)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, core.CheckType("corpnames"), results[0].CheckType)
+	require.Equal(t, []string{"example"}, checker.seen)
+	require.NotNil(t, results[0].Confidence)
+	require.Equal(t, core.ConfidenceMedium, results[0].Confidence.Level)
+}
+
+type httpChecker struct {
+	stubChecker
+	url string
+}
+
+func (c *httpChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+	return &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken}, nil
+}
+
+func TestOrchestratorRecordsAddressFamily(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	orchestrator := &Orchestrator{
+		Checkers: map[core.CheckType]Checker{
+			core.CheckTypeDomain: &httpChecker{url: server.URL},
+		},
+	}
+
+	results, err := orchestrator.Check(context.Background(), "example", core.Profile{Name: "test", TLDs: []string{"com"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "ipv4", results[0].Provenance.AddressFamily)
+}
+
+func TestOrchestratorCountsRequests(t *testing.T) {
+	orchestrator := &Orchestrator{
+		Checkers:         map[core.CheckType]Checker{core.CheckTypeDomain: &stubChecker{}},
+		RegistryCheckers: map[string]Checker{"corpnames": &customChecker{}},
+	}
+
+	budget := &core.RequestBudget{}
+	ctx := core.WithRequestBudget(context.Background(), budget)
+	profile := core.Profile{Name: "test", TLDs: []string{"com", "io"}, Registries: []string{"corpnames"}}
+
+	_, err := orchestrator.Check(ctx, "example", profile)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{core.RequestRDAP: 2, core.RequestRegistry: 1}, budget.Counts())
+}
+
+type offlineChecker struct {
+	stubChecker
+	offline []bool
+}
+
+func (c *offlineChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	c.offline = append(c.offline, core.IsOffline(ctx))
+	return &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, Available: core.AvailabilityUnknown, Message: core.OfflineMessage}, nil
+}
+
+func TestOrchestratorOffline(t *testing.T) {
+	checker := &offlineChecker{}
+	history := &stubHistory{}
+	orchestrator := &Orchestrator{
+		Checkers: map[core.CheckType]Checker{core.CheckTypeDomain: checker},
+		History:  history,
+		Offline:  true,
+	}
+
+	budget := &core.RequestBudget{}
+	ctx := core.WithRequestBudget(context.Background(), budget)
+	results, err := orchestrator.Check(ctx, "example", core.Profile{TLDs: []string{"com"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, []bool{true}, checker.offline)
+	require.Nil(t, budget.Counts())
+	require.Nil(t, history.results)
+}
+
+// hangingChecker blocks until released, ignoring its context, except for
+// names in fast.
+type hangingChecker struct {
+	stubChecker
+	fast    map[string]bool
+	release chan struct{}
+}
+
+func (h *hangingChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	if !h.fast[name] {
+		<-h.release
+	}
+	return h.stubChecker.Check(ctx, name)
+}
+
+func TestOrchestratorCheckTimeout(t *testing.T) {
+	checker := &hangingChecker{fast: map[string]bool{"example.io": true}, release: make(chan struct{})}
+	defer close(checker.release)
+	orchestrator := &Orchestrator{
+		Checkers:     map[core.CheckType]Checker{core.CheckTypeDomain: checker},
+		CheckTimeout: 20 * time.Millisecond,
+	}
+
+	results, err := orchestrator.Check(context.Background(), "example", core.Profile{TLDs: []string{"com", "io"}})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	require.Equal(t, core.AvailabilityUnknown, results[0].Available)
+	require.Equal(t, "check timed out after 20ms", results[0].Message)
+	require.Equal(t, true, results[0].ExtraData["timed_out"])
+	require.Equal(t, "example.io", results[1].Name)
+	require.Nil(t, results[1].ExtraData)
+}
+
+func TestOrchestratorDeadline(t *testing.T) {
+	checker := &stubChecker{}
+	orchestrator := &Orchestrator{
+		Checkers: map[core.CheckType]Checker{core.CheckTypeDomain: checker},
+		Deadline: time.Now().Add(-time.Second),
+	}
+
+	results, err := orchestrator.Check(context.Background(), "example", core.Profile{TLDs: []string{"com"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, core.AvailabilityUnknown, results[0].Available)
+	require.Equal(t, "run deadline exceeded; check not started", results[0].Message)
+	require.Empty(t, checker.seen)
+}
+
+type failingChecker struct{}
+
+func (failingChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	return nil, errors.New("registry unreachable")
+}
+
+func (failingChecker) Type() core.CheckType {
+	return core.CheckTypeNPM
+}
+
+func (failingChecker) SupportsName(name string) bool {
+	return true
+}
+
+func TestOrchestratorTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	original := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(original)
+	})
+
+	orchestrator := &Orchestrator{
+		Checkers:         map[core.CheckType]Checker{core.CheckTypeDomain: &stubChecker{}},
+		RegistryCheckers: map[string]Checker{"npm": failingChecker{}},
+	}
+	results, err := orchestrator.Check(context.Background(), "example", core.Profile{
+		TLDs:       []string{"com"},
+		Registries: []string{"npm"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	root := spans[2]
+	require.Equal(t, "orchestrator.Check", root.Name())
+	for _, span := range spans[:2] {
+		require.Equal(t, "checker.Check", span.Name())
+		require.Equal(t, root.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	// A checker error is a failed span, even though it becomes an error result
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+
+	traceID := root.SpanContext().TraceID().String()
+	for _, result := range results {
+		require.Equal(t, traceID, result.Provenance.TraceID)
+	}
+}
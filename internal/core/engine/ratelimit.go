@@ -9,12 +9,16 @@ import (
 	"github.com/namelens/namelens/internal/core"
 )
 
-// RateLimiter enforces per-endpoint rate limits.
+// RateLimiter enforces per-endpoint rate limits. State lives in Store unless
+// Backend is set.
 type RateLimiter struct {
-	Store  RateLimitStore
-	Limits map[string]RateLimit
-	Clock  func() time.Time
-	Margin float64
+	Store RateLimitStore
+	// Backend shares state between processes, such as server replicas; it
+	// takes precedence over Store.
+	Backend RateLimitBackend
+	Limits  map[string]RateLimit
+	Clock   func() time.Time
+	Margin  float64
 }
 
 // RateLimit represents a rate limit window.
@@ -29,6 +33,15 @@ type RateLimitStore interface {
 	UpdateRateLimit(ctx context.Context, endpoint string, state *core.RateLimitState) error
 }
 
+// RateLimitBackend keeps rate limit state outside the store, applying each
+// operation atomically so concurrent processes agree on the counts.
+type RateLimitBackend interface {
+	// Acquire checks the limit and counts the request in one step, so
+	// concurrent processes cannot all pass the check before any counts.
+	Acquire(ctx context.Context, endpoint string, limit RateLimit, now time.Time) (bool, time.Duration, error)
+	Record429(ctx context.Context, endpoint string, retryAfter time.Duration, now time.Time) error
+}
+
 // DefaultLimits provides conservative defaults per endpoint.
 var DefaultLimits = map[string]RateLimit{
 	"rdap.verisign.com":  {RequestsPerWindow: 30, WindowDuration: time.Minute},
@@ -41,7 +54,11 @@ var DefaultLimits = map[string]RateLimit{
 }
 
 // Allow checks if a request is allowed and returns wait duration if not.
+// With a Backend, an allowed request is also counted.
 func (r *RateLimiter) Allow(ctx context.Context, endpoint string) (bool, time.Duration, error) {
+	if r != nil && r.Backend != nil {
+		return r.Backend.Acquire(ctx, endpoint, r.getLimit(endpoint), r.now())
+	}
 	if r == nil || r.Store == nil {
 		return true, 0, nil
 	}
@@ -72,9 +89,10 @@ func (r *RateLimiter) Allow(ctx context.Context, endpoint string) (bool, time.Du
 	return true, 0, nil
 }
 
-// Record increments the request count for an endpoint.
+// Record increments the request count for an endpoint. With a Backend it does
+// nothing, because Allow already counted the request.
 func (r *RateLimiter) Record(ctx context.Context, endpoint string) error {
-	if r == nil || r.Store == nil {
+	if r == nil || r.Backend != nil || r.Store == nil {
 		return nil
 	}
 
@@ -96,6 +114,9 @@ func (r *RateLimiter) Record(ctx context.Context, endpoint string) error {
 
 // Record429 applies a backoff window from a 429 response.
 func (r *RateLimiter) Record429(ctx context.Context, endpoint string, retryAfter time.Duration) error {
+	if r != nil && r.Backend != nil {
+		return r.Backend.Record429(ctx, endpoint, retryAfter, r.now())
+	}
 	if r == nil || r.Store == nil {
 		return nil
 	}
@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisRateLimitPrefix namespaces rate limit keys in Redis.
+const DefaultRedisRateLimitPrefix = "namelens:ratelimit:"
+
+// RedisRateLimitBackend shares rate limit state through Redis so that every
+// server replica counts against the same per-endpoint limits. Each operation
+// is a Lua script, so concurrent replicas never interleave a read and a write.
+// Times are passed in by the caller, keeping RateLimiter.Clock authoritative.
+type RedisRateLimitBackend struct {
+	Client redis.UniversalClient
+	// Prefix namespaces the keys; DefaultRedisRateLimitPrefix when empty.
+	Prefix string
+}
+
+// NewRedisRateLimitBackend connects to the Redis server at rawURL
+// (redis://[user:password@]host:port/db, or rediss:// for TLS).
+func NewRedisRateLimitBackend(rawURL string) (*RedisRateLimitBackend, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, errors.New("redis rate limiter url is required")
+	}
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis rate limiter url: %w", err)
+	}
+	return &RedisRateLimitBackend{Client: redis.NewClient(opts)}, nil
+}
+
+// Close releases the Redis connections.
+func (b *RedisRateLimitBackend) Close() error {
+	if b == nil || b.Client == nil {
+		return nil
+	}
+	return b.Client.Close()
+}
+
+// keepScript extends a key's expiry to at least until_ms; shared by the
+// scripts that write state so idle endpoints do not linger in Redis.
+const keepScript = `
+local function keep(key, until_ms, now)
+	local want = until_ms - now
+	if want < 1 then want = 1 end
+	if redis.call('PTTL', key) < want then
+		redis.call('PEXPIRE', key, want)
+	end
+end
+`
+
+// KEYS[1] state hash; ARGV now_ms, requests per window, window_ms.
+// Counts the request and returns {1, 0} when the window has room, or
+// {0, wait_ms} without counting it. Checking and counting in one script keeps
+// concurrent replicas from all passing the check before any of them counts.
+var redisAcquireScript = redis.NewScript(keepScript + `
+local now = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local window = tonumber(ARGV[3])
+local backoff = tonumber(redis.call('HGET', KEYS[1], 'backoff_until') or '0')
+if backoff > now then
+	return {0, backoff - now}
+end
+local start = tonumber(redis.call('HGET', KEYS[1], 'window_start') or '0')
+if start == 0 or now > start + window then
+	start = now
+	redis.call('HSET', KEYS[1], 'window_start', start, 'count', 0)
+end
+local count = tonumber(redis.call('HGET', KEYS[1], 'count') or '0')
+if count >= limit then
+	return {0, start + window - now}
+end
+redis.call('HINCRBY', KEYS[1], 'count', 1)
+keep(KEYS[1], start + window, now)
+return {1, 0}
+`)
+
+// KEYS[1] state hash; ARGV now_ms, retry_after_ms.
+var redisRecord429Script = redis.NewScript(keepScript + `
+local now = tonumber(ARGV[1])
+local retry = tonumber(ARGV[2])
+redis.call('HSET', KEYS[1], 'last_429_at', now)
+if retry > 0 then
+	redis.call('HSET', KEYS[1], 'backoff_until', now + retry)
+end
+keep(KEYS[1], now + retry, now)
+return 1
+`)
+
+// Acquire counts a request against endpoint if its current window has room,
+// starting a new window when the previous one has ended. When it does not,
+// the request is not counted and Acquire returns how long to wait.
+func (b *RedisRateLimitBackend) Acquire(ctx context.Context, endpoint string, limit RateLimit, now time.Time) (bool, time.Duration, error) {
+	values, err := redisAcquireScript.Run(ctx, b.Client, []string{b.key(endpoint)},
+		now.UnixMilli(), limit.RequestsPerWindow, limit.WindowDuration.Milliseconds()).Int64Slice()
+	if err != nil {
+		return true, 0, fmt.Errorf("redis rate limit acquire: %w", err)
+	}
+	if len(values) != 2 {
+		return true, 0, fmt.Errorf("redis rate limit acquire: unexpected reply %v", values)
+	}
+	return values[0] == 1, time.Duration(values[1]) * time.Millisecond, nil
+}
+
+// Record429 backs endpoint off for retryAfter.
+func (b *RedisRateLimitBackend) Record429(ctx context.Context, endpoint string, retryAfter time.Duration, now time.Time) error {
+	err := redisRecord429Script.Run(ctx, b.Client, []string{b.key(endpoint)},
+		now.UnixMilli(), retryAfter.Milliseconds()).Err()
+	if err != nil {
+		return fmt.Errorf("redis rate limit record 429: %w", err)
+	}
+	return nil
+}
+
+func (b *RedisRateLimitBackend) key(endpoint string) string {
+	prefix := b.Prefix
+	if prefix == "" {
+		prefix = DefaultRedisRateLimitPrefix
+	}
+	return prefix + strings.TrimSpace(endpoint)
+}
@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisBackend(t *testing.T) (*RedisRateLimitBackend, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	backend, err := NewRedisRateLimitBackend("redis://" + server.Addr())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backend.Close() })
+	return backend, server
+}
+
+func TestRedisRateLimitBackendWindow(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := newTestRedisBackend(t)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := &RateLimiter{
+		Backend: backend,
+		Limits:  map[string]RateLimit{"example.com": {RequestsPerWindow: 2, WindowDuration: time.Minute}},
+		Clock:   func() time.Time { return now },
+	}
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := limiter.Allow(ctx, "example.com")
+		require.NoError(t, err)
+		require.True(t, allowed)
+		require.NoError(t, limiter.Record(ctx, "example.com"))
+	}
+
+	now = now.Add(20 * time.Second)
+	allowed, wait, err := limiter.Allow(ctx, "example.com")
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.Equal(t, 40*time.Second, wait)
+
+	// A new window starts once the old one ends, and the count starts over
+	now = now.Add(41 * time.Second)
+	allowed, _, err = limiter.Allow(ctx, "example.com")
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.NoError(t, limiter.Record(ctx, "example.com"))
+	allowed, _, err = limiter.Allow(ctx, "example.com")
+	require.NoError(t, err)
+	require.True(t, allowed)
+}
+
+func TestRedisRateLimitBackendSharedAcrossLimiters(t *testing.T) {
+	ctx := context.Background()
+	backend, server := newTestRedisBackend(t)
+	other, err := NewRedisRateLimitBackend("redis://" + server.Addr())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = other.Close() })
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limits := map[string]RateLimit{"example.com": {RequestsPerWindow: 1, WindowDuration: time.Minute}}
+	replicaA := &RateLimiter{Backend: backend, Limits: limits, Clock: func() time.Time { return now }}
+	replicaB := &RateLimiter{Backend: other, Limits: limits, Clock: func() time.Time { return now }}
+
+	allowed, _, err := replicaA.Allow(ctx, "example.com")
+	require.NoError(t, err)
+	require.True(t, allowed)
+	require.NoError(t, replicaA.Record(ctx, "example.com"))
+	allowed, _, err = replicaB.Allow(ctx, "example.com")
+	require.NoError(t, err)
+	require.False(t, allowed)
+}
+
+func TestRedisRateLimitBackendConcurrentReplicas(t *testing.T) {
+	ctx := context.Background()
+	_, server := newTestRedisBackend(t)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limits := map[string]RateLimit{"example.com": {RequestsPerWindow: 5, WindowDuration: time.Minute}}
+	replicas := make([]*RateLimiter, 4)
+	for i := range replicas {
+		backend, err := NewRedisRateLimitBackend("redis://" + server.Addr())
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = backend.Close() })
+		replicas[i] = &RateLimiter{Backend: backend, Limits: limits, Clock: func() time.Time { return now }}
+	}
+
+	var (
+		wg      sync.WaitGroup
+		allowed atomic.Int32
+	)
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(limiter *RateLimiter) {
+			defer wg.Done()
+			ok, _, err := limiter.Allow(ctx, "example.com")
+			if err == nil && ok {
+				allowed.Add(1)
+				_ = limiter.Record(ctx, "example.com")
+			}
+		}(replicas[i%len(replicas)])
+	}
+	wg.Wait()
+	require.Equal(t, int32(5), allowed.Load())
+}
+
+func TestRedisRateLimitBackendRecord429(t *testing.T) {
+	ctx := context.Background()
+	backend, server := newTestRedisBackend(t)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := &RateLimiter{Backend: backend, Clock: func() time.Time { return now }}
+
+	require.NoError(t, limiter.Record429(ctx, "example.com", 30*time.Second))
+	allowed, wait, err := limiter.Allow(ctx, "example.com")
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.Equal(t, 30*time.Second, wait)
+
+	// The key expires with the backoff rather than lingering
+	require.Equal(t, 30*time.Second, server.TTL(DefaultRedisRateLimitPrefix+"example.com"))
+
+	now = now.Add(31 * time.Second)
+	allowed, _, err = limiter.Allow(ctx, "example.com")
+	require.NoError(t, err)
+	require.True(t, allowed)
+}
+
+func TestNewRedisRateLimitBackendValidatesURL(t *testing.T) {
+	_, err := NewRedisRateLimitBackend("")
+	require.Error(t, err)
+
+	_, err = NewRedisRateLimitBackend("http://localhost:6379")
+	require.Error(t, err)
+}
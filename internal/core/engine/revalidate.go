@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultRevalidateTimeout = 30 * time.Second
+
+type revalidatingKey struct{}
+
+// Revalidator runs the background refreshes behind stale-while-revalidate
+// caching: at most one per cache key at a time, each bounded by Timeout.
+// Commands call Wait before closing the store the refreshes write to. The
+// zero value is ready to use.
+type Revalidator struct {
+	// Timeout bounds each refresh (default 30s).
+	Timeout time.Duration
+
+	mu       sync.Mutex
+	inflight map[string]bool
+	wg       sync.WaitGroup
+}
+
+// Go starts refresh for key in the background unless one is already running.
+// The refresh context keeps ctx's values (offline mode, tracing) but not its
+// cancellation, so it outlives the request that served the stale result, and
+// is marked so checkers skip the cache and do a fresh lookup. It reports
+// whether a refresh was started.
+func (r *Revalidator) Go(ctx context.Context, key string, refresh func(ctx context.Context)) bool {
+	if r == nil || refresh == nil {
+		return false
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	r.mu.Lock()
+	if r.inflight[key] {
+		r.mu.Unlock()
+		return false
+	}
+	if r.inflight == nil {
+		r.inflight = make(map[string]bool)
+	}
+	r.inflight[key] = true
+	r.wg.Add(1)
+	r.mu.Unlock()
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = defaultRevalidateTimeout
+	}
+	go func() {
+		defer func() {
+			r.mu.Lock()
+			delete(r.inflight, key)
+			r.mu.Unlock()
+			r.wg.Done()
+		}()
+		refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+		refresh(context.WithValue(refreshCtx, revalidatingKey{}, true))
+	}()
+	return true
+}
+
+// Wait blocks until every refresh started so far has finished.
+func (r *Revalidator) Wait() {
+	if r == nil {
+		return
+	}
+	r.wg.Wait()
+}
+
+// IsRevalidating reports whether ctx belongs to a background refresh, which
+// must bypass the cache it is refreshing.
+func IsRevalidating(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	revalidating, _ := ctx.Value(revalidatingKey{}).(bool)
+	return revalidating
+}
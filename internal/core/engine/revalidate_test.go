@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevalidator(t *testing.T) {
+	revalidator := &Revalidator{}
+	release := make(chan struct{})
+	var runs, canceled, unmarked atomic.Int32
+
+	ctx, cancel := context.WithCancel(context.Background())
+	refresh := func(ctx context.Context) {
+		runs.Add(1)
+		<-release
+		if ctx.Err() != nil {
+			canceled.Add(1)
+		}
+		if !IsRevalidating(ctx) {
+			unmarked.Add(1)
+		}
+	}
+
+	require.True(t, revalidator.Go(ctx, "npm|example", refresh))
+	// One refresh per key at a time
+	require.False(t, revalidator.Go(ctx, "npm|example", refresh))
+	require.True(t, revalidator.Go(ctx, "npm|other", refresh))
+	cancel()
+	close(release)
+	revalidator.Wait()
+	require.Equal(t, int32(2), runs.Load())
+	// The refreshes outlive the request that started them
+	require.Zero(t, canceled.Load())
+	require.Zero(t, unmarked.Load())
+
+	// The key is free again once its refresh finished
+	require.True(t, revalidator.Go(context.Background(), "npm|example", func(context.Context) { runs.Add(1) }))
+	revalidator.Wait()
+	require.Equal(t, int32(3), runs.Load())
+
+	require.False(t, IsRevalidating(context.Background()))
+	var nilRevalidator *Revalidator
+	require.False(t, nilRevalidator.Go(context.Background(), "key", refresh))
+	nilRevalidator.Wait()
+}
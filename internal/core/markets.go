@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Market describes the coverage a target market implies for each analysis.
+type Market struct {
+	Code      string   // ISO 3166-1 alpha-2 country code
+	Name      string   // Country name
+	Locales   []string // BCP 47 locales for suitability and phonetics
+	Keyboards []string // Keyboard layouts for typeability
+	CCTLD     string   // Country-code TLD for availability
+	Languages []string // Languages for sentiment analysis
+}
+
+// Markets lists the target markets NameLens knows how to expand, keyed by
+// upper-case country code. UK is accepted as an alias for GB.
+var Markets = map[string]Market{
+	"AE": {Code: "AE", Name: "United Arab Emirates", Locales: []string{"ar-AE", "en-AE"}, Keyboards: []string{"Arabic", "QWERTY (US)"}, CCTLD: "ae", Languages: []string{"Arabic", "English"}},
+	"AR": {Code: "AR", Name: "Argentina", Locales: []string{"es-AR"}, Keyboards: []string{"QWERTY (ES-LA)"}, CCTLD: "ar", Languages: []string{"Spanish"}},
+	"AU": {Code: "AU", Name: "Australia", Locales: []string{"en-AU"}, Keyboards: []string{"QWERTY (US)"}, CCTLD: "au", Languages: []string{"English"}},
+	"BE": {Code: "BE", Name: "Belgium", Locales: []string{"nl-BE", "fr-BE"}, Keyboards: []string{"AZERTY (BE)"}, CCTLD: "be", Languages: []string{"Dutch", "French"}},
+	"BR": {Code: "BR", Name: "Brazil", Locales: []string{"pt-BR"}, Keyboards: []string{"QWERTY (ABNT2)"}, CCTLD: "br", Languages: []string{"Portuguese"}},
+	"CA": {Code: "CA", Name: "Canada", Locales: []string{"en-CA", "fr-CA"}, Keyboards: []string{"QWERTY (US)", "QWERTY (CA-FR)"}, CCTLD: "ca", Languages: []string{"English", "French"}},
+	"CH": {Code: "CH", Name: "Switzerland", Locales: []string{"de-CH", "fr-CH", "it-CH"}, Keyboards: []string{"QWERTZ (CH)"}, CCTLD: "ch", Languages: []string{"German", "French", "Italian"}},
+	"CN": {Code: "CN", Name: "China", Locales: []string{"zh-CN"}, Keyboards: []string{"QWERTY (US)", "Pinyin IME"}, CCTLD: "cn", Languages: []string{"Mandarin Chinese"}},
+	"DE": {Code: "DE", Name: "Germany", Locales: []string{"de-DE"}, Keyboards: []string{"QWERTZ (DE)"}, CCTLD: "de", Languages: []string{"German"}},
+	"DK": {Code: "DK", Name: "Denmark", Locales: []string{"da-DK"}, Keyboards: []string{"QWERTY (Nordic)"}, CCTLD: "dk", Languages: []string{"Danish"}},
+	"ES": {Code: "ES", Name: "Spain", Locales: []string{"es-ES"}, Keyboards: []string{"QWERTY (ES)"}, CCTLD: "es", Languages: []string{"Spanish"}},
+	"FI": {Code: "FI", Name: "Finland", Locales: []string{"fi-FI"}, Keyboards: []string{"QWERTY (Nordic)"}, CCTLD: "fi", Languages: []string{"Finnish"}},
+	"FR": {Code: "FR", Name: "France", Locales: []string{"fr-FR"}, Keyboards: []string{"AZERTY (FR)"}, CCTLD: "fr", Languages: []string{"French"}},
+	"GB": {Code: "GB", Name: "United Kingdom", Locales: []string{"en-GB"}, Keyboards: []string{"QWERTY (UK)"}, CCTLD: "uk", Languages: []string{"English"}},
+	"HK": {Code: "HK", Name: "Hong Kong", Locales: []string{"zh-HK", "en-HK"}, Keyboards: []string{"QWERTY (US)", "Cangjie IME"}, CCTLD: "hk", Languages: []string{"Cantonese", "English"}},
+	"ID": {Code: "ID", Name: "Indonesia", Locales: []string{"id-ID"}, Keyboards: []string{"QWERTY (US)"}, CCTLD: "id", Languages: []string{"Indonesian"}},
+	"IE": {Code: "IE", Name: "Ireland", Locales: []string{"en-IE"}, Keyboards: []string{"QWERTY (UK)"}, CCTLD: "ie", Languages: []string{"English"}},
+	"IL": {Code: "IL", Name: "Israel", Locales: []string{"he-IL"}, Keyboards: []string{"Hebrew"}, CCTLD: "il", Languages: []string{"Hebrew"}},
+	"IN": {Code: "IN", Name: "India", Locales: []string{"hi-IN", "en-IN"}, Keyboards: []string{"QWERTY (US)", "InScript"}, CCTLD: "in", Languages: []string{"Hindi", "English"}},
+	"IT": {Code: "IT", Name: "Italy", Locales: []string{"it-IT"}, Keyboards: []string{"QWERTY (IT)"}, CCTLD: "it", Languages: []string{"Italian"}},
+	"JP": {Code: "JP", Name: "Japan", Locales: []string{"ja-JP"}, Keyboards: []string{"JIS (JP)"}, CCTLD: "jp", Languages: []string{"Japanese"}},
+	"KR": {Code: "KR", Name: "South Korea", Locales: []string{"ko-KR"}, Keyboards: []string{"Dubeolsik (KR)"}, CCTLD: "kr", Languages: []string{"Korean"}},
+	"MX": {Code: "MX", Name: "Mexico", Locales: []string{"es-MX"}, Keyboards: []string{"QWERTY (ES-LA)"}, CCTLD: "mx", Languages: []string{"Spanish"}},
+	"NL": {Code: "NL", Name: "Netherlands", Locales: []string{"nl-NL"}, Keyboards: []string{"QWERTY (US)"}, CCTLD: "nl", Languages: []string{"Dutch"}},
+	"NO": {Code: "NO", Name: "Norway", Locales: []string{"nb-NO"}, Keyboards: []string{"QWERTY (Nordic)"}, CCTLD: "no", Languages: []string{"Norwegian"}},
+	"NZ": {Code: "NZ", Name: "New Zealand", Locales: []string{"en-NZ"}, Keyboards: []string{"QWERTY (US)"}, CCTLD: "nz", Languages: []string{"English", "Māori"}},
+	"PL": {Code: "PL", Name: "Poland", Locales: []string{"pl-PL"}, Keyboards: []string{"QWERTY (PL)"}, CCTLD: "pl", Languages: []string{"Polish"}},
+	"PT": {Code: "PT", Name: "Portugal", Locales: []string{"pt-PT"}, Keyboards: []string{"QWERTY (PT)"}, CCTLD: "pt", Languages: []string{"Portuguese"}},
+	"RU": {Code: "RU", Name: "Russia", Locales: []string{"ru-RU"}, Keyboards: []string{"ЙЦУКЕН (RU)"}, CCTLD: "ru", Languages: []string{"Russian"}},
+	"SA": {Code: "SA", Name: "Saudi Arabia", Locales: []string{"ar-SA"}, Keyboards: []string{"Arabic"}, CCTLD: "sa", Languages: []string{"Arabic"}},
+	"SE": {Code: "SE", Name: "Sweden", Locales: []string{"sv-SE"}, Keyboards: []string{"QWERTY (Nordic)"}, CCTLD: "se", Languages: []string{"Swedish"}},
+	"SG": {Code: "SG", Name: "Singapore", Locales: []string{"en-SG", "zh-SG"}, Keyboards: []string{"QWERTY (US)"}, CCTLD: "sg", Languages: []string{"English", "Mandarin Chinese", "Malay"}},
+	"TR": {Code: "TR", Name: "Turkey", Locales: []string{"tr-TR"}, Keyboards: []string{"QWERTY (TR)"}, CCTLD: "tr", Languages: []string{"Turkish"}},
+	"TW": {Code: "TW", Name: "Taiwan", Locales: []string{"zh-TW"}, Keyboards: []string{"QWERTY (US)", "Zhuyin IME"}, CCTLD: "tw", Languages: []string{"Mandarin Chinese"}},
+	"US": {Code: "US", Name: "United States", Locales: []string{"en-US", "es-US"}, Keyboards: []string{"QWERTY (US)"}, CCTLD: "us", Languages: []string{"English", "Spanish"}},
+	"ZA": {Code: "ZA", Name: "South Africa", Locales: []string{"en-ZA", "af-ZA"}, Keyboards: []string{"QWERTY (US)"}, CCTLD: "za", Languages: []string{"English", "Afrikaans", "Zulu"}},
+}
+
+var marketAliases = map[string]string{"UK": "GB"}
+
+// MarketCoverage is the combined coverage of a set of target markets, in
+// market order with duplicates removed.
+type MarketCoverage struct {
+	Markets   []string
+	Locales   []string
+	Keyboards []string
+	CCTLDs    []string
+	Languages []string
+}
+
+// Empty reports whether no markets were expanded.
+func (c MarketCoverage) Empty() bool {
+	return len(c.Markets) == 0
+}
+
+// ExpandMarkets expands country codes into the locales, keyboards, ccTLDs,
+// and languages they cover. Codes are case-insensitive and may be
+// comma-separated; unknown codes are an error.
+func ExpandMarkets(codes []string) (MarketCoverage, error) {
+	var (
+		coverage MarketCoverage
+		unknown  []string
+	)
+	for _, value := range codes {
+		for _, part := range strings.Split(value, ",") {
+			code := strings.ToUpper(strings.TrimSpace(part))
+			if code == "" {
+				continue
+			}
+			if alias, ok := marketAliases[code]; ok {
+				code = alias
+			}
+			market, ok := Markets[code]
+			if !ok {
+				unknown = append(unknown, strings.TrimSpace(part))
+				continue
+			}
+			coverage.Markets = appendUnique(coverage.Markets, market.Code)
+			coverage.Locales = appendUnique(coverage.Locales, market.Locales...)
+			coverage.Keyboards = appendUnique(coverage.Keyboards, market.Keyboards...)
+			coverage.CCTLDs = appendUnique(coverage.CCTLDs, market.CCTLD)
+			coverage.Languages = appendUnique(coverage.Languages, market.Languages...)
+		}
+	}
+	if len(unknown) > 0 {
+		return coverage, fmt.Errorf("unknown market %s (known: %s)", strings.Join(unknown, ", "), strings.Join(MarketCodes(), ", "))
+	}
+	return coverage, nil
+}
+
+// MarketCodes returns the known market codes, sorted.
+func MarketCodes() []string {
+	codes := make([]string, 0, len(Markets))
+	for code := range Markets {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
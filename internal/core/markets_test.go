@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandMarkets(t *testing.T) {
+	coverage, err := ExpandMarkets([]string{"us,de", " jp", "uk", "US"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"US", "DE", "JP", "GB"}, coverage.Markets)
+	require.Equal(t, []string{"en-US", "es-US", "de-DE", "ja-JP", "en-GB"}, coverage.Locales)
+	require.Equal(t, []string{"QWERTY (US)", "QWERTZ (DE)", "JIS (JP)", "QWERTY (UK)"}, coverage.Keyboards)
+	require.Equal(t, []string{"us", "de", "jp", "uk"}, coverage.CCTLDs)
+	require.Equal(t, []string{"English", "Spanish", "German", "Japanese"}, coverage.Languages)
+}
+
+func TestExpandMarketsUnknownCode(t *testing.T) {
+	_, err := ExpandMarkets([]string{"US", "XX"})
+	require.ErrorContains(t, err, "unknown market XX")
+
+	coverage, err := ExpandMarkets(nil)
+	require.NoError(t, err)
+	require.True(t, coverage.Empty())
+}
+
+func TestMarketsAreConsistent(t *testing.T) {
+	for code, market := range Markets {
+		require.Equal(t, code, market.Code)
+		require.NotEmpty(t, market.Locales, code)
+		require.NotEmpty(t, market.Keyboards, code)
+		require.NotEmpty(t, market.CCTLD, code)
+		require.NotEmpty(t, market.Languages, code)
+	}
+}
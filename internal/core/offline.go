@@ -0,0 +1,24 @@
+package core
+
+import "context"
+
+// OfflineMessage is the message on results that offline mode could not
+// answer from cache.
+const OfflineMessage = "offline: no cached result"
+
+type offlineKey struct{}
+
+// WithOffline marks the context so checkers answer only from cache and never
+// make network requests.
+func WithOffline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, offlineKey{}, true)
+}
+
+// IsOffline reports whether the context is in offline mode.
+func IsOffline(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	offline, _ := ctx.Value(offlineKey{}).(bool)
+	return offline
+}
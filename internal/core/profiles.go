@@ -12,6 +12,7 @@ type Profile struct {
 	TLDs        []string `json:"tlds,omitempty"`
 	Registries  []string `json:"registries,omitempty"`
 	Handles     []string `json:"handles,omitempty"`
+	ExpertDepth string   `json:"expert_depth,omitempty"`
 }
 
 // ProfileRecord wraps a profile with persistence metadata.
@@ -0,0 +1,45 @@
+package core
+
+import "context"
+
+// ProgressFunc receives a short description of the lookup about to run, such
+// as "rdap acme.io" or "AI name-phonetics".
+type ProgressFunc func(step string)
+
+type progressKey struct{}
+
+// WithProgress attaches a progress callback to the context.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress passes step to the context's progress callback, if any.
+func ReportProgress(ctx context.Context, step string) {
+	if ctx == nil {
+		return
+	}
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(step)
+	}
+}
+
+// ResultFunc receives each check result as soon as it resolves.
+type ResultFunc func(result *CheckResult)
+
+type resultKey struct{}
+
+// WithResults attaches a callback for individual check results to the
+// context, for callers that stream results before the whole run finishes.
+func WithResults(ctx context.Context, fn ResultFunc) context.Context {
+	return context.WithValue(ctx, resultKey{}, fn)
+}
+
+// ReportResult passes result to the context's result callback, if any.
+func ReportResult(ctx context.Context, result *CheckResult) {
+	if ctx == nil || result == nil {
+		return
+	}
+	if fn, ok := ctx.Value(resultKey{}).(ResultFunc); ok && fn != nil {
+		fn(result)
+	}
+}
@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AIUsageRecord is the AI call count of a subject for the current UTC day
+// and month.
+type AIUsageRecord struct {
+	Subject string
+	Daily   int
+	Monthly int
+}
+
+// aiUsagePeriods returns the day and month period keys for at.
+func aiUsagePeriods(at time.Time) (string, string) {
+	at = at.UTC()
+	return "day:" + at.Format("2006-01-02"), "month:" + at.Format("2006-01")
+}
+
+// IncrementAIUsage counts one AI call for subject in the day and month of at.
+func (s *Store) IncrementAIUsage(ctx context.Context, subject string, at time.Time) error {
+	if s == nil || s.DB == nil {
+		return errors.New("store is not initialized")
+	}
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return errors.New("subject is required")
+	}
+
+	day, month := aiUsagePeriods(at)
+	for _, period := range []string{day, month} {
+		if _, err := s.execContext(ctx, `
+			INSERT INTO ai_usage (subject, period, calls, updated_at)
+			VALUES (?, ?, 1, ?)
+			ON CONFLICT(subject, period) DO UPDATE SET
+				calls = ai_usage.calls + 1,
+				updated_at = excluded.updated_at
+		`, subject, period, at.UTC().Unix()); err != nil {
+			return fmt.Errorf("record ai usage: %w", err)
+		}
+	}
+	return nil
+}
+
+// AIUsage returns subject's AI call counts for the day and month of at.
+func (s *Store) AIUsage(ctx context.Context, subject string, at time.Time) (int, int, error) {
+	if s == nil || s.DB == nil {
+		return 0, 0, errors.New("store is not initialized")
+	}
+
+	day, month := aiUsagePeriods(at)
+	counts := make(map[string]int, 2)
+	rows, err := s.queryContext(ctx, `
+		SELECT period, calls FROM ai_usage
+		WHERE subject = ? AND period IN (?, ?)
+	`, strings.TrimSpace(subject), day, month)
+	if err != nil {
+		return 0, 0, fmt.Errorf("fetch ai usage: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	for rows.Next() {
+		var (
+			period string
+			calls  int
+		)
+		if err := rows.Scan(&period, &calls); err != nil {
+			return 0, 0, fmt.Errorf("scan ai usage: %w", err)
+		}
+		counts[period] = calls
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("fetch ai usage: %w", err)
+	}
+	return counts[day], counts[month], nil
+}
+
+// ListAIUsage returns AI call counts for every subject with calls in the
+// month of at, ordered by subject.
+func (s *Store) ListAIUsage(ctx context.Context, at time.Time) ([]AIUsageRecord, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	day, month := aiUsagePeriods(at)
+	rows, err := s.queryContext(ctx, `
+		SELECT subject,
+			SUM(CASE WHEN period = ? THEN calls ELSE 0 END),
+			SUM(CASE WHEN period = ? THEN calls ELSE 0 END)
+		FROM ai_usage
+		WHERE period IN (?, ?)
+		GROUP BY subject
+		ORDER BY subject
+	`, day, month, day, month)
+	if err != nil {
+		return nil, fmt.Errorf("list ai usage: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	var records []AIUsageRecord
+	for rows.Next() {
+		var (
+			record  AIUsageRecord
+			daily   sql.NullInt64
+			monthly sql.NullInt64
+		)
+		if err := rows.Scan(&record.Subject, &daily, &monthly); err != nil {
+			return nil, fmt.Errorf("scan ai usage: %w", err)
+		}
+		record.Daily = int(daily.Int64)
+		record.Monthly = int(monthly.Int64)
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list ai usage: %w", err)
+	}
+	return records, nil
+}
+
+// RecordAISpend counts one AI call and its estimated cost for the UTC day of
+// at, across all subjects.
+func (s *Store) RecordAISpend(ctx context.Context, at time.Time, costUSD float64) error {
+	if s == nil || s.DB == nil {
+		return errors.New("store is not initialized")
+	}
+
+	day, _ := aiUsagePeriods(at)
+	if _, err := s.execContext(ctx, `
+		INSERT INTO ai_spend (day, calls, cost_usd, updated_at)
+		VALUES (?, 1, ?, ?)
+		ON CONFLICT(day) DO UPDATE SET
+			calls = ai_spend.calls + 1,
+			cost_usd = ai_spend.cost_usd + excluded.cost_usd,
+			updated_at = excluded.updated_at
+	`, day, costUSD, at.UTC().Unix()); err != nil {
+		return fmt.Errorf("record ai spend: %w", err)
+	}
+	return nil
+}
+
+// AISpend returns the AI calls and estimated cost for the UTC day of at.
+func (s *Store) AISpend(ctx context.Context, at time.Time) (int, float64, error) {
+	if s == nil || s.DB == nil {
+		return 0, 0, errors.New("store is not initialized")
+	}
+
+	day, _ := aiUsagePeriods(at)
+	var (
+		calls int
+		cost  float64
+	)
+	err := s.queryRowContext(ctx, `SELECT calls, cost_usd FROM ai_spend WHERE day = ?`, day).Scan(&calls, &cost)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("fetch ai spend: %w", err)
+	}
+	return calls, cost, nil
+}
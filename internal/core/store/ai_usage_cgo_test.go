@@ -0,0 +1,68 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+)
+
+func TestAIUsage(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	yesterday := time.Date(2026, 3, 14, 23, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, store.IncrementAIUsage(ctx, "dashboard", yesterday))
+	require.NoError(t, store.IncrementAIUsage(ctx, "dashboard", today))
+	require.NoError(t, store.IncrementAIUsage(ctx, "dashboard", today))
+	require.NoError(t, store.IncrementAIUsage(ctx, "ci", today))
+
+	daily, monthly, err := store.AIUsage(ctx, "dashboard", today)
+	require.NoError(t, err)
+	require.Equal(t, 2, daily)
+	require.Equal(t, 3, monthly)
+
+	daily, monthly, err = store.AIUsage(ctx, "dashboard", today.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	require.Zero(t, daily)
+	require.Zero(t, monthly)
+
+	records, err := store.ListAIUsage(ctx, today)
+	require.NoError(t, err)
+	require.Equal(t, []AIUsageRecord{
+		{Subject: "ci", Daily: 1, Monthly: 1},
+		{Subject: "dashboard", Daily: 2, Monthly: 3},
+	}, records)
+}
+
+func TestAISpend(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	today := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+	calls, cost, err := store.AISpend(ctx, today)
+	require.NoError(t, err)
+	require.Zero(t, calls)
+	require.Zero(t, cost)
+
+	require.NoError(t, store.RecordAISpend(ctx, today, 0.25))
+	require.NoError(t, store.RecordAISpend(ctx, today.Add(time.Hour), 0))
+	require.NoError(t, store.RecordAISpend(ctx, today.AddDate(0, 0, -1), 3))
+
+	calls, cost, err = store.AISpend(ctx, today)
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	require.InDelta(t, 0.25, cost, 1e-9)
+}
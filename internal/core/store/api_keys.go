@@ -0,0 +1,229 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APIKey is a control plane API key created with 'namelens apikey create'.
+// Only a hash of the secret is stored.
+type APIKey struct {
+	Name string
+	Role string
+	// RateLimit is requests per minute; zero is unlimited.
+	RateLimit int
+	// DailyQuota is requests per UTC day; zero is unlimited.
+	DailyQuota int
+	CreatedAt  time.Time
+	// RevokedAt is zero for active keys.
+	RevokedAt time.Time
+}
+
+// Active reports whether the key has not been revoked.
+func (k APIKey) Active() bool {
+	return k.RevokedAt.IsZero()
+}
+
+// hashAPIKey returns the stored form of an API key secret.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(secret)))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIKey stores key with the hash of secret. A revoked key's name may be
+// reused; an active one may not.
+func (s *Store) CreateAPIKey(ctx context.Context, key APIKey, secret string) error {
+	if s == nil || s.DB == nil {
+		return errors.New("store is not initialized")
+	}
+	name := strings.TrimSpace(key.Name)
+	if name == "" {
+		return errors.New("api key name is required")
+	}
+	if strings.TrimSpace(secret) == "" {
+		return errors.New("api key secret is required")
+	}
+
+	result, err := s.execContext(ctx, `
+		INSERT INTO api_keys (name, key_hash, role, rate_limit, daily_quota, created_at, revoked_at)
+		VALUES (?, ?, ?, ?, ?, ?, NULL)
+		ON CONFLICT(name) DO UPDATE SET
+			key_hash = excluded.key_hash,
+			role = excluded.role,
+			rate_limit = excluded.rate_limit,
+			daily_quota = excluded.daily_quota,
+			created_at = excluded.created_at,
+			revoked_at = NULL
+		WHERE api_keys.revoked_at IS NOT NULL
+	`, name, hashAPIKey(secret), key.Role, key.RateLimit, key.DailyQuota, key.CreatedAt.UTC().Unix())
+	if err != nil {
+		return fmt.Errorf("create api key: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return fmt.Errorf("api key %s already exists", name)
+	}
+	return nil
+}
+
+// LookupAPIKey returns the active key whose secret is secret, or nil.
+func (s *Store) LookupAPIKey(ctx context.Context, secret string) (*APIKey, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	row := s.queryRowContext(ctx, `
+		SELECT name, role, rate_limit, daily_quota, created_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = ? AND revoked_at IS NULL
+	`, hashAPIKey(secret))
+	key, err := scanAPIKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lookup api key: %w", err)
+	}
+	return key, nil
+}
+
+// RevokeAPIKey revokes the active key called name and reports whether there
+// was one.
+func (s *Store) RevokeAPIKey(ctx context.Context, name string, at time.Time) (bool, error) {
+	if s == nil || s.DB == nil {
+		return false, errors.New("store is not initialized")
+	}
+
+	result, err := s.execContext(ctx, `
+		UPDATE api_keys SET revoked_at = ?
+		WHERE name = ? AND revoked_at IS NULL
+	`, at.UTC().Unix(), strings.TrimSpace(name))
+	if err != nil {
+		return false, fmt.Errorf("revoke api key: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("revoke api key: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ListAPIKeys returns every stored key, revoked ones included, by name.
+func (s *Store) ListAPIKeys(ctx context.Context) ([]APIKey, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	rows, err := s.queryContext(ctx, `
+		SELECT name, role, rate_limit, daily_quota, created_at, revoked_at
+		FROM api_keys
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	var keys []APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// CountActiveAPIKeys returns the number of keys that have not been revoked.
+func (s *Store) CountActiveAPIKeys(ctx context.Context) (int, error) {
+	if s == nil || s.DB == nil {
+		return 0, errors.New("store is not initialized")
+	}
+
+	var count int
+	if err := s.queryRowContext(ctx, `SELECT COUNT(*) FROM api_keys WHERE revoked_at IS NULL`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count api keys: %w", err)
+	}
+	return count, nil
+}
+
+// IncrementAPIKeyUsage counts one request for the key called name in the UTC
+// day of at and returns the day's count so far.
+func (s *Store) IncrementAPIKeyUsage(ctx context.Context, name string, at time.Time) (int, error) {
+	if s == nil || s.DB == nil {
+		return 0, errors.New("store is not initialized")
+	}
+
+	var requests int
+	err := s.queryRowContext(ctx, `
+		INSERT INTO api_key_usage (name, day, requests)
+		VALUES (?, ?, 1)
+		ON CONFLICT(name, day) DO UPDATE SET
+			requests = api_key_usage.requests + 1
+		RETURNING requests
+	`, strings.TrimSpace(name), at.UTC().Format("2006-01-02")).Scan(&requests)
+	if err != nil {
+		return 0, fmt.Errorf("record api key usage: %w", err)
+	}
+	return requests, nil
+}
+
+// APIKeyUsage returns the requests of every key in the UTC day of at, by name.
+func (s *Store) APIKeyUsage(ctx context.Context, at time.Time) (map[string]int, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	rows, err := s.queryContext(ctx, `
+		SELECT name, requests FROM api_key_usage WHERE day = ?
+	`, at.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("fetch api key usage: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	usage := make(map[string]int)
+	for rows.Next() {
+		var (
+			name     string
+			requests int
+		)
+		if err := rows.Scan(&name, &requests); err != nil {
+			return nil, fmt.Errorf("scan api key usage: %w", err)
+		}
+		usage[name] = requests
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fetch api key usage: %w", err)
+	}
+	return usage, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row rowScanner) (*APIKey, error) {
+	var (
+		key       APIKey
+		createdAt int64
+		revokedAt sql.NullInt64
+	)
+	if err := row.Scan(&key.Name, &key.Role, &key.RateLimit, &key.DailyQuota, &createdAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	key.CreatedAt = time.Unix(createdAt, 0).UTC()
+	if revokedAt.Valid {
+		key.RevokedAt = time.Unix(revokedAt.Int64, 0).UTC()
+	}
+	return &key, nil
+}
@@ -0,0 +1,88 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+)
+
+func TestAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	defer store.Close() // nolint:errcheck // test cleanup
+	require.NoError(t, store.Migrate(ctx))
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	key := APIKey{Name: "partner", Role: "check", RateLimit: 60, DailyQuota: 1000, CreatedAt: now}
+	require.NoError(t, store.CreateAPIKey(ctx, key, "nlcp_secret1"))
+	require.ErrorContains(t, store.CreateAPIKey(ctx, key, "nlcp_secret2"), "already exists")
+
+	found, err := store.LookupAPIKey(ctx, "nlcp_secret1")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.Equal(t, "partner", found.Name)
+	require.Equal(t, 60, found.RateLimit)
+	require.True(t, found.Active())
+
+	missing, err := store.LookupAPIKey(ctx, "nlcp_secret2")
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	count, err := store.CountActiveAPIKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	revoked, err := store.RevokeAPIKey(ctx, "partner", now.Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, revoked)
+	revoked, err = store.RevokeAPIKey(ctx, "partner", now.Add(time.Hour))
+	require.NoError(t, err)
+	require.False(t, revoked, "already revoked")
+
+	found, err = store.LookupAPIKey(ctx, "nlcp_secret1")
+	require.NoError(t, err)
+	require.Nil(t, found, "revoked keys no longer authenticate")
+
+	keys, err := store.ListAPIKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.False(t, keys[0].Active())
+
+	// A revoked name can be reused with a new secret
+	require.NoError(t, store.CreateAPIKey(ctx, key, "nlcp_secret2"))
+	found, err = store.LookupAPIKey(ctx, "nlcp_secret2")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.True(t, found.Active())
+}
+
+func TestAPIKeyUsage(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	defer store.Close() // nolint:errcheck // test cleanup
+	require.NoError(t, store.Migrate(ctx))
+
+	day := time.Date(2026, 3, 1, 23, 0, 0, 0, time.UTC)
+	for want := 1; want <= 3; want++ {
+		got, err := store.IncrementAPIKeyUsage(ctx, "partner", day)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	// The count starts over on the next UTC day
+	got, err := store.IncrementAPIKeyUsage(ctx, "partner", day.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, got)
+
+	usage, err := store.APIKeyUsage(ctx, day)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"partner": 3}, usage)
+}
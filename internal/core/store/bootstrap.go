@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/namelens/namelens/internal/core"
 )
 
 // SetRDAPServers stores RDAP server URLs for a TLD.
@@ -30,7 +32,7 @@ func (s *Store) SetRDAPServers(ctx context.Context, tld string, servers []string
 		return fmt.Errorf("marshal rdap servers: %w", err)
 	}
 
-	_, err = s.DB.ExecContext(ctx, `
+	_, err = s.execContext(ctx, `
 		INSERT INTO bootstrap_tlds (tld, rdap_urls, updated_at)
 		VALUES (?, ?, ?)
 		ON CONFLICT(tld) DO UPDATE SET
@@ -60,7 +62,7 @@ func (s *Store) GetRDAPServers(ctx context.Context, tld string) ([]string, error
 	}
 
 	var payload string
-	if err := s.DB.QueryRowContext(ctx, `SELECT rdap_urls FROM bootstrap_tlds WHERE tld = ?`, normalized).Scan(&payload); err != nil {
+	if err := s.queryRowContext(ctx, `SELECT rdap_urls FROM bootstrap_tlds WHERE tld = ?`, normalized).Scan(&payload); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
 		}
@@ -75,6 +77,210 @@ func (s *Store) GetRDAPServers(ctx context.Context, tld string) ([]string, error
 	return servers, nil
 }
 
+// ListRDAPServers returns the RDAP server URLs of every cached TLD.
+func (s *Store) ListRDAPServers(ctx context.Context) (map[string][]string, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	rows, err := s.queryContext(ctx, `SELECT tld, rdap_urls FROM bootstrap_tlds`)
+	if err != nil {
+		return nil, fmt.Errorf("list rdap servers: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	servers := make(map[string][]string)
+	for rows.Next() {
+		var tld, payload string
+		if err := rows.Scan(&tld, &payload); err != nil {
+			return nil, fmt.Errorf("scan rdap servers: %w", err)
+		}
+		var urls []string
+		if err := json.Unmarshal([]byte(payload), &urls); err != nil {
+			return nil, fmt.Errorf("decode rdap servers for %s: %w", tld, err)
+		}
+		servers[tld] = urls
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list rdap servers: %w", err)
+	}
+
+	return servers, nil
+}
+
+// ReplaceRDAPServers stores the RDAP server URLs of every TLD in one
+// transaction and removes TLDs that are no longer listed.
+func (s *Store) ReplaceRDAPServers(ctx context.Context, servers map[string][]string, updatedAt time.Time) error {
+	if s == nil || s.DB == nil {
+		return errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if len(servers) == 0 {
+		return errors.New("rdap servers are required")
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin bootstrap transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint:errcheck // no-op after commit
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM bootstrap_tlds`); err != nil {
+		return fmt.Errorf("clear rdap servers: %w", err)
+	}
+	for tld, urls := range servers {
+		normalized := normalizeTLD(tld)
+		if normalized == "" {
+			continue
+		}
+		payload, err := json.Marshal(urls)
+		if err != nil {
+			return fmt.Errorf("marshal rdap servers: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, s.rebind(`
+			INSERT INTO bootstrap_tlds (tld, rdap_urls, updated_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT(tld) DO UPDATE SET
+				rdap_urls = excluded.rdap_urls,
+				updated_at = excluded.updated_at
+		`), normalized, string(payload), updatedAt.Unix())
+		if err != nil {
+			return fmt.Errorf("store rdap servers: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit rdap servers: %w", err)
+	}
+
+	return nil
+}
+
+// SaveBootstrapSnapshot records the bootstrap data of an update, keeping only
+// the newest keep snapshots (all of them when keep is not positive).
+func (s *Store) SaveBootstrapSnapshot(ctx context.Context, snapshot core.BootstrapSnapshot, keep int) error {
+	if s == nil || s.DB == nil {
+		return errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	payload, err := json.Marshal(snapshot.Servers)
+	if err != nil {
+		return fmt.Errorf("marshal bootstrap snapshot: %w", err)
+	}
+
+	tldCount := snapshot.TLDCount
+	if tldCount == 0 {
+		tldCount = len(snapshot.Servers)
+	}
+
+	_, err = s.execContext(ctx, `
+		INSERT INTO bootstrap_snapshots (version, publication, source, fetched_at, tld_count, servers)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, snapshot.Version, snapshot.Publication, snapshot.Source, snapshot.FetchedAt.UTC().Unix(), tldCount, string(payload))
+	if err != nil {
+		return fmt.Errorf("store bootstrap snapshot: %w", err)
+	}
+
+	if keep > 0 {
+		_, err = s.execContext(ctx, `
+			DELETE FROM bootstrap_snapshots
+			WHERE id NOT IN (SELECT id FROM bootstrap_snapshots ORDER BY id DESC LIMIT ?)
+		`, keep)
+		if err != nil {
+			return fmt.Errorf("prune bootstrap snapshots: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListBootstrapSnapshots returns the stored snapshots, newest first, without
+// their server mappings.
+func (s *Store) ListBootstrapSnapshots(ctx context.Context) ([]core.BootstrapSnapshot, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	rows, err := s.queryContext(ctx, `
+		SELECT id, COALESCE(version, ''), COALESCE(publication, ''), COALESCE(source, ''), fetched_at, tld_count
+		FROM bootstrap_snapshots
+		ORDER BY id DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list bootstrap snapshots: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	var snapshots []core.BootstrapSnapshot
+	for rows.Next() {
+		var (
+			snapshot  core.BootstrapSnapshot
+			fetchedAt int64
+		)
+		if err := rows.Scan(&snapshot.ID, &snapshot.Version, &snapshot.Publication, &snapshot.Source, &fetchedAt, &snapshot.TLDCount); err != nil {
+			return nil, fmt.Errorf("scan bootstrap snapshot: %w", err)
+		}
+		snapshot.FetchedAt = time.Unix(fetchedAt, 0).UTC()
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list bootstrap snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// GetBootstrapSnapshot returns a stored snapshot with its server mappings, or
+// nil when there is no snapshot with that ID.
+func (s *Store) GetBootstrapSnapshot(ctx context.Context, id int64) (*core.BootstrapSnapshot, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var (
+		snapshot  core.BootstrapSnapshot
+		fetchedAt int64
+		payload   string
+	)
+	err := s.queryRowContext(ctx, `
+		SELECT id, COALESCE(version, ''), COALESCE(publication, ''), COALESCE(source, ''), fetched_at, tld_count, servers
+		FROM bootstrap_snapshots
+		WHERE id = ?
+	`, id).Scan(&snapshot.ID, &snapshot.Version, &snapshot.Publication, &snapshot.Source, &fetchedAt, &snapshot.TLDCount, &payload)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fetch bootstrap snapshot: %w", err)
+	}
+	snapshot.FetchedAt = time.Unix(fetchedAt, 0).UTC()
+	if err := json.Unmarshal([]byte(payload), &snapshot.Servers); err != nil {
+		return nil, fmt.Errorf("decode bootstrap snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
 // SetBootstrapMeta stores a bootstrap metadata key/value.
 func (s *Store) SetBootstrapMeta(ctx context.Context, key, value string) error {
 	if s == nil || s.DB == nil {
@@ -89,7 +295,7 @@ func (s *Store) SetBootstrapMeta(ctx context.Context, key, value string) error {
 		return errors.New("bootstrap meta key is required")
 	}
 
-	_, err := s.DB.ExecContext(ctx, `
+	_, err := s.execContext(ctx, `
 		INSERT INTO bootstrap_meta (key, value)
 		VALUES (?, ?)
 		ON CONFLICT(key) DO UPDATE SET
@@ -117,7 +323,7 @@ func (s *Store) GetBootstrapMeta(ctx context.Context, key string) (string, error
 	}
 
 	var value string
-	if err := s.DB.QueryRowContext(ctx, `SELECT value FROM bootstrap_meta WHERE key = ?`, key).Scan(&value); err != nil {
+	if err := s.queryRowContext(ctx, `SELECT value FROM bootstrap_meta WHERE key = ?`, key).Scan(&value); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return "", nil
 		}
@@ -138,7 +344,7 @@ func (s *Store) CountBootstrapTLDs(ctx context.Context) (int, error) {
 	}
 
 	var count int
-	if err := s.DB.QueryRowContext(ctx, `SELECT COUNT(1) FROM bootstrap_tlds`).Scan(&count); err != nil {
+	if err := s.queryRowContext(ctx, `SELECT COUNT(1) FROM bootstrap_tlds`).Scan(&count); err != nil {
 		return 0, fmt.Errorf("count bootstrap tlds: %w", err)
 	}
 
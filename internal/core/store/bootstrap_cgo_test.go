@@ -0,0 +1,83 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestReplaceRDAPServers(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.SetRDAPServers(ctx, "old", []string{"https://rdap.old.example/"}, now))
+	require.Error(t, store.ReplaceRDAPServers(ctx, nil, now))
+
+	servers := map[string][]string{
+		"com": {"https://rdap.verisign.com/com/v1/"},
+		"dev": {"https://rdap.nic.google/", "https://rdap.backup.example/"},
+	}
+	require.NoError(t, store.ReplaceRDAPServers(ctx, servers, now))
+
+	got, err := store.ListRDAPServers(ctx)
+	require.NoError(t, err)
+	require.Equal(t, servers, got)
+
+	count, err := store.CountBootstrapTLDs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
+
+func TestBootstrapSnapshots(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	snapshots, err := store.ListBootstrapSnapshots(ctx)
+	require.NoError(t, err)
+	require.Empty(t, snapshots)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := range 4 {
+		require.NoError(t, store.SaveBootstrapSnapshot(ctx, core.BootstrapSnapshot{
+			Version:     "1.0",
+			Publication: "2026-02-28T00:00:00Z",
+			Source:      "https://data.iana.org/rdap/dns.json",
+			FetchedAt:   start.Add(time.Duration(i) * time.Hour),
+			Servers:     map[string][]string{"com": {"https://rdap.verisign.com/com/v1/"}},
+		}, 3))
+	}
+
+	snapshots, err = store.ListBootstrapSnapshots(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 3)
+	require.Equal(t, start.Add(3*time.Hour), snapshots[0].FetchedAt)
+	require.Equal(t, start.Add(time.Hour), snapshots[2].FetchedAt)
+	require.Equal(t, 1, snapshots[0].TLDCount)
+	require.Nil(t, snapshots[0].Servers)
+
+	snapshot, err := store.GetBootstrapSnapshot(ctx, snapshots[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	require.Equal(t, "1.0", snapshot.Version)
+	require.Equal(t, "https://data.iana.org/rdap/dns.json", snapshot.Source)
+	require.Equal(t, map[string][]string{"com": {"https://rdap.verisign.com/com/v1/"}}, snapshot.Servers)
+
+	// The oldest snapshot was pruned
+	snapshot, err = store.GetBootstrapSnapshot(ctx, snapshots[2].ID-1)
+	require.NoError(t, err)
+	require.Nil(t, snapshot)
+}
@@ -12,8 +12,36 @@ import (
 	"github.com/namelens/namelens/internal/core"
 )
 
-// GetCachedResult returns a cached check result if it is still valid.
+// GetCachedResult returns a cached check result if it is still valid,
+// consulting the memory cache first when it is enabled.
 func (s *Store) GetCachedResult(ctx context.Context, name string, checkType core.CheckType, tld string) (*core.CheckResult, error) {
+	if s == nil || s.memory == nil {
+		return s.getCachedResult(ctx, name, checkType, tld, time.Now().UTC())
+	}
+
+	key := memoryCacheKey(name, checkType, tld)
+	if cached := s.memory.get(key); cached != nil {
+		return cached, nil
+	}
+	// A SetCachedResult that lands during the read bumps the generation, so
+	// the row read here is not put back over the new one.
+	generation := s.memory.generation(key)
+	result, err := s.getCachedResult(ctx, name, checkType, tld, time.Now().UTC())
+	if err == nil && result != nil {
+		s.memory.put(key, result, generation)
+	}
+	return result, err
+}
+
+// GetStaleCachedResult returns a cached check result that is still valid or
+// expired less than maxStale ago.
+func (s *Store) GetStaleCachedResult(ctx context.Context, name string, checkType core.CheckType, tld string, maxStale time.Duration) (*core.CheckResult, error) {
+	return s.getCachedResult(ctx, name, checkType, tld, time.Now().UTC().Add(-maxStale))
+}
+
+// getCachedResult returns the cached result if it expires after
+// expiresAfter.
+func (s *Store) getCachedResult(ctx context.Context, name string, checkType core.CheckType, tld string, expiresAfter time.Time) (*core.CheckResult, error) {
 	if s == nil || s.DB == nil {
 		return nil, errors.New("store is not initialized")
 	}
@@ -38,11 +66,11 @@ func (s *Store) GetCachedResult(ctx context.Context, name string, checkType core
 		statusCode sql.NullInt64
 	)
 
-	row := s.DB.QueryRowContext(ctx, `
+	row := s.queryRowContext(ctx, `
 		SELECT available, status_code, message, extra_data, checked_at, expires_at
 		FROM check_cache
 		WHERE name = ? AND check_type = ? AND tld = ? AND expires_at > ?
-	`, keyName, string(checkType), tld, time.Now().UTC().Unix())
+	`, keyName, string(checkType), tld, expiresAfter.Unix())
 
 	if err := row.Scan(&available, &statusCode, &message, &extraJSON, &checkedAt, &expiresAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -58,6 +86,9 @@ func (s *Store) GetCachedResult(ctx context.Context, name string, checkType core
 		}
 	}
 
+	// Hit counts feed `namelens stats`; they are buffered so reads stay reads.
+	s.countCacheHit(ctx, keyName, string(checkType), tld)
+
 	checked := time.Unix(checkedAt, 0).UTC()
 	expires := time.Unix(expiresAt, 0).UTC()
 
@@ -114,7 +145,7 @@ func (s *Store) SetCachedResult(ctx context.Context, name string, result *core.C
 	now := time.Now().UTC()
 	expires := now.Add(ttl)
 
-	_, err = s.DB.ExecContext(ctx, `
+	_, err = s.execContext(ctx, `
 		INSERT INTO check_cache (name, check_type, tld, available, status_code, extra_data, message, checked_at, expires_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(name, check_type, tld) DO UPDATE SET
@@ -128,6 +159,9 @@ func (s *Store) SetCachedResult(ctx context.Context, name string, result *core.C
 	if err != nil {
 		return fmt.Errorf("store cached result: %w", err)
 	}
+	if s.memory != nil {
+		s.memory.remove(memoryCacheKey(keyName, result.CheckType, result.TLD))
+	}
 
 	return nil
 }
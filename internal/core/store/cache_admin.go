@@ -0,0 +1,226 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CacheTypeStat summarizes the check cache for one check type.
+type CacheTypeStat struct {
+	CheckType string `json:"check_type"`
+	Entries   int    `json:"entries"`
+	Live      int    `json:"live"`
+	// Bytes approximates the stored size: names, messages, and extra data
+	Bytes        int64   `json:"bytes"`
+	Hits         int     `json:"hits"`
+	FreshLookups int     `json:"fresh_lookups"`
+	HitRate      float64 `json:"hit_rate"`
+}
+
+// CacheStats summarizes the check cache by check type. Hit rates compare
+// cache hits with the fresh lookups recorded in check history.
+func (s *Store) CacheStats(ctx context.Context, now time.Time) ([]CacheTypeStat, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Include hits this process has buffered but not yet written
+	_ = s.FlushCacheHits(ctx)
+
+	byType := map[string]*CacheTypeStat{}
+	stat := func(checkType string) *CacheTypeStat {
+		if byType[checkType] == nil {
+			byType[checkType] = &CacheTypeStat{CheckType: checkType}
+		}
+		return byType[checkType]
+	}
+
+	rows, err := s.queryContext(ctx, `
+		SELECT check_type, COUNT(*),
+			SUM(CASE WHEN expires_at > ? THEN 1 ELSE 0 END),
+			COALESCE(SUM(LENGTH(name) + LENGTH(COALESCE(tld, '')) + LENGTH(COALESCE(message, '')) + LENGTH(COALESCE(extra_data, ''))), 0),
+			COALESCE(SUM(hits), 0)
+		FROM check_cache
+		GROUP BY check_type
+	`, now.UTC().Unix())
+	if err != nil {
+		return nil, fmt.Errorf("summarize cache: %w", err)
+	}
+	for rows.Next() {
+		var (
+			checkType string
+			entry     CacheTypeStat
+		)
+		if err := rows.Scan(&checkType, &entry.Entries, &entry.Live, &entry.Bytes, &entry.Hits); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan cache stats: %w", err)
+		}
+		entry.CheckType = checkType
+		*stat(checkType) = entry
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, fmt.Errorf("summarize cache: %w", err)
+	}
+
+	rows, err = s.queryContext(ctx, `
+		SELECT check_type, COUNT(*)
+		FROM check_history
+		GROUP BY check_type
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("summarize lookups: %w", err)
+	}
+	for rows.Next() {
+		var (
+			checkType string
+			lookups   int
+		)
+		if err := rows.Scan(&checkType, &lookups); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan lookup stats: %w", err)
+		}
+		stat(checkType).FreshLookups = lookups
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, fmt.Errorf("summarize lookups: %w", err)
+	}
+
+	stats := make([]CacheTypeStat, 0, len(byType))
+	for _, entry := range byType {
+		if total := entry.Hits + entry.FreshLookups; total > 0 {
+			entry.HitRate = float64(entry.Hits) / float64(total)
+		}
+		stats = append(stats, *entry)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].CheckType < stats[j].CheckType
+	})
+	return stats, nil
+}
+
+// CachePurgeQuery selects check cache entries to purge. Filters combine: an
+// entry must match all of them.
+type CachePurgeQuery struct {
+	All       bool
+	Name      string
+	TLD       string
+	CheckType string
+	// CheckedBefore matches entries checked before it
+	CheckedBefore time.Time
+	// ExpiredAt matches entries that expired at or before it
+	ExpiredAt time.Time
+}
+
+func (q CachePurgeQuery) Validate() error {
+	if q.All {
+		return nil
+	}
+	if strings.TrimSpace(q.Name) != "" || strings.TrimSpace(q.TLD) != "" || strings.TrimSpace(q.CheckType) != "" {
+		return nil
+	}
+	if !q.CheckedBefore.IsZero() || !q.ExpiredAt.IsZero() {
+		return nil
+	}
+	return errors.New("must specify --all, --name, --tld, --type, --older-than, or --expired")
+}
+
+func (q CachePurgeQuery) whereClause() (string, []any, error) {
+	if err := q.Validate(); err != nil {
+		return "", nil, err
+	}
+
+	var (
+		conditions []string
+		args       []any
+	)
+	if name := strings.ToLower(strings.TrimSpace(q.Name)); name != "" {
+		conditions = append(conditions, "name = ?")
+		args = append(args, name)
+	}
+	if tld := normalizeTLD(q.TLD); tld != "" {
+		conditions = append(conditions, "tld = ?")
+		args = append(args, tld)
+	}
+	if checkType := strings.ToLower(strings.TrimSpace(q.CheckType)); checkType != "" {
+		conditions = append(conditions, "check_type = ?")
+		args = append(args, checkType)
+	}
+	if !q.CheckedBefore.IsZero() {
+		conditions = append(conditions, "checked_at < ?")
+		args = append(args, q.CheckedBefore.UTC().Unix())
+	}
+	if !q.ExpiredAt.IsZero() {
+		conditions = append(conditions, "expires_at <= ?")
+		args = append(args, q.ExpiredAt.UTC().Unix())
+	}
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// CountCacheEntries counts the check cache entries q matches.
+func (s *Store) CountCacheEntries(ctx context.Context, q CachePurgeQuery) (int, error) {
+	if s == nil || s.DB == nil {
+		return 0, errors.New("store is not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	where, args, err := q.whereClause()
+	if err != nil {
+		return 0, err
+	}
+
+	row := s.queryRowContext(ctx, fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM check_cache
+		%s
+	`, where), args...)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("count cache entries: %w", err)
+	}
+	return count, nil
+}
+
+// PurgeCache deletes the check cache entries q matches.
+func (s *Store) PurgeCache(ctx context.Context, q CachePurgeQuery) (int64, error) {
+	if s == nil || s.DB == nil {
+		return 0, errors.New("store is not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	where, args, err := q.whereClause()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.execContext(ctx, fmt.Sprintf(`
+		DELETE FROM check_cache
+		%s
+	`, where), args...)
+	if err != nil {
+		return 0, fmt.Errorf("purge cache: %w", err)
+	}
+	if s.memory != nil {
+		s.memory.clear()
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("purge cache: %w", err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,86 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestCacheAdmin(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	empty, err := store.CacheStats(ctx, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, empty)
+
+	for _, entry := range []struct {
+		name   string
+		result *core.CheckResult
+	}{
+		{"acme", &core.CheckResult{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}},
+		{"acme", &core.CheckResult{Name: "acme.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable}},
+		{"zenith", &core.CheckResult{Name: "zenith.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable}},
+		{"acme", &core.CheckResult{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken, Message: "package exists"}},
+	} {
+		require.NoError(t, store.SetCachedResult(ctx, entry.name, entry.result, time.Hour))
+	}
+	_, err = store.RecordHistory(ctx, "acme", []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+	})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := store.GetCachedResult(ctx, "acme", core.CheckTypeDomain, "com")
+		require.NoError(t, err)
+	}
+
+	stats, err := store.CacheStats(ctx, time.Now())
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, "domain", stats[0].CheckType)
+	require.Equal(t, 3, stats[0].Entries)
+	require.Equal(t, 3, stats[0].Live)
+	require.Equal(t, 3, stats[0].Hits)
+	require.Equal(t, 1, stats[0].FreshLookups)
+	require.Equal(t, 0.75, stats[0].HitRate)
+	require.Positive(t, stats[0].Bytes)
+	require.Equal(t, "npm", stats[1].CheckType)
+	require.Zero(t, stats[1].HitRate)
+
+	// Entries stop being live after their one-hour TTL
+	later, err := store.CacheStats(ctx, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, later[0].Live)
+
+	require.Error(t, CachePurgeQuery{}.Validate())
+
+	count, err := store.CountCacheEntries(ctx, CachePurgeQuery{Name: "acme", TLD: ".IO"})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	deleted, err := store.PurgeCache(ctx, CachePurgeQuery{TLD: "io"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+
+	deleted, err = store.PurgeCache(ctx, CachePurgeQuery{ExpiredAt: time.Now()})
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+
+	deleted, err = store.PurgeCache(ctx, CachePurgeQuery{CheckType: "npm", CheckedBefore: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	deleted, err = store.PurgeCache(ctx, CachePurgeQuery{All: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+}
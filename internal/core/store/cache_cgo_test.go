@@ -0,0 +1,81 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestGetStaleCachedResult(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	result := &core.CheckResult{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}
+	require.NoError(t, store.SetCachedResult(ctx, "acme", result, time.Hour))
+
+	// Expire the entry ten minutes ago
+	_, err = store.DB.ExecContext(ctx, `UPDATE check_cache SET expires_at = ?`, time.Now().Add(-10*time.Minute).Unix())
+	require.NoError(t, err)
+
+	cached, err := store.GetCachedResult(ctx, "acme", core.CheckTypeDomain, "com")
+	require.NoError(t, err)
+	require.Nil(t, cached)
+
+	cached, err = store.GetStaleCachedResult(ctx, "acme", core.CheckTypeDomain, "com", time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	require.Equal(t, core.AvailabilityTaken, cached.Available)
+	require.True(t, cached.Provenance.CacheExpiresAt.Before(time.Now()))
+
+	cached, err = store.GetStaleCachedResult(ctx, "acme", core.CheckTypeDomain, "com", 5*time.Minute)
+	require.NoError(t, err)
+	require.Nil(t, cached)
+}
+
+func TestMemoryCacheSkipsSQL(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+	store.EnableMemoryCache(100, time.Minute)
+
+	result := &core.CheckResult{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}
+	require.NoError(t, store.SetCachedResult(ctx, "acme", result, time.Hour))
+	cached, err := store.GetCachedResult(ctx, "acme", core.CheckTypeDomain, "com")
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+
+	// Remove the row behind the store's back: the memory copy still answers
+	_, err = store.DB.ExecContext(ctx, `DELETE FROM check_cache`)
+	require.NoError(t, err)
+	cached, err = store.GetCachedResult(ctx, "acme", core.CheckTypeDomain, ".COM")
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	require.True(t, cached.Provenance.FromCache)
+	require.Equal(t, core.AvailabilityTaken, cached.Available)
+
+	// Writes replace the memory copy
+	result.Available = core.AvailabilityAvailable
+	require.NoError(t, store.SetCachedResult(ctx, "acme", result, time.Hour))
+	cached, err = store.GetCachedResult(ctx, "acme", core.CheckTypeDomain, "com")
+	require.NoError(t, err)
+	require.Equal(t, core.AvailabilityAvailable, cached.Available)
+
+	// Purges clear it
+	_, err = store.PurgeCache(ctx, CachePurgeQuery{All: true})
+	require.NoError(t, err)
+	cached, err = store.GetCachedResult(ctx, "acme", core.CheckTypeDomain, "com")
+	require.NoError(t, err)
+	require.Nil(t, cached)
+}
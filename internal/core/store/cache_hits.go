@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// cacheHitFlushSize is how many check cache hits are buffered before they are
+// written in one transaction.
+const cacheHitFlushSize = 64
+
+type cacheHitKey struct {
+	name      string
+	checkType string
+	tld       string
+}
+
+// cacheHits buffers check cache hit counts so cache reads do not each write
+// to the store. A failed write, such as on a read-only database, turns
+// counting off for the rest of the store's life.
+type cacheHits struct {
+	mu       sync.Mutex
+	pending  map[cacheHitKey]int
+	total    int
+	disabled bool
+}
+
+// countCacheHit buffers one hit and writes the buffer once it is full.
+func (s *Store) countCacheHit(ctx context.Context, name, checkType, tld string) {
+	s.hits.mu.Lock()
+	if s.hits.disabled {
+		s.hits.mu.Unlock()
+		return
+	}
+	if s.hits.pending == nil {
+		s.hits.pending = map[cacheHitKey]int{}
+	}
+	s.hits.pending[cacheHitKey{name: name, checkType: checkType, tld: tld}]++
+	s.hits.total++
+	full := s.hits.total >= cacheHitFlushSize
+	s.hits.mu.Unlock()
+
+	if full {
+		_ = s.FlushCacheHits(ctx)
+	}
+}
+
+// FlushCacheHits writes buffered check cache hit counts. Close calls it, and
+// the stats queries call it before reading hits.
+func (s *Store) FlushCacheHits(ctx context.Context) error {
+	if s == nil || s.DB == nil {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s.hits.mu.Lock()
+	pending := s.hits.pending
+	s.hits.pending, s.hits.total = nil, 0
+	s.hits.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := s.writeCacheHits(ctx, pending); err != nil {
+		s.hits.mu.Lock()
+		s.hits.disabled = true
+		s.hits.pending, s.hits.total = nil, 0
+		s.hits.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *Store) writeCacheHits(ctx context.Context, pending map[cacheHitKey]int) error {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin cache hit transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint:errcheck // no-op after commit
+
+	query := s.rebind(`
+		UPDATE check_cache SET hits = hits + ?
+		WHERE name = ? AND check_type = ? AND tld = ?
+	`)
+	for key, count := range pending {
+		if _, err := tx.ExecContext(ctx, query, count, key.name, key.checkType, key.tld); err != nil {
+			return fmt.Errorf("record cache hits: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("record cache hits: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -22,7 +24,7 @@ func (s *Store) GetExpertCache(ctx context.Context, name, promptSlug, model, bas
 		ctx = context.Background()
 	}
 
-	row := s.DB.QueryRowContext(ctx,
+	row := s.queryRowContext(ctx,
 		`SELECT response_json, expires_at FROM expert_cache
 		 WHERE name = ? AND prompt_slug = ? AND model = ? AND base_url = ? AND depth = ?`,
 		name, promptSlug, model, baseURL, depth,
@@ -62,7 +64,7 @@ func (s *Store) SetExpertCache(ctx context.Context, name, promptSlug, model, bas
 	now := time.Now().UTC()
 	expiresAt := now.Add(ttl)
 
-	_, err := s.DB.ExecContext(ctx,
+	_, err := s.execContext(ctx,
 		`INSERT INTO expert_cache (name, prompt_slug, model, base_url, depth, response_json, created_at, expires_at)
 		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(name, prompt_slug, model, base_url, depth)
@@ -73,3 +75,182 @@ func (s *Store) SetExpertCache(ctx context.Context, name, promptSlug, model, bas
 	)
 	return err
 }
+
+// ExpertCacheRecord is one stored expert response with its cache key.
+type ExpertCacheRecord struct {
+	ID         int64     `json:"id"`
+	Name       string    `json:"name"`
+	PromptSlug string    `json:"prompt_slug"`
+	Model      string    `json:"model"`
+	BaseURL    string    `json:"base_url"`
+	Depth      string    `json:"depth"`
+	Bytes      int       `json:"bytes"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Expired    bool      `json:"expired"`
+	// ResponseJSON is the raw cached payload; it is only loaded by
+	// ExpertCacheEntries with IncludeResponse set
+	ResponseJSON string `json:"response_json,omitempty"`
+}
+
+// ExpertCacheQuery selects expert cache entries. Filters combine: an entry
+// must match all of them.
+type ExpertCacheQuery struct {
+	All  bool
+	ID   int64
+	Name string
+	// PromptSlug also matches the slug:hash keys of analysis prompts
+	PromptSlug string
+	Model      string
+	// CreatedBefore matches entries cached before it
+	CreatedBefore time.Time
+	// ExpiredAt matches entries that expired at or before it
+	ExpiredAt time.Time
+}
+
+// HasFilter reports whether q narrows the entries at all.
+func (q ExpertCacheQuery) HasFilter() bool {
+	return q.ID > 0 || strings.TrimSpace(q.Name) != "" || strings.TrimSpace(q.PromptSlug) != "" ||
+		strings.TrimSpace(q.Model) != "" || !q.CreatedBefore.IsZero() || !q.ExpiredAt.IsZero()
+}
+
+func (q ExpertCacheQuery) whereClause() (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if q.ID > 0 {
+		conditions = append(conditions, "id = ?")
+		args = append(args, q.ID)
+	}
+	if name := strings.ToLower(strings.TrimSpace(q.Name)); name != "" {
+		conditions = append(conditions, "LOWER(name) = ?")
+		args = append(args, name)
+	}
+	if slug := strings.TrimSpace(q.PromptSlug); slug != "" {
+		// Analysis entries key the slug with a hash of the prompt variables,
+		// as slug:hash.
+		conditions = append(conditions, "(prompt_slug = ? OR SUBSTR(prompt_slug, 1, ?) = ?)")
+		args = append(args, slug, len(slug)+1, slug+":")
+	}
+	if model := strings.TrimSpace(q.Model); model != "" {
+		conditions = append(conditions, "model = ?")
+		args = append(args, model)
+	}
+	if !q.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, q.CreatedBefore.UTC().Unix())
+	}
+	if !q.ExpiredAt.IsZero() {
+		conditions = append(conditions, "expires_at <= ?")
+		args = append(args, q.ExpiredAt.UTC().Unix())
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// ExpertCacheEntries lists the expert cache entries q matches, newest first.
+// A limit of 0 or less returns every match.
+func (s *Store) ExpertCacheEntries(ctx context.Context, q ExpertCacheQuery, limit int, includeResponse bool, now time.Time) ([]ExpertCacheRecord, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	where, args := q.whereClause()
+	response := "''"
+	if includeResponse {
+		response = "response_json"
+	}
+	query := fmt.Sprintf(`
+		SELECT id, name, prompt_slug, model, base_url, depth, LENGTH(response_json), %s, created_at, expires_at
+		FROM expert_cache
+		%s
+		ORDER BY created_at DESC, id DESC
+	`, response, where)
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list expert cache: %w", err)
+	}
+	var records []ExpertCacheRecord
+	for rows.Next() {
+		var (
+			record           ExpertCacheRecord
+			created, expires int64
+		)
+		if err := rows.Scan(&record.ID, &record.Name, &record.PromptSlug, &record.Model, &record.BaseURL, &record.Depth,
+			&record.Bytes, &record.ResponseJSON, &created, &expires); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan expert cache: %w", err)
+		}
+		record.CreatedAt = time.Unix(created, 0).UTC()
+		record.ExpiresAt = time.Unix(expires, 0).UTC()
+		record.Expired = !now.UTC().Before(record.ExpiresAt)
+		records = append(records, record)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, fmt.Errorf("list expert cache: %w", err)
+	}
+	return records, nil
+}
+
+// CountExpertCache counts the expert cache entries q matches.
+func (s *Store) CountExpertCache(ctx context.Context, q ExpertCacheQuery) (int, error) {
+	if s == nil || s.DB == nil {
+		return 0, errors.New("store is not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	where, args := q.whereClause()
+	row := s.queryRowContext(ctx, fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM expert_cache
+		%s
+	`, where), args...)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("count expert cache: %w", err)
+	}
+	return count, nil
+}
+
+// PurgeExpertCache deletes the expert cache entries q matches, so the next
+// expert run for them calls the provider again. Without a filter it requires
+// q.All.
+func (s *Store) PurgeExpertCache(ctx context.Context, q ExpertCacheQuery) (int64, error) {
+	if s == nil || s.DB == nil {
+		return 0, errors.New("store is not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !q.All && !q.HasFilter() {
+		return 0, errors.New("must specify --all, an id, --name, --prompt, --model, --older-than, or --expired")
+	}
+
+	where, args := q.whereClause()
+	result, err := s.execContext(ctx, fmt.Sprintf(`
+		DELETE FROM expert_cache
+		%s
+	`, where), args...)
+	if err != nil {
+		return 0, fmt.Errorf("purge expert cache: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("purge expert cache: %w", err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,75 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+)
+
+func TestExpertCacheAdmin(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	require.NoError(t, store.SetExpertCache(ctx, "acme", "name-availability", "grok", "https://api", "quick", `{"summary":"taken"}`, time.Hour))
+	require.NoError(t, store.SetExpertCache(ctx, "acme", "name-phonetics:0123abcd", "grok", "https://api", "quick", `{"score":7}`, time.Hour))
+	require.NoError(t, store.SetExpertCache(ctx, "zenith", "name-availability", "gpt-4o", "https://api", "deep", `{"summary":"free"}`, time.Hour))
+
+	now := time.Now()
+	all, err := store.ExpertCacheEntries(ctx, ExpertCacheQuery{}, 0, false, now)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	require.Empty(t, all[0].ResponseJSON)
+	require.False(t, all[0].Expired)
+
+	acme, err := store.ExpertCacheEntries(ctx, ExpertCacheQuery{Name: "ACME"}, 0, true, now)
+	require.NoError(t, err)
+	require.Len(t, acme, 2)
+
+	phonetics, err := store.ExpertCacheEntries(ctx, ExpertCacheQuery{PromptSlug: "name-phonetics"}, 0, true, now)
+	require.NoError(t, err)
+	require.Len(t, phonetics, 1)
+	require.Equal(t, `{"score":7}`, phonetics[0].ResponseJSON)
+	require.Equal(t, len(`{"score":7}`), phonetics[0].Bytes)
+
+	byID, err := store.ExpertCacheEntries(ctx, ExpertCacheQuery{ID: phonetics[0].ID}, 0, false, now)
+	require.NoError(t, err)
+	require.Len(t, byID, 1)
+
+	limited, err := store.ExpertCacheEntries(ctx, ExpertCacheQuery{}, 1, false, now)
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+
+	// Entries stop being live after their one-hour TTL
+	later := now.Add(2 * time.Hour)
+	expired, err := store.ExpertCacheEntries(ctx, ExpertCacheQuery{ExpiredAt: later}, 0, false, later)
+	require.NoError(t, err)
+	require.Len(t, expired, 3)
+	require.True(t, expired[0].Expired)
+
+	_, err = store.PurgeExpertCache(ctx, ExpertCacheQuery{})
+	require.ErrorContains(t, err, "must specify")
+
+	count, err := store.CountExpertCache(ctx, ExpertCacheQuery{Model: "grok"})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	deleted, err := store.PurgeExpertCache(ctx, ExpertCacheQuery{Name: "acme", PromptSlug: "name-availability"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	entry, err := store.GetExpertCache(ctx, "acme", "name-availability", "grok", "https://api", "quick")
+	require.NoError(t, err)
+	require.Nil(t, entry)
+
+	deleted, err = store.PurgeExpertCache(ctx, ExpertCacheQuery{All: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+}
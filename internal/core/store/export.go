@@ -0,0 +1,319 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+// Export table names.
+const (
+	ExportHistory = "history"
+	ExportCache   = "cache"
+	ExportExpert  = "expert"
+)
+
+// ExportTables lists the exportable datasets in output order.
+var ExportTables = []string{ExportHistory, ExportCache, ExportExpert}
+
+// ExportFilter narrows exported rows. Empty fields match everything.
+type ExportFilter struct {
+	// Name matches a base name; cache rows also match "<name>.<tld>" targets.
+	Name      string
+	CheckType string
+	From      time.Time
+	To        time.Time
+}
+
+// ExportColumn describes an exported column and its SQLite type.
+type ExportColumn struct {
+	Name string
+	Type string
+}
+
+// ExportTable is a flattened dataset ready to be written to another format.
+// Timestamps are RFC3339 strings and availability is a status label.
+type ExportTable struct {
+	Name    string
+	Columns []ExportColumn
+	Rows    [][]any
+}
+
+// Export reads the requested tables from the store, applying the filter.
+func (s *Store) Export(ctx context.Context, tables []string, filter ExportFilter) ([]ExportTable, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	filter.Name = strings.ToLower(strings.TrimSpace(filter.Name))
+	filter.CheckType = strings.ToLower(strings.TrimSpace(filter.CheckType))
+
+	result := make([]ExportTable, 0, len(tables))
+	for _, table := range tables {
+		var (
+			exported ExportTable
+			err      error
+		)
+		switch table {
+		case ExportHistory:
+			exported, err = s.exportHistory(ctx, filter)
+		case ExportCache:
+			exported, err = s.exportCache(ctx, filter)
+		case ExportExpert:
+			exported, err = s.exportExpert(ctx, filter)
+		default:
+			return nil, fmt.Errorf("unknown export table %q (use %s)", table, strings.Join(ExportTables, ", "))
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, exported)
+	}
+
+	return result, nil
+}
+
+func (s *Store) exportHistory(ctx context.Context, filter ExportFilter) (ExportTable, error) {
+	table := ExportTable{
+		Name: ExportHistory,
+		Columns: []ExportColumn{
+			{"run_id", "TEXT"}, {"name", "TEXT"}, {"target", "TEXT"}, {"check_type", "TEXT"},
+			{"tld", "TEXT"}, {"status", "TEXT"}, {"status_code", "INTEGER"}, {"message", "TEXT"},
+			{"source", "TEXT"}, {"extra_data", "TEXT"}, {"checked_at", "TEXT"},
+		},
+		Rows: make([][]any, 0),
+	}
+
+	query := `
+		SELECT run_id, name, target, check_type, tld, available, status_code, message, source, extra_data, checked_at
+		FROM check_history
+		WHERE 1 = 1`
+	args := make([]any, 0)
+	if filter.Name != "" {
+		query += ` AND name = ?`
+		args = append(args, filter.Name)
+	}
+	query, args = appendExportFilters(query, args, filter, "checked_at", true)
+	query += ` ORDER BY checked_at ASC, id ASC`
+
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return table, fmt.Errorf("export history: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	for rows.Next() {
+		var (
+			runID, name, target, checkType string
+			tld, message, source, extra    sql.NullString
+			available                      int
+			statusCode                     sql.NullInt64
+			checkedAt                      int64
+		)
+		if err := rows.Scan(&runID, &name, &target, &checkType, &tld, &available, &statusCode, &message, &source, &extra, &checkedAt); err != nil {
+			return table, fmt.Errorf("scan history export: %w", err)
+		}
+		table.Rows = append(table.Rows, []any{
+			runID, name, target, checkType, tld.String, core.Availability(available).String(),
+			statusCode.Int64, message.String, source.String, extra.String, exportTime(checkedAt),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return table, fmt.Errorf("export history: %w", err)
+	}
+
+	return table, nil
+}
+
+func (s *Store) exportCache(ctx context.Context, filter ExportFilter) (ExportTable, error) {
+	table := ExportTable{
+		Name: ExportCache,
+		Columns: []ExportColumn{
+			{"target", "TEXT"}, {"check_type", "TEXT"}, {"tld", "TEXT"}, {"status", "TEXT"},
+			{"status_code", "INTEGER"}, {"message", "TEXT"}, {"extra_data", "TEXT"}, {"hits", "INTEGER"},
+			{"checked_at", "TEXT"}, {"expires_at", "TEXT"},
+		},
+		Rows: make([][]any, 0),
+	}
+
+	query := `
+		SELECT name, check_type, tld, available, status_code, message, extra_data, hits, checked_at, expires_at
+		FROM check_cache
+		WHERE 1 = 1`
+	args := make([]any, 0)
+	if filter.Name != "" {
+		query += ` AND (name = ? OR name LIKE ?)`
+		args = append(args, filter.Name, filter.Name+".%")
+	}
+	query, args = appendExportFilters(query, args, filter, "checked_at", true)
+	query += ` ORDER BY checked_at ASC, id ASC`
+
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return table, fmt.Errorf("export cache: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	for rows.Next() {
+		var (
+			target, checkType    string
+			tld, message, extra  sql.NullString
+			available            sql.NullInt64
+			statusCode           sql.NullInt64
+			hits                 int64
+			checkedAt, expiresAt int64
+		)
+		if err := rows.Scan(&target, &checkType, &tld, &available, &statusCode, &message, &extra, &hits, &checkedAt, &expiresAt); err != nil {
+			return table, fmt.Errorf("scan cache export: %w", err)
+		}
+		table.Rows = append(table.Rows, []any{
+			target, checkType, tld.String, core.Availability(available.Int64).String(), statusCode.Int64,
+			message.String, extra.String, hits, exportTime(checkedAt), exportTime(expiresAt),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return table, fmt.Errorf("export cache: %w", err)
+	}
+
+	return table, nil
+}
+
+func (s *Store) exportExpert(ctx context.Context, filter ExportFilter) (ExportTable, error) {
+	table := ExportTable{
+		Name: ExportExpert,
+		Columns: []ExportColumn{
+			{"name", "TEXT"}, {"prompt_slug", "TEXT"}, {"model", "TEXT"}, {"base_url", "TEXT"},
+			{"depth", "TEXT"}, {"response_json", "TEXT"}, {"created_at", "TEXT"}, {"expires_at", "TEXT"},
+		},
+		Rows: make([][]any, 0),
+	}
+
+	query := `
+		SELECT name, prompt_slug, model, base_url, depth, response_json, created_at, expires_at
+		FROM expert_cache
+		WHERE 1 = 1`
+	args := make([]any, 0)
+	if filter.Name != "" {
+		query += ` AND LOWER(name) = ?`
+		args = append(args, filter.Name)
+	}
+	// Expert analyses have no check type; the type filter does not apply.
+	query, args = appendExportFilters(query, args, filter, "created_at", false)
+	query += ` ORDER BY created_at ASC, id ASC`
+
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return table, fmt.Errorf("export expert analyses: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	for rows.Next() {
+		var (
+			name, slug, model, baseURL, depth, response string
+			createdAt, expiresAt                        int64
+		)
+		if err := rows.Scan(&name, &slug, &model, &baseURL, &depth, &response, &createdAt, &expiresAt); err != nil {
+			return table, fmt.Errorf("scan expert export: %w", err)
+		}
+		table.Rows = append(table.Rows, []any{
+			name, slug, model, baseURL, depth, response, exportTime(createdAt), exportTime(expiresAt),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return table, fmt.Errorf("export expert analyses: %w", err)
+	}
+
+	return table, nil
+}
+
+func appendExportFilters(query string, args []any, filter ExportFilter, timeColumn string, checkType bool) (string, []any) {
+	if checkType && filter.CheckType != "" {
+		query += ` AND check_type = ?`
+		args = append(args, filter.CheckType)
+	}
+	if !filter.From.IsZero() {
+		query += ` AND ` + timeColumn + ` >= ?`
+		args = append(args, filter.From.UTC().Unix())
+	}
+	if !filter.To.IsZero() {
+		query += ` AND ` + timeColumn + ` <= ?`
+		args = append(args, filter.To.UTC().Unix())
+	}
+	return query, args
+}
+
+func exportTime(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+// WriteExportSQLite writes exported tables into a new SQLite database at path.
+// The file must not already exist.
+func WriteExportSQLite(ctx context.Context, path string, tables []ExportTable) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("export target %s already exists", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("check export target: %w", err)
+	}
+
+	// One connection, so nothing else holds the WAL open when it is switched
+	// off below
+	out, err := Open(ctx, config.StoreConfig{Driver: driverLibsql, Path: path, Pool: config.StorePoolConfig{MaxOpenConns: 1}})
+	if err != nil {
+		return err
+	}
+	defer out.Close() // nolint:errcheck // best-effort cleanup
+
+	tx, err := out.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin export transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint:errcheck // no-op after commit
+
+	for _, table := range tables {
+		columns := make([]string, 0, len(table.Columns))
+		definitions := make([]string, 0, len(table.Columns))
+		placeholders := make([]string, 0, len(table.Columns))
+		for _, column := range table.Columns {
+			columns = append(columns, column.Name)
+			definitions = append(definitions, column.Name+" "+column.Type)
+			placeholders = append(placeholders, "?")
+		}
+
+		// #nosec G202 -- table and column names are fixed by ExportTables, not user input
+		if _, err := tx.ExecContext(ctx, `CREATE TABLE `+table.Name+` (`+strings.Join(definitions, ", ")+`)`); err != nil {
+			return fmt.Errorf("create export table %s: %w", table.Name, err)
+		}
+
+		insert := `INSERT INTO ` + table.Name + ` (` + strings.Join(columns, ", ") + `) VALUES (` + strings.Join(placeholders, ", ") + `)` // #nosec G202 -- fixed identifiers
+		for _, row := range table.Rows {
+			if _, err := tx.ExecContext(ctx, insert, row...); err != nil {
+				return fmt.Errorf("write export table %s: %w", table.Name, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit export: %w", err)
+	}
+
+	// Leave a single self-contained file rather than a WAL database.
+	var mode string
+	_ = out.DB.QueryRowContext(ctx, "PRAGMA journal_mode=DELETE").Scan(&mode)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestExportFiltersAndWritesSQLite(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	_, err = store.RecordHistory(ctx, "acme", []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+		{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+	})
+	require.NoError(t, err)
+	_, err = store.RecordHistory(ctx, "other", []*core.CheckResult{
+		{Name: "other.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.SetCachedResult(ctx, "acme.com", &core.CheckResult{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}, time.Hour))
+	require.NoError(t, store.SetCachedResult(ctx, "other.com", &core.CheckResult{Name: "other.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable}, time.Hour))
+	require.NoError(t, store.SetExpertCache(ctx, "acme", "name-availability", "m", "https://api", "quick", `{"summary":"ok"}`, time.Hour))
+
+	tables, err := store.Export(ctx, ExportTables, ExportFilter{Name: "ACME", CheckType: "domain"})
+	require.NoError(t, err)
+	require.Len(t, tables, 3)
+
+	history := tables[0]
+	require.Equal(t, ExportHistory, history.Name)
+	require.Len(t, history.Rows, 1)
+	require.Equal(t, "acme.com", history.Rows[0][2])
+	require.Equal(t, "taken", history.Rows[0][5])
+
+	cache := tables[1]
+	require.Len(t, cache.Rows, 1)
+	require.Equal(t, "acme.com", cache.Rows[0][0])
+
+	expert := tables[2]
+	require.Len(t, expert.Rows, 1, "type filter does not apply to expert analyses")
+
+	future, err := store.Export(ctx, []string{ExportHistory}, ExportFilter{From: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Empty(t, future[0].Rows)
+
+	_, err = store.Export(ctx, []string{"profiles"}, ExportFilter{})
+	require.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "export.db")
+	require.NoError(t, WriteExportSQLite(ctx, path, tables))
+	require.Error(t, WriteExportSQLite(ctx, path, tables), "existing files are not overwritten")
+	_, err = os.Stat(path + "-wal")
+	require.True(t, os.IsNotExist(err), "export leaves a single file")
+
+	out, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: path})
+	require.NoError(t, err)
+	defer out.Close() // nolint:errcheck // test cleanup
+
+	var status string
+	require.NoError(t, out.DB.QueryRowContext(ctx, `SELECT status FROM history WHERE target = 'acme.com'`).Scan(&status))
+	require.Equal(t, "taken", status)
+
+	var count int
+	require.NoError(t, out.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM expert`).Scan(&count))
+	require.Equal(t, 1, count)
+}
@@ -0,0 +1,255 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// HistoryEntry is a check result recorded as part of a run.
+type HistoryEntry struct {
+	RunID     string
+	Name      string
+	CheckedAt time.Time
+	Result    *core.CheckResult
+}
+
+// HistoryRun summarizes a single recorded check run for a name.
+type HistoryRun struct {
+	RunID     string    `json:"run_id"`
+	Name      string    `json:"name"`
+	CheckedAt time.Time `json:"checked_at"`
+	Results   int       `json:"results"`
+}
+
+// RecordHistory appends results for a base name as a new run and returns the run ID.
+func (s *Store) RecordHistory(ctx context.Context, name string, results []*core.CheckResult) (string, error) {
+	if s == nil || s.DB == nil {
+		return "", errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	keyName := strings.ToLower(strings.TrimSpace(name))
+	if keyName == "" {
+		return "", errors.New("history name is required")
+	}
+	if len(results) == 0 {
+		return "", nil
+	}
+
+	runID := uuid.New().String()
+	checkedAt := time.Now().UTC().Unix()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("begin history transaction: %w", err)
+	}
+	defer tx.Rollback() // nolint:errcheck // no-op after commit
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		extraJSON, err := json.Marshal(result.ExtraData)
+		if err != nil {
+			return "", fmt.Errorf("encode history result: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, s.rebind(`
+			INSERT INTO check_history (run_id, name, target, check_type, tld, available, status_code, extra_data, message, source, checked_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`), runID, keyName, result.Name, string(result.CheckType), normalizeTLD(result.TLD), int(result.Available), result.StatusCode, string(extraJSON), result.Message, result.Provenance.Source, checkedAt)
+		if err != nil {
+			return "", fmt.Errorf("store history result: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("commit history: %w", err)
+	}
+
+	return runID, nil
+}
+
+// ListHistory returns recorded results for a name in chronological order.
+// Zero from/to values leave that end of the range open.
+func (s *Store) ListHistory(ctx context.Context, name string, from, to time.Time) ([]HistoryEntry, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := `
+		SELECT run_id, name, target, check_type, tld, available, status_code, extra_data, message, source, checked_at
+		FROM check_history
+		WHERE name = ?`
+	args := []any{strings.ToLower(strings.TrimSpace(name))}
+	if !from.IsZero() {
+		query += ` AND checked_at >= ?`
+		args = append(args, from.UTC().Unix())
+	}
+	if !to.IsZero() {
+		query += ` AND checked_at <= ?`
+		args = append(args, to.UTC().Unix())
+	}
+	query += ` ORDER BY checked_at ASC, id ASC`
+
+	return s.queryHistory(ctx, query, args...)
+}
+
+// GetHistoryRun returns the results recorded for a run ID.
+func (s *Store) GetHistoryRun(ctx context.Context, runID string) ([]HistoryEntry, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return s.queryHistory(ctx, `
+		SELECT run_id, name, target, check_type, tld, available, status_code, extra_data, message, source, checked_at
+		FROM check_history
+		WHERE run_id = ?
+		ORDER BY id ASC
+	`, strings.TrimSpace(runID))
+}
+
+// ListHistoryRuns returns the recorded runs for a name, newest first.
+func (s *Store) ListHistoryRuns(ctx context.Context, name string) ([]HistoryRun, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	rows, err := s.queryContext(ctx, `
+		SELECT run_id, name, MAX(checked_at), COUNT(*)
+		FROM check_history
+		WHERE name = ?
+		GROUP BY run_id, name
+		ORDER BY MAX(checked_at) DESC, MAX(id) DESC
+	`, strings.ToLower(strings.TrimSpace(name)))
+	if err != nil {
+		return nil, fmt.Errorf("list history runs: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	runs := make([]HistoryRun, 0)
+	for rows.Next() {
+		var (
+			run       HistoryRun
+			checkedAt int64
+		)
+		if err := rows.Scan(&run.RunID, &run.Name, &checkedAt, &run.Results); err != nil {
+			return nil, fmt.Errorf("scan history run: %w", err)
+		}
+		run.CheckedAt = time.Unix(checkedAt, 0).UTC()
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list history runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// HistorySnapshot returns the latest recorded result per target for a name as of the given time.
+func (s *Store) HistorySnapshot(ctx context.Context, name string, at time.Time) ([]HistoryEntry, error) {
+	entries, err := s.ListHistory(ctx, name, time.Time{}, at)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int)
+	snapshot := make([]HistoryEntry, 0)
+	for _, entry := range entries {
+		key := HistoryKey(entry.Result)
+		if pos, ok := index[key]; ok {
+			snapshot[pos] = entry
+			continue
+		}
+		index[key] = len(snapshot)
+		snapshot = append(snapshot, entry)
+	}
+
+	return snapshot, nil
+}
+
+// HistoryKey identifies the checked target of a result across runs.
+func HistoryKey(result *core.CheckResult) string {
+	if result == nil {
+		return ""
+	}
+	return string(result.CheckType) + ":" + strings.ToLower(strings.TrimSpace(result.Name))
+}
+
+func (s *Store) queryHistory(ctx context.Context, query string, args ...any) ([]HistoryEntry, error) {
+	rows, err := s.queryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query history: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	entries := make([]HistoryEntry, 0)
+	for rows.Next() {
+		var (
+			entry      HistoryEntry
+			target     string
+			checkType  string
+			tld        sql.NullString
+			available  int
+			statusCode sql.NullInt64
+			extraJSON  sql.NullString
+			message    sql.NullString
+			source     sql.NullString
+			checkedAt  int64
+		)
+		if err := rows.Scan(&entry.RunID, &entry.Name, &target, &checkType, &tld, &available, &statusCode, &extraJSON, &message, &source, &checkedAt); err != nil {
+			return nil, fmt.Errorf("scan history: %w", err)
+		}
+
+		var extra map[string]any
+		if extraJSON.Valid && extraJSON.String != "" && extraJSON.String != "null" {
+			if err := json.Unmarshal([]byte(extraJSON.String), &extra); err != nil {
+				return nil, fmt.Errorf("decode history result: %w", err)
+			}
+		}
+
+		entry.CheckedAt = time.Unix(checkedAt, 0).UTC()
+		entry.Result = &core.CheckResult{
+			Name:       target,
+			CheckType:  core.CheckType(checkType),
+			TLD:        tld.String,
+			Available:  core.Availability(available),
+			StatusCode: int(statusCode.Int64),
+			Message:    message.String,
+			ExtraData:  extra,
+			Provenance: core.Provenance{
+				ResolvedAt: entry.CheckedAt,
+				Source:     source.String,
+			},
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query history: %w", err)
+	}
+
+	return entries, nil
+}
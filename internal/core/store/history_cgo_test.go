@@ -0,0 +1,56 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestHistoryRecordAndSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	first, err := store.RecordHistory(ctx, "Acme", []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable},
+		{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken, ExtraData: map[string]any{"latest_version": "1.0.0"}},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, first)
+
+	second, err := store.RecordHistory(ctx, "acme", []*core.CheckResult{
+		{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+
+	runs, err := store.ListHistoryRuns(ctx, "acme")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, second, runs[0].RunID)
+	require.Equal(t, 1, runs[0].Results)
+
+	entries, err := store.GetHistoryRun(ctx, first)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "1.0.0", entries[1].Result.ExtraData["latest_version"])
+
+	snapshot, err := store.HistorySnapshot(ctx, "acme", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, snapshot, 2)
+	require.Equal(t, core.AvailabilityTaken, snapshot[0].Result.Available)
+	require.Equal(t, second, snapshot[0].RunID)
+
+	empty, err := store.HistorySnapshot(ctx, "acme", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
@@ -0,0 +1,216 @@
+package store
+
+import (
+	"container/list"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/metrics"
+)
+
+const defaultMemoryCacheTTL = time.Minute
+
+// memoryCache is an in-process LRU in front of the check_cache table, so hot
+// names skip SQL under bursty server traffic. An entry lives until the cached
+// result expires or ttl passes, whichever is first; ttl bounds how long
+// writes by other processes (such as 'namelens cache purge') go unseen.
+type memoryCache struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+
+	// generations records when each key was last invalidated, so a read
+	// that started before a write cannot put the old row back. Keys without
+	// an entry are at floor.
+	generations map[string]uint64
+	floor       uint64
+	seq         uint64
+}
+
+type memoryEntry struct {
+	key     string
+	result  *core.CheckResult
+	expires time.Time
+}
+
+func newMemoryCache(size int, ttl time.Duration) *memoryCache {
+	if ttl <= 0 {
+		ttl = defaultMemoryCacheTTL
+	}
+	return &memoryCache{
+		size:        size,
+		ttl:         ttl,
+		now:         time.Now,
+		order:       list.New(),
+		entries:     make(map[string]*list.Element),
+		generations: make(map[string]uint64),
+	}
+}
+
+// EnableMemoryCache puts an LRU of up to size check results in front of
+// GetCachedResult; size 0 disables it. Memory hits do not count toward the
+// hits column read by 'namelens cache stats'; they are reported by the
+// cache_memory_lookups_total metric instead. Call it before the store is
+// shared.
+func (s *Store) EnableMemoryCache(size int, ttl time.Duration) {
+	if s == nil {
+		return
+	}
+	if size <= 0 {
+		s.memory = nil
+		return
+	}
+	s.memory = newMemoryCache(size, ttl)
+}
+
+func memoryCacheKey(name string, checkType core.CheckType, tld string) string {
+	return strings.Join([]string{string(checkType), normalizeTLD(tld), strings.TrimSpace(name)}, "|")
+}
+
+// get returns a copy of the cached result for key, or nil.
+func (c *memoryCache) get(key string) *core.CheckResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		metrics.RecordMemoryCacheLookup(false)
+		return nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if !c.now().Before(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		metrics.RecordMemoryCacheLookup(false)
+		return nil
+	}
+	c.order.MoveToFront(element)
+	metrics.RecordMemoryCacheLookup(true)
+	return cloneCheckResult(entry.result)
+}
+
+// generation returns the current generation of key. Take it before reading
+// the row that is later passed to put.
+func (c *memoryCache) generation(key string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation, ok := c.generations[key]; ok {
+		return generation
+	}
+	return c.floor
+}
+
+// put stores a copy of result, evicting the least recently used entry when
+// the cache is full. It does nothing if key was invalidated since generation
+// was taken, because result may be older than the store's row.
+func (c *memoryCache) put(key string, result *core.CheckResult, generation uint64) {
+	expires := c.now().Add(c.ttl)
+	if at := result.Provenance.CacheExpiresAt; at != nil && at.Before(expires) {
+		expires = *at
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, ok := c.generations[key]
+	if !ok {
+		current = c.floor
+	}
+	if current != generation {
+		return
+	}
+
+	entry := &memoryEntry{key: key, result: cloneCheckResult(result), expires: expires}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+// remove drops key and invalidates reads of it that are still in flight.
+func (c *memoryCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.order.Remove(element)
+		delete(c.entries, key)
+	}
+	c.seq++
+	if len(c.generations) >= c.size {
+		// Forget per-key generations rather than grow without bound; raising
+		// the floor still invalidates every read in flight.
+		clear(c.generations)
+		c.floor = c.seq
+		return
+	}
+	c.generations[key] = c.seq
+}
+
+// clear drops every entry and invalidates every read in flight.
+func (c *memoryCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	clear(c.entries)
+	c.seq++
+	clear(c.generations)
+	c.floor = c.seq
+}
+
+// cloneCheckResult copies the parts of a cached result that callers may
+// modify, including values nested in ExtraData, so they never change the
+// cached copy.
+func cloneCheckResult(result *core.CheckResult) *core.CheckResult {
+	clone := *result
+	if result.ExtraData != nil {
+		clone.ExtraData = cloneExtraValue(result.ExtraData).(map[string]any)
+	}
+	if result.Provenance.CacheExpiresAt != nil {
+		expires := *result.Provenance.CacheExpiresAt
+		clone.Provenance.CacheExpiresAt = &expires
+	}
+	return &clone
+}
+
+// cloneExtraValue deep-copies the maps and slices ExtraData holds, such as
+// decoded JSON objects and arrays; other values are immutable and shared.
+func cloneExtraValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		clone := make(map[string]any, len(v))
+		for key, item := range v {
+			clone[key] = cloneExtraValue(item)
+		}
+		return clone
+	case []any:
+		clone := make([]any, len(v))
+		for i, item := range v {
+			clone[i] = cloneExtraValue(item)
+		}
+		return clone
+	case map[string]string:
+		return maps.Clone(v)
+	case []string:
+		return slices.Clone(v)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,101 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newMemoryCache(2, time.Minute)
+	for _, name := range []string{"alpha", "beta"} {
+		cache.put(name, &core.CheckResult{Name: name}, 0)
+	}
+
+	// Touch alpha so beta is the least recently used
+	require.NotNil(t, cache.get("alpha"))
+	cache.put("gamma", &core.CheckResult{Name: "gamma"}, 0)
+
+	require.Nil(t, cache.get("beta"))
+	require.NotNil(t, cache.get("alpha"))
+	require.NotNil(t, cache.get("gamma"))
+
+	cache.remove("alpha")
+	require.Nil(t, cache.get("alpha"))
+	cache.clear()
+	require.Nil(t, cache.get("gamma"))
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := newMemoryCache(10, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	// The result expires before the memory TTL runs out
+	expires := now.Add(30 * time.Second)
+	cache.put("short", &core.CheckResult{Name: "short", Provenance: core.Provenance{CacheExpiresAt: &expires}}, 0)
+	cache.put("long", &core.CheckResult{Name: "long"}, 0)
+
+	now = now.Add(45 * time.Second)
+	require.Nil(t, cache.get("short"))
+	require.NotNil(t, cache.get("long"))
+
+	now = now.Add(15 * time.Second)
+	require.Nil(t, cache.get("long"))
+}
+
+func TestMemoryCacheReturnsCopies(t *testing.T) {
+	cache := newMemoryCache(10, time.Minute)
+	cache.put("acme", &core.CheckResult{Name: "acme", ExtraData: map[string]any{
+		"server":      "rdap.example",
+		"maintainers": []any{"alice"},
+		"drop_window": map[string]any{"phase": "pending_delete"},
+	}}, 0)
+
+	first := cache.get("acme")
+	first.ExtraData["server"] = "changed"
+	first.ExtraData["maintainers"].([]any)[0] = "mallory"
+	first.ExtraData["drop_window"].(map[string]any)["phase"] = "dropped"
+	first.Provenance.CacheStale = true
+
+	second := cache.get("acme")
+	require.Equal(t, "rdap.example", second.ExtraData["server"])
+	require.Equal(t, []any{"alice"}, second.ExtraData["maintainers"])
+	require.Equal(t, "pending_delete", second.ExtraData["drop_window"].(map[string]any)["phase"])
+	require.False(t, second.Provenance.CacheStale)
+}
+
+func TestMemoryCacheSkipsPutAfterInvalidation(t *testing.T) {
+	cache := newMemoryCache(10, time.Minute)
+
+	// A read takes the generation, then a write invalidates the key before
+	// the read's row is put
+	generation := cache.generation("acme")
+	cache.remove("acme")
+	cache.put("acme", &core.CheckResult{Name: "old"}, generation)
+	require.Nil(t, cache.get("acme"))
+
+	cache.put("acme", &core.CheckResult{Name: "new"}, cache.generation("acme"))
+	require.Equal(t, "new", cache.get("acme").Name)
+
+	// Clearing invalidates reads in flight too
+	generation = cache.generation("beta")
+	cache.clear()
+	cache.put("beta", &core.CheckResult{Name: "old"}, generation)
+	require.Nil(t, cache.get("beta"))
+}
+
+func TestMemoryCacheBoundsGenerations(t *testing.T) {
+	cache := newMemoryCache(2, time.Minute)
+	generation := cache.generation("acme")
+	for _, key := range []string{"acme", "beta", "gamma", "delta"} {
+		cache.remove(key)
+	}
+	require.LessOrEqual(t, len(cache.generations), 2)
+
+	cache.put("acme", &core.CheckResult{Name: "old"}, generation)
+	require.Nil(t, cache.get("acme"))
+}
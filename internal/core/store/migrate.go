@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var schemaStatements = []string{
@@ -17,6 +18,15 @@ var schemaStatements = []string{
 		key TEXT PRIMARY KEY,
 		value TEXT NOT NULL
 	);`,
+	`CREATE TABLE IF NOT EXISTS bootstrap_snapshots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		version TEXT,
+		publication TEXT,
+		source TEXT,
+		fetched_at INTEGER NOT NULL,
+		tld_count INTEGER NOT NULL,
+		servers TEXT NOT NULL
+	);`,
 	`CREATE TABLE IF NOT EXISTS check_cache (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
 		name TEXT NOT NULL,
@@ -58,8 +68,75 @@ var schemaStatements = []string{
 		UNIQUE(name, prompt_slug, model, base_url, depth)
 	);`,
 	`CREATE INDEX IF NOT EXISTS idx_expert_cache_expires ON expert_cache(expires_at);`,
+	`CREATE TABLE IF NOT EXISTS check_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		run_id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		target TEXT NOT NULL,
+		check_type TEXT NOT NULL,
+		tld TEXT,
+		available INTEGER,
+		status_code INTEGER,
+		extra_data TEXT,
+		message TEXT,
+		source TEXT,
+		checked_at INTEGER NOT NULL
+	);`,
+	`CREATE INDEX IF NOT EXISTS idx_check_history_name ON check_history(name, checked_at);`,
+	`CREATE INDEX IF NOT EXISTS idx_check_history_run ON check_history(run_id);`,
+	`CREATE TABLE IF NOT EXISTS ai_usage (
+		subject TEXT NOT NULL,
+		period TEXT NOT NULL,
+		calls INTEGER NOT NULL DEFAULT 0,
+		updated_at INTEGER NOT NULL,
+		PRIMARY KEY(subject, period)
+	);`,
+	`CREATE TABLE IF NOT EXISTS ai_spend (
+		day TEXT PRIMARY KEY,
+		calls INTEGER NOT NULL DEFAULT 0,
+		cost_usd REAL NOT NULL DEFAULT 0,
+		updated_at INTEGER NOT NULL
+	);`,
+	`CREATE TABLE IF NOT EXISTS portfolio (
+		domain TEXT PRIMARY KEY,
+		relation TEXT NOT NULL,
+		note TEXT,
+		added_at INTEGER NOT NULL
+	);`,
+	`CREATE TABLE IF NOT EXISTS shares (
+		id TEXT PRIMARY KEY,
+		kind TEXT NOT NULL,
+		title TEXT,
+		payload TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		expires_at INTEGER NOT NULL
+	);`,
+	`CREATE INDEX IF NOT EXISTS idx_shares_expires ON shares(expires_at);`,
+	`CREATE TABLE IF NOT EXISTS api_keys (
+		name TEXT PRIMARY KEY,
+		key_hash TEXT NOT NULL UNIQUE,
+		role TEXT NOT NULL,
+		rate_limit INTEGER NOT NULL DEFAULT 0,
+		daily_quota INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL,
+		revoked_at INTEGER
+	);`,
+	`CREATE TABLE IF NOT EXISTS api_key_usage (
+		name TEXT NOT NULL,
+		day TEXT NOT NULL,
+		requests INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY(name, day)
+	);`,
 }
 
+// postgresDDL adapts the SQLite schema for Postgres. Integer columns widen to
+// BIGINT because most of them hold Unix timestamps.
+var postgresDDL = strings.NewReplacer(
+	"INTEGER PRIMARY KEY AUTOINCREMENT", "BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY",
+	"INTEGER", "BIGINT",
+	"REAL", "DOUBLE PRECISION",
+)
+
 // Migrate ensures the required database tables exist.
 func (s *Store) Migrate(ctx context.Context) error {
 	if s == nil || s.DB == nil {
@@ -71,7 +148,10 @@ func (s *Store) Migrate(ctx context.Context) error {
 	}
 
 	for _, stmt := range schemaStatements {
-		if _, err := s.DB.ExecContext(ctx, stmt); err != nil {
+		if s.driver == driverPostgres {
+			stmt = postgresDDL.Replace(stmt)
+		}
+		if _, err := s.execContext(ctx, stmt); err != nil {
 			return fmt.Errorf("store migration failed: %w", err)
 		}
 	}
@@ -79,12 +159,26 @@ func (s *Store) Migrate(ctx context.Context) error {
 	if err := s.ensureColumn(ctx, "check_cache", "message", "TEXT"); err != nil {
 		return err
 	}
+	if err := s.ensureColumn(ctx, "check_cache", "hits", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := s.ensureColumn(ctx, "portfolio", "notify_before_minutes", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func (s *Store) ensureColumn(ctx context.Context, table, column, columnDef string) error {
-	rows, err := s.DB.QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if s.driver == driverPostgres {
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", table, column, postgresDDL.Replace(columnDef))
+		if _, err := s.execContext(ctx, stmt); err != nil {
+			return fmt.Errorf("add %s.%s column: %w", table, column, err)
+		}
+		return nil
+	}
+
+	rows, err := s.queryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", table))
 	if err != nil {
 		return fmt.Errorf("inspect %s schema: %w", table, err)
 	}
@@ -110,7 +204,7 @@ func (s *Store) ensureColumn(ctx context.Context, table, column, columnDef strin
 		return fmt.Errorf("inspect %s columns: %w", table, err)
 	}
 
-	if _, err := s.DB.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, columnDef)); err != nil {
+	if _, err := s.execContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, columnDef)); err != nil {
 		return fmt.Errorf("add %s.%s column: %w", table, column, err)
 	}
 
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/namelens/namelens/internal/config"
+)
+
+// Pool defaults, picked with BenchmarkCacheParallel against a local file:
+// WAL lets readers run beside the single writer, and past four connections
+// writers only queue longer on the file lock.
+const (
+	DefaultMaxOpenConns    = 4
+	DefaultMaxIdleConns    = 4
+	DefaultConnMaxIdleTime = 5 * time.Minute
+	DefaultBusyTimeout     = 5 * time.Second
+)
+
+// configurePool applies the pool settings of cfg to db, filling in defaults
+// for zero values.
+func configurePool(db *sql.DB, cfg config.StorePoolConfig) {
+	maxOpen := cfg.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = DefaultMaxOpenConns
+	}
+	maxIdle := cfg.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = DefaultMaxIdleConns
+	}
+	if maxIdle > maxOpen {
+		maxIdle = maxOpen
+	}
+	idleTime := cfg.ConnMaxIdleTime
+	if idleTime <= 0 {
+		idleTime = DefaultConnMaxIdleTime
+	}
+
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxIdleTime(idleTime)
+}
+
+// localPragmas are run on every new connection to a local SQLite file, since
+// busy_timeout and synchronous are per-connection settings.
+func localPragmas(cfg config.StoreConfig) []string {
+	busyTimeout := cfg.BusyTimeout
+	if busyTimeout <= 0 {
+		busyTimeout = DefaultBusyTimeout
+	}
+	return []string{
+		"PRAGMA busy_timeout=" + strconv.FormatInt(busyTimeout.Milliseconds(), 10),
+		// NORMAL is durable in WAL mode except across power loss, and skips
+		// an fsync per commit
+		"PRAGMA synchronous=NORMAL",
+	}
+}
+
+// openLibsqlWithPragmas opens dsn through a connector that runs pragmas on each
+// new connection.
+func openLibsqlWithPragmas(dsn string, pragmas []string) (*sql.DB, error) {
+	// go-libsql does not export its driver; borrow it from a throwaway handle
+	probe, err := sql.Open(driverLibsql, ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	drv := probe.Driver()
+	_ = probe.Close()
+
+	driverCtx, ok := drv.(driver.DriverContext)
+	if !ok {
+		return nil, errors.New("libsql driver does not support connectors")
+	}
+	connector, err := driverCtx.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(&pragmaConnector{Connector: connector, pragmas: pragmas}), nil
+}
+
+// pragmaConnector runs pragmas on every connection it opens.
+type pragmaConnector struct {
+	driver.Connector
+	pragmas []string
+}
+
+func (c *pragmaConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	queryer, ok := conn.(driver.QueryerContext)
+	if !ok {
+		_ = conn.Close()
+		return nil, errors.New("libsql connection does not support queries")
+	}
+	for _, pragma := range c.pragmas {
+		// Pragmas that set a value also return it, so they are queries
+		rows, err := queryer.QueryContext(ctx, pragma, nil)
+		if err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("%s: %w", pragma, err)
+		}
+		_ = rows.Close()
+	}
+	return conn, nil
+}
+
+// Close releases the underlying database; sql.DB.Close calls it.
+func (c *pragmaConnector) Close() error {
+	if closer, ok := c.Connector.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
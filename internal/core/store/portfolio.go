@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Portfolio relations: domains the team has registered and domains it is
+// watching for a chance to acquire.
+const (
+	PortfolioOwned   = "owned"
+	PortfolioWatched = "watched"
+)
+
+// PortfolioDomain is a domain tracked in the portfolio.
+type PortfolioDomain struct {
+	Domain   string
+	Relation string
+	Note     string
+	// NotifyBefore is how long before an expiration or expected drop to be
+	// reminded; zero uses the calendar default.
+	NotifyBefore time.Duration
+	AddedAt      time.Time
+}
+
+// AddPortfolioDomain adds a domain to the portfolio, updating its relation
+// and note when it is already tracked.
+func (s *Store) AddPortfolioDomain(ctx context.Context, entry PortfolioDomain) error {
+	if s == nil || s.DB == nil {
+		return errors.New("store is not initialized")
+	}
+
+	domain := strings.ToLower(strings.TrimSpace(entry.Domain))
+	if domain == "" {
+		return errors.New("domain is required")
+	}
+	relation := strings.TrimSpace(entry.Relation)
+	if relation == "" {
+		relation = PortfolioWatched
+	}
+	if relation != PortfolioOwned && relation != PortfolioWatched {
+		return fmt.Errorf("invalid portfolio relation %q", relation)
+	}
+	if entry.NotifyBefore < 0 {
+		return errors.New("notify lead time must not be negative")
+	}
+	addedAt := entry.AddedAt
+	if addedAt.IsZero() {
+		addedAt = time.Now()
+	}
+
+	_, err := s.execContext(ctx, `
+		INSERT INTO portfolio (domain, relation, note, notify_before_minutes, added_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(domain) DO UPDATE SET
+			relation = excluded.relation,
+			note = excluded.note,
+			notify_before_minutes = excluded.notify_before_minutes
+	`, domain, relation, strings.TrimSpace(entry.Note), int64(entry.NotifyBefore/time.Minute), addedAt.UTC().Unix())
+	if err != nil {
+		return fmt.Errorf("add portfolio domain: %w", err)
+	}
+	return nil
+}
+
+// RemovePortfolioDomain removes a domain from the portfolio, reporting
+// whether it was tracked.
+func (s *Store) RemovePortfolioDomain(ctx context.Context, domain string) (bool, error) {
+	if s == nil || s.DB == nil {
+		return false, errors.New("store is not initialized")
+	}
+
+	result, err := s.execContext(ctx, `DELETE FROM portfolio WHERE domain = ?`, strings.ToLower(strings.TrimSpace(domain)))
+	if err != nil {
+		return false, fmt.Errorf("remove portfolio domain: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("remove portfolio domain: %w", err)
+	}
+	return affected > 0, nil
+}
+
+// ListPortfolio returns the tracked domains ordered by domain.
+func (s *Store) ListPortfolio(ctx context.Context) ([]PortfolioDomain, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	rows, err := s.queryContext(ctx, `
+		SELECT domain, relation, note, notify_before_minutes, added_at
+		FROM portfolio
+		ORDER BY domain
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list portfolio: %w", err)
+	}
+	defer rows.Close() // nolint:errcheck // best-effort cleanup on SQL rows
+
+	var entries []PortfolioDomain
+	for rows.Next() {
+		var (
+			entry         PortfolioDomain
+			note          sql.NullString
+			notifyMinutes int64
+			addedAt       int64
+		)
+		if err := rows.Scan(&entry.Domain, &entry.Relation, &note, &notifyMinutes, &addedAt); err != nil {
+			return nil, fmt.Errorf("scan portfolio: %w", err)
+		}
+		entry.Note = note.String
+		entry.NotifyBefore = time.Duration(notifyMinutes) * time.Minute
+		entry.AddedAt = time.Unix(addedAt, 0).UTC()
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list portfolio: %w", err)
+	}
+	return entries, nil
+}
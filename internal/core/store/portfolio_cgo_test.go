@@ -0,0 +1,48 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+)
+
+func TestPortfolio(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	require.NoError(t, store.AddPortfolioDomain(ctx, PortfolioDomain{Domain: "Acme.com", Relation: PortfolioOwned}))
+	require.NoError(t, store.AddPortfolioDomain(ctx, PortfolioDomain{Domain: "acme.io", Note: "wanted", NotifyBefore: 36 * time.Hour}))
+	require.Error(t, store.AddPortfolioDomain(ctx, PortfolioDomain{Domain: "acme.dev", Relation: "rented"}))
+
+	entries, err := store.ListPortfolio(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "acme.com", entries[0].Domain)
+	require.Equal(t, PortfolioOwned, entries[0].Relation)
+	require.Equal(t, PortfolioWatched, entries[1].Relation)
+	require.Equal(t, "wanted", entries[1].Note)
+	require.Equal(t, 36*time.Hour, entries[1].NotifyBefore)
+
+	// Re-adding updates the relation in place.
+	require.NoError(t, store.AddPortfolioDomain(ctx, PortfolioDomain{Domain: "acme.io", Relation: PortfolioOwned}))
+	entries, err = store.ListPortfolio(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, PortfolioOwned, entries[1].Relation)
+
+	removed, err := store.RemovePortfolioDomain(ctx, "ACME.COM")
+	require.NoError(t, err)
+	require.True(t, removed)
+	removed, err = store.RemovePortfolioDomain(ctx, "acme.com")
+	require.NoError(t, err)
+	require.False(t, removed)
+}
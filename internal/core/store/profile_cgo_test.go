@@ -51,3 +51,36 @@ func TestProfileCRUD(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, profiles)
 }
+
+func TestDeleteProfile(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	require.NoError(t, store.SeedBuiltInProfiles(ctx))
+	custom := core.Profile{Name: "fintech", TLDs: []string{"com", "money"}, ExpertDepth: "deep"}
+	require.NoError(t, store.UpsertProfile(ctx, custom, false, time.Now().UTC()))
+
+	record, err := store.GetProfile(ctx, "fintech")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	require.Equal(t, "deep", record.Profile.ExpertDepth)
+
+	deleted, err := store.DeleteProfile(ctx, "fintech")
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	record, err = store.GetProfile(ctx, "fintech")
+	require.NoError(t, err)
+	require.Nil(t, record)
+
+	deleted, err = store.DeleteProfile(ctx, "startup")
+	require.NoError(t, err)
+	require.False(t, deleted, "builtin profiles are never deleted")
+
+	builtin, err := store.GetProfile(ctx, "startup")
+	require.NoError(t, err)
+	require.NotNil(t, builtin)
+}
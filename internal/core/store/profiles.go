@@ -53,7 +53,7 @@ func (s *Store) UpsertProfile(ctx context.Context, profile core.Profile, isBuilt
 		builtinValue = 1
 	}
 
-	_, err = s.DB.ExecContext(ctx, `
+	_, err = s.execContext(ctx, `
 		INSERT INTO profiles (name, config, is_builtin, updated_at)
 		VALUES (?, ?, ?, ?)
 		ON CONFLICT(name) DO UPDATE SET
@@ -89,7 +89,7 @@ func (s *Store) GetProfile(ctx context.Context, name string) (*core.ProfileRecor
 		updatedAt  sql.NullInt64
 	)
 
-	row := s.DB.QueryRowContext(ctx, `
+	row := s.queryRowContext(ctx, `
 		SELECT config, is_builtin, updated_at
 		FROM profiles
 		WHERE name = ?
@@ -121,6 +121,38 @@ func (s *Store) GetProfile(ctx context.Context, name string) (*core.ProfileRecor
 	return record, nil
 }
 
+// DeleteProfile removes a custom profile. It reports whether a profile was
+// deleted; built-in profiles are never removed.
+func (s *Store) DeleteProfile(ctx context.Context, name string) (bool, error) {
+	if s == nil || s.DB == nil {
+		return false, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false, errors.New("profile name is required")
+	}
+
+	result, err := s.execContext(ctx, `
+		DELETE FROM profiles
+		WHERE name = ? AND is_builtin = 0
+	`, name)
+	if err != nil {
+		return false, fmt.Errorf("delete profile: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("delete profile: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
 // ListProfiles returns all profiles ordered by name.
 func (s *Store) ListProfiles(ctx context.Context) ([]core.ProfileRecord, error) {
 	if s == nil || s.DB == nil {
@@ -131,7 +163,7 @@ func (s *Store) ListProfiles(ctx context.Context) ([]core.ProfileRecord, error)
 		ctx = context.Background()
 	}
 
-	rows, err := s.DB.QueryContext(ctx, `
+	rows, err := s.queryContext(ctx, `
 		SELECT name, config, is_builtin, updated_at
 		FROM profiles
 		ORDER BY name
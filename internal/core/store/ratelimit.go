@@ -33,7 +33,7 @@ func (s *Store) GetRateLimit(ctx context.Context, endpoint string) (*core.RateLi
 		last429At    sql.NullInt64
 	)
 
-	row := s.DB.QueryRowContext(ctx, `
+	row := s.queryRowContext(ctx, `
 		SELECT request_count, window_start, backoff_until, last_429_at
 		FROM rate_limits
 		WHERE endpoint = ?
@@ -91,7 +91,7 @@ func (s *Store) UpdateRateLimit(ctx context.Context, endpoint string, state *cor
 		last429At = sql.NullInt64{Int64: state.Last429At.UTC().Unix(), Valid: true}
 	}
 
-	_, err := s.DB.ExecContext(ctx, `
+	_, err := s.execContext(ctx, `
 		INSERT INTO rate_limits (endpoint, request_count, window_start, backoff_until, last_429_at)
 		VALUES (?, ?, ?, ?, ?)
 		ON CONFLICT(endpoint) DO UPDATE SET
@@ -65,7 +65,7 @@ func (s *Store) ListRateLimits(ctx context.Context, q RateLimitQuery) ([]RateLim
 		return nil, err
 	}
 
-	rows, err := s.DB.QueryContext(ctx, fmt.Sprintf(`
+	rows, err := s.queryContext(ctx, fmt.Sprintf(`
 		SELECT endpoint, request_count, window_start, backoff_until, last_429_at
 		FROM rate_limits
 		%s
@@ -124,7 +124,7 @@ func (s *Store) CountRateLimits(ctx context.Context, q RateLimitQuery) (int, err
 		return 0, err
 	}
 
-	row := s.DB.QueryRowContext(ctx, fmt.Sprintf(`
+	row := s.queryRowContext(ctx, fmt.Sprintf(`
 		SELECT COUNT(*)
 		FROM rate_limits
 		%s
@@ -150,7 +150,7 @@ func (s *Store) ResetRateLimits(ctx context.Context, q RateLimitQuery) (int64, e
 		return 0, err
 	}
 
-	result, err := s.DB.ExecContext(ctx, fmt.Sprintf(`
+	result, err := s.execContext(ctx, fmt.Sprintf(`
 		DELETE FROM rate_limits
 		%s
 	`, where), args...)
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Share is an immutable snapshot of a result published as a share link.
+type Share struct {
+	ID        string
+	Kind      string
+	Title     string
+	Payload   []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// CreateShare stores a share snapshot. Snapshots are immutable, so an ID
+// that already exists is an error.
+func (s *Store) CreateShare(ctx context.Context, share Share) error {
+	if s == nil || s.DB == nil {
+		return errors.New("store is not initialized")
+	}
+	id := strings.TrimSpace(share.ID)
+	if id == "" {
+		return errors.New("share id is required")
+	}
+	if len(share.Payload) == 0 {
+		return errors.New("share payload is required")
+	}
+	if !share.ExpiresAt.After(share.CreatedAt) {
+		return errors.New("share must expire after it is created")
+	}
+
+	_, err := s.execContext(ctx, `
+		INSERT INTO shares (id, kind, title, payload, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, id, share.Kind, strings.TrimSpace(share.Title), string(share.Payload), share.CreatedAt.UTC().Unix(), share.ExpiresAt.UTC().Unix())
+	if err != nil {
+		return fmt.Errorf("create share: %w", err)
+	}
+	return nil
+}
+
+// GetShare returns the share with id, or nil when there is none or it has
+// expired at now.
+func (s *Store) GetShare(ctx context.Context, id string, now time.Time) (*Share, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	var (
+		share     Share
+		title     sql.NullString
+		payload   string
+		createdAt int64
+		expiresAt int64
+	)
+	err := s.queryRowContext(ctx, `
+		SELECT id, kind, title, payload, created_at, expires_at
+		FROM shares
+		WHERE id = ? AND expires_at > ?
+	`, strings.TrimSpace(id), now.UTC().Unix()).Scan(&share.ID, &share.Kind, &title, &payload, &createdAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get share: %w", err)
+	}
+	share.Title = title.String
+	share.Payload = []byte(payload)
+	share.CreatedAt = time.Unix(createdAt, 0).UTC()
+	share.ExpiresAt = time.Unix(expiresAt, 0).UTC()
+	return &share, nil
+}
+
+// PurgeExpiredShares deletes shares expired at now and returns how many
+// were removed.
+func (s *Store) PurgeExpiredShares(ctx context.Context, now time.Time) (int64, error) {
+	if s == nil || s.DB == nil {
+		return 0, errors.New("store is not initialized")
+	}
+
+	result, err := s.execContext(ctx, `DELETE FROM shares WHERE expires_at <= ?`, now.UTC().Unix())
+	if err != nil {
+		return 0, fmt.Errorf("purge shares: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,54 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+)
+
+func TestShares(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	share := Share{
+		ID:        "abc123",
+		Kind:      "compare",
+		Title:     "Finalists",
+		Payload:   []byte(`{"candidates":[]}`),
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	require.NoError(t, store.CreateShare(ctx, share))
+	// Snapshots are immutable
+	require.Error(t, store.CreateShare(ctx, share))
+	require.Error(t, store.CreateShare(ctx, Share{ID: "expired", Payload: []byte("{}"), CreatedAt: now, ExpiresAt: now}))
+
+	got, err := store.GetShare(ctx, "abc123", now.Add(time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	require.Equal(t, "compare", got.Kind)
+	require.Equal(t, "Finalists", got.Title)
+	require.JSONEq(t, `{"candidates":[]}`, string(got.Payload))
+	require.Equal(t, now.Add(time.Hour), got.ExpiresAt)
+
+	got, err = store.GetShare(ctx, "abc123", now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Nil(t, got)
+	got, err = store.GetShare(ctx, "missing", now)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	purged, err := store.PurgeExpiredShares(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, purged)
+}
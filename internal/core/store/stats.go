@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// StoreStats summarizes what the local store has recorded.
+type StoreStats struct {
+	NamesResearched int                 `json:"names_researched"`
+	Runs            int                 `json:"runs"`
+	Results         int                 `json:"results"`
+	FirstCheckedAt  *time.Time          `json:"first_checked_at,omitempty"`
+	LastCheckedAt   *time.Time          `json:"last_checked_at,omitempty"`
+	TLDs            []TLDStat           `json:"tlds"`
+	TopNames        []NameStat          `json:"top_names"`
+	AIAnalyses      []AIAnalysisStat    `json:"ai_analyses"`
+	AISpend         []AISpendStat       `json:"ai_spend"`
+	Cache           CacheEfficiencyStat `json:"cache"`
+}
+
+// TLDStat reports recorded availability outcomes for a TLD.
+type TLDStat struct {
+	TLD       string `json:"tld"`
+	Checks    int    `json:"checks"`
+	Available int    `json:"available"`
+	Taken     int    `json:"taken"`
+}
+
+// NameStat reports how often a name has been checked.
+type NameStat struct {
+	Name          string    `json:"name"`
+	Runs          int       `json:"runs"`
+	LastCheckedAt time.Time `json:"last_checked_at"`
+}
+
+// AIAnalysisStat counts cached AI analyses per month and prompt. It says
+// nothing about cost; see AISpendStat.
+type AIAnalysisStat struct {
+	Month  string `json:"month"`
+	Prompt string `json:"prompt"`
+	Count  int    `json:"count"`
+}
+
+// AISpendStat reports AI provider calls and their estimated cost per month.
+// Calls to models without pricing count toward Calls only.
+type AISpendStat struct {
+	Month   string  `json:"month"`
+	Calls   int     `json:"calls"`
+	CostUSD float64 `json:"cost_usd"`
+}
+
+// CacheEfficiencyStat compares cache hits with recorded fresh lookups.
+type CacheEfficiencyStat struct {
+	CheckEntries  int     `json:"check_entries"`
+	CheckLive     int     `json:"check_live"`
+	Hits          int     `json:"hits"`
+	FreshLookups  int     `json:"fresh_lookups"`
+	HitRate       float64 `json:"hit_rate"`
+	ExpertEntries int     `json:"expert_entries"`
+	ExpertLive    int     `json:"expert_live"`
+}
+
+// Stats aggregates history, cache, and AI usage. topN limits the most-checked names.
+func (s *Store) Stats(ctx context.Context, topN int) (*StoreStats, error) {
+	if s == nil || s.DB == nil {
+		return nil, errors.New("store is not initialized")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if topN <= 0 {
+		topN = 10
+	}
+
+	stats := &StoreStats{
+		TLDs:       make([]TLDStat, 0),
+		TopNames:   make([]NameStat, 0),
+		AIAnalyses: make([]AIAnalysisStat, 0),
+		AISpend:    make([]AISpendStat, 0),
+	}
+	// Include hits this process has buffered but not yet written
+	_ = s.FlushCacheHits(ctx)
+
+	var first, last *int64
+	row := s.queryRowContext(ctx, `
+		SELECT COUNT(DISTINCT name), COUNT(DISTINCT run_id), COUNT(*), MIN(checked_at), MAX(checked_at)
+		FROM check_history
+	`)
+	if err := row.Scan(&stats.NamesResearched, &stats.Runs, &stats.Results, &first, &last); err != nil {
+		return nil, fmt.Errorf("summarize history: %w", err)
+	}
+	if first != nil {
+		t := time.Unix(*first, 0).UTC()
+		stats.FirstCheckedAt = &t
+	}
+	if last != nil {
+		t := time.Unix(*last, 0).UTC()
+		stats.LastCheckedAt = &t
+	}
+
+	rows, err := s.queryContext(ctx, `
+		SELECT tld, COUNT(*),
+			SUM(CASE WHEN available = 1 THEN 1 ELSE 0 END),
+			SUM(CASE WHEN available = 2 THEN 1 ELSE 0 END)
+		FROM check_history
+		WHERE check_type = 'domain' AND tld IS NOT NULL AND tld != ''
+		GROUP BY tld
+		ORDER BY COUNT(*) DESC, tld ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("summarize tlds: %w", err)
+	}
+	for rows.Next() {
+		var stat TLDStat
+		if err := rows.Scan(&stat.TLD, &stat.Checks, &stat.Available, &stat.Taken); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan tld stats: %w", err)
+		}
+		stats.TLDs = append(stats.TLDs, stat)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, fmt.Errorf("summarize tlds: %w", err)
+	}
+
+	rows, err = s.queryContext(ctx, `
+		SELECT name, COUNT(DISTINCT run_id), MAX(checked_at)
+		FROM check_history
+		GROUP BY name
+		ORDER BY COUNT(DISTINCT run_id) DESC, MAX(checked_at) DESC
+		LIMIT ?
+	`, topN)
+	if err != nil {
+		return nil, fmt.Errorf("summarize names: %w", err)
+	}
+	for rows.Next() {
+		var (
+			stat      NameStat
+			checkedAt int64
+		)
+		if err := rows.Scan(&stat.Name, &stat.Runs, &checkedAt); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan name stats: %w", err)
+		}
+		stat.LastCheckedAt = time.Unix(checkedAt, 0).UTC()
+		stats.TopNames = append(stats.TopNames, stat)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, fmt.Errorf("summarize names: %w", err)
+	}
+
+	month := `strftime('%Y-%m', created_at, 'unixepoch')`
+	if s.driver == driverPostgres {
+		month = `to_char(to_timestamp(created_at) AT TIME ZONE 'UTC', 'YYYY-MM')`
+	}
+	rows, err = s.queryContext(ctx, `
+		SELECT `+month+`, prompt_slug, COUNT(*)
+		FROM expert_cache
+		GROUP BY 1, 2
+		ORDER BY 1 ASC, 2 ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("summarize ai analyses: %w", err)
+	}
+	for rows.Next() {
+		var stat AIAnalysisStat
+		if err := rows.Scan(&stat.Month, &stat.Prompt, &stat.Count); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan ai stats: %w", err)
+		}
+		stats.AIAnalyses = append(stats.AIAnalyses, stat)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, fmt.Errorf("summarize ai analyses: %w", err)
+	}
+
+	// ai_spend is keyed "day:YYYY-MM-DD"; characters 5-11 are the month
+	rows, err = s.queryContext(ctx, `
+		SELECT substr(day, 5, 7), SUM(calls), SUM(cost_usd)
+		FROM ai_spend
+		GROUP BY 1
+		ORDER BY 1 ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("summarize ai spend: %w", err)
+	}
+	for rows.Next() {
+		var stat AISpendStat
+		if err := rows.Scan(&stat.Month, &stat.Calls, &stat.CostUSD); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("scan ai spend: %w", err)
+		}
+		stats.AISpend = append(stats.AISpend, stat)
+	}
+	if err := closeRows(rows); err != nil {
+		return nil, fmt.Errorf("summarize ai spend: %w", err)
+	}
+
+	now := time.Now().UTC().Unix()
+	row = s.queryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM check_cache),
+			(SELECT COUNT(*) FROM check_cache WHERE expires_at > ?),
+			(SELECT COALESCE(SUM(hits), 0) FROM check_cache),
+			(SELECT COUNT(*) FROM expert_cache),
+			(SELECT COUNT(*) FROM expert_cache WHERE expires_at > ?)
+	`, now, now)
+	if err := row.Scan(&stats.Cache.CheckEntries, &stats.Cache.CheckLive, &stats.Cache.Hits, &stats.Cache.ExpertEntries, &stats.Cache.ExpertLive); err != nil {
+		return nil, fmt.Errorf("summarize cache: %w", err)
+	}
+	stats.Cache.FreshLookups = stats.Results
+	if total := stats.Cache.Hits + stats.Cache.FreshLookups; total > 0 {
+		stats.Cache.HitRate = float64(stats.Cache.Hits) / float64(total)
+	}
+
+	return stats, nil
+}
+
+func closeRows(rows interface {
+	Err() error
+	Close() error
+}) error {
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return err
+	}
+	return rows.Close()
+}
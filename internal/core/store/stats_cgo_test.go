@@ -0,0 +1,100 @@
+//go:build cgo
+
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestStoreStats(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	empty, err := store.Stats(ctx, 5)
+	require.NoError(t, err)
+	require.Zero(t, empty.NamesResearched)
+	require.Nil(t, empty.FirstCheckedAt)
+
+	for i := 0; i < 2; i++ {
+		_, err := store.RecordHistory(ctx, "acme", []*core.CheckResult{
+			{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+			{Name: "acme.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityAvailable},
+		})
+		require.NoError(t, err)
+	}
+	_, err = store.RecordHistory(ctx, "zenith", []*core.CheckResult{
+		{Name: "zenith.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+	})
+	require.NoError(t, err)
+
+	result := &core.CheckResult{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}
+	require.NoError(t, store.SetCachedResult(ctx, "acme.com", result, time.Hour))
+	cached, err := store.GetCachedResult(ctx, "acme.com", core.CheckTypeDomain, "com")
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+
+	require.NoError(t, store.RecordAISpend(ctx, time.Date(2026, 9, 30, 12, 0, 0, 0, time.UTC), 0.5))
+	require.NoError(t, store.RecordAISpend(ctx, time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), 0.25))
+	require.NoError(t, store.RecordAISpend(ctx, time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC), 0))
+
+	stats, err := store.Stats(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.NamesResearched)
+	require.Equal(t, 3, stats.Runs)
+	require.Equal(t, 5, stats.Results)
+	require.Equal(t, []TLDStat{
+		{TLD: "com", Checks: 3, Available: 0, Taken: 3},
+		{TLD: "io", Checks: 2, Available: 2, Taken: 0},
+	}, stats.TLDs)
+	require.Len(t, stats.TopNames, 1)
+	require.Equal(t, "acme", stats.TopNames[0].Name)
+	require.Equal(t, 2, stats.TopNames[0].Runs)
+	require.Equal(t, 1, stats.Cache.Hits)
+	require.Equal(t, 1, stats.Cache.CheckLive)
+	require.InDelta(t, 1.0/6.0, stats.Cache.HitRate, 0.001)
+	require.Equal(t, []AISpendStat{
+		{Month: "2026-09", Calls: 1, CostUSD: 0.5},
+		{Month: "2026-10", Calls: 2, CostUSD: 0.25},
+	}, stats.AISpend)
+}
+
+func TestCacheHitsAreBuffered(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(ctx))
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	result := &core.CheckResult{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}
+	require.NoError(t, store.SetCachedResult(ctx, "acme.com", result, time.Hour))
+	hits := func() int {
+		var n int
+		require.NoError(t, store.DB.QueryRowContext(ctx, `SELECT hits FROM check_cache`).Scan(&n))
+		return n
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := store.GetCachedResult(ctx, "acme.com", core.CheckTypeDomain, "com")
+		require.NoError(t, err)
+	}
+	require.Zero(t, hits(), "reads must not write each hit")
+	require.NoError(t, store.FlushCacheHits(ctx))
+	require.Equal(t, 3, hits())
+
+	// A full buffer is written without an explicit flush
+	for i := 0; i < cacheHitFlushSize; i++ {
+		_, err := store.GetCachedResult(ctx, "acme.com", core.CheckTypeDomain, "com")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3+cacheHitFlushSize, hits())
+}
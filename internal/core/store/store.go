@@ -8,20 +8,29 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	_ "github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/tursodatabase/go-libsql"
 
 	"github.com/namelens/namelens/internal/config"
 )
 
-const driverLibsql = "libsql"
+const (
+	driverLibsql   = "libsql"
+	driverPostgres = "postgres"
+)
 
 // Store wraps the database connection for NameLens.
 type Store struct {
 	DB     *sql.DB
 	driver string
+	// memory is the optional LRU in front of the check cache
+	memory *memoryCache
+	// hits buffers check cache hit counts until they are written
+	hits cacheHits
 }
 
 // Open initializes a store connection using the provided configuration.
@@ -42,18 +51,47 @@ func Open(ctx context.Context, cfg config.StoreConfig) (*Store, error) {
 			return nil, err
 		}
 
-		db, err := sql.Open(driverLibsql, dsn)
+		local := isLocalFile(dsn, cfg)
+		var db *sql.DB
+		if local {
+			db, err = openLibsqlWithPragmas(dsn, localPragmas(cfg))
+		} else {
+			db, err = sql.Open(driverLibsql, dsn)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("open libsql store: %w", err)
 		}
+		if dsn != ":memory:" {
+			configurePool(db, cfg.Pool)
+		}
 		if err := db.PingContext(ctx); err != nil {
 			_ = db.Close()
 			return nil, fmt.Errorf("ping libsql store: %w", err)
 		}
 
-		if err := configureLocalSQLite(ctx, db, dsn, cfg); err != nil {
+		if local {
+			if err := enableWAL(ctx, db); err != nil {
+				_ = db.Close()
+				return nil, err
+			}
+		}
+
+		return &Store{DB: db, driver: driver}, nil
+	case driverPostgres:
+		dsn := strings.TrimSpace(cfg.URL)
+		if dsn == "" {
+			return nil, errors.New("store url is required for the postgres driver")
+		}
+
+		// pgx registers its database/sql driver as "pgx"
+		db, err := sql.Open("pgx", dsn)
+		if err != nil {
+			return nil, fmt.Errorf("open postgres store: %w", err)
+		}
+		configurePool(db, cfg.Pool)
+		if err := db.PingContext(ctx); err != nil {
 			_ = db.Close()
-			return nil, err
+			return nil, fmt.Errorf("ping postgres store: %w", err)
 		}
 
 		return &Store{DB: db, driver: driver}, nil
@@ -67,9 +105,37 @@ func (s *Store) Close() error {
 	if s == nil || s.DB == nil {
 		return nil
 	}
+	_ = s.FlushCacheHits(context.Background()) // counts only; never blocks closing
 	return s.DB.Close()
 }
 
+// IntegrityCheck runs SQLite's quick_check and returns an error describing the
+// first problem found. Postgres stores are only pinged.
+func (s *Store) IntegrityCheck(ctx context.Context) error {
+	if s == nil || s.DB == nil {
+		return errors.New("store is not initialized")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := s.DB.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping store: %w", err)
+	}
+	if s.driver == driverPostgres {
+		// Postgres has no quick_check; a live connection is the best we get.
+		return nil
+	}
+	var result string
+	if err := s.DB.QueryRowContext(ctx, "PRAGMA quick_check").Scan(&result); err != nil {
+		return fmt.Errorf("quick_check: %w", err)
+	}
+	if !strings.EqualFold(strings.TrimSpace(result), "ok") {
+		return fmt.Errorf("quick_check: %s", result)
+	}
+	return nil
+}
+
 // Driver returns the configured store driver.
 func (s *Store) Driver() string {
 	if s == nil {
@@ -78,6 +144,45 @@ func (s *Store) Driver() string {
 	return s.driver
 }
 
+// rebind rewrites the ? placeholders used throughout the store into the
+// driver's style. Queries are written for SQLite; Postgres wants $1, $2, ...
+func (s *Store) rebind(query string) string {
+	if s == nil || s.driver != driverPostgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var (
+		b      strings.Builder
+		n      int
+		quoted bool
+	)
+	b.Grow(len(query) + 8)
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == '?' && !quoted:
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (s *Store) execContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return s.DB.ExecContext(ctx, s.rebind(query), args...)
+}
+
+func (s *Store) queryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return s.DB.QueryContext(ctx, s.rebind(query), args...)
+}
+
+func (s *Store) queryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return s.DB.QueryRowContext(ctx, s.rebind(query), args...)
+}
+
 func buildLibsqlDSN(cfg config.StoreConfig) (string, error) {
 	if dsn := strings.TrimSpace(cfg.URL); dsn != "" {
 		return addAuthToken(dsn, cfg.AuthToken)
@@ -145,25 +250,15 @@ func extractFilePath(dsn string) (string, error) {
 	return strings.TrimPrefix(parsed.Opaque, "//"), nil
 }
 
-func configureLocalSQLite(ctx context.Context, db *sql.DB, dsn string, cfg config.StoreConfig) error {
-	if db == nil {
-		return errors.New("store connection is nil")
-	}
-	if strings.TrimSpace(cfg.URL) != "" {
-		return nil
-	}
-	if dsn == ":memory:" {
-		return nil
-	}
-	if !strings.HasPrefix(dsn, "file:") {
-		return nil
-	}
-
-	// SQLite is embedded; keep a single connection and use WAL to reduce lock contention.
-	// This makes concurrent CLI checks behave predictably.
-	db.SetMaxOpenConns(1)
-	db.SetMaxIdleConns(1)
+// isLocalFile reports whether dsn is a SQLite file on local disk, as opposed
+// to :memory: or a remote libsql database.
+func isLocalFile(dsn string, cfg config.StoreConfig) bool {
+	return strings.TrimSpace(cfg.URL) == "" && strings.HasPrefix(dsn, "file:")
+}
 
+// enableWAL switches a local file to WAL so readers do not block behind the
+// writer. The journal mode is persistent, so this only writes once per file.
+func enableWAL(ctx context.Context, db *sql.DB) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -171,11 +266,6 @@ func configureLocalSQLite(ctx context.Context, db *sql.DB, dsn string, cfg confi
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	var busyTimeout int
-	if err := db.QueryRowContext(ctx, "PRAGMA busy_timeout=5000").Scan(&busyTimeout); err != nil {
-		return fmt.Errorf("set busy timeout: %w", err)
-	}
-
 	var journalMode string
 	if err := db.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode); err != nil {
 		return fmt.Errorf("read journal mode: %w", err)
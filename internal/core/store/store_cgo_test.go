@@ -23,3 +23,13 @@ func TestOpenMemoryStore(t *testing.T) {
 	require.Equal(t, "libsql", store.Driver())
 	require.NoError(t, store.Close())
 }
+
+func TestIntegrityCheck(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: ":memory:"})
+	require.NoError(t, err)
+	defer store.Close() // nolint:errcheck // test cleanup
+
+	require.NoError(t, store.Migrate(ctx))
+	require.NoError(t, store.IntegrityCheck(ctx))
+}
@@ -4,9 +4,13 @@ package store
 
 import (
 	"context"
+	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/namelens/namelens/internal/config"
+	"github.com/namelens/namelens/internal/core"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,7 +26,7 @@ func TestOpenLocalStore_ConfiguresSQLite(t *testing.T) {
 	require.NoError(t, err)
 	defer func() { _ = store.Close() }()
 
-	require.Equal(t, 1, store.DB.Stats().MaxOpenConnections)
+	require.Equal(t, DefaultMaxOpenConns, store.DB.Stats().MaxOpenConnections)
 
 	var journalMode string
 	require.NoError(t, store.DB.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode))
@@ -30,5 +34,108 @@ func TestOpenLocalStore_ConfiguresSQLite(t *testing.T) {
 
 	var busyTimeout int
 	require.NoError(t, store.DB.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout))
-	require.GreaterOrEqual(t, busyTimeout, 1000)
+	require.Equal(t, int(DefaultBusyTimeout.Milliseconds()), busyTimeout)
+}
+
+func TestOpenLocalStore_PragmasOnEveryConnection(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := config.StoreConfig{
+		Driver:      "libsql",
+		Path:        "file:" + t.TempDir() + "/namelens.db",
+		BusyTimeout: 2 * time.Second,
+		Pool:        config.StorePoolConfig{MaxOpenConns: 3, MaxIdleConns: 8},
+	}
+
+	store, err := Open(ctx, cfg)
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+
+	stats := store.DB.Stats()
+	require.Equal(t, 3, stats.MaxOpenConnections)
+
+	// Hold several connections at once so each is a distinct one
+	for i := 0; i < 3; i++ {
+		conn, err := store.DB.Conn(ctx)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		var busyTimeout, synchronous int
+		require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout))
+		require.NoError(t, conn.QueryRowContext(ctx, "PRAGMA synchronous").Scan(&synchronous))
+		require.Equal(t, 2000, busyTimeout)
+		require.Equal(t, 1, synchronous, "synchronous=NORMAL")
+	}
+}
+
+func TestOpenLocalStore_ConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := Open(ctx, config.StoreConfig{Driver: "libsql", Path: "file:" + t.TempDir() + "/namelens.db"})
+	require.NoError(t, err)
+	defer func() { _ = store.Close() }()
+	require.NoError(t, store.Migrate(ctx))
+
+	errs := make(chan error, 32)
+	for i := 0; i < cap(errs); i++ {
+		go func(i int) {
+			name := fmt.Sprintf("name%d.com", i)
+			result := &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable}
+			if err := store.SetCachedResult(ctx, name, result, time.Hour); err != nil {
+				errs <- err
+				return
+			}
+			_, err := store.GetCachedResult(ctx, name, core.CheckTypeDomain, "com")
+			errs <- err
+		}(i)
+	}
+	for i := 0; i < cap(errs); i++ {
+		require.NoError(t, <-errs)
+	}
+}
+
+// BenchmarkCacheParallel measures a serve-like mix of cache reads and writes
+// against a local file at different pool sizes; DefaultMaxOpenConns comes from
+// it.
+func BenchmarkCacheParallel(b *testing.B) {
+	for _, conns := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("conns=%d", conns), func(b *testing.B) {
+			ctx := context.Background()
+			store, err := Open(ctx, config.StoreConfig{
+				Driver: "libsql",
+				Path:   "file:" + b.TempDir() + "/namelens.db",
+				Pool:   config.StorePoolConfig{MaxOpenConns: conns, MaxIdleConns: conns},
+			})
+			require.NoError(b, err)
+			defer func() { _ = store.Close() }()
+			require.NoError(b, store.Migrate(ctx))
+
+			for i := 0; i < 100; i++ {
+				name := fmt.Sprintf("name%d.com", i)
+				result := &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}
+				require.NoError(b, store.SetCachedResult(ctx, name, result, time.Hour))
+			}
+
+			var counter atomic.Int64
+			b.SetParallelism(4)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					n := counter.Add(1)
+					name := fmt.Sprintf("name%d.com", n%100)
+					// One write per ten reads, roughly serve's cache miss rate
+					if n%10 == 0 {
+						result := &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken}
+						if err := store.SetCachedResult(ctx, name, result, time.Hour); err != nil {
+							b.Error(err)
+						}
+						continue
+					}
+					if _, err := store.GetCachedResult(ctx, name, core.CheckTypeDomain, "com"); err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+	}
 }
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"testing"
 
 	"github.com/namelens/namelens/internal/config"
@@ -53,3 +54,31 @@ func TestBuildLibsqlDSN(t *testing.T) {
 		require.Equal(t, ":memory:", dsn)
 	})
 }
+
+func TestRebind(t *testing.T) {
+	query := `SELECT * FROM t WHERE a = ? AND b LIKE '?%' AND c IN (?, ?)`
+
+	libsql := &Store{driver: driverLibsql}
+	require.Equal(t, query, libsql.rebind(query))
+
+	postgres := &Store{driver: driverPostgres}
+	require.Equal(t, `SELECT * FROM t WHERE a = $1 AND b LIKE '?%' AND c IN ($2, $3)`, postgres.rebind(query))
+}
+
+func TestPostgresDDL(t *testing.T) {
+	stmt := postgresDDL.Replace(`CREATE TABLE x (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		created_at INTEGER NOT NULL,
+		cost_usd REAL NOT NULL DEFAULT 0
+	);`)
+
+	require.Contains(t, stmt, "id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,")
+	require.Contains(t, stmt, "created_at BIGINT NOT NULL")
+	require.Contains(t, stmt, "cost_usd DOUBLE PRECISION NOT NULL")
+	require.NotContains(t, stmt, "AUTOINCREMENT")
+}
+
+func TestOpenPostgresRequiresURL(t *testing.T) {
+	_, err := Open(context.Background(), config.StoreConfig{Driver: "postgres"})
+	require.ErrorContains(t, err, "store url is required")
+}
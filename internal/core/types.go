@@ -11,6 +11,11 @@ const (
 	CheckTypePyPI   CheckType = "pypi"
 	CheckTypeCargo  CheckType = "cargo"
 	CheckTypeGitHub CheckType = "github"
+
+	// SaaS identities, checked with --saas.
+	CheckTypeSlack           CheckType = "slack"
+	CheckTypeDiscord         CheckType = "discord"
+	CheckTypeGoogleWorkspace CheckType = "google-workspace"
 )
 
 // Availability represents the availability state for a check.
@@ -25,6 +30,24 @@ const (
 	AvailabilityUnsupported Availability = 5
 )
 
+// String returns the lowercase label used in API and report output.
+func (a Availability) String() string {
+	switch a {
+	case AvailabilityAvailable:
+		return "available"
+	case AvailabilityTaken:
+		return "taken"
+	case AvailabilityError:
+		return "error"
+	case AvailabilityRateLimited:
+		return "rate_limited"
+	case AvailabilityUnsupported:
+		return "unsupported"
+	default:
+		return "unknown"
+	}
+}
+
 // Provenance captures metadata about how a check was resolved.
 type Provenance struct {
 	CheckID        string     `json:"check_id"`
@@ -34,7 +57,29 @@ type Provenance struct {
 	Server         string     `json:"server,omitempty"`
 	FromCache      bool       `json:"from_cache"`
 	CacheExpiresAt *time.Time `json:"cache_expires_at,omitempty"`
-	ToolVersion    string     `json:"tool_version"`
+	// CacheStale marks a cached result served past CacheExpiresAt while a
+	// background refresh replaces it (stale-while-revalidate).
+	CacheStale  bool   `json:"cache_stale,omitempty"`
+	ToolVersion string `json:"tool_version"`
+	// AddressFamily is the IP family (ipv4, ipv6) of the connection that
+	// produced the result.
+	AddressFamily string `json:"address_family,omitempty"`
+	// Attempts is the number of requests made across servers and retries;
+	// zero when the source does not track it.
+	Attempts int `json:"attempts,omitempty"`
+	// BootstrapFetchedAt is when the RDAP bootstrap data that routed the
+	// lookup was fetched; BootstrapStale marks it older than the configured
+	// bootstrap max age.
+	BootstrapFetchedAt *time.Time `json:"bootstrap_fetched_at,omitempty"`
+	BootstrapStale     bool       `json:"bootstrap_stale,omitempty"`
+	// ServersConsulted is the number of servers queried for the verdict;
+	// ServersAgreed is how many of them returned it. Servers that failed
+	// (errors, timeouts) count as consulted but not agreeing.
+	ServersConsulted int `json:"servers_consulted,omitempty"`
+	ServersAgreed    int `json:"servers_agreed,omitempty"`
+	// TraceID is the OpenTelemetry trace of the run that produced the
+	// result, when tracing is enabled.
+	TraceID string `json:"trace_id,omitempty"`
 }
 
 // CheckResult reports availability and supporting context.
@@ -47,4 +92,62 @@ type CheckResult struct {
 	Message    string         `json:"message,omitempty"`
 	ExtraData  map[string]any `json:"extra_data,omitempty"`
 	Provenance Provenance     `json:"provenance"`
+	// Confidence rates the available/taken verdict; nil for other states.
+	Confidence *Confidence `json:"confidence,omitempty"`
+	// Verification is set when an available verdict was re-checked against
+	// independent sources (check --verify-taken).
+	Verification *Verification `json:"verification,omitempty"`
+	// Pricing is the registrar price quote for an available domain, when
+	// price enrichment is enabled (check --prices).
+	Pricing *Pricing `json:"pricing,omitempty"`
+}
+
+// Pricing is a registrar's price quote for registering a domain.
+type Pricing struct {
+	Provider string `json:"provider"`
+	Currency string `json:"currency"`
+	// Registration is the first-year registration price.
+	Registration float64 `json:"registration"`
+	// Renewal is the yearly renewal price; zero when the provider does not
+	// report it.
+	Renewal float64 `json:"renewal,omitempty"`
+	// Premium marks a premium domain: flagged by the provider, or priced at
+	// or above the configured premium threshold.
+	Premium bool `json:"premium,omitempty"`
+}
+
+// Verification statuses.
+const (
+	VerificationConfirmed    = "confirmed"
+	VerificationDisputed     = "disputed"
+	VerificationInconclusive = "inconclusive"
+)
+
+// Verification records an independent re-check of an available verdict.
+// Status is disputed when any source found the name taken, confirmed when a
+// source agreed and none disagreed, and inconclusive otherwise.
+type Verification struct {
+	Status string              `json:"status"`
+	Checks []VerificationCheck `json:"checks"`
+}
+
+// VerificationCheck is one source's answer: available, taken, or unknown.
+type VerificationCheck struct {
+	Source  string `json:"source"`
+	Verdict string `json:"verdict"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// Disputes returns the sources that found the name taken.
+func (v *Verification) Disputes() []string {
+	if v == nil {
+		return nil
+	}
+	var sources []string
+	for _, check := range v.Checks {
+		if check.Verdict == AvailabilityTaken.String() {
+			sources = append(sources, check.Source)
+		}
+	}
+	return sources
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/namelens/namelens/internal/metrics"
 	"github.com/namelens/namelens/internal/observability"
 	"github.com/namelens/namelens/internal/server/middleware"
+	"github.com/namelens/namelens/internal/tracing"
 	"go.uber.org/zap"
 )
 
@@ -182,10 +183,12 @@ func extractCorrelationID(ctx context.Context) string {
 	return uuid.New().String()
 }
 
-// extractTraceID gets trace ID from context, falls back to generating new UUID
+// extractTraceID gets the OpenTelemetry trace ID from context, falls back to
+// the correlation ID outside a trace
 func extractTraceID(ctx context.Context) string {
-	// TODO: Extract from OpenTelemetry or other tracing system when implemented
-	// For now, use correlation ID as trace ID
+	if traceID := tracing.TraceID(ctx); traceID != "" {
+		return traceID
+	}
 	return extractCorrelationID(ctx)
 }
 
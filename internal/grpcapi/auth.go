@@ -0,0 +1,127 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	namelensv1 "github.com/namelens/namelens/api/namelens/v1"
+	"github.com/namelens/namelens/internal/api"
+)
+
+// apiKeyMetadata is the metadata key carrying the control plane API key,
+// matching the HTTP API's X-API-Key header.
+const apiKeyMetadata = "x-api-key"
+
+// methodRoles is the minimum API key role per method, matching the HTTP API:
+// checks need the check role and AI calls need admin. Other methods, such as
+// reflection, are open to read-only keys.
+var methodRoles = map[string]api.Role{
+	namelensv1.NamelensService_Check_FullMethodName:    api.RoleCheck,
+	namelensv1.NamelensService_Review_FullMethodName:   api.RoleAdmin,
+	namelensv1.NamelensService_Generate_FullMethodName: api.RoleAdmin,
+}
+
+// limitedMethods count against the caller's rate limit and daily quota, as
+// live lookups do over HTTP.
+var limitedMethods = map[string]bool{
+	namelensv1.NamelensService_Check_FullMethodName: true,
+}
+
+// UnaryAuthInterceptor applies the HTTP API's authentication rules to gRPC
+// calls: a provided key is always validated, and calls without one are only
+// allowed from loopback peers when AllowLocalhost is set. The key's role must
+// allow the method, and checks must be within the key's limits.
+func UnaryAuthInterceptor(cfg api.AuthConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		principal, err := authorizeMethod(ctx, cfg, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if limitedMethods[info.FullMethod] {
+			if err := allowByLimits(ctx, cfg.Limits, principal); err != nil {
+				return nil, err
+			}
+		}
+		return handler(api.WithPrincipal(ctx, principal), req)
+	}
+}
+
+func authorizeMethod(ctx context.Context, cfg api.AuthConfig, method string) (api.Principal, error) {
+	principal, err := authenticate(ctx, cfg)
+	if err != nil {
+		return api.Principal{}, err
+	}
+	required, ok := methodRoles[method]
+	if !ok {
+		required = api.RoleReadOnly
+	}
+	if !principal.Role.Allows(required) {
+		return api.Principal{}, status.Errorf(codes.PermissionDenied, "API key role %s does not allow %s (requires %s)", principal.Role, method, required)
+	}
+	return principal, nil
+}
+
+// authenticate returns the caller's principal. Calls allowed without a key
+// get the admin role.
+func authenticate(ctx context.Context, cfg api.AuthConfig) (api.Principal, error) {
+	required, err := cfg.Required(ctx)
+	if err != nil {
+		return api.Principal{}, status.Error(codes.Internal, "failed to verify API key")
+	}
+	if !required {
+		return api.Principal{Role: api.RoleAdmin}, nil
+	}
+
+	provided := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(apiKeyMetadata); len(values) > 0 {
+			provided = values[0]
+		}
+	}
+	if provided != "" {
+		principal, ok, err := cfg.Resolve(ctx, provided)
+		if err != nil {
+			return api.Principal{}, status.Error(codes.Internal, "failed to verify API key")
+		}
+		if !ok {
+			return api.Principal{}, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+		return principal, nil
+	}
+
+	if cfg.AllowLocalhost && isLoopbackPeer(ctx) {
+		return api.Principal{Role: api.RoleAdmin}, nil
+	}
+	return api.Principal{}, status.Error(codes.Unauthenticated, "API key required for non-localhost requests")
+}
+
+func allowByLimits(ctx context.Context, limits *api.KeyLimiter, principal api.Principal) error {
+	decision, err := limits.Allow(ctx, principal)
+	if err != nil {
+		return status.Error(codes.Internal, "failed to check API key quota")
+	}
+	if !decision.Allowed {
+		return status.Errorf(codes.ResourceExhausted, "%s (retry after %s)", decision.Message, decision.RetryAfter.Round(time.Second))
+	}
+	return nil
+}
+
+func isLoopbackPeer(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
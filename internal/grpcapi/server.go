@@ -0,0 +1,327 @@
+// Package grpcapi serves the namelens.v1 gRPC API (api/namelens/v1) so other
+// services can run checks, reviews, and name generation without the CLI.
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	namelensv1 "github.com/namelens/namelens/api/namelens/v1"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+// DefaultReviewPrompts are run by Review when the request names none.
+var DefaultReviewPrompts = []string{"name-availability", "name-phonetics", "name-suitability"}
+
+// AnalyzeFunc runs an AI prompt for a name and returns its JSON response.
+type AnalyzeFunc func(ctx context.Context, prompt, name, depth, model string) (json.RawMessage, error)
+
+// GenerateFunc runs a name generation prompt and returns its JSON response.
+type GenerateFunc func(ctx context.Context, prompt, depth, model string, variables map[string]string) (json.RawMessage, error)
+
+// AnalysisError carries an AI error code through AnalyzeFunc.
+type AnalysisError struct {
+	Code    string
+	Message string
+}
+
+func (e *AnalysisError) Error() string {
+	return e.Message
+}
+
+// Server implements namelensv1.NamelensServiceServer.
+type Server struct {
+	namelensv1.UnimplementedNamelensServiceServer
+
+	orchestrator *engine.Orchestrator
+	analyze      AnalyzeFunc
+	generate     GenerateFunc
+}
+
+// NewServer creates a gRPC API server. A nil analyze or generate function
+// makes Review and Generate return FailedPrecondition.
+func NewServer(orchestrator *engine.Orchestrator, analyze AnalyzeFunc, generate GenerateFunc) *Server {
+	return &Server{
+		orchestrator: orchestrator,
+		analyze:      analyze,
+		generate:     generate,
+	}
+}
+
+// Check runs availability checks for a name.
+func (s *Server) Check(ctx context.Context, req *namelensv1.CheckRequest) (*namelensv1.CheckResponse, error) {
+	name, results, err := s.check(ctx, req.GetName(), req.GetProfile(), req.GetTlds(), req.GetRegistries(), req.GetHandles())
+	if err != nil {
+		return nil, err
+	}
+	return &namelensv1.CheckResponse{
+		Name:    name,
+		Results: toResults(results),
+		Summary: toSummary(results),
+	}, nil
+}
+
+// Review runs availability checks and AI analyses for a name.
+func (s *Server) Review(ctx context.Context, req *namelensv1.ReviewRequest) (*namelensv1.ReviewResponse, error) {
+	if s.analyze == nil {
+		return nil, status.Error(codes.FailedPrecondition, "AI analyses are not configured")
+	}
+	name, results, err := s.check(ctx, req.GetName(), req.GetProfile(), req.GetTlds(), req.GetRegistries(), req.GetHandles())
+	if err != nil {
+		return nil, err
+	}
+
+	prompts := normalize(req.GetPrompts())
+	if len(prompts) == 0 {
+		prompts = DefaultReviewPrompts
+	}
+
+	analyses := make([]*namelensv1.Analysis, 0, len(prompts))
+	for _, prompt := range prompts {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+		data, err := s.analyze(ctx, prompt, name, req.GetDepth(), req.GetModel())
+		analyses = append(analyses, toAnalysis(prompt, data, err))
+	}
+
+	return &namelensv1.ReviewResponse{
+		Name:     name,
+		Results:  toResults(results),
+		Summary:  toSummary(results),
+		Analyses: analyses,
+	}, nil
+}
+
+// Generate asks the AI provider for name candidates.
+func (s *Server) Generate(ctx context.Context, req *namelensv1.GenerateRequest) (*namelensv1.GenerateResponse, error) {
+	if s.generate == nil {
+		return nil, status.Error(codes.FailedPrecondition, "name generation is not configured")
+	}
+	concept := strings.TrimSpace(req.GetConcept())
+	if concept == "" {
+		return nil, status.Error(codes.InvalidArgument, "concept is required")
+	}
+
+	// Prompts use different variable names for the main input.
+	variables := map[string]string{
+		"concept": concept,
+		"name":    concept,
+		"input":   concept,
+	}
+	for key, value := range map[string]string{
+		"description":  req.GetDescription(),
+		"constraints":  req.GetConstraints(),
+		"current_name": req.GetCurrentName(),
+		"tagline":      req.GetTagline(),
+	} {
+		if value = strings.TrimSpace(value); value != "" {
+			variables[key] = value
+		}
+	}
+
+	prompt := strings.TrimSpace(req.GetPrompt())
+	if prompt == "" {
+		prompt = "name-alternatives"
+	}
+
+	data, err := s.generate(ctx, prompt, req.GetDepth(), req.GetModel(), variables)
+	if err != nil {
+		var analysisErr *AnalysisError
+		if errors.As(err, &analysisErr) {
+			code := codes.Unavailable
+			if analysisErr.Code == "AILINK_QUOTA_EXCEEDED" {
+				code = codes.ResourceExhausted
+			}
+			return nil, status.Errorf(code, "%s: %s", analysisErr.Code, analysisErr.Message)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	result, err := toStruct(data)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decode generation response: %v", err)
+	}
+	return &namelensv1.GenerateResponse{Result: result}, nil
+}
+
+func (s *Server) check(ctx context.Context, name, profileName string, tlds, registries, handles []string) (string, []*core.CheckResult, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(name) > 63 {
+		return "", nil, status.Error(codes.InvalidArgument, "name exceeds maximum length of 63 characters")
+	}
+	if s.orchestrator == nil {
+		return "", nil, status.Error(codes.FailedPrecondition, "checks are not configured")
+	}
+
+	profile, err := buildProfile(profileName, tlds, registries, handles)
+	if err != nil {
+		return "", nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	results, err := s.orchestrator.Check(ctx, name, profile)
+	if err != nil {
+		return "", nil, status.Error(codes.Internal, err.Error())
+	}
+	return name, results, nil
+}
+
+// buildProfile starts from a built-in profile and applies explicit lists,
+// defaulting to the minimal profile like the HTTP API.
+func buildProfile(name string, tlds, registries, handles []string) (core.Profile, error) {
+	var profile core.Profile
+	if name = strings.TrimSpace(name); name != "" {
+		p, ok := core.FindBuiltInProfile(name)
+		if !ok {
+			return core.Profile{}, fmt.Errorf("invalid profile: %s", name)
+		}
+		profile = *p
+	}
+	if values := normalize(tlds); len(values) > 0 {
+		profile.TLDs = values
+	}
+	if values := normalize(registries); len(values) > 0 {
+		profile.Registries = values
+	}
+	if values := normalize(handles); len(values) > 0 {
+		profile.Handles = values
+	}
+	if len(profile.TLDs) == 0 && len(profile.Registries) == 0 && len(profile.Handles) == 0 {
+		if p, ok := core.FindBuiltInProfile("minimal"); ok {
+			profile = *p
+		}
+	}
+	return profile, nil
+}
+
+func normalize(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func toResults(results []*core.CheckResult) []*namelensv1.CheckResult {
+	converted := make([]*namelensv1.CheckResult, 0, len(results))
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		converted = append(converted, toResult(result))
+	}
+	return converted
+}
+
+func toResult(result *core.CheckResult) *namelensv1.CheckResult {
+	converted := &namelensv1.CheckResult{
+		Name:       result.Name,
+		CheckType:  string(result.CheckType),
+		Tld:        result.TLD,
+		Available:  namelensv1.Availability(result.Available), // #nosec G115 -- enum values mirror core.Availability
+		StatusCode: int32(result.StatusCode),                  // #nosec G115 -- HTTP status codes
+		Message:    result.Message,
+		Provenance: &namelensv1.Provenance{
+			CheckId:       result.Provenance.CheckID,
+			Source:        result.Provenance.Source,
+			Server:        result.Provenance.Server,
+			FromCache:     result.Provenance.FromCache,
+			ToolVersion:   result.Provenance.ToolVersion,
+			AddressFamily: result.Provenance.AddressFamily,
+		},
+	}
+	if !result.Provenance.RequestedAt.IsZero() {
+		converted.Provenance.RequestedAt = timestamppb.New(result.Provenance.RequestedAt)
+	}
+	if !result.Provenance.ResolvedAt.IsZero() {
+		converted.Provenance.ResolvedAt = timestamppb.New(result.Provenance.ResolvedAt)
+	}
+	if result.Provenance.CacheExpiresAt != nil {
+		converted.Provenance.CacheExpiresAt = timestamppb.New(*result.Provenance.CacheExpiresAt)
+	}
+	if len(result.ExtraData) > 0 {
+		// ExtraData may hold typed values structpb cannot take directly.
+		if payload, err := json.Marshal(result.ExtraData); err == nil {
+			if extra, err := toStruct(payload); err == nil {
+				converted.ExtraData = extra
+			}
+		}
+	}
+	return converted
+}
+
+func toSummary(results []*core.CheckResult) *namelensv1.CheckSummary {
+	summary := &namelensv1.CheckSummary{RiskLevel: "low"}
+	comTaken := false
+	for _, result := range results {
+		summary.Total++
+		if result == nil {
+			summary.Unknown++
+			continue
+		}
+		switch result.Available {
+		case core.AvailabilityAvailable:
+			summary.Available++
+		case core.AvailabilityTaken:
+			summary.Taken++
+			if result.CheckType == core.CheckTypeDomain && strings.HasSuffix(result.Name, ".com") {
+				comTaken = true
+			}
+		default:
+			summary.Unknown++
+		}
+	}
+	switch {
+	case comTaken:
+		summary.RiskLevel = "high"
+	case summary.Taken > 0:
+		summary.RiskLevel = "medium"
+	}
+	return summary
+}
+
+func toAnalysis(prompt string, data json.RawMessage, err error) *namelensv1.Analysis {
+	analysis := &namelensv1.Analysis{Prompt: prompt}
+	if err != nil {
+		analysis.ErrorMessage = err.Error()
+		var analysisErr *AnalysisError
+		if errors.As(err, &analysisErr) {
+			analysis.ErrorCode = analysisErr.Code
+		}
+		return analysis
+	}
+	result, err := toStruct(data)
+	if err != nil {
+		analysis.ErrorCode = "DECODE_ERROR"
+		analysis.ErrorMessage = err.Error()
+		return analysis
+	}
+	analysis.Ok = true
+	analysis.Data = result
+	return analysis
+}
+
+// toStruct decodes a JSON object into a protobuf Struct.
+func toStruct(data json.RawMessage) (*structpb.Struct, error) {
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return &structpb.Struct{}, nil
+	}
+	result := &structpb.Struct{}
+	if err := result.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,164 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	namelensv1 "github.com/namelens/namelens/api/namelens/v1"
+	"github.com/namelens/namelens/internal/api"
+	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/core/engine"
+)
+
+type domainChecker struct{}
+
+func (domainChecker) Check(ctx context.Context, name string) (*core.CheckResult, error) {
+	available := core.AvailabilityAvailable
+	if name == "acme.com" {
+		available = core.AvailabilityTaken
+	}
+	return &core.CheckResult{Name: name, CheckType: core.CheckTypeDomain, Available: available}, nil
+}
+
+func (domainChecker) Type() core.CheckType { return core.CheckTypeDomain }
+
+func (domainChecker) SupportsName(name string) bool { return name != "" }
+
+func dial(t *testing.T, srv *Server, auth api.AuthConfig) namelensv1.NamelensServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(UnaryAuthInterceptor(auth)))
+	namelensv1.RegisterNamelensServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return namelensv1.NewNamelensServiceClient(conn)
+}
+
+func newTestServer(analyze AnalyzeFunc, generate GenerateFunc) *Server {
+	orchestrator := &engine.Orchestrator{
+		Checkers: map[core.CheckType]engine.Checker{core.CheckTypeDomain: domainChecker{}},
+	}
+	return NewServer(orchestrator, analyze, generate)
+}
+
+func TestCheck(t *testing.T) {
+	client := dial(t, newTestServer(nil, nil), api.AuthConfig{})
+
+	resp, err := client.Check(context.Background(), &namelensv1.CheckRequest{Name: " acme ", Tlds: []string{"com", "IO"}})
+	require.NoError(t, err)
+	require.Equal(t, "acme", resp.GetName())
+	require.Len(t, resp.GetResults(), 2)
+	require.Equal(t, namelensv1.Availability_AVAILABILITY_TAKEN, resp.GetResults()[0].GetAvailable())
+	require.Equal(t, int32(1), resp.GetSummary().GetAvailable())
+	require.Equal(t, "high", resp.GetSummary().GetRiskLevel())
+
+	_, err = client.Check(context.Background(), &namelensv1.CheckRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Check(context.Background(), &namelensv1.CheckRequest{Name: "acme", Profile: "nope"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestReview(t *testing.T) {
+	var prompts []string
+	analyze := func(ctx context.Context, prompt, name, depth, model string) (json.RawMessage, error) {
+		prompts = append(prompts, prompt)
+		if prompt == "name-phonetics" {
+			return nil, &AnalysisError{Code: "AILINK_NO_API_KEY", Message: "provider api key not configured"}
+		}
+		return json.RawMessage(`{"summary":"ok"}`), nil
+	}
+	client := dial(t, newTestServer(analyze, nil), api.AuthConfig{})
+
+	resp, err := client.Review(context.Background(), &namelensv1.ReviewRequest{Name: "acme", Tlds: []string{"io"}})
+	require.NoError(t, err)
+	require.Equal(t, DefaultReviewPrompts, prompts)
+	require.Len(t, resp.GetAnalyses(), 3)
+	require.True(t, resp.GetAnalyses()[0].GetOk())
+	require.Equal(t, "ok", resp.GetAnalyses()[0].GetData().GetFields()["summary"].GetStringValue())
+	require.False(t, resp.GetAnalyses()[1].GetOk())
+	require.Equal(t, "AILINK_NO_API_KEY", resp.GetAnalyses()[1].GetErrorCode())
+}
+
+func TestGenerate(t *testing.T) {
+	var seen map[string]string
+	generate := func(ctx context.Context, prompt, depth, model string, variables map[string]string) (json.RawMessage, error) {
+		require.Equal(t, "name-alternatives", prompt)
+		seen = variables
+		return json.RawMessage(`{"candidates":[{"name":"acmely"}]}`), nil
+	}
+	client := dial(t, newTestServer(nil, generate), api.AuthConfig{})
+
+	resp, err := client.Generate(context.Background(), &namelensv1.GenerateRequest{Concept: "rocket parts", Tagline: " fast "})
+	require.NoError(t, err)
+	require.Equal(t, "rocket parts", seen["concept"])
+	require.Equal(t, "fast", seen["tagline"])
+	require.NotContains(t, seen, "description")
+	require.Len(t, resp.GetResult().GetFields()["candidates"].GetListValue().GetValues(), 1)
+
+	_, err = client.Review(context.Background(), &namelensv1.ReviewRequest{Name: "acme"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestAuthenticate(t *testing.T) {
+	cfg := api.AuthConfig{APIKey: "secret", AllowLocalhost: true}
+	remote := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.5"), Port: 4000}})
+	local := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 4000}})
+	method := namelensv1.NamelensService_Check_FullMethodName
+
+	_, err := authorizeMethod(local, cfg, method)
+	require.NoError(t, err)
+	_, err = authorizeMethod(remote, api.AuthConfig{}, method)
+	require.NoError(t, err)
+	_, err = authorizeMethod(remote, cfg, method)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	withKey := metadata.NewIncomingContext(remote, metadata.Pairs(apiKeyMetadata, "secret"))
+	principal, err := authenticate(withKey, cfg)
+	require.NoError(t, err)
+	require.Equal(t, api.RoleAdmin, principal.Role)
+
+	wrongKey := metadata.NewIncomingContext(local, metadata.Pairs(apiKeyMetadata, "wrong"))
+	_, err = authorizeMethod(wrongKey, cfg, method)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthorizeMethodRoles(t *testing.T) {
+	cfg := api.AuthConfig{Keys: map[string]api.Principal{
+		"dashboard": {Name: "dashboard", Role: api.RoleReadOnly},
+		"ci":        {Name: "ci", Role: api.RoleCheck},
+	}}
+	remote := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.5"), Port: 4000}})
+	dashboard := metadata.NewIncomingContext(remote, metadata.Pairs(apiKeyMetadata, "dashboard"))
+	ci := metadata.NewIncomingContext(remote, metadata.Pairs(apiKeyMetadata, "ci"))
+
+	_, err := authorizeMethod(dashboard, cfg, namelensv1.NamelensService_Check_FullMethodName)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	principal, err := authorizeMethod(ci, cfg, namelensv1.NamelensService_Check_FullMethodName)
+	require.NoError(t, err)
+	require.Equal(t, "ci", principal.Name)
+	_, err = authorizeMethod(ci, cfg, namelensv1.NamelensService_Review_FullMethodName)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = authorizeMethod(dashboard, cfg, "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo")
+	require.NoError(t, err)
+}
@@ -0,0 +1,99 @@
+// Package keychain stores credentials in the OS keychain: the macOS
+// Keychain, the Windows Credential Manager, or the Secret Service on Linux.
+//
+// Entries live under the namelens service, one account per credential.
+// Lookups are cached for the life of the process, so a keychain that is
+// locked or unavailable costs one attempt rather than one per config load.
+package keychain
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Service is the keychain service name for every namelens entry.
+const Service = "namelens"
+
+// GitHubAccount holds the token for the GitHub handle checker.
+const GitHubAccount = "github"
+
+// ErrNotFound is returned when no entry exists for an account.
+var ErrNotFound = keyring.ErrNotFound
+
+var (
+	mu    sync.Mutex
+	cache = map[string]lookup{}
+)
+
+type lookup struct {
+	secret string
+	err    error
+}
+
+// AIAccount returns the account for an AILink provider credential. A
+// labeled credential gets its own entry.
+func AIAccount(providerID, label string) string {
+	account := "ailink/" + strings.TrimSpace(providerID)
+	if label = strings.TrimSpace(label); label != "" {
+		account += "/" + label
+	}
+	return account
+}
+
+// Get returns the secret stored for account.
+func Get(account string) (string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if cached, ok := cache[account]; ok {
+		return cached.secret, cached.err
+	}
+	secret, err := keyring.Get(Service, account)
+	cache[account] = lookup{secret: secret, err: err}
+	return secret, err
+}
+
+// Lookup returns the secret stored for account, or "" when there is none or
+// the keychain is unavailable.
+func Lookup(account string) string {
+	secret, err := Get(account)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(secret)
+}
+
+// Set stores secret for account, replacing any existing entry.
+func Set(account, secret string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if err := keyring.Set(Service, account, secret); err != nil {
+		return err
+	}
+	cache[account] = lookup{secret: secret}
+	return nil
+}
+
+// Delete removes the entry for account. Deleting a missing entry returns
+// ErrNotFound.
+func Delete(account string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(cache, account)
+	err := keyring.Delete(Service, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// MockInit replaces the OS keychain with an in-memory store and clears the
+// lookup cache, for tests.
+func MockInit() {
+	mu.Lock()
+	defer mu.Unlock()
+	keyring.MockInit()
+	cache = map[string]lookup{}
+}
@@ -0,0 +1,28 @@
+package keychain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAIAccount(t *testing.T) {
+	require.Equal(t, "ailink/namelens-xai", AIAccount("namelens-xai", ""))
+	require.Equal(t, "ailink/namelens-xai/prod", AIAccount(" namelens-xai ", " prod "))
+}
+
+func TestSetLookupDelete(t *testing.T) {
+	MockInit()
+
+	require.Empty(t, Lookup(GitHubAccount))
+	_, err := Get(GitHubAccount)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Set replaces the cached miss.
+	require.NoError(t, Set(GitHubAccount, " ghp_token\n"))
+	require.Equal(t, "ghp_token", Lookup(GitHubAccount))
+
+	require.NoError(t, Delete(GitHubAccount))
+	require.Empty(t, Lookup(GitHubAccount))
+	require.ErrorIs(t, Delete(GitHubAccount), ErrNotFound)
+}
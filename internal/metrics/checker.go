@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// Checker metrics
+const (
+	ChecksTotal             = "checks_total"
+	CheckDuration           = "check_duration_ms"
+	RDAPServerErrorsTotal   = "rdap_server_errors_total"
+	CacheHitsTotal          = "cache_hits_total"
+	CacheMemoryLookupsTotal = "cache_memory_lookups_total"
+)
+
+// RecordCheck records a completed availability check. The duration is only
+// recorded for lookups, since cache hits would drag the histogram down.
+func RecordCheck(checkType, source, availability string, fromCache bool, duration time.Duration) {
+	if observability.TelemetrySystem == nil {
+		return
+	}
+	if source == "" {
+		source = "unknown"
+	}
+
+	_ = observability.TelemetrySystem.Counter(
+		ChecksTotal,
+		1,
+		map[string]string{
+			"type":         checkType,
+			"source":       source,
+			"availability": availability,
+		},
+	)
+
+	if fromCache {
+		_ = observability.TelemetrySystem.Counter(
+			CacheHitsTotal,
+			1,
+			map[string]string{
+				"type": checkType,
+			},
+		)
+		return
+	}
+
+	_ = observability.TelemetrySystem.Histogram(
+		CheckDuration,
+		duration,
+		map[string]string{
+			"type":   checkType,
+			"source": source,
+		},
+	)
+}
+
+// RecordRDAPServerError records an RDAP server that failed to answer, by
+// host and HTTP status; status 0 means the request itself failed.
+func RecordRDAPServerError(server string, status int) {
+	if observability.TelemetrySystem != nil {
+		_ = observability.TelemetrySystem.Counter(
+			RDAPServerErrorsTotal,
+			1,
+			map[string]string{
+				"server": server,
+				"status": strconv.Itoa(status),
+			},
+		)
+	}
+}
+
+// RecordMemoryCacheLookup records a lookup in the in-memory check cache as a
+// hit or a miss; misses fall through to the database.
+func RecordMemoryCacheLookup(hit bool) {
+	if observability.TelemetrySystem == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	_ = observability.TelemetrySystem.Counter(
+		CacheMemoryLookupsTotal,
+		1,
+		map[string]string{
+			"result": result,
+		},
+	)
+}
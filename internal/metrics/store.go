@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/namelens/namelens/internal/observability"
+)
+
+// Store connection pool metrics
+const (
+	StorePoolOpenConnections  = "store_pool_open_connections"
+	StorePoolInUseConnections = "store_pool_in_use_connections"
+	StorePoolIdleConnections  = "store_pool_idle_connections"
+	StorePoolWaitCount        = "store_pool_wait_count"
+	StorePoolWaitDuration     = "store_pool_wait_duration_ms"
+)
+
+// RecordStorePoolStats publishes a snapshot of the store's connection pool.
+// The wait count and duration are cumulative since the store was opened; a
+// rising wait count means queries are queuing for a connection.
+func RecordStorePoolStats(stats sql.DBStats) {
+	if observability.TelemetrySystem == nil {
+		return
+	}
+	gauges := map[string]float64{
+		StorePoolOpenConnections:  float64(stats.OpenConnections),
+		StorePoolInUseConnections: float64(stats.InUse),
+		StorePoolIdleConnections:  float64(stats.Idle),
+		StorePoolWaitCount:        float64(stats.WaitCount),
+		StorePoolWaitDuration:     float64(stats.WaitDuration.Milliseconds()),
+	}
+	for name, value := range gauges {
+		_ = observability.TelemetrySystem.Gauge(name, value, nil)
+	}
+}
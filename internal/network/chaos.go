@@ -0,0 +1,69 @@
+package network
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// ChaosTransport wraps an HTTP transport with synthetic latency and failures
+// so retries, fallbacks, and partial results can be exercised against
+// real endpoints. It is for testing only.
+type ChaosTransport struct {
+	Base http.RoundTripper
+	// FailureRate is the fraction of requests, from 0 to 1, that fail with a
+	// ChaosError instead of being sent.
+	FailureRate float64
+	// Latency is added before every request.
+	Latency time.Duration
+	// Rand returns values in [0, 1); nil uses math/rand.
+	Rand func() float64
+}
+
+// ChaosError is the network error returned for an injected failure. It is
+// temporary, like a reset connection, so callers treat it as retryable.
+type ChaosError struct {
+	Method string
+	Host   string
+}
+
+func (e *ChaosError) Error() string {
+	return fmt.Sprintf("chaos: injected failure for %s %s", e.Method, e.Host)
+}
+
+// Timeout reports false; injected failures are immediate.
+func (e *ChaosError) Timeout() bool { return false }
+
+// Temporary reports true.
+func (e *ChaosError) Temporary() bool { return true }
+
+// RoundTrip waits for Latency, then either fails the request or sends it
+// through Base.
+func (t *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Latency > 0 {
+		timer := time.NewTimer(t.Latency)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	if t.FailureRate > 0 && t.random() < t.FailureRate {
+		return nil, &ChaosError{Method: req.Method, Host: req.URL.Host}
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+func (t *ChaosTransport) random() float64 {
+	if t.Rand != nil {
+		return t.Rand()
+	}
+	return rand.Float64() // #nosec G404 -- fault injection, not security sensitive
+}
@@ -0,0 +1,70 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChaosTransportInjectsFailures(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	rolls := []float64{0.1, 0.9}
+	client := &http.Client{Transport: &ChaosTransport{
+		FailureRate: 0.5,
+		Rand: func() float64 {
+			roll := rolls[0]
+			rolls = rolls[1:]
+			return roll
+		},
+	}}
+
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr), "injected failures must look like network errors")
+	var chaosErr *ChaosError
+	require.True(t, errors.As(err, &chaosErr))
+	require.Equal(t, http.MethodGet, chaosErr.Method)
+	require.Zero(t, hits)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	require.Equal(t, 1, hits)
+}
+
+func TestChaosTransportAddsLatency(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &ChaosTransport{Latency: 50 * time.Millisecond}}
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// Latency honours the request context
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	client.Transport = &ChaosTransport{Latency: time.Minute}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,110 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IP strategies for outbound connections.
+const (
+	// StrategyRace dials IPv6 and IPv4 concurrently (Happy Eyeballs).
+	StrategyRace = "race"
+	// StrategyPreferIPv4 tries IPv4 addresses first and falls back to IPv6.
+	StrategyPreferIPv4 = "prefer_ipv4"
+	// StrategyPreferIPv6 tries IPv6 addresses first and falls back to IPv4.
+	StrategyPreferIPv6 = "prefer_ipv6"
+)
+
+const defaultDialTimeout = 30 * time.Second
+
+// Dialer dials outbound TCP connections using an IP strategy.
+type Dialer struct {
+	Strategy string
+	Timeout  time.Duration
+	// Proxy routes connections; see Proxy for the zero value.
+	Proxy Proxy
+}
+
+// NewDialer validates the strategy; empty means StrategyRace.
+func NewDialer(strategy string) (*Dialer, error) {
+	strategy = strings.ToLower(strings.TrimSpace(strategy))
+	switch strategy {
+	case "":
+		strategy = StrategyRace
+	case StrategyRace, StrategyPreferIPv4, StrategyPreferIPv6:
+	default:
+		return nil, fmt.Errorf("unknown ip strategy %q (use %s, %s, or %s)", strategy, StrategyRace, StrategyPreferIPv4, StrategyPreferIPv6)
+	}
+	return &Dialer{Strategy: strategy, Timeout: defaultDialTimeout}, nil
+}
+
+// WithProxy returns a copy of d that routes connections through p.
+func (d *Dialer) WithProxy(p Proxy) *Dialer {
+	copied := Dialer{Proxy: p}
+	if d != nil {
+		copied.Strategy, copied.Timeout = d.Strategy, d.Timeout
+	}
+	return &copied
+}
+
+// DialContext connects to address, through the proxy when it is a SOCKS5
+// proxy. HTTP proxies cannot carry raw TCP, so with one, or with the
+// environment default, the connection is direct.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if d != nil && d.Proxy.socks() {
+		return d.Proxy.dialSOCKS(ctx, d.dialDirect, network, address)
+	}
+	return d.dialDirect(ctx, network, address)
+}
+
+// dialDirect connects to address without a proxy. With a preference, the
+// preferred family is dialed first and the other is tried only if that fails,
+// so a host with broken IPv6 fails fast on IPv4 instead of timing out.
+func (d *Dialer) dialDirect(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: defaultDialTimeout}
+	strategy := StrategyRace
+	if d != nil {
+		if d.Timeout > 0 {
+			dialer.Timeout = d.Timeout
+		}
+		if d.Strategy != "" {
+			strategy = d.Strategy
+		}
+	}
+
+	if network != "tcp" || strategy == StrategyRace {
+		return dialer.DialContext(ctx, network, address)
+	}
+
+	order := []string{"tcp4", "tcp6"}
+	if strategy == StrategyPreferIPv6 {
+		order = []string{"tcp6", "tcp4"}
+	}
+	conn, err := dialer.DialContext(ctx, order[0], address)
+	if err == nil {
+		return conn, nil
+	}
+	if ctx.Err() != nil {
+		return nil, err
+	}
+	conn, fallbackErr := dialer.DialContext(ctx, order[1], address)
+	if fallbackErr != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Transport returns an HTTP transport with the default settings that dials
+// through d and sends requests through its proxy.
+func (d *Dialer) Transport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = d.dialDirect
+	if d != nil {
+		transport.Proxy = d.Proxy.httpProxy()
+	}
+	return transport
+}
@@ -0,0 +1,79 @@
+package network
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDialerStrategies(t *testing.T) {
+	dialer, err := NewDialer("")
+	require.NoError(t, err)
+	require.Equal(t, StrategyRace, dialer.Strategy)
+
+	dialer, err = NewDialer(" Prefer_IPv4 ")
+	require.NoError(t, err)
+	require.Equal(t, StrategyPreferIPv4, dialer.Strategy)
+
+	_, err = NewDialer("ipv5")
+	require.Error(t, err)
+}
+
+func TestDialerFallsBackToOtherFamily(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close() // nolint:errcheck // test cleanup
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	// The listener is IPv4-only, so preferring IPv6 must fall back.
+	dialer, err := NewDialer(StrategyPreferIPv6)
+	require.NoError(t, err)
+	conn, err := dialer.DialContext(context.Background(), "tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close() // nolint:errcheck // test cleanup
+	require.Equal(t, FamilyIPv4, AddressFamily(conn.RemoteAddr()))
+}
+
+func TestWithAddressFamilyRecordsHTTPConnection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	dialer, err := NewDialer(StrategyPreferIPv4)
+	require.NoError(t, err)
+	client := &http.Client{Transport: dialer.Transport()}
+
+	ctx, family := WithAddressFamily(context.Background())
+	require.Empty(t, family())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.Equal(t, FamilyIPv4, family())
+}
+
+func TestAddressFamily(t *testing.T) {
+	require.Equal(t, FamilyIPv4, AddressFamily(&net.TCPAddr{IP: net.ParseIP("192.0.2.1")}))
+	require.Equal(t, FamilyIPv6, AddressFamily(&net.TCPAddr{IP: net.ParseIP("2001:db8::1")}))
+	require.Equal(t, "", AddressFamily(nil))
+	require.Equal(t, "", AddressFamily(dohAddr("https://dns.example/dns-query")))
+
+	// RecordAddressFamily ignores contexts without a recorder.
+	RecordAddressFamily(context.Background(), &net.TCPAddr{IP: net.ParseIP("192.0.2.1")})
+}
@@ -0,0 +1,90 @@
+package network
+
+import (
+	"context"
+	"net"
+	"net/http/httptrace"
+	"sync"
+)
+
+// Address families reported in check provenance.
+const (
+	FamilyIPv4 = "ipv4"
+	FamilyIPv6 = "ipv6"
+)
+
+// AddressFamily reports whether addr is an IPv4 or IPv6 address, or "" when
+// it is neither.
+func AddressFamily(addr net.Addr) string {
+	var ip net.IP
+	switch a := addr.(type) {
+	case *net.TCPAddr:
+		ip = a.IP
+	case *net.UDPAddr:
+		ip = a.IP
+	case nil:
+		return ""
+	default:
+		host, _, err := net.SplitHostPort(a.String())
+		if err != nil {
+			return ""
+		}
+		ip = net.ParseIP(host)
+	}
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return FamilyIPv4
+	default:
+		return FamilyIPv6
+	}
+}
+
+type familyKey struct{}
+
+type familyRecorder struct {
+	mu     sync.Mutex
+	family string
+}
+
+func (r *familyRecorder) record(addr net.Addr) {
+	family := AddressFamily(addr)
+	if family == "" {
+		return
+	}
+	r.mu.Lock()
+	r.family = family
+	r.mu.Unlock()
+}
+
+// WithAddressFamily returns a context that records the address family of the
+// last connection used by HTTP requests or RecordAddressFamily calls made with
+// it, and a function reporting that family ("" when nothing connected).
+func WithAddressFamily(ctx context.Context) (context.Context, func() string) {
+	recorder := &familyRecorder{}
+	ctx = context.WithValue(ctx, familyKey{}, recorder)
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Conn != nil {
+				recorder.record(info.Conn.RemoteAddr())
+			}
+		},
+	})
+	return ctx, func() string {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		return recorder.family
+	}
+}
+
+// RecordAddressFamily notes a non-HTTP connection on a context from
+// WithAddressFamily. It is a no-op for other contexts.
+func RecordAddressFamily(ctx context.Context, addr net.Addr) {
+	if ctx == nil {
+		return
+	}
+	if recorder, ok := ctx.Value(familyKey{}).(*familyRecorder); ok {
+		recorder.record(addr)
+	}
+}
@@ -0,0 +1,109 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/proxy"
+)
+
+// ProxyDirect connects without a proxy, ignoring HTTP_PROXY and HTTPS_PROXY.
+const ProxyDirect = "direct"
+
+// Proxy routes outbound connections. The zero value follows the HTTP_PROXY,
+// HTTPS_PROXY, and NO_PROXY environment variables for HTTP requests and
+// connects raw TCP (WHOIS) directly.
+type Proxy struct {
+	// URL is an http, https, socks5, or socks5h proxy; nil uses the
+	// environment unless Direct is set.
+	URL    *url.URL
+	Direct bool
+}
+
+// ParseProxy parses a proxy setting: empty for the environment, "direct", or
+// a proxy URL.
+func ParseProxy(value string) (Proxy, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "":
+		return Proxy{}, nil
+	case ProxyDirect:
+		return Proxy{Direct: true}, nil
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		return Proxy{}, fmt.Errorf("invalid proxy %q: expected direct or a proxy URL", value)
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return Proxy{}, fmt.Errorf("invalid proxy %q: scheme must be http, https, socks5, or socks5h", value)
+	}
+	return Proxy{URL: parsed}, nil
+}
+
+// String returns the proxy URL without credentials, "direct", or
+// "environment".
+func (p Proxy) String() string {
+	switch {
+	case p.URL != nil:
+		return p.URL.Redacted()
+	case p.Direct:
+		return ProxyDirect
+	}
+	return "environment"
+}
+
+// socks reports whether the proxy can carry raw TCP connections.
+func (p Proxy) socks() bool {
+	if p.URL == nil {
+		return false
+	}
+	scheme := strings.ToLower(p.URL.Scheme)
+	return scheme == "socks5" || scheme == "socks5h"
+}
+
+// httpProxy returns the Proxy function for an http.Transport.
+func (p Proxy) httpProxy() func(*http.Request) (*url.URL, error) {
+	switch {
+	case p.URL != nil:
+		return http.ProxyURL(p.URL)
+	case p.Direct:
+		return nil
+	}
+	return http.ProxyFromEnvironment
+}
+
+// dialSOCKS connects to address through the SOCKS5 proxy, dialing the proxy
+// itself with forward. The proxy resolves the target hostname.
+func (p Proxy) dialSOCKS(ctx context.Context, forward dialFunc, network, address string) (net.Conn, error) {
+	var auth *proxy.Auth
+	if p.URL.User != nil {
+		password, _ := p.URL.User.Password()
+		auth = &proxy.Auth{User: p.URL.User.Username(), Password: password}
+	}
+	dialer, err := proxy.SOCKS5("tcp", p.URL.Host, auth, contextDialer(forward))
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dialer.(proxy.ContextDialer).DialContext(ctx, network, address)
+	if err != nil {
+		return nil, fmt.Errorf("socks5 proxy %s: %w", p.URL.Host, err)
+	}
+	return conn, nil
+}
+
+// contextDialer adapts a dial function to proxy.Dialer.
+type contextDialer dialFunc
+
+func (d contextDialer) Dial(network, address string) (net.Conn, error) {
+	return d(context.Background(), network, address)
+}
+
+func (d contextDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return d(ctx, network, address)
+}
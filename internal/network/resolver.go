@@ -0,0 +1,344 @@
+// Package network provides the DNS resolver shared by lookups that must not
+// depend on the system resolver, such as the DNS fallback and connectivity
+// diagnostics. Corporate split-horizon DNS often answers differently for
+// external names than public resolvers do.
+package network
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	dnsPort           = "53"
+	dotPort           = "853"
+	dohContentType    = "application/dns-message"
+	dohMaxResponse    = 65535
+	defaultDNSTimeout = 5 * time.Second
+)
+
+type upstream struct {
+	addr string // host:port for plain DNS or DNS-over-TLS
+	tls  bool   // RFC 7858 DNS-over-TLS to addr
+	doh  string // RFC 8484 endpoint URL
+}
+
+// NewResolver returns a resolver that sends queries to the given servers
+// instead of the system resolver. Entries are an IP or host with optional port
+// (plain DNS, port 53), a tls:// DNS-over-TLS server (port 853), or an
+// https:// DNS-over-HTTPS endpoint; comma-separated entries are split. Queries
+// rotate across servers so retries fail over. An empty list returns
+// net.DefaultResolver.
+//
+// DoH and DoT hostnames of well-known public resolvers (cloudflare-dns.com,
+// dns.google, dns.quad9.net) are dialed at their published addresses, so they
+// work where port 53 is blocked. Other hostnames are resolved by the system
+// resolver; use an IP address (https://1.1.1.1/dns-query, tls://1.1.1.1) to
+// avoid that.
+func NewResolver(servers []string) (*net.Resolver, error) {
+	return newResolver(servers, nil)
+}
+
+// newResolver is NewResolver with a base TLS configuration for DoT servers;
+// nil uses the system roots.
+func newResolver(servers []string, tlsConfig *tls.Config) (*net.Resolver, error) {
+	upstreams, err := parseServers(servers)
+	if err != nil {
+		return nil, err
+	}
+	if len(upstreams) == 0 {
+		return net.DefaultResolver, nil
+	}
+
+	dialer := &net.Dialer{Timeout: defaultDNSTimeout}
+	dial := bootstrapDial(dialer.DialContext)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dial
+	client := &http.Client{Timeout: defaultDNSTimeout, Transport: transport}
+	var next atomic.Uint32
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			start := int(next.Add(1) - 1)
+			var lastErr error
+			for i := range upstreams {
+				server := upstreams[(start+i)%len(upstreams)]
+				if server.doh != "" {
+					return &dohConn{ctx: ctx, client: client, endpoint: server.doh}, nil
+				}
+				var (
+					conn net.Conn
+					err  error
+				)
+				if server.tls {
+					conn, err = dialTLS(ctx, dial, tlsConfig, server.addr)
+				} else {
+					conn, err = dialer.DialContext(ctx, network, server.addr)
+				}
+				if err == nil {
+					return conn, nil
+				}
+				lastErr = err
+			}
+			return nil, lastErr
+		},
+	}, nil
+}
+
+// Servers reports the normalized resolver list, for diagnostics output.
+func Servers(servers []string) []string {
+	upstreams, err := parseServers(servers)
+	if err != nil {
+		return nil
+	}
+	result := make([]string, 0, len(upstreams))
+	for _, server := range upstreams {
+		switch {
+		case server.doh != "":
+			result = append(result, server.doh)
+		case server.tls:
+			result = append(result, "tls://"+server.addr)
+		default:
+			result = append(result, server.addr)
+		}
+	}
+	return result
+}
+
+func parseServers(servers []string) ([]upstream, error) {
+	upstreams := make([]upstream, 0, len(servers))
+	for _, entry := range servers {
+		for _, value := range strings.Split(entry, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			server, err := parseServer(value)
+			if err != nil {
+				return nil, err
+			}
+			upstreams = append(upstreams, server)
+		}
+	}
+	return upstreams, nil
+}
+
+func parseServer(value string) (upstream, error) {
+	if rest, ok := strings.CutPrefix(value, "tls://"); ok {
+		rest = strings.TrimSuffix(rest, "/")
+		host, port, err := net.SplitHostPort(rest)
+		if err != nil {
+			host, port = strings.Trim(rest, "[]"), dotPort
+		}
+		if host == "" || port == "" || strings.ContainsAny(host, "/ ") {
+			return upstream{}, fmt.Errorf("invalid DoT resolver %q: expected tls://host or tls://host:port", value)
+		}
+		return upstream{addr: net.JoinHostPort(host, port), tls: true}, nil
+	}
+	if strings.Contains(value, "://") {
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+			return upstream{}, fmt.Errorf("invalid DoH resolver %q: expected an http(s) URL", value)
+		}
+		return upstream{doh: parsed.String()}, nil
+	}
+
+	if ip := net.ParseIP(strings.Trim(value, "[]")); ip != nil {
+		return upstream{addr: net.JoinHostPort(ip.String(), dnsPort)}, nil
+	}
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		// A bare hostname without a port.
+		if strings.ContainsAny(value, ":/ ") {
+			return upstream{}, fmt.Errorf("invalid resolver %q: expected host, host:port, or https:// URL", value)
+		}
+		return upstream{addr: net.JoinHostPort(value, dnsPort)}, nil
+	}
+	if host == "" || port == "" {
+		return upstream{}, fmt.Errorf("invalid resolver %q: expected host, host:port, or https:// URL", value)
+	}
+	return upstream{addr: net.JoinHostPort(host, port)}, nil
+}
+
+// dialTLS opens a DNS-over-TLS connection. The resolver frames messages with
+// a two-byte length prefix on stream connections, as RFC 7858 requires.
+func dialTLS(ctx context.Context, dial dialFunc, base *tls.Config, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if base != nil {
+		config = base.Clone()
+	}
+	config.ServerName = host
+
+	raw, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := tls.Client(raw, config)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		_ = raw.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// bootstrapAddrs are the published addresses of well-known public DoH and DoT
+// resolvers, so their hostnames need no DNS lookup.
+var bootstrapAddrs = map[string][]string{
+	"cloudflare-dns.com": {"1.1.1.1", "1.0.0.1"},
+	"one.one.one.one":    {"1.1.1.1", "1.0.0.1"},
+	"dns.google":         {"8.8.8.8", "8.8.4.4"},
+	"dns.quad9.net":      {"9.9.9.9", "149.112.112.112"},
+}
+
+// bootstrapDial wraps dial so hosts in bootstrapAddrs are dialed at their
+// published addresses, trying each in turn.
+func bootstrapDial(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return dial(ctx, network, address)
+		}
+		addrs, ok := bootstrapAddrs[strings.ToLower(strings.TrimSuffix(host, "."))]
+		if !ok {
+			return dial(ctx, network, address)
+		}
+		var lastErr error
+		for _, addr := range addrs {
+			conn, err := dial(ctx, network, net.JoinHostPort(addr, port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	}
+}
+
+// dohConn carries the resolver's TCP-framed DNS messages (two-byte length
+// prefix) over DNS-over-HTTPS. Each complete query written is POSTed to the
+// endpoint and the answer is queued for the next reads.
+type dohConn struct {
+	ctx      context.Context
+	client   *http.Client
+	endpoint string
+
+	mu       sync.Mutex
+	deadline time.Time
+	pending  bytes.Buffer
+	answers  bytes.Buffer
+}
+
+func (c *dohConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pending.Write(b)
+	for c.pending.Len() >= 2 {
+		size := int(binary.BigEndian.Uint16(c.pending.Bytes()[:2]))
+		if c.pending.Len() < 2+size {
+			break
+		}
+		c.pending.Next(2)
+		query := make([]byte, size)
+		copy(query, c.pending.Next(size))
+
+		answer, err := c.exchange(query)
+		if err != nil {
+			return 0, err
+		}
+		var prefix [2]byte
+		binary.BigEndian.PutUint16(prefix[:], uint16(len(answer))) // #nosec G115 -- bounded by dohMaxResponse
+		c.answers.Write(prefix[:])
+		c.answers.Write(answer)
+	}
+	return len(b), nil
+}
+
+func (c *dohConn) Read(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.answers.Len() == 0 {
+		return 0, io.EOF
+	}
+	return c.answers.Read(b)
+}
+
+func (c *dohConn) exchange(query []byte) ([]byte, error) {
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !c.deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, c.deadline)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("doh %s: %w", c.endpoint, err)
+	}
+	defer resp.Body.Close() // nolint:errcheck // best-effort cleanup on HTTP response body
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("doh %s: unexpected status %d", c.endpoint, resp.StatusCode)
+	}
+	answer, err := io.ReadAll(io.LimitReader(resp.Body, dohMaxResponse))
+	if err != nil {
+		return nil, fmt.Errorf("doh %s: %w", c.endpoint, err)
+	}
+	if len(answer) < 12 {
+		return nil, errors.New("doh " + c.endpoint + ": short response")
+	}
+	return answer, nil
+}
+
+func (c *dohConn) Close() error { return nil }
+
+func (c *dohConn) LocalAddr() net.Addr { return dohAddr(c.endpoint) }
+
+func (c *dohConn) RemoteAddr() net.Addr { return dohAddr(c.endpoint) }
+
+func (c *dohConn) SetDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deadline = t
+	return nil
+}
+
+func (c *dohConn) SetReadDeadline(t time.Time) error { return c.SetDeadline(t) }
+
+func (c *dohConn) SetWriteDeadline(t time.Time) error { return c.SetDeadline(t) }
+
+type dohAddr string
+
+func (a dohAddr) Network() string { return "https" }
+
+func (a dohAddr) String() string { return string(a) }
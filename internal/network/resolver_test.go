@@ -0,0 +1,160 @@
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// dohAnswer answers A queries with 192.0.2.1 and everything else with no records.
+func dohAnswer(t *testing.T, query []byte) []byte {
+	t.Helper()
+	require.GreaterOrEqual(t, len(query), 12)
+
+	// Question ends after the name labels, QTYPE, and QCLASS.
+	end := 12
+	for query[end] != 0 {
+		end += int(query[end]) + 1
+	}
+	end += 5
+	qtype := binary.BigEndian.Uint16(query[end-4 : end-2])
+
+	answer := append([]byte{}, query[:end]...)
+	answer[2] = 0x81 // response, recursion desired
+	answer[3] = 0x80 // recursion available, NOERROR
+	binary.BigEndian.PutUint16(answer[6:8], 0)
+	binary.BigEndian.PutUint16(answer[8:10], 0)
+	binary.BigEndian.PutUint16(answer[10:12], 0)
+	if qtype == 1 {
+		binary.BigEndian.PutUint16(answer[6:8], 1)
+		answer = append(answer,
+			0xc0, 0x0c, // pointer to the question name
+			0x00, 0x01, 0x00, 0x01, // A, IN
+			0x00, 0x00, 0x00, 0x3c, // TTL 60
+			0x00, 0x04, 192, 0, 2, 1,
+		)
+	}
+	return answer
+}
+
+func TestNewResolverDoH(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, dohContentType, r.Header.Get("Content-Type"))
+		query, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", dohContentType)
+		_, _ = w.Write(dohAnswer(t, query))
+	}))
+	defer server.Close()
+
+	resolver, err := NewResolver([]string{server.URL + "/dns-query"})
+	require.NoError(t, err)
+
+	addrs, err := resolver.LookupHost(context.Background(), "example.test.")
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.0.2.1"}, addrs)
+}
+
+func TestNewResolverDoT(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", server.TLS)
+	require.NoError(t, err)
+	defer listener.Close() //nolint:errcheck
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close() //nolint:errcheck
+				for {
+					var prefix [2]byte
+					if _, err := io.ReadFull(conn, prefix[:]); err != nil {
+						return
+					}
+					query := make([]byte, binary.BigEndian.Uint16(prefix[:]))
+					if _, err := io.ReadFull(conn, query); err != nil {
+						return
+					}
+					answer := dohAnswer(t, query)
+					binary.BigEndian.PutUint16(prefix[:], uint16(len(answer)))
+					_, _ = conn.Write(append(prefix[:], answer...))
+				}
+			}()
+		}
+	}()
+
+	roots := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+	resolver, err := newResolver([]string{"tls://" + listener.Addr().String()}, &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12})
+	require.NoError(t, err)
+
+	addrs, err := resolver.LookupHost(context.Background(), "example.test.")
+	require.NoError(t, err)
+	require.Equal(t, []string{"192.0.2.1"}, addrs)
+
+	// The system roots do not trust the test certificate.
+	resolver, err = NewResolver([]string{"tls://" + listener.Addr().String()})
+	require.NoError(t, err)
+	_, err = resolver.LookupHost(context.Background(), "example.test.")
+	require.Error(t, err)
+}
+
+func TestBootstrapDial(t *testing.T) {
+	var dialed []string
+	dial := bootstrapDial(func(_ context.Context, _, address string) (net.Conn, error) {
+		dialed = append(dialed, address)
+		if address == "8.8.8.8:443" {
+			return nil, errors.New("unreachable")
+		}
+		client, server := net.Pipe()
+		_ = server.Close()
+		return client, nil
+	})
+
+	conn, err := dial(context.Background(), "tcp", "dns.google:443")
+	require.NoError(t, err)
+	_ = conn.Close()
+	conn, err = dial(context.Background(), "tcp", "doh.example.com:443")
+	require.NoError(t, err)
+	_ = conn.Close()
+
+	require.Equal(t, []string{"8.8.8.8:443", "8.8.4.4:443", "doh.example.com:443"}, dialed)
+}
+
+func TestNewResolverEmptyUsesSystem(t *testing.T) {
+	resolver, err := NewResolver([]string{" ", ""})
+	require.NoError(t, err)
+	require.Same(t, net.DefaultResolver, resolver)
+}
+
+func TestParseServers(t *testing.T) {
+	upstreams, err := parseServers([]string{"1.1.1.1, 9.9.9.9:5353", "2606:4700:4700::1111", "dns.example.com", "https://1.1.1.1/dns-query", "tls://1.1.1.1", "tls://dns.quad9.net:8853", "tls://2606:4700:4700::1111"})
+	require.NoError(t, err)
+	require.Equal(t, []upstream{
+		{addr: "1.1.1.1:53"},
+		{addr: "9.9.9.9:5353"},
+		{addr: "[2606:4700:4700::1111]:53"},
+		{addr: "dns.example.com:53"},
+		{doh: "https://1.1.1.1/dns-query"},
+		{addr: "1.1.1.1:853", tls: true},
+		{addr: "dns.quad9.net:8853", tls: true},
+		{addr: "[2606:4700:4700::1111]:853", tls: true},
+	}, upstreams)
+
+	for _, invalid := range []string{"ftp://dns.example.com", "https://", "bad host", "tls://", "tls://dns.example.com/dns-query"} {
+		_, err := parseServers([]string{invalid})
+		require.Error(t, err, invalid)
+	}
+}
@@ -6,7 +6,11 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/jedib0t/go-pretty/v6/table"
+
 	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/similarity"
+	"github.com/namelens/namelens/internal/wordscan"
 )
 
 type analysisSection struct {
@@ -24,6 +28,10 @@ type phoneticsSummary struct {
 	} `json:"pronunciation"`
 	Typeability struct {
 		OverallScore int `json:"overall_score"`
+		ByKeyboard   []struct {
+			Layout         string  `json:"layout"`
+			TravelDistance float64 `json:"travel_distance"`
+		} `json:"by_keyboard"`
 	} `json:"typeability"`
 	CLISuitability struct {
 		Score int `json:"score"`
@@ -31,6 +39,12 @@ type phoneticsSummary struct {
 	OverallAssessment struct {
 		Recommendation string `json:"recommendation"`
 	} `json:"overall_assessment"`
+	// Fields set only by the built-in engine
+	Engine            string   `json:"engine"`
+	ConsonantClusters []string `json:"consonant_clusters"`
+	SoundAlikes       []struct {
+		Word string `json:"word"`
+	} `json:"sound_alikes"`
 }
 
 type suitabilitySummary struct {
@@ -40,6 +54,17 @@ type suitabilitySummary struct {
 		Summary string `json:"summary"`
 	} `json:"overall_suitability"`
 	RiskAssessment map[string]riskLevel `json:"risk_assessment"`
+	Consensus      *consensusSummary    `json:"consensus"`
+}
+
+// consensusSummary is the part of a merged multi-model analysis shown in
+// reports.
+type consensusSummary struct {
+	Members       []json.RawMessage `json:"members"`
+	Answered      int               `json:"answered"`
+	Spread        int               `json:"spread"`
+	Disagreement  bool              `json:"disagreement"`
+	Disagreements []string          `json:"disagreements"`
 }
 
 type riskLevel struct {
@@ -51,16 +76,77 @@ func analysisSections(result *core.BatchResult) []analysisSection {
 		return nil
 	}
 
-	sections := make([]analysisSection, 0, 2)
+	sections := make([]analysisSection, 0, 3)
+	if section, ok := alternativesSection(result); ok {
+		sections = append(sections, section)
+	}
+	if section, ok := variantsSection(result); ok {
+		sections = append(sections, section)
+	}
 	if section, ok := phoneticsSection(result); ok {
 		sections = append(sections, section)
 	}
+	if section, ok := unfortunateWordsSection(result); ok {
+		sections = append(sections, section)
+	}
 	if section, ok := suitabilitySection(result); ok {
 		sections = append(sections, section)
 	}
 	return sections
 }
 
+func alternativesSection(result *core.BatchResult) (analysisSection, bool) {
+	if result == nil || len(result.Alternatives) == 0 {
+		return analysisSection{}, false
+	}
+
+	available := make([]string, 0, len(result.Alternatives))
+	other := make([]string, 0, len(result.Alternatives))
+	for _, alt := range result.Alternatives {
+		if alt == nil {
+			continue
+		}
+		line := fmt.Sprintf("%s: %s", alt.Name, statusLabel(alt))
+		if alt.Available == core.AvailabilityAvailable {
+			available = append(available, line)
+		} else {
+			other = append(other, line)
+		}
+	}
+	if len(available) == 0 && len(other) == 0 {
+		return analysisSection{}, false
+	}
+
+	return analysisSection{
+		Title: "Alternatives (.com taken)",
+		Lines: append(available, other...),
+	}, true
+}
+
+// variantsSection lists typo and look-alike variants, registered ones first,
+// with a count of the squat-risk domains already registered.
+func variantsSection(result *core.BatchResult) (analysisSection, bool) {
+	if result == nil || len(result.Variants) == 0 {
+		return analysisSection{}, false
+	}
+
+	registered := make([]string, 0, len(result.Variants))
+	other := make([]string, 0, len(result.Variants))
+	for _, variant := range result.Variants {
+		line := fmt.Sprintf("%s: %s (%s)", variant.Name, statusLabel(variant.Result), variant.Kind)
+		if variant.Result != nil && variant.Result.Available == core.AvailabilityTaken {
+			registered = append(registered, line)
+		} else {
+			other = append(other, line)
+		}
+	}
+
+	return analysisSection{
+		Title: fmt.Sprintf("Typo Variants (%d of %d .com registered)", len(registered), len(result.Variants)),
+		Lines: append(registered, other...),
+	}, true
+}
+
 func phoneticsSection(result *core.BatchResult) (analysisSection, bool) {
 	if result == nil {
 		return analysisSection{}, false
@@ -107,6 +193,29 @@ func phoneticsSection(result *core.BatchResult) (analysisSection, bool) {
 	if summary.CLISuitability.Score > 0 {
 		lines = append(lines, fmt.Sprintf("CLI suitability: %d/100", summary.CLISuitability.Score))
 	}
+
+	title := "Phonetics Analysis"
+	if summary.Engine == "builtin" {
+		title = "Phonetics Analysis (built-in)"
+		travel := make([]string, 0, len(summary.Typeability.ByKeyboard))
+		for _, keyboard := range summary.Typeability.ByKeyboard {
+			travel = append(travel, fmt.Sprintf("%s %.1f", keyboard.Layout, keyboard.TravelDistance))
+		}
+		if len(travel) > 0 {
+			lines = append(lines, fmt.Sprintf("Keyboard travel (keys): %s", strings.Join(travel, ", ")))
+		}
+		if len(summary.ConsonantClusters) > 0 {
+			lines = append(lines, fmt.Sprintf("Consonant clusters: %s", strings.Join(summary.ConsonantClusters, ", ")))
+		}
+		if len(summary.SoundAlikes) > 0 {
+			words := make([]string, 0, len(summary.SoundAlikes))
+			for _, alike := range summary.SoundAlikes {
+				words = append(words, alike.Word)
+			}
+			lines = append(lines, fmt.Sprintf("Sounds like: %s", strings.Join(words, ", ")))
+		}
+	}
+
 	if strings.TrimSpace(summary.OverallAssessment.Recommendation) != "" {
 		lines = append(lines, fmt.Sprintf("Notes: %s", summary.OverallAssessment.Recommendation))
 	}
@@ -114,7 +223,29 @@ func phoneticsSection(result *core.BatchResult) (analysisSection, bool) {
 		lines = append(lines, "analysis complete")
 	}
 
-	return analysisSection{Title: "Phonetics Analysis", Lines: lines}, true
+	return analysisSection{Title: title, Lines: lines}, true
+}
+
+// unfortunateWordsSection lists words hidden in the name that read badly in
+// a scanned language, most severe first.
+func unfortunateWordsSection(result *core.BatchResult) (analysisSection, bool) {
+	if result == nil || len(result.UnfortunateWords) == 0 {
+		return analysisSection{}, false
+	}
+
+	lines := make([]string, 0, len(result.UnfortunateWords))
+	for _, finding := range result.UnfortunateWords {
+		languages := make([]string, 0, len(finding.Languages))
+		for _, code := range finding.Languages {
+			languages = append(languages, wordscan.LanguageName(code))
+		}
+		line := fmt.Sprintf("%q: %s (%s, %s)", finding.Word, finding.Meaning, strings.Join(languages, "/"), finding.Severity)
+		if !finding.Boundary {
+			line += ", inside a word"
+		}
+		lines = append(lines, line)
+	}
+	return analysisSection{Title: "Unfortunate Words", Lines: lines}, true
 }
 
 func suitabilitySection(result *core.BatchResult) (analysisSection, bool) {
@@ -164,6 +295,16 @@ func suitabilitySection(result *core.BatchResult) (analysisSection, bool) {
 	if riskLine != "" {
 		lines = append(lines, riskLine)
 	}
+	if consensus := summary.Consensus; consensus != nil {
+		line := fmt.Sprintf("Consensus: %d/%d models, score spread %d", consensus.Answered, len(consensus.Members), consensus.Spread)
+		if consensus.Disagreement {
+			line += " (disagreement)"
+		}
+		lines = append(lines, line)
+		for _, disagreement := range consensus.Disagreements {
+			lines = append(lines, fmt.Sprintf("Disagree: %s", disagreement))
+		}
+	}
 
 	if strings.TrimSpace(summary.OverallSuitability.Summary) != "" {
 		lines = append(lines, fmt.Sprintf("Notes: %s", summary.OverallSuitability.Summary))
@@ -224,3 +365,86 @@ func renderAnalysisSections(sections []analysisSection, markdown bool) string {
 	}
 	return sb.String()
 }
+
+// renderLocaleMatrix renders a name's per-locale suitability as a locale ×
+// rating table.
+func renderLocaleMatrix(matrix []core.LocaleSuitability, markdown bool) string {
+	if len(matrix) == 0 {
+		return ""
+	}
+
+	if markdown {
+		var sb strings.Builder
+		sb.WriteString("\n\n### Locale Suitability\n\n")
+		sb.WriteString("| Locale | Score | Rating | Concerns |\n")
+		sb.WriteString("|--------|-------|--------|----------|\n")
+		for _, row := range matrix {
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+				escapeMarkdownCell(row.Locale),
+				localeScoreLabel(row),
+				escapeMarkdownCell(row.Rating),
+				escapeMarkdownCell(localeConcernsLabel(row)),
+			)
+		}
+		return sb.String()
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Locale", "Score", "Rating", "Concerns"})
+	for _, row := range matrix {
+		t.AppendRow(table.Row{row.Locale, localeScoreLabel(row), row.Rating, localeConcernsLabel(row)})
+	}
+	return "\n\nLocale Suitability:\n" + t.Render()
+}
+
+func localeScoreLabel(row core.LocaleSuitability) string {
+	if row.Score == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d/100", *row.Score)
+}
+
+// localeConcernsLabel shows the first concern and how many more there are.
+func localeConcernsLabel(row core.LocaleSuitability) string {
+	switch len(row.Concerns) {
+	case 0:
+		return ""
+	case 1:
+		return row.Concerns[0]
+	default:
+		return fmt.Sprintf("%s (+%d more)", row.Concerns[0], len(row.Concerns)-1)
+	}
+}
+
+// renderSimilarBrands renders the existing brands a name is confusingly close
+// to.
+func renderSimilarBrands(matches []similarity.Match, markdown bool) string {
+	if len(matches) == 0 {
+		return ""
+	}
+
+	if markdown {
+		var sb strings.Builder
+		sb.WriteString("\n\n### Similar Brands\n\n")
+		sb.WriteString("| Brand | Score | Match | Source |\n")
+		sb.WriteString("|-------|-------|-------|--------|\n")
+		for _, match := range matches {
+			fmt.Fprintf(&sb, "| %s | %d/100 | %s | %s |\n",
+				escapeMarkdownCell(match.Brand),
+				match.Score,
+				strings.Join(match.Metrics, ", "),
+				match.Source,
+			)
+		}
+		return sb.String()
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Brand", "Score", "Match", "Source"})
+	for _, match := range matches {
+		t.AppendRow(table.Row{match.Brand, fmt.Sprintf("%d/100", match.Score), strings.Join(match.Metrics, ", "), match.Source})
+	}
+	return "\n\nSimilar Brands:\n" + t.Render()
+}
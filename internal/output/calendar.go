@@ -0,0 +1,158 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// icsLineLimit is the longest content line RFC 5545 allows, in octets,
+// before it must be folded.
+const icsLineLimit = 75
+
+// ExpirationEvent is a domain expiration, or an expected drop of a deleted
+// domain, rendered as a calendar event.
+type ExpirationEvent struct {
+	Domain    string
+	Relation  string
+	Registrar string
+	Expires   time.Time
+	// Drop marks an expected release of a domain in redemption or
+	// pendingDelete; the event spans Expires through DropLatest.
+	Drop       bool
+	DropLatest time.Time
+	// Reminder overrides the feed reminder when above zero.
+	Reminder time.Duration
+}
+
+// WriteICS renders events as an iCalendar feed of all-day events on each
+// expiration date or drop window. A reminder above zero adds a display alarm
+// that long before each event. stamp is the DTSTAMP of every event.
+func WriteICS(w io.Writer, events []ExpirationEvent, reminder time.Duration, stamp time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//namelens//domain expirations//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Domain expirations",
+	}
+	for _, event := range events {
+		day := event.Expires.UTC()
+		end := day
+		summary := event.Domain + " expires"
+		description := fmt.Sprintf("%s expires %s.", event.Domain, day.Format(time.RFC3339))
+		if event.Relation != "" {
+			description = fmt.Sprintf("%s domain %s", capitalize(event.Relation), description)
+		}
+		uid := fmt.Sprintf("%s-%s@namelens", event.Domain, day.Format("20060102"))
+		if event.Drop {
+			if event.DropLatest.After(day) {
+				end = event.DropLatest.UTC()
+			}
+			summary = event.Domain + " may drop"
+			description = fmt.Sprintf("%s is expected to be released between %s and %s.",
+				event.Domain, day.Format(time.RFC3339), end.Format(time.RFC3339))
+			uid = fmt.Sprintf("%s-drop-%s@namelens", event.Domain, day.Format("20060102"))
+		}
+		if event.Registrar != "" {
+			description += "\nRegistrar: " + event.Registrar
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+uid,
+			"DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"),
+			"DTSTART;VALUE=DATE:"+day.Format("20060102"),
+			"DTEND;VALUE=DATE:"+end.AddDate(0, 0, 1).Format("20060102"),
+			"SUMMARY:"+icsText(summary),
+			"DESCRIPTION:"+icsText(description),
+			"TRANSP:TRANSPARENT",
+		)
+		alarm := reminder
+		if event.Reminder > 0 {
+			alarm = event.Reminder
+		}
+		if alarm > 0 {
+			lines = append(lines,
+				"BEGIN:VALARM",
+				"ACTION:DISPLAY",
+				"DESCRIPTION:"+icsText(fmt.Sprintf("%s in %s", summary, humanDuration(alarm))),
+				"TRIGGER:-"+icsDuration(alarm),
+				"END:VALARM",
+			)
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICSLine(line))
+		b.WriteString("\r\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// icsDuration formats d as an RFC 5545 duration, in whole days when possible.
+func icsDuration(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("P%dD", d/(24*time.Hour))
+	}
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+	if minutes == 0 {
+		return fmt.Sprintf("PT%dH", hours)
+	}
+	return fmt.Sprintf("PT%dH%dM", hours, minutes)
+}
+
+func humanDuration(d time.Duration) string {
+	switch {
+	case d == 24*time.Hour:
+		return "1 day"
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%d days", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%d hours", d/time.Hour)
+	default:
+		return d.String()
+	}
+}
+
+// icsText escapes a TEXT property value.
+func icsText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// foldICSLine splits lines longer than icsLineLimit octets into CRLF plus
+// space continuations without breaking UTF-8 sequences.
+func foldICSLine(line string) string {
+	if len(line) <= icsLineLimit {
+		return line
+	}
+	var b strings.Builder
+	width := 0
+	limit := icsLineLimit
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 0
+			// Continuation lines spend one octet on the leading space.
+			limit = icsLineLimit - 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+func capitalize(value string) string {
+	if value == "" {
+		return value
+	}
+	return strings.ToUpper(value[:1]) + value[1:]
+}
@@ -0,0 +1,106 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// csvHeader are the columns every CSV row starts with.
+var csvHeader = []string{
+	"name", "check_type", "target", "status", "confidence",
+	"score", "total", "unknown",
+	"typeability", "suitability", "suitability_rating", "notes",
+}
+
+// CSVFormatter renders results as one flat table for spreadsheets: a row per
+// name and target, with the name's scores repeated on each row so the sheet
+// can be filtered and pivoted without joins. A name without targets still
+// gets a row.
+type CSVFormatter struct {
+	// ExtraHeader names columns appended after the standard ones.
+	ExtraHeader []string
+	// Extra holds the ExtraHeader values for each name, keyed by batch name.
+	Extra map[string][]string
+}
+
+// FormatBatch renders a single batch result with a header row.
+func (f *CSVFormatter) FormatBatch(result *core.BatchResult) (string, error) {
+	if result == nil {
+		return "", nil
+	}
+	return f.FormatBatches([]*core.BatchResult{result})
+}
+
+// FormatBatches renders several batch results as one table.
+func (f *CSVFormatter) FormatBatches(results []*core.BatchResult) (string, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	if err := w.Write(append(append([]string{}, csvHeader...), f.ExtraHeader...)); err != nil {
+		return "", err
+	}
+
+	for _, batch := range results {
+		if batch == nil {
+			continue
+		}
+		scores := csvScores(batch)
+		extra := f.extra(batch.Name)
+
+		wrote := false
+		for _, result := range batch.Results {
+			if result == nil {
+				continue
+			}
+			row := []string{batch.Name, string(result.CheckType), displayName(result), statusLabel(result), confidenceLabel(result)}
+			row = append(row, scores...)
+			row = append(row, formatNotes(result))
+			if err := w.Write(append(row, extra...)); err != nil {
+				return "", err
+			}
+			wrote = true
+		}
+		if !wrote {
+			row := append([]string{batch.Name, "", "", "", ""}, scores...)
+			if err := w.Write(append(append(row, ""), extra...)); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// extra returns the extra column values for name, padded to ExtraHeader.
+func (f *CSVFormatter) extra(name string) []string {
+	values := make([]string, len(f.ExtraHeader))
+	copy(values, f.Extra[name])
+	return values
+}
+
+// csvScores returns the score, total, unknown, typeability, suitability, and
+// suitability rating columns of batch; analysis scores are empty when the
+// analysis was not run.
+func csvScores(batch *core.BatchResult) []string {
+	scores := []string{strconv.Itoa(batch.Score), strconv.Itoa(batch.Total), strconv.Itoa(batch.Unknown), "", "", ""}
+
+	var phonetics phoneticsSummary
+	if len(batch.Phonetics) > 0 && json.Unmarshal(batch.Phonetics, &phonetics) == nil && phonetics.Typeability.OverallScore > 0 {
+		scores[3] = strconv.Itoa(phonetics.Typeability.OverallScore)
+	}
+	var suitability suitabilitySummary
+	if len(batch.Suitability) > 0 && json.Unmarshal(batch.Suitability, &suitability) == nil {
+		if suitability.OverallSuitability.Score > 0 {
+			scores[4] = strconv.Itoa(suitability.OverallSuitability.Score)
+		}
+		scores[5] = suitability.OverallSuitability.Rating
+	}
+	return scores
+}
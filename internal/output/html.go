@@ -0,0 +1,285 @@
+package output
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// HTMLFormatter renders results as a self-contained HTML report: styled
+// tables, scores, AI analysis summaries, and numbered provenance footnotes,
+// with no external assets, so the file can be mailed or printed to PDF.
+type HTMLFormatter struct {
+	// Title heads the report; empty uses the checked names.
+	Title string
+}
+
+// FormatBatch renders a single batch result as a report.
+func (f *HTMLFormatter) FormatBatch(result *core.BatchResult) (string, error) {
+	if result == nil {
+		return "", nil
+	}
+	return f.FormatBatches([]*core.BatchResult{result})
+}
+
+// FormatBatches renders several batch results as one report.
+func (f *HTMLFormatter) FormatBatches(results []*core.BatchResult) (string, error) {
+	var sb strings.Builder
+	report := HTMLReport{Title: f.Title, Batches: results}
+	if err := report.Render(&sb); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// HTMLReport is a report page for commands that add their own tables to the
+// per-name sections, such as review and compare.
+type HTMLReport struct {
+	// Title heads the report; empty uses the names of Batches.
+	Title string
+	// GeneratedAt is shown under the title; zero uses the current time.
+	GeneratedAt time.Time
+	// Overview is an optional table above the per-name sections.
+	Overview *HTMLTable
+	Batches  []*core.BatchResult
+	// Extras are tables appended to a name's section, keyed by batch name.
+	Extras map[string][]HTMLTable
+}
+
+// HTMLTable is a titled table of plain text cells.
+type HTMLTable struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+	// Note is a line of small print under the table.
+	Note string
+}
+
+type htmlPage struct {
+	Title       string
+	GeneratedAt time.Time
+	Overview    *HTMLTable
+	Names       []htmlName
+	Sources     []htmlSource
+}
+
+type htmlName struct {
+	Name     string
+	Score    string
+	Rating   string
+	Rows     []htmlRow
+	Sections []analysisSection
+	Tables   []HTMLTable
+}
+
+type htmlRow struct {
+	Type       string
+	Name       string
+	Status     string
+	Class      string
+	Confidence string
+	Notes      string
+	Source     int
+}
+
+type htmlSource struct {
+	N    int
+	Text string
+}
+
+// Render writes the report.
+func (r HTMLReport) Render(w io.Writer) error {
+	page := htmlPage{
+		Title:       r.Title,
+		GeneratedAt: r.GeneratedAt,
+		Overview:    r.Overview,
+	}
+	if page.GeneratedAt.IsZero() {
+		page.GeneratedAt = time.Now()
+	}
+
+	names := make([]string, 0, len(r.Batches))
+	for _, batch := range r.Batches {
+		if batch == nil {
+			continue
+		}
+		names = append(names, batch.Name)
+		page.Names = append(page.Names, page.nameSection(batch, r.Extras[batch.Name]))
+	}
+	if page.Title == "" {
+		page.Title = "Naming report: " + strings.Join(names, ", ")
+	}
+	return htmlReportTemplate.Execute(w, page)
+}
+
+func (p *htmlPage) nameSection(batch *core.BatchResult, extras []HTMLTable) htmlName {
+	section := htmlName{
+		Name:     batch.Name,
+		Rating:   htmlRating(batch),
+		Sections: analysisSections(batch),
+		Tables:   extras,
+	}
+	if batch.Total > 0 || batch.Unknown > 0 {
+		section.Score = fmt.Sprintf("%d/%d available", batch.Score, batch.Total)
+		if batch.Unknown > 0 {
+			section.Score += fmt.Sprintf(", %d unknown", batch.Unknown)
+		}
+	}
+
+	for _, result := range batch.Results {
+		if result == nil {
+			continue
+		}
+		section.Rows = append(section.Rows, htmlRow{
+			Type:       string(result.CheckType),
+			Name:       displayName(result),
+			Status:     statusLabel(result),
+			Class:      result.Available.String(),
+			Confidence: confidenceLabel(result),
+			Notes:      formatNotes(result),
+			Source:     p.addSource(result),
+		})
+	}
+	if rowType, name, status, notes, ok := expertRow(batch); ok {
+		section.Rows = append(section.Rows, htmlRow{Type: rowType, Name: name, Status: status, Notes: notes})
+	}
+
+	if len(batch.LocaleMatrix) > 0 {
+		table := HTMLTable{Title: "Locale Suitability", Header: []string{"Locale", "Score", "Rating", "Concerns"}}
+		for _, row := range batch.LocaleMatrix {
+			table.Rows = append(table.Rows, []string{row.Locale, localeScoreLabel(row), row.Rating, strings.Join(row.Concerns, "; ")})
+		}
+		section.Tables = append([]HTMLTable{table}, section.Tables...)
+	}
+	if len(batch.SimilarBrands) > 0 {
+		table := HTMLTable{Title: "Similar Brands", Header: []string{"Brand", "Score", "Match", "Source"}}
+		for _, match := range batch.SimilarBrands {
+			table.Rows = append(table.Rows, []string{match.Brand, fmt.Sprintf("%d/100", match.Score), strings.Join(match.Metrics, ", "), match.Source})
+		}
+		section.Tables = append([]HTMLTable{table}, section.Tables...)
+	}
+	return section
+}
+
+// addSource records where a result came from as a footnote and returns its
+// number, or 0 when the result carries no provenance.
+func (p *htmlPage) addSource(result *core.CheckResult) int {
+	prov := result.Provenance
+	if prov.Source == "" {
+		return 0
+	}
+
+	text := displayName(result) + ": " + prov.Source
+	if prov.Server != "" {
+		text += " via " + prov.Server
+	}
+	if !prov.ResolvedAt.IsZero() {
+		text += ", resolved " + prov.ResolvedAt.UTC().Format("2006-01-02 15:04 MST")
+	}
+	if prov.FromCache {
+		text += " (cached"
+		if prov.CacheExpiresAt != nil {
+			text += " until " + prov.CacheExpiresAt.UTC().Format("2006-01-02 15:04 MST")
+		}
+		if prov.CacheStale {
+			text += ", stale"
+		}
+		text += ")"
+	}
+
+	n := len(p.Sources) + 1
+	p.Sources = append(p.Sources, htmlSource{N: n, Text: text})
+	return n
+}
+
+// htmlRating colors a name's score: every target available, none, or some.
+func htmlRating(batch *core.BatchResult) string {
+	switch {
+	case batch.Total == 0:
+		return ""
+	case batch.Score == batch.Total:
+		return "available"
+	case batch.Score == 0:
+		return "taken"
+	default:
+		return "partial"
+	}
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 MST") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · namelens</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 60rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin: 0.5rem 0 1.5rem; }
+th, td { text-align: left; vertical-align: top; padding: 0.3rem 0.6rem; border-bottom: 1px solid #ddd; }
+th { background: #f5f5f5; }
+.available { color: #17803d; } .taken { color: #b42318; } .partial { color: #b54708; }
+.score { font-size: 1rem; font-weight: normal; margin-left: 0.5rem; }
+.meta, .sources { color: #666; font-size: 0.9rem; }
+sup a { color: #666; text-decoration: none; }
+section { margin-bottom: 2rem; }
+@media print { body { margin: 0; max-width: none; } section { break-inside: avoid-page; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">Generated {{date .GeneratedAt}} by namelens.</p>
+{{- with .Overview}}
+{{template "table" .}}
+{{- end}}
+{{- range .Names}}
+<section>
+<h2>{{.Name}}{{if .Score}} <span class="score {{.Rating}}">{{.Score}}</span>{{end}}</h2>
+{{- if .Rows}}
+<table>
+<tr><th>Type</th><th>Name</th><th>Status</th><th>Confidence</th><th>Notes</th></tr>
+{{- range .Rows}}
+<tr><td>{{.Type}}</td><td>{{.Name}}{{if .Source}}<sup><a href="#source-{{.Source}}">{{.Source}}</a></sup>{{end}}</td><td class="{{.Class}}">{{.Status}}</td><td>{{.Confidence}}</td><td>{{.Notes}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- range .Sections}}
+<h3>{{.Title}}</h3>
+<ul>
+{{- range .Lines}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- range .Tables}}
+{{template "table" .}}
+{{- end}}
+</section>
+{{- end}}
+{{- if .Sources}}
+<h2>Sources</h2>
+<ol class="sources">
+{{- range .Sources}}
+<li id="source-{{.N}}">{{.Text}}</li>
+{{- end}}
+</ol>
+{{- end}}
+</body>
+</html>
+{{define "table"}}
+{{- if .Title}}<h3>{{.Title}}</h3>{{end}}
+<table>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{- range .Rows}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- if .Note}}
+<p class="meta">{{.Note}}</p>
+{{- end}}
+{{- end}}`))
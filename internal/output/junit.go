@@ -0,0 +1,178 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// JUnitPolicy decides how JUnit output reports lookups that did not reach an
+// answer: errors, rate limits, and unknown results.
+type JUnitPolicy string
+
+const (
+	// JUnitPolicyError reports them as test errors (the default).
+	JUnitPolicyError JUnitPolicy = "error"
+	// JUnitPolicyFailure reports them as failures, like taken targets.
+	JUnitPolicyFailure JUnitPolicy = "failure"
+	// JUnitPolicySkip reports them as skipped tests.
+	JUnitPolicySkip JUnitPolicy = "skip"
+)
+
+// ParseJUnitPolicy validates and normalizes a policy string.
+func ParseJUnitPolicy(value string) (JUnitPolicy, error) {
+	switch normalized := JUnitPolicy(strings.ToLower(strings.TrimSpace(value))); normalized {
+	case "", JUnitPolicyError:
+		return JUnitPolicyError, nil
+	case JUnitPolicyFailure, JUnitPolicySkip:
+		return normalized, nil
+	default:
+		return "", fmt.Errorf("unsupported junit policy: %s (use error, failure, skip)", value)
+	}
+}
+
+// JUnitFormatter renders results as a JUnit XML report for CI test report
+// views: a test suite per name and a test case per target. Available targets
+// pass, taken ones fail, unsupported ones are skipped, and lookups without an
+// answer follow Incomplete.
+type JUnitFormatter struct {
+	Incomplete JUnitPolicy
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	Classname string       `xml:"classname,attr"`
+	Time      string       `xml:"time,attr"`
+	Failure   *junitResult `xml:"failure,omitempty"`
+	Error     *junitResult `xml:"error,omitempty"`
+	Skipped   *junitResult `xml:"skipped,omitempty"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// FormatBatch renders a single batch result as a report.
+func (f *JUnitFormatter) FormatBatch(result *core.BatchResult) (string, error) {
+	if result == nil {
+		return "", nil
+	}
+	return f.FormatBatches([]*core.BatchResult{result})
+}
+
+// FormatBatches renders several batch results as one report.
+func (f *JUnitFormatter) FormatBatches(results []*core.BatchResult) (string, error) {
+	report := junitTestSuites{Name: "namelens"}
+	var seconds float64
+	for _, batch := range results {
+		if batch == nil {
+			continue
+		}
+		suite, suiteSeconds := f.suite(batch)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+		seconds += suiteSeconds
+	}
+	report.Time = junitSeconds(seconds)
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data) + "\n", nil
+}
+
+func (f *JUnitFormatter) suite(batch *core.BatchResult) (junitTestSuite, float64) {
+	suite := junitTestSuite{
+		Name: batch.Name,
+		Properties: []junitProperty{
+			{Name: "score", Value: fmt.Sprintf("%d/%d", batch.Score, batch.Total)},
+		},
+	}
+	var seconds float64
+	for _, result := range batch.Results {
+		if result == nil {
+			continue
+		}
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", result.CheckType, displayName(result)),
+			Classname: "namelens." + batch.Name,
+		}
+		if prov := result.Provenance; !prov.RequestedAt.IsZero() && prov.ResolvedAt.After(prov.RequestedAt) {
+			elapsed := prov.ResolvedAt.Sub(prov.RequestedAt).Seconds()
+			testCase.Time = junitSeconds(elapsed)
+			seconds += elapsed
+		} else {
+			testCase.Time = junitSeconds(0)
+		}
+
+		outcome := &junitResult{Message: displayName(result) + ": " + statusLabel(result), Type: result.Available.String(), Text: formatNotes(result)}
+		switch result.Available {
+		case core.AvailabilityAvailable:
+		case core.AvailabilityTaken:
+			testCase.Failure = outcome
+		case core.AvailabilityUnsupported:
+			testCase.Skipped = outcome
+		default:
+			switch f.Incomplete {
+			case JUnitPolicyFailure:
+				testCase.Failure = outcome
+			case JUnitPolicySkip:
+				testCase.Skipped = outcome
+			default:
+				testCase.Error = outcome
+			}
+		}
+
+		suite.Tests++
+		switch {
+		case testCase.Failure != nil:
+			suite.Failures++
+		case testCase.Error != nil:
+			suite.Errors++
+		case testCase.Skipped != nil:
+			suite.Skipped++
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = junitSeconds(seconds)
+	return suite, seconds
+}
+
+func junitSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
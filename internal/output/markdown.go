@@ -18,17 +18,18 @@ func (f *MarkdownFormatter) FormatBatch(result *core.BatchResult) (string, error
 
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "## %s availability\n\n", escapeMarkdownCell(result.Name))
-	sb.WriteString("| Type | Name | Status | Notes |\n")
-	sb.WriteString("|------|------|--------|-------|\n")
+	sb.WriteString("| Type | Name | Status | Confidence | Notes |\n")
+	sb.WriteString("|------|------|--------|------------|-------|\n")
 
 	for _, r := range result.Results {
 		if r == nil {
 			continue
 		}
-		fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %s |\n",
 			escapeMarkdownCell(string(r.CheckType)),
 			escapeMarkdownCell(displayName(r)),
 			escapeMarkdownCell(statusLabel(r)),
+			escapeMarkdownCell(confidenceLabel(r)),
 			escapeMarkdownCell(formatNotes(r)),
 		)
 	}
@@ -36,7 +37,7 @@ func (f *MarkdownFormatter) FormatBatch(result *core.BatchResult) (string, error
 	if result.AILink != nil || result.AILinkError != nil {
 		rowType, name, status, notes, ok := expertRow(result)
 		if ok {
-			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+			fmt.Fprintf(&sb, "| %s | %s | %s |  | %s |\n",
 				escapeMarkdownCell(rowType),
 				escapeMarkdownCell(name),
 				escapeMarkdownCell(status),
@@ -54,6 +55,8 @@ func (f *MarkdownFormatter) FormatBatch(result *core.BatchResult) (string, error
 	}
 
 	sb.WriteString(renderAnalysisSections(analysisSections(result), true))
+	sb.WriteString(renderLocaleMatrix(result.LocaleMatrix, true))
+	sb.WriteString(renderSimilarBrands(result.SimilarBrands, true))
 	return sb.String(), nil
 }
 
@@ -27,6 +27,9 @@ func expertRow(result *core.BatchResult) (string, string, string, string, bool)
 	if level := strings.TrimSpace(result.AILink.RiskLevel); level != "" {
 		status = "risk: " + level
 	}
+	if result.AILink.Suspect {
+		status = "suspect"
+	}
 
 	notes := strings.TrimSpace(result.AILink.Summary)
 	if notes == "" {
@@ -36,6 +39,9 @@ func expertRow(result *core.BatchResult) (string, string, string, string, bool)
 			notes = "expert analysis complete"
 		}
 	}
+	if result.AILink.Suspect && len(result.AILink.Warnings) > 0 {
+		notes = "warning: " + strings.Join(result.AILink.Warnings, "; ") + " — " + notes
+	}
 
 	return "expert", name, status, notes, true
 }
@@ -110,7 +116,7 @@ func expertResultCandidate(result *core.CheckResult) string {
 		name = strings.TrimPrefix(name, "@")
 	}
 
-	if result.CheckType == core.CheckTypeDomain {
+	if result.CheckType == core.CheckTypeDomain || result.CheckType == core.CheckTypeGoogleWorkspace {
 		tld := strings.ToLower(strings.TrimSpace(result.TLD))
 		if tld != "" {
 			suffix := "." + tld
@@ -161,6 +167,9 @@ func statusLabel(result *core.CheckResult) string {
 
 	switch result.Available {
 	case core.AvailabilityAvailable:
+		if result.Pricing != nil && result.Pricing.Premium {
+			return "available (premium)"
+		}
 		return "available"
 	case core.AvailabilityTaken:
 		return "taken"
@@ -175,6 +184,15 @@ func statusLabel(result *core.CheckResult) string {
 	}
 }
 
+// confidenceLabel renders a result's confidence as "level (score)", or "" when
+// the result carries none.
+func confidenceLabel(result *core.CheckResult) string {
+	if result == nil || result.Confidence == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%.2f)", result.Confidence.Level, result.Confidence.Score)
+}
+
 func formatNotes(result *core.CheckResult) string {
 	if result == nil {
 		return ""
@@ -184,6 +202,9 @@ func formatNotes(result *core.CheckResult) string {
 	if result.Message != "" && result.Available == core.AvailabilityError {
 		parts = append(parts, result.Message)
 	}
+	if timedOut, _ := result.ExtraData["timed_out"].(bool); timedOut && result.Message != "" {
+		parts = append(parts, result.Message)
+	}
 	if result.Available == core.AvailabilityRateLimited && result.ExtraData != nil {
 		if retry, ok := result.ExtraData["retry_after"]; ok {
 			parts = append(parts, fmt.Sprintf("retry: %v", retry))
@@ -193,12 +214,18 @@ func formatNotes(result *core.CheckResult) string {
 	switch result.CheckType {
 	case core.CheckTypeDomain:
 		parts = append(parts, domainNotes(result)...)
+		parts = append(parts, verificationNotes(result.Verification)...)
+		parts = append(parts, pricingNotes(result.Pricing)...)
 	case core.CheckTypeNPM:
 		parts = append(parts, npmNotes(result)...)
 	case core.CheckTypePyPI:
 		parts = append(parts, pypiNotes(result)...)
 	case core.CheckTypeGitHub:
 		parts = append(parts, githubNotes(result)...)
+	case core.CheckTypeSlack, core.CheckTypeDiscord, core.CheckTypeGoogleWorkspace:
+		parts = append(parts, saasNotes(result)...)
+	default:
+		parts = append(parts, catalogNotes(result)...)
 	}
 
 	return strings.Join(parts, "; ")
@@ -217,12 +244,72 @@ func domainNotes(result *core.CheckResult) []string {
 	if expiration, ok := result.ExtraData["expiration"]; ok {
 		notes = append(notes, fmt.Sprintf("exp: %v", expiration))
 	}
+	if labels := domainStatusLabels(result.ExtraData); len(labels) > 0 {
+		notes = append(notes, "status: "+strings.Join(labels, ", "))
+	}
+	if window, ok := core.ParseDropWindow(result.ExtraData); ok {
+		notes = append(notes, "drops: "+FormatDropWindow(window))
+	}
+	if listed, ok := result.ExtraData["drop_list"].(map[string]any); ok {
+		if source, ok := listed["source"].(string); ok && source != "" {
+			notes = append(notes, fmt.Sprintf("on %s drop list", source))
+		}
+	}
 	if registrar, ok := result.ExtraData["registrar"]; ok {
 		notes = append(notes, fmt.Sprintf("registrar: %v", registrar))
 	}
 	return notes
 }
 
+// domainStatusLabels returns the distinct short labels of a domain's status
+// codes, skipping codes without one (ok, active, unrecognized).
+func domainStatusLabels(extra map[string]any) []string {
+	var labels []string
+	seen := map[string]bool{}
+	for _, status := range core.ExplainDomainStatuses(core.DomainStatusCodes(extra)) {
+		if status.Label == "" || seen[status.Label] {
+			continue
+		}
+		seen[status.Label] = true
+		labels = append(labels, status.Label)
+	}
+	return labels
+}
+
+func verificationNotes(verification *core.Verification) []string {
+	if verification == nil {
+		return nil
+	}
+	if sources := verification.Disputes(); len(sources) > 0 {
+		return []string{fmt.Sprintf("verify: disputed by %s", strings.Join(sources, ", "))}
+	}
+	return []string{"verify: " + verification.Status}
+}
+
+// pricingNotes shows a registrar quote as "price: $12.99, renews $14.99
+// (registrar)"; premium quotes are labelled as such.
+func pricingNotes(pricing *core.Pricing) []string {
+	if pricing == nil {
+		return nil
+	}
+	label := "price"
+	if pricing.Premium {
+		label = "premium price"
+	}
+	note := fmt.Sprintf("%s: %s", label, formatPrice(pricing.Registration, pricing.Currency))
+	if pricing.Renewal > 0 {
+		note += ", renews " + formatPrice(pricing.Renewal, pricing.Currency)
+	}
+	return []string{note + " (" + pricing.Provider + ")"}
+}
+
+func formatPrice(amount float64, currency string) string {
+	if currency == "" || currency == "USD" {
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
 func npmNotes(result *core.CheckResult) []string {
 	if result == nil || result.ExtraData == nil {
 		return nil
@@ -256,3 +343,38 @@ func githubNotes(result *core.CheckResult) []string {
 	}
 	return nil
 }
+
+// catalogNotes surfaces internal service catalog collisions, which are only
+// described in the message.
+// saasNotes explains SaaS verdicts, which are hints rather than registry
+// answers.
+func saasNotes(result *core.CheckResult) []string {
+	if result == nil || result.Available == core.AvailabilityError || result.Available == core.AvailabilityRateLimited {
+		return nil
+	}
+	notes := []string{}
+	if guild, ok := result.ExtraData["guild"].(string); ok && guild != "" {
+		notes = append(notes, fmt.Sprintf("server: %s", guild))
+	}
+	if result.Message != "" && result.Message != core.OfflineMessage {
+		notes = append(notes, result.Message)
+	}
+	return notes
+}
+
+func catalogNotes(result *core.CheckResult) []string {
+	if result == nil || result.Available != core.AvailabilityTaken || result.ExtraData["catalog"] == nil {
+		return nil
+	}
+	return []string{result.Message}
+}
+
+// FormatDropWindow renders a drop window as a UTC date range.
+func FormatDropWindow(window core.DropWindow) string {
+	earliest := window.Earliest.UTC().Format("2006-01-02")
+	latest := window.Latest.UTC().Format("2006-01-02")
+	if earliest == latest {
+		return earliest
+	}
+	return earliest + " to " + latest
+}
@@ -15,6 +15,18 @@ const (
 	FormatTable    Format = "table"
 	FormatJSON     Format = "json"
 	FormatMarkdown Format = "markdown"
+	// FormatPRComment is a markdown comment for bots to post on pull
+	// requests; see PRCommentFormatter.
+	FormatPRComment Format = "pr-comment"
+	// FormatHTML is a self-contained HTML page for reports meant to be
+	// handed around; see HTMLFormatter.
+	FormatHTML Format = "html"
+	// FormatCSV is a flat table with a row per name and target for
+	// spreadsheets; see CSVFormatter.
+	FormatCSV Format = "csv"
+	// FormatJUnit is a JUnit XML report for CI test views; see
+	// JUnitFormatter.
+	FormatJUnit Format = "junit"
 )
 
 // Formatter renders batch results.
@@ -32,6 +44,14 @@ func ParseFormat(value string) (Format, error) {
 		return FormatJSON, nil
 	case string(FormatMarkdown):
 		return FormatMarkdown, nil
+	case string(FormatPRComment):
+		return FormatPRComment, nil
+	case string(FormatHTML):
+		return FormatHTML, nil
+	case string(FormatCSV):
+		return FormatCSV, nil
+	case string(FormatJUnit):
+		return FormatJUnit, nil
 	default:
 		return "", fmt.Errorf("unsupported output format: %s", value)
 	}
@@ -44,6 +64,14 @@ func NewFormatter(format Format) Formatter {
 		return &JSONFormatter{Indent: true}
 	case FormatMarkdown:
 		return &MarkdownFormatter{}
+	case FormatPRComment:
+		return &PRCommentFormatter{}
+	case FormatHTML:
+		return &HTMLFormatter{}
+	case FormatCSV:
+		return &CSVFormatter{}
+	case FormatJUnit:
+		return &JUnitFormatter{}
 	default:
 		return &TableFormatter{}
 	}
@@ -58,6 +86,22 @@ func FormatBatchList(format Format, results []*core.BatchResult) (string, error)
 		}
 		return string(data), nil
 	}
+	if format == FormatPRComment {
+		// One comment covers every name
+		return (&PRCommentFormatter{}).FormatBatches(results)
+	}
+	if format == FormatHTML {
+		// One page covers every name
+		return (&HTMLFormatter{}).FormatBatches(results)
+	}
+	if format == FormatCSV {
+		// One table with a single header row
+		return (&CSVFormatter{}).FormatBatches(results)
+	}
+	if format == FormatJUnit {
+		// One report with a test suite per name
+		return (&JUnitFormatter{}).FormatBatches(results)
+	}
 
 	formatter := NewFormatter(format)
 	rendered := make([]string, 0, len(results))
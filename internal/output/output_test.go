@@ -2,6 +2,7 @@ package output
 
 import (
 	"encoding/json"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/namelens/namelens/internal/ailink"
 	"github.com/namelens/namelens/internal/core"
+	"github.com/namelens/namelens/internal/similarity"
+	"github.com/namelens/namelens/internal/wordscan"
 )
 
 func TestParseFormat(t *testing.T) {
@@ -25,7 +28,19 @@ func TestParseFormat(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, FormatTable, format)
 
-	_, err = ParseFormat("csv")
+	format, err = ParseFormat("pr-comment")
+	require.NoError(t, err)
+	require.Equal(t, FormatPRComment, format)
+
+	format, err = ParseFormat("html")
+	require.NoError(t, err)
+	require.Equal(t, FormatHTML, format)
+
+	format, err = ParseFormat("csv")
+	require.NoError(t, err)
+	require.Equal(t, FormatCSV, format)
+
+	_, err = ParseFormat("xlsx")
 	require.Error(t, err)
 }
 
@@ -85,10 +100,37 @@ func TestFormatters(t *testing.T) {
 
 	markdownRendered, err := NewFormatter(FormatMarkdown).FormatBatch(result)
 	require.NoError(t, err)
-	require.Contains(t, markdownRendered, "| Type | Name | Status | Notes |")
+	require.Contains(t, markdownRendered, "| Type | Name | Status | Confidence | Notes |")
 	require.Contains(t, markdownRendered, "delta.com")
 }
 
+func TestConfidenceColumn(t *testing.T) {
+	result := &core.BatchResult{
+		Name: "delta",
+		Results: []*core.CheckResult{
+			{
+				Name:       "delta.com",
+				CheckType:  core.CheckTypeDomain,
+				Available:  core.AvailabilityTaken,
+				Confidence: &core.Confidence{Score: 0.95, Level: core.ConfidenceHigh},
+			},
+		},
+	}
+
+	tableRendered, err := NewFormatter(FormatTable).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, tableRendered, "CONFIDENCE")
+	require.Contains(t, tableRendered, "high (0.95)")
+
+	markdownRendered, err := NewFormatter(FormatMarkdown).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, markdownRendered, "| domain | delta.com | taken | high (0.95) |")
+
+	jsonRendered, err := NewFormatter(FormatJSON).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, jsonRendered, "\"level\": \"high\"")
+}
+
 func TestAnalysisRendering(t *testing.T) {
 	result := &core.BatchResult{
 		Name:  "delta",
@@ -119,6 +161,114 @@ func TestAnalysisRendering(t *testing.T) {
 	require.Contains(t, markdownRendered, "### Suitability Analysis")
 }
 
+func TestSuitabilityConsensusRendering(t *testing.T) {
+	result := &core.BatchResult{
+		Name:        "delta",
+		Suitability: json.RawMessage(`{"overall_suitability":{"score":70,"rating":"caution"},"consensus":{"members":[{},{},{}],"answered":2,"spread":30,"disagreement":true,"disagreements":["overall score: 55 (a/m1) vs 85 (b/m2)"]}}`),
+	}
+
+	rendered, err := NewFormatter(FormatTable).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, rendered, "Consensus: 2/3 models, score spread 30 (disagreement)")
+	require.Contains(t, rendered, "Disagree: overall score: 55 (a/m1) vs 85 (b/m2)")
+}
+
+func TestAlternativesRendering(t *testing.T) {
+	result := &core.BatchResult{
+		Name: "acme",
+		Results: []*core.CheckResult{
+			{Name: "acme.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken},
+		},
+		Alternatives: []*core.CheckResult{
+			{Name: "acme.io", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken},
+			{Name: "acme.dev", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable},
+		},
+	}
+
+	tableRendered, err := NewFormatter(FormatTable).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, tableRendered, "Alternatives (.com taken):\n  acme.dev: available\n  acme.io: taken")
+
+	markdownRendered, err := NewFormatter(FormatMarkdown).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, markdownRendered, "### Alternatives (.com taken)\n- acme.dev: available")
+}
+
+func TestVariantsRendering(t *testing.T) {
+	result := &core.BatchResult{
+		Name: "stripe",
+		Variants: []core.VariantCheck{
+			{Name: "strlpe.com", Kind: "homoglyph", Result: &core.CheckResult{Name: "strlpe.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityAvailable}},
+			{Name: "5tripe.com", Kind: "leet", Result: &core.CheckResult{Name: "5tripe.com", CheckType: core.CheckTypeDomain, Available: core.AvailabilityTaken}},
+		},
+	}
+
+	tableRendered, err := NewFormatter(FormatTable).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, tableRendered, "Typo Variants (1 of 2 .com registered):\n  5tripe.com: taken (leet)\n  strlpe.com: available (homoglyph)")
+
+	markdownRendered, err := NewFormatter(FormatMarkdown).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, markdownRendered, "### Typo Variants (1 of 2 .com registered)\n- 5tripe.com: taken (leet)")
+}
+
+func TestUnfortunateWordsRendering(t *testing.T) {
+	result := &core.BatchResult{
+		Name: "analmist",
+		UnfortunateWords: []wordscan.Finding{
+			{Word: "anal", Languages: []string{"en"}, Meaning: "sexual term", Severity: wordscan.SeverityHigh, Boundary: true},
+			{Word: "mist", Languages: []string{"de"}, Meaning: "manure, rubbish", Severity: wordscan.SeverityLow, Start: 5},
+		},
+	}
+
+	tableRendered, err := NewFormatter(FormatTable).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, tableRendered, "Unfortunate Words:\n  \"anal\": sexual term (English, high)\n  \"mist\": manure, rubbish (German, low), inside a word")
+
+	markdownRendered, err := NewFormatter(FormatMarkdown).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, markdownRendered, "### Unfortunate Words\n- \"anal\": sexual term (English, high)")
+}
+
+func TestPRCommentRendering(t *testing.T) {
+	results := []*core.BatchResult{
+		{
+			Name:  "acme",
+			Score: 1,
+			Total: 2,
+			Results: []*core.CheckResult{
+				{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityTaken},
+				{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityAvailable},
+			},
+			SimilarBrands: []similarity.Match{{Brand: "Acme Cloud", Score: 90, Metrics: []string{similarity.MetricSpelling}}},
+		},
+		{
+			Name:  "zephyr",
+			Score: 1,
+			Total: 1,
+			Results: []*core.CheckResult{
+				{Name: "zephyr.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable},
+			},
+		},
+	}
+
+	rendered, err := FormatBatchList(FormatPRComment, results)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(rendered, PRCommentMarker+"\n"))
+	require.Contains(t, rendered, "| 🟡 | `acme` | 1/2 | ⚠️ 1 similar brand |")
+	require.Contains(t, rendered, "| ✅ | `zephyr` | 1/1 | - |")
+	require.Contains(t, rendered, "<summary>🟡 <code>acme</code>: 1/2</summary>")
+	require.Contains(t, rendered, "| ❌ | domain acme.com | taken |  |")
+	require.Contains(t, rendered, "> ⚠️ Similar to **Acme Cloud** (90/100, spelling)")
+	require.Equal(t, 2, strings.Count(rendered, "<details>"))
+	require.NotContains(t, rendered, "Rerun")
+
+	rendered, err = (&PRCommentFormatter{Rerun: "namelens check acme"}).FormatBatch(results[0])
+	require.NoError(t, err)
+	require.Contains(t, rendered, "<sub>Rerun locally: <code>namelens check acme</code></sub>")
+	require.NotContains(t, rendered, "zephyr")
+}
+
 func TestDisplayName(t *testing.T) {
 	require.Equal(t, "@octocat", displayName(&core.CheckResult{
 		Name:      "octocat",
@@ -248,3 +398,371 @@ func TestExpertRowKeepsBatchNameWhenItMatchesChecks(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "ailink", name)
 }
+
+func TestExpertRowMarksSuspectResponse(t *testing.T) {
+	result := &core.BatchResult{
+		Name: "acme",
+		AILink: &ailink.SearchResponse{
+			RiskLevel: "low",
+			Summary:   "looks clear",
+			Suspect:   true,
+			Warnings:  []string{"deep analysis returned no insights"},
+		},
+	}
+
+	_, _, status, notes, ok := expertRow(result)
+	require.True(t, ok)
+	require.Equal(t, "suspect", status)
+	require.Equal(t, "warning: deep analysis returned no insights — looks clear", notes)
+}
+
+func TestFormatNotesVerification(t *testing.T) {
+	result := &core.CheckResult{
+		CheckType: core.CheckTypeDomain,
+		Name:      "acme.com",
+		Available: core.AvailabilityAvailable,
+		Verification: &core.Verification{
+			Status: core.VerificationDisputed,
+			Checks: []core.VerificationCheck{
+				{Source: "dns", Verdict: "taken"},
+				{Source: "http", Verdict: "unknown"},
+			},
+		},
+	}
+	require.Equal(t, "verify: disputed by dns", formatNotes(result))
+
+	result.Verification = &core.Verification{Status: core.VerificationConfirmed}
+	require.Equal(t, "verify: confirmed", formatNotes(result))
+}
+
+func TestFormatNotesPricing(t *testing.T) {
+	result := &core.CheckResult{
+		CheckType: core.CheckTypeDomain,
+		Name:      "acme.ai",
+		Available: core.AvailabilityAvailable,
+		Pricing:   &core.Pricing{Provider: "registrar", Currency: "USD", Registration: 9800, Renewal: 90, Premium: true},
+	}
+	require.Equal(t, "premium price: $9800.00, renews $90.00 (registrar)", formatNotes(result))
+	require.Equal(t, "available (premium)", statusLabel(result))
+
+	result.Pricing = &core.Pricing{Provider: "registrar", Currency: "EUR", Registration: 12.5}
+	require.Equal(t, "price: 12.50 EUR (registrar)", formatNotes(result))
+	require.Equal(t, "available", statusLabel(result))
+}
+
+func TestFormatNotesTimedOut(t *testing.T) {
+	result := &core.CheckResult{
+		CheckType: core.CheckTypeNPM,
+		Name:      "acme",
+		Available: core.AvailabilityUnknown,
+		Message:   "check timed out after 10s",
+		ExtraData: map[string]any{"timed_out": true},
+	}
+	require.Equal(t, "check timed out after 10s", formatNotes(result))
+}
+
+func TestFormatNotesCatalogCollision(t *testing.T) {
+	result := &core.CheckResult{
+		CheckType: core.CheckType("internal"),
+		Name:      "payments",
+		Available: core.AvailabilityTaken,
+		Message:   "collides with internal service payments owned by team-payments",
+		ExtraData: map[string]any{"catalog": "internal", "service": "payments"},
+	}
+	require.Equal(t, result.Message, formatNotes(result))
+
+	result.Available = core.AvailabilityAvailable
+	require.Empty(t, formatNotes(result))
+}
+
+func TestFormatNotesSaaS(t *testing.T) {
+	result := &core.CheckResult{
+		CheckType: core.CheckTypeDiscord,
+		Name:      "acme",
+		Available: core.AvailabilityTaken,
+		Message:   "invite or vanity URL in use",
+		ExtraData: map[string]any{"guild": "Acme HQ"},
+	}
+	require.Equal(t, "server: Acme HQ; invite or vanity URL in use", formatNotes(result))
+
+	result.Available = core.AvailabilityError
+	result.Message = "unexpected discord response"
+	require.Equal(t, "unexpected discord response", formatNotes(result))
+}
+
+func TestDomainNotesStatusLabels(t *testing.T) {
+	result := &core.CheckResult{
+		CheckType: core.CheckTypeDomain,
+		Name:      "acme.com",
+		Available: core.AvailabilityTaken,
+		ExtraData: map[string]any{
+			"status": []any{"active", "client transfer prohibited", "server transfer prohibited", "client delete prohibited"},
+		},
+	}
+	require.Equal(t, "status: transfer lock, delete lock", formatNotes(result))
+}
+
+func TestWriteICS(t *testing.T) {
+	var b strings.Builder
+	events := []ExpirationEvent{{
+		Domain:    "acme.com",
+		Relation:  "owned",
+		Registrar: "Example Registrar, Inc.",
+		Expires:   time.Date(2027, 1, 31, 18, 0, 0, 0, time.UTC),
+	}}
+	stamp := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, WriteICS(&b, events, 30*24*time.Hour, stamp))
+
+	ics := b.String()
+	require.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	require.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	require.Contains(t, ics, "UID:acme.com-20270131@namelens\r\n")
+	require.Contains(t, ics, "DTSTAMP:20261016T120000Z\r\n")
+	require.Contains(t, ics, "DTSTART;VALUE=DATE:20270131\r\nDTEND;VALUE=DATE:20270201\r\n")
+	require.Contains(t, ics, "SUMMARY:acme.com expires\r\n")
+	require.Contains(t, ics, "TRIGGER:-P30D\r\n")
+	// Commas are escaped and the long description is folded.
+	require.Contains(t, strings.ReplaceAll(ics, "\r\n ", ""), `\nRegistrar: Example Registrar\, Inc.`)
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		require.LessOrEqual(t, len(line), 75, line)
+	}
+
+	b.Reset()
+	require.NoError(t, WriteICS(&b, events, 0, stamp))
+	require.NotContains(t, b.String(), "VALARM")
+
+	b.Reset()
+	drop := []ExpirationEvent{{
+		Domain:     "zentro.com",
+		Drop:       true,
+		Expires:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		DropLatest: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC),
+		Reminder:   12 * time.Hour,
+	}}
+	require.NoError(t, WriteICS(&b, drop, 30*24*time.Hour, stamp))
+	ics = b.String()
+	require.Contains(t, ics, "UID:zentro.com-drop-20261019@namelens\r\n")
+	require.Contains(t, ics, "DTSTART;VALUE=DATE:20261019\r\nDTEND;VALUE=DATE:20261022\r\n")
+	require.Contains(t, ics, "SUMMARY:zentro.com may drop\r\n")
+	require.Contains(t, ics, "TRIGGER:-PT12H\r\n")
+}
+
+func TestDomainNotesDropWindow(t *testing.T) {
+	result := &core.CheckResult{
+		CheckType: core.CheckTypeDomain,
+		Available: core.AvailabilityTaken,
+		ExtraData: map[string]any{
+			"drop_window": map[string]any{
+				"earliest": "2026-10-19T00:00:00Z",
+				"latest":   "2026-10-21T00:00:00Z",
+				"phase":    core.DropPhasePendingDelete,
+			},
+		},
+	}
+	require.Contains(t, domainNotes(result), "drops: 2026-10-19 to 2026-10-21")
+
+	result.ExtraData["drop_list"] = map[string]any{"source": "zonedrops", "drop_date": "2026-10-19T00:00:00Z"}
+	require.Contains(t, domainNotes(result), "on zonedrops drop list")
+}
+
+func TestLocaleMatrixRendering(t *testing.T) {
+	score := 92
+	result := &core.BatchResult{
+		Name:        "delta",
+		Suitability: json.RawMessage(`{"overall_suitability":{"score":80,"rating":"suitable"}}`),
+		LocaleMatrix: []core.LocaleSuitability{
+			{Locale: "de-DE", Rating: "clear", Score: &score},
+			{Locale: "ja-JP", Rating: "medium", Concerns: []string{"Sounds like a slang term", "Hard to write in katakana"}},
+			{Locale: "pt-BR", Rating: "not assessed"},
+		},
+	}
+
+	tableRendered, err := NewFormatter(FormatTable).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, tableRendered, "Locale Suitability:")
+	require.Regexp(t, `de-DE\s+│\s+92/100\s+│\s+clear`, tableRendered)
+	require.Contains(t, tableRendered, "Sounds like a slang term (+1 more)")
+
+	markdownRendered, err := NewFormatter(FormatMarkdown).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, markdownRendered, "### Locale Suitability\n\n| Locale | Score | Rating | Concerns |")
+	require.Contains(t, markdownRendered, "| pt-BR | - | not assessed |  |")
+}
+
+func TestSimilarBrandsRendering(t *testing.T) {
+	result := &core.BatchResult{
+		Name: "strype",
+		SimilarBrands: []similarity.Match{
+			{Brand: "Stripe", Source: similarity.SourceBuiltin, Score: 85, Distance: 1, Metrics: []string{similarity.MetricSpelling, similarity.MetricPhonetic}},
+			{Brand: "Strype Labs", Source: similarity.SourceUser, Score: 100, Metrics: []string{similarity.MetricIdentical}},
+		},
+	}
+
+	tableRendered, err := NewFormatter(FormatTable).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, tableRendered, "Similar Brands:")
+	require.Regexp(t, `Stripe\s+│\s+85/100\s+│\s+spelling, phonetic\s+│\s+builtin`, tableRendered)
+
+	markdownRendered, err := NewFormatter(FormatMarkdown).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, markdownRendered, "### Similar Brands\n\n| Brand | Score | Match | Source |")
+	require.Contains(t, markdownRendered, "| Strype Labs | 100/100 | identical | user |")
+}
+
+func TestBuiltinPhoneticsRendering(t *testing.T) {
+	result := &core.BatchResult{
+		Name:      "scamm",
+		Phonetics: json.RawMessage(`{"name":"scamm","engine":"builtin","syllables":{"count":1,"breakdown":"scamm"},"typeability":{"overall_score":77,"by_keyboard":[{"layout":"QWERTY","travel_distance":12.25}]},"cli_suitability":{"score":100},"overall_assessment":{"combined_score":81,"recommendation":"Usable"},"sound_alikes":[{"word":"scam"},{"word":"scum"}]}`),
+	}
+
+	rendered, err := NewFormatter(FormatTable).FormatBatch(result)
+	require.NoError(t, err)
+	require.Contains(t, rendered, "Phonetics Analysis (built-in):")
+	require.Contains(t, rendered, "Keyboard travel (keys): QWERTY 12.2")
+	require.Contains(t, rendered, "Sounds like: scam, scum")
+}
+
+func TestHTMLRendering(t *testing.T) {
+	result := &core.BatchResult{
+		Name:  "acme<script>",
+		Score: 1,
+		Total: 2,
+		Results: []*core.CheckResult{
+			{
+				Name:       "acme.com",
+				CheckType:  core.CheckTypeDomain,
+				Available:  core.AvailabilityAvailable,
+				Provenance: core.Provenance{Source: "rdap", Server: "rdap.verisign.com", FromCache: true},
+			},
+			{
+				Name:      "acme",
+				CheckType: core.CheckTypeNPM,
+				Available: core.AvailabilityTaken,
+			},
+		},
+		Suitability: json.RawMessage(`{"overall_suitability":{"score":92,"rating":"suitable","summary":"No major issues"}}`),
+	}
+
+	rendered, err := NewFormatter(FormatHTML).FormatBatch(result)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(rendered, "<!DOCTYPE html>"))
+	require.Contains(t, rendered, "acme&lt;script&gt;")
+	require.NotContains(t, rendered, "acme<script>")
+	require.Contains(t, rendered, `<span class="score partial">1/2 available</span>`)
+	require.Contains(t, rendered, `<a href="#source-1">1</a>`)
+	require.Contains(t, rendered, `<li id="source-1">acme.com: rdap via rdap.verisign.com (cached)</li>`)
+	require.Contains(t, rendered, "<h3>Suitability Analysis</h3>")
+
+	report := HTMLReport{
+		Title:    "Shortlist",
+		Overview: &HTMLTable{Header: []string{"Name", "Score"}, Rows: [][]string{{"acme", "1/2"}}},
+		Batches:  []*core.BatchResult{result},
+		Extras:   map[string][]HTMLTable{result.Name: {{Title: "AI usage", Header: []string{"Prompt"}, Note: "2 calls"}}},
+	}
+	var sb strings.Builder
+	require.NoError(t, report.Render(&sb))
+	require.Contains(t, sb.String(), "<h1>Shortlist</h1>")
+	require.Contains(t, sb.String(), "<tr><td>acme</td><td>1/2</td></tr>")
+	require.Contains(t, sb.String(), "<h3>AI usage</h3>")
+	require.Contains(t, sb.String(), `<p class="meta">2 calls</p>`)
+}
+
+func TestCSVRendering(t *testing.T) {
+	results := []*core.BatchResult{
+		{
+			Name:  "acme",
+			Score: 1,
+			Total: 2,
+			Results: []*core.CheckResult{
+				{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable},
+				{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken},
+			},
+			Phonetics:   json.RawMessage(`{"typeability":{"overall_score":82}}`),
+			Suitability: json.RawMessage(`{"overall_suitability":{"score":92,"rating":"suitable"}}`),
+		},
+		{Name: "zenith, inc"},
+	}
+
+	rendered, err := FormatBatchList(FormatCSV, results)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(rendered), "\n")
+	require.Len(t, lines, 4)
+	require.Equal(t, "name,check_type,target,status,confidence,score,total,unknown,typeability,suitability,suitability_rating,notes", lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "acme,domain,acme.com,available,"))
+	require.Contains(t, lines[1], ",1,2,0,82,92,suitable,")
+	require.True(t, strings.HasPrefix(lines[2], "acme,npm,acme,taken,"))
+	require.Equal(t, `"zenith, inc",,,,,0,0,0,,,,`, lines[3])
+
+	extra := &CSVFormatter{ExtraHeader: []string{"length", "risk"}, Extra: map[string][]string{"acme": {"4"}}}
+	rendered, err = extra.FormatBatch(results[0])
+	require.NoError(t, err)
+	lines = strings.Split(strings.TrimSpace(rendered), "\n")
+	require.True(t, strings.HasSuffix(lines[0], ",notes,length,risk"))
+	require.True(t, strings.HasSuffix(lines[1], ",4,"))
+}
+
+func TestJUnitRendering(t *testing.T) {
+	requested := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	results := []*core.BatchResult{
+		{
+			Name:  "acme",
+			Score: 1,
+			Total: 3,
+			Results: []*core.CheckResult{
+				{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable,
+					Provenance: core.Provenance{RequestedAt: requested, ResolvedAt: requested.Add(250 * time.Millisecond)}},
+				{Name: "acme", CheckType: core.CheckTypeNPM, Available: core.AvailabilityTaken},
+				{Name: "acme.io", CheckType: core.CheckTypeDomain, TLD: "io", Available: core.AvailabilityError},
+				{Name: "acme.zz", CheckType: core.CheckTypeDomain, TLD: "zz", Available: core.AvailabilityUnsupported},
+			},
+		},
+	}
+
+	rendered, err := FormatBatchList(FormatJUnit, results)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(rendered, `<?xml version="1.0" encoding="UTF-8"?>`))
+	require.Contains(t, rendered, `<testsuites name="namelens" tests="4" failures="1" errors="1" skipped="1" time="0.250">`)
+	require.Contains(t, rendered, `<property name="score" value="1/3"></property>`)
+	require.Contains(t, rendered, `<testcase name="domain acme.com" classname="namelens.acme" time="0.250"></testcase>`)
+	require.Contains(t, rendered, `<failure message="acme: taken" type="taken">`)
+	require.Contains(t, rendered, `<error message="acme.io: error" type="error">`)
+	require.Contains(t, rendered, `<skipped message="acme.zz: unsupported" type="unsupported">`)
+
+	rendered, err = (&JUnitFormatter{Incomplete: JUnitPolicySkip}).FormatBatch(results[0])
+	require.NoError(t, err)
+	require.Contains(t, rendered, `failures="1" errors="0" skipped="2"`)
+
+	policy, err := ParseJUnitPolicy("Failure")
+	require.NoError(t, err)
+	require.Equal(t, JUnitPolicyFailure, policy)
+	_, err = ParseJUnitPolicy("ignore")
+	require.Error(t, err)
+}
+
+func TestTemplateRendering(t *testing.T) {
+	path := t.TempDir() + "/slack.txt.tmpl"
+	require.NoError(t, os.WriteFile(path, []byte(`*{{.Command}}*: {{join ", " .Names}}
+{{range .Results}}{{.Name}} {{.Score}}/{{.Total}}{{range .Results}}
+- {{target .}} {{status .}}{{end}}
+{{with fromJSON .Suitability}}suitability {{.overall_suitability.rating | upper}}{{end}}
+{{end}}`), 0o600))
+
+	tmpl, err := LoadTemplate(path)
+	require.NoError(t, err)
+	formatter := &TemplateFormatter{Template: tmpl, Command: "check"}
+	rendered, err := formatter.FormatBatch(&core.BatchResult{
+		Name:  "acme",
+		Score: 1,
+		Total: 1,
+		Results: []*core.CheckResult{
+			{Name: "acme.com", CheckType: core.CheckTypeDomain, TLD: "com", Available: core.AvailabilityAvailable},
+		},
+		Suitability: json.RawMessage(`{"overall_suitability":{"rating":"suitable"}}`),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "*check*: acme\nacme 1/1\n- acme.com available\nsuitability SUITABLE\n", rendered)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{{.Missing`), 0o600))
+	_, err = LoadTemplate(path)
+	require.ErrorContains(t, err, "parse output template")
+}
@@ -0,0 +1,164 @@
+package output
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// PRCommentMarker is the hidden first line of a pull request comment. Bots
+// search for it to update their earlier comment instead of posting another.
+const PRCommentMarker = "<!-- namelens:pr-comment -->"
+
+// PRCommentFormatter renders results as a compact GitHub/GitLab-flavored
+// markdown comment for bots to post on pull requests that propose names: a
+// summary table with one badge per name, then each name's targets in a
+// collapsible block.
+type PRCommentFormatter struct {
+	// Rerun is the command that reproduces the check, shown at the end of the
+	// comment; empty omits it.
+	Rerun string
+}
+
+// FormatBatch renders a single batch result as a comment.
+func (f *PRCommentFormatter) FormatBatch(result *core.BatchResult) (string, error) {
+	if result == nil {
+		return "", nil
+	}
+	return f.FormatBatches([]*core.BatchResult{result})
+}
+
+// FormatBatches renders several batch results as one comment.
+func (f *PRCommentFormatter) FormatBatches(results []*core.BatchResult) (string, error) {
+	var sb strings.Builder
+	sb.WriteString(PRCommentMarker + "\n")
+	sb.WriteString("### Name availability\n\n")
+	sb.WriteString("| | Name | Available | Warnings |\n")
+	sb.WriteString("|---|------|-----------|----------|\n")
+
+	rendered := 0
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		rendered++
+		fmt.Fprintf(&sb, "| %s | `%s` | %s | %s |\n",
+			nameBadge(result),
+			escapeMarkdownCell(result.Name),
+			availabilitySummary(result),
+			escapeMarkdownCell(warningSummary(result)),
+		)
+	}
+	if rendered == 0 {
+		return "", nil
+	}
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n<details>\n<summary>%s <code>%s</code>: %s</summary>\n\n",
+			nameBadge(result), html.EscapeString(result.Name), availabilitySummary(result))
+		sb.WriteString("| | Target | Status | Notes |\n")
+		sb.WriteString("|---|--------|--------|-------|\n")
+		for _, r := range result.Results {
+			if r == nil {
+				continue
+			}
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n",
+				statusEmoji(r),
+				escapeMarkdownCell(fmt.Sprintf("%s %s", r.CheckType, displayName(r))),
+				escapeMarkdownCell(statusLabel(r)),
+				escapeMarkdownCell(formatNotes(r)),
+			)
+		}
+		if lines := warningLines(result); len(lines) > 0 {
+			sb.WriteString("\n")
+			for _, line := range lines {
+				fmt.Fprintf(&sb, "> %s\n", line)
+			}
+		}
+		sb.WriteString("\n</details>\n")
+	}
+
+	if rerun := strings.TrimSpace(f.Rerun); rerun != "" {
+		fmt.Fprintf(&sb, "\n<sub>Rerun locally: <code>%s</code></sub>\n", html.EscapeString(rerun))
+	}
+	return sb.String(), nil
+}
+
+// nameBadge rates a name at a glance: every target available, none, or some.
+func nameBadge(result *core.BatchResult) string {
+	switch {
+	case result.Total == 0:
+		return "⚪"
+	case result.Score == result.Total && result.Unknown == 0:
+		return "✅"
+	case result.Score == 0:
+		return "❌"
+	default:
+		return "🟡"
+	}
+}
+
+func availabilitySummary(result *core.BatchResult) string {
+	summary := fmt.Sprintf("%d/%d", result.Score, result.Total)
+	if result.Unknown > 0 {
+		summary += fmt.Sprintf(" (%d unknown)", result.Unknown)
+	}
+	return summary
+}
+
+func statusEmoji(result *core.CheckResult) string {
+	switch result.Available {
+	case core.AvailabilityAvailable:
+		return "✅"
+	case core.AvailabilityTaken:
+		return "❌"
+	case core.AvailabilityRateLimited:
+		return "⏳"
+	case core.AvailabilityUnsupported:
+		return "➖"
+	default:
+		return "⚠️"
+	}
+}
+
+// warningSummary counts the offline warnings about a name for the summary
+// table.
+func warningSummary(result *core.BatchResult) string {
+	var parts []string
+	if n := len(result.SimilarBrands); n > 0 {
+		parts = append(parts, pluralize(n, "similar brand", "similar brands"))
+	}
+	if n := len(result.UnfortunateWords); n > 0 {
+		parts = append(parts, pluralize(n, "unfortunate word", "unfortunate words"))
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return "⚠️ " + strings.Join(parts, ", ")
+}
+
+// warningLines describes each offline warning about a name.
+func warningLines(result *core.BatchResult) []string {
+	var lines []string
+	for _, match := range result.SimilarBrands {
+		lines = append(lines, fmt.Sprintf("⚠️ Similar to **%s** (%d/100, %s)",
+			html.EscapeString(match.Brand), match.Score, strings.Join(match.Metrics, ", ")))
+	}
+	for _, finding := range result.UnfortunateWords {
+		lines = append(lines, fmt.Sprintf("⚠️ Contains **%s**: %s (%s)",
+			html.EscapeString(finding.Word), html.EscapeString(finding.Meaning), finding.Severity))
+	}
+	return lines
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
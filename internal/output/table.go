@@ -19,7 +19,7 @@ func (f *TableFormatter) FormatBatch(result *core.BatchResult) (string, error) {
 
 	t := table.NewWriter()
 	t.SetStyle(table.StyleRounded)
-	t.AppendHeader(table.Row{"Type", "Name", "Status", "Notes"})
+	t.AppendHeader(table.Row{"Type", "Name", "Status", "Confidence", "Notes"})
 
 	for _, r := range result.Results {
 		if r == nil {
@@ -29,6 +29,7 @@ func (f *TableFormatter) FormatBatch(result *core.BatchResult) (string, error) {
 			string(r.CheckType),
 			displayName(r),
 			statusLabel(r),
+			confidenceLabel(r),
 			formatNotes(r),
 		})
 	}
@@ -36,7 +37,7 @@ func (f *TableFormatter) FormatBatch(result *core.BatchResult) (string, error) {
 	if result.AILink != nil || result.AILinkError != nil {
 		rowType, name, status, notes, ok := expertRow(result)
 		if ok {
-			t.AppendRow(table.Row{rowType, name, status, notes})
+			t.AppendRow(table.Row{rowType, name, status, "", notes})
 		}
 	}
 
@@ -50,10 +51,13 @@ func (f *TableFormatter) FormatBatch(result *core.BatchResult) (string, error) {
 			"",
 			summary,
 			"",
+			"",
 		})
 	}
 
 	rendered := t.Render()
 	rendered += renderAnalysisSections(analysisSections(result), false)
+	rendered += renderLocaleMatrix(result.LocaleMatrix, false)
+	rendered += renderSimilarBrands(result.SimilarBrands, false)
 	return rendered, nil
 }
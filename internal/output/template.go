@@ -0,0 +1,104 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+// TemplateData is what user output templates are executed with.
+type TemplateData struct {
+	// Command is the command that produced the results, e.g. "check".
+	Command     string
+	GeneratedAt time.Time
+	Names       []string
+	// Results holds the command's own results: []*core.BatchResult for
+	// check, the review results for review, and the comparison rows for
+	// compare, in the same shape as their JSON output.
+	Results any
+}
+
+// TemplateFuncs returns the helpers available to user output templates.
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"status":     statusLabel,
+		"target":     displayName,
+		"notes":      formatNotes,
+		"confidence": confidenceLabel,
+		"json": func(value any) (string, error) {
+			data, err := json.MarshalIndent(value, "", "  ")
+			return string(data), err
+		},
+		// fromJSON decodes raw analysis output, such as .Phonetics, so
+		// templates can reach into it
+		"fromJSON": func(raw json.RawMessage) (any, error) {
+			if len(raw) == 0 {
+				return nil, nil
+			}
+			var value any
+			err := json.Unmarshal(raw, &value)
+			return value, err
+		},
+		"join":  func(sep string, items []string) string { return strings.Join(items, sep) },
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"date":  func(layout string, t time.Time) string { return t.Format(layout) },
+	}
+}
+
+// LoadTemplate parses a user output template file.
+func LoadTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- user-provided template path
+	if err != nil {
+		return nil, fmt.Errorf("read output template: %w", err)
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(TemplateFuncs()).Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// TemplateFormatter renders results through a user output template, for
+// layouts the built-in formats do not cover, such as chat messages or issue
+// descriptions.
+type TemplateFormatter struct {
+	Template *template.Template
+	Command  string
+}
+
+// FormatBatch renders a single batch result.
+func (f *TemplateFormatter) FormatBatch(result *core.BatchResult) (string, error) {
+	if result == nil {
+		return "", nil
+	}
+	return f.FormatBatches([]*core.BatchResult{result})
+}
+
+// FormatBatches renders several batch results in one execution.
+func (f *TemplateFormatter) FormatBatches(results []*core.BatchResult) (string, error) {
+	names := make([]string, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			names = append(names, result.Name)
+		}
+	}
+	return f.Render(names, results)
+}
+
+// Render executes the template with results of any shape.
+func (f *TemplateFormatter) Render(names []string, results any) (string, error) {
+	var sb strings.Builder
+	data := TemplateData{Command: f.Command, GeneratedAt: time.Now(), Names: names, Results: results}
+	if err := f.Template.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("render output template: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,397 @@
+// Package phonetics is a built-in, deterministic phonetic and typeability
+// analysis of names. It needs no AI provider and produces a document shaped
+// like the name-phonetics prompt's response, so reports render either one.
+package phonetics
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Engine identifies documents produced by this package.
+const Engine = "builtin"
+
+//go:embed words.txt
+var wordsFile string
+
+type commonWord struct {
+	word      string
+	soundex   string
+	metaphone string
+}
+
+var (
+	commonWordsOnce sync.Once
+	commonWords     []commonWord
+)
+
+func loadCommonWords() []commonWord {
+	commonWordsOnce.Do(func() {
+		for _, line := range strings.Split(wordsFile, "\n") {
+			word := strings.TrimSpace(line)
+			if word == "" || strings.HasPrefix(word, "#") {
+				continue
+			}
+			commonWords = append(commonWords, commonWord{word: word, soundex: Soundex(word), metaphone: Metaphone(word)})
+		}
+	})
+	return commonWords
+}
+
+// Common shell commands that a CLI name should not shadow.
+var shellCommands = map[string]bool{
+	"apt": true, "awk": true, "bash": true, "cat": true, "cd": true, "chmod": true,
+	"cp": true, "curl": true, "cut": true, "date": true, "dd": true, "df": true,
+	"diff": true, "du": true, "echo": true, "env": true, "find": true, "git": true,
+	"go": true, "grep": true, "head": true, "kill": true, "less": true, "ln": true,
+	"ls": true, "make": true, "man": true, "mkdir": true, "more": true, "mv": true,
+	"node": true, "npm": true, "open": true, "ps": true, "pwd": true, "python": true,
+	"rm": true, "sed": true, "sh": true, "sort": true, "ssh": true, "sudo": true,
+	"tail": true, "tar": true, "test": true, "top": true, "touch": true, "tr": true,
+	"vi": true, "vim": true, "wc": true, "wget": true, "which": true, "yes": true,
+	"zip": true,
+}
+
+// SoundAlike is a common word that encodes like the analyzed name.
+type SoundAlike struct {
+	Word      string `json:"word"`
+	Soundex   string `json:"soundex"`
+	Metaphone string `json:"metaphone"`
+}
+
+// Analysis is the built-in phonetics document. Fields with schema names match
+// the name-phonetics response; the rest are specific to the built-in engine.
+type Analysis struct {
+	Name              string            `json:"name"`
+	Engine            string            `json:"engine"`
+	Syllables         Syllables         `json:"syllables"`
+	Typeability       Typeability       `json:"typeability"`
+	CLISuitability    CLISuitability    `json:"cli_suitability"`
+	OverallAssessment OverallAssessment `json:"overall_assessment"`
+	Soundex           string            `json:"soundex"`
+	Metaphone         string            `json:"metaphone"`
+	ConsonantClusters []string          `json:"consonant_clusters,omitempty"`
+	DoubleLetters     []string          `json:"double_letters,omitempty"`
+	SoundAlikes       []SoundAlike      `json:"sound_alikes,omitempty"`
+	Memorability      Memorability      `json:"memorability"`
+}
+
+// Syllables is the estimated syllable structure.
+type Syllables struct {
+	Count     int    `json:"count"`
+	Breakdown string `json:"breakdown"`
+}
+
+// Typeability summarizes typing effort across layouts.
+type Typeability struct {
+	OverallScore int                `json:"overall_score"`
+	ByKeyboard   []KeyboardAnalysis `json:"by_keyboard"`
+	MuscleMemory string             `json:"muscle_memory"`
+}
+
+// CLISuitability rates the name as a command.
+type CLISuitability struct {
+	Score            int      `json:"score"`
+	LengthAssessment string   `json:"length_assessment"`
+	ShellConflicts   []string `json:"shell_conflicts,omitempty"`
+}
+
+// OverallAssessment combines the scores.
+type OverallAssessment struct {
+	PhoneticsScore   int      `json:"phonetics_score"`
+	TypeabilityScore int      `json:"typeability_score"`
+	CombinedScore    int      `json:"combined_score"`
+	Recommendation   string   `json:"recommendation"`
+	Concerns         []string `json:"concerns,omitempty"`
+	Strengths        []string `json:"strengths,omitempty"`
+}
+
+// Analyze runs the built-in analysis of name on the given keyboards (names
+// like "QWERTY (US)" or "azerty"; DefaultLayouts when empty). Keyboards
+// without a built-in layout are skipped.
+func Analyze(name string, keyboards []string) Analysis {
+	letters := lettersOnly(name)
+	analysis := Analysis{
+		Name:              name,
+		Engine:            Engine,
+		Soundex:           Soundex(name),
+		Metaphone:         Metaphone(name),
+		ConsonantClusters: consonantClusters(letters),
+		DoubleLetters:     doubleLetters(letters),
+	}
+	syllables := splitSyllables(letters)
+	analysis.Syllables = Syllables{Count: max(1, len(syllables)), Breakdown: strings.Join(syllables, "-")}
+
+	analysis.SoundAlikes = soundAlikes(letters, analysis.Soundex, analysis.Metaphone)
+
+	analysis.Typeability = typeability(name, keyboards)
+	analysis.CLISuitability = cliSuitability(name)
+	analysis.OverallAssessment = assess(analysis)
+	analysis.Memorability = ScoreMemorability(name)
+	return analysis
+}
+
+func typeability(name string, keyboards []string) Typeability {
+	var layouts []Layout
+	seen := map[string]bool{}
+	add := func(layout Layout) {
+		if !seen[layout.Name] {
+			seen[layout.Name] = true
+			layouts = append(layouts, layout)
+		}
+	}
+	for _, keyboard := range keyboards {
+		if layout, ok := LayoutFor(keyboard); ok {
+			add(layout)
+		}
+	}
+	if len(layouts) == 0 {
+		for _, id := range DefaultLayouts {
+			add(Layouts[id])
+		}
+	}
+
+	result := Typeability{ByKeyboard: make([]KeyboardAnalysis, 0, len(layouts))}
+	total := 0
+	for _, layout := range layouts {
+		keyboard := layout.Type(name)
+		result.ByKeyboard = append(result.ByKeyboard, keyboard)
+		total += keyboard.Score
+	}
+	result.OverallScore = int(math.Round(float64(total) / float64(len(layouts))))
+	switch {
+	case result.OverallScore >= 80:
+		result.MuscleMemory = "easy"
+	case result.OverallScore >= 60:
+		result.MuscleMemory = "moderate"
+	default:
+		result.MuscleMemory = "difficult"
+	}
+	return result
+}
+
+func cliSuitability(name string) CLISuitability {
+	lower := strings.ToLower(strings.TrimSpace(name))
+	result := CLISuitability{}
+	score := 100.0
+	switch n := len(lower); {
+	case n <= 6:
+		result.LengthAssessment = "good"
+	case n <= 10:
+		result.LengthAssessment = "acceptable"
+		score -= 3 * float64(n-6)
+	default:
+		result.LengthAssessment = "too_long"
+		score -= 12 + 6*float64(n-10)
+	}
+	if strings.ContainsAny(lower, "-_") {
+		score -= 10
+	}
+	if shellCommands[lower] {
+		result.ShellConflicts = []string{lower}
+		score -= 50
+	}
+	result.Score = clampScore(score)
+	return result
+}
+
+func assess(analysis Analysis) OverallAssessment {
+	result := OverallAssessment{TypeabilityScore: analysis.Typeability.OverallScore}
+
+	score := 100.0
+	if extra := analysis.Syllables.Count - 3; extra > 0 {
+		score -= 10 * float64(extra)
+		result.Concerns = append(result.Concerns, fmt.Sprintf("%d syllables is long to say", analysis.Syllables.Count))
+	}
+	if len(analysis.ConsonantClusters) > 0 {
+		score -= 10 * float64(len(analysis.ConsonantClusters))
+		result.Concerns = append(result.Concerns, fmt.Sprintf("consonant clusters: %s", strings.Join(analysis.ConsonantClusters, ", ")))
+	}
+	if len(analysis.DoubleLetters) > 0 {
+		score -= 5 * float64(len(analysis.DoubleLetters))
+		result.Concerns = append(result.Concerns, fmt.Sprintf("double letters are easy to misspell: %s", strings.Join(analysis.DoubleLetters, ", ")))
+	}
+	if len(analysis.SoundAlikes) > 0 {
+		words := make([]string, 0, len(analysis.SoundAlikes))
+		for _, alike := range analysis.SoundAlikes {
+			words = append(words, alike.Word)
+		}
+		score -= math.Min(30, 15+5*float64(len(words)-1))
+		result.Concerns = append(result.Concerns, fmt.Sprintf("sounds like %s", strings.Join(words, ", ")))
+	}
+	if len(analysis.CLISuitability.ShellConflicts) > 0 {
+		result.Concerns = append(result.Concerns, fmt.Sprintf("shadows the %q command", analysis.CLISuitability.ShellConflicts[0]))
+	}
+	result.PhoneticsScore = clampScore(score)
+
+	if analysis.Syllables.Count <= 2 {
+		result.Strengths = append(result.Strengths, "short to say")
+	}
+	if analysis.Typeability.MuscleMemory == "easy" {
+		result.Strengths = append(result.Strengths, "easy to type")
+	}
+	if len(analysis.SoundAlikes) == 0 {
+		result.Strengths = append(result.Strengths, "no sound-alike common words")
+	}
+
+	result.CombinedScore = int(math.Round(float64(result.PhoneticsScore+result.TypeabilityScore+analysis.CLISuitability.Score) / 3))
+	details := ""
+	if len(result.Concerns) > 0 {
+		details = ": " + strings.Join(result.Concerns, "; ")
+	}
+	switch {
+	case result.CombinedScore >= 80 && len(result.Concerns) == 0:
+		result.Recommendation = "No significant phonetic or typing issues found."
+	case result.CombinedScore >= 60:
+		result.Recommendation = "Usable, with minor issues" + details + "."
+	default:
+		result.Recommendation = "Notable phonetic or typing issues" + details + "."
+	}
+	return result
+}
+
+// maxSoundAlikes limits how many sound-alike words an analysis reports.
+const maxSoundAlikes = 5
+
+// soundAlikes returns the common words whose Soundex and Metaphone codes both
+// match, closest spelling first.
+func soundAlikes(letters, soundex, metaphone string) []SoundAlike {
+	if metaphone == "" {
+		return nil
+	}
+	var matches []SoundAlike
+	for _, word := range loadCommonWords() {
+		if word.word != letters && word.metaphone == metaphone && word.soundex == soundex {
+			matches = append(matches, SoundAlike{Word: word.word, Soundex: word.soundex, Metaphone: word.metaphone})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return EditDistance(letters, matches[i].Word) < EditDistance(letters, matches[j].Word)
+	})
+	if len(matches) > maxSoundAlikes {
+		matches = matches[:maxSoundAlikes]
+	}
+	return matches
+}
+
+// EditDistance returns the Levenshtein distance between a and b in bytes.
+func EditDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// Consonant pairs pronounced as one sound; syllables do not split them.
+var digraphs = map[string]bool{"ch": true, "ck": true, "gh": true, "ng": true, "ph": true, "sh": true, "th": true, "wh": true}
+
+// splitSyllables estimates syllables from vowel groups (y counts as a vowel
+// after a consonant), dropping a silent final e. A single consonant between
+// vowel groups starts the next syllable ("ta-ble"); of several, the first
+// stays with the previous one ("cof-fee", "ash-craft").
+func splitSyllables(letters string) []string {
+	if letters == "" {
+		return nil
+	}
+	vowel := func(i int) bool {
+		c := letters[i]
+		return isVowel(c) || (c == 'y' && i > 0 && !isVowel(letters[i-1]))
+	}
+
+	// Nuclei are [start, end) vowel runs
+	var nuclei [][2]int
+	for i := 0; i < len(letters); {
+		if !vowel(i) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(letters) && vowel(i) {
+			i++
+		}
+		nuclei = append(nuclei, [2]int{start, i})
+	}
+	// A final e after a consonant is usually silent ("make"), but not in a
+	// consonant-le ending ("table")
+	if n := len(nuclei); n > 1 {
+		last := nuclei[n-1]
+		if last[0] == len(letters)-1 && letters[last[0]] == 'e' && !syllabicLE(letters) {
+			nuclei = nuclei[:n-1]
+		}
+	}
+	if len(nuclei) <= 1 {
+		return []string{letters}
+	}
+
+	parts := make([]string, 0, len(nuclei))
+	start := 0
+	for i := 0; i < len(nuclei)-1; i++ {
+		gapStart, gapEnd := nuclei[i][1], nuclei[i+1][0]
+		first := 1
+		if gapEnd-gapStart >= 2 && digraphs[letters[gapStart:gapStart+2]] {
+			first = 2
+		}
+		cut := gapStart + first
+		if cut >= gapEnd {
+			cut = gapStart
+		}
+		if i == len(nuclei)-2 && gapEnd-gapStart >= 2 && syllabicLE(letters) {
+			// A final consonant-le is its own syllable ("ta-ble")
+			cut = gapEnd - 2
+		}
+		parts = append(parts, letters[start:cut])
+		start = cut
+	}
+	return append(parts, letters[start:])
+}
+
+// syllabicLE reports a consonant-le ending, such as "ble" or "tle".
+func syllabicLE(letters string) bool {
+	n := len(letters)
+	return n >= 3 && letters[n-2:] == "le" && !isVowel(letters[n-3]) && letters[n-3] != 'y'
+}
+
+// consonantClusters returns runs of three or more consonants.
+func consonantClusters(letters string) []string {
+	var clusters []string
+	run := 0
+	for i := 0; i <= len(letters); i++ {
+		if i < len(letters) && !isVowel(letters[i]) && letters[i] != 'y' {
+			run++
+			continue
+		}
+		if run >= 3 {
+			clusters = append(clusters, letters[i-run:i])
+		}
+		run = 0
+	}
+	return clusters
+}
+
+// doubleLetters returns repeated adjacent letters, such as "ll".
+func doubleLetters(letters string) []string {
+	var doubles []string
+	for i := 1; i < len(letters); i++ {
+		if letters[i] == letters[i-1] && (i == 1 || letters[i-2] != letters[i]) {
+			doubles = append(doubles, letters[i-1:i+1])
+		}
+	}
+	return doubles
+}
@@ -0,0 +1,187 @@
+package phonetics
+
+import "strings"
+
+// Soundex returns the American Soundex code of word (a letter and three
+// digits), or "" when word has no letters.
+func Soundex(word string) string {
+	letters := lettersOnly(word)
+	if letters == "" {
+		return ""
+	}
+
+	codes := map[byte]byte{
+		'b': '1', 'f': '1', 'p': '1', 'v': '1',
+		'c': '2', 'g': '2', 'j': '2', 'k': '2', 'q': '2', 's': '2', 'x': '2', 'z': '2',
+		'd': '3', 't': '3',
+		'l': '4',
+		'm': '5', 'n': '5',
+		'r': '6',
+	}
+
+	out := []byte{letters[0] - 'a' + 'A'}
+	last := codes[letters[0]]
+	for i := 1; i < len(letters) && len(out) < 4; i++ {
+		c := letters[i]
+		code, ok := codes[c]
+		switch {
+		case !ok && (c == 'h' || c == 'w'):
+			// h and w do not separate letters with the same code
+			continue
+		case !ok:
+			last = 0
+		case code != last:
+			out = append(out, code)
+			last = code
+		}
+	}
+	for len(out) < 4 {
+		out = append(out, '0')
+	}
+	return string(out)
+}
+
+// Metaphone returns the original Metaphone key of word, using "0" for the
+// "th" sound and "X" for "sh", or "" when word has no letters.
+func Metaphone(word string) string {
+	w := lettersOnly(word)
+	if w == "" {
+		return ""
+	}
+
+	// Initial exceptions
+	switch {
+	case hasPrefixAny(w, "ae", "gn", "kn", "pn", "wr"):
+		w = w[1:]
+	case w[0] == 'x':
+		w = "s" + w[1:]
+	case strings.HasPrefix(w, "wh"):
+		w = "w" + w[2:]
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(w); i++ {
+		c := w[i]
+		// Duplicate letters count once, except c
+		if c != 'c' && i > 0 && at(i-1) == c {
+			continue
+		}
+		next, after := at(i+1), at(i+2)
+
+		switch c {
+		case 'a', 'e', 'i', 'o', 'u':
+			if i == 0 {
+				b.WriteByte(c - 'a' + 'A')
+			}
+		case 'b':
+			if !(i == len(w)-1 && at(i-1) == 'm') {
+				b.WriteByte('B')
+			}
+		case 'c':
+			switch {
+			case next == 'i' && after == 'a', next == 'h' && at(i-1) != 's':
+				b.WriteByte('X')
+			case next == 'i' || next == 'e' || next == 'y':
+				if at(i-1) != 's' {
+					b.WriteByte('S')
+				}
+			default:
+				b.WriteByte('K')
+			}
+		case 'd':
+			if next == 'g' && (after == 'e' || after == 'y' || after == 'i') {
+				b.WriteByte('J')
+			} else {
+				b.WriteByte('T')
+			}
+		case 'g':
+			switch {
+			case next == 'h' && i+2 < len(w) && !isVowel(after):
+				// Silent, as in "night"
+			case next == 'n' && (i+2 == len(w) || (after == 'e' && at(i+3) == 'd' && i+4 == len(w))):
+				// Silent, as in "sign" and "signed"
+			case (next == 'i' || next == 'e' || next == 'y') && at(i-1) != 'g':
+				b.WriteByte('J')
+			default:
+				b.WriteByte('K')
+			}
+		case 'h':
+			prev := at(i - 1)
+			if isVowel(next) && !strings.ContainsRune("cgpst", rune(prev)) {
+				b.WriteByte('H')
+			}
+		case 'k':
+			if at(i-1) != 'c' {
+				b.WriteByte('K')
+			}
+		case 'p':
+			if next == 'h' {
+				b.WriteByte('F')
+			} else {
+				b.WriteByte('P')
+			}
+		case 'q':
+			b.WriteByte('K')
+		case 's':
+			if next == 'h' || (next == 'i' && (after == 'o' || after == 'a')) {
+				b.WriteByte('X')
+			} else {
+				b.WriteByte('S')
+			}
+		case 't':
+			switch {
+			case next == 'i' && (after == 'o' || after == 'a'):
+				b.WriteByte('X')
+			case next == 'h':
+				b.WriteByte('0')
+			case next == 'c' && after == 'h':
+				// Silent, as in "match"
+			default:
+				b.WriteByte('T')
+			}
+		case 'v':
+			b.WriteByte('F')
+		case 'w', 'y':
+			if isVowel(next) {
+				b.WriteByte(c - 'a' + 'A')
+			}
+		case 'x':
+			b.WriteString("KS")
+		case 'z':
+			b.WriteByte('S')
+		default:
+			b.WriteByte(c - 'a' + 'A')
+		}
+	}
+	return b.String()
+}
+
+func lettersOnly(word string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if r >= 'a' && r <= 'z' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func hasPrefixAny(s string, prefixes ...string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isVowel(c byte) bool {
+	return c == 'a' || c == 'e' || c == 'i' || c == 'o' || c == 'u'
+}
@@ -0,0 +1,212 @@
+package phonetics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Layout is a keyboard layout's letter and digit positions.
+type Layout struct {
+	Name string
+	keys map[rune]keyPos
+}
+
+type keyPos struct {
+	row int
+	col int
+	x   float64
+	y   float64
+}
+
+// Row offsets in key widths for a standard staggered keyboard: number row,
+// top row, home row, bottom row.
+var rowStagger = []float64{0, 0.5, 0.75, 1.25}
+
+func newLayout(name string, rows ...string) Layout {
+	layout := Layout{Name: name, keys: map[rune]keyPos{}}
+	for row, keys := range rows {
+		for col, r := range []rune(keys) {
+			layout.keys[r] = keyPos{row: row, col: col, x: float64(col) + rowStagger[row], y: float64(row)}
+		}
+	}
+	return layout
+}
+
+// Layouts lists the built-in keyboard layouts by key. Layout names such as
+// "QWERTY (US)" or "qwertz" map to them with LayoutFor.
+var Layouts = map[string]Layout{
+	"qwerty":  newLayout("QWERTY", "1234567890-", "qwertyuiop", "asdfghjkl", "zxcvbnm"),
+	"qwertz":  newLayout("QWERTZ", "1234567890ß", "qwertzuiop", "asdfghjkl", "yxcvbnm"),
+	"azerty":  newLayout("AZERTY", "1234567890-", "azertyuiop", "qsdfghjklm", "wxcvbn"),
+	"dvorak":  newLayout("Dvorak", "1234567890", "',.pyfgcrl", "aoeuidhtns-", ";qjkxbmwvz"),
+	"colemak": newLayout("Colemak", "1234567890-", "qwfpgjluy;", "arstdhneio", "zxcvbkm"),
+}
+
+// DefaultLayouts are analyzed when no keyboards are requested.
+var DefaultLayouts = []string{"qwerty", "qwertz", "azerty"}
+
+// LayoutFor maps a keyboard name to a built-in layout. JIS and other
+// QWERTY-based national layouts use QWERTY letter positions.
+func LayoutFor(name string) (Layout, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, id := range []string{"qwertz", "azerty", "dvorak", "colemak", "qwerty", "jis", "abnt"} {
+		if strings.Contains(key, id) {
+			if id == "jis" || id == "abnt" {
+				id = "qwerty"
+			}
+			return Layouts[id], true
+		}
+	}
+	return Layout{}, false
+}
+
+// Neighbors returns the letter and digit keys touching r's key on the
+// layout, nearest first, for predicting fat-finger typos.
+func (l Layout) Neighbors(r rune) []rune {
+	pos, ok := l.keys[r]
+	if !ok {
+		return nil
+	}
+	type neighbor struct {
+		key      rune
+		distance float64
+	}
+	var near []neighbor
+	for key, other := range l.keys {
+		if key == r || !(unicode.IsLetter(key) || unicode.IsDigit(key)) {
+			continue
+		}
+		// Keys on the same row are 1 apart; the two touching keys on the
+		// next row, staggered by a quarter or half key, are at most 1.25
+		if distance := math.Hypot(other.x-pos.x, other.y-pos.y); distance <= 1.3 {
+			near = append(near, neighbor{key: key, distance: distance})
+		}
+	}
+	sort.Slice(near, func(i, j int) bool {
+		if near[i].distance != near[j].distance {
+			return near[i].distance < near[j].distance
+		}
+		return near[i].key < near[j].key
+	})
+	keys := make([]rune, 0, len(near))
+	for _, n := range near {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// KeyboardAnalysis is the typeability of a name on one layout, shaped like a
+// name-phonetics by_keyboard entry.
+type KeyboardAnalysis struct {
+	Layout            string   `json:"layout"`
+	Score             int      `json:"score"`
+	HandAlternation   string   `json:"hand_alternation"`
+	HomeRowPercentage int      `json:"home_row_percentage"`
+	TravelDistance    float64  `json:"travel_distance"`
+	AwkwardSequences  []string `json:"awkward_sequences,omitempty"`
+	Notes             string   `json:"notes,omitempty"`
+}
+
+// Type measures typing name on the layout: total finger travel in key widths
+// between consecutive keys, home row use, hand alternation, and same-finger
+// sequences.
+func (l Layout) Type(name string) KeyboardAnalysis {
+	analysis := KeyboardAnalysis{Layout: l.Name}
+
+	var (
+		keys     []keyPos
+		typed    []rune
+		missing  []string
+		homeRow  int
+		switches int
+	)
+	for _, r := range strings.ToLower(name) {
+		pos, ok := l.keys[r]
+		if !ok {
+			missing = append(missing, string(r))
+			continue
+		}
+		keys = append(keys, pos)
+		typed = append(typed, r)
+		if pos.row == 2 {
+			homeRow++
+		}
+	}
+	if len(keys) == 0 {
+		analysis.Notes = "no keys on this layout"
+		return analysis
+	}
+
+	for i := 1; i < len(keys); i++ {
+		prev, cur := keys[i-1], keys[i]
+		analysis.TravelDistance += math.Hypot(cur.x-prev.x, cur.y-prev.y)
+		if handOf(prev) != handOf(cur) {
+			switches++
+		}
+		if typed[i] != typed[i-1] && fingerOf(prev) == fingerOf(cur) {
+			analysis.AwkwardSequences = append(analysis.AwkwardSequences, string(typed[i-1:i+1]))
+		}
+	}
+	analysis.TravelDistance = math.Round(analysis.TravelDistance*10) / 10
+	analysis.HomeRowPercentage = homeRow * 100 / len(keys)
+
+	alternation := 1.0
+	if len(keys) > 1 {
+		alternation = float64(switches) / float64(len(keys)-1)
+	}
+	switch {
+	case alternation >= 0.6:
+		analysis.HandAlternation = "good"
+	case alternation >= 0.35:
+		analysis.HandAlternation = "mixed"
+	default:
+		analysis.HandAlternation = "poor"
+	}
+
+	// Average travel per keystroke of about 2 key widths is typical
+	perKey := analysis.TravelDistance / float64(len(keys))
+	score := 100 - 12*math.Max(0, perKey-1) - 10*float64(len(analysis.AwkwardSequences)) + 10*float64(analysis.HomeRowPercentage)/100
+	if analysis.HandAlternation == "poor" {
+		score -= 10
+	}
+	score -= 15 * float64(len(missing))
+	analysis.Score = clampScore(score)
+
+	if len(missing) > 0 {
+		analysis.Notes = fmt.Sprintf("not on layout: %s", strings.Join(missing, " "))
+	}
+	return analysis
+}
+
+// handOf assumes touch typing with the split between columns 4 and 5.
+func handOf(pos keyPos) int {
+	if pos.col <= 4 {
+		return 0
+	}
+	return 1
+}
+
+// fingerOf maps a key to the touch-typing finger that presses it: pinky,
+// ring, middle, and index for each hand, with index fingers covering two
+// columns and the right pinky everything past column 8.
+func fingerOf(pos keyPos) int {
+	switch {
+	case pos.col <= 2:
+		return pos.col
+	case pos.col <= 4:
+		return 3
+	case pos.col <= 6:
+		return 4
+	case pos.col <= 8:
+		return pos.col - 2
+	default:
+		return 7
+	}
+}
+
+func clampScore(score float64) int {
+	return int(math.Round(math.Max(0, math.Min(100, score))))
+}
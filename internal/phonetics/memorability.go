@@ -0,0 +1,162 @@
+package phonetics
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// Memorability is a deterministic estimate of how easily a name is
+// remembered, scored 0-100 from its length, syllable structure, how familiar
+// its letter pairs are in English, and how close it is to a common word.
+type Memorability struct {
+	Score          int    `json:"score"`
+	LengthScore    int    `json:"length_score"`
+	StructureScore int    `json:"structure_score"`
+	Familiarity    int    `json:"familiarity"`
+	WordAnchor     int    `json:"word_anchor"`
+	NearestWord    string `json:"nearest_word,omitempty"`
+}
+
+// Weights of the memorability components; they add up to 1.
+const (
+	lengthWeight      = 0.25
+	structureWeight   = 0.25
+	familiarityWeight = 0.30
+	anchorWeight      = 0.20
+)
+
+var (
+	bigramsOnce sync.Once
+	bigrams     map[string]int
+	maxBigram   int
+)
+
+// loadBigrams counts the letter pairs of the common words, with "^" and "$"
+// marking the start and end of a word so that openings and endings count too.
+func loadBigrams() (map[string]int, int) {
+	bigramsOnce.Do(func() {
+		bigrams = map[string]int{}
+		for _, word := range loadCommonWords() {
+			for _, pair := range wordBigrams(word.word) {
+				bigrams[pair]++
+				maxBigram = max(maxBigram, bigrams[pair])
+			}
+		}
+	})
+	return bigrams, maxBigram
+}
+
+func wordBigrams(letters string) []string {
+	if letters == "" {
+		return nil
+	}
+	padded := "^" + letters + "$"
+	pairs := make([]string, 0, len(padded)-1)
+	for i := 0; i+1 < len(padded); i++ {
+		pairs = append(pairs, padded[i:i+2])
+	}
+	return pairs
+}
+
+// ScoreMemorability scores how memorable name is. It only looks at the
+// letters, so the same name always gets the same score.
+func ScoreMemorability(name string) Memorability {
+	letters := lettersOnly(name)
+	if letters == "" {
+		return Memorability{}
+	}
+	result := Memorability{
+		LengthScore:    lengthScore(len(letters)),
+		StructureScore: structureScore(letters),
+		Familiarity:    familiarity(letters),
+	}
+	result.WordAnchor, result.NearestWord = wordAnchor(letters)
+	result.Score = clampScore(lengthWeight*float64(result.LengthScore) +
+		structureWeight*float64(result.StructureScore) +
+		familiarityWeight*float64(result.Familiarity) +
+		anchorWeight*float64(result.WordAnchor))
+	return result
+}
+
+// lengthScore favors four to seven letters; very short names are easy to
+// confuse and every letter past seven is harder to recall.
+func lengthScore(n int) int {
+	switch {
+	case n <= 2:
+		return 60
+	case n == 3:
+		return 85
+	case n <= 7:
+		return 100
+	default:
+		return clampScore(100 - 10*float64(n-7))
+	}
+}
+
+// structureScore favors one or two syllables built from alternating
+// consonants and vowels, penalizing extra syllables, long consonant runs and
+// names with no vowel sound.
+func structureScore(letters string) int {
+	syllables := splitSyllables(letters)
+	score := 100.0
+	switch n := len(syllables); {
+	case n == 3:
+		score -= 10
+	case n > 3:
+		score -= 10 + 15*float64(n-3)
+	}
+	for _, cluster := range consonantClusters(letters) {
+		score -= 10 * float64(len(cluster)-2)
+	}
+	vowels := 0
+	for i := 0; i < len(letters); i++ {
+		if isVowel(letters[i]) || letters[i] == 'y' {
+			vowels++
+		}
+	}
+	if vowels == 0 {
+		score -= 40
+	}
+	return clampScore(score)
+}
+
+// familiarity rates the name's letter pairs against the common words: pairs
+// English never uses score nothing, and frequent ones score most.
+func familiarity(letters string) int {
+	counts, top := loadBigrams()
+	pairs := wordBigrams(letters)
+	if len(pairs) == 0 || top == 0 {
+		return 0
+	}
+	total := 0.0
+	for _, pair := range pairs {
+		if count := counts[pair]; count > 0 {
+			// A pair seen once is still familiar; frequency adds the rest
+			total += 0.5 + 0.5*math.Log1p(float64(count))/math.Log1p(float64(top))
+		}
+	}
+	return clampScore(100 * total / float64(len(pairs)))
+}
+
+// wordAnchor rates how close the name is to a common word, which gives it
+// something to hang on to, and returns the closest word. A name that is a
+// common word, or contains one, scores highest.
+func wordAnchor(letters string) (int, string) {
+	best, nearest := math.MaxInt, ""
+	for _, word := range loadCommonWords() {
+		distance := EditDistance(letters, word.word)
+		if len(word.word) >= 3 && strings.Contains(letters, word.word) {
+			// Embedded words ("fulgate") anchor on the word itself; the rest
+			// of the name still costs a little
+			distance = min(distance, (len(letters)-len(word.word)+1)/2)
+		}
+		if distance < best || (distance == best && len(word.word) > len(nearest)) {
+			best, nearest = distance, word.word
+		}
+	}
+	if nearest == "" {
+		return 0, ""
+	}
+	return clampScore(100 * (1 - float64(best)/float64(len(letters)))), nearest
+}
@@ -0,0 +1,134 @@
+package phonetics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoundex(t *testing.T) {
+	for word, want := range map[string]string{
+		"Robert":   "R163",
+		"Rupert":   "R163",
+		"Ashcraft": "A261",
+		"Tymczak":  "T522",
+		"Pfister":  "P236",
+		"Honeyman": "H555",
+		"Lee":      "L000",
+		"42":       "",
+	} {
+		require.Equal(t, want, Soundex(word), word)
+	}
+}
+
+func TestMetaphone(t *testing.T) {
+	for word, want := range map[string]string{
+		"knight":   "NT",
+		"phone":    "FN",
+		"science":  "SNS",
+		"thumb":    "0M",
+		"Xanadu":   "SNT",
+		"whistle":  "WSTL",
+		"fulsigil": "FLSJL",
+		"scam":     "SKM",
+		"nation":   "NXN",
+	} {
+		require.Equal(t, want, Metaphone(word), word)
+	}
+}
+
+func TestSplitSyllables(t *testing.T) {
+	for word, want := range map[string]string{
+		"fulsigil": "ful-si-gil",
+		"coffee":   "cof-fee",
+		"ashcraft": "ash-craft",
+		"table":    "ta-ble",
+		"make":     "make",
+		"tale":     "tale",
+		"strength": "strength",
+	} {
+		require.Equal(t, want, strings.Join(splitSyllables(word), "-"), word)
+	}
+}
+
+func TestAnalyzeFlagsSoundAlikesAndClusters(t *testing.T) {
+	analysis := Analyze("Scamm", nil)
+	require.Equal(t, Engine, analysis.Engine)
+	require.Equal(t, []string{"mm"}, analysis.DoubleLetters)
+	require.NotEmpty(t, analysis.SoundAlikes)
+	require.Equal(t, "scam", analysis.SoundAlikes[0].Word)
+	require.Contains(t, analysis.OverallAssessment.Concerns, "sounds like scam, scum")
+
+	analysis = Analyze("strength", nil)
+	require.Equal(t, []string{"str", "ngth"}, analysis.ConsonantClusters)
+	require.Equal(t, 1, analysis.Syllables.Count)
+	require.Empty(t, analysis.SoundAlikes)
+
+	analysis = Analyze("ls", nil)
+	require.Equal(t, []string{"ls"}, analysis.CLISuitability.ShellConflicts)
+	require.Less(t, analysis.CLISuitability.Score, 60)
+}
+
+func TestScoreMemorability(t *testing.T) {
+	fulgate := ScoreMemorability("fulgate")
+	require.Equal(t, fulgate, ScoreMemorability("FulGate"))
+	require.Equal(t, "gate", fulgate.NearestWord)
+	require.Equal(t, 100, fulgate.LengthScore)
+	require.Equal(t, 100, fulgate.StructureScore)
+
+	// A common word anchors perfectly
+	apple := ScoreMemorability("apple")
+	require.Equal(t, "apple", apple.NearestWord)
+	require.Equal(t, 100, apple.WordAnchor)
+
+	// Consonant runs and unfamiliar letter pairs are hard to recall
+	jumble := ScoreMemorability("xkqzvw")
+	require.Less(t, jumble.StructureScore, 50)
+	require.Less(t, jumble.Familiarity, fulgate.Familiarity)
+	require.Less(t, jumble.Score, 50)
+	require.Greater(t, fulgate.Score, 80)
+
+	// Every letter past seven costs length
+	require.Less(t, ScoreMemorability("stellaplexon").LengthScore, ScoreMemorability("stellap").LengthScore)
+
+	require.Equal(t, Memorability{}, ScoreMemorability("42"))
+	require.Equal(t, fulgate, Analyze("fulgate", nil).Memorability)
+}
+
+func TestAnalyzeKeyboards(t *testing.T) {
+	analysis := Analyze("fulsigil", nil)
+	require.Len(t, analysis.Typeability.ByKeyboard, len(DefaultLayouts))
+
+	analysis = Analyze("fulsigil", []string{"QWERTY (US)", "JIS (JP)", "Dvorak", "Hebrew"})
+	require.Len(t, analysis.Typeability.ByKeyboard, 2)
+	require.Equal(t, "QWERTY", analysis.Typeability.ByKeyboard[0].Layout)
+	require.Equal(t, "Dvorak", analysis.Typeability.ByKeyboard[1].Layout)
+	for _, keyboard := range analysis.Typeability.ByKeyboard {
+		require.Positive(t, keyboard.TravelDistance)
+		require.NotEmpty(t, keyboard.HandAlternation)
+	}
+}
+
+func TestLayoutTravel(t *testing.T) {
+	qwerty := Layouts["qwerty"]
+	// Home row neighbours are one key apart
+	require.Equal(t, 1.0, qwerty.Type("as").TravelDistance)
+	require.Equal(t, 100, qwerty.Type("as").HomeRowPercentage)
+
+	// "z" sits on the bottom row on QWERTY and the top row on QWERTZ
+	require.Less(t, Layouts["qwertz"].Type("zu").TravelDistance, qwerty.Type("zu").TravelDistance)
+
+	// Same finger, different keys
+	require.Equal(t, []string{"de"}, qwerty.Type("de").AwkwardSequences)
+
+	analysis := qwerty.Type("ab€")
+	require.Contains(t, analysis.Notes, "not on layout")
+}
+
+func TestLayoutNeighbors(t *testing.T) {
+	qwerty := Layouts["qwerty"]
+	require.Equal(t, []rune("adwxze"), qwerty.Neighbors('s'))
+	require.Equal(t, []rune("njk"), qwerty.Neighbors('m'))
+	require.Nil(t, qwerty.Neighbors('€'))
+}
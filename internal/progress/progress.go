@@ -0,0 +1,182 @@
+// Package progress renders a single-line live status for long-running CLI
+// commands: a spinner, a completed/total counter, and the lookup each active
+// name is waiting on. It is meant for interactive terminals only; callers
+// decide when to enable it.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/namelens/namelens/internal/ailink"
+	"github.com/namelens/namelens/internal/core"
+)
+
+const (
+	defaultInterval = 100 * time.Millisecond
+	defaultWidth    = 80
+	clearLine       = "\r\033[K"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Display tracks units of work and redraws a status line. All methods are safe
+// for concurrent use, and a nil Display does nothing.
+type Display struct {
+	w        io.Writer
+	total    int
+	width    int
+	interval time.Duration
+
+	mu     sync.Mutex
+	done   int
+	frame  int
+	active map[string]*activeName
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+type activeName struct {
+	units int
+	step  string
+}
+
+// New creates a display for total units of work, truncating the status line
+// to width columns (80 when width is not positive).
+func New(w io.Writer, total, width int) *Display {
+	if width <= 0 {
+		width = defaultWidth
+	}
+	return &Display{
+		w:        w,
+		total:    total,
+		width:    width,
+		interval: defaultInterval,
+		active:   make(map[string]*activeName),
+	}
+}
+
+// Start begins redrawing the status line until Stop is called.
+func (d *Display) Start() {
+	if d == nil || d.stop != nil {
+		return
+	}
+	d.stop = make(chan struct{})
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-d.stop:
+				return
+			case <-ticker.C:
+				d.render()
+			}
+		}
+	}()
+}
+
+// Stop ends redrawing and clears the status line so regular output starts on
+// a clean line.
+func (d *Display) Stop() {
+	if d == nil || d.stop == nil {
+		return
+	}
+	close(d.stop)
+	d.wg.Wait()
+	d.stop = nil
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, _ = io.WriteString(d.w, clearLine)
+}
+
+// Begin marks one unit of work for name as in progress and returns a context
+// whose lookups report their step to the display. Streamed AI responses also
+// report the fields received so far.
+func (d *Display) Begin(ctx context.Context, name string) context.Context {
+	if d == nil {
+		return ctx
+	}
+	d.mu.Lock()
+	entry := d.active[name]
+	if entry == nil {
+		entry = &activeName{}
+		d.active[name] = entry
+	}
+	entry.units++
+	d.mu.Unlock()
+
+	ctx = core.WithProgress(ctx, func(step string) { d.Step(name, step) })
+	return ailink.WithStreamProgress(ctx, func(p ailink.StreamProgress) {
+		d.Step(name, fmt.Sprintf("AI %s: %s", p.PromptSlug, p))
+	})
+}
+
+// Step records the lookup name is currently waiting on.
+func (d *Display) Step(name, step string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if entry := d.active[name]; entry != nil {
+		entry.step = step
+	}
+}
+
+// Done marks one unit of work for name as finished.
+func (d *Display) Done(name string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.done++
+	if entry := d.active[name]; entry != nil {
+		entry.units--
+		if entry.units <= 0 {
+			delete(d.active, name)
+		}
+	}
+}
+
+func (d *Display) render() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.frame = (d.frame + 1) % len(spinnerFrames)
+	_, _ = io.WriteString(d.w, clearLine+d.line())
+}
+
+// line builds the status text; callers hold d.mu.
+func (d *Display) line() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %d/%d", spinnerFrames[d.frame], d.done, d.total)
+
+	names := make([]string, 0, len(d.active))
+	for name := range d.active {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(" · ")
+		b.WriteString(name)
+		if step := d.active[name].step; step != "" {
+			b.WriteString(": ")
+			b.WriteString(step)
+		}
+	}
+
+	runes := []rune(b.String())
+	if len(runes) > d.width-1 {
+		return string(runes[:d.width-2]) + "…"
+	}
+	return string(runes)
+}
@@ -0,0 +1,47 @@
+package progress
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/namelens/namelens/internal/core"
+)
+
+func TestDisplayLine(t *testing.T) {
+	d := New(&bytes.Buffer{}, 3, 80)
+
+	ctx := d.Begin(context.Background(), "beta")
+	d.Begin(context.Background(), "acme")
+	core.ReportProgress(ctx, "domain beta.io")
+	require.Equal(t, "⠋ 0/3 · acme · beta: domain beta.io", d.line())
+
+	d.Done("acme")
+	require.Equal(t, "⠋ 1/3 · beta: domain beta.io", d.line())
+}
+
+func TestDisplayTruncatesToWidth(t *testing.T) {
+	d := New(&bytes.Buffer{}, 1, 20)
+	d.Begin(context.Background(), strings.Repeat("x", 40))
+
+	line := d.line()
+	require.Len(t, []rune(line), 19)
+	require.True(t, strings.HasSuffix(line, "…"))
+}
+
+func TestDisplayStopClearsLine(t *testing.T) {
+	var buf bytes.Buffer
+	d := New(&buf, 1, 80)
+	d.Start()
+	d.Stop()
+	require.True(t, strings.HasSuffix(buf.String(), clearLine))
+
+	var nilDisplay *Display
+	nilDisplay.Start()
+	require.Equal(t, context.Background(), nilDisplay.Begin(context.Background(), "acme"))
+	nilDisplay.Done("acme")
+	nilDisplay.Stop()
+}
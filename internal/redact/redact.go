@@ -0,0 +1,251 @@
+// Package redact scrubs credentials from text before it reaches command
+// output, AILink trace files, evidence captures, or logs.
+//
+// A process-wide Redactor is configured from config (known secrets plus
+// redaction.patterns); until then the built-in credential patterns apply.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// Placeholder replaces every redacted value.
+const Placeholder = "[REDACTED]"
+
+// minSecretLength keeps very short configured values from redacting ordinary
+// words that happen to match them.
+const minSecretLength = 8
+
+type pattern struct {
+	re *regexp.Regexp
+	// keepPrefix preserves capture group 1 (e.g. "Bearer " or "?api_key=")
+	// and redacts the rest of the match.
+	keepPrefix bool
+}
+
+// builtinPatterns match common credential formats.
+var builtinPatterns = []pattern{
+	{re: regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`)},        // OpenAI and Anthropic keys
+	{re: regexp.MustCompile(`\bxai-[A-Za-z0-9_-]{16,}`)},       // xAI keys
+	{re: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{20,}`)},   // GitHub tokens
+	{re: regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{20,}`)}, // GitHub fine-grained tokens
+	{re: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{30,}`)},       // Google API keys
+	{re: regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9._~+/=-]{20,}`), keepPrefix: true},
+	{re: regexp.MustCompile(`(?i)(\bauthorization:\s*basic\s+)[A-Za-z0-9+/=]{8,}`), keepPrefix: true},
+	{re: regexp.MustCompile(`(?i)([?&](?:api[_-]?key|key|token|access_token|auth)=)[^&\s"']+`), keepPrefix: true},
+}
+
+// sensitiveKeys are JSON keys and header names whose values are always
+// redacted, whatever they contain.
+var sensitiveKeys = map[string]struct{}{
+	"authorization":       {},
+	"proxy-authorization": {},
+	"api_key":             {},
+	"api-key":             {},
+	"apikey":              {},
+	"x-api-key":           {},
+	"x-goog-api-key":      {},
+	"token":               {},
+	"access_token":        {},
+	"refresh_token":       {},
+	"auth_token":          {},
+	"secret":              {},
+	"client_secret":       {},
+	"password":            {},
+}
+
+// IsSensitiveKey reports whether values under a JSON key or header name are
+// always redacted.
+func IsSensitiveKey(name string) bool {
+	_, ok := sensitiveKeys[strings.ToLower(strings.TrimSpace(name))]
+	return ok
+}
+
+// Redactor replaces known secret values and credential patterns. A nil
+// Redactor passes values through unchanged.
+type Redactor struct {
+	secrets  []string
+	patterns []pattern
+}
+
+// New returns a Redactor for the given secret values and extra regular
+// expressions, on top of the built-in credential patterns.
+func New(secrets []string, patterns []string) (*Redactor, error) {
+	r := &Redactor{patterns: append([]pattern(nil), builtinPatterns...)}
+
+	seen := make(map[string]struct{}, len(secrets))
+	for _, secret := range secrets {
+		secret = strings.TrimSpace(secret)
+		if len(secret) < minSecretLength {
+			continue
+		}
+		if _, ok := seen[secret]; ok {
+			continue
+		}
+		seen[secret] = struct{}{}
+		r.secrets = append(r.secrets, secret)
+	}
+	// Longest first, so a secret containing another is replaced whole.
+	sort.Slice(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+
+	for _, expr := range patterns {
+		if strings.TrimSpace(expr) == "" {
+			continue
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", expr, err)
+		}
+		r.patterns = append(r.patterns, pattern{re: re})
+	}
+	return r, nil
+}
+
+// String returns s with secrets replaced by Placeholder.
+func (r *Redactor) String(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, Placeholder)
+	}
+	for _, p := range r.patterns {
+		if p.keepPrefix {
+			s = p.re.ReplaceAllString(s, "${1}"+Placeholder)
+		} else {
+			s = p.re.ReplaceAllLiteralString(s, Placeholder)
+		}
+	}
+	return s
+}
+
+// JSON redacts a JSON document: values under sensitive keys are replaced
+// outright and every string is scrubbed. The input is returned unchanged
+// when nothing needed redacting; invalid JSON is scrubbed as text.
+func (r *Redactor) JSON(raw []byte) []byte {
+	if r == nil || len(bytes.TrimSpace(raw)) == 0 {
+		return raw
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return []byte(r.String(string(raw)))
+	}
+
+	redacted, changed := r.walk(value)
+	if !changed {
+		return raw
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redacted); err != nil {
+		return []byte(r.String(string(raw)))
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+// Value redacts strings inside maps and slices such as decoded JSON or
+// report details. Values under sensitive keys are replaced outright.
+func (r *Redactor) Value(value any) any {
+	if r == nil {
+		return value
+	}
+	redacted, _ := r.walk(value)
+	return redacted
+}
+
+func (r *Redactor) walk(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		out := r.String(v)
+		return out, out != v
+	case map[string]any:
+		changed := false
+		out := make(map[string]any, len(v))
+		for key, item := range v {
+			if IsSensitiveKey(key) && item != nil && item != "" {
+				out[key] = Placeholder
+				changed = true
+				continue
+			}
+			redacted, itemChanged := r.walk(item)
+			out[key] = redacted
+			changed = changed || itemChanged
+		}
+		return out, changed
+	case []any:
+		changed := false
+		out := make([]any, len(v))
+		for i, item := range v {
+			redacted, itemChanged := r.walk(item)
+			out[i] = redacted
+			changed = changed || itemChanged
+		}
+		return out, changed
+	default:
+		return value, false
+	}
+}
+
+var current atomic.Pointer[Redactor]
+
+func init() {
+	r, _ := New(nil, nil)
+	current.Store(r)
+}
+
+// SetDefault replaces the process-wide Redactor; nil disables redaction.
+func SetDefault(r *Redactor) {
+	current.Store(r)
+}
+
+// Default returns the process-wide Redactor, or nil when disabled.
+func Default() *Redactor {
+	return current.Load()
+}
+
+// String scrubs s with the process-wide Redactor.
+func String(s string) string {
+	return Default().String(s)
+}
+
+// JSON scrubs a JSON document with the process-wide Redactor.
+func JSON(raw []byte) []byte {
+	return Default().JSON(raw)
+}
+
+// Value scrubs decoded values with the process-wide Redactor.
+func Value(value any) any {
+	return Default().Value(value)
+}
+
+// Writer wraps w so each Write is scrubbed with the process-wide Redactor in
+// effect at the time of the write. Secrets split across two writes are not
+// detected, so callers should write whole documents.
+func Writer(w io.Writer) io.Writer {
+	return &writer{w: w}
+}
+
+type writer struct {
+	w io.Writer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	r := Default()
+	if r == nil {
+		return w.w.Write(p)
+	}
+	if _, err := io.WriteString(w.w, r.String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,205 @@
+// Package reviewtemplate loads review templates: named presets that bundle the
+// review mode, availability profile, suitability sensitivity, locales, and
+// output format for a decision context.
+package reviewtemplate
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed templates/*.yaml
+var defaultTemplatesFS embed.FS
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+var (
+	validModes         = []string{"quick", "core", "brand", "full"}
+	validDepths        = []string{"quick", "deep"}
+	validSensitivities = []string{"minimal", "standard", "strict"}
+	validFormats       = []string{"table", "json", "markdown", "html", "csv"}
+)
+
+// Template is a review preset. Empty fields leave the corresponding review
+// flag at its default.
+type Template struct {
+	Name         string   `yaml:"name" json:"name"`
+	Description  string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Mode         string   `yaml:"mode,omitempty" json:"mode,omitempty"`
+	Profile      string   `yaml:"profile,omitempty" json:"profile,omitempty"`
+	Depth        string   `yaml:"depth,omitempty" json:"depth,omitempty"`
+	Sensitivity  string   `yaml:"sensitivity,omitempty" json:"sensitivity,omitempty"`
+	Locales      []string `yaml:"locales,omitempty" json:"locales,omitempty"`
+	OutputFormat string   `yaml:"output_format,omitempty" json:"output_format,omitempty"`
+
+	// Source is the file the template was loaded from.
+	Source string `yaml:"-" json:"source"`
+}
+
+// Load parses and validates a template definition from YAML bytes.
+func Load(source string, data []byte) (*Template, error) {
+	var tmpl Template
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("parse review template %s: %w", source, err)
+	}
+	tmpl.Source = source
+	tmpl.normalize()
+	if err := tmpl.validate(); err != nil {
+		return nil, fmt.Errorf("validate review template %s: %w", source, err)
+	}
+	return &tmpl, nil
+}
+
+// LoadDefaults loads the embedded template set.
+func LoadDefaults() ([]*Template, error) {
+	entries, err := defaultTemplatesFS.ReadDir("templates")
+	if err != nil {
+		return nil, fmt.Errorf("read embedded review templates: %w", err)
+	}
+	results := make([]*Template, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := defaultTemplatesFS.ReadFile("templates/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read embedded review template %s: %w", entry.Name(), err)
+		}
+		tmpl, err := Load("builtin:"+entry.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, tmpl)
+	}
+	return results, nil
+}
+
+// LoadFromDir reads all template files (.yaml or .yml) from a directory.
+// A missing directory yields no templates.
+func LoadFromDir(dir string) ([]*Template, error) {
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	paths := make([]string, 0)
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("scan review templates: %w", err)
+		}
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+
+	results := make([]*Template, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path) // #nosec G304 -- template directory is user-configured
+		if err != nil {
+			return nil, fmt.Errorf("read review template %s: %w", path, err)
+		}
+		tmpl, err := Load(path, data)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, tmpl)
+	}
+	return results, nil
+}
+
+// LoadAll returns the built-in templates overlaid with templates from dir,
+// sorted by name. A template in dir replaces a built-in with the same name.
+func LoadAll(dir string) ([]*Template, error) {
+	defaults, err := LoadDefaults()
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]*Template, len(defaults))
+	for _, tmpl := range defaults {
+		merged[tmpl.Name] = tmpl
+	}
+
+	if dir = strings.TrimSpace(dir); dir != "" {
+		overrides, err := LoadFromDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, tmpl := range overrides {
+			merged[tmpl.Name] = tmpl
+		}
+	}
+
+	templates := make([]*Template, 0, len(merged))
+	for _, tmpl := range merged {
+		templates = append(templates, tmpl)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Find looks up a template by name in a loaded set.
+func Find(templates []*Template, name string) (*Template, error) {
+	needle := strings.ToLower(strings.TrimSpace(name))
+	names := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		if tmpl.Name == needle {
+			return tmpl, nil
+		}
+		names = append(names, tmpl.Name)
+	}
+	return nil, fmt.Errorf("unknown review template %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+func (t *Template) normalize() {
+	t.Name = strings.ToLower(strings.TrimSpace(t.Name))
+	t.Description = strings.TrimSpace(t.Description)
+	t.Mode = strings.ToLower(strings.TrimSpace(t.Mode))
+	t.Profile = strings.TrimSpace(t.Profile)
+	t.Depth = strings.ToLower(strings.TrimSpace(t.Depth))
+	t.Sensitivity = strings.ToLower(strings.TrimSpace(t.Sensitivity))
+	t.OutputFormat = strings.ToLower(strings.TrimSpace(t.OutputFormat))
+
+	locales := make([]string, 0, len(t.Locales))
+	for _, locale := range t.Locales {
+		if locale = strings.TrimSpace(locale); locale != "" {
+			locales = append(locales, locale)
+		}
+	}
+	t.Locales = locales
+}
+
+func (t *Template) validate() error {
+	if !namePattern.MatchString(t.Name) {
+		return fmt.Errorf("name %q must be lowercase letters, digits, '-' or '_'", t.Name)
+	}
+	if err := checkOneOf("mode", t.Mode, validModes); err != nil {
+		return err
+	}
+	if err := checkOneOf("depth", t.Depth, validDepths); err != nil {
+		return err
+	}
+	if err := checkOneOf("sensitivity", t.Sensitivity, validSensitivities); err != nil {
+		return err
+	}
+	return checkOneOf("output_format", t.OutputFormat, validFormats)
+}
+
+func checkOneOf(field, value string, allowed []string) error {
+	if value == "" {
+		return nil
+	}
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s %q must be one of %s", field, value, strings.Join(allowed, ", "))
+}
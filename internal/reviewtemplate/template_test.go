@@ -0,0 +1,67 @@
+package reviewtemplate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	templates, err := LoadDefaults()
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name)
+	}
+	require.ElementsMatch(t, []string{"startup-launch", "oss-library", "internal-codename"}, names)
+
+	oss, err := Find(templates, "OSS-Library")
+	require.NoError(t, err)
+	require.Equal(t, "oss", oss.Profile)
+	require.Equal(t, "builtin:oss-library.yaml", oss.Source)
+}
+
+func TestLoadAllOverridesBuiltins(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "oss.yaml"), []byte("name: oss-library\nmode: full\nprofile: developer\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fintech.yml"), []byte("name: fintech\nsensitivity: strict\nlocales: [en-US, ' ', de-DE]\n"), 0o600))
+
+	templates, err := LoadAll(dir)
+	require.NoError(t, err)
+	require.Len(t, templates, 4)
+	require.Equal(t, "fintech", templates[0].Name, "templates are sorted by name")
+	require.Equal(t, []string{"en-US", "de-DE"}, templates[0].Locales)
+
+	oss, err := Find(templates, "oss-library")
+	require.NoError(t, err)
+	require.Equal(t, "full", oss.Mode)
+	require.Equal(t, "developer", oss.Profile)
+
+	_, err = Find(templates, "missing")
+	require.ErrorContains(t, err, "available: fintech, internal-codename, oss-library, startup-launch")
+}
+
+func TestLoadAllMissingDir(t *testing.T) {
+	templates, err := LoadAll(filepath.Join(t.TempDir(), "absent"))
+	require.NoError(t, err)
+	require.Len(t, templates, 3)
+}
+
+func TestLoadRejectsInvalidTemplates(t *testing.T) {
+	cases := map[string]string{
+		"bad name":        "name: Bad Name\n",
+		"bad mode":        "name: x\nmode: deep\n",
+		"bad sensitivity": "name: x\nsensitivity: loose\n",
+		"bad format":      "name: x\noutput_format: pr-comment\n",
+		"unknown field":   "name: x\ntlds: [com]\n",
+	}
+	for label, data := range cases {
+		t.Run(label, func(t *testing.T) {
+			_, err := Load("test.yaml", []byte(data))
+			require.Error(t, err)
+		})
+	}
+}
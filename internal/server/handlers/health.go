@@ -38,14 +38,18 @@ type HealthChecker interface {
 // HealthManager manages health checks and probe states
 type HealthManager struct {
 	checkers map[string]HealthChecker
-	version  string
+	// readiness checkers gate only the readiness probe, so a replica that is
+	// still warming up is kept out of rotation without being restarted.
+	readiness map[string]HealthChecker
+	version   string
 }
 
 // NewHealthManager creates a new health manager
 func NewHealthManager(version string) *HealthManager {
 	return &HealthManager{
-		checkers: make(map[string]HealthChecker),
-		version:  version,
+		checkers:  make(map[string]HealthChecker),
+		readiness: make(map[string]HealthChecker),
+		version:   version,
 	}
 }
 
@@ -54,12 +58,21 @@ func (hm *HealthManager) RegisterChecker(name string, checker HealthChecker) {
 	hm.checkers[name] = checker
 }
 
+// RegisterReadinessChecker registers a checker consulted only by the
+// readiness probe
+func (hm *HealthManager) RegisterReadinessChecker(name string, checker HealthChecker) {
+	hm.readiness[name] = checker
+}
+
 // runHealthChecks executes all registered health checks
 // Returns checks with OpenAPI-conformant status values: pass, fail, warn
 func (hm *HealthManager) runHealthChecks(ctx context.Context) map[string]*HealthCheck {
-	checks := make(map[string]*HealthCheck)
+	return runCheckers(ctx, hm.checkers, make(map[string]*HealthCheck))
+}
 
-	for name, checker := range hm.checkers {
+// runCheckers executes checkers, adding their results to checks
+func runCheckers(ctx context.Context, checkers map[string]HealthChecker, checks map[string]*HealthCheck) map[string]*HealthCheck {
+	for name, checker := range checkers {
 		select {
 		case <-ctx.Done():
 			checks[name] = &HealthCheck{Status: "warn", Message: "timeout"}
@@ -167,7 +180,7 @@ func (hm *HealthManager) ReadinessHandler(w http.ResponseWriter, r *http.Request
 	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	checks := hm.runHealthChecks(checkCtx)
+	checks := runCheckers(checkCtx, hm.readiness, hm.runHealthChecks(checkCtx))
 	status := hm.determineOverallStatus(checks)
 
 	if status == "unhealthy" {
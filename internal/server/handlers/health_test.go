@@ -95,6 +95,24 @@ func TestHealthHandlerReturnsServiceUnavailableWhenUnhealthy(t *testing.T) {
 	}
 }
 
+func TestReadinessCheckerGatesOnlyReadiness(t *testing.T) {
+	manager := NewHealthManager("1.2.3")
+	manager.RegisterChecker("ok", stubChecker{err: nil})
+	manager.RegisterReadinessChecker("warmup", stubChecker{err: errors.New("warming up")})
+
+	rec := httptest.NewRecorder()
+	manager.ReadinessHandler(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected readiness status 503, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	manager.LivenessHandler(rec, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected liveness status 200, got %d", rec.Code)
+	}
+}
+
 func TestDetermineOverallStatusTreatsTimeoutAsDegraded(t *testing.T) {
 	manager := NewHealthManager("dev")
 
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+)
+
+// apiKeySlot carries the name of the API key that authenticated a request
+// back out to RequestMetrics, which runs before authentication.
+type apiKeySlot struct {
+	name string
+}
+
+type apiKeyContextKey struct{}
+
+func withAPIKeySlot(ctx context.Context) (context.Context, *apiKeySlot) {
+	slot := &apiKeySlot{}
+	return context.WithValue(ctx, apiKeyContextKey{}, slot), slot
+}
+
+// SetAPIKey records the name of the API key that authenticated the request,
+// for the request log and per-key metrics.
+func SetAPIKey(ctx context.Context, name string) {
+	if slot, ok := ctx.Value(apiKeyContextKey{}).(*apiKeySlot); ok {
+		slot.name = name
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// MaxBodyBytes rejects requests that declare a body larger than limit with
+// 413 and caps the rest, so handlers reading past limit get an
+// *http.MaxBytesError. A limit of zero or less disables it.
+func MaxBodyBytes(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				_ = json.NewEncoder(w).Encode(ErrorResponse{Error: ErrorDetail{
+					Code:      "too_large",
+					Message:   fmt.Sprintf("request body exceeds %d bytes", limit),
+					RequestID: GetRequestID(r.Context()),
+				}})
+				return
+			}
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSConfig configures cross-origin access for browser clients.
+type CORSConfig struct {
+	// AllowedOrigins are exact origins (scheme://host[:port]) or "*" for any.
+	// Empty disables CORS.
+	AllowedOrigins []string
+	// AllowedMethods default to GET and POST.
+	AllowedMethods []string
+	// AllowedHeaders default to Content-Type and X-API-Key.
+	AllowedHeaders []string
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+}
+
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodPost}
+	defaultCORSHeaders = []string{"Content-Type", "X-API-Key"}
+	// corsExposedHeaders are response headers browser code may read.
+	corsExposedHeaders = strings.Join([]string{RequestIDHeader, "Retry-After", "X-Quota-Limit", "X-Quota-Remaining"}, ", ")
+)
+
+// CORS answers preflight requests and adds CORS headers for allowed origins.
+// Requests from other origins get no CORS headers, so browsers refuse to
+// expose their responses; preflights from them are rejected with 403.
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	anyOrigin := false
+	for _, origin := range cfg.AllowedOrigins {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin == "*" {
+			anyOrigin = true
+		}
+		if origin != "" {
+			origins[strings.ToLower(origin)] = true
+		}
+	}
+
+	methods := normalizeList(cfg.AllowedMethods, defaultCORSMethods, strings.ToUpper)
+	headers := normalizeList(cfg.AllowedHeaders, defaultCORSHeaders, strings.TrimSpace)
+	allowedMethods := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		allowedMethods[method] = true
+	}
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		if len(origins) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			allowed := anyOrigin || origins[strings.ToLower(origin)]
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			w.Header().Add("Vary", "Origin")
+
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+				method := strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))
+				if !allowed || !allowedMethods[method] {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				setAllowOrigin(w, origin, anyOrigin)
+				w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+				w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+				if cfg.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", maxAge)
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if allowed {
+				setAllowOrigin(w, origin, anyOrigin)
+				w.Header().Set("Access-Control-Expose-Headers", corsExposedHeaders)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func setAllowOrigin(w http.ResponseWriter, origin string, anyOrigin bool) {
+	if anyOrigin {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+}
+
+func normalizeList(values, defaults []string, normalize func(string) string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			out = append(out, normalize(value))
+		}
+	}
+	if len(out) == 0 {
+		return defaults
+	}
+	return out
+}
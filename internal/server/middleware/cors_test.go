@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func corsHandler(cfg CORSConfig) http.Handler {
+	return CORS(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestCORS_Disabled(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/check", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+
+	corsHandler(CORSConfig{}).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	handler := corsHandler(CORSConfig{AllowedOrigins: []string{"https://app.example.com/"}})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/check", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, rec.Header().Values("Vary"), "Origin")
+	assert.Contains(t, rec.Header().Get("Access-Control-Expose-Headers"), "Retry-After")
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/check", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "the browser, not the server, blocks the response")
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	handler := corsHandler(CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, MaxAge: 10 * time.Minute})
+
+	tests := []struct {
+		name   string
+		origin string
+		method string
+		want   int
+	}{
+		{"allowed", "https://app.example.com", http.MethodPost, http.StatusNoContent},
+		{"other origin", "https://evil.example.com", http.MethodPost, http.StatusForbidden},
+		{"method not allowed", "https://app.example.com", http.MethodDelete, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/v1/check", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", tt.method)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Code)
+			if tt.want != http.StatusNoContent {
+				assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+				return
+			}
+			assert.Equal(t, tt.origin, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, "GET, POST", rec.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, "Content-Type, X-API-Key", rec.Header().Get("Access-Control-Allow-Headers"))
+			assert.Equal(t, "600", rec.Header().Get("Access-Control-Max-Age"))
+		})
+	}
+}
+
+func TestCORS_Wildcard(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/status", nil)
+	req.Header.Set("Origin", "https://anything.example")
+	rec := httptest.NewRecorder()
+
+	corsHandler(CORSConfig{AllowedOrigins: []string{"*"}}).ServeHTTP(rec, req)
+
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	var readErr error
+	handler := MaxBodyBytes(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 64)
+		for readErr == nil {
+			_, readErr = r.Body.Read(buf)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/check", strings.NewReader(`{"name":"toolong"}`))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Contains(t, rec.Body.String(), "too_large")
+
+	// Without a Content-Length the limit applies while reading
+	req = httptest.NewRequest(http.MethodPost, "/v1/check", io.NopCloser(strings.NewReader(`{"name":"toolong"}`)))
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	var tooLarge *http.MaxBytesError
+	assert.ErrorAs(t, readErr, &tooLarge)
+
+	readErr = nil
+	req = httptest.NewRequest(http.MethodPost, "/v1/check", strings.NewReader(`{}`))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.ErrorIs(t, readErr, io.EOF)
+}
@@ -28,6 +28,12 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, so
+// streaming handlers can flush.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // getEndpointPattern extracts chi route pattern to avoid high-cardinality paths
 func getEndpointPattern(r *http.Request) string {
 	// Try to get chi route pattern
@@ -72,6 +78,9 @@ func RequestMetrics(next http.Handler) http.Handler {
 			}
 		}
 
+		ctx, keySlot := withAPIKeySlot(r.Context())
+		r = r.WithContext(ctx)
+
 		next.ServeHTTP(wrapped, r)
 
 		duration := time.Since(start)
@@ -137,10 +146,23 @@ func RequestMetrics(next http.Handler) http.Handler {
 			)
 		}
 
+		// Key names come from config and the key store, so they are bounded
+		if keySlot.name != "" {
+			_ = observability.TelemetrySystem.Counter(
+				"http_api_key_requests_total",
+				1,
+				map[string]string{
+					"api_key":  keySlot.name,
+					"endpoint": endpoint,
+					"status":   strconv.Itoa(wrapped.statusCode),
+				},
+			)
+		}
+
 		// Log request with request ID for tracing (request ID stays in logs, not metrics)
 		requestID := GetRequestID(r.Context())
 		if observability.ServerLogger != nil {
-			observability.ServerLogger.Info("HTTP request completed",
+			fields := []zap.Field{
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
 				zap.String("endpoint", endpoint),
@@ -149,7 +171,11 @@ func RequestMetrics(next http.Handler) http.Handler {
 				zap.Int64("request_size", requestSize),
 				zap.Int64("response_size", wrapped.bytesWritten),
 				zap.String("requestID", requestID),
-			)
+			}
+			if keySlot.name != "" {
+				fields = append(fields, zap.String("api_key", keySlot.name))
+			}
+			observability.ServerLogger.Info("HTTP request completed", fields...)
 		}
 	})
 }
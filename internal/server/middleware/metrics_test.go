@@ -203,3 +203,25 @@ func TestRequestMetrics_DurationMeasurement(t *testing.T) {
 	assert.Greater(t, collector.CountMetricsByName("http_request_duration_ms"), 0,
 		"expected http_request_duration_ms metric to be emitted")
 }
+
+func TestRequestMetrics_APIKeyIdentity(t *testing.T) {
+	collector := setupTelemetry(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		SetAPIKey(r.Context(), "partner")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	rec := httptest.NewRecorder()
+	RequestMetrics(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, 1, collector.CountMetricsByName("http_api_key_requests_total"))
+
+	// Requests without a key add no per-key metric
+	anonymous := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	RequestMetrics(anonymous).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/test", nil))
+	assert.Equal(t, 1, collector.CountMetricsByName("http_api_key_requests_total"))
+}
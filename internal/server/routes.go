@@ -47,16 +47,27 @@ func (s *Server) registerAPIRoutes(authConfig api.AuthConfig) {
 		// Mount the generated API handlers
 		// Note: /health is already handled by existing health handlers
 		// So we only mount /v1/* endpoints here
-		r.Post("/v1/check", s.apiServer.CheckName)
-		r.Post("/v1/compare", s.apiServer.CompareCandidates)
-		r.Get("/v1/profiles", s.apiServer.ListProfiles)
-		r.Get("/v1/status", s.apiServer.GetStatus)
+		// Live checks need the check role; reads are open to read-only keys
+		// Live lookups also count against the key's rate limit and daily quota
+		r.With(api.RequireRole(api.RoleCheck), authConfig.Limits.Middleware).Post("/v1/check", s.apiServer.CheckName)
+		r.With(api.RequireRole(api.RoleCheck), authConfig.Limits.Middleware).Post("/v1/check/stream", s.apiServer.CheckStream)
+		r.With(api.RequireRole(api.RoleCheck), authConfig.Limits.Middleware).Post("/v1/compare", s.apiServer.CompareCandidates)
+		r.With(api.RequireRole(api.RoleReadOnly)).Get("/v1/profiles", s.apiServer.ListProfiles)
+		r.With(api.RequireRole(api.RoleReadOnly)).Get("/v1/status", s.apiServer.GetStatus)
+		r.With(api.RequireRole(api.RoleReadOnly)).Get("/v1/usage", s.apiServer.GetUsage)
+		r.With(api.RequireRole(api.RoleReadOnly)).Get("/calendar.ics", s.apiServer.GetCalendar)
+		r.With(api.RequireRole(api.RoleCheck)).Post("/v1/share", s.apiServer.CreateShare)
 	})
 
+	// Share links carry their own signed token, so they skip API auth
+	s.router.Get("/share/{token}", s.apiServer.GetShare)
+
 	logger := observability.ServerLogger
 	if logger != nil {
 		logger.Info("Control plane API routes registered",
-			zap.Bool("auth_required", authConfig.APIKey != ""),
+			zap.Bool("auth_configured", authConfig.Enabled()),
+			zap.Int("scoped_keys", len(authConfig.Keys)),
+			zap.Bool("stored_keys", authConfig.Store != nil),
 			zap.Bool("localhost_allowed", authConfig.AllowLocalhost))
 	}
 }
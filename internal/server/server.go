@@ -16,6 +16,7 @@ import (
 	"github.com/namelens/namelens/internal/observability"
 	"github.com/namelens/namelens/internal/server/handlers"
 	servermw "github.com/namelens/namelens/internal/server/middleware"
+	"github.com/namelens/namelens/internal/server/ui"
 )
 
 // Server represents the HTTP server
@@ -25,6 +26,9 @@ type Server struct {
 	host      string
 	port      int
 	apiServer *api.Server
+
+	cors         servermw.CORSConfig
+	maxBodyBytes int64
 }
 
 // New creates a new HTTP server instance (without control plane API)
@@ -39,11 +43,12 @@ func NewWithAPI(host string, port int, version string, authConfig api.AuthConfig
 	// Standard chi middleware
 	r.Use(middleware.RealIP)
 
-	// Our custom middleware in correct order (RequestID → Metrics → Logging → Recovery)
+	// Our custom middleware in correct order (RequestID → Tracing → Metrics → Logging → Recovery)
 	r.Use(servermw.RequestID)      // 1. Request ID (early for correlation)
-	r.Use(servermw.RequestMetrics) // 2. Metrics (measure everything)
-	r.Use(servermw.ErrorHandler)   // 3. Error handling (after metrics)
-	r.Use(servermw.Recovery)       // 4. Panic recovery (outermost)
+	r.Use(servermw.Tracing)        // 2. Tracing (spans for the whole request)
+	r.Use(servermw.RequestMetrics) // 3. Metrics (measure everything)
+	r.Use(servermw.ErrorHandler)   // 4. Error handling (after metrics)
+	r.Use(servermw.Recovery)       // 5. Panic recovery (outermost)
 
 	// Chi's Recoverer is redundant since we have our own Recovery middleware
 	// r.Use(middleware.Recoverer)
@@ -83,13 +88,62 @@ func NewWithAPI(host string, port int, version string, authConfig api.AuthConfig
 	return s
 }
 
+// SetUsage enables the control plane AI usage endpoint.
+func (s *Server) SetUsage(fn api.UsageFunc) {
+	if s.apiServer != nil {
+		s.apiServer.SetUsage(fn)
+	}
+}
+
+// SetCalendar enables the portfolio expiration calendar endpoint.
+func (s *Server) SetCalendar(fn api.CalendarFunc) {
+	if s.apiServer != nil {
+		s.apiServer.SetCalendar(fn)
+	}
+}
+
+// SetSharing enables share links to review and compare results.
+func (s *Server) SetSharing(sharing *api.Sharing) {
+	if s.apiServer != nil {
+		s.apiServer.SetSharing(sharing)
+	}
+}
+
+// SetReadOnly rejects mutating control plane endpoints, for public demos.
+func (s *Server) SetReadOnly(readOnly bool) {
+	if s.apiServer != nil {
+		s.apiServer.SetReadOnly(readOnly)
+	}
+}
+
+// SetCORS lets browser frontends on the configured origins call the API.
+func (s *Server) SetCORS(cfg servermw.CORSConfig) {
+	s.cors = cfg
+}
+
+// SetMaxBodyBytes caps request bodies; zero or less means no limit.
+func (s *Server) SetMaxBodyBytes(limit int64) {
+	s.maxBodyBytes = limit
+}
+
+// EnableUI serves the web dashboard at /ui. It needs the control plane API.
+func (s *Server) EnableUI() {
+	if s.apiServer == nil {
+		return
+	}
+	s.router.Get("/ui", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ui/", http.StatusMovedPermanently)
+	})
+	s.router.Handle("/ui/*", http.StripPrefix("/ui/", ui.Handler()))
+}
+
 // Start starts the HTTP server
 func (s *Server) Start() error {
 	addr := fmt.Sprintf("%s:%d", s.host, s.port)
 
 	s.server = &http.Server{
 		Addr:         addr,
-		Handler:      s.router,
+		Handler:      s.Handler(),
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  120 * time.Second,
@@ -109,9 +163,11 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return s.server.Shutdown(ctx)
 }
 
-// Handler exposes the underlying router for testing and instrumentation
+// Handler exposes the router, behind CORS and the body size limit, for
+// testing and instrumentation. They wrap the router rather than going
+// through Use so that preflight requests are answered before routing.
 func (s *Server) Handler() http.Handler {
-	return s.router
+	return servermw.CORS(s.cors)(servermw.MaxBodyBytes(s.maxBodyBytes)(s.router))
 }
 
 // Port returns the server port for testing
@@ -1,11 +1,15 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/namelens/namelens/internal/api"
+	"github.com/namelens/namelens/internal/core/engine"
 	apperrors "github.com/namelens/namelens/internal/errors"
 )
 
@@ -30,3 +34,64 @@ func TestServerUsesStandardErrorHandlers(t *testing.T) {
 		t.Fatalf("expected error code not_found, got %s", body.Error.Code)
 	}
 }
+
+func TestShareLinksSkipAPIAuth(t *testing.T) {
+	srv := NewWithAPI("127.0.0.1", 0, "1.0.0", api.AuthConfig{APIKey: "secret"}, &engine.Orchestrator{})
+	srv.SetSharing(&api.Sharing{Store: nopShareStore{}, Secret: []byte("share-secret"), TTL: time.Hour})
+
+	// Creating a share needs an API key
+	req := httptest.NewRequest(http.MethodPost, "/v1/share", nil)
+	req.RemoteAddr = "203.0.113.5:1234"
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", rec.Code)
+	}
+
+	// Viewing one does not; an unknown token renders the expired page
+	req = httptest.NewRequest(http.MethodGet, "/share/abc.1.sig", nil)
+	req.RemoteAddr = "203.0.113.5:1234"
+	rec = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+		t.Fatalf("expected the share page, got content type %q", got)
+	}
+}
+
+func TestEnableUI(t *testing.T) {
+	srv := NewWithAPI("127.0.0.1", 0, "1.0.0", api.AuthConfig{}, &engine.Orchestrator{})
+
+	req := httptest.NewRequest(http.MethodGet, "/ui/", nil)
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected no dashboard without --ui, got status %d", rec.Code)
+	}
+
+	srv.EnableUI()
+	for path, contentType := range map[string]string{
+		"/ui/":       "text/html; charset=utf-8",
+		"/ui/app.js": "text/javascript; charset=utf-8",
+	} {
+		req = httptest.NewRequest(http.MethodGet, path, nil)
+		rec = httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", path, rec.Code)
+		}
+		if got := rec.Header().Get("Content-Type"); got != contentType {
+			t.Fatalf("%s: expected content type %q, got %q", path, contentType, got)
+		}
+	}
+}
+
+type nopShareStore struct{}
+
+func (nopShareStore) SaveShare(ctx context.Context, record api.ShareRecord) error { return nil }
+
+func (nopShareStore) LoadShare(ctx context.Context, id string, now time.Time) (*api.ShareRecord, error) {
+	return nil, nil
+}
@@ -0,0 +1,251 @@
+// namelens dashboard: runs checks through POST /v1/check/stream and shows
+// results as each lookup resolves.
+(function () {
+  "use strict";
+
+  const keyStorage = "namelens.apiKey";
+  const $ = (id) => document.getElementById(id);
+
+  const form = $("check-form");
+  const apiKey = $("api-key");
+  const errorBox = $("error");
+  const progress = $("progress");
+  const resultsSection = $("results");
+
+  let reports = [];
+  let controller = null;
+
+  apiKey.value = localStorage.getItem(keyStorage) || "";
+  apiKey.addEventListener("change", () => {
+    localStorage.setItem(keyStorage, apiKey.value.trim());
+    loadProfiles();
+  });
+
+  function headers(extra) {
+    const h = Object.assign({}, extra);
+    const key = apiKey.value.trim();
+    if (key) {
+      h["X-API-Key"] = key;
+    }
+    return h;
+  }
+
+  function showError(message) {
+    errorBox.textContent = message;
+    errorBox.hidden = !message;
+  }
+
+  async function errorMessage(resp) {
+    try {
+      const body = await resp.json();
+      if (body.error && body.error.message) {
+        return body.error.message;
+      }
+    } catch (e) {
+      // not a JSON error body
+    }
+    return resp.status + " " + resp.statusText;
+  }
+
+  async function loadProfiles() {
+    const select = $("profile");
+    try {
+      const [status, profiles] = await Promise.all([
+        fetch("/v1/status", { headers: headers() }),
+        fetch("/v1/profiles", { headers: headers() }),
+      ]);
+      if (!profiles.ok) {
+        throw new Error(await errorMessage(profiles));
+      }
+      const selected = select.value || "startup";
+      select.replaceChildren();
+      for (const profile of (await profiles.json()).profiles) {
+        const option = document.createElement("option");
+        option.value = profile.name;
+        option.textContent = profile.description ? profile.name + " — " + profile.description : profile.name;
+        option.selected = profile.name === selected;
+        select.append(option);
+      }
+      if (status.ok) {
+        const info = await status.json();
+        $("server-status").textContent = "namelens " + (info.version || "") + " · " + location.host;
+      }
+      showError("");
+    } catch (err) {
+      $("server-status").textContent = "Not connected";
+      showError("Could not load profiles: " + err.message);
+    }
+  }
+
+  function splitList(value) {
+    return value.split(/[\s,]+/).map((s) => s.trim()).filter(Boolean);
+  }
+
+  function newTable(name) {
+    const heading = document.createElement("h2");
+    heading.textContent = name;
+    const summary = document.createElement("span");
+    summary.className = "meta";
+    heading.append(" ", summary);
+
+    const table = document.createElement("table");
+    const head = table.createTHead().insertRow();
+    for (const label of ["Type", "Name", "Status", "Notes"]) {
+      const th = document.createElement("th");
+      th.textContent = label;
+      head.append(th);
+    }
+    resultsSection.append(heading, table);
+    return { body: table.createTBody(), summary: summary };
+  }
+
+  function addRow(body, result) {
+    const row = body.insertRow();
+    const cells = [result.check_type, result.name, result.available, result.message || ""];
+    cells.forEach((text, i) => {
+      const cell = row.insertCell();
+      cell.textContent = text;
+      if (i === 2) {
+        cell.className = text;
+      }
+    });
+  }
+
+  function describeSummary(summary) {
+    return summary.available + " of " + summary.total + " available";
+  }
+
+  // readEvents calls onEvent for each server-sent event in the response body.
+  async function readEvents(resp, onEvent) {
+    const reader = resp.body.getReader();
+    const decoder = new TextDecoder();
+    let buffer = "";
+    for (;;) {
+      const { value, done } = await reader.read();
+      if (done) {
+        return;
+      }
+      buffer += decoder.decode(value, { stream: true });
+      let end;
+      while ((end = buffer.indexOf("\n\n")) >= 0) {
+        const frame = buffer.slice(0, end);
+        buffer = buffer.slice(end + 2);
+        let event = "message";
+        const data = [];
+        for (const line of frame.split("\n")) {
+          if (line.startsWith("event:")) {
+            event = line.slice(6).trim();
+          } else if (line.startsWith("data:")) {
+            data.push(line.slice(5).trim());
+          }
+        }
+        onEvent(event, JSON.parse(data.join("\n")));
+      }
+    }
+  }
+
+  async function checkName(name, request, signal) {
+    const view = newTable(name);
+    const resp = await fetch("/v1/check/stream", {
+      method: "POST",
+      headers: headers({ "Content-Type": "application/json" }),
+      body: JSON.stringify(Object.assign({ name: name }, request)),
+      signal: signal,
+    });
+    if (!resp.ok) {
+      throw new Error(name + ": " + (await errorMessage(resp)));
+    }
+    await readEvents(resp, (event, data) => {
+      switch (event) {
+        case "progress":
+          progress.textContent = data.step + "…";
+          break;
+        case "result":
+          addRow(view.body, data);
+          break;
+        case "done":
+          view.summary.textContent = describeSummary(data.summary);
+          reports.push(data);
+          break;
+        case "error":
+          throw new Error(name + ": " + data.error.message);
+      }
+    });
+  }
+
+  form.addEventListener("submit", async (e) => {
+    e.preventDefault();
+    const names = splitList($("names").value);
+    if (names.length === 0) {
+      return;
+    }
+    const request = { profile: $("profile").value };
+    const tlds = splitList($("tlds").value);
+    if (tlds.length > 0) {
+      request.tlds = tlds;
+    }
+
+    reports = [];
+    resultsSection.replaceChildren();
+    $("downloads").hidden = true;
+    showError("");
+    controller = new AbortController();
+    $("run").disabled = true;
+    $("stop").disabled = false;
+    try {
+      for (const name of names) {
+        await checkName(name, request, controller.signal);
+      }
+    } catch (err) {
+      if (err.name !== "AbortError") {
+        showError(err.message);
+      }
+    } finally {
+      progress.textContent = "";
+      $("run").disabled = false;
+      $("stop").disabled = true;
+      $("downloads").hidden = reports.length === 0;
+    }
+  });
+
+  $("stop").addEventListener("click", () => controller && controller.abort());
+
+  function download(filename, type, content) {
+    const link = document.createElement("a");
+    link.href = URL.createObjectURL(new Blob([content], { type: type }));
+    link.download = filename;
+    link.click();
+    URL.revokeObjectURL(link.href);
+  }
+
+  function reportName() {
+    return "namelens-" + reports.map((r) => r.name).join("-").slice(0, 60);
+  }
+
+  function markdownCell(value) {
+    return String(value || "").replace(/\|/g, "\\|").replace(/\n/g, " ");
+  }
+
+  function toMarkdown() {
+    const lines = ["# namelens report", ""];
+    for (const report of reports) {
+      lines.push("## " + report.name, "", describeSummary(report.summary), "");
+      lines.push("| Type | Name | Status | Notes |", "|------|------|--------|-------|");
+      for (const r of report.results) {
+        lines.push("| " + [r.check_type, r.name, r.available, r.message].map(markdownCell).join(" | ") + " |");
+      }
+      lines.push("");
+    }
+    return lines.join("\n");
+  }
+
+  $("download-json").addEventListener("click", () => {
+    const payload = reports.length === 1 ? reports[0] : reports;
+    download(reportName() + ".json", "application/json", JSON.stringify(payload, null, 2) + "\n");
+  });
+  $("download-markdown").addEventListener("click", () => {
+    download(reportName() + ".md", "text/markdown", toMarkdown());
+  });
+
+  loadProfiles();
+})();
@@ -0,0 +1,253 @@
+// Package similarity flags candidate names that are confusingly close to
+// existing brands. It compares names locally against a bundled list of
+// well-known brands and top domains plus any user-supplied brands, by
+// spelling, sound, and visually confusable characters, and generates the
+// typo and look-alike variants of a name that squatters register.
+package similarity
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/namelens/namelens/internal/phonetics"
+)
+
+//go:embed brands.txt
+var brandsFile string
+
+// Match sources.
+const (
+	SourceBuiltin = "builtin"
+	SourceUser    = "user"
+)
+
+// Metrics that make a brand a near collision.
+const (
+	// MetricIdentical means the name and brand are the same once case and
+	// punctuation are ignored.
+	MetricIdentical = "identical"
+	// MetricSpelling means the name is one or two edits from the brand.
+	MetricSpelling = "spelling"
+	// MetricPhonetic means the name and brand share Soundex and Metaphone
+	// codes, so they sound alike.
+	MetricPhonetic = "phonetic"
+	// MetricVisual means the name and brand look alike once confusable
+	// characters such as 0/o, 1/l, and rn/m are folded.
+	MetricVisual = "visual"
+)
+
+const (
+	// DefaultMinScore is the least score reported as a near collision.
+	DefaultMinScore = 75
+	// maxMatches limits how many brands a comparison reports.
+	maxMatches = 5
+	// minFuzzyLength is the shortest brand compared by spelling and sound;
+	// shorter brands only match identically or visually.
+	minFuzzyLength = 4
+)
+
+// Scores for metrics that do not depend on the edit distance.
+const (
+	identicalScore = 100
+	visualScore    = 95
+	phoneticScore  = 85
+)
+
+// Match is an existing brand that a name is close to.
+type Match struct {
+	Brand  string `json:"brand"`
+	Source string `json:"source"`
+	// Score rates how confusable the name and brand are, 0-100.
+	Score int `json:"score"`
+	// Distance is the edit distance between the normalized name and brand.
+	Distance int      `json:"distance"`
+	Metrics  []string `json:"metrics"`
+}
+
+type brand struct {
+	name      string
+	source    string
+	key       string
+	soundex   string
+	metaphone string
+	skeleton  string
+}
+
+// Index compares names against a set of brands.
+type Index struct {
+	// MinScore is the least score reported; zero uses DefaultMinScore.
+	MinScore int
+	brands   []brand
+}
+
+// NewIndex returns an index of the bundled brands plus user brands. A user
+// brand that matches a bundled one replaces it.
+func NewIndex(user []string) *Index {
+	index := &Index{}
+	seen := map[string]int{}
+	add := func(name, source string) {
+		name = strings.TrimSpace(name)
+		key := normalize(name)
+		if key == "" {
+			return
+		}
+		b := brand{
+			name:      name,
+			source:    source,
+			key:       key,
+			soundex:   phonetics.Soundex(key),
+			metaphone: phonetics.Metaphone(key),
+			skeleton:  skeleton(key),
+		}
+		if i, ok := seen[key]; ok {
+			index.brands[i] = b
+			return
+		}
+		seen[key] = len(index.brands)
+		index.brands = append(index.brands, b)
+	}
+	for _, name := range parseBrands(brandsFile) {
+		add(name, SourceBuiltin)
+	}
+	for _, name := range user {
+		add(name, SourceUser)
+	}
+	return index
+}
+
+// LoadBrandsFile reads brands from a file with one brand per line. Blank
+// lines and lines starting with # are skipped.
+func LoadBrandsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- user-provided brands file
+	if err != nil {
+		return nil, fmt.Errorf("read brands file: %w", err)
+	}
+	return parseBrands(string(data)), nil
+}
+
+func parseBrands(text string) []string {
+	var brands []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		brands = append(brands, line)
+	}
+	return brands
+}
+
+// Len returns the number of brands in the index.
+func (i *Index) Len() int {
+	if i == nil {
+		return 0
+	}
+	return len(i.brands)
+}
+
+// Compare returns the brands name is a near collision with, most confusable
+// first.
+func (i *Index) Compare(name string) []Match {
+	key := normalize(name)
+	if i == nil || key == "" {
+		return nil
+	}
+	minScore := i.MinScore
+	if minScore <= 0 {
+		minScore = DefaultMinScore
+	}
+	soundex, metaphone, shape := phonetics.Soundex(key), phonetics.Metaphone(key), skeleton(key)
+
+	var matches []Match
+	for _, b := range i.brands {
+		distance := phonetics.EditDistance(key, b.key)
+		match := Match{Brand: b.name, Source: b.source, Distance: distance}
+		if distance == 0 {
+			match.Score = identicalScore
+			match.Metrics = []string{MetricIdentical}
+			matches = append(matches, match)
+			continue
+		}
+
+		fuzzy := len(b.key) >= minFuzzyLength && len(key) >= minFuzzyLength
+		if fuzzy && distance <= maxEdits(len(b.key)) {
+			match.Metrics = append(match.Metrics, MetricSpelling)
+			match.Score = spellingScore(distance, key, b.key)
+		}
+		// Phonetic codes are coarse, so sound-alikes must also be spelled
+		// somewhat alike
+		if fuzzy && metaphone != "" && metaphone == b.metaphone && soundex == b.soundex && distance <= max(2, len(b.key)/3) {
+			match.Metrics = append(match.Metrics, MetricPhonetic)
+			match.Score = max(match.Score, phoneticScore)
+		}
+		if shape == b.skeleton {
+			match.Metrics = append(match.Metrics, MetricVisual)
+			match.Score = max(match.Score, visualScore)
+		}
+		if len(match.Metrics) > 0 && match.Score >= minScore {
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].Score != matches[b].Score {
+			return matches[a].Score > matches[b].Score
+		}
+		return matches[a].Distance < matches[b].Distance
+	})
+	if len(matches) > maxMatches {
+		matches = matches[:maxMatches]
+	}
+	return matches
+}
+
+// maxEdits allows one edit for brands up to six characters and two beyond.
+func maxEdits(length int) int {
+	if length <= 6 {
+		return 1
+	}
+	return 2
+}
+
+// spellingScore scales the share of characters in common to 0-100.
+func spellingScore(distance int, a, b string) int {
+	longest := max(len(a), len(b))
+	return int(math.Round(100 * (1 - float64(distance)/float64(longest))))
+}
+
+// normalize keeps lowercase ASCII letters and digits.
+func normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Character sequences that look alike in common fonts, folded to one form.
+// Longer sequences are replaced first.
+var confusables = strings.NewReplacer(
+	"rn", "m",
+	"vv", "w",
+	"cl", "d",
+	"0", "o",
+	"1", "l",
+	"i", "l",
+	"3", "e",
+	"5", "s",
+	"8", "b",
+	"9", "g",
+)
+
+// skeleton folds confusable characters so look-alike names compare equal.
+func skeleton(key string) string {
+	return confusables.Replace(key)
+}
@@ -0,0 +1,84 @@
+package similarity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareFindsNearCollisions(t *testing.T) {
+	index := NewIndex(nil)
+	require.Greater(t, index.Len(), 200)
+
+	matches := index.Compare("Strype")
+	require.NotEmpty(t, matches)
+	require.Equal(t, "stripe", matches[0].Brand)
+	require.Equal(t, SourceBuiltin, matches[0].Source)
+	require.Equal(t, 1, matches[0].Distance)
+	require.Equal(t, []string{MetricSpelling, MetricPhonetic}, matches[0].Metrics)
+	require.Equal(t, phoneticScore, matches[0].Score)
+
+	matches = index.Compare("G00GLE")
+	require.Equal(t, "google", matches[0].Brand)
+	require.Contains(t, matches[0].Metrics, MetricVisual)
+	require.Equal(t, visualScore, matches[0].Score)
+
+	matches = index.Compare("rnicrosoft")
+	require.Equal(t, "microsoft", matches[0].Brand)
+	require.Contains(t, matches[0].Metrics, MetricVisual)
+
+	matches = index.Compare("Git-Hub")
+	require.Equal(t, []string{MetricIdentical}, matches[0].Metrics)
+	require.Equal(t, identicalScore, matches[0].Score)
+}
+
+func TestCompareIgnoresDistantNames(t *testing.T) {
+	index := NewIndex(nil)
+	require.Empty(t, index.Compare("namelens"))
+	require.Empty(t, index.Compare("quillfeather"))
+	// Short brands only match identically or visually
+	require.Empty(t, index.Compare("ibx"))
+}
+
+func TestCompareRespectsMinScore(t *testing.T) {
+	index := NewIndex([]string{"Widgetly"})
+	matches := index.Compare("wadgetlx")
+	require.Len(t, matches, 1)
+	require.Equal(t, 75, matches[0].Score)
+
+	index.MinScore = 80
+	require.Empty(t, index.Compare("wadgetlx"))
+}
+
+func TestUserBrands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "brands.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# competitors\nWidgetly\n\nstripe\n"), 0o600))
+
+	brands, err := LoadBrandsFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Widgetly", "stripe"}, brands)
+
+	index := NewIndex(brands)
+	matches := index.Compare("widgetli")
+	require.Len(t, matches, 1)
+	require.Equal(t, "Widgetly", matches[0].Brand)
+	require.Equal(t, SourceUser, matches[0].Source)
+
+	// A user brand replaces the bundled entry with the same key
+	require.Equal(t, SourceUser, index.Compare("stripe")[0].Source)
+
+	_, err = LoadBrandsFile(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestComparePhoneticNeedsSimilarSpelling(t *testing.T) {
+	index := NewIndex(nil)
+	// "acme" shares sound codes with "akamai" but is spelled too differently
+	require.Empty(t, index.Compare("acme"))
+
+	matches := index.Compare("payple")
+	require.Equal(t, "paypal", matches[0].Brand)
+	require.Equal(t, []string{MetricPhonetic}, matches[0].Metrics)
+}
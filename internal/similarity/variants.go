@@ -0,0 +1,189 @@
+package similarity
+
+import (
+	"strings"
+
+	"github.com/namelens/namelens/internal/phonetics"
+)
+
+// Variant kinds, in the order Variants takes from them.
+const (
+	// KindHomoglyph swaps a letter for look-alike letters, such as m and rn.
+	KindHomoglyph = "homoglyph"
+	// KindLeet swaps a letter for a digit, such as e and 3.
+	KindLeet = "leet"
+	// KindTransposition swaps two neighbouring characters.
+	KindTransposition = "transposition"
+	// KindOmission drops a character.
+	KindOmission = "omission"
+	// KindAdjacentKey replaces a letter with a neighbouring QWERTY key.
+	KindAdjacentKey = "adjacent-key"
+	// KindRepetition doubles a character.
+	KindRepetition = "repetition"
+)
+
+// homoglyphs maps letter sequences to letters that look like them in common
+// fonts; it is the inverse of the confusables folded by skeleton.
+var homoglyphs = []struct{ from, to string }{
+	{"m", "rn"},
+	{"rn", "m"},
+	{"w", "vv"},
+	{"vv", "w"},
+	{"d", "cl"},
+	{"cl", "d"},
+	{"l", "i"},
+	{"i", "l"},
+}
+
+// leet maps letters to the digits they are commonly written as.
+var leet = map[byte]byte{
+	'a': '4',
+	'b': '8',
+	'e': '3',
+	'g': '9',
+	'i': '1',
+	'l': '1',
+	'o': '0',
+	's': '5',
+	't': '7',
+	'z': '2',
+}
+
+// Variant is a typo or look-alike spelling of a name.
+type Variant struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// Variants returns up to limit typo and look-alike variants of name, the
+// spellings typo-squatters register. It takes one variant of each kind in
+// turn, so a small limit still covers every kind; within a kind, edits
+// nearer the start of the name come first. Variants are valid domain labels
+// and never equal name; limit <= 0 returns all of them.
+func Variants(name string, limit int) []Variant {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) < 2 {
+		return nil
+	}
+
+	byKind := [][]Variant{
+		homoglyphVariants(name),
+		leetVariants(name),
+		transpositionVariants(name),
+		omissionVariants(name),
+		adjacentKeyVariants(name),
+		repetitionVariants(name),
+	}
+
+	seen := map[string]bool{name: true}
+	var variants []Variant
+	for round := 0; ; round++ {
+		added := false
+		for _, kind := range byKind {
+			if round >= len(kind) {
+				continue
+			}
+			added = true
+			variant := kind[round]
+			if seen[variant.Name] || !validLabel(variant.Name) {
+				continue
+			}
+			seen[variant.Name] = true
+			variants = append(variants, variant)
+			if limit > 0 && len(variants) == limit {
+				return variants
+			}
+		}
+		if !added {
+			return variants
+		}
+	}
+}
+
+func homoglyphVariants(name string) []Variant {
+	var variants []Variant
+	for i := 0; i < len(name); i++ {
+		for _, h := range homoglyphs {
+			if strings.HasPrefix(name[i:], h.from) {
+				variants = append(variants, Variant{Name: name[:i] + h.to + name[i+len(h.from):], Kind: KindHomoglyph})
+			}
+		}
+	}
+	return variants
+}
+
+func leetVariants(name string) []Variant {
+	var variants []Variant
+	for i := 0; i < len(name); i++ {
+		if digit, ok := leet[name[i]]; ok {
+			variants = append(variants, Variant{Name: name[:i] + string(digit) + name[i+1:], Kind: KindLeet})
+		}
+	}
+	return variants
+}
+
+func transpositionVariants(name string) []Variant {
+	var variants []Variant
+	for i := 0; i+1 < len(name); i++ {
+		if name[i] == name[i+1] {
+			continue
+		}
+		swapped := []byte(name)
+		swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+		variants = append(variants, Variant{Name: string(swapped), Kind: KindTransposition})
+	}
+	return variants
+}
+
+func omissionVariants(name string) []Variant {
+	if len(name) < 4 {
+		return nil
+	}
+	var variants []Variant
+	for i := 0; i < len(name); i++ {
+		// Dropping either letter of a double gives the same variant
+		if i > 0 && name[i] == name[i-1] {
+			continue
+		}
+		variants = append(variants, Variant{Name: name[:i] + name[i+1:], Kind: KindOmission})
+	}
+	return variants
+}
+
+func adjacentKeyVariants(name string) []Variant {
+	qwerty := phonetics.Layouts["qwerty"]
+	var variants []Variant
+	for i := 0; i < len(name); i++ {
+		for _, key := range qwerty.Neighbors(rune(name[i])) {
+			if key >= 'a' && key <= 'z' {
+				variants = append(variants, Variant{Name: name[:i] + string(key) + name[i+1:], Kind: KindAdjacentKey})
+			}
+		}
+	}
+	return variants
+}
+
+func repetitionVariants(name string) []Variant {
+	var variants []Variant
+	for i := 0; i < len(name); i++ {
+		if name[i] == '-' || (i > 0 && name[i] == name[i-1]) {
+			continue
+		}
+		variants = append(variants, Variant{Name: name[:i+1] + name[i:], Kind: KindRepetition})
+	}
+	return variants
+}
+
+// validLabel reports whether name is a valid domain label: lowercase letters,
+// digits, and inner hyphens, without a double hyphen.
+func validLabel(name string) bool {
+	if name == "" || len(name) > 63 || strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") || strings.Contains(name, "--") {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package similarity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariantsCoverEveryKind(t *testing.T) {
+	variants := Variants("Stripe", 6)
+	require.Equal(t, []Variant{
+		{Name: "strlpe", Kind: KindHomoglyph},
+		{Name: "5tripe", Kind: KindLeet},
+		{Name: "tsripe", Kind: KindTransposition},
+		{Name: "tripe", Kind: KindOmission},
+		{Name: "atripe", Kind: KindAdjacentKey},
+		{Name: "sstripe", Kind: KindRepetition},
+	}, variants)
+
+	all := Variants("stripe", 0)
+	require.Greater(t, len(all), 40)
+	seen := map[string]bool{}
+	for _, variant := range all {
+		require.NotEqual(t, "stripe", variant.Name)
+		require.False(t, seen[variant.Name], "duplicate variant %s", variant.Name)
+		seen[variant.Name] = true
+	}
+	require.True(t, seen["strpie"])
+	require.True(t, seen["str1pe"])
+}
+
+func TestVariantsHomoglyphs(t *testing.T) {
+	names := map[string]string{}
+	for _, variant := range Variants("modern", 0) {
+		if variant.Kind == KindHomoglyph {
+			names[variant.Name] = variant.Kind
+		}
+	}
+	require.Contains(t, names, "rnodern")
+	require.Contains(t, names, "moclern")
+	require.Contains(t, names, "modem")
+}
+
+func TestVariantsAreValidLabels(t *testing.T) {
+	for _, variant := range Variants("a-b", 0) {
+		require.True(t, validLabel(variant.Name), variant.Name)
+		require.NotContains(t, variant.Name, "--")
+	}
+	require.Nil(t, Variants("x", 5))
+}
@@ -0,0 +1,159 @@
+// Package tracing wraps OpenTelemetry for namelens. Setup installs an OTLP
+// exporter as the global tracer provider; until then spans are no-ops, so
+// instrumented code costs nothing when tracing is off.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/namelens/namelens"
+
+// OTLP protocols.
+const (
+	ProtocolHTTP = "http/protobuf"
+	ProtocolGRPC = "grpc"
+)
+
+// Options configures the OTLP exporter.
+type Options struct {
+	// Endpoint is the collector as host:port or a URL; empty uses the
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable or the exporter
+	// default.
+	Endpoint string
+	// Protocol is ProtocolHTTP or ProtocolGRPC; empty is ProtocolHTTP.
+	Protocol string
+	// Insecure disables TLS to the collector.
+	Insecure bool
+	// Headers are sent with every export.
+	Headers map[string]string
+	// SampleRatio is the fraction of traces recorded, from 0 to 1.
+	SampleRatio float64
+
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Setup exports spans over OTLP and returns a function that flushes and
+// stops the exporter.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	if opts.SampleRatio < 0 || opts.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sample ratio %v must be between 0 and 1", opts.SampleRatio)
+	}
+
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("service.name", opts.ServiceName)}
+	if opts.ServiceVersion != "" {
+		attrs = append(attrs, attribute.String("service.version", opts.ServiceVersion))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attrs...))
+	if err != nil {
+		return nil, fmt.Errorf("tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, opts Options) (*otlptrace.Exporter, error) {
+	endpoint := strings.TrimSpace(opts.Endpoint)
+	isURL := strings.Contains(endpoint, "://")
+
+	switch strings.ToLower(strings.TrimSpace(opts.Protocol)) {
+	case "", ProtocolHTTP, "http":
+		var options []otlptracehttp.Option
+		switch {
+		case isURL:
+			options = append(options, otlptracehttp.WithEndpointURL(endpoint))
+		case endpoint != "":
+			options = append(options, otlptracehttp.WithEndpoint(endpoint))
+		}
+		if opts.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		if len(opts.Headers) > 0 {
+			options = append(options, otlptracehttp.WithHeaders(opts.Headers))
+		}
+		return otlptracehttp.New(ctx, options...)
+	case ProtocolGRPC:
+		var options []otlptracegrpc.Option
+		switch {
+		case isURL:
+			options = append(options, otlptracegrpc.WithEndpointURL(endpoint))
+		case endpoint != "":
+			options = append(options, otlptracegrpc.WithEndpoint(endpoint))
+		}
+		if opts.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		if len(opts.Headers) > 0 {
+			options = append(options, otlptracegrpc.WithHeaders(opts.Headers))
+		}
+		return otlptracegrpc.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("unknown tracing protocol %q (use %s or %s)", opts.Protocol, ProtocolHTTP, ProtocolGRPC)
+	}
+}
+
+// Start starts a span named name as a child of any span in ctx.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if any, and ends it. Context cancellation is not
+// marked as a span error, since the caller chose to stop.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if !errors.Is(err, context.Canceled) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+// Extract returns ctx joined to the trace that carrier's headers, such as a
+// W3C traceparent, continue.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// TraceID returns the ID of the trace ctx belongs to, or "" when ctx is not
+// part of a trace.
+func TraceID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
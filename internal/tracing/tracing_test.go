@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetupRejectsInvalidOptions(t *testing.T) {
+	_, err := Setup(context.Background(), Options{Protocol: "udp", SampleRatio: 1})
+	require.ErrorContains(t, err, `unknown tracing protocol "udp"`)
+
+	_, err = Setup(context.Background(), Options{SampleRatio: 1.5})
+	require.ErrorContains(t, err, "between 0 and 1")
+}
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	original := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(original)
+	})
+
+	require.Empty(t, TraceID(context.Background()))
+
+	ctx, span := Start(context.Background(), "work")
+	require.Len(t, TraceID(ctx), 32)
+	End(span, errors.New("boom"))
+
+	_, span = Start(ctx, "cancelled")
+	End(span, context.Canceled)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	require.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func TestExtractContinuesCallerTrace(t *testing.T) {
+	original := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTextMapPropagator(original)
+	})
+
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := Extract(context.Background(), propagation.HeaderCarrier(header))
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", TraceID(ctx))
+}
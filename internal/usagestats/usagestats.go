@@ -0,0 +1,285 @@
+// Package usagestats keeps the opt-in anonymous usage queue: which commands
+// ran, with which flags, output format, and checker kinds, how many
+// arguments, how long they took, and whether they succeeded. Names, flag
+// values other than the output format, paths, and errors are never recorded.
+//
+// Consent and the queue live in one directory. Nothing is recorded until
+// SetEnabled(true), and DO_NOT_TRACK overrides consent.
+package usagestats
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxQueued bounds the local queue; the oldest events are dropped first.
+const MaxQueued = 500
+
+const (
+	stateFile = "state.json"
+	queueFile = "queue.ndjson"
+)
+
+// Event is one command run.
+type Event struct {
+	// Date is the UTC day the command ran, without a time of day
+	Date    string `json:"date"`
+	Command string `json:"command"`
+	// Flags are the names of the flags that were set, without their values
+	Flags        []string `json:"flags,omitempty"`
+	OutputFormat string   `json:"output_format,omitempty"`
+	// Checkers are the checker kinds requested; custom checkers are
+	// reported as "custom"
+	Checkers   []string `json:"checkers,omitempty"`
+	Args       int      `json:"args"`
+	DurationMs int64    `json:"duration_ms"`
+	Success    bool     `json:"success"`
+	Version    string   `json:"version,omitempty"`
+	OS         string   `json:"os"`
+	Arch       string   `json:"arch"`
+}
+
+// State is the recorded consent.
+type State struct {
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	LastFlush time.Time `json:"last_flush,omitempty"`
+}
+
+// Store is the consent file and event queue in one directory.
+type Store struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewStore returns the store kept in dir.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the store directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// DoNotTrack reports whether the DO_NOT_TRACK environment variable turns
+// recording off regardless of consent.
+func DoNotTrack() bool {
+	value := strings.TrimSpace(os.Getenv("DO_NOT_TRACK"))
+	return value != "" && value != "0" && !strings.EqualFold(value, "false")
+}
+
+// State returns the recorded consent; a missing file means disabled.
+func (s *Store) State() (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readState()
+}
+
+// Enabled reports whether events are recorded: consent was given and
+// DO_NOT_TRACK is not set.
+func (s *Store) Enabled() bool {
+	if DoNotTrack() {
+		return false
+	}
+	state, err := s.State()
+	return err == nil && state.Enabled
+}
+
+// SetEnabled records consent. Turning recording off also deletes queued
+// events.
+func (s *Store) SetEnabled(enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, err := s.readState()
+	if err != nil {
+		return err
+	}
+	state.Enabled = enabled
+	state.UpdatedAt = time.Now().UTC()
+	if err := s.writeState(state); err != nil {
+		return err
+	}
+	if !enabled {
+		if err := os.Remove(filepath.Join(s.dir, queueFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("delete usage queue: %w", err)
+		}
+	}
+	return nil
+}
+
+// Record appends event to the queue when recording is enabled.
+func (s *Store) Record(event Event) error {
+	if !s.Enabled() {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, err := s.readQueue()
+	if err != nil {
+		return err
+	}
+	events = append(events, event)
+	if len(events) > MaxQueued {
+		events = events[len(events)-MaxQueued:]
+	}
+	return s.writeQueue(events)
+}
+
+// Pending returns the queued events, oldest first.
+func (s *Store) Pending() ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readQueue()
+}
+
+// Flush posts the queued events to endpoint as {"events": [...]} and clears
+// the queue on a 2xx response. It returns the number of events sent.
+func (s *Store) Flush(ctx context.Context, client *http.Client, endpoint string) (int, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return 0, errors.New("no telemetry endpoint configured")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events, err := s.readQueue()
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	payload, err := json.Marshal(map[string]any{"events": events})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("build telemetry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("send usage events: %w", err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("send usage events: unexpected status %d", resp.StatusCode)
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, queueFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return len(events), fmt.Errorf("clear usage queue: %w", err)
+	}
+	state, err := s.readState()
+	if err != nil {
+		return len(events), err
+	}
+	state.LastFlush = time.Now().UTC()
+	return len(events), s.writeState(state)
+}
+
+// FlushDue reports whether the last flush is older than interval. A zero
+// interval never flushes automatically.
+func (st State) FlushDue(interval time.Duration, now time.Time) bool {
+	return interval > 0 && now.Sub(st.LastFlush) >= interval
+}
+
+func (s *Store) readState() (State, error) {
+	var state State
+	data, err := os.ReadFile(filepath.Join(s.dir, stateFile)) // #nosec G304 -- app data directory
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("read telemetry state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("parse telemetry state: %w", err)
+	}
+	return state, nil
+}
+
+func (s *Store) writeState(state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.writeFile(stateFile, append(data, '\n'))
+}
+
+// readQueue skips lines it cannot parse, such as a line cut short by a
+// crash.
+func (s *Store) readQueue() ([]Event, error) {
+	f, err := os.Open(filepath.Join(s.dir, queueFile)) // #nosec G304 -- app data directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read usage queue: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err == nil {
+			events = append(events, event)
+		}
+	}
+	return events, scanner.Err()
+}
+
+func (s *Store) writeQueue(events []Event) error {
+	var buf bytes.Buffer
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return s.writeFile(queueFile, buf.Bytes())
+}
+
+// writeFile replaces name atomically so a concurrent run never reads a
+// partial file.
+func (s *Store) writeFile(name string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("create telemetry directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, name+".*")
+	if err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(s.dir, name)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package usagestats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordRequiresConsent(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	store := NewStore(t.TempDir())
+
+	require.False(t, store.Enabled())
+	require.NoError(t, store.Record(Event{Command: "check"}))
+	events, err := store.Pending()
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	require.NoError(t, store.SetEnabled(true))
+	require.NoError(t, store.Record(Event{Command: "check", Args: 2}))
+	events, err = store.Pending()
+	require.NoError(t, err)
+	require.Equal(t, []Event{{Command: "check", Args: 2}}, events)
+
+	// Opting out deletes what was queued.
+	require.NoError(t, store.SetEnabled(false))
+	events, err = store.Pending()
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestDoNotTrackOverridesConsent(t *testing.T) {
+	store := NewStore(t.TempDir())
+	require.NoError(t, store.SetEnabled(true))
+
+	t.Setenv("DO_NOT_TRACK", "1")
+	require.False(t, store.Enabled())
+	require.NoError(t, store.Record(Event{Command: "check"}))
+	events, err := store.Pending()
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	t.Setenv("DO_NOT_TRACK", "0")
+	require.True(t, store.Enabled())
+}
+
+func TestRecordDropsOldestPastLimit(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	store := NewStore(t.TempDir())
+	require.NoError(t, store.SetEnabled(true))
+	for i := 0; i < MaxQueued+2; i++ {
+		require.NoError(t, store.Record(Event{Command: "check", Args: i}))
+	}
+	events, err := store.Pending()
+	require.NoError(t, err)
+	require.Len(t, events, MaxQueued)
+	require.Equal(t, 2, events[0].Args)
+}
+
+func TestFlush(t *testing.T) {
+	t.Setenv("DO_NOT_TRACK", "")
+	var received struct {
+		Events []Event `json:"events"`
+	}
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	store := NewStore(t.TempDir())
+	require.NoError(t, store.SetEnabled(true))
+	require.NoError(t, store.Record(Event{Command: "check", OutputFormat: "json"}))
+
+	_, err := store.Flush(context.Background(), server.Client(), "")
+	require.ErrorContains(t, err, "no telemetry endpoint")
+
+	// A failed flush keeps the queue.
+	_, err = store.Flush(context.Background(), server.Client(), server.URL)
+	require.ErrorContains(t, err, "503")
+	events, err := store.Pending()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	status = http.StatusAccepted
+	sent, err := store.Flush(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+	require.Equal(t, []Event{{Command: "check", OutputFormat: "json"}}, received.Events)
+
+	events, err = store.Pending()
+	require.NoError(t, err)
+	require.Empty(t, events)
+	state, err := store.State()
+	require.NoError(t, err)
+	require.False(t, state.LastFlush.IsZero())
+	require.False(t, state.FlushDue(24*time.Hour, time.Now()))
+	require.True(t, state.FlushDue(24*time.Hour, time.Now().Add(25*time.Hour)))
+	require.False(t, state.FlushDue(0, time.Now().Add(25*time.Hour)))
+}
@@ -0,0 +1,287 @@
+// Package wordscan finds unfortunate words hidden in candidate names, such
+// as "anal" in "analytics" or the German "mist" (manure) in "mistify". It
+// checks names offline against bundled word lists for several languages, so
+// it works without an AI provider.
+package wordscan
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//go:embed words/*.txt
+var wordsFS embed.FS
+
+// Severities, lowest first.
+const (
+	SeverityLow    = "low"
+	SeverityMedium = "medium"
+	SeverityHigh   = "high"
+)
+
+// Sensitivities choose the least severity reported. They use the names of the
+// suitability analysis's sensitivity levels.
+const (
+	// SensitivityMinimal reports high severity words only.
+	SensitivityMinimal = "minimal"
+	// SensitivityStandard reports medium and high severity words.
+	SensitivityStandard = "standard"
+	// SensitivityStrict reports every word.
+	SensitivityStrict = "strict"
+)
+
+// minSubstringLength is the shortest word matched inside a name part; shorter
+// words, like "ass" or "con", only match a whole part.
+const minSubstringLength = 4
+
+var severityRank = map[string]int{SeverityLow: 1, SeverityMedium: 2, SeverityHigh: 3}
+
+var sensitivityRank = map[string]int{SensitivityMinimal: 3, SensitivityStandard: 2, SensitivityStrict: 1}
+
+// languageNames are the display names of the bundled word lists.
+var languageNames = map[string]string{
+	"de": "German",
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"it": "Italian",
+	"pt": "Portuguese",
+}
+
+// Finding is an unfortunate word found in a name.
+type Finding struct {
+	Word string `json:"word"`
+	// Languages lists the languages the word is unfortunate in.
+	Languages []string `json:"languages"`
+	Meaning   string   `json:"meaning"`
+	// Severity is the word's severity, lowered to low when the word is
+	// buried inside a name part rather than starting or ending one.
+	Severity string `json:"severity"`
+	// Start is the word's byte offset in the lowercased name.
+	Start int `json:"start"`
+	// Boundary reports whether the word starts or ends a name part, where
+	// readers are most likely to notice it.
+	Boundary bool `json:"boundary"`
+}
+
+type entry struct {
+	word     string
+	language string
+	severity string
+	meaning  string
+}
+
+// Scanner checks names against word lists.
+type Scanner struct {
+	entries []entry
+}
+
+// Languages returns the codes of the bundled word lists, sorted.
+func Languages() []string {
+	codes := make([]string, 0, len(languageNames))
+	for code := range languageNames {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// LanguageName returns the display name of a word list's language code.
+func LanguageName(code string) string {
+	if name, ok := languageNames[code]; ok {
+		return name
+	}
+	return code
+}
+
+// ValidSensitivity reports whether s is a sensitivity level.
+func ValidSensitivity(s string) bool {
+	_, ok := sensitivityRank[s]
+	return ok
+}
+
+// NewScanner loads the word lists for languages, given as codes like "de" or
+// locales like "de-DE". No languages loads every list.
+func NewScanner(languages []string) (*Scanner, error) {
+	codes := Languages()
+	if len(languages) > 0 {
+		seen := map[string]bool{}
+		codes = codes[:0]
+		for _, language := range languages {
+			code := strings.ToLower(strings.TrimSpace(language))
+			code, _, _ = strings.Cut(code, "-")
+			code, _, _ = strings.Cut(code, "_")
+			if code == "" || seen[code] {
+				continue
+			}
+			if _, ok := languageNames[code]; !ok {
+				return nil, fmt.Errorf("no word list for language %q (available: %s)", language, strings.Join(Languages(), ", "))
+			}
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+
+	scanner := &Scanner{}
+	for _, code := range codes {
+		entries, err := loadWords(code)
+		if err != nil {
+			return nil, err
+		}
+		scanner.entries = append(scanner.entries, entries...)
+	}
+	return scanner, nil
+}
+
+func loadWords(code string) ([]entry, error) {
+	data, err := wordsFS.ReadFile(path.Join("words", code+".txt"))
+	if err != nil {
+		return nil, fmt.Errorf("read %s word list: %w", code, err)
+	}
+	var entries []entry
+	lines := bufio.NewScanner(strings.NewReader(string(data)))
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 3 || severityRank[fields[1]] == 0 {
+			return nil, fmt.Errorf("%s word list: malformed line %q", code, line)
+		}
+		entries = append(entries, entry{word: fields[0], language: code, severity: fields[1], meaning: fields[2]})
+	}
+	return entries, nil
+}
+
+// Scan returns the unfortunate words in name at or above the severity that
+// sensitivity reports, most severe first. An empty sensitivity is standard.
+func (s *Scanner) Scan(name, sensitivity string) []Finding {
+	if s == nil {
+		return nil
+	}
+	minRank := sensitivityRank[sensitivity]
+	if minRank == 0 {
+		minRank = sensitivityRank[SensitivityStandard]
+	}
+	lower, parts := splitParts(name)
+
+	byWord := map[string]int{}
+	var findings []Finding
+	for _, e := range s.entries {
+		start, boundary, ok := locate(lower, parts, e.word)
+		if !ok {
+			continue
+		}
+		severity := e.severity
+		if !boundary {
+			severity = SeverityLow
+		}
+		if i, ok := byWord[e.word]; ok {
+			// The same word in another language's list
+			findings[i].Languages = append(findings[i].Languages, e.language)
+			if severityRank[severity] > severityRank[findings[i].Severity] {
+				findings[i].Severity = severity
+				findings[i].Meaning = e.meaning
+			}
+			continue
+		}
+		byWord[e.word] = len(findings)
+		findings = append(findings, Finding{
+			Word:      e.word,
+			Languages: []string{e.language},
+			Meaning:   e.meaning,
+			Severity:  severity,
+			Start:     start,
+			Boundary:  boundary,
+		})
+	}
+
+	reported := findings[:0]
+	for _, finding := range findings {
+		if severityRank[finding.Severity] >= minRank {
+			reported = append(reported, finding)
+		}
+	}
+	sort.SliceStable(reported, func(i, j int) bool {
+		if severityRank[reported[i].Severity] != severityRank[reported[j].Severity] {
+			return severityRank[reported[i].Severity] > severityRank[reported[j].Severity]
+		}
+		return reported[i].Start < reported[j].Start
+	})
+	if len(reported) == 0 {
+		return nil
+	}
+	return reported
+}
+
+// part is a word within a name, as byte offsets into the lowercased name.
+type part struct {
+	start, end int
+}
+
+// splitParts lowercases name and splits it into parts at separators, digits,
+// and camelCase humps, so "getMist-app" has the parts get, mist, and app.
+func splitParts(name string) (string, []part) {
+	runes := []rune(name)
+	var (
+		lower strings.Builder
+		parts []part
+		start = -1
+	)
+	for i, r := range runes {
+		letter := unicode.IsLetter(r) && r < unicode.MaxASCII
+		hump := letter && i > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1])
+		if start >= 0 && (!letter || hump) {
+			parts = append(parts, part{start: start, end: lower.Len()})
+			start = -1
+		}
+		if letter && start < 0 {
+			start = lower.Len()
+		}
+		lower.WriteRune(unicode.ToLower(r))
+	}
+	if start >= 0 {
+		parts = append(parts, part{start: start, end: lower.Len()})
+	}
+	return lower.String(), parts
+}
+
+// locate finds word in a part of name. Short words must be a whole part;
+// longer ones may sit anywhere in a part and are at a boundary when they
+// start or end it. A boundary match is preferred to one inside a part.
+func locate(lower string, parts []part, word string) (start int, boundary bool, ok bool) {
+	found := -1
+	for _, p := range parts {
+		text := lower[p.start:p.end]
+		if len(word) < minSubstringLength {
+			if text == word {
+				return p.start, true, true
+			}
+			continue
+		}
+		for offset := 0; ; {
+			idx := strings.Index(text[offset:], word)
+			if idx < 0 {
+				break
+			}
+			at := offset + idx
+			if at == 0 || at+len(word) == len(text) {
+				return p.start + at, true, true
+			}
+			if found < 0 {
+				found = p.start + at
+			}
+			offset = at + 1
+		}
+	}
+	if found >= 0 {
+		return found, false, true
+	}
+	return 0, false, false
+}
@@ -0,0 +1,76 @@
+package wordscan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanFindsWordsAtBoundaries(t *testing.T) {
+	scanner, err := NewScanner(nil)
+	require.NoError(t, err)
+
+	findings := scanner.Scan("Analytics", SensitivityStandard)
+	require.Len(t, findings, 1)
+	require.Equal(t, Finding{Word: "anal", Languages: []string{"en"}, Meaning: "sexual term", Severity: SeverityHigh, Start: 0, Boundary: true}, findings[0])
+
+	// camelCase humps and separators start new parts
+	findings = scanner.Scan("SilverMist", SensitivityStandard)
+	require.Len(t, findings, 1)
+	require.Equal(t, "mist", findings[0].Word)
+	require.Equal(t, []string{"de"}, findings[0].Languages)
+	require.Equal(t, 6, findings[0].Start)
+
+	// The same word in several lists is one finding
+	findings = scanner.Scan("puta", SensitivityStandard)
+	require.Len(t, findings, 1)
+	require.Equal(t, []string{"es", "pt"}, findings[0].Languages)
+}
+
+func TestScanSensitivity(t *testing.T) {
+	scanner, err := NewScanner(nil)
+	require.NoError(t, err)
+
+	// Buried inside a part, a word is low severity
+	require.Empty(t, scanner.Scan("canalside", SensitivityStandard))
+	findings := scanner.Scan("canalside", SensitivityStrict)
+	require.Len(t, findings, 1)
+	require.Equal(t, SeverityLow, findings[0].Severity)
+	require.False(t, findings[0].Boundary)
+
+	require.Len(t, scanner.Scan("giftly", ""), 1)
+	require.Empty(t, scanner.Scan("giftly", SensitivityMinimal))
+}
+
+func TestScanShortWordsMatchWholeParts(t *testing.T) {
+	scanner, err := NewScanner(nil)
+	require.NoError(t, err)
+
+	require.Empty(t, scanner.Scan("classic", SensitivityStrict))
+	require.Empty(t, scanner.Scan("assemble", SensitivityStrict))
+	findings := scanner.Scan("big-ass", SensitivityStrict)
+	require.Len(t, findings, 1)
+	require.Equal(t, "ass", findings[0].Word)
+	require.Equal(t, 4, findings[0].Start)
+}
+
+func TestNewScannerLanguages(t *testing.T) {
+	scanner, err := NewScanner([]string{"en-US", "en"})
+	require.NoError(t, err)
+	require.Empty(t, scanner.Scan("giftly", SensitivityStrict))
+	require.NotEmpty(t, scanner.Scan("analytics", SensitivityStrict))
+
+	_, err = NewScanner([]string{"xx"})
+	require.ErrorContains(t, err, `no word list for language "xx"`)
+	require.Equal(t, "German", LanguageName("de"))
+	require.True(t, ValidSensitivity("strict"))
+	require.False(t, ValidSensitivity("lax"))
+}
+
+func TestWordListsParse(t *testing.T) {
+	for _, code := range Languages() {
+		entries, err := loadWords(code)
+		require.NoError(t, err, code)
+		require.NotEmpty(t, entries, code)
+	}
+}